@@ -0,0 +1,3 @@
+ALTER TABLE chats DROP COLUMN IF EXISTS assistant_id;
+DROP TABLE IF EXISTS assistant_documents;
+DROP TABLE IF EXISTS assistants;
\ No newline at end of file
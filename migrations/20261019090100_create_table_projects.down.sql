@@ -0,0 +1,3 @@
+ALTER TABLE chats DROP COLUMN IF EXISTS project_id;
+DROP TABLE IF EXISTS project_files;
+DROP TABLE IF EXISTS projects;
\ No newline at end of file
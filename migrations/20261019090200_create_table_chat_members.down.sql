@@ -0,0 +1 @@
+DROP TABLE IF EXISTS chat_members;
\ No newline at end of file
@@ -0,0 +1,32 @@
+CREATE TABLE scheduled_prompts (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    chat_id BIGINT DEFAULT NULL REFERENCES chats(id) ON DELETE SET NULL,
+    name VARCHAR(255) NOT NULL,
+    prompt TEXT NOT NULL,
+    cron_expression VARCHAR(255) NOT NULL,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    catch_up_policy VARCHAR(16) NOT NULL DEFAULT 'skip' CHECK (catch_up_policy IN ('skip', 'run_once')),
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    next_run_at TIMESTAMP DEFAULT NULL,
+    last_run_at TIMESTAMP DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'utc'),
+    updated_at TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'utc')
+);
+
+CREATE INDEX scheduled_prompts_enabled_next_run_at_index ON scheduled_prompts (enabled, next_run_at);
+
+CREATE TABLE scheduled_prompt_runs (
+    id BIGSERIAL PRIMARY KEY,
+    scheduled_prompt_id BIGINT NOT NULL REFERENCES scheduled_prompts(id) ON DELETE CASCADE,
+    chat_id BIGINT DEFAULT NULL REFERENCES chats(id) ON DELETE SET NULL,
+    status VARCHAR(16) NOT NULL CHECK (status IN ('pending', 'running', 'succeeded', 'failed', 'skipped')),
+    scheduled_for TIMESTAMP NOT NULL,
+    started_at TIMESTAMP DEFAULT NULL,
+    finished_at TIMESTAMP DEFAULT NULL,
+    error TEXT DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'utc'),
+    updated_at TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'utc')
+);
+
+CREATE INDEX scheduled_prompt_runs_scheduled_prompt_id_index ON scheduled_prompt_runs (scheduled_prompt_id);
\ No newline at end of file
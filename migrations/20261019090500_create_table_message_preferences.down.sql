@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS message_preferences;
+
+DROP INDEX IF EXISTS messages_compare_group_index;
+
+ALTER TABLE messages
+    DROP COLUMN IF EXISTS active,
+    DROP COLUMN IF EXISTS compare_group,
+    DROP COLUMN IF EXISTS model;
\ No newline at end of file
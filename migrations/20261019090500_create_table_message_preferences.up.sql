@@ -0,0 +1,18 @@
+ALTER TABLE messages
+    ADD COLUMN model VARCHAR(255) DEFAULT NULL,
+    ADD COLUMN compare_group VARCHAR(64) DEFAULT NULL,
+    ADD COLUMN active BOOLEAN NOT NULL DEFAULT TRUE;
+
+CREATE INDEX messages_compare_group_index ON messages (compare_group);
+
+CREATE TABLE message_preferences (
+    id BIGSERIAL PRIMARY KEY,
+    chat_id BIGINT NOT NULL REFERENCES chats(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    compare_group VARCHAR(64) NOT NULL,
+    message_id BIGINT NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    model VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'utc'),
+    updated_at TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'utc'),
+    UNIQUE (compare_group, user_id)
+);
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE signing_keys (
+    id BIGSERIAL PRIMARY KEY,
+    kid VARCHAR(64) UNIQUE NOT NULL,
+    algorithm VARCHAR(16) NOT NULL CHECK (algorithm IN ('RS256', 'EdDSA')),
+    private_key TEXT NOT NULL,
+    retired_at TIMESTAMP DEFAULT NULL,
+    expires_at TIMESTAMP DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'utc'),
+    updated_at TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'utc')
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS totp_credentials;
\ No newline at end of file
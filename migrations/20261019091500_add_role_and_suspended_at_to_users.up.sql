@@ -0,0 +1,4 @@
+-- Promote the first administrator by hand: UPDATE users SET role = 'admin' WHERE email = '...';
+ALTER TABLE users ADD COLUMN role VARCHAR(32) NOT NULL DEFAULT 'user';
+ALTER TABLE users ADD COLUMN suspended_at TIMESTAMP DEFAULT NULL;
+CREATE INDEX idx_users_role ON users (role);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS sessions_revoked_at_index;
\ No newline at end of file
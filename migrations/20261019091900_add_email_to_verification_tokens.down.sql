@@ -0,0 +1,4 @@
+DELETE FROM verification_tokens WHERE purpose = 'change_email';
+ALTER TABLE verification_tokens DROP CONSTRAINT IF EXISTS verification_tokens_purpose_check;
+ALTER TABLE verification_tokens ADD CONSTRAINT verification_tokens_purpose_check CHECK (purpose IN ('verify_email', 'reset_password'));
+ALTER TABLE verification_tokens DROP COLUMN IF EXISTS email;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS user_preferences;
\ No newline at end of file
@@ -0,0 +1,5 @@
+DELETE FROM verification_tokens WHERE purpose = 'cancel_deletion';
+ALTER TABLE verification_tokens DROP CONSTRAINT IF EXISTS verification_tokens_purpose_check;
+ALTER TABLE verification_tokens ADD CONSTRAINT verification_tokens_purpose_check CHECK (purpose IN ('verify_email', 'reset_password', 'change_email'));
+DROP TABLE IF EXISTS account_deletion_steps;
+DROP TABLE IF EXISTS account_deletions;
\ No newline at end of file
@@ -0,0 +1,18 @@
+CREATE TABLE account_exports (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'ready', 'failed', 'expired')),
+    -- The zip archive, removed once it expires
+    archive BYTEA DEFAULT NULL,
+    size BIGINT NOT NULL DEFAULT 0,
+    error TEXT DEFAULT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    started_at TIMESTAMP DEFAULT NULL,
+    completed_at TIMESTAMP DEFAULT NULL,
+    expires_at TIMESTAMP DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'utc'),
+    updated_at TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'utc')
+);
+
+CREATE INDEX account_exports_user_id_index ON account_exports (user_id, id);
+CREATE INDEX account_exports_status_index ON account_exports (status, id);
\ No newline at end of file
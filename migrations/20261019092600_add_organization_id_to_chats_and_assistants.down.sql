@@ -0,0 +1,2 @@
+ALTER TABLE assistants DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE chats DROP COLUMN IF EXISTS organization_id;
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE chats ADD COLUMN organization_id BIGINT DEFAULT NULL REFERENCES organizations(id) ON DELETE CASCADE;
+ALTER TABLE assistants ADD COLUMN organization_id BIGINT DEFAULT NULL REFERENCES organizations(id) ON DELETE CASCADE;
+
+CREATE INDEX chats_organization_id_index ON chats (organization_id);
+CREATE INDEX assistants_organization_id_index ON assistants (organization_id);
\ No newline at end of file
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"api-gateway/app/model/proto"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Audit log actions recorded by the gateway, the auth service records its own
+const (
+	AuditActionChatDelete      = "chat.delete"
+	AuditActionChatMemberInvite= "chat.member.invite"
+	AuditActionChatMemberUpdate= "chat.member.update"
+	AuditActionChatMemberRemove= "chat.member.remove"
+	AuditActionAssistantShare  = "assistant.share"
+)
+
+// Audit log targets recorded by the gateway
+const (
+	AuditTargetChat      = "chat"
+	AuditTargetAssistant = "assistant"
+)
+
+type clientInfoKey struct{}
+
+// WithClientInfo keeps the device of the request for the audit events recorded with the context
+func WithClientInfo(ctx context.Context, client *proto.ClientInfo) context.Context {
+	return context.WithValue(ctx, clientInfoKey{}, client)
+}
+
+func clientInfoFromContext(ctx context.Context) *proto.ClientInfo {
+	client, _ := ctx.Value(clientInfoKey{}).(*proto.ClientInfo)
+	return client
+}
+
+// AuditRecorder appends the events of the chat service to the audit log of the auth service
+type AuditRecorder struct {
+	Auth *AuthClient
+	Log  *logrus.Logger
+}
+
+func NewAuditRecorder(auth *AuthClient, log *logrus.Logger) *AuditRecorder {
+	return &AuditRecorder{
+		Auth: auth,
+		Log:  log,
+	}
+}
+
+// Record appends the event for the user and device of the context. The action
+// already happened, a failure is logged and does not fail the request.
+func (r *AuditRecorder) Record(ctx context.Context, action string, targetType string, targetID string, diff map[string]any) {
+	encoded := []byte("{}")
+	if diff != nil {
+		var err error
+		if encoded, err = json.Marshal(diff); err != nil {
+			r.Log.Errorf("Failed to encode audit diff: %v", err)
+			return
+		}
+	}
+
+	recordCtx, cancelRecord := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelRecord()
+
+	if _, err := r.Auth.Service.RecordAuditEvent(recordCtx, &proto.RecordAuditEventRequest{
+		Action:     action,
+		ActorId:    userIDFromContext(ctx),
+		TargetType: targetType,
+		TargetId:   targetID,
+		Diff:       string(encoded),
+		Client:     clientInfoFromContext(ctx),
+	}); err != nil {
+		r.Log.Errorf("Failed to record audit event %s: %v", action, err)
+	}
+}
\ No newline at end of file
@@ -1,9 +1,8 @@
 package client
 
 import (
+	"api-gateway/app/helper"
 	"api-gateway/app/model/proto"
-	"crypto/tls"
-	"crypto/x509"
 	"fmt"
 
 	"github.com/spf13/viper"
@@ -30,16 +29,16 @@ func NewChatClient(config *viper.Viper) *ChatClient {
 	if !secure {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	} else {
-		systemRoots, err := x509.SystemCertPool()
+		// Presents the certificate of the service when the server requires mutual TLS
+		tlsConfig, err := helper.ClientTLSConfig(config)
 		if err != nil {
-			panic(fmt.Errorf("error loading system root CA pool: %v", err))
+			panic(err)
 		}
-		cred := credentials.NewTLS(&tls.Config{
-			RootCAs: systemRoots,
-		})
-		opts = append(opts, grpc.WithTransportCredentials(cred))
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
 	}
 
+	opts = append(opts, internalIdentityOptions(config)...)
+
 	chatConn, err := grpc.NewClient(chatHost, opts...)
 	if err != nil {
 		panic(fmt.Errorf("error connecting to chat service: %v", err))
@@ -0,0 +1,465 @@
+package client
+
+import (
+	"api-gateway/app/model/proto"
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+const (
+	// accessTokenTTL matches the auth service, revocations older than it concern expired tokens only
+	accessTokenTTL = 2 * time.Hour
+	// revocationOverlap refetches recent revocations, a session revoked in a slow
+	// transaction becomes visible with a revokedAt older than the last poll
+	revocationOverlap  = time.Minute
+	revocationPageSize = 500
+	// keyRefreshCooldown limits refetching the JWKS when tokens name an unknown kid
+	keyRefreshCooldown = 30 * time.Second
+
+	VerificationLocal  = "local"
+	VerificationRemote = "remote"
+)
+
+// errKeyUnavailable means the token cannot be verified here, the auth service decides
+var errKeyUnavailable = errors.New("verification key unavailable")
+
+// IdentityVerifier verifies access tokens in the gateway with the JWKS of the auth
+// service, so requests and socket events do not wait on a GetProfile call. Verified
+// identities are cached shortly and dropped as soon as the auth service lists their
+// session as revoked. Tokens signed by a key the gateway does not have, and every
+// token when AUTH_TOKEN_VERIFICATION is remote, are still verified by GetProfile.
+//
+// While the auth service is unreachable tokens keep being verified with the keys
+// already fetched, revocations can only be made by the auth service anyway and
+// apply as soon as it answers again.
+type IdentityVerifier struct {
+	Log  *logrus.Logger
+	Auth *AuthClient
+
+	local        bool
+	issuer       string
+	audience     string
+	legacySecret []byte
+	cacheTTL     time.Duration
+
+	mu            sync.RWMutex
+	keys          map[string]verificationKey
+	keysFetchedAt time.Time
+	identities    map[string]*cachedIdentity
+	revoked       map[int64]time.Time
+	revokedSince  time.Time
+}
+
+type verificationKey struct {
+	Algorithm string
+	PublicKey crypto.PublicKey
+}
+
+type cachedIdentity struct {
+	Profile   *proto.GetProfileResponse
+	ExpiresAt time.Time
+}
+
+func NewIdentityVerifier(config *viper.Viper, log *logrus.Logger, auth *AuthClient) *IdentityVerifier {
+	cacheTTL := config.GetDuration("AUTH_IDENTITY_CACHE_TTL")
+	if cacheTTL <= 0 {
+		cacheTTL = 30 * time.Second
+	}
+
+	jwksRefreshInterval := config.GetDuration("AUTH_JWKS_REFRESH_INTERVAL")
+	if jwksRefreshInterval <= 0 {
+		jwksRefreshInterval = 5 * time.Minute
+	}
+
+	revocationPollInterval := config.GetDuration("AUTH_REVOCATION_POLL_INTERVAL")
+	if revocationPollInterval <= 0 {
+		revocationPollInterval = 5 * time.Second
+	}
+
+	v := &IdentityVerifier{
+		Log:          log,
+		Auth:         auth,
+		local:        config.GetString("AUTH_TOKEN_VERIFICATION") != VerificationRemote,
+		issuer:       config.GetString("AUTH_TOKEN_ISSUER"),
+		audience:     config.GetString("AUTH_TOKEN_AUDIENCE"),
+		legacySecret: []byte(config.GetString("JWT_ACCESS_KEY")),
+		cacheTTL:     cacheTTL,
+		keys:         make(map[string]verificationKey),
+		identities:   make(map[string]*cachedIdentity),
+		revoked:      make(map[int64]time.Time),
+		revokedSince: time.Now().Add(-accessTokenTTL),
+	}
+
+	go v.watch(jwksRefreshInterval, revocationPollInterval)
+
+	return v
+}
+
+// Verify returns the identity of the access token, like GetProfile does
+func (v *IdentityVerifier) Verify(ctx context.Context, accessToken string) (*proto.GetProfileResponse, error) {
+	if accessToken == "" {
+		return nil, jwt.ErrTokenMalformed
+	}
+
+	sum := sha256.Sum256([]byte(accessToken))
+	cacheKey := hex.EncodeToString(sum[:])
+
+	v.mu.RLock()
+	cached, ok := v.identities[cacheKey]
+	v.mu.RUnlock()
+	if ok && time.Now().Before(cached.ExpiresAt) && !v.isRevoked(cached.Profile.GetSessionId()) {
+		return cached.Profile, nil
+	}
+
+	if v.local {
+		profile, expiresAt, err := v.verifyLocally(ctx, accessToken)
+		if err == nil {
+			if v.isRevoked(profile.GetSessionId()) {
+				return nil, errors.New("session has been revoked")
+			}
+
+			v.remember(cacheKey, profile, expiresAt)
+			return profile, nil
+		}
+		if !errors.Is(err, errKeyUnavailable) {
+			return nil, err
+		}
+	}
+
+	getProfileCtx, cancelGetProfile := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetProfile()
+
+	profile, err := v.Auth.Service.GetProfile(getProfileCtx, &proto.GetProfileRequest{
+		AccessToken: accessToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	v.remember(cacheKey, profile, time.Now().Add(v.cacheTTL))
+	return profile, nil
+}
+
+// verifyLocally checks the signature and claims the way the auth service does.
+// HS256 tokens issued before key rotation are verified while JWT_ACCESS_KEY is set.
+func (v *IdentityVerifier) verifyLocally(ctx context.Context, accessToken string) (*proto.GetProfileResponse, time.Time, error) {
+	legacy := false
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"RS256", "EdDSA", jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	}
+	if v.issuer != "" {
+		options = append(options, jwt.WithIssuer(v.issuer))
+	}
+	if v.audience != "" {
+		options = append(options, jwt.WithAudience(v.audience))
+	}
+
+	token, err := jwt.Parse(accessToken, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+			if len(v.legacySecret) == 0 {
+				return nil, errKeyUnavailable
+			}
+			legacy = true
+			return v.legacySecret, nil
+		}
+
+		kid, _ := token.Header["kid"].(string)
+		key, err := v.key(ctx, kid)
+		if err != nil {
+			return nil, err
+		}
+		if key.Algorithm != token.Method.Alg() {
+			return nil, jwt.ErrTokenSignatureInvalid
+		}
+		return key.PublicKey, nil
+	}, options...)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, time.Time{}, jwt.ErrTokenInvalidClaims
+	}
+
+	// Refresh and challenge tokens share the signing keys
+	if !legacy && claims["token_use"] != "access" {
+		return nil, time.Time{}, jwt.ErrTokenInvalidClaims
+	}
+
+	sub, ok := claims["sub"].(float64)
+	if !ok {
+		return nil, time.Time{}, jwt.ErrTokenInvalidSubject
+	}
+	sid, _ := claims["sid"].(float64)
+	org, _ := claims["org"].(float64)
+
+	profile := &proto.GetProfileResponse{
+		Id:             int64(sub),
+		SessionId:      int64(sid),
+		OrganizationId: int64(org),
+		Permissions:    []string{},
+	}
+	profile.Name, _ = claims["name"].(string)
+	profile.Email, _ = claims["email"].(string)
+	profile.ImageUrl, _ = claims["imageURL"].(string)
+	profile.Role, _ = claims["role"].(string)
+	profile.OrganizationRole, _ = claims["org_role"].(string)
+
+	if values, ok := claims["permissions"].([]interface{}); ok {
+		for _, value := range values {
+			if permission, ok := value.(string); ok {
+				profile.Permissions = append(profile.Permissions, permission)
+			}
+		}
+	}
+
+	expiresAt, err := claims.GetExpirationTime()
+	if err != nil || expiresAt == nil {
+		return nil, time.Time{}, jwt.ErrTokenInvalidClaims
+	}
+
+	return profile, expiresAt.Time, nil
+}
+
+// key returns the key of the kid, fetching the JWKS again when the kid is unknown
+func (v *IdentityVerifier) key(ctx context.Context, kid string) (verificationKey, error) {
+	v.mu.RLock()
+	key, ok := v.keys[kid]
+	fetchedAt := v.keysFetchedAt
+	v.mu.RUnlock()
+
+	if ok {
+		return key, nil
+	}
+
+	if time.Since(fetchedAt) >= keyRefreshCooldown {
+		if err := v.refreshKeys(ctx); err != nil {
+			v.Log.Warnf("Failed to refresh the auth service keys: %v", err)
+		}
+
+		v.mu.RLock()
+		key, ok = v.keys[kid]
+		v.mu.RUnlock()
+
+		if ok {
+			return key, nil
+		}
+	}
+
+	return verificationKey{}, errKeyUnavailable
+}
+
+func (v *IdentityVerifier) refreshKeys(ctx context.Context) error {
+	getJwksCtx, cancelGetJwks := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetJwks()
+
+	res, err := v.Auth.Service.GetJwks(getJwksCtx, &proto.GetJwksRequest{})
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.keysFetchedAt = time.Now()
+	if err != nil {
+		return err
+	}
+
+	keys := make(map[string]verificationKey, len(res.GetKeys()))
+	for _, jwk := range res.GetKeys() {
+		if jwk.GetUse() != "" && jwk.GetUse() != "sig" {
+			continue
+		}
+
+		publicKey, err := jwkPublicKey(jwk)
+		if err != nil {
+			v.Log.Warnf("Skipping auth service key %s: %v", jwk.GetKid(), err)
+			continue
+		}
+		keys[jwk.GetKid()] = verificationKey{
+			Algorithm: jwk.GetAlg(),
+			PublicKey: publicKey,
+		}
+	}
+
+	v.keys = keys
+	return nil
+}
+
+// syncRevocations pages through the sessions revoked since the last poll
+func (v *IdentityVerifier) syncRevocations(ctx context.Context) error {
+	v.mu.RLock()
+	since := v.revokedSince.Add(-revocationOverlap)
+	v.mu.RUnlock()
+
+	for {
+		listCtx, cancelList := context.WithTimeout(ctx, 2 * time.Second)
+		res, err := v.Auth.Service.ListRevokedSessions(listCtx, &proto.ListRevokedSessionsRequest{
+			Since: since.UnixMilli(),
+			Limit: revocationPageSize,
+		})
+		cancelList()
+		if err != nil {
+			return err
+		}
+
+		sessions := res.GetSessions()
+
+		v.mu.Lock()
+		for _, session := range sessions {
+			revokedAt := time.UnixMilli(session.GetRevokedAt())
+			v.revoked[session.GetId()] = revokedAt
+			if revokedAt.After(v.revokedSince) {
+				v.revokedSince = revokedAt
+			}
+		}
+		v.mu.Unlock()
+
+		if len(sessions) < revocationPageSize {
+			return nil
+		}
+
+		// A full page of revocations sharing one timestamp cannot be paged further
+		next := time.UnixMilli(sessions[len(sessions)-1].GetRevokedAt())
+		if !next.After(since) {
+			return nil
+		}
+		since = next
+	}
+}
+
+func (v *IdentityVerifier) isRevoked(sessionID int64) bool {
+	if sessionID == 0 {
+		return false
+	}
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	_, ok := v.revoked[sessionID]
+	return ok
+}
+
+func (v *IdentityVerifier) remember(cacheKey string, profile *proto.GetProfileResponse, expiresAt time.Time) {
+	if limit := time.Now().Add(v.cacheTTL); expiresAt.After(limit) {
+		expiresAt = limit
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.identities[cacheKey] = &cachedIdentity{
+		Profile:   profile,
+		ExpiresAt: expiresAt,
+	}
+}
+
+// watch keeps the keys and revocations up to date and sweeps expired entries
+func (v *IdentityVerifier) watch(jwksRefreshInterval time.Duration, revocationPollInterval time.Duration) {
+	ctx := context.Background()
+
+	if v.local {
+		if err := v.refreshKeys(ctx); err != nil {
+			v.Log.Warnf("Failed to fetch the auth service keys, verifying tokens remotely until they load: %v", err)
+		}
+	}
+	if err := v.syncRevocations(ctx); err != nil {
+		v.Log.Warnf("Failed to sync revoked sessions: %v", err)
+	}
+
+	jwksTicker := time.NewTicker(jwksRefreshInterval)
+	defer jwksTicker.Stop()
+
+	revocationTicker := time.NewTicker(revocationPollInterval)
+	defer revocationTicker.Stop()
+
+	for {
+		select {
+		case <-jwksTicker.C:
+			if !v.local {
+				continue
+			}
+			if err := v.refreshKeys(ctx); err != nil {
+				v.Log.Warnf("Failed to refresh the auth service keys: %v", err)
+			}
+		case <-revocationTicker.C:
+			if err := v.syncRevocations(ctx); err != nil {
+				v.Log.Warnf("Failed to sync revoked sessions: %v", err)
+			}
+			v.sweep()
+		}
+	}
+}
+
+func (v *IdentityVerifier) sweep() {
+	now := time.Now()
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	for cacheKey, cached := range v.identities {
+		if now.After(cached.ExpiresAt) {
+			delete(v.identities, cacheKey)
+		}
+	}
+
+	for sessionID, revokedAt := range v.revoked {
+		if now.Sub(revokedAt) > accessTokenTTL+revocationOverlap {
+			delete(v.revoked, sessionID)
+		}
+	}
+}
+
+// jwkPublicKey decodes the RSA and Ed25519 keys the auth service signs with
+func jwkPublicKey(jwk *proto.Jwk) (crypto.PublicKey, error) {
+	decode := base64.RawURLEncoding.DecodeString
+
+	switch jwk.GetKty() {
+	case "RSA":
+		n, err := decode(jwk.GetN())
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode(jwk.GetE())
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "OKP":
+		if !strings.EqualFold(jwk.GetCrv(), "Ed25519") {
+			return nil, fmt.Errorf("unsupported curve %s", jwk.GetCrv())
+		}
+
+		x, err := decode(jwk.GetX())
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key")
+		}
+
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", jwk.GetKty())
+	}
+}
\ No newline at end of file
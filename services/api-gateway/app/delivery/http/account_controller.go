@@ -0,0 +1,110 @@
+package http
+
+import (
+	"api-gateway/app/model/dto"
+	"api-gateway/app/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AccountController struct {
+    AccountUseCase usecase.AccountUseCase
+}
+
+func NewAccountController(accountUseCase usecase.AccountUseCase) *AccountController {
+    return &AccountController{
+        AccountUseCase: accountUseCase,
+    }
+}
+
+func (c *AccountController) RequestExport(ctx *fiber.Ctx) error {
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    response, err := c.AccountUseCase.RequestExport(ctx.UserContext(), clientInfo(ctx), accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.Status(fiber.StatusAccepted).JSON(dto.Response[dto.AccountExportData]{
+        Message: "Export requested, you will get an email once it is ready",
+        Data:    response,
+    })
+}
+
+func (c *AccountController) GetExports(ctx *fiber.Ctx) error {
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    response, err := c.AccountUseCase.GetExports(ctx.UserContext(), accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.GetAccountExportsResponse]{
+        Message: "Exports retrieved",
+        Data:    response,
+    })
+}
+
+func (c *AccountController) DownloadExport(ctx *fiber.Ctx) error {
+    id := ctx.Params("export_id")
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    file, err := c.AccountUseCase.DownloadExport(ctx.UserContext(), &id, accessToken)
+    if err != nil {
+        return err
+    }
+
+    ctx.Attachment(file.Filename)
+    ctx.Set(fiber.HeaderContentType, "application/zip")
+
+    return ctx.Send(file.Content)
+}
+
+func (c *AccountController) RequestDeletion(ctx *fiber.Ctx) error {
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    request := new(dto.RequestAccountDeletionRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+    request.Client = clientInfo(ctx)
+
+    response, err := c.AccountUseCase.RequestDeletion(ctx.UserContext(), request, accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.Status(fiber.StatusAccepted).JSON(dto.Response[dto.AccountDeletionData]{
+        Message: "Account scheduled for deletion, check your email to cancel it",
+        Data:    response,
+    })
+}
+
+func (c *AccountController) CancelDeletion(ctx *fiber.Ctx) error {
+    request := new(dto.CancelAccountDeletionRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+
+    response, err := c.AccountUseCase.CancelDeletion(ctx.UserContext(), request)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.AccountDeletionData]{
+        Message: "Account deletion cancelled, sign in to continue",
+        Data:    response,
+    })
+}
\ No newline at end of file
@@ -0,0 +1,303 @@
+package http
+
+import (
+	"api-gateway/app/model/dto"
+	"api-gateway/app/usecase"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// defaultReportDays is the report window when no days are given
+const defaultReportDays = 30
+
+type AdminController struct {
+    AdminUseCase usecase.AdminUseCase
+}
+
+func NewAdminController(adminUseCase usecase.AdminUseCase) *AdminController {
+    return &AdminController{
+        AdminUseCase: adminUseCase,
+    }
+}
+
+func (c *AdminController) GetUsers(ctx *fiber.Ctx) error {
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    request := &dto.GetAdminUsersRequest{
+        Query:    ctx.Query("q"),
+        Page:     ctx.QueryInt("page", 1),
+        PageSize: ctx.QueryInt("page_size", 20),
+    }
+
+    response, err := c.AdminUseCase.GetUsers(ctx.UserContext(), request, accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.GetAdminUsersResponse]{
+        Message: "Users retrieved",
+        Data:    response,
+    })
+}
+
+func (c *AdminController) SuspendUser(ctx *fiber.Ctx) error {
+    id := ctx.Params("user_id")
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    request := new(dto.SuspendUserRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+    request.Client = clientInfo(ctx)
+
+    response, err := c.AdminUseCase.SuspendUser(ctx.UserContext(), &id, request, accessToken)
+    if err != nil {
+        return err
+    }
+
+    message := "User restored"
+    if response.Suspended {
+        message = "User suspended"
+    }
+
+    return ctx.JSON(dto.Response[dto.AdminUserData]{
+        Message: message,
+        Data:    response,
+    })
+}
+
+func (c *AdminController) UnlockUser(ctx *fiber.Ctx) error {
+    id := ctx.Params("user_id")
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    // The body is optional, it only names an IP address to unlock as well
+    request := new(dto.UnlockUserRequest)
+    if len(ctx.Body()) > 0 {
+        if err := ctx.BodyParser(request); err != nil {
+            return fiber.ErrBadRequest
+        }
+    }
+    request.Client = clientInfo(ctx)
+
+    response, err := c.AdminUseCase.UnlockUser(ctx.UserContext(), &id, request, accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.AdminUserData]{
+        Message: "User unlocked",
+        Data:    response,
+    })
+}
+
+func (c *AdminController) UpdateUserRole(ctx *fiber.Ctx) error {
+    id := ctx.Params("user_id")
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    request := new(dto.UpdateUserRoleRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+    request.Client = clientInfo(ctx)
+
+    response, err := c.AdminUseCase.UpdateUserRole(ctx.UserContext(), &id, request, accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.AdminUserData]{
+        Message: "User role updated",
+        Data:    response,
+    })
+}
+
+func (c *AdminController) GetUsageReport(ctx *fiber.Ctx) error {
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+
+    request := &dto.ReportRequest{
+        Days: ctx.QueryInt("days", defaultReportDays),
+    }
+
+    response, err := c.AdminUseCase.GetUsageReport(ctx.UserContext(), request, &userIdUint, accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.UsageReportResponse]{
+        Message: "Usage report retrieved",
+        Data:    response,
+    })
+}
+
+func (c *AdminController) GetFeedbackReport(ctx *fiber.Ctx) error {
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+
+    request := &dto.ReportRequest{
+        Days: ctx.QueryInt("days", defaultReportDays),
+    }
+
+    response, err := c.AdminUseCase.GetFeedbackReport(ctx.UserContext(), request, &userIdUint)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.FeedbackReportResponse]{
+        Message: "Feedback report retrieved",
+        Data:    response,
+    })
+}
+
+func (c *AdminController) GetAuditLogs(ctx *fiber.Ctx) error {
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    request := &dto.GetAuditLogsRequest{
+        AuditLogFilter: auditLogFilter(ctx),
+        Page:           ctx.QueryInt("page", 1),
+        PageSize:       ctx.QueryInt("page_size", 20),
+    }
+
+    response, err := c.AdminUseCase.GetAuditLogs(ctx.UserContext(), request, accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.GetAuditLogsResponse]{
+        Message: "Audit logs retrieved",
+        Data:    response,
+    })
+}
+
+// ExportAuditLogs downloads every entry matching the filters as CSV or JSON
+func (c *AdminController) ExportAuditLogs(ctx *fiber.Ctx) error {
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    request := &dto.ExportAuditLogsRequest{
+        AuditLogFilter: auditLogFilter(ctx),
+        Format:         ctx.Query("format", "csv"),
+    }
+
+    logs, err := c.AdminUseCase.ExportAuditLogs(ctx.UserContext(), request, accessToken)
+    if err != nil {
+        return err
+    }
+
+    filename := fmt.Sprintf("audit-logs-%s.%s", time.Now().UTC().Format("20060102T150405Z"), request.Format)
+    ctx.Attachment(filename)
+
+    if request.Format == "json" {
+        return ctx.JSON(logs)
+    }
+
+    ctx.Set(fiber.HeaderContentType, "text/csv")
+    writer := csv.NewWriter(ctx)
+    writer.Write([]string{"id", "created_at", "action", "actor_id", "target_type", "target_id", "ip_address", "user_agent", "diff", "prev_hash", "hash"})
+    for _, log := range logs {
+        writer.Write([]string{
+            strconv.FormatUint(uint64(log.ID), 10),
+            time.Unix(log.CreatedAt, 0).UTC().Format(time.RFC3339),
+            log.Action,
+            strconv.FormatUint(uint64(log.ActorID), 10),
+            log.TargetType,
+            log.TargetID,
+            log.IPAddress,
+            log.UserAgent,
+            string(log.Diff),
+            log.PrevHash,
+            log.Hash,
+        })
+    }
+    writer.Flush()
+
+    return writer.Error()
+}
+
+func (c *AdminController) VerifyAuditLogs(ctx *fiber.Ctx) error {
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    response, err := c.AdminUseCase.VerifyAuditLogs(ctx.UserContext(), accessToken)
+    if err != nil {
+        return err
+    }
+
+    message := "Audit log chain is intact"
+    if !response.Valid {
+        message = "Audit log chain is broken"
+    }
+
+    return ctx.JSON(dto.Response[dto.VerifyAuditLogsResponse]{
+        Message: message,
+        Data:    response,
+    })
+}
+
+func (c *AdminController) GetAccountDeletions(ctx *fiber.Ctx) error {
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    request := &dto.GetAccountDeletionsRequest{
+        Status:   ctx.Query("status"),
+        Page:     ctx.QueryInt("page", 1),
+        PageSize: ctx.QueryInt("page_size", 20),
+    }
+
+    response, err := c.AdminUseCase.GetAccountDeletions(ctx.UserContext(), request, accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.GetAccountDeletionsResponse]{
+        Message: "Account deletions retrieved",
+        Data:    response,
+    })
+}
+
+// auditLogFilter reads the filters shared by listing and exporting the audit log
+func auditLogFilter(ctx *fiber.Ctx) dto.AuditLogFilter {
+    return dto.AuditLogFilter{
+        ActorID:    uint(ctx.QueryInt("actor_id", 0)),
+        Action:     ctx.Query("action"),
+        TargetType: ctx.Query("target_type"),
+        TargetID:   ctx.Query("target_id"),
+        Since:      int64(ctx.QueryInt("since", 0)),
+        Until:      int64(ctx.QueryInt("until", 0)),
+    }
+}
\ No newline at end of file
@@ -0,0 +1,114 @@
+package http
+
+import (
+	"api-gateway/app/model/dto"
+	"api-gateway/app/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ApiKeyController struct {
+    ApiKeyUseCase usecase.ApiKeyUseCase
+}
+
+func NewApiKeyController(apiKeyUseCase usecase.ApiKeyUseCase) *ApiKeyController {
+    return &ApiKeyController{
+        ApiKeyUseCase: apiKeyUseCase,
+    }
+}
+
+func (c *ApiKeyController) GetApiKeys(ctx *fiber.Ctx) error {
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    response, err := c.ApiKeyUseCase.GetApiKeys(ctx.UserContext(), accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.GetApiKeysResponse]{
+        Message: "Api keys retrieved",
+        Data:    response,
+    })
+}
+
+func (c *ApiKeyController) GetApiKey(ctx *fiber.Ctx) error {
+    id := ctx.Params("api_key_id")
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    response, err := c.ApiKeyUseCase.GetApiKey(ctx.UserContext(), &id, accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.ApiKeyData]{
+        Message: "Api key retrieved",
+        Data:    response,
+    })
+}
+
+func (c *ApiKeyController) CreateApiKey(ctx *fiber.Ctx) error {
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    request := new(dto.CreateApiKeyRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+
+    response, err := c.ApiKeyUseCase.CreateApiKey(ctx.UserContext(), request, accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.Status(fiber.StatusCreated).JSON(dto.Response[dto.CreateApiKeyResponse]{
+        Message: "Api key created, copy it now as it will not be shown again",
+        Data:    response,
+    })
+}
+
+func (c *ApiKeyController) UpdateApiKey(ctx *fiber.Ctx) error {
+    id := ctx.Params("api_key_id")
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    request := new(dto.UpdateApiKeyRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+
+    response, err := c.ApiKeyUseCase.UpdateApiKey(ctx.UserContext(), &id, request, accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.ApiKeyData]{
+        Message: "Api key updated",
+        Data:    response,
+    })
+}
+
+func (c *ApiKeyController) DeleteApiKey(ctx *fiber.Ctx) error {
+    id := ctx.Params("api_key_id")
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    if err := c.ApiKeyUseCase.DeleteApiKey(ctx.UserContext(), &id, accessToken); err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[any]{
+        Message: "Api key deleted",
+    })
+}
\ No newline at end of file
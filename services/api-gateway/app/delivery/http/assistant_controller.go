@@ -0,0 +1,161 @@
+package http
+
+import (
+	"api-gateway/app/model/dto"
+	"api-gateway/app/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AssistantController struct {
+    AssistantUseCase usecase.AssistantUseCase
+}
+
+func NewAssistantController(assistantUseCase usecase.AssistantUseCase) *AssistantController {
+    return &AssistantController{
+        AssistantUseCase: assistantUseCase,
+    }
+}
+
+func (c *AssistantController) CreateAssistant(ctx *fiber.Ctx) error {
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+    organizationId, _ := ctx.Locals("organizationId").(int64)
+    organizationIdUint := uint(organizationId)
+
+    request := new(dto.AssistantRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+
+    response, err := c.AssistantUseCase.CreateAssistant(ctx.UserContext(), &userIdUint, &organizationIdUint, request)
+    if err != nil {
+        return err
+    }
+
+    return ctx.Status(fiber.StatusCreated).JSON(dto.Response[dto.AssistantData]{
+        Message: "Assistant created",
+        Data:    response,
+    })
+}
+
+func (c *AssistantController) GetAssistants(ctx *fiber.Ctx) error {
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+    organizationId, _ := ctx.Locals("organizationId").(int64)
+    organizationIdUint := uint(organizationId)
+
+    response, err := c.AssistantUseCase.GetAssistants(ctx.UserContext(), &userIdUint, &organizationIdUint)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.GetAssistantsResponse]{
+        Message: "Assistants retrieved",
+        Data:    response,
+    })
+}
+
+func (c *AssistantController) GetAssistant(ctx *fiber.Ctx) error {
+    id := ctx.Params("assistant_id")
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+
+    response, err := c.AssistantUseCase.GetAssistant(ctx.UserContext(), &id, &userIdUint)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.AssistantData]{
+        Message: "Assistant retrieved",
+        Data:    response,
+    })
+}
+
+func (c *AssistantController) UpdateAssistant(ctx *fiber.Ctx) error {
+    id := ctx.Params("assistant_id")
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+
+    request := new(dto.AssistantRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+
+    response, err := c.AssistantUseCase.UpdateAssistant(ctx.UserContext(), &id, &userIdUint, request)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.AssistantData]{
+        Message: "Assistant updated",
+        Data:    response,
+    })
+}
+
+func (c *AssistantController) DeleteAssistant(ctx *fiber.Ctx) error {
+    id := ctx.Params("assistant_id")
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+
+    if err := c.AssistantUseCase.DeleteAssistant(ctx.UserContext(), &id, &userIdUint); err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[any]{
+        Message: "Assistant deleted",
+    })
+}
+
+func (c *AssistantController) ShareAssistant(ctx *fiber.Ctx) error {
+    id := ctx.Params("assistant_id")
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+
+    request := new(dto.ShareAssistantRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+
+    response, err := c.AssistantUseCase.ShareAssistant(ctx.UserContext(), &id, &userIdUint, request)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.AssistantData]{
+        Message: "Assistant sharing updated",
+        Data:    response,
+    })
+}
+
+func (c *AssistantController) GetSharedAssistant(ctx *fiber.Ctx) error {
+    shareToken := ctx.Params("share_token")
+
+    response, err := c.AssistantUseCase.GetSharedAssistant(ctx.UserContext(), &shareToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.AssistantData]{
+        Message: "Assistant retrieved",
+        Data:    response,
+    })
+}
@@ -22,14 +22,21 @@ func (c *AuthController) Register(ctx *fiber.Ctx) error {
     if err := ctx.BodyParser(request); err != nil {
         return fiber.ErrBadRequest
     }
+    request.Client = clientInfo(ctx)
 
     response, err := c.AuthUseCase.Register(ctx.UserContext(), request)
     if err != nil {
         return err
     }
 
+    // No token is issued while the email address has to be verified
+    message := "Register successful"
+    if response.Token == nil {
+        message = "Register successful, please verify your email address"
+    }
+
     return ctx.JSON(dto.Response[dto.LoginResponse]{
-        Message: "Register successful",
+        Message: message,
         Data:    response,
     })
 }
@@ -39,12 +46,38 @@ func (c *AuthController) Login(ctx *fiber.Ctx) error {
     if err := ctx.BodyParser(request); err != nil {
         return fiber.ErrBadRequest
     }
+    request.Client = clientInfo(ctx)
 
     response, err := c.AuthUseCase.Login(ctx.UserContext(), request)
     if err != nil {
         return err
     }
 
+    // Accounts with a second factor get a challenge instead of a token
+    message := "Login successful"
+    if response.TwoFactor != nil {
+        message = "Two-factor authentication required"
+    }
+
+    return ctx.JSON(dto.Response[dto.LoginResponse]{
+        Message: message,
+        Data:    response,
+    })
+}
+
+func (c *AuthController) LoginWithProvider(ctx *fiber.Ctx) error {
+    request := new(dto.LoginWithProviderRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+    request.Provider = ctx.Params("provider")
+    request.Client = clientInfo(ctx)
+
+    response, err := c.AuthUseCase.LoginWithProvider(ctx.UserContext(), request)
+    if err != nil {
+        return err
+    }
+
     return ctx.JSON(dto.Response[dto.LoginResponse]{
         Message: "Login successful",
         Data:    response,
@@ -56,6 +89,7 @@ func (c *AuthController) LoginWithGoogle(ctx *fiber.Ctx) error {
     if err := ctx.BodyParser(request); err != nil {
         return fiber.ErrBadRequest
     }
+    request.Client = clientInfo(ctx)
 
     response, err := c.AuthUseCase.LoginWithGoogle(ctx.UserContext(), request)
     if err != nil {
@@ -73,6 +107,7 @@ func (c *AuthController) RefreshToken(ctx *fiber.Ctx) error {
     if err := ctx.BodyParser(request); err != nil {
         return fiber.ErrBadRequest
     }
+    request.Client = clientInfo(ctx)
 
     response, err := c.AuthUseCase.Refresh(ctx.UserContext(), request)
     if err != nil {
@@ -83,4 +118,115 @@ func (c *AuthController) RefreshToken(ctx *fiber.Ctx) error {
         Message: "Token refreshed",
         Data:    response,
     })
+}
+
+func (c *AuthController) Logout(ctx *fiber.Ctx) error {
+    request := new(dto.LogoutRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+
+    if err := c.AuthUseCase.Logout(ctx.UserContext(), request); err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[any]{
+        Message: "Logout successful",
+    })
+}
+
+func (c *AuthController) LogoutAll(ctx *fiber.Ctx) error {
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    if err := c.AuthUseCase.LogoutAll(ctx.UserContext(), accessToken); err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[any]{
+        Message: "Logged out from all devices",
+    })
+}
+
+func (c *AuthController) ResendVerificationEmail(ctx *fiber.Ctx) error {
+    request := new(dto.ResendVerificationEmailRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+
+    if err := c.AuthUseCase.ResendVerificationEmail(ctx.UserContext(), request); err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[any]{
+        Message: "If the account exists and is not verified, a verification email has been sent",
+    })
+}
+
+func (c *AuthController) VerifyEmail(ctx *fiber.Ctx) error {
+    request := new(dto.VerifyEmailRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+
+    response, err := c.AuthUseCase.VerifyEmail(ctx.UserContext(), request)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.VerifyEmailResponse]{
+        Message: "Email verified",
+        Data:    response,
+    })
+}
+
+func (c *AuthController) ForgotPassword(ctx *fiber.Ctx) error {
+    request := new(dto.RequestPasswordResetRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+
+    if err := c.AuthUseCase.RequestPasswordReset(ctx.UserContext(), request); err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[any]{
+        Message: "If the account exists, a password reset email has been sent",
+    })
+}
+
+func (c *AuthController) ResetPassword(ctx *fiber.Ctx) error {
+    request := new(dto.ResetPasswordRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+
+    if err := c.AuthUseCase.ResetPassword(ctx.UserContext(), request); err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[any]{
+        Message: "Password reset, please login again",
+    })
+}
+
+// JWKS publishes the token verification keys as a standard JWK set, not wrapped in a response
+func (c *AuthController) JWKS(ctx *fiber.Ctx) error {
+    response, err := c.AuthUseCase.GetJWKS(ctx.UserContext())
+    if err != nil {
+        return err
+    }
+
+    ctx.Set(fiber.HeaderCacheControl, "public, max-age=300")
+    return ctx.JSON(response)
+}
+
+// clientInfo identifies the device of the session being started or refreshed
+func clientInfo(ctx *fiber.Ctx) dto.ClientInfo {
+    return dto.ClientInfo{
+        UserAgent: ctx.Get(fiber.HeaderUserAgent),
+        IPAddress: ctx.IP(),
+    }
 }
\ No newline at end of file
@@ -24,8 +24,33 @@ func (c *ChatController) GetChats(ctx *fiber.Ctx) error {
         return fiber.ErrUnauthorized
     }
     userIdUint := uint(userId)
+    organizationId, _ := ctx.Locals("organizationId").(int64)
+    organizationIdUint := uint(organizationId)
 
-    response, err := c.ChatUseCase.GetChats(ctx.UserContext(), &userIdUint)
+    projectId := ctx.Query("project_id")
+
+    response, err := c.ChatUseCase.GetChats(ctx.UserContext(), &userIdUint, &organizationIdUint, &projectId)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.GetChatsResponse]{
+        Message: "Chats retrieved",
+        Data:    response,
+    })
+}
+
+func (c *ChatController) GetProjectChats(ctx *fiber.Ctx) error {
+    projectId := ctx.Params("project_id")
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+    organizationId, _ := ctx.Locals("organizationId").(int64)
+    organizationIdUint := uint(organizationId)
+
+    response, err := c.ChatUseCase.GetChats(ctx.UserContext(), &userIdUint, &organizationIdUint, &projectId)
     if err != nil {
         return err
     }
@@ -36,6 +61,49 @@ func (c *ChatController) GetChats(ctx *fiber.Ctx) error {
     })
 }
 
+func (c *ChatController) MoveChat(ctx *fiber.Ctx) error {
+    id := ctx.Params("chat_id")
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+
+    request := new(dto.MoveChatRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+
+    response, err := c.ChatUseCase.MoveChat(ctx.UserContext(), &id, &userIdUint, request)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.ChatData]{
+        Message: "Chat moved",
+        Data:    response,
+    })
+}
+
+func (c *ChatController) DeleteChat(ctx *fiber.Ctx) error {
+    id := ctx.Params("chat_id")
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+    organizationId, _ := ctx.Locals("organizationId").(int64)
+    organizationIdUint := uint(organizationId)
+
+    if err := c.ChatUseCase.DeleteChat(ctx.UserContext(), &id, &userIdUint, &organizationIdUint); err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[any]{
+        Message: "Chat deleted",
+    })
+}
+
 func (c *ChatController) GetMessages(ctx *fiber.Ctx) error {
     id := ctx.Params("chat_id")
     userId, ok := ctx.Locals("userId").(int64)
@@ -43,8 +111,10 @@ func (c *ChatController) GetMessages(ctx *fiber.Ctx) error {
         return fiber.ErrUnauthorized
     }
     userIdUint := uint(userId)
+    organizationId, _ := ctx.Locals("organizationId").(int64)
+    organizationIdUint := uint(organizationId)
 
-    response, err := c.ChatUseCase.GetMessages(ctx.UserContext(), &id, &userIdUint)
+    response, err := c.ChatUseCase.GetMessages(ctx.UserContext(), &id, &userIdUint, &organizationIdUint)
     if err != nil {
         return err
     }
@@ -55,10 +125,40 @@ func (c *ChatController) GetMessages(ctx *fiber.Ctx) error {
     })
 }
 
+func (c *ChatController) PreferMessage(ctx *fiber.Ctx) error {
+    id := ctx.Params("chat_id")
+    messageId := ctx.Params("message_id")
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+    organizationId, _ := ctx.Locals("organizationId").(int64)
+    organizationIdUint := uint(organizationId)
+
+    response, err := c.ChatUseCase.PreferMessage(ctx.UserContext(), &id, &messageId, &userIdUint, &organizationIdUint)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.MessageData]{
+        Message: "Message preferred",
+        Data:    response,
+    })
+}
+
 func (c *ChatController) JoinChatRoom(ep *socketio.EventPayload) {
     c.ChatUseCase.JoinChatRoom(ep)
 }
 
 func (c *ChatController) CreateMessage(ep *socketio.EventPayload) {
     c.ChatUseCase.CreateMessage(ep)
+}
+
+func (c *ChatController) CompareMessage(ep *socketio.EventPayload) {
+    c.ChatUseCase.CompareMessage(ep)
+}
+
+func (c *ChatController) Disconnect(ep *socketio.EventPayload) {
+    c.ChatUseCase.Disconnect(ep)
 }
\ No newline at end of file
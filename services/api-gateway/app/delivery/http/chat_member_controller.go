@@ -0,0 +1,104 @@
+package http
+
+import (
+	"api-gateway/app/model/dto"
+	"api-gateway/app/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ChatMemberController struct {
+    ChatMemberUseCase usecase.ChatMemberUseCase
+}
+
+func NewChatMemberController(chatMemberUseCase usecase.ChatMemberUseCase) *ChatMemberController {
+    return &ChatMemberController{
+        ChatMemberUseCase: chatMemberUseCase,
+    }
+}
+
+func (c *ChatMemberController) InviteChatMember(ctx *fiber.Ctx) error {
+    chatId := ctx.Params("chat_id")
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+
+    request := new(dto.InviteChatMemberRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+
+    response, err := c.ChatMemberUseCase.InviteChatMember(ctx.UserContext(), &chatId, &userIdUint, request)
+    if err != nil {
+        return err
+    }
+
+    return ctx.Status(fiber.StatusCreated).JSON(dto.Response[dto.ChatMemberData]{
+        Message: "Member invited",
+        Data:    response,
+    })
+}
+
+func (c *ChatMemberController) GetChatMembers(ctx *fiber.Ctx) error {
+    chatId := ctx.Params("chat_id")
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+
+    response, err := c.ChatMemberUseCase.GetChatMembers(ctx.UserContext(), &chatId, &userIdUint)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.GetChatMembersResponse]{
+        Message: "Members retrieved",
+        Data:    response,
+    })
+}
+
+func (c *ChatMemberController) UpdateChatMember(ctx *fiber.Ctx) error {
+    chatId := ctx.Params("chat_id")
+    memberId := ctx.Params("member_id")
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+
+    request := new(dto.UpdateChatMemberRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+
+    response, err := c.ChatMemberUseCase.UpdateChatMember(ctx.UserContext(), &chatId, &memberId, &userIdUint, request)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.ChatMemberData]{
+        Message: "Member updated",
+        Data:    response,
+    })
+}
+
+func (c *ChatMemberController) RemoveChatMember(ctx *fiber.Ctx) error {
+    chatId := ctx.Params("chat_id")
+    memberId := ctx.Params("member_id")
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+
+    if err := c.ChatMemberUseCase.RemoveChatMember(ctx.UserContext(), &chatId, &memberId, &userIdUint); err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[any]{
+        Message: "Member removed",
+    })
+}
@@ -0,0 +1,74 @@
+package http
+
+import (
+	"api-gateway/app/model/dto"
+	"api-gateway/app/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type IdentityController struct {
+    IdentityUseCase usecase.IdentityUseCase
+}
+
+func NewIdentityController(identityUseCase usecase.IdentityUseCase) *IdentityController {
+    return &IdentityController{
+        IdentityUseCase: identityUseCase,
+    }
+}
+
+func (c *IdentityController) GetIdentities(ctx *fiber.Ctx) error {
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    response, err := c.IdentityUseCase.GetIdentities(ctx.UserContext(), accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.GetIdentitiesResponse]{
+        Message: "Linked accounts retrieved",
+        Data:    response,
+    })
+}
+
+func (c *IdentityController) LinkIdentity(ctx *fiber.Ctx) error {
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    request := new(dto.LinkIdentityRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+    request.Provider = ctx.Params("provider")
+
+    response, err := c.IdentityUseCase.LinkIdentity(ctx.UserContext(), request, accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.Status(fiber.StatusCreated).JSON(dto.Response[dto.IdentityData]{
+        Message: "Account linked",
+        Data:    response,
+    })
+}
+
+func (c *IdentityController) UnlinkIdentity(ctx *fiber.Ctx) error {
+    provider := ctx.Params("provider")
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    if err := c.IdentityUseCase.UnlinkIdentity(ctx.UserContext(), &provider, accessToken); err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[any]{
+        Message: "Account unlinked",
+    })
+}
\ No newline at end of file
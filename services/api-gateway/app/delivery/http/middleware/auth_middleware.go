@@ -2,29 +2,45 @@ package middleware
 
 import (
 	"api-gateway/app/delivery/client"
+	"api-gateway/app/model"
 	"api-gateway/app/model/proto"
+	"slices"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
 )
 
+// apiKeyPrefix starts every API key, access tokens are JWTs
+const apiKeyPrefix = "sk_"
+
+// apiKeyResources are the route groups API keys can be scoped to
+var apiKeyResources = []string{"chats", "assistants", "projects", "scheduled-prompts", "prompt-templates", "webhooks"}
+
 type AuthMiddleware struct {
 	Handler fiber.Handler
 }
 
-func NewAuthMiddleware(auth *client.AuthClient) *AuthMiddleware {
+func NewAuthMiddleware(auth *client.AuthClient, identity *client.IdentityVerifier) *AuthMiddleware {
 	return &AuthMiddleware{
 		Handler: func(ctx *fiber.Ctx) error {
+			// API keys are sent in X-API-Key or as bearer token
+			apiKey := ctx.Get("X-API-Key")
 			splitToken := strings.Split(ctx.Get("Authorization"), "Bearer ")
+			if apiKey == "" && len(splitToken) >= 2 && strings.HasPrefix(splitToken[1], apiKeyPrefix) {
+				apiKey = splitToken[1]
+			}
+
+			if apiKey != "" {
+				return authenticateApiKey(ctx, auth, apiKey)
+			}
+
 			if len(splitToken) < 2 {
 				return fiber.ErrUnauthorized
 			}
 
 			accessToken := splitToken[1]
 
-			user, err := auth.Service.GetProfile(ctx.Context(), &proto.GetProfileRequest{
-				AccessToken: accessToken,
-			})
+			user, err := identity.Verify(ctx.Context(), accessToken)
 			if err != nil {
 				return fiber.ErrUnauthorized
 			}
@@ -33,9 +49,68 @@ func NewAuthMiddleware(auth *client.AuthClient) *AuthMiddleware {
 			ctx.Locals("email", user.GetEmail())
 			ctx.Locals("name", user.GetName())
 			ctx.Locals("imageURL", user.GetImageUrl())
+			ctx.Locals("role", user.GetRole())
+			ctx.Locals("permissions", user.GetPermissions())
+			ctx.Locals("organizationId", user.GetOrganizationId())
+			ctx.Locals("organizationRole", user.GetOrganizationRole())
 			ctx.Locals("accessToken", accessToken)
 
+			// Internal services take the user from the signed identity of the calls
+			ctx.SetUserContext(client.WithClientInfo(client.WithUserID(ctx.UserContext(), user.GetId()), requestClient(ctx)))
+
 			return ctx.Next()
 		},
 	}
+}
+
+// authenticateApiKey resolves the key and checks its scopes against the route.
+// Keys carry no access token, so routes managing the account stay out of reach.
+func authenticateApiKey(ctx *fiber.Ctx, auth *client.AuthClient, apiKey string) error {
+	user, err := auth.Service.ResolveApiKey(ctx.Context(), &proto.ResolveApiKeyRequest{
+		Key: apiKey,
+	})
+	if err != nil {
+		return fiber.ErrUnauthorized
+	}
+
+	scope := requiredScope(ctx)
+	if scope == "" {
+		return model.NewError(model.StatusForbidden, "Api keys cannot access this route", nil)
+	}
+	if !slices.Contains(user.GetScopes(), scope) {
+		return model.NewError(model.StatusForbidden, "Api key is missing the "+scope+" scope", nil)
+	}
+
+	ctx.Locals("userId", user.GetId())
+	ctx.Locals("email", user.GetEmail())
+	ctx.Locals("name", user.GetName())
+	ctx.Locals("imageURL", user.GetImageUrl())
+	ctx.Locals("apiKeyId", user.GetApiKeyId())
+	ctx.Locals("organizationId", int64(0))
+	ctx.SetUserContext(client.WithClientInfo(client.WithUserID(ctx.UserContext(), user.GetId()), requestClient(ctx)))
+
+	return ctx.Next()
+}
+
+// requestClient describes the device of the request for the audit log
+func requestClient(ctx *fiber.Ctx) *proto.ClientInfo {
+	return &proto.ClientInfo{
+		UserAgent: ctx.Get(fiber.HeaderUserAgent),
+		IpAddress: ctx.IP(),
+	}
+}
+
+// requiredScope returns the scope of the route group, :read for reads and
+// :write for every other method, empty when keys cannot use the route
+func requiredScope(ctx *fiber.Ctx) string {
+	resource := strings.Split(strings.TrimPrefix(ctx.Path(), "/"), "/")[0]
+	if !slices.Contains(apiKeyResources, resource) {
+		return ""
+	}
+
+	if ctx.Method() == fiber.MethodGet || ctx.Method() == fiber.MethodHead {
+		return resource + ":read"
+	}
+
+	return resource + ":write"
 }
\ No newline at end of file
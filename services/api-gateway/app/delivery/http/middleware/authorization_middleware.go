@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"api-gateway/app/model"
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Permissions granted by the roles of the user service
+const (
+	PermissionUsersRead    = "users:read"
+	PermissionUsersSuspend = "users:suspend"
+	PermissionUsersRoles   = "users:roles"
+	PermissionReportsRead  = "reports:read"
+	PermissionAuditRead    = "audit:read"
+)
+
+// AuthorizationMiddleware checks the permissions of the access token per route.
+// It runs after AuthMiddleware, API keys carry no permissions and never pass.
+type AuthorizationMiddleware struct{}
+
+func NewAuthorizationMiddleware() *AuthorizationMiddleware {
+	return &AuthorizationMiddleware{}
+}
+
+// Require lets the request through when the access token grants the permission
+func (m *AuthorizationMiddleware) Require(permission string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		permissions, _ := ctx.Locals("permissions").([]string)
+		if !slices.Contains(permissions, permission) {
+			return model.NewError(model.StatusForbidden, "Missing the "+permission+" permission", nil)
+		}
+
+		return ctx.Next()
+	}
+}
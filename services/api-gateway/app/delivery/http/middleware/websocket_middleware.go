@@ -2,7 +2,6 @@ package middleware
 
 import (
 	"api-gateway/app/delivery/client"
-	"api-gateway/app/model/proto"
 	"context"
 	// socketio "github.com/doquangtan/gofiber-socket.io"
 )
@@ -12,7 +11,7 @@ type WebsocketMiddleware struct {
 	Handler func(params map[string]string) bool
 }
 
-func NewWebsocketMiddleware(auth *client.AuthClient) *WebsocketMiddleware {
+func NewWebsocketMiddleware(identity *client.IdentityVerifier) *WebsocketMiddleware {
 	return &WebsocketMiddleware{
 		// Handler: func (socket *socketio.Socket) {
 		// 	// Validate token
@@ -38,9 +37,7 @@ func NewWebsocketMiddleware(auth *client.AuthClient) *WebsocketMiddleware {
 		Handler: func(params map[string]string) bool {
 			accessToken := params["token"]
 
-			_, err := auth.Service.GetProfile(context.Background(), &proto.GetProfileRequest{
-				AccessToken: accessToken,
-			})
+			_, err := identity.Verify(context.Background(), accessToken)
 			return err == nil
 		},
 	}
@@ -0,0 +1,278 @@
+package http
+
+import (
+	"api-gateway/app/model/dto"
+	"api-gateway/app/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type OrganizationController struct {
+    OrganizationUseCase usecase.OrganizationUseCase
+}
+
+func NewOrganizationController(organizationUseCase usecase.OrganizationUseCase) *OrganizationController {
+    return &OrganizationController{
+        OrganizationUseCase: organizationUseCase,
+    }
+}
+
+func (c *OrganizationController) SwitchOrganization(ctx *fiber.Ctx) error {
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    request := new(dto.SwitchOrganizationRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+    request.Client = clientInfo(ctx)
+
+    response, err := c.OrganizationUseCase.SwitchOrganization(ctx.UserContext(), request, accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.LoginResponse]{
+        Message: "Organization switched",
+        Data:    response,
+    })
+}
+
+func (c *OrganizationController) CreateOrganization(ctx *fiber.Ctx) error {
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    request := new(dto.CreateOrganizationRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+
+    response, err := c.OrganizationUseCase.CreateOrganization(ctx.UserContext(), request, accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.Status(fiber.StatusCreated).JSON(dto.Response[dto.OrganizationData]{
+        Message: "Organization created",
+        Data:    response,
+    })
+}
+
+func (c *OrganizationController) GetOrganizations(ctx *fiber.Ctx) error {
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    response, err := c.OrganizationUseCase.GetOrganizations(ctx.UserContext(), accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.GetOrganizationsResponse]{
+        Message: "Organizations retrieved",
+        Data:    response,
+    })
+}
+
+func (c *OrganizationController) GetOrganization(ctx *fiber.Ctx) error {
+    id := ctx.Params("organization_id")
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    response, err := c.OrganizationUseCase.GetOrganization(ctx.UserContext(), &id, accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.OrganizationData]{
+        Message: "Organization retrieved",
+        Data:    response,
+    })
+}
+
+func (c *OrganizationController) UpdateOrganization(ctx *fiber.Ctx) error {
+    id := ctx.Params("organization_id")
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    request := new(dto.UpdateOrganizationRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+
+    response, err := c.OrganizationUseCase.UpdateOrganization(ctx.UserContext(), &id, request, accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.OrganizationData]{
+        Message: "Organization updated",
+        Data:    response,
+    })
+}
+
+func (c *OrganizationController) DeleteOrganization(ctx *fiber.Ctx) error {
+    id := ctx.Params("organization_id")
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    if err := c.OrganizationUseCase.DeleteOrganization(ctx.UserContext(), &id, clientInfo(ctx), accessToken); err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[any]{
+        Message: "Organization deleted",
+    })
+}
+
+func (c *OrganizationController) GetOrganizationMembers(ctx *fiber.Ctx) error {
+    id := ctx.Params("organization_id")
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    response, err := c.OrganizationUseCase.GetOrganizationMembers(ctx.UserContext(), &id, accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.GetOrganizationMembersResponse]{
+        Message: "Organization members retrieved",
+        Data:    response,
+    })
+}
+
+func (c *OrganizationController) UpdateOrganizationMember(ctx *fiber.Ctx) error {
+    id := ctx.Params("organization_id")
+    userId := ctx.Params("user_id")
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    request := new(dto.UpdateOrganizationMemberRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+    request.Client = clientInfo(ctx)
+
+    response, err := c.OrganizationUseCase.UpdateOrganizationMember(ctx.UserContext(), &id, &userId, request, accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.OrganizationMemberData]{
+        Message: "Organization member updated",
+        Data:    response,
+    })
+}
+
+func (c *OrganizationController) RemoveOrganizationMember(ctx *fiber.Ctx) error {
+    id := ctx.Params("organization_id")
+    userId := ctx.Params("user_id")
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    if err := c.OrganizationUseCase.RemoveOrganizationMember(ctx.UserContext(), &id, &userId, clientInfo(ctx), accessToken); err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[any]{
+        Message: "Organization member removed",
+    })
+}
+
+func (c *OrganizationController) InviteOrganizationMember(ctx *fiber.Ctx) error {
+    id := ctx.Params("organization_id")
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    request := new(dto.InviteOrganizationMemberRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+    request.Client = clientInfo(ctx)
+
+    response, err := c.OrganizationUseCase.InviteOrganizationMember(ctx.UserContext(), &id, request, accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.Status(fiber.StatusCreated).JSON(dto.Response[dto.OrganizationInviteData]{
+        Message: "Organization member invited",
+        Data:    response,
+    })
+}
+
+func (c *OrganizationController) GetOrganizationInvites(ctx *fiber.Ctx) error {
+    id := ctx.Params("organization_id")
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    response, err := c.OrganizationUseCase.GetOrganizationInvites(ctx.UserContext(), &id, accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.GetOrganizationInvitesResponse]{
+        Message: "Organization invites retrieved",
+        Data:    response,
+    })
+}
+
+func (c *OrganizationController) RevokeOrganizationInvite(ctx *fiber.Ctx) error {
+    id := ctx.Params("organization_id")
+    inviteId := ctx.Params("invite_id")
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    if err := c.OrganizationUseCase.RevokeOrganizationInvite(ctx.UserContext(), &id, &inviteId, accessToken); err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[any]{
+        Message: "Organization invite revoked",
+    })
+}
+
+func (c *OrganizationController) AcceptOrganizationInvite(ctx *fiber.Ctx) error {
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    request := new(dto.AcceptOrganizationInviteRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+
+    response, err := c.OrganizationUseCase.AcceptOrganizationInvite(ctx.UserContext(), request, accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.OrganizationData]{
+        Message: "Organization invite accepted",
+        Data:    response,
+    })
+}
\ No newline at end of file
@@ -0,0 +1,197 @@
+package http
+
+import (
+	"api-gateway/app/model/dto"
+	"api-gateway/app/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ProfileController struct {
+    ProfileUseCase usecase.ProfileUseCase
+}
+
+func NewProfileController(profileUseCase usecase.ProfileUseCase) *ProfileController {
+    return &ProfileController{
+        ProfileUseCase: profileUseCase,
+    }
+}
+
+func (c *ProfileController) GetMe(ctx *fiber.Ctx) error {
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    response, err := c.ProfileUseCase.GetMe(ctx.UserContext(), accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.MeData]{
+        Message: "Profile retrieved",
+        Data:    response,
+    })
+}
+
+func (c *ProfileController) UpdateMe(ctx *fiber.Ctx) error {
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    request := new(dto.UpdateMeRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+    request.Client = clientInfo(ctx)
+
+    response, err := c.ProfileUseCase.UpdateMe(ctx.UserContext(), request, accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.LoginResponse]{
+        Message: "Profile updated",
+        Data:    response,
+    })
+}
+
+func (c *ProfileController) ChangePassword(ctx *fiber.Ctx) error {
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    request := new(dto.ChangePasswordRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+    request.Client = clientInfo(ctx)
+
+    response, err := c.ProfileUseCase.ChangePassword(ctx.UserContext(), request, accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.LoginResponse]{
+        Message: "Password changed, other devices were signed out",
+        Data:    response,
+    })
+}
+
+func (c *ProfileController) RequestEmailChange(ctx *fiber.Ctx) error {
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    request := new(dto.RequestEmailChangeRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+    request.Client = clientInfo(ctx)
+
+    if err := c.ProfileUseCase.RequestEmailChange(ctx.UserContext(), request, accessToken); err != nil {
+        return err
+    }
+
+    return ctx.Status(fiber.StatusAccepted).JSON(dto.Response[any]{
+        Message: "Check the new email address to confirm the change",
+    })
+}
+
+func (c *ProfileController) ConfirmEmailChange(ctx *fiber.Ctx) error {
+    request := new(dto.ConfirmEmailChangeRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+
+    response, err := c.ProfileUseCase.ConfirmEmailChange(ctx.UserContext(), request)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.VerifyEmailResponse]{
+        Message: "Email changed, refresh your tokens to use it",
+        Data:    response,
+    })
+}
+
+func (c *ProfileController) UpdateAvatar(ctx *fiber.Ctx) error {
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    file, err := ctx.FormFile("avatar")
+    if err != nil {
+        return fiber.NewError(fiber.StatusBadRequest, "The avatar file is required")
+    }
+
+    response, err := c.ProfileUseCase.UpdateAvatar(ctx.UserContext(), file, clientInfo(ctx), accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.LoginResponse]{
+        Message: "Avatar updated",
+        Data:    response,
+    })
+}
+
+func (c *ProfileController) DeleteAvatar(ctx *fiber.Ctx) error {
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    response, err := c.ProfileUseCase.DeleteAvatar(ctx.UserContext(), clientInfo(ctx), accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.LoginResponse]{
+        Message: "Avatar removed",
+        Data:    response,
+    })
+}
+
+func (c *ProfileController) GetPreferences(ctx *fiber.Ctx) error {
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    response, err := c.ProfileUseCase.GetPreferences(ctx.UserContext(), accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.PreferencesData]{
+        Message: "Preferences retrieved",
+        Data:    response,
+    })
+}
+
+func (c *ProfileController) UpdatePreferences(ctx *fiber.Ctx) error {
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    request := new(dto.UpdatePreferencesRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+
+    response, err := c.ProfileUseCase.UpdatePreferences(ctx.UserContext(), request, accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.PreferencesData]{
+        Message: "Preferences updated",
+        Data:    response,
+    })
+}
\ No newline at end of file
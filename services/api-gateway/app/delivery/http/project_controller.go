@@ -0,0 +1,119 @@
+package http
+
+import (
+	"api-gateway/app/model/dto"
+	"api-gateway/app/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ProjectController struct {
+    ProjectUseCase usecase.ProjectUseCase
+}
+
+func NewProjectController(projectUseCase usecase.ProjectUseCase) *ProjectController {
+    return &ProjectController{
+        ProjectUseCase: projectUseCase,
+    }
+}
+
+func (c *ProjectController) CreateProject(ctx *fiber.Ctx) error {
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+
+    request := new(dto.ProjectRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+
+    response, err := c.ProjectUseCase.CreateProject(ctx.UserContext(), &userIdUint, request)
+    if err != nil {
+        return err
+    }
+
+    return ctx.Status(fiber.StatusCreated).JSON(dto.Response[dto.ProjectData]{
+        Message: "Project created",
+        Data:    response,
+    })
+}
+
+func (c *ProjectController) GetProjects(ctx *fiber.Ctx) error {
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+
+    response, err := c.ProjectUseCase.GetProjects(ctx.UserContext(), &userIdUint)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.GetProjectsResponse]{
+        Message: "Projects retrieved",
+        Data:    response,
+    })
+}
+
+func (c *ProjectController) GetProject(ctx *fiber.Ctx) error {
+    id := ctx.Params("project_id")
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+
+    response, err := c.ProjectUseCase.GetProject(ctx.UserContext(), &id, &userIdUint)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.ProjectData]{
+        Message: "Project retrieved",
+        Data:    response,
+    })
+}
+
+func (c *ProjectController) UpdateProject(ctx *fiber.Ctx) error {
+    id := ctx.Params("project_id")
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+
+    request := new(dto.ProjectRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+
+    response, err := c.ProjectUseCase.UpdateProject(ctx.UserContext(), &id, &userIdUint, request)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.ProjectData]{
+        Message: "Project updated",
+        Data:    response,
+    })
+}
+
+func (c *ProjectController) DeleteProject(ctx *fiber.Ctx) error {
+    id := ctx.Params("project_id")
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+
+    if err := c.ProjectUseCase.DeleteProject(ctx.UserContext(), &id, &userIdUint); err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[any]{
+        Message: "Project deleted",
+    })
+}
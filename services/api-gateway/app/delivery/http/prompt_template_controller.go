@@ -0,0 +1,104 @@
+package http
+
+import (
+	"api-gateway/app/model/dto"
+	"api-gateway/app/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PromptTemplateController struct {
+    PromptTemplateUseCase usecase.PromptTemplateUseCase
+}
+
+func NewPromptTemplateController(promptTemplateUseCase usecase.PromptTemplateUseCase) *PromptTemplateController {
+    return &PromptTemplateController{
+        PromptTemplateUseCase: promptTemplateUseCase,
+    }
+}
+
+func (c *PromptTemplateController) CreatePromptTemplate(ctx *fiber.Ctx) error {
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+    organizationId, _ := ctx.Locals("organizationId").(int64)
+    organizationIdUint := uint(organizationId)
+
+    request := new(dto.PromptTemplateRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+
+    response, err := c.PromptTemplateUseCase.CreatePromptTemplate(ctx.UserContext(), &userIdUint, &organizationIdUint, request)
+    if err != nil {
+        return err
+    }
+
+    return ctx.Status(fiber.StatusCreated).JSON(dto.Response[dto.PromptTemplateData]{
+        Message: "Prompt template created",
+        Data:    response,
+    })
+}
+
+func (c *PromptTemplateController) GetPromptTemplates(ctx *fiber.Ctx) error {
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+    organizationId, _ := ctx.Locals("organizationId").(int64)
+    organizationIdUint := uint(organizationId)
+
+    response, err := c.PromptTemplateUseCase.GetPromptTemplates(ctx.UserContext(), &userIdUint, &organizationIdUint)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.GetPromptTemplatesResponse]{
+        Message: "Prompt templates retrieved",
+        Data:    response,
+    })
+}
+
+func (c *PromptTemplateController) UpdatePromptTemplate(ctx *fiber.Ctx) error {
+    id := ctx.Params("prompt_template_id")
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+
+    request := new(dto.PromptTemplateRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+
+    response, err := c.PromptTemplateUseCase.UpdatePromptTemplate(ctx.UserContext(), &id, &userIdUint, request)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.PromptTemplateData]{
+        Message: "Prompt template updated",
+        Data:    response,
+    })
+}
+
+func (c *PromptTemplateController) DeletePromptTemplate(ctx *fiber.Ctx) error {
+    id := ctx.Params("prompt_template_id")
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+
+    if err := c.PromptTemplateUseCase.DeletePromptTemplate(ctx.UserContext(), &id, &userIdUint); err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[any]{
+        Message: "Prompt template deleted",
+    })
+}
\ No newline at end of file
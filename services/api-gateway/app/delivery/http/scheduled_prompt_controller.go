@@ -0,0 +1,138 @@
+package http
+
+import (
+	"api-gateway/app/model/dto"
+	"api-gateway/app/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ScheduledPromptController struct {
+    ScheduledPromptUseCase usecase.ScheduledPromptUseCase
+}
+
+func NewScheduledPromptController(scheduledPromptUseCase usecase.ScheduledPromptUseCase) *ScheduledPromptController {
+    return &ScheduledPromptController{
+        ScheduledPromptUseCase: scheduledPromptUseCase,
+    }
+}
+
+func (c *ScheduledPromptController) CreateScheduledPrompt(ctx *fiber.Ctx) error {
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+
+    request := new(dto.ScheduledPromptRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+
+    response, err := c.ScheduledPromptUseCase.CreateScheduledPrompt(ctx.UserContext(), &userIdUint, request)
+    if err != nil {
+        return err
+    }
+
+    return ctx.Status(fiber.StatusCreated).JSON(dto.Response[dto.ScheduledPromptData]{
+        Message: "Scheduled prompt created",
+        Data:    response,
+    })
+}
+
+func (c *ScheduledPromptController) GetScheduledPrompts(ctx *fiber.Ctx) error {
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+
+    response, err := c.ScheduledPromptUseCase.GetScheduledPrompts(ctx.UserContext(), &userIdUint)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.GetScheduledPromptsResponse]{
+        Message: "Scheduled prompts retrieved",
+        Data:    response,
+    })
+}
+
+func (c *ScheduledPromptController) GetScheduledPrompt(ctx *fiber.Ctx) error {
+    id := ctx.Params("scheduled_prompt_id")
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+
+    response, err := c.ScheduledPromptUseCase.GetScheduledPrompt(ctx.UserContext(), &id, &userIdUint)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.ScheduledPromptData]{
+        Message: "Scheduled prompt retrieved",
+        Data:    response,
+    })
+}
+
+func (c *ScheduledPromptController) UpdateScheduledPrompt(ctx *fiber.Ctx) error {
+    id := ctx.Params("scheduled_prompt_id")
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+
+    request := new(dto.ScheduledPromptRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+
+    response, err := c.ScheduledPromptUseCase.UpdateScheduledPrompt(ctx.UserContext(), &id, &userIdUint, request)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.ScheduledPromptData]{
+        Message: "Scheduled prompt updated",
+        Data:    response,
+    })
+}
+
+func (c *ScheduledPromptController) DeleteScheduledPrompt(ctx *fiber.Ctx) error {
+    id := ctx.Params("scheduled_prompt_id")
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+
+    if err := c.ScheduledPromptUseCase.DeleteScheduledPrompt(ctx.UserContext(), &id, &userIdUint); err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[any]{
+        Message: "Scheduled prompt deleted",
+    })
+}
+
+func (c *ScheduledPromptController) GetScheduledPromptRuns(ctx *fiber.Ctx) error {
+    id := ctx.Params("scheduled_prompt_id")
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+
+    response, err := c.ScheduledPromptUseCase.GetScheduledPromptRuns(ctx.UserContext(), &id, &userIdUint)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.GetScheduledPromptRunsResponse]{
+        Message: "Scheduled prompt runs retrieved",
+        Data:    response,
+    })
+}
@@ -0,0 +1,51 @@
+package http
+
+import (
+	"api-gateway/app/model/dto"
+	"api-gateway/app/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type SessionController struct {
+    SessionUseCase usecase.SessionUseCase
+}
+
+func NewSessionController(sessionUseCase usecase.SessionUseCase) *SessionController {
+    return &SessionController{
+        SessionUseCase: sessionUseCase,
+    }
+}
+
+func (c *SessionController) GetSessions(ctx *fiber.Ctx) error {
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    response, err := c.SessionUseCase.GetSessions(ctx.UserContext(), accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.GetSessionsResponse]{
+        Message: "Sessions retrieved",
+        Data:    response,
+    })
+}
+
+func (c *SessionController) RevokeSession(ctx *fiber.Ctx) error {
+    id := ctx.Params("session_id")
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    if err := c.SessionUseCase.RevokeSession(ctx.UserContext(), &id, accessToken); err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[any]{
+        Message: "Session revoked",
+    })
+}
\ No newline at end of file
@@ -0,0 +1,95 @@
+package http
+
+import (
+	"api-gateway/app/model/dto"
+	"api-gateway/app/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type TwoFactorController struct {
+    TwoFactorUseCase usecase.TwoFactorUseCase
+}
+
+func NewTwoFactorController(twoFactorUseCase usecase.TwoFactorUseCase) *TwoFactorController {
+    return &TwoFactorController{
+        TwoFactorUseCase: twoFactorUseCase,
+    }
+}
+
+func (c *TwoFactorController) EnrollTotp(ctx *fiber.Ctx) error {
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    response, err := c.TwoFactorUseCase.EnrollTotp(ctx.UserContext(), accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.EnrollTotpResponse]{
+        Message: "Scan the secret with an authenticator app and confirm it with a code",
+        Data:    response,
+    })
+}
+
+func (c *TwoFactorController) ConfirmTotp(ctx *fiber.Ctx) error {
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    request := new(dto.TotpCodeRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+
+    response, err := c.TwoFactorUseCase.ConfirmTotp(ctx.UserContext(), request, accessToken)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.ConfirmTotpResponse]{
+        Message: "Two-factor authentication enabled",
+        Data:    response,
+    })
+}
+
+func (c *TwoFactorController) DisableTotp(ctx *fiber.Ctx) error {
+    accessToken, ok := ctx.Locals("accessToken").(string)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+
+    request := new(dto.TotpCodeRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+
+    if err := c.TwoFactorUseCase.DisableTotp(ctx.UserContext(), request, accessToken); err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[any]{
+        Message: "Two-factor authentication disabled",
+    })
+}
+
+func (c *TwoFactorController) CompleteChallenge(ctx *fiber.Ctx) error {
+    request := new(dto.CompleteTwoFactorLoginRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+    request.Client = clientInfo(ctx)
+
+    response, err := c.TwoFactorUseCase.CompleteChallenge(ctx.UserContext(), request)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.LoginResponse]{
+        Message: "Login successful",
+        Data:    response,
+    })
+}
\ No newline at end of file
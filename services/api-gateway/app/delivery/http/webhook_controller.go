@@ -0,0 +1,158 @@
+package http
+
+import (
+	"api-gateway/app/model/dto"
+	"api-gateway/app/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type WebhookController struct {
+    WebhookUseCase usecase.WebhookUseCase
+}
+
+func NewWebhookController(webhookUseCase usecase.WebhookUseCase) *WebhookController {
+    return &WebhookController{
+        WebhookUseCase: webhookUseCase,
+    }
+}
+
+func (c *WebhookController) CreateWebhook(ctx *fiber.Ctx) error {
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+
+    request := new(dto.WebhookRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+
+    response, err := c.WebhookUseCase.CreateWebhook(ctx.UserContext(), &userIdUint, request)
+    if err != nil {
+        return err
+    }
+
+    return ctx.Status(fiber.StatusCreated).JSON(dto.Response[dto.WebhookData]{
+        Message: "Webhook created",
+        Data:    response,
+    })
+}
+
+func (c *WebhookController) GetWebhooks(ctx *fiber.Ctx) error {
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+
+    response, err := c.WebhookUseCase.GetWebhooks(ctx.UserContext(), &userIdUint)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.GetWebhooksResponse]{
+        Message: "Webhooks retrieved",
+        Data:    response,
+    })
+}
+
+func (c *WebhookController) GetWebhook(ctx *fiber.Ctx) error {
+    id := ctx.Params("webhook_id")
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+
+    response, err := c.WebhookUseCase.GetWebhook(ctx.UserContext(), &id, &userIdUint)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.WebhookData]{
+        Message: "Webhook retrieved",
+        Data:    response,
+    })
+}
+
+func (c *WebhookController) UpdateWebhook(ctx *fiber.Ctx) error {
+    id := ctx.Params("webhook_id")
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+
+    request := new(dto.WebhookRequest)
+    if err := ctx.BodyParser(request); err != nil {
+        return fiber.ErrBadRequest
+    }
+
+    response, err := c.WebhookUseCase.UpdateWebhook(ctx.UserContext(), &id, &userIdUint, request)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.WebhookData]{
+        Message: "Webhook updated",
+        Data:    response,
+    })
+}
+
+func (c *WebhookController) DeleteWebhook(ctx *fiber.Ctx) error {
+    id := ctx.Params("webhook_id")
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+
+    if err := c.WebhookUseCase.DeleteWebhook(ctx.UserContext(), &id, &userIdUint); err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[any]{
+        Message: "Webhook deleted",
+    })
+}
+
+func (c *WebhookController) GetWebhookDeliveries(ctx *fiber.Ctx) error {
+    id := ctx.Params("webhook_id")
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+
+    response, err := c.WebhookUseCase.GetWebhookDeliveries(ctx.UserContext(), &id, &userIdUint)
+    if err != nil {
+        return err
+    }
+
+    return ctx.JSON(dto.Response[dto.GetWebhookDeliveriesResponse]{
+        Message: "Webhook deliveries retrieved",
+        Data:    response,
+    })
+}
+
+func (c *WebhookController) RedeliverWebhookDelivery(ctx *fiber.Ctx) error {
+    id := ctx.Params("webhook_id")
+    deliveryId := ctx.Params("delivery_id")
+    userId, ok := ctx.Locals("userId").(int64)
+    if !ok {
+        return fiber.ErrUnauthorized
+    }
+    userIdUint := uint(userId)
+
+    response, err := c.WebhookUseCase.RedeliverWebhookDelivery(ctx.UserContext(), &id, &deliveryId, &userIdUint)
+    if err != nil {
+        return err
+    }
+
+    return ctx.Status(fiber.StatusCreated).JSON(dto.Response[dto.WebhookDeliveryData]{
+        Message: "Webhook delivery queued",
+        Data:    response,
+    })
+}
@@ -21,18 +21,108 @@ var authSet = wire.NewSet(
 )
 
 var chatSet = wire.NewSet(
+	usecase.NewEphemeralSessionStore,
+	usecase.NewChatRoomRegistry,
 	usecase.NewChatUseCaseImpl,
 	wire.Bind(new(usecase.ChatUseCase), new(*usecase.ChatUseCaseImpl)),
 	http.NewChatController,
 )
 
+var assistantSet = wire.NewSet(
+	usecase.NewAssistantUseCaseImpl,
+	wire.Bind(new(usecase.AssistantUseCase), new(*usecase.AssistantUseCaseImpl)),
+	http.NewAssistantController,
+)
+
+var projectSet = wire.NewSet(
+	usecase.NewProjectUseCaseImpl,
+	wire.Bind(new(usecase.ProjectUseCase), new(*usecase.ProjectUseCaseImpl)),
+	http.NewProjectController,
+)
+
+var chatMemberSet = wire.NewSet(
+	usecase.NewChatMemberUseCaseImpl,
+	wire.Bind(new(usecase.ChatMemberUseCase), new(*usecase.ChatMemberUseCaseImpl)),
+	http.NewChatMemberController,
+)
+
+var scheduledPromptSet = wire.NewSet(
+	usecase.NewScheduledPromptUseCaseImpl,
+	wire.Bind(new(usecase.ScheduledPromptUseCase), new(*usecase.ScheduledPromptUseCaseImpl)),
+	http.NewScheduledPromptController,
+)
+
+var webhookSet = wire.NewSet(
+	usecase.NewWebhookUseCaseImpl,
+	wire.Bind(new(usecase.WebhookUseCase), new(*usecase.WebhookUseCaseImpl)),
+	http.NewWebhookController,
+)
+
+var sessionSet = wire.NewSet(
+	usecase.NewSessionUseCaseImpl,
+	wire.Bind(new(usecase.SessionUseCase), new(*usecase.SessionUseCaseImpl)),
+	http.NewSessionController,
+)
+
+var twoFactorSet = wire.NewSet(
+	usecase.NewTwoFactorUseCaseImpl,
+	wire.Bind(new(usecase.TwoFactorUseCase), new(*usecase.TwoFactorUseCaseImpl)),
+	http.NewTwoFactorController,
+)
+
+var identitySet = wire.NewSet(
+	usecase.NewIdentityUseCaseImpl,
+	wire.Bind(new(usecase.IdentityUseCase), new(*usecase.IdentityUseCaseImpl)),
+	http.NewIdentityController,
+)
+
+var apiKeySet = wire.NewSet(
+	usecase.NewApiKeyUseCaseImpl,
+	wire.Bind(new(usecase.ApiKeyUseCase), new(*usecase.ApiKeyUseCaseImpl)),
+	http.NewApiKeyController,
+)
+
 var grpcClientSet = wire.NewSet(
 	client.NewAuthClient,
+	client.NewIdentityVerifier,
 	client.NewChatClient,
+	client.NewAuditRecorder,
+)
+
+var profileSet = wire.NewSet(
+	usecase.NewAvatarStore,
+	usecase.NewProfileUseCaseImpl,
+	wire.Bind(new(usecase.ProfileUseCase), new(*usecase.ProfileUseCaseImpl)),
+	http.NewProfileController,
+)
+
+var adminSet = wire.NewSet(
+	usecase.NewAdminUseCaseImpl,
+	wire.Bind(new(usecase.AdminUseCase), new(*usecase.AdminUseCaseImpl)),
+	http.NewAdminController,
+)
+
+var accountSet = wire.NewSet(
+	usecase.NewAccountUseCaseImpl,
+	wire.Bind(new(usecase.AccountUseCase), new(*usecase.AccountUseCaseImpl)),
+	http.NewAccountController,
+)
+
+var organizationSet = wire.NewSet(
+	usecase.NewOrganizationUseCaseImpl,
+	wire.Bind(new(usecase.OrganizationUseCase), new(*usecase.OrganizationUseCaseImpl)),
+	http.NewOrganizationController,
+)
+
+var promptTemplateSet = wire.NewSet(
+	usecase.NewPromptTemplateUseCaseImpl,
+	wire.Bind(new(usecase.PromptTemplateUseCase), new(*usecase.PromptTemplateUseCaseImpl)),
+	http.NewPromptTemplateController,
 )
 
 var middlewareSet = wire.NewSet(
 	middleware.NewAuthMiddleware,
+	middleware.NewAuthorizationMiddleware,
 	middleware.NewWebsocketMiddleware,
 )
 
@@ -47,6 +137,20 @@ func InitializedApp() *config.App {
 		middlewareSet,
 		authSet,
 		chatSet,
+		assistantSet,
+		projectSet,
+		chatMemberSet,
+		scheduledPromptSet,
+		webhookSet,
+		sessionSet,
+		twoFactorSet,
+		identitySet,
+		apiKeySet,
+		profileSet,
+		adminSet,
+		accountSet,
+		organizationSet,
+		promptTemplateSet,
 		grpcClientSet,
 	)
 	return nil
@@ -23,15 +23,49 @@ func InitializedApp() *config.App {
 	viper := config.NewViper(logger)
 	app := config.NewFiber(viper)
 	authClient := client.NewAuthClient(viper)
-	authMiddleware := middleware.NewAuthMiddleware(authClient)
-	websocketMiddleware := middleware.NewWebsocketMiddleware(authClient)
+	identityVerifier := client.NewIdentityVerifier(viper, logger, authClient)
+	authMiddleware := middleware.NewAuthMiddleware(authClient, identityVerifier)
+	authorizationMiddleware := middleware.NewAuthorizationMiddleware()
+	websocketMiddleware := middleware.NewWebsocketMiddleware(identityVerifier)
 	validate := config.NewValidator()
 	authUseCaseImpl := usecase.NewAuthUseCaseImpl(validate, logger, authClient)
 	authController := http.NewAuthController(authUseCaseImpl)
 	chatClient := client.NewChatClient(viper)
-	chatUseCaseImpl := usecase.NewChatUseCaseImpl(validate, logger, authClient, chatClient)
+	ephemeralSessionStore := usecase.NewEphemeralSessionStore(viper)
+	chatRoomRegistry := usecase.NewChatRoomRegistry()
+	auditRecorder := client.NewAuditRecorder(authClient, logger)
+	chatUseCaseImpl := usecase.NewChatUseCaseImpl(validate, logger, authClient, identityVerifier, chatClient, ephemeralSessionStore, chatRoomRegistry, auditRecorder)
 	chatController := http.NewChatController(chatUseCaseImpl)
-	httpRouter := route.NewHttpRouter(app, authMiddleware, websocketMiddleware, authController, chatController)
+	assistantUseCaseImpl := usecase.NewAssistantUseCaseImpl(validate, logger, chatClient, auditRecorder)
+	assistantController := http.NewAssistantController(assistantUseCaseImpl)
+	projectUseCaseImpl := usecase.NewProjectUseCaseImpl(validate, logger, chatClient)
+	projectController := http.NewProjectController(projectUseCaseImpl)
+	chatMemberUseCaseImpl := usecase.NewChatMemberUseCaseImpl(validate, logger, chatClient, chatRoomRegistry, auditRecorder)
+	chatMemberController := http.NewChatMemberController(chatMemberUseCaseImpl)
+	scheduledPromptUseCaseImpl := usecase.NewScheduledPromptUseCaseImpl(validate, logger, chatClient)
+	scheduledPromptController := http.NewScheduledPromptController(scheduledPromptUseCaseImpl)
+	webhookUseCaseImpl := usecase.NewWebhookUseCaseImpl(validate, logger, chatClient)
+	webhookController := http.NewWebhookController(webhookUseCaseImpl)
+	sessionUseCaseImpl := usecase.NewSessionUseCaseImpl(validate, logger, authClient)
+	sessionController := http.NewSessionController(sessionUseCaseImpl)
+	twoFactorUseCaseImpl := usecase.NewTwoFactorUseCaseImpl(validate, logger, authClient)
+	twoFactorController := http.NewTwoFactorController(twoFactorUseCaseImpl)
+	identityUseCaseImpl := usecase.NewIdentityUseCaseImpl(validate, logger, authClient)
+	identityController := http.NewIdentityController(identityUseCaseImpl)
+	apiKeyUseCaseImpl := usecase.NewApiKeyUseCaseImpl(validate, logger, authClient)
+	apiKeyController := http.NewApiKeyController(apiKeyUseCaseImpl)
+	avatarStore := usecase.NewAvatarStore(viper)
+	profileUseCaseImpl := usecase.NewProfileUseCaseImpl(validate, logger, authClient, avatarStore)
+	profileController := http.NewProfileController(profileUseCaseImpl)
+	adminUseCaseImpl := usecase.NewAdminUseCaseImpl(validate, logger, authClient, chatClient)
+	adminController := http.NewAdminController(adminUseCaseImpl)
+	accountUseCaseImpl := usecase.NewAccountUseCaseImpl(validate, logger, authClient)
+	accountController := http.NewAccountController(accountUseCaseImpl)
+	organizationUseCaseImpl := usecase.NewOrganizationUseCaseImpl(validate, logger, authClient, chatRoomRegistry)
+	organizationController := http.NewOrganizationController(organizationUseCaseImpl)
+	promptTemplateUseCaseImpl := usecase.NewPromptTemplateUseCaseImpl(validate, logger, chatClient)
+	promptTemplateController := http.NewPromptTemplateController(promptTemplateUseCaseImpl)
+	httpRouter := route.NewHttpRouter(app, authMiddleware, authorizationMiddleware, websocketMiddleware, authController, chatController, assistantController, projectController, chatMemberController, scheduledPromptController, webhookController, sessionController, twoFactorController, identityController, apiKeyController, profileController, adminController, accountController, organizationController, promptTemplateController, avatarStore)
 	configApp := config.NewApp(viper, httpRouter, authClient, chatClient)
 	return configApp
 }
@@ -40,8 +74,36 @@ func InitializedApp() *config.App {
 
 var authSet = wire.NewSet(usecase.NewAuthUseCaseImpl, wire.Bind(new(usecase.AuthUseCase), new(*usecase.AuthUseCaseImpl)), http.NewAuthController)
 
-var chatSet = wire.NewSet(usecase.NewChatUseCaseImpl, wire.Bind(new(usecase.ChatUseCase), new(*usecase.ChatUseCaseImpl)), http.NewChatController)
+var chatSet = wire.NewSet(usecase.NewEphemeralSessionStore, usecase.NewChatRoomRegistry, usecase.NewChatUseCaseImpl, wire.Bind(new(usecase.ChatUseCase), new(*usecase.ChatUseCaseImpl)), http.NewChatController)
 
-var grpcClientSet = wire.NewSet(client.NewAuthClient, client.NewChatClient)
+var assistantSet = wire.NewSet(usecase.NewAssistantUseCaseImpl, wire.Bind(new(usecase.AssistantUseCase), new(*usecase.AssistantUseCaseImpl)), http.NewAssistantController)
 
-var middlewareSet = wire.NewSet(middleware.NewAuthMiddleware, middleware.NewWebsocketMiddleware)
+var projectSet = wire.NewSet(usecase.NewProjectUseCaseImpl, wire.Bind(new(usecase.ProjectUseCase), new(*usecase.ProjectUseCaseImpl)), http.NewProjectController)
+
+var chatMemberSet = wire.NewSet(usecase.NewChatMemberUseCaseImpl, wire.Bind(new(usecase.ChatMemberUseCase), new(*usecase.ChatMemberUseCaseImpl)), http.NewChatMemberController)
+
+var scheduledPromptSet = wire.NewSet(usecase.NewScheduledPromptUseCaseImpl, wire.Bind(new(usecase.ScheduledPromptUseCase), new(*usecase.ScheduledPromptUseCaseImpl)), http.NewScheduledPromptController)
+
+var webhookSet = wire.NewSet(usecase.NewWebhookUseCaseImpl, wire.Bind(new(usecase.WebhookUseCase), new(*usecase.WebhookUseCaseImpl)), http.NewWebhookController)
+
+var sessionSet = wire.NewSet(usecase.NewSessionUseCaseImpl, wire.Bind(new(usecase.SessionUseCase), new(*usecase.SessionUseCaseImpl)), http.NewSessionController)
+
+var twoFactorSet = wire.NewSet(usecase.NewTwoFactorUseCaseImpl, wire.Bind(new(usecase.TwoFactorUseCase), new(*usecase.TwoFactorUseCaseImpl)), http.NewTwoFactorController)
+
+var identitySet = wire.NewSet(usecase.NewIdentityUseCaseImpl, wire.Bind(new(usecase.IdentityUseCase), new(*usecase.IdentityUseCaseImpl)), http.NewIdentityController)
+
+var apiKeySet = wire.NewSet(usecase.NewApiKeyUseCaseImpl, wire.Bind(new(usecase.ApiKeyUseCase), new(*usecase.ApiKeyUseCaseImpl)), http.NewApiKeyController)
+
+var grpcClientSet = wire.NewSet(client.NewAuthClient, client.NewIdentityVerifier, client.NewChatClient, client.NewAuditRecorder)
+
+var profileSet = wire.NewSet(usecase.NewAvatarStore, usecase.NewProfileUseCaseImpl, wire.Bind(new(usecase.ProfileUseCase), new(*usecase.ProfileUseCaseImpl)), http.NewProfileController)
+
+var adminSet = wire.NewSet(usecase.NewAdminUseCaseImpl, wire.Bind(new(usecase.AdminUseCase), new(*usecase.AdminUseCaseImpl)), http.NewAdminController)
+
+var accountSet = wire.NewSet(usecase.NewAccountUseCaseImpl, wire.Bind(new(usecase.AccountUseCase), new(*usecase.AccountUseCaseImpl)), http.NewAccountController)
+
+var organizationSet = wire.NewSet(usecase.NewOrganizationUseCaseImpl, wire.Bind(new(usecase.OrganizationUseCase), new(*usecase.OrganizationUseCaseImpl)), http.NewOrganizationController)
+
+var promptTemplateSet = wire.NewSet(usecase.NewPromptTemplateUseCaseImpl, wire.Bind(new(usecase.PromptTemplateUseCase), new(*usecase.PromptTemplateUseCaseImpl)), http.NewPromptTemplateController)
+
+var middlewareSet = wire.NewSet(middleware.NewAuthMiddleware, middleware.NewAuthorizationMiddleware, middleware.NewWebsocketMiddleware)
@@ -0,0 +1,35 @@
+package helper
+
+import (
+	"api-gateway/app/model"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GrpcError converts an error returned by a downstream service into a request error
+func GrpcError(err error, fallback string) error {
+	s, ok := status.FromError(err)
+	if !ok {
+		return model.NewError(model.StatusInternalServerError, fallback, nil)
+	}
+
+	switch s.Code() {
+	case codes.InvalidArgument:
+		return model.NewError(model.StatusBadRequest, s.Message(), nil)
+	case codes.NotFound:
+		return model.NewError(model.StatusNotFound, s.Message(), nil)
+	case codes.AlreadyExists:
+		return model.NewError(model.StatusConflict, s.Message(), nil)
+	case codes.PermissionDenied:
+		return model.NewError(model.StatusForbidden, s.Message(), nil)
+	case codes.Unauthenticated:
+		return model.NewError(model.StatusUnauthorized, s.Message(), nil)
+	case codes.FailedPrecondition:
+		return model.NewError(model.StatusPreconditionFailed, s.Message(), nil)
+	case codes.ResourceExhausted:
+		return model.NewError(model.StatusTooManyRequests, s.Message(), nil)
+	default:
+		return model.NewError(model.StatusInternalServerError, s.Message(), nil)
+	}
+}
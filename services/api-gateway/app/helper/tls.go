@@ -0,0 +1,59 @@
+package helper
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/spf13/viper"
+)
+
+// ClientTLSConfig trusts the CA of GRPC_TLS_CA_FILE, the system roots otherwise, and
+// presents the certificate of GRPC_TLS_CERT_FILE and GRPC_TLS_KEY_FILE to servers
+// requiring mutual TLS
+func ClientTLSConfig(config *viper.Viper) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	roots, err := certPool(config.GetString("GRPC_TLS_CA_FILE"))
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig.RootCAs = roots
+
+	certFile, keyFile := config.GetString("GRPC_TLS_CERT_FILE"), config.GetString("GRPC_TLS_KEY_FILE")
+	if certFile != "" && keyFile != "" {
+		certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading client certificate: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
+
+// certPool loads the CA file, an empty path returns the system roots
+func certPool(caFile string) (*x509.CertPool, error) {
+	if caFile == "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			return nil, fmt.Errorf("error loading system root CA pool: %v", err)
+		}
+		return pool, nil
+	}
+
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading CA file: %v", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %s", caFile)
+	}
+
+	return pool, nil
+}
\ No newline at end of file
@@ -0,0 +1,150 @@
+package dto
+
+import "encoding/json"
+
+type GetAdminUsersRequest struct {
+    Query    string `json:"q" validate:"max=255"`
+    Page     int    `json:"page" validate:"min=1"`
+    PageSize int    `json:"page_size" validate:"min=1,max=100"`
+}
+
+type AdminUserData struct {
+    ID            uint   `json:"id"`
+    Name          string `json:"name"`
+    Email         string `json:"email"`
+    ImageURL      string `json:"image_url"`
+    Role          string `json:"role"`
+    EmailVerified bool   `json:"email_verified"`
+    Suspended     bool   `json:"suspended"`
+    CreatedAt     int64  `json:"created_at"`
+}
+
+type GetAdminUsersResponse struct {
+    Users    []AdminUserData `json:"users"`
+    Total    int64           `json:"total"`
+    Page     int             `json:"page"`
+    PageSize int             `json:"page_size"`
+}
+
+type SuspendUserRequest struct {
+    Suspended *bool      `json:"suspended" validate:"required"`
+    Client    ClientInfo `json:"-"`
+}
+
+type UnlockUserRequest struct {
+    IPAddress string     `json:"ip_address" validate:"omitempty,ip"`
+    Client    ClientInfo `json:"-"`
+}
+
+type UpdateUserRoleRequest struct {
+    Role   string     `json:"role" validate:"required,oneof=user support admin"`
+    Client ClientInfo `json:"-"`
+}
+
+type ReportRequest struct {
+    Days int `json:"days" validate:"min=1,max=365"`
+}
+
+type UserStatsData struct {
+    Total     int64 `json:"total"`
+    Verified  int64 `json:"verified"`
+    Suspended int64 `json:"suspended"`
+    Created   int64 `json:"created"`
+}
+
+type UsageDayData struct {
+    Date        string `json:"date"`
+    Messages    int64  `json:"messages"`
+    ActiveUsers int64  `json:"active_users"`
+}
+
+type UserUsageData struct {
+    UserID  uint  `json:"user_id"`
+    Prompts int64 `json:"prompts"`
+}
+
+type UsageReportResponse struct {
+    Since       int64           `json:"since"`
+    Users       UserStatsData   `json:"users"`
+    Messages    int64           `json:"messages"`
+    Prompts     int64           `json:"prompts"`
+    Replies     int64           `json:"replies"`
+    Chats       int64           `json:"chats"`
+    ActiveUsers int64           `json:"active_users"`
+    Days        []UsageDayData  `json:"days"`
+    TopUsers    []UserUsageData `json:"top_users"`
+}
+
+type ModelFeedbackData struct {
+    Model     string `json:"model"`
+    Shown     int64  `json:"shown"`
+    Preferred int64  `json:"preferred"`
+}
+
+type FeedbackReportResponse struct {
+    Since       int64               `json:"since"`
+    Comparisons int64               `json:"comparisons"`
+    Preferences int64               `json:"preferences"`
+    Models      []ModelFeedbackData `json:"models"`
+}
+
+// AuditLogFilter narrows the audit log, zero values match every entry
+type AuditLogFilter struct {
+    ActorID    uint   `json:"actor_id"`
+    Action     string `json:"action" validate:"max=100"`
+    TargetType string `json:"target_type" validate:"max=50"`
+    TargetID   string `json:"target_id" validate:"max=320"`
+    Since      int64  `json:"since" validate:"gte=0"`
+    Until      int64  `json:"until" validate:"gte=0"`
+}
+
+type GetAuditLogsRequest struct {
+    AuditLogFilter
+    Page     int `json:"page" validate:"min=1"`
+    PageSize int `json:"page_size" validate:"min=1,max=100"`
+}
+
+type ExportAuditLogsRequest struct {
+    AuditLogFilter
+    Format string `json:"format" validate:"oneof=csv json"`
+}
+
+type AuditLogData struct {
+    ID         uint            `json:"id"`
+    Action     string          `json:"action"`
+    ActorID    uint            `json:"actor_id"`
+    TargetType string          `json:"target_type"`
+    TargetID   string          `json:"target_id"`
+    IPAddress  string          `json:"ip_address"`
+    UserAgent  string          `json:"user_agent"`
+    Diff       json.RawMessage `json:"diff"`
+    PrevHash   string          `json:"prev_hash"`
+    Hash       string          `json:"hash"`
+    CreatedAt  int64           `json:"created_at"`
+}
+
+type GetAuditLogsResponse struct {
+    Logs     []AuditLogData `json:"logs"`
+    Total    int64          `json:"total"`
+    Page     int            `json:"page"`
+    PageSize int            `json:"page_size"`
+}
+
+type VerifyAuditLogsResponse struct {
+    Valid    bool  `json:"valid"`
+    Checked  int64 `json:"checked"`
+    BrokenID uint  `json:"broken_id,omitempty"`
+}
+
+type GetAccountDeletionsRequest struct {
+    Status   string `json:"status" validate:"omitempty,oneof=scheduled purging purged cancelled"`
+    Page     int    `json:"page" validate:"min=1"`
+    PageSize int    `json:"page_size" validate:"min=1,max=100"`
+}
+
+type GetAccountDeletionsResponse struct {
+    Deletions []AccountDeletionData `json:"deletions"`
+    Total     int64                 `json:"total"`
+    Page      int                   `json:"page"`
+    PageSize  int                   `json:"page_size"`
+}
\ No newline at end of file
@@ -0,0 +1,43 @@
+package dto
+
+type AssistantDocument struct {
+    ID      uint   `json:"id,omitempty"`
+    Name    string `json:"name" validate:"required,max=255"`
+    Content string `json:"content" validate:"required"`
+}
+
+type AssistantRequest struct {
+    Name            string              `json:"name" validate:"required,max=255"`
+    Description     string              `json:"description" validate:"omitempty"`
+    Instructions    string              `json:"instructions" validate:"required"`
+    Model           string              `json:"model" validate:"omitempty,max=255"`
+    Temperature     *float32            `json:"temperature" validate:"omitempty,min=0,max=2"`
+    TopP            *float32            `json:"top_p" validate:"omitempty,min=0,max=1"`
+    MaxOutputTokens *int32              `json:"max_output_tokens" validate:"omitempty,min=1"`
+    Tools           []string            `json:"tools" validate:"omitempty,dive,oneof=code_execution"`
+    Documents       []AssistantDocument `json:"documents" validate:"omitempty,dive"`
+}
+
+type ShareAssistantRequest struct {
+    Enabled bool `json:"enabled"`
+}
+
+type AssistantData struct {
+    ID              uint                `json:"id"`
+    UserID          uint                `json:"user_id"`
+    Name            string              `json:"name"`
+    Description     string              `json:"description"`
+    Instructions    string              `json:"instructions,omitempty"`
+    Model           string              `json:"model"`
+    Temperature     *float32            `json:"temperature"`
+    TopP            *float32            `json:"top_p"`
+    MaxOutputTokens *int32              `json:"max_output_tokens"`
+    Tools           []string            `json:"tools"`
+    Documents       []AssistantDocument `json:"documents,omitempty"`
+    ShareToken      string              `json:"share_token,omitempty"`
+    OrganizationID  *uint               `json:"organization_id"`
+}
+
+type GetAssistantsResponse struct {
+    Assistants []AssistantData `json:"assistants"`
+}
@@ -1,28 +1,66 @@
 package dto
 
+// ClientInfo describes the device a session is started from, taken from the request
+type ClientInfo struct {
+    UserAgent string
+    IPAddress string
+}
+
 type LoginRequest struct {
     Email    string `json:"email" validate:"required,email"`
     Password string `json:"password" validate:"required"`
+    Client   ClientInfo `json:"-"`
 }
 
 type RegisterRequest struct {
     Name     string `json:"name" validate:"required"`
     Email    string `json:"email" validate:"required,email"`
     Password string `json:"password" validate:"required"`
+    Client   ClientInfo `json:"-"`
 }
 
 type LoginWIthGoogleRequest struct {
     IdToken string `json:"id_token" validate:"required"`
+    Client  ClientInfo `json:"-"`
+}
+
+type LoginWithProviderRequest struct {
+    Provider string `json:"-" validate:"required"`
+    IdToken  string `json:"id_token" validate:"required"`
+    Client   ClientInfo `json:"-"`
 }
 
 type RefreshRequest struct {
     RefreshToken string `json:"refresh_token" validate:"required"`
+    Client       ClientInfo `json:"-"`
+}
+
+type ResendVerificationEmailRequest struct {
+    Email string `json:"email" validate:"required,email"`
+}
+
+type VerifyEmailRequest struct {
+    Token string `json:"token" validate:"required"`
+}
+
+type RequestPasswordResetRequest struct {
+    Email string `json:"email" validate:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+    Token    string `json:"token" validate:"required"`
+    Password string `json:"password" validate:"required,min=8"`
+}
+
+type LogoutRequest struct {
+    RefreshToken string `json:"refresh_token" validate:"required"`
 }
 
 type CredentialData struct {
     Name  string `json:"name"`
     Email string `json:"email"`
     ImageURL *string `json:"image_url,omitempty"`
+    EmailVerified bool `json:"email_verified"`
 }
 
 type TokenData struct {
@@ -31,7 +69,215 @@ type TokenData struct {
     ExpiredAt int64 `json:"expired_at"`
 }
 
+type TwoFactorChallengeData struct {
+    ChallengeToken string `json:"challenge_token"`
+    ExpiredAt int64 `json:"expired_at"`
+}
+
 type LoginResponse struct {
     User  CredentialData `json:"user"`
-    Token TokenData      `json:"token"`
+    Token *TokenData     `json:"token,omitempty"`
+    TwoFactor *TwoFactorChallengeData `json:"two_factor,omitempty"`
+}
+
+type VerifyEmailResponse struct {
+    Email string `json:"email"`
 }
+
+type JWKData struct {
+    Kty string `json:"kty"`
+    Kid string `json:"kid"`
+    Use string `json:"use"`
+    Alg string `json:"alg"`
+    N string `json:"n,omitempty"`
+    E string `json:"e,omitempty"`
+    Crv string `json:"crv,omitempty"`
+    X string `json:"x,omitempty"`
+}
+
+type JWKSResponse struct {
+    Keys []JWKData `json:"keys"`
+}
+
+type MeData struct {
+    ID int64 `json:"id"`
+    Name string `json:"name"`
+    Email string `json:"email"`
+    ImageURL *string `json:"image_url,omitempty"`
+    EmailVerified bool `json:"email_verified"`
+    HasPassword bool `json:"has_password"`
+    TwoFactorEnabled bool `json:"two_factor_enabled"`
+    Role string `json:"role"`
+    Permissions []string `json:"permissions"`
+    OrganizationID *uint `json:"organization_id"`
+    OrganizationRole string `json:"organization_role,omitempty"`
+    CreatedAt int64 `json:"created_at"`
+}
+
+type UpdateMeRequest struct {
+    Name   string     `json:"name" validate:"required,max=255"`
+    Client ClientInfo `json:"-"`
+}
+
+// CurrentPassword is not needed by accounts that only sign in with an identity provider
+type ChangePasswordRequest struct {
+    CurrentPassword string     `json:"current_password"`
+    Password        string     `json:"password" validate:"required,min=8"`
+    Client          ClientInfo `json:"-"`
+}
+
+type RequestEmailChangeRequest struct {
+    Email           string     `json:"email" validate:"required,email,max=255"`
+    CurrentPassword string     `json:"current_password"`
+    Client          ClientInfo `json:"-"`
+}
+
+type ConfirmEmailChangeRequest struct {
+    Token string `json:"token" validate:"required"`
+}
+
+type PreferencesData struct {
+    DefaultModel string `json:"default_model"`
+    ResponseLanguage string `json:"response_language"`
+    Theme string `json:"theme"`
+    Timezone string `json:"timezone"`
+    MemoryEnabled bool `json:"memory_enabled"`
+    HistoryEnabled bool `json:"history_enabled"`
+}
+
+// Omitted fields are left unchanged, empty strings restore the default. The user
+// service validates the values.
+type UpdatePreferencesRequest struct {
+    DefaultModel     *string `json:"default_model"`
+    ResponseLanguage *string `json:"response_language"`
+    Theme            *string `json:"theme"`
+    Timezone         *string `json:"timezone"`
+    MemoryEnabled    *bool   `json:"memory_enabled"`
+    HistoryEnabled   *bool   `json:"history_enabled"`
+}
+
+type SessionData struct {
+    ID uint `json:"id"`
+    UserAgent string `json:"user_agent"`
+    IPAddress string `json:"ip_address"`
+    LastSeenAt int64 `json:"last_seen_at"`
+    CreatedAt int64 `json:"created_at"`
+    Current bool `json:"current"`
+}
+
+type GetSessionsResponse struct {
+    Sessions []SessionData `json:"sessions"`
+}
+
+type TotpCodeRequest struct {
+    Code string `json:"code" validate:"max=32"`
+}
+
+type CompleteTwoFactorLoginRequest struct {
+    ChallengeToken string `json:"challenge_token" validate:"required"`
+    Code           string `json:"code" validate:"required,max=32"`
+    Client         ClientInfo `json:"-"`
+}
+
+type EnrollTotpResponse struct {
+    Secret string `json:"secret"`
+    URI string `json:"uri"`
+}
+
+type ConfirmTotpResponse struct {
+    RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type LinkIdentityRequest struct {
+    Provider string `json:"-" validate:"required"`
+    IdToken  string `json:"id_token" validate:"required"`
+}
+
+type IdentityData struct {
+    Provider string `json:"provider"`
+    Email string `json:"email"`
+    CreatedAt int64 `json:"created_at"`
+}
+
+type GetIdentitiesResponse struct {
+    Identities []IdentityData `json:"identities"`
+    Providers []string `json:"providers"`
+}
+
+type CreateApiKeyRequest struct {
+    Name      string   `json:"name" validate:"required,max=100"`
+    Scopes    []string `json:"scopes" validate:"required,min=1"`
+    ExpiresAt int64    `json:"expires_at" validate:"gte=0"`
+}
+
+type UpdateApiKeyRequest struct {
+    Name   string   `json:"name" validate:"required,max=100"`
+    Scopes []string `json:"scopes" validate:"required,min=1"`
+}
+
+type ApiKeyData struct {
+    ID uint `json:"id"`
+    Name string `json:"name"`
+    Prefix string `json:"prefix"`
+    Scopes []string `json:"scopes"`
+    ExpiresAt *int64 `json:"expires_at"`
+    LastUsedAt *int64 `json:"last_used_at"`
+    CreatedAt int64 `json:"created_at"`
+}
+
+type CreateApiKeyResponse struct {
+    ApiKeyData
+    Key string `json:"key"`
+}
+
+type GetApiKeysResponse struct {
+    ApiKeys []ApiKeyData `json:"api_keys"`
+}
+
+type AccountExportData struct {
+    ID          uint   `json:"id"`
+    Status      string `json:"status"`
+    Size        int64  `json:"size"`
+    Error       string `json:"error,omitempty"`
+    CreatedAt   int64  `json:"created_at"`
+    CompletedAt *int64 `json:"completed_at"`
+    ExpiresAt   *int64 `json:"expires_at"`
+}
+
+type GetAccountExportsResponse struct {
+    Exports []AccountExportData `json:"exports"`
+}
+
+// AccountExportFile is a downloaded export archive
+type AccountExportFile struct {
+    Filename string
+    Content  []byte
+}
+
+type RequestAccountDeletionRequest struct {
+    CurrentPassword string     `json:"current_password"`
+    Client          ClientInfo `json:"-"`
+}
+
+type CancelAccountDeletionRequest struct {
+    Token string `json:"token" validate:"required"`
+}
+
+type AccountDeletionStepData struct {
+    Service     string `json:"service"`
+    Status      string `json:"status"`
+    Attempts    int32  `json:"attempts"`
+    LastError   string `json:"last_error,omitempty"`
+    CompletedAt *int64 `json:"completed_at"`
+}
+
+type AccountDeletionData struct {
+    ID          uint                      `json:"id"`
+    UserID      uint                      `json:"user_id"`
+    Status      string                    `json:"status"`
+    PurgeAfter  int64                     `json:"purge_after"`
+    CreatedAt   int64                     `json:"created_at"`
+    CancelledAt *int64                    `json:"cancelled_at"`
+    PurgedAt    *int64                    `json:"purged_at"`
+    Steps       []AccountDeletionStepData `json:"steps"`
+}
\ No newline at end of file
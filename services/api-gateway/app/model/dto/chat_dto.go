@@ -13,18 +13,45 @@ type GetChatRequest struct {
 
 type CreateMessageRequest struct {
     ChatID uint `json:"chat_id" validate:"omitempty"`
+    AssistantID uint `json:"assistant_id" validate:"omitempty"`
+    AssistantShareToken string `json:"assistant_share_token" validate:"omitempty,max=255"`
+    ProjectID uint `json:"project_id" validate:"omitempty"`
+    Ephemeral bool `json:"ephemeral"`
     Message string `json:"message" validate:"required"`
 }
 
+type CompareMessageRequest struct {
+    ChatID uint `json:"chat_id" validate:"omitempty"`
+    AssistantID uint `json:"assistant_id" validate:"omitempty"`
+    AssistantShareToken string `json:"assistant_share_token" validate:"omitempty,max=255"`
+    ProjectID uint `json:"project_id" validate:"omitempty"`
+    Message string `json:"message" validate:"required"`
+    Targets []CompareTarget `json:"targets" validate:"required,min=2,max=4,unique=Model,dive"`
+}
+
+type CompareTarget struct {
+    Provider string `json:"provider" validate:"required,oneof=gemini"`
+    Model string `json:"model" validate:"required,max=255"`
+}
+
+type MoveChatRequest struct {
+    ProjectID *uint `json:"project_id"`
+}
+
 type GetMessagesRequest struct {
     ChatID uint `json:"chat_id" validate:"required"`
     UserID uint `json:"user_id" validate:"required"`
+    OrganizationID uint `json:"organization_id" validate:"omitempty"`
 }
 
 type ChatData struct {
     ID uint `json:"id"`
     UserID uint `json:"user_id"`
     Topic string `json:"topic"`
+    AssistantID *uint `json:"assistant_id"`
+    ProjectID *uint `json:"project_id"`
+    OrganizationID *uint `json:"organization_id"`
+    Role string `json:"role"`
 } 
 
 type GetChatsResponse struct {
@@ -37,6 +64,9 @@ type MessageData struct {
     UserID uint `json:"user_id"`
     Role string `json:"role"`
     Content string `json:"content"`
+    Model string `json:"model,omitempty"`
+    CompareGroup string `json:"compare_group,omitempty"`
+    Active bool `json:"active"`
 }
 
 type GetMessagesResponse struct {
@@ -47,4 +77,36 @@ type MessagePart struct {
     ChatID uint `json:"chat_id"`
     Part string `json:"part"`
     Status proto.Status `json:"status"`
+    Ephemeral bool `json:"ephemeral,omitempty"`
+    Model string `json:"model,omitempty"`
+    CompareGroup string `json:"compare_group,omitempty"`
+    MessageID uint `json:"message_id,omitempty"`
+}
+
+type MessagePrompt struct {
+    ChatID uint `json:"chat_id"`
+    UserID uint `json:"user_id"`
+    Name string `json:"name"`
+    Message string `json:"message"`
+}
+
+type InviteChatMemberRequest struct {
+    Email string `json:"email" validate:"required,email"`
+    Role string `json:"role" validate:"required,oneof=viewer editor"`
+}
+
+type UpdateChatMemberRequest struct {
+    Role string `json:"role" validate:"required,oneof=viewer editor"`
+}
+
+type ChatMemberData struct {
+    ID uint `json:"id"`
+    ChatID uint `json:"chat_id"`
+    UserID uint `json:"user_id"`
+    Email string `json:"email"`
+    Role string `json:"role"`
 }
+
+type GetChatMembersResponse struct {
+    Members []ChatMemberData `json:"members"`
+}
\ No newline at end of file
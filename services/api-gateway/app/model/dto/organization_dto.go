@@ -0,0 +1,73 @@
+package dto
+
+type SwitchOrganizationRequest struct {
+    OrganizationID uint       `json:"organization_id"`
+    Client         ClientInfo `json:"-"`
+}
+
+type CreateOrganizationRequest struct {
+    Name string `json:"name" validate:"required,max=255"`
+}
+
+// UpdateOrganizationRequest leaves missing fields unchanged, a negative quota
+// removes the limit and an empty list of models allows every model
+type UpdateOrganizationRequest struct {
+    Name                    *string  `json:"name" validate:"omitempty,min=1,max=255"`
+    MemberDailyMessageQuota *int32   `json:"member_daily_message_quota"`
+    AllowedModels           []string `json:"allowed_models" validate:"omitempty,dive,required,max=255"`
+}
+
+type OrganizationData struct {
+    ID                      uint     `json:"id"`
+    Name                    string   `json:"name"`
+    MemberDailyMessageQuota *int32   `json:"member_daily_message_quota"`
+    AllowedModels           []string `json:"allowed_models"`
+    Role                    string   `json:"role"`
+    CreatedAt               int64    `json:"created_at"`
+}
+
+type GetOrganizationsResponse struct {
+    Organizations []OrganizationData `json:"organizations"`
+}
+
+type OrganizationMemberData struct {
+    UserID   uint   `json:"user_id"`
+    Name     string `json:"name"`
+    Email    string `json:"email"`
+    ImageURL string `json:"image_url"`
+    Role     string `json:"role"`
+    JoinedAt int64  `json:"joined_at"`
+}
+
+type GetOrganizationMembersResponse struct {
+    Members []OrganizationMemberData `json:"members"`
+}
+
+type UpdateOrganizationMemberRequest struct {
+    Role   string     `json:"role" validate:"required,oneof=owner admin member"`
+    Client ClientInfo `json:"-"`
+}
+
+type InviteOrganizationMemberRequest struct {
+    Email  string     `json:"email" validate:"required,email"`
+    Role   string     `json:"role" validate:"omitempty,oneof=admin member"`
+    Client ClientInfo `json:"-"`
+}
+
+type OrganizationInviteData struct {
+    ID               uint   `json:"id"`
+    OrganizationID   uint   `json:"organization_id"`
+    OrganizationName string `json:"organization_name"`
+    Email            string `json:"email"`
+    Role             string `json:"role"`
+    ExpiresAt        int64  `json:"expires_at"`
+    CreatedAt        int64  `json:"created_at"`
+}
+
+type GetOrganizationInvitesResponse struct {
+    Invites []OrganizationInviteData `json:"invites"`
+}
+
+type AcceptOrganizationInviteRequest struct {
+    Token string `json:"token" validate:"required"`
+}
\ No newline at end of file
@@ -0,0 +1,25 @@
+package dto
+
+type ProjectFile struct {
+    ID      uint   `json:"id,omitempty"`
+    Name    string `json:"name" validate:"required,max=255"`
+    Content string `json:"content" validate:"required"`
+}
+
+type ProjectRequest struct {
+    Name         string        `json:"name" validate:"required,max=255"`
+    Instructions string        `json:"instructions" validate:"omitempty"`
+    Files        []ProjectFile `json:"files" validate:"omitempty,dive"`
+}
+
+type ProjectData struct {
+    ID           uint          `json:"id"`
+    UserID       uint          `json:"user_id"`
+    Name         string        `json:"name"`
+    Instructions string        `json:"instructions"`
+    Files        []ProjectFile `json:"files"`
+}
+
+type GetProjectsResponse struct {
+    Projects []ProjectData `json:"projects"`
+}
@@ -0,0 +1,18 @@
+package dto
+
+type PromptTemplateRequest struct {
+    Name    string `json:"name" validate:"required,max=255"`
+    Content string `json:"content" validate:"required"`
+}
+
+type PromptTemplateData struct {
+    ID             uint   `json:"id"`
+    UserID         uint   `json:"user_id"`
+    OrganizationID *uint  `json:"organization_id"`
+    Name           string `json:"name"`
+    Content        string `json:"content"`
+}
+
+type GetPromptTemplatesResponse struct {
+    PromptTemplates []PromptTemplateData `json:"prompt_templates"`
+}
\ No newline at end of file
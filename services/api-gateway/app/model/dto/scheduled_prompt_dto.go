@@ -0,0 +1,46 @@
+package dto
+
+import "time"
+
+type ScheduledPromptRequest struct {
+    Name           string `json:"name" validate:"required,max=255"`
+    Prompt         string `json:"prompt" validate:"required"`
+    CronExpression string `json:"cron_expression" validate:"required,max=255"`
+    Timezone       string `json:"timezone" validate:"omitempty,max=64"`
+    ChatID         *uint  `json:"chat_id" validate:"omitempty"`
+    CatchUpPolicy  string `json:"catch_up_policy" validate:"omitempty,oneof=skip run_once"`
+    Enabled        *bool  `json:"enabled" validate:"omitempty"`
+}
+
+type ScheduledPromptData struct {
+    ID             uint       `json:"id"`
+    UserID         uint       `json:"user_id"`
+    Name           string     `json:"name"`
+    Prompt         string     `json:"prompt"`
+    CronExpression string     `json:"cron_expression"`
+    Timezone       string     `json:"timezone"`
+    ChatID         *uint      `json:"chat_id"`
+    CatchUpPolicy  string     `json:"catch_up_policy"`
+    Enabled        bool       `json:"enabled"`
+    NextRunAt      *time.Time `json:"next_run_at"`
+    LastRunAt      *time.Time `json:"last_run_at"`
+}
+
+type GetScheduledPromptsResponse struct {
+    ScheduledPrompts []ScheduledPromptData `json:"scheduled_prompts"`
+}
+
+type ScheduledPromptRunData struct {
+    ID                uint       `json:"id"`
+    ScheduledPromptID uint       `json:"scheduled_prompt_id"`
+    ChatID            *uint      `json:"chat_id"`
+    Status            string     `json:"status"`
+    ScheduledFor      *time.Time `json:"scheduled_for"`
+    StartedAt         *time.Time `json:"started_at"`
+    FinishedAt        *time.Time `json:"finished_at"`
+    Error             string     `json:"error,omitempty"`
+}
+
+type GetScheduledPromptRunsResponse struct {
+    Runs []ScheduledPromptRunData `json:"runs"`
+}
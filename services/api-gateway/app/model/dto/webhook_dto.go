@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+type WebhookRequest struct {
+    URL     string   `json:"url" validate:"required,url,startswith=https://,max=2048"`
+    Events  []string `json:"events" validate:"required,min=1,dive,oneof=chat.created message.completed chat.deleted feedback.created"`
+    Enabled *bool    `json:"enabled" validate:"omitempty"`
+}
+
+type WebhookData struct {
+    ID      uint     `json:"id"`
+    UserID  uint     `json:"user_id"`
+    URL     string   `json:"url"`
+    Secret  string   `json:"secret"`
+    Events  []string `json:"events"`
+    Enabled bool     `json:"enabled"`
+}
+
+type GetWebhooksResponse struct {
+    Webhooks []WebhookData `json:"webhooks"`
+}
+
+type WebhookDeliveryData struct {
+    ID             uint       `json:"id"`
+    WebhookID      uint       `json:"webhook_id"`
+    Event          string     `json:"event"`
+    Payload        string     `json:"payload"`
+    Status         string     `json:"status"`
+    Attempts       int32      `json:"attempts"`
+    ResponseStatus int32      `json:"response_status,omitempty"`
+    Error          string     `json:"error,omitempty"`
+    NextAttemptAt  *time.Time `json:"next_attempt_at"`
+    DeliveredAt    *time.Time `json:"delivered_at"`
+    CreatedAt      *time.Time `json:"created_at"`
+}
+
+type GetWebhookDeliveriesResponse struct {
+    Deliveries []WebhookDeliveryData `json:"deliveries"`
+}
@@ -3,6 +3,7 @@ package route
 import (
 	"api-gateway/app/delivery/http"
 	"api-gateway/app/delivery/http/middleware"
+	"api-gateway/app/usecase"
 
 	socketio "github.com/doquangtan/gofiber-socket.io"
 	"github.com/gofiber/fiber/v2"
@@ -17,9 +18,25 @@ type HttpRouter struct {
 func NewHttpRouter(
 	router *fiber.App, 
 	auth *middleware.AuthMiddleware,
+	authorization *middleware.AuthorizationMiddleware,
 	websocket *middleware.WebsocketMiddleware,
 	authController *http.AuthController,
 	chatController *http.ChatController,
+	assistantController *http.AssistantController,
+	projectController *http.ProjectController,
+	chatMemberController *http.ChatMemberController,
+	scheduledPromptController *http.ScheduledPromptController,
+	webhookController *http.WebhookController,
+	sessionController *http.SessionController,
+	twoFactorController *http.TwoFactorController,
+	identityController *http.IdentityController,
+	apiKeyController *http.ApiKeyController,
+	profileController *http.ProfileController,
+	adminController *http.AdminController,
+	accountController *http.AccountController,
+	organizationController *http.OrganizationController,
+	promptTemplateController *http.PromptTemplateController,
+	avatars *usecase.AvatarStore,
 ) *HttpRouter {
 	// Middleware
 	logFormat := `{"time": "${time}", "status": "${status}", "latency": "${latency}", "ip": "${ip}", "method": "${method}", "path": "${path}", "error": "${error}"}` + "\n"
@@ -27,13 +44,119 @@ func NewHttpRouter(
 	router.Use(cors.New())
 
 	// Routes
+	router.Get("/.well-known/jwks.json", authController.JWKS)
+	router.Static(avatars.Path(), avatars.Dir())
+
 	router.Post("/auth/register", authController.Register)
 	router.Post("/auth/login/google", authController.LoginWithGoogle)
 	router.Post("/auth/login", authController.Login)
+	router.Post("/auth/login/:provider", authController.LoginWithProvider)
 	router.Post("/auth/refresh", authController.RefreshToken)
+	router.Post("/auth/logout", authController.Logout)
+	router.Post("/auth/logout-all", auth.Handler, authController.LogoutAll)
+	router.Post("/auth/email/verify", authController.VerifyEmail)
+	router.Post("/auth/email/resend", authController.ResendVerificationEmail)
+	router.Post("/auth/password/forgot", authController.ForgotPassword)
+	router.Post("/auth/password/reset", authController.ResetPassword)
+	router.Post("/auth/email/change/confirm", profileController.ConfirmEmailChange)
+	router.Post("/auth/account/deletion/cancel", accountController.CancelDeletion)
+	router.Post("/auth/2fa/challenge", twoFactorController.CompleteChallenge)
+	router.Post("/auth/2fa/totp/enroll", auth.Handler, twoFactorController.EnrollTotp)
+	router.Post("/auth/2fa/totp/confirm", auth.Handler, twoFactorController.ConfirmTotp)
+	router.Post("/auth/2fa/totp/disable", auth.Handler, twoFactorController.DisableTotp)
+
+	router.Get("/me", auth.Handler, profileController.GetMe)
+	router.Patch("/me", auth.Handler, profileController.UpdateMe)
+	router.Delete("/me", auth.Handler, accountController.RequestDeletion)
+	router.Put("/me/password", auth.Handler, profileController.ChangePassword)
+	router.Post("/me/email", auth.Handler, profileController.RequestEmailChange)
+	router.Put("/me/avatar", auth.Handler, profileController.UpdateAvatar)
+	router.Delete("/me/avatar", auth.Handler, profileController.DeleteAvatar)
+	router.Get("/me/preferences", auth.Handler, profileController.GetPreferences)
+	router.Patch("/me/preferences", auth.Handler, profileController.UpdatePreferences)
+	router.Get("/me/exports", auth.Handler, accountController.GetExports)
+	router.Post("/me/exports", auth.Handler, accountController.RequestExport)
+	router.Get("/me/exports/:export_id/download", auth.Handler, accountController.DownloadExport)
+	router.Get("/me/sessions", auth.Handler, sessionController.GetSessions)
+	router.Delete("/me/sessions/:session_id", auth.Handler, sessionController.RevokeSession)
+	router.Get("/me/identities", auth.Handler, identityController.GetIdentities)
+	router.Post("/me/identities/:provider", auth.Handler, identityController.LinkIdentity)
+	router.Delete("/me/identities/:provider", auth.Handler, identityController.UnlinkIdentity)
+	router.Put("/me/organization", auth.Handler, organizationController.SwitchOrganization)
+	router.Get("/me/api-keys", auth.Handler, apiKeyController.GetApiKeys)
+	router.Post("/me/api-keys", auth.Handler, apiKeyController.CreateApiKey)
+	router.Get("/me/api-keys/:api_key_id", auth.Handler, apiKeyController.GetApiKey)
+	router.Put("/me/api-keys/:api_key_id", auth.Handler, apiKeyController.UpdateApiKey)
+	router.Delete("/me/api-keys/:api_key_id", auth.Handler, apiKeyController.DeleteApiKey)
 
 	router.Get("/chats", auth.Handler, chatController.GetChats)
 	router.Get("/chats/:chat_id/messages", auth.Handler, chatController.GetMessages)
+	router.Put("/chats/:chat_id/messages/:message_id/prefer", auth.Handler, chatController.PreferMessage)
+	router.Delete("/chats/:chat_id", auth.Handler, chatController.DeleteChat)
+	router.Put("/chats/:chat_id/project", auth.Handler, chatController.MoveChat)
+	router.Get("/chats/:chat_id/members", auth.Handler, chatMemberController.GetChatMembers)
+	router.Post("/chats/:chat_id/members", auth.Handler, chatMemberController.InviteChatMember)
+	router.Put("/chats/:chat_id/members/:member_id", auth.Handler, chatMemberController.UpdateChatMember)
+	router.Delete("/chats/:chat_id/members/:member_id", auth.Handler, chatMemberController.RemoveChatMember)
+
+	router.Get("/assistants", auth.Handler, assistantController.GetAssistants)
+	router.Post("/assistants", auth.Handler, assistantController.CreateAssistant)
+	router.Get("/assistants/shared/:share_token", auth.Handler, assistantController.GetSharedAssistant)
+	router.Get("/assistants/:assistant_id", auth.Handler, assistantController.GetAssistant)
+	router.Put("/assistants/:assistant_id", auth.Handler, assistantController.UpdateAssistant)
+	router.Delete("/assistants/:assistant_id", auth.Handler, assistantController.DeleteAssistant)
+	router.Post("/assistants/:assistant_id/share", auth.Handler, assistantController.ShareAssistant)
+
+	router.Get("/projects", auth.Handler, projectController.GetProjects)
+	router.Post("/projects", auth.Handler, projectController.CreateProject)
+	router.Get("/projects/:project_id", auth.Handler, projectController.GetProject)
+	router.Put("/projects/:project_id", auth.Handler, projectController.UpdateProject)
+	router.Delete("/projects/:project_id", auth.Handler, projectController.DeleteProject)
+	router.Get("/projects/:project_id/chats", auth.Handler, chatController.GetProjectChats)
+
+	router.Get("/prompt-templates", auth.Handler, promptTemplateController.GetPromptTemplates)
+	router.Post("/prompt-templates", auth.Handler, promptTemplateController.CreatePromptTemplate)
+	router.Put("/prompt-templates/:prompt_template_id", auth.Handler, promptTemplateController.UpdatePromptTemplate)
+	router.Delete("/prompt-templates/:prompt_template_id", auth.Handler, promptTemplateController.DeletePromptTemplate)
+
+	router.Get("/organizations", auth.Handler, organizationController.GetOrganizations)
+	router.Post("/organizations", auth.Handler, organizationController.CreateOrganization)
+	router.Post("/organizations/invites/accept", auth.Handler, organizationController.AcceptOrganizationInvite)
+	router.Get("/organizations/:organization_id", auth.Handler, organizationController.GetOrganization)
+	router.Put("/organizations/:organization_id", auth.Handler, organizationController.UpdateOrganization)
+	router.Delete("/organizations/:organization_id", auth.Handler, organizationController.DeleteOrganization)
+	router.Get("/organizations/:organization_id/members", auth.Handler, organizationController.GetOrganizationMembers)
+	router.Put("/organizations/:organization_id/members/:user_id", auth.Handler, organizationController.UpdateOrganizationMember)
+	router.Delete("/organizations/:organization_id/members/:user_id", auth.Handler, organizationController.RemoveOrganizationMember)
+	router.Get("/organizations/:organization_id/invites", auth.Handler, organizationController.GetOrganizationInvites)
+	router.Post("/organizations/:organization_id/invites", auth.Handler, organizationController.InviteOrganizationMember)
+	router.Delete("/organizations/:organization_id/invites/:invite_id", auth.Handler, organizationController.RevokeOrganizationInvite)
+
+	router.Get("/scheduled-prompts", auth.Handler, scheduledPromptController.GetScheduledPrompts)
+	router.Post("/scheduled-prompts", auth.Handler, scheduledPromptController.CreateScheduledPrompt)
+	router.Get("/scheduled-prompts/:scheduled_prompt_id", auth.Handler, scheduledPromptController.GetScheduledPrompt)
+	router.Put("/scheduled-prompts/:scheduled_prompt_id", auth.Handler, scheduledPromptController.UpdateScheduledPrompt)
+	router.Delete("/scheduled-prompts/:scheduled_prompt_id", auth.Handler, scheduledPromptController.DeleteScheduledPrompt)
+	router.Get("/scheduled-prompts/:scheduled_prompt_id/runs", auth.Handler, scheduledPromptController.GetScheduledPromptRuns)
+
+	router.Get("/webhooks", auth.Handler, webhookController.GetWebhooks)
+	router.Post("/webhooks", auth.Handler, webhookController.CreateWebhook)
+	router.Get("/webhooks/:webhook_id", auth.Handler, webhookController.GetWebhook)
+	router.Put("/webhooks/:webhook_id", auth.Handler, webhookController.UpdateWebhook)
+	router.Delete("/webhooks/:webhook_id", auth.Handler, webhookController.DeleteWebhook)
+	router.Get("/webhooks/:webhook_id/deliveries", auth.Handler, webhookController.GetWebhookDeliveries)
+	router.Post("/webhooks/:webhook_id/deliveries/:delivery_id/redeliver", auth.Handler, webhookController.RedeliverWebhookDelivery)
+
+	router.Get("/admin/users", auth.Handler, authorization.Require(middleware.PermissionUsersRead), adminController.GetUsers)
+	router.Put("/admin/users/:user_id/suspension", auth.Handler, authorization.Require(middleware.PermissionUsersSuspend), adminController.SuspendUser)
+	router.Post("/admin/users/:user_id/unlock", auth.Handler, authorization.Require(middleware.PermissionUsersSuspend), adminController.UnlockUser)
+	router.Put("/admin/users/:user_id/role", auth.Handler, authorization.Require(middleware.PermissionUsersRoles), adminController.UpdateUserRole)
+	router.Get("/admin/account-deletions", auth.Handler, authorization.Require(middleware.PermissionUsersRead), adminController.GetAccountDeletions)
+	router.Get("/admin/reports/usage", auth.Handler, authorization.Require(middleware.PermissionReportsRead), adminController.GetUsageReport)
+	router.Get("/admin/reports/feedback", auth.Handler, authorization.Require(middleware.PermissionReportsRead), adminController.GetFeedbackReport)
+	router.Get("/admin/audit-logs", auth.Handler, authorization.Require(middleware.PermissionAuditRead), adminController.GetAuditLogs)
+	router.Get("/admin/audit-logs/export", auth.Handler, authorization.Require(middleware.PermissionAuditRead), adminController.ExportAuditLogs)
+	router.Get("/admin/audit-logs/verify", auth.Handler, authorization.Require(middleware.PermissionAuditRead), adminController.VerifyAuditLogs)
 
 	// Websocket
 	socketIoRoute(router, websocket, chatController)
@@ -54,6 +177,8 @@ func socketIoRoute(router *fiber.App, middleware *middleware.WebsocketMiddleware
 		// middleware.Handler(socket)
 		socket.On("join_chat_room", chatController.JoinChatRoom)
 		socket.On("create_message", chatController.CreateMessage)
+		socket.On("compare_message", chatController.CompareMessage)
+		socket.On("disconnect", chatController.Disconnect)
 	})
 
 	router.Use("/", io.Middleware)
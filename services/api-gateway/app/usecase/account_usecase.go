@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"api-gateway/app/delivery/client"
+	"api-gateway/app/helper"
+	"api-gateway/app/model"
+	"api-gateway/app/model/dto"
+	"api-gateway/app/model/proto"
+	"bytes"
+	"context"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+type AccountUseCase interface {
+	RequestExport(ctx context.Context, clientInfo dto.ClientInfo, accessToken string) (*dto.AccountExportData, error)
+	GetExports(ctx context.Context, accessToken string) (*dto.GetAccountExportsResponse, error)
+	DownloadExport(ctx context.Context, exportID *string, accessToken string) (*dto.AccountExportFile, error)
+	RequestDeletion(ctx context.Context, req *dto.RequestAccountDeletionRequest, accessToken string) (*dto.AccountDeletionData, error)
+	CancelDeletion(ctx context.Context, req *dto.CancelAccountDeletionRequest) (*dto.AccountDeletionData, error)
+}
+
+type AccountUseCaseImpl struct {
+	Validate *validator.Validate
+	Log      *logrus.Logger
+	Auth     *client.AuthClient
+}
+
+func NewAccountUseCaseImpl(
+	validate *validator.Validate,
+	log *logrus.Logger,
+	auth *client.AuthClient,
+) *AccountUseCaseImpl {
+	return &AccountUseCaseImpl{
+		Validate: validate,
+		Log:      log,
+		Auth:     auth,
+	}
+}
+
+func (u *AccountUseCaseImpl) RequestExport(ctx context.Context, clientInfo dto.ClientInfo, accessToken string) (*dto.AccountExportData, error) {
+	// Get response from auth service
+	requestCtx, cancelRequest := context.WithTimeout(ctx, 5 * time.Second)
+	defer cancelRequest()
+
+	res, err := u.Auth.Service.RequestAccountExport(requestCtx, &proto.RequestAccountExportRequest{
+		AccessToken: accessToken,
+		Client:      toClientInfo(clientInfo),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to request export")
+	}
+
+	export := toAccountExportData(res)
+	return &export, nil
+}
+
+func (u *AccountUseCaseImpl) GetExports(ctx context.Context, accessToken string) (*dto.GetAccountExportsResponse, error) {
+	// Get response from auth service
+	listCtx, cancelList := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelList()
+
+	res, err := u.Auth.Service.ListAccountExports(listCtx, &proto.ListAccountExportsRequest{
+		AccessToken: accessToken,
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to get exports")
+	}
+
+	exports := make([]dto.AccountExportData, 0)
+	for _, export := range res.GetExports() {
+		exports = append(exports, toAccountExportData(export))
+	}
+
+	return &dto.GetAccountExportsResponse{
+		Exports: exports,
+	}, nil
+}
+
+// DownloadExport reads the chunks of the archive from the auth service
+func (u *AccountUseCaseImpl) DownloadExport(ctx context.Context, exportID *string, accessToken string) (*dto.AccountExportFile, error) {
+	// Convert exportID to uint
+	id, err := strconv.ParseUint(*exportID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "Export ID must be a number", nil)
+	}
+
+	// Get response from auth service
+	downloadCtx, cancelDownload := context.WithTimeout(ctx, 60 * time.Second)
+	defer cancelDownload()
+
+	stream, err := u.Auth.Service.DownloadAccountExport(downloadCtx, &proto.DownloadAccountExportRequest{
+		AccessToken: accessToken,
+		Id:          int64(id),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to download export")
+	}
+
+	file := new(dto.AccountExportFile)
+	content := new(bytes.Buffer)
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, helper.GrpcError(err, "Failed to download export")
+		}
+
+		if chunk.GetFilename() != "" {
+			file.Filename = chunk.GetFilename()
+			content.Grow(int(chunk.GetSize()))
+		}
+		content.Write(chunk.GetContent())
+	}
+	file.Content = content.Bytes()
+
+	return file, nil
+}
+
+func (u *AccountUseCaseImpl) RequestDeletion(ctx context.Context, req *dto.RequestAccountDeletionRequest, accessToken string) (*dto.AccountDeletionData, error) {
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from auth service
+	deleteCtx, cancelDelete := context.WithTimeout(ctx, 30 * time.Second)
+	defer cancelDelete()
+
+	res, err := u.Auth.Service.RequestAccountDeletion(deleteCtx, &proto.RequestAccountDeletionRequest{
+		AccessToken:     accessToken,
+		CurrentPassword: req.CurrentPassword,
+		Client:          toClientInfo(req.Client),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to delete account")
+	}
+
+	deletion := toAccountDeletionData(res)
+	return &deletion, nil
+}
+
+func (u *AccountUseCaseImpl) CancelDeletion(ctx context.Context, req *dto.CancelAccountDeletionRequest) (*dto.AccountDeletionData, error) {
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from auth service
+	cancelCtx, cancelCancel := context.WithTimeout(ctx, 10 * time.Second)
+	defer cancelCancel()
+
+	res, err := u.Auth.Service.CancelAccountDeletion(cancelCtx, &proto.CancelAccountDeletionRequest{
+		Token: req.Token,
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to cancel account deletion")
+	}
+
+	deletion := toAccountDeletionData(res)
+	return &deletion, nil
+}
+
+func toAccountExportData(export *proto.AccountExport) dto.AccountExportData {
+	data := dto.AccountExportData{
+		ID:        uint(export.GetId()),
+		Status:    export.GetStatus(),
+		Size:      export.GetSize(),
+		Error:     export.GetError(),
+		CreatedAt: export.GetCreatedAt(),
+	}
+	if completedAt := export.GetCompletedAt(); completedAt > 0 {
+		data.CompletedAt = &completedAt
+	}
+	if expiresAt := export.GetExpiresAt(); expiresAt > 0 {
+		data.ExpiresAt = &expiresAt
+	}
+
+	return data
+}
+
+func toAccountDeletionData(deletion *proto.AccountDeletion) dto.AccountDeletionData {
+	data := dto.AccountDeletionData{
+		ID:         uint(deletion.GetId()),
+		UserID:     uint(deletion.GetUserId()),
+		Status:     deletion.GetStatus(),
+		PurgeAfter: deletion.GetPurgeAfter(),
+		CreatedAt:  deletion.GetCreatedAt(),
+		Steps:      make([]dto.AccountDeletionStepData, 0),
+	}
+	if cancelledAt := deletion.GetCancelledAt(); cancelledAt > 0 {
+		data.CancelledAt = &cancelledAt
+	}
+	if purgedAt := deletion.GetPurgedAt(); purgedAt > 0 {
+		data.PurgedAt = &purgedAt
+	}
+
+	for _, step := range deletion.GetSteps() {
+		stepData := dto.AccountDeletionStepData{
+			Service:   step.GetService(),
+			Status:    step.GetStatus(),
+			Attempts:  step.GetAttempts(),
+			LastError: step.GetLastError(),
+		}
+		if completedAt := step.GetCompletedAt(); completedAt > 0 {
+			stepData.CompletedAt = &completedAt
+		}
+		data.Steps = append(data.Steps, stepData)
+	}
+
+	return data
+}
\ No newline at end of file
@@ -0,0 +1,447 @@
+package usecase
+
+import (
+	"api-gateway/app/delivery/client"
+	"api-gateway/app/helper"
+	"api-gateway/app/model"
+	"api-gateway/app/model/dto"
+	"api-gateway/app/model/proto"
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+// The gateway only checks the permissions carried by the access token,
+// the auth, user and chat services check the current role again.
+type AdminUseCase interface {
+	GetUsers(ctx context.Context, req *dto.GetAdminUsersRequest, accessToken string) (*dto.GetAdminUsersResponse, error)
+	SuspendUser(ctx context.Context, userID *string, req *dto.SuspendUserRequest, accessToken string) (*dto.AdminUserData, error)
+	UpdateUserRole(ctx context.Context, userID *string, req *dto.UpdateUserRoleRequest, accessToken string) (*dto.AdminUserData, error)
+	UnlockUser(ctx context.Context, userID *string, req *dto.UnlockUserRequest, accessToken string) (*dto.AdminUserData, error)
+	GetUsageReport(ctx context.Context, req *dto.ReportRequest, userID *uint, accessToken string) (*dto.UsageReportResponse, error)
+	GetFeedbackReport(ctx context.Context, req *dto.ReportRequest, userID *uint) (*dto.FeedbackReportResponse, error)
+	GetAuditLogs(ctx context.Context, req *dto.GetAuditLogsRequest, accessToken string) (*dto.GetAuditLogsResponse, error)
+	ExportAuditLogs(ctx context.Context, req *dto.ExportAuditLogsRequest, accessToken string) ([]dto.AuditLogData, error)
+	VerifyAuditLogs(ctx context.Context, accessToken string) (*dto.VerifyAuditLogsResponse, error)
+	GetAccountDeletions(ctx context.Context, req *dto.GetAccountDeletionsRequest, accessToken string) (*dto.GetAccountDeletionsResponse, error)
+}
+
+const (
+	// auditExportPageSize is how many entries an export reads from the auth service at once
+	auditExportPageSize = 500
+	// auditExportLimit keeps exports in memory, larger ones have to be narrowed by filters
+	auditExportLimit = 100000
+)
+
+type AdminUseCaseImpl struct {
+	Validate *validator.Validate
+	Log      *logrus.Logger
+	Auth     *client.AuthClient
+	Chat     *client.ChatClient
+}
+
+func NewAdminUseCaseImpl(
+	validate *validator.Validate,
+	log *logrus.Logger,
+	auth *client.AuthClient,
+	chat *client.ChatClient,
+) *AdminUseCaseImpl {
+	return &AdminUseCaseImpl{
+		Validate: validate,
+		Log:      log,
+		Auth:     auth,
+		Chat:     chat,
+	}
+}
+
+func (u *AdminUseCaseImpl) GetUsers(ctx context.Context, req *dto.GetAdminUsersRequest, accessToken string) (*dto.GetAdminUsersResponse, error) {
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from auth service
+	listCtx, cancelList := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelList()
+
+	res, err := u.Auth.Service.AdminListUsers(listCtx, &proto.AdminListUsersRequest{
+		AccessToken: accessToken,
+		Query:       req.Query,
+		Page:        int32(req.Page),
+		PageSize:    int32(req.PageSize),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to get users")
+	}
+
+	users := make([]dto.AdminUserData, 0)
+	for _, user := range res.GetUsers() {
+		users = append(users, toAdminUserData(user))
+	}
+
+	return &dto.GetAdminUsersResponse{
+		Users:    users,
+		Total:    res.GetTotal(),
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, nil
+}
+
+func (u *AdminUseCaseImpl) SuspendUser(ctx context.Context, userID *string, req *dto.SuspendUserRequest, accessToken string) (*dto.AdminUserData, error) {
+	// Convert userID to uint
+	id, err := strconv.ParseUint(*userID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "User ID must be a number", nil)
+	}
+
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from auth service
+	suspendCtx, cancelSuspend := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelSuspend()
+
+	res, err := u.Auth.Service.AdminSuspendUser(suspendCtx, &proto.AdminSuspendUserRequest{
+		AccessToken: accessToken,
+		UserId:      int64(id),
+		Suspended:   *req.Suspended,
+		Client:      toClientInfo(req.Client),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to suspend user")
+	}
+
+	user := toAdminUserData(res)
+	return &user, nil
+}
+
+// UnlockUser lifts a lockout caused by failed logins, optionally for an IP address too
+func (u *AdminUseCaseImpl) UnlockUser(ctx context.Context, userID *string, req *dto.UnlockUserRequest, accessToken string) (*dto.AdminUserData, error) {
+	// Convert userID to uint
+	id, err := strconv.ParseUint(*userID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "User ID must be a number", nil)
+	}
+
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from auth service
+	unlockCtx, cancelUnlock := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelUnlock()
+
+	res, err := u.Auth.Service.AdminUnlockUser(unlockCtx, &proto.AdminUnlockUserRequest{
+		AccessToken: accessToken,
+		UserId:      int64(id),
+		IpAddress:   req.IPAddress,
+		Client:      toClientInfo(req.Client),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to unlock user")
+	}
+
+	user := toAdminUserData(res)
+	return &user, nil
+}
+
+func (u *AdminUseCaseImpl) UpdateUserRole(ctx context.Context, userID *string, req *dto.UpdateUserRoleRequest, accessToken string) (*dto.AdminUserData, error) {
+	// Convert userID to uint
+	id, err := strconv.ParseUint(*userID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "User ID must be a number", nil)
+	}
+
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from auth service
+	updateCtx, cancelUpdate := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelUpdate()
+
+	res, err := u.Auth.Service.AdminUpdateUserRole(updateCtx, &proto.AdminUpdateUserRoleRequest{
+		AccessToken: accessToken,
+		UserId:      int64(id),
+		Role:        req.Role,
+		Client:      toClientInfo(req.Client),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to update user role")
+	}
+
+	user := toAdminUserData(res)
+	return &user, nil
+}
+
+func (u *AdminUseCaseImpl) GetUsageReport(ctx context.Context, req *dto.ReportRequest, userID *uint, accessToken string) (*dto.UsageReportResponse, error) {
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	since := reportSince(req.Days)
+
+	// Get user stats from auth service
+	statsCtx, cancelStats := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelStats()
+
+	stats, err := u.Auth.Service.AdminGetUserStats(statsCtx, &proto.AdminGetUserStatsRequest{
+		AccessToken: accessToken,
+		Since:       since,
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to get usage report")
+	}
+
+	// Get message usage from chat service
+	usageCtx, cancelUsage := context.WithTimeout(ctx, 5 * time.Second)
+	defer cancelUsage()
+
+	usage, err := u.Chat.Service.GetUsageReport(usageCtx, &proto.GetReportRequest{
+		UserId: int64(*userID),
+		Since:  since,
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to get usage report")
+	}
+
+	res := &dto.UsageReportResponse{
+		Since: since,
+		Users: dto.UserStatsData{
+			Total:     stats.GetTotal(),
+			Verified:  stats.GetVerified(),
+			Suspended: stats.GetSuspended(),
+			Created:   stats.GetCreated(),
+		},
+		Messages:    usage.GetMessages(),
+		Prompts:     usage.GetPrompts(),
+		Replies:     usage.GetReplies(),
+		Chats:       usage.GetChats(),
+		ActiveUsers: usage.GetActiveUsers(),
+		Days:        make([]dto.UsageDayData, 0),
+		TopUsers:    make([]dto.UserUsageData, 0),
+	}
+	for _, day := range usage.GetDays() {
+		res.Days = append(res.Days, dto.UsageDayData{
+			Date:        day.GetDate(),
+			Messages:    day.GetMessages(),
+			ActiveUsers: day.GetActiveUsers(),
+		})
+	}
+	for _, user := range usage.GetTopUsers() {
+		res.TopUsers = append(res.TopUsers, dto.UserUsageData{
+			UserID:  uint(user.GetUserId()),
+			Prompts: user.GetPrompts(),
+		})
+	}
+
+	return res, nil
+}
+
+func (u *AdminUseCaseImpl) GetFeedbackReport(ctx context.Context, req *dto.ReportRequest, userID *uint) (*dto.FeedbackReportResponse, error) {
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	since := reportSince(req.Days)
+
+	// Get response from chat service
+	feedbackCtx, cancelFeedback := context.WithTimeout(ctx, 5 * time.Second)
+	defer cancelFeedback()
+
+	feedback, err := u.Chat.Service.GetFeedbackReport(feedbackCtx, &proto.GetReportRequest{
+		UserId: int64(*userID),
+		Since:  since,
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to get feedback report")
+	}
+
+	res := &dto.FeedbackReportResponse{
+		Since:       since,
+		Comparisons: feedback.GetComparisons(),
+		Preferences: feedback.GetPreferences(),
+		Models:      make([]dto.ModelFeedbackData, 0),
+	}
+	for _, modelFeedback := range feedback.GetModels() {
+		res.Models = append(res.Models, dto.ModelFeedbackData{
+			Model:     modelFeedback.GetModel(),
+			Shown:     modelFeedback.GetShown(),
+			Preferred: modelFeedback.GetPreferred(),
+		})
+	}
+
+	return res, nil
+}
+
+func (u *AdminUseCaseImpl) GetAuditLogs(ctx context.Context, req *dto.GetAuditLogsRequest, accessToken string) (*dto.GetAuditLogsResponse, error) {
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	res, err := u.listAuditLogs(ctx, &req.AuditLogFilter, req.Page, req.PageSize, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := make([]dto.AuditLogData, 0)
+	for _, log := range res.GetLogs() {
+		logs = append(logs, toAuditLogData(log))
+	}
+
+	return &dto.GetAuditLogsResponse{
+		Logs:     logs,
+		Total:    res.GetTotal(),
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, nil
+}
+
+// ExportAuditLogs reads every entry matching the filters, newest first
+func (u *AdminUseCaseImpl) ExportAuditLogs(ctx context.Context, req *dto.ExportAuditLogsRequest, accessToken string) ([]dto.AuditLogData, error) {
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	logs := make([]dto.AuditLogData, 0)
+	for page := 1; ; page++ {
+		res, err := u.listAuditLogs(ctx, &req.AuditLogFilter, page, auditExportPageSize, accessToken)
+		if err != nil {
+			return nil, err
+		}
+
+		if res.GetTotal() > auditExportLimit {
+			return nil, model.NewError(model.StatusBadRequest, "Exports are limited to "+strconv.Itoa(auditExportLimit)+" entries, narrow the filters", nil)
+		}
+
+		for _, log := range res.GetLogs() {
+			logs = append(logs, toAuditLogData(log))
+		}
+
+		if len(res.GetLogs()) < auditExportPageSize || int64(len(logs)) >= res.GetTotal() {
+			return logs, nil
+		}
+	}
+}
+
+func (u *AdminUseCaseImpl) VerifyAuditLogs(ctx context.Context, accessToken string) (*dto.VerifyAuditLogsResponse, error) {
+	// Get response from auth service, the whole chain is read
+	verifyCtx, cancelVerify := context.WithTimeout(ctx, 60 * time.Second)
+	defer cancelVerify()
+
+	res, err := u.Auth.Service.AdminVerifyAuditLogs(verifyCtx, &proto.AdminVerifyAuditLogsRequest{
+		AccessToken: accessToken,
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to verify audit logs")
+	}
+
+	return &dto.VerifyAuditLogsResponse{
+		Valid:    res.GetValid(),
+		Checked:  res.GetChecked(),
+		BrokenID: uint(res.GetBrokenId()),
+	}, nil
+}
+
+func (u *AdminUseCaseImpl) GetAccountDeletions(ctx context.Context, req *dto.GetAccountDeletionsRequest, accessToken string) (*dto.GetAccountDeletionsResponse, error) {
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from auth service
+	listCtx, cancelList := context.WithTimeout(ctx, 5 * time.Second)
+	defer cancelList()
+
+	res, err := u.Auth.Service.AdminListAccountDeletions(listCtx, &proto.AdminListAccountDeletionsRequest{
+		AccessToken: accessToken,
+		Status:      req.Status,
+		Page:        int32(req.Page),
+		PageSize:    int32(req.PageSize),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to get account deletions")
+	}
+
+	deletions := make([]dto.AccountDeletionData, 0)
+	for _, deletion := range res.GetDeletions() {
+		deletions = append(deletions, toAccountDeletionData(deletion))
+	}
+
+	return &dto.GetAccountDeletionsResponse{
+		Deletions: deletions,
+		Total:     res.GetTotal(),
+		Page:      req.Page,
+		PageSize:  req.PageSize,
+	}, nil
+}
+
+func (u *AdminUseCaseImpl) listAuditLogs(ctx context.Context, filter *dto.AuditLogFilter, page int, pageSize int, accessToken string) (*proto.AdminListAuditLogsResponse, error) {
+	// Get response from auth service
+	listCtx, cancelList := context.WithTimeout(ctx, 5 * time.Second)
+	defer cancelList()
+
+	res, err := u.Auth.Service.AdminListAuditLogs(listCtx, &proto.AdminListAuditLogsRequest{
+		AccessToken: accessToken,
+		ActorId:     int64(filter.ActorID),
+		Action:      filter.Action,
+		TargetType:  filter.TargetType,
+		TargetId:    filter.TargetID,
+		Since:       filter.Since,
+		Until:       filter.Until,
+		Page:        int32(page),
+		PageSize:    int32(pageSize),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to get audit logs")
+	}
+
+	return res, nil
+}
+
+// reportSince returns the start of the report window, midnight UTC days ago
+func reportSince(days int) int64 {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	return today.AddDate(0, 0, 1-days).Unix()
+}
+
+func toAdminUserData(user *proto.AdminUser) dto.AdminUserData {
+	return dto.AdminUserData{
+		ID:            uint(user.GetId()),
+		Name:          user.GetName(),
+		Email:         user.GetEmail(),
+		ImageURL:      user.GetImageUrl(),
+		Role:          user.GetRole(),
+		EmailVerified: user.GetEmailVerified(),
+		Suspended:     user.GetSuspended(),
+		CreatedAt:     user.GetCreatedAt(),
+	}
+}
+
+func toAuditLogData(log *proto.AuditLog) dto.AuditLogData {
+	return dto.AuditLogData{
+		ID:         uint(log.GetId()),
+		Action:     log.GetAction(),
+		ActorID:    uint(log.GetActorId()),
+		TargetType: log.GetTargetType(),
+		TargetID:   log.GetTargetId(),
+		IPAddress:  log.GetIpAddress(),
+		UserAgent:  log.GetUserAgent(),
+		Diff:       json.RawMessage(log.GetDiff()),
+		PrevHash:   log.GetPrevHash(),
+		Hash:       log.GetHash(),
+		CreatedAt:  log.GetCreatedAt(),
+	}
+}
\ No newline at end of file
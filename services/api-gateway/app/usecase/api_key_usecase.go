@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"api-gateway/app/delivery/client"
+	"api-gateway/app/helper"
+	"api-gateway/app/model"
+	"api-gateway/app/model/dto"
+	"api-gateway/app/model/proto"
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+type ApiKeyUseCase interface {
+	GetApiKeys(ctx context.Context, accessToken string) (*dto.GetApiKeysResponse, error)
+	GetApiKey(ctx context.Context, apiKeyID *string, accessToken string) (*dto.ApiKeyData, error)
+	CreateApiKey(ctx context.Context, req *dto.CreateApiKeyRequest, accessToken string) (*dto.CreateApiKeyResponse, error)
+	UpdateApiKey(ctx context.Context, apiKeyID *string, req *dto.UpdateApiKeyRequest, accessToken string) (*dto.ApiKeyData, error)
+	DeleteApiKey(ctx context.Context, apiKeyID *string, accessToken string) error
+}
+
+type ApiKeyUseCaseImpl struct {
+	Validate *validator.Validate
+	Log      *logrus.Logger
+	Auth     *client.AuthClient
+}
+
+func NewApiKeyUseCaseImpl(
+	validate *validator.Validate,
+	log *logrus.Logger,
+	auth *client.AuthClient,
+) *ApiKeyUseCaseImpl {
+	return &ApiKeyUseCaseImpl{
+		Validate: validate,
+		Log:      log,
+		Auth:     auth,
+	}
+}
+
+func (u *ApiKeyUseCaseImpl) GetApiKeys(ctx context.Context, accessToken string) (*dto.GetApiKeysResponse, error) {
+	// Get response from auth service
+	listCtx, cancelList := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelList()
+
+	res, err := u.Auth.Service.ListApiKeys(listCtx, &proto.ListApiKeysRequest{
+		AccessToken: accessToken,
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to get api keys")
+	}
+
+	apiKeys := make([]dto.ApiKeyData, 0)
+	for _, apiKey := range res.GetApiKeys() {
+		apiKeys = append(apiKeys, toApiKeyData(apiKey))
+	}
+
+	return &dto.GetApiKeysResponse{
+		ApiKeys: apiKeys,
+	}, nil
+}
+
+func (u *ApiKeyUseCaseImpl) GetApiKey(ctx context.Context, apiKeyID *string, accessToken string) (*dto.ApiKeyData, error) {
+	// Convert apiKeyID to uint
+	id, err := strconv.ParseUint(*apiKeyID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "Api key ID must be a number", nil)
+	}
+
+	// Get response from auth service
+	getCtx, cancelGet := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGet()
+
+	res, err := u.Auth.Service.GetApiKey(getCtx, &proto.GetApiKeyRequest{
+		AccessToken: accessToken,
+		Id:          int64(id),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to get api key")
+	}
+
+	apiKey := toApiKeyData(res)
+	return &apiKey, nil
+}
+
+func (u *ApiKeyUseCaseImpl) CreateApiKey(ctx context.Context, req *dto.CreateApiKeyRequest, accessToken string) (*dto.CreateApiKeyResponse, error) {
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from auth service
+	createCtx, cancelCreate := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelCreate()
+
+	res, err := u.Auth.Service.CreateApiKey(createCtx, &proto.CreateApiKeyRequest{
+		AccessToken: accessToken,
+		Name:        req.Name,
+		Scopes:      req.Scopes,
+		ExpiresAt:   req.ExpiresAt,
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to create api key")
+	}
+
+	return &dto.CreateApiKeyResponse{
+		ApiKeyData: toApiKeyData(res.GetApiKey()),
+		Key:        res.GetKey(),
+	}, nil
+}
+
+func (u *ApiKeyUseCaseImpl) UpdateApiKey(ctx context.Context, apiKeyID *string, req *dto.UpdateApiKeyRequest, accessToken string) (*dto.ApiKeyData, error) {
+	// Convert apiKeyID to uint
+	id, err := strconv.ParseUint(*apiKeyID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "Api key ID must be a number", nil)
+	}
+
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from auth service
+	updateCtx, cancelUpdate := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelUpdate()
+
+	res, err := u.Auth.Service.UpdateApiKey(updateCtx, &proto.UpdateApiKeyRequest{
+		AccessToken: accessToken,
+		Id:          int64(id),
+		Name:        req.Name,
+		Scopes:      req.Scopes,
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to update api key")
+	}
+
+	apiKey := toApiKeyData(res)
+	return &apiKey, nil
+}
+
+func (u *ApiKeyUseCaseImpl) DeleteApiKey(ctx context.Context, apiKeyID *string, accessToken string) error {
+	// Convert apiKeyID to uint
+	id, err := strconv.ParseUint(*apiKeyID, 10, 64)
+	if err != nil {
+		return model.NewError(model.StatusBadRequest, "Api key ID must be a number", nil)
+	}
+
+	// Get response from auth service
+	deleteCtx, cancelDelete := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelDelete()
+
+	_, err = u.Auth.Service.DeleteApiKey(deleteCtx, &proto.DeleteApiKeyRequest{
+		AccessToken: accessToken,
+		Id:          int64(id),
+	})
+	if err != nil {
+		return helper.GrpcError(err, "Failed to delete api key")
+	}
+
+	return nil
+}
+
+func toApiKeyData(apiKey *proto.ApiKey) dto.ApiKeyData {
+	data := dto.ApiKeyData{
+		ID:        uint(apiKey.GetId()),
+		Name:      apiKey.GetName(),
+		Prefix:    apiKey.GetPrefix(),
+		Scopes:    apiKey.GetScopes(),
+		CreatedAt: apiKey.GetCreatedAt(),
+	}
+	if data.Scopes == nil {
+		data.Scopes = make([]string, 0)
+	}
+	if expiresAt := apiKey.GetExpiresAt(); expiresAt > 0 {
+		data.ExpiresAt = &expiresAt
+	}
+	if lastUsedAt := apiKey.GetLastUsedAt(); lastUsedAt > 0 {
+		data.LastUsedAt = &lastUsedAt
+	}
+
+	return data
+}
\ No newline at end of file
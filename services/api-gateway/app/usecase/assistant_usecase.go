@@ -0,0 +1,265 @@
+package usecase
+
+import (
+	"api-gateway/app/delivery/client"
+	"api-gateway/app/helper"
+	"api-gateway/app/model"
+	"api-gateway/app/model/dto"
+	"api-gateway/app/model/proto"
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+type AssistantUseCase interface {
+	CreateAssistant(ctx context.Context, userID *uint, organizationID *uint, req *dto.AssistantRequest) (*dto.AssistantData, error)
+	GetAssistants(ctx context.Context, userID *uint, organizationID *uint) (*dto.GetAssistantsResponse, error)
+	GetAssistant(ctx context.Context, assistantID *string, userID *uint) (*dto.AssistantData, error)
+	UpdateAssistant(ctx context.Context, assistantID *string, userID *uint, req *dto.AssistantRequest) (*dto.AssistantData, error)
+	DeleteAssistant(ctx context.Context, assistantID *string, userID *uint) error
+	ShareAssistant(ctx context.Context, assistantID *string, userID *uint, req *dto.ShareAssistantRequest) (*dto.AssistantData, error)
+	GetSharedAssistant(ctx context.Context, shareToken *string) (*dto.AssistantData, error)
+}
+
+type AssistantUseCaseImpl struct {
+	Validate *validator.Validate
+	Log      *logrus.Logger
+	Chat     *client.ChatClient
+	Audit    *client.AuditRecorder
+}
+
+func NewAssistantUseCaseImpl(
+	validate *validator.Validate,
+	log *logrus.Logger,
+	chat *client.ChatClient,
+	audit *client.AuditRecorder,
+) *AssistantUseCaseImpl {
+	return &AssistantUseCaseImpl{
+		Validate: validate,
+		Log:      log,
+		Chat:     chat,
+		Audit:    audit,
+	}
+}
+
+func (u *AssistantUseCaseImpl) CreateAssistant(ctx context.Context, userID *uint, organizationID *uint, req *dto.AssistantRequest) (*dto.AssistantData, error) {
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from chat service
+	createCtx, cancelCreate := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelCreate()
+
+	res, err := u.Chat.Service.CreateAssistant(createCtx, &proto.CreateAssistantRequest{
+		UserId:          int64(*userID),
+		Name:            req.Name,
+		Description:     req.Description,
+		Instructions:    req.Instructions,
+		Model:           req.Model,
+		Temperature:     req.Temperature,
+		TopP:            req.TopP,
+		MaxOutputTokens: req.MaxOutputTokens,
+		Tools:           req.Tools,
+		Documents:       toAssistantDocumentProtos(req.Documents),
+		OrganizationId:  int64(*organizationID),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to create assistant")
+	}
+
+	return toAssistantData(res), nil
+}
+
+func (u *AssistantUseCaseImpl) GetAssistants(ctx context.Context, userID *uint, organizationID *uint) (*dto.GetAssistantsResponse, error) {
+	// Get response from chat service
+	getAssistantsCtx, cancelGetAssistants := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetAssistants()
+
+	res, err := u.Chat.Service.GetAssistants(getAssistantsCtx, &proto.GetAssistantsRequest{
+		UserId:         int64(*userID),
+		OrganizationId: int64(*organizationID),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to get assistants")
+	}
+
+	assistants := make([]dto.AssistantData, 0)
+	for _, assistant := range res.GetAssistants() {
+		assistants = append(assistants, *toAssistantData(assistant))
+	}
+
+	return &dto.GetAssistantsResponse{
+		Assistants: assistants,
+	}, nil
+}
+
+func (u *AssistantUseCaseImpl) GetAssistant(ctx context.Context, assistantID *string, userID *uint) (*dto.AssistantData, error) {
+	// Convert assistantID to uint
+	id, err := strconv.ParseUint(*assistantID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "Assistant ID must be a number", nil)
+	}
+
+	// Get response from chat service
+	getAssistantCtx, cancelGetAssistant := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetAssistant()
+
+	res, err := u.Chat.Service.GetAssistant(getAssistantCtx, &proto.GetAssistantRequest{
+		Id:     int64(id),
+		UserId: int64(*userID),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to get assistant")
+	}
+
+	return toAssistantData(res), nil
+}
+
+func (u *AssistantUseCaseImpl) UpdateAssistant(ctx context.Context, assistantID *string, userID *uint, req *dto.AssistantRequest) (*dto.AssistantData, error) {
+	// Convert assistantID to uint
+	id, err := strconv.ParseUint(*assistantID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "Assistant ID must be a number", nil)
+	}
+
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from chat service
+	updateCtx, cancelUpdate := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelUpdate()
+
+	res, err := u.Chat.Service.UpdateAssistant(updateCtx, &proto.UpdateAssistantRequest{
+		Id:              int64(id),
+		UserId:          int64(*userID),
+		Name:            req.Name,
+		Description:     req.Description,
+		Instructions:    req.Instructions,
+		Model:           req.Model,
+		Temperature:     req.Temperature,
+		TopP:            req.TopP,
+		MaxOutputTokens: req.MaxOutputTokens,
+		Tools:           req.Tools,
+		Documents:       toAssistantDocumentProtos(req.Documents),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to update assistant")
+	}
+
+	return toAssistantData(res), nil
+}
+
+func (u *AssistantUseCaseImpl) DeleteAssistant(ctx context.Context, assistantID *string, userID *uint) error {
+	// Convert assistantID to uint
+	id, err := strconv.ParseUint(*assistantID, 10, 64)
+	if err != nil {
+		return model.NewError(model.StatusBadRequest, "Assistant ID must be a number", nil)
+	}
+
+	// Get response from chat service
+	deleteCtx, cancelDelete := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelDelete()
+
+	_, err = u.Chat.Service.DeleteAssistant(deleteCtx, &proto.DeleteAssistantRequest{
+		Id:     int64(id),
+		UserId: int64(*userID),
+	})
+	if err != nil {
+		return helper.GrpcError(err, "Failed to delete assistant")
+	}
+
+	return nil
+}
+
+func (u *AssistantUseCaseImpl) ShareAssistant(ctx context.Context, assistantID *string, userID *uint, req *dto.ShareAssistantRequest) (*dto.AssistantData, error) {
+	// Convert assistantID to uint
+	id, err := strconv.ParseUint(*assistantID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "Assistant ID must be a number", nil)
+	}
+
+	// Get response from chat service
+	shareCtx, cancelShare := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelShare()
+
+	res, err := u.Chat.Service.ShareAssistant(shareCtx, &proto.ShareAssistantRequest{
+		Id:      int64(id),
+		UserId:  int64(*userID),
+		Enabled: req.Enabled,
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to share assistant")
+	}
+
+	u.Audit.Record(ctx, client.AuditActionAssistantShare, client.AuditTargetAssistant, *assistantID, map[string]any{
+		"shared": map[string]any{"new": req.Enabled},
+	})
+
+	return toAssistantData(res), nil
+}
+
+func (u *AssistantUseCaseImpl) GetSharedAssistant(ctx context.Context, shareToken *string) (*dto.AssistantData, error) {
+	// Get response from chat service
+	getSharedCtx, cancelGetShared := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetShared()
+
+	res, err := u.Chat.Service.GetSharedAssistant(getSharedCtx, &proto.GetSharedAssistantRequest{
+		ShareToken: *shareToken,
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to get assistant")
+	}
+
+	return toAssistantData(res), nil
+}
+
+func toAssistantDocumentProtos(documents []dto.AssistantDocument) []*proto.AssistantDocument {
+	res := make([]*proto.AssistantDocument, 0, len(documents))
+	for _, document := range documents {
+		res = append(res, &proto.AssistantDocument{
+			Name:    document.Name,
+			Content: document.Content,
+		})
+	}
+
+	return res
+}
+
+func toAssistantData(assistant *proto.Assistant) *dto.AssistantData {
+	documents := make([]dto.AssistantDocument, 0, len(assistant.GetDocuments()))
+	for _, document := range assistant.GetDocuments() {
+		documents = append(documents, dto.AssistantDocument{
+			ID:      uint(document.GetId()),
+			Name:    document.GetName(),
+			Content: document.GetContent(),
+		})
+	}
+
+	tools := assistant.GetTools()
+	if tools == nil {
+		tools = []string{}
+	}
+
+	return &dto.AssistantData{
+		ID:              uint(assistant.GetId()),
+		UserID:          uint(assistant.GetUserId()),
+		Name:            assistant.GetName(),
+		Description:     assistant.GetDescription(),
+		Instructions:    assistant.GetInstructions(),
+		Model:           assistant.GetModel(),
+		Temperature:     assistant.Temperature,
+		TopP:            assistant.TopP,
+		MaxOutputTokens: assistant.MaxOutputTokens,
+		Tools:           tools,
+		Documents:       documents,
+		ShareToken:      assistant.GetShareToken(),
+		OrganizationID:  optionalID(assistant.GetOrganizationId()),
+	}
+}
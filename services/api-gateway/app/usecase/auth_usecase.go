@@ -19,7 +19,15 @@ type AuthUseCase interface {
     Register(ctx context.Context, req *dto.RegisterRequest) (*dto.LoginResponse, error)
     Login(ctx context.Context, req *dto.LoginRequest) (*dto.LoginResponse, error)
     LoginWithGoogle(ctx context.Context, req *dto.LoginWIthGoogleRequest) (*dto.LoginResponse, error)
+	LoginWithProvider(ctx context.Context, req *dto.LoginWithProviderRequest) (*dto.LoginResponse, error)
 	Refresh(ctx context.Context, req *dto.RefreshRequest) (*dto.LoginResponse, error)
+	Logout(ctx context.Context, req *dto.LogoutRequest) error
+	LogoutAll(ctx context.Context, accessToken string) error
+	GetJWKS(ctx context.Context) (*dto.JWKSResponse, error)
+	ResendVerificationEmail(ctx context.Context, req *dto.ResendVerificationEmailRequest) error
+	VerifyEmail(ctx context.Context, req *dto.VerifyEmailRequest) (*dto.VerifyEmailResponse, error)
+	RequestPasswordReset(ctx context.Context, req *dto.RequestPasswordResetRequest) error
+	ResetPassword(ctx context.Context, req *dto.ResetPasswordRequest) error
 }
 
 type AuthUseCaseImpl struct {
@@ -54,6 +62,7 @@ func (a *AuthUseCaseImpl) Register(ctx context.Context, req *dto.RegisterRequest
 		Name:     req.Name,
 		Email:    req.Email,
 		Password: req.Password,
+		Client:   toClientInfo(req.Client),
 	})
 
 	if err != nil {
@@ -70,20 +79,7 @@ func (a *AuthUseCaseImpl) Register(ctx context.Context, req *dto.RegisterRequest
 		return nil, model.NewError(model.StatusInternalServerError, "Failed to register", nil)
 	}
 
-	imageURL := res.GetImageUrl()
-
-	return &dto.LoginResponse{
-		User: dto.CredentialData{
-			Name:     res.GetName(),
-			Email:    res.GetEmail(),
-			ImageURL: &imageURL,
-		},
-		Token: dto.TokenData{
-			AccessToken: res.GetToken().GetAccessToken(),
-			RefreshToken: res.GetToken().GetRefreshToken(),
-			ExpiredAt: res.GetToken().GetExpiredAt(),
-		},
-	}, nil
+	return toLoginResponse(res), nil
 }
 
 func (a *AuthUseCaseImpl) Login(ctx context.Context, req *dto.LoginRequest) (*dto.LoginResponse, error) {
@@ -99,36 +95,15 @@ func (a *AuthUseCaseImpl) Login(ctx context.Context, req *dto.LoginRequest) (*dt
 	res, err := a.Auth.Service.Login(loginCtx, &proto.LoginRequest{
 		Email:    req.Email,
 		Password: req.Password,
+		Client:   toClientInfo(req.Client),
 	})
 
+	// Unverified accounts are refused with a failed precondition
 	if err != nil {
-		if s, ok := status.FromError(err); ok {
-			if s.Code() == codes.InvalidArgument {
-				return nil, model.NewError(model.StatusBadRequest, s.Message(), nil)
-			} 
-			if s.Code() == codes.NotFound {
-				return nil, model.NewError(model.StatusNotFound, s.Message(), nil)
-			} 
-			return nil, model.NewError(model.StatusInternalServerError, s.Message(), nil)
-		}
-
-		return nil, model.NewError(model.StatusInternalServerError, "Failed to login", nil)
+		return nil, helper.GrpcError(err, "Failed to login")
 	}
 
-	imageURL := res.GetImageUrl()
-
-	return &dto.LoginResponse{
-		User: dto.CredentialData{
-			Name:     res.GetName(),
-			Email:    res.GetEmail(),
-			ImageURL: &imageURL,
-		},
-		Token: dto.TokenData{
-			AccessToken: res.GetToken().GetAccessToken(),
-			RefreshToken: res.GetToken().GetRefreshToken(),
-			ExpiredAt: res.GetToken().GetExpiredAt(),
-		},
-	}, nil
+	return toLoginResponse(res), nil
 }
 
 func (a *AuthUseCaseImpl) LoginWithGoogle(ctx context.Context, req *dto.LoginWIthGoogleRequest) (*dto.LoginResponse, error) {
@@ -143,39 +118,37 @@ func (a *AuthUseCaseImpl) LoginWithGoogle(ctx context.Context, req *dto.LoginWIt
 
 	res, err := a.Auth.Service.LoginWithGoogle(loginWithGoogleCtx, &proto.LoginWithGoogleRequest{
 		IdToken: req.IdToken,
+		Client:  toClientInfo(req.Client),
 	})
 
+	// Existing accounts that did not link the provider are refused with a failed precondition
 	if err != nil {
-		if s, ok := status.FromError(err); ok {
-			if s.Code() == codes.InvalidArgument {
-				return nil, model.NewError(model.StatusBadRequest, s.Message(), nil)
-			} 
-			if s.Code() == codes.NotFound {
-				return nil, model.NewError(model.StatusNotFound, s.Message(), nil)
-			} 
-			if s.Code() == codes.AlreadyExists {
-				return nil, model.NewError(model.StatusConflict, s.Message(), nil)
-			} 
-			return nil, model.NewError(model.StatusInternalServerError, s.Message(), nil)
-		}
+		return nil, helper.GrpcError(err, "Failed to login with google")
+	}
+
+	return toLoginResponse(res), nil
+}
 
-		return nil, model.NewError(model.StatusInternalServerError, "Failed to login with google", nil)
+func (a *AuthUseCaseImpl) LoginWithProvider(ctx context.Context, req *dto.LoginWithProviderRequest) (*dto.LoginResponse, error) {
+	// Validate request
+	if errors := helper.Validate(a.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
 	}
 
-	imageURL := res.GetImageUrl()
+	// Get response from auth service
+	loginWithProviderCtx, cancelLoginWithProvider := context.WithTimeout(ctx, 10 * time.Second)
+	defer cancelLoginWithProvider()
 
-	return &dto.LoginResponse{
-		User: dto.CredentialData{
-			Name:     res.GetName(),
-			Email:    res.GetEmail(),
-			ImageURL: &imageURL,
-		},
-		Token: dto.TokenData{
-			AccessToken: res.GetToken().GetAccessToken(),
-			RefreshToken: res.GetToken().GetRefreshToken(),
-			ExpiredAt: res.GetToken().GetExpiredAt(),
-		},
-	}, nil
+	res, err := a.Auth.Service.LoginWithProvider(loginWithProviderCtx, &proto.LoginWithProviderRequest{
+		Provider: req.Provider,
+		IdToken:  req.IdToken,
+		Client:   toClientInfo(req.Client),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to login with provider")
+	}
+
+	return toLoginResponse(res), nil
 }
 
 func (a *AuthUseCaseImpl) Refresh(ctx context.Context, req *dto.RefreshRequest) (*dto.LoginResponse, error) {
@@ -190,34 +163,199 @@ func (a *AuthUseCaseImpl) Refresh(ctx context.Context, req *dto.RefreshRequest)
 
 	res, err := a.Auth.Service.Refresh(refreshCtx, &proto.RefreshRequest{
 		RefreshToken: req.RefreshToken,
+		Client:       toClientInfo(req.Client),
 	})
 
+	// A replayed or revoked refresh token is reported as unauthorized
 	if err != nil {
-		if s, ok := status.FromError(err); ok {
-			if s.Code() == codes.InvalidArgument {
-				return nil, model.NewError(model.StatusBadRequest, s.Message(), nil)
-			} 
-			if s.Code() == codes.NotFound {
-				return nil, model.NewError(model.StatusNotFound, s.Message(), nil)
-			} 
-			return nil, model.NewError(model.StatusInternalServerError, s.Message(), nil)
-		}
+		return nil, helper.GrpcError(err, "Failed to refresh token")
+	}
+
+	return toLoginResponse(res), nil
+}
+
+func (a *AuthUseCaseImpl) Logout(ctx context.Context, req *dto.LogoutRequest) error {
+	// Validate request
+	if errors := helper.Validate(a.Validate, req); len(errors) > 0 {
+		return model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from auth service
+	logoutCtx, cancelLogout := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelLogout()
+
+	_, err := a.Auth.Service.Logout(logoutCtx, &proto.LogoutRequest{
+		RefreshToken: req.RefreshToken,
+	})
+	if err != nil {
+		return helper.GrpcError(err, "Failed to logout")
+	}
+
+	return nil
+}
+
+func (a *AuthUseCaseImpl) LogoutAll(ctx context.Context, accessToken string) error {
+	// Get response from auth service
+	logoutAllCtx, cancelLogoutAll := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelLogoutAll()
+
+	_, err := a.Auth.Service.LogoutAll(logoutAllCtx, &proto.LogoutAllRequest{
+		AccessToken: accessToken,
+	})
+	if err != nil {
+		return helper.GrpcError(err, "Failed to logout")
+	}
+
+	return nil
+}
+
+func (a *AuthUseCaseImpl) GetJWKS(ctx context.Context) (*dto.JWKSResponse, error) {
+	// Get response from auth service
+	getJwksCtx, cancelGetJwks := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetJwks()
+
+	res, err := a.Auth.Service.GetJwks(getJwksCtx, &proto.GetJwksRequest{})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to get signing keys")
+	}
+
+	keys := make([]dto.JWKData, 0)
+	for _, key := range res.GetKeys() {
+		keys = append(keys, dto.JWKData{
+			Kty: key.GetKty(),
+			Kid: key.GetKid(),
+			Use: key.GetUse(),
+			Alg: key.GetAlg(),
+			N:   key.GetN(),
+			E:   key.GetE(),
+			Crv: key.GetCrv(),
+			X:   key.GetX(),
+		})
+	}
+
+	return &dto.JWKSResponse{
+		Keys: keys,
+	}, nil
+}
+
+func (a *AuthUseCaseImpl) ResendVerificationEmail(ctx context.Context, req *dto.ResendVerificationEmailRequest) error {
+	// Validate request
+	if errors := helper.Validate(a.Validate, req); len(errors) > 0 {
+		return model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from auth service
+	resendCtx, cancelResend := context.WithTimeout(ctx, 15 * time.Second)
+	defer cancelResend()
+
+	_, err := a.Auth.Service.ResendVerificationEmail(resendCtx, &proto.ResendVerificationEmailRequest{
+		Email: req.Email,
+	})
+	if err != nil {
+		return helper.GrpcError(err, "Failed to send verification email")
+	}
+
+	return nil
+}
+
+func (a *AuthUseCaseImpl) VerifyEmail(ctx context.Context, req *dto.VerifyEmailRequest) (*dto.VerifyEmailResponse, error) {
+	// Validate request
+	if errors := helper.Validate(a.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from auth service
+	verifyCtx, cancelVerify := context.WithTimeout(ctx, 5 * time.Second)
+	defer cancelVerify()
+
+	res, err := a.Auth.Service.VerifyEmail(verifyCtx, &proto.VerifyEmailRequest{
+		Token: req.Token,
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to verify email")
+	}
+
+	return &dto.VerifyEmailResponse{
+		Email: res.GetEmail(),
+	}, nil
+}
+
+func (a *AuthUseCaseImpl) RequestPasswordReset(ctx context.Context, req *dto.RequestPasswordResetRequest) error {
+	// Validate request
+	if errors := helper.Validate(a.Validate, req); len(errors) > 0 {
+		return model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from auth service
+	requestCtx, cancelRequest := context.WithTimeout(ctx, 15 * time.Second)
+	defer cancelRequest()
+
+	_, err := a.Auth.Service.RequestPasswordReset(requestCtx, &proto.RequestPasswordResetRequest{
+		Email: req.Email,
+	})
+	if err != nil {
+		return helper.GrpcError(err, "Failed to send password reset email")
+	}
+
+	return nil
+}
+
+func (a *AuthUseCaseImpl) ResetPassword(ctx context.Context, req *dto.ResetPasswordRequest) error {
+	// Validate request
+	if errors := helper.Validate(a.Validate, req); len(errors) > 0 {
+		return model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
 
-		return nil, model.NewError(model.StatusInternalServerError, "Failed to refresh token", nil)
+	// Get response from auth service
+	resetCtx, cancelReset := context.WithTimeout(ctx, 5 * time.Second)
+	defer cancelReset()
+
+	_, err := a.Auth.Service.ResetPassword(resetCtx, &proto.ResetPasswordRequest{
+		Token:    req.Token,
+		Password: req.Password,
+	})
+	if err != nil {
+		return helper.GrpcError(err, "Failed to reset password")
 	}
 
+	return nil
+}
+
+// toLoginResponse converts an authenticated response, the token is missing
+// when the account still has to verify its email.
+func toLoginResponse(res *proto.AuthenticatedResponse) *dto.LoginResponse {
 	imageURL := res.GetImageUrl()
 
-	return &dto.LoginResponse{
+	response := &dto.LoginResponse{
 		User: dto.CredentialData{
 			Name:     res.GetName(),
 			Email:    res.GetEmail(),
 			ImageURL: &imageURL,
+			EmailVerified: res.GetEmailVerified(),
 		},
-		Token: dto.TokenData{
+	}
+
+	if res.GetToken() != nil {
+		response.Token = &dto.TokenData{
 			AccessToken: res.GetToken().GetAccessToken(),
 			RefreshToken: res.GetToken().GetRefreshToken(),
 			ExpiredAt: res.GetToken().GetExpiredAt(),
-		},
-	}, nil
-}
\ No newline at end of file
+		}
+	}
+
+	if res.GetTwoFactorRequired() {
+		response.TwoFactor = &dto.TwoFactorChallengeData{
+			ChallengeToken: res.GetChallengeToken(),
+			ExpiredAt: res.GetChallengeExpiredAt(),
+		}
+	}
+
+	return response
+}
+
+func toClientInfo(client dto.ClientInfo) *proto.ClientInfo {
+	return &proto.ClientInfo{
+		UserAgent: client.UserAgent,
+		IpAddress: client.IPAddress,
+	}
+}
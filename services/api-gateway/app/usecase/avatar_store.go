@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+	"golang.org/x/image/draw"
+)
+
+// avatarMaxPixels refuses images that would take too much memory to decode
+const avatarMaxPixels = 40_000_000
+
+var ErrInvalidAvatar = errors.New("avatar must be a JPEG, PNG or GIF image")
+
+// AvatarStore resizes uploaded avatars to a square PNG and keeps them in a local
+// directory that the gateway serves under the path of AVATAR_PUBLIC_URL.
+type AvatarStore struct {
+	dir       string
+	publicURL string
+	size      int
+	maxBytes  int64
+}
+
+func NewAvatarStore(config *viper.Viper) *AvatarStore {
+	dir := config.GetString("AVATAR_STORAGE_DIR")
+	if dir == "" {
+		dir = "storage/avatars"
+	}
+
+	publicURL := strings.TrimRight(config.GetString("AVATAR_PUBLIC_URL"), "/")
+	if publicURL == "" {
+		publicURL = "/avatars"
+	}
+
+	size := config.GetInt("AVATAR_SIZE")
+	if size <= 0 {
+		size = 256
+	}
+
+	maxBytes := config.GetInt64("AVATAR_MAX_BYTES")
+	if maxBytes <= 0 {
+		maxBytes = 2 << 20
+	}
+
+	return &AvatarStore{
+		dir:       dir,
+		publicURL: publicURL,
+		size:      size,
+		maxBytes:  maxBytes,
+	}
+}
+
+// Dir is the directory the avatars are stored in
+func (s *AvatarStore) Dir() string {
+	return s.dir
+}
+
+// Path is the URL path the avatars are served under
+func (s *AvatarStore) Path() string {
+	if u, err := url.Parse(s.publicURL); err == nil && u.Path != "" {
+		return u.Path
+	}
+
+	return "/avatars"
+}
+
+func (s *AvatarStore) MaxBytes() int64 {
+	return s.maxBytes
+}
+
+// Save decodes the upload, crops it to its center square and stores it resized.
+// It returns the public URL of the stored avatar.
+func (s *AvatarStore) Save(file io.ReadSeeker) (string, error) {
+	config, _, err := image.DecodeConfig(file)
+	if err != nil || config.Width <= 0 || config.Height <= 0 {
+		return "", ErrInvalidAvatar
+	}
+	if config.Width * config.Height > avatarMaxPixels {
+		return "", ErrInvalidAvatar
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	src, _, err := image.Decode(file)
+	if err != nil {
+		return "", ErrInvalidAvatar
+	}
+
+	bounds := src.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	x := bounds.Min.X + (bounds.Dx() - side) / 2
+	y := bounds.Min.Y + (bounds.Dy() - side) / 2
+
+	dst := image.NewRGBA(image.Rect(0, 0, s.size, s.size))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, image.Rect(x, y, x + side, y + side), draw.Src, nil)
+
+	name, err := avatarName()
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return "", err
+	}
+
+	// Write next to the target first so a half written file is never served
+	tmp, err := os.CreateTemp(s.dir, ".avatar-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := png.Encode(tmp, dst); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return "", err
+	}
+
+	if err := os.Rename(tmp.Name(), filepath.Join(s.dir, name)); err != nil {
+		return "", err
+	}
+
+	return s.publicURL + "/" + name, nil
+}
+
+// Remove deletes a stored avatar, URLs of avatars kept elsewhere are ignored
+func (s *AvatarStore) Remove(imageURL string) error {
+	name, ok := strings.CutPrefix(imageURL, s.publicURL + "/")
+	if !ok || name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return nil
+	}
+
+	if err := os.Remove(filepath.Join(s.dir, name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func avatarName() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b) + ".png", nil
+}
\ No newline at end of file
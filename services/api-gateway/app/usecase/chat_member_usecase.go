@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"api-gateway/app/delivery/client"
+	"api-gateway/app/helper"
+	"api-gateway/app/model"
+	"api-gateway/app/model/dto"
+	"api-gateway/app/model/proto"
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+type ChatMemberUseCase interface {
+	InviteChatMember(ctx context.Context, chatID *string, userID *uint, req *dto.InviteChatMemberRequest) (*dto.ChatMemberData, error)
+	GetChatMembers(ctx context.Context, chatID *string, userID *uint) (*dto.GetChatMembersResponse, error)
+	UpdateChatMember(ctx context.Context, chatID *string, memberID *string, userID *uint, req *dto.UpdateChatMemberRequest) (*dto.ChatMemberData, error)
+	RemoveChatMember(ctx context.Context, chatID *string, memberID *string, userID *uint) error
+}
+
+type ChatMemberUseCaseImpl struct {
+	Validate *validator.Validate
+	Log      *logrus.Logger
+	Chat     *client.ChatClient
+	Rooms    *ChatRoomRegistry
+	Audit    *client.AuditRecorder
+}
+
+func NewChatMemberUseCaseImpl(
+	validate *validator.Validate,
+	log *logrus.Logger,
+	chat *client.ChatClient,
+	rooms *ChatRoomRegistry,
+	audit *client.AuditRecorder,
+) *ChatMemberUseCaseImpl {
+	return &ChatMemberUseCaseImpl{
+		Validate: validate,
+		Log:      log,
+		Chat:     chat,
+		Rooms:    rooms,
+		Audit:    audit,
+	}
+}
+
+func (u *ChatMemberUseCaseImpl) InviteChatMember(ctx context.Context, chatID *string, userID *uint, req *dto.InviteChatMemberRequest) (*dto.ChatMemberData, error) {
+	// Convert chatID to uint
+	chatIDUint, err := strconv.ParseUint(*chatID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "Chat ID must be a number", nil)
+	}
+
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from chat service
+	inviteCtx, cancelInvite := context.WithTimeout(ctx, 5 * time.Second)
+	defer cancelInvite()
+
+	res, err := u.Chat.Service.InviteChatMember(inviteCtx, &proto.InviteChatMemberRequest{
+		ChatId: int64(chatIDUint),
+		UserId: int64(*userID),
+		Email:  req.Email,
+		Role:   req.Role,
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to invite member")
+	}
+
+	u.Audit.Record(ctx, client.AuditActionChatMemberInvite, client.AuditTargetChat, *chatID, map[string]any{
+		"member_id": map[string]any{"new": res.GetId()},
+		"email":     map[string]any{"new": res.GetEmail()},
+		"role":      map[string]any{"new": res.GetRole()},
+	})
+
+	return toChatMemberData(res), nil
+}
+
+func (u *ChatMemberUseCaseImpl) GetChatMembers(ctx context.Context, chatID *string, userID *uint) (*dto.GetChatMembersResponse, error) {
+	// Convert chatID to uint
+	chatIDUint, err := strconv.ParseUint(*chatID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "Chat ID must be a number", nil)
+	}
+
+	// Get response from chat service
+	getMembersCtx, cancelGetMembers := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetMembers()
+
+	res, err := u.Chat.Service.GetChatMembers(getMembersCtx, &proto.GetChatMembersRequest{
+		ChatId: int64(chatIDUint),
+		UserId: int64(*userID),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to get members")
+	}
+
+	members := make([]dto.ChatMemberData, 0)
+	for _, member := range res.GetMembers() {
+		members = append(members, *toChatMemberData(member))
+	}
+
+	return &dto.GetChatMembersResponse{
+		Members: members,
+	}, nil
+}
+
+func (u *ChatMemberUseCaseImpl) UpdateChatMember(ctx context.Context, chatID *string, memberID *string, userID *uint, req *dto.UpdateChatMemberRequest) (*dto.ChatMemberData, error) {
+	// Convert chatID and memberID to uint
+	chatIDUint, err := strconv.ParseUint(*chatID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "Chat ID must be a number", nil)
+	}
+
+	memberIDUint, err := strconv.ParseUint(*memberID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "Member ID must be a number", nil)
+	}
+
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from chat service
+	updateCtx, cancelUpdate := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelUpdate()
+
+	res, err := u.Chat.Service.UpdateChatMember(updateCtx, &proto.UpdateChatMemberRequest{
+		ChatId:   int64(chatIDUint),
+		UserId:   int64(*userID),
+		MemberId: int64(memberIDUint),
+		Role:     req.Role,
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to update member")
+	}
+
+	// Open sockets of the member re-join under the new role
+	u.Rooms.Evict(uint(chatIDUint), res.GetUserId())
+
+	u.Audit.Record(ctx, client.AuditActionChatMemberUpdate, client.AuditTargetChat, *chatID, map[string]any{
+		"member_id": res.GetId(),
+		"role":      map[string]any{"new": res.GetRole()},
+	})
+
+	return toChatMemberData(res), nil
+}
+
+func (u *ChatMemberUseCaseImpl) RemoveChatMember(ctx context.Context, chatID *string, memberID *string, userID *uint) error {
+	// Convert chatID and memberID to uint
+	chatIDUint, err := strconv.ParseUint(*chatID, 10, 64)
+	if err != nil {
+		return model.NewError(model.StatusBadRequest, "Chat ID must be a number", nil)
+	}
+
+	memberIDUint, err := strconv.ParseUint(*memberID, 10, 64)
+	if err != nil {
+		return model.NewError(model.StatusBadRequest, "Member ID must be a number", nil)
+	}
+
+	// Get response from chat service
+	removeCtx, cancelRemove := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelRemove()
+
+	_, err = u.Chat.Service.RemoveChatMember(removeCtx, &proto.RemoveChatMemberRequest{
+		ChatId:   int64(chatIDUint),
+		UserId:   int64(*userID),
+		MemberId: int64(memberIDUint),
+	})
+	if err != nil {
+		return helper.GrpcError(err, "Failed to remove member")
+	}
+
+	u.Rooms.Evict(uint(chatIDUint), int64(memberIDUint))
+
+	u.Audit.Record(ctx, client.AuditActionChatMemberRemove, client.AuditTargetChat, *chatID, map[string]any{
+		"member_id": map[string]any{"old": memberIDUint},
+	})
+
+	return nil
+}
+
+func toChatMemberData(member *proto.ChatMember) *dto.ChatMemberData {
+	return &dto.ChatMemberData{
+		ID:     uint(member.GetId()),
+		ChatID: uint(member.GetChatId()),
+		UserID: uint(member.GetUserId()),
+		Email:  member.GetEmail(),
+		Role:   member.GetRole(),
+	}
+}
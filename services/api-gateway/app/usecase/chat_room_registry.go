@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"fmt"
+	"sync"
+
+	socketio "github.com/doquangtan/gofiber-socket.io"
+)
+
+type chatRoomSocket struct {
+	socket         *socketio.Socket
+	userID         int64
+	organizationID int64
+}
+
+// ChatRoomRegistry records which user and organization every socket of a chat
+// room belongs to, so sockets can be evicted when the membership of their user
+// changes or the chat is deleted.
+type ChatRoomRegistry struct {
+	mu    sync.Mutex
+	rooms map[uint]map[string]chatRoomSocket
+}
+
+func NewChatRoomRegistry() *ChatRoomRegistry {
+	return &ChatRoomRegistry{
+		rooms: make(map[uint]map[string]chatRoomSocket),
+	}
+}
+
+// Join adds the socket to the room of the chat, callers must have authorized the
+// user first. The organization is the one the user works in, 0 for the personal workspace.
+func (r *ChatRoomRegistry) Join(chatID uint, userID int64, organizationID int64, socket *socketio.Socket) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	room := chatRoom(chatID)
+	socket.Join(room)
+
+	if r.rooms[chatID] == nil {
+		r.rooms[chatID] = make(map[string]chatRoomSocket)
+	}
+	r.rooms[chatID][socket.Id] = chatRoomSocket{socket: socket, userID: userID, organizationID: organizationID}
+
+	return room
+}
+
+// Evict removes the sockets of the user from the room of the chat, they have to
+// join again and pass the access check of the chat service
+func (r *ChatRoomRegistry) Evict(chatID uint, userID int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.evict(chatID, func(member chatRoomSocket) bool {
+		return member.userID == userID
+	})
+}
+
+// EvictOrganization removes the sockets the user opened in the organization from every room
+func (r *ChatRoomRegistry) EvictOrganization(organizationID int64, userID int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for chatID := range r.rooms {
+		r.evict(chatID, func(member chatRoomSocket) bool {
+			return member.userID == userID && member.organizationID == organizationID
+		})
+	}
+}
+
+// Drop removes every socket from the room of a deleted chat
+func (r *ChatRoomRegistry) Drop(chatID uint) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.evict(chatID, func(member chatRoomSocket) bool {
+		return true
+	})
+}
+
+// DropSocket forgets the socket in every room, sockets leave their rooms on disconnect
+func (r *ChatRoomRegistry) DropSocket(socketID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for chatID, sockets := range r.rooms {
+		delete(sockets, socketID)
+		if len(sockets) == 0 {
+			delete(r.rooms, chatID)
+		}
+	}
+}
+
+// evict makes the matching sockets leave the room of the chat, the caller holds the lock
+func (r *ChatRoomRegistry) evict(chatID uint, match func(member chatRoomSocket) bool) {
+	room := chatRoom(chatID)
+	for socketID, member := range r.rooms[chatID] {
+		if !match(member) {
+			continue
+		}
+
+		member.socket.Leave(room)
+		member.socket.Emit("left_chat_room", fmt.Sprintf("%d", chatID))
+		delete(r.rooms[chatID], socketID)
+	}
+
+	if len(r.rooms[chatID]) == 0 {
+		delete(r.rooms, chatID)
+	}
+}
+
+func chatRoom(chatID uint) string {
+	return fmt.Sprintf("chat_%d", chatID)
+}
\ No newline at end of file
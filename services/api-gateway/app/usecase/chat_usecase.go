@@ -22,42 +22,75 @@ import (
 )
 
 type ChatUseCase interface {
-	GetChats(ctx context.Context, userID *uint) (*dto.GetChatsResponse, error)
-	GetMessages(ctx context.Context, chatID *string, userID *uint) (*dto.GetMessagesResponse, error)
+	GetChats(ctx context.Context, userID *uint, organizationID *uint, projectID *string) (*dto.GetChatsResponse, error)
+	MoveChat(ctx context.Context, chatID *string, userID *uint, req *dto.MoveChatRequest) (*dto.ChatData, error)
+	DeleteChat(ctx context.Context, chatID *string, userID *uint, organizationID *uint) error
+	GetMessages(ctx context.Context, chatID *string, userID *uint, organizationID *uint) (*dto.GetMessagesResponse, error)
 	// InitChatConnection(kws *socketio.Websocket)
 	JoinChatRoom(ep *socketio.EventPayload)
 	CreateMessage(ep *socketio.EventPayload)
+	CompareMessage(ep *socketio.EventPayload)
+	PreferMessage(ctx context.Context, chatID *string, messageID *string, userID *uint, organizationID *uint) (*dto.MessageData, error)
+	Disconnect(ep *socketio.EventPayload)
 }
 
 type ChatUseCaseImpl struct {
     Validate        *validator.Validate
     Log             *logrus.Logger
 	Auth 			*client.AuthClient
+	Identity 		*client.IdentityVerifier
 	Chat 			*client.ChatClient
+	Sessions 		*EphemeralSessionStore
+	Rooms 			*ChatRoomRegistry
+	Audit 			*client.AuditRecorder
 }
 
 func NewChatUseCaseImpl(
 	validate *validator.Validate,
 	log *logrus.Logger,
 	auth *client.AuthClient,
+	identity *client.IdentityVerifier,
 	chat *client.ChatClient,
+	sessions *EphemeralSessionStore,
+	rooms *ChatRoomRegistry,
+	audit *client.AuditRecorder,
 ) *ChatUseCaseImpl {
 	return &ChatUseCaseImpl{
 		Validate: validate,
 		Log:      log,
 		Auth:     auth,
+		Identity: identity,
 		Chat:     chat,
+		Sessions: sessions,
+		Rooms:    rooms,
+		Audit:    audit,
 	}
 }
 
-func (u *ChatUseCaseImpl) GetChats(ctx context.Context, userID *uint) (*dto.GetChatsResponse, error) {
+func (u *ChatUseCaseImpl) GetChats(ctx context.Context, userID *uint, organizationID *uint, projectID *string) (*dto.GetChatsResponse, error) {
+	request := &proto.GetChatsRequest{
+		UserId:         int64(*userID),
+		OrganizationId: int64(*organizationID),
+	}
+
+	// Filter by project, "none" lists the chats outside any project
+	if projectID != nil && *projectID != "" {
+		var projectIDInt int64
+		if *projectID != "none" {
+			projectIDUint, err := strconv.ParseUint(*projectID, 10, 64)
+			if err != nil {
+				return nil, model.NewError(model.StatusBadRequest, "Project ID must be a number", nil)
+			}
+			projectIDInt = int64(projectIDUint)
+		}
+		request.ProjectId = &projectIDInt
+	}
+
 	// Get response from chat service
 	getChatsCtx, cancelGetChats := context.WithTimeout(ctx, 2 * time.Second)
 	defer cancelGetChats()
 
-	res, err := u.Chat.Service.GetChats(getChatsCtx, &proto.GetChatsRequest{
-		UserId: int64(*userID),
-	})
+	res, err := u.Chat.Service.GetChats(getChatsCtx, request)
 	
 	if err != nil {
 		if s, ok := status.FromError(err); ok {
@@ -75,11 +108,7 @@ func (u *ChatUseCaseImpl) GetChats(ctx context.Context, userID *uint) (*dto.GetC
 
 	chats := make([]dto.ChatData, 0)
 	for _, chat := range res.GetChats() {
-		chats = append(chats, dto.ChatData{
-			ID:   uint(chat.GetId()),
-			UserID: uint(chat.GetUserId()),
-			Topic: chat.GetTopic(),
-		})
+		chats = append(chats, *toChatData(chat))
 	}
 
 	return &dto.GetChatsResponse{
@@ -87,7 +116,64 @@ func (u *ChatUseCaseImpl) GetChats(ctx context.Context, userID *uint) (*dto.GetC
 	}, nil
 }
 
-func (u *ChatUseCaseImpl) GetMessages(ctx context.Context, chatID *string, userID *uint) (*dto.GetMessagesResponse, error) {
+func (u *ChatUseCaseImpl) MoveChat(ctx context.Context, chatID *string, userID *uint, req *dto.MoveChatRequest) (*dto.ChatData, error) {
+	// Convert chatID to uint
+	chatIDUint, err := strconv.ParseUint(*chatID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "Chat ID must be a number", nil)
+	}
+
+	// A null project moves the chat out of its project
+	var projectID int64
+	if req.ProjectID != nil {
+		projectID = int64(*req.ProjectID)
+	}
+
+	// Get response from chat service
+	moveChatCtx, cancelMoveChat := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelMoveChat()
+
+	res, err := u.Chat.Service.MoveChat(moveChatCtx, &proto.MoveChatRequest{
+		ChatId:    int64(chatIDUint),
+		UserId:    int64(*userID),
+		ProjectId: projectID,
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to move chat")
+	}
+
+	return toChatData(res), nil
+}
+
+func (u *ChatUseCaseImpl) DeleteChat(ctx context.Context, chatID *string, userID *uint, organizationID *uint) error {
+	// Convert chatID to uint
+	chatIDUint, err := strconv.ParseUint(*chatID, 10, 64)
+	if err != nil {
+		return model.NewError(model.StatusBadRequest, "Chat ID must be a number", nil)
+	}
+
+	// Get response from chat service
+	deleteChatCtx, cancelDeleteChat := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelDeleteChat()
+
+	_, err = u.Chat.Service.DeleteChat(deleteChatCtx, &proto.DeleteChatRequest{
+		ChatId:         int64(chatIDUint),
+		UserId:         int64(*userID),
+		OrganizationId: int64(*organizationID),
+	})
+	if err != nil {
+		return helper.GrpcError(err, "Failed to delete chat")
+	}
+
+	// Sockets still in the room of the chat stop receiving its broadcasts
+	u.Rooms.Drop(uint(chatIDUint))
+
+	u.Audit.Record(ctx, client.AuditActionChatDelete, client.AuditTargetChat, *chatID, nil)
+
+	return nil
+}
+
+func (u *ChatUseCaseImpl) GetMessages(ctx context.Context, chatID *string, userID *uint, organizationID *uint) (*dto.GetMessagesResponse, error) {
 	// Convert chatID to uint
 	chatIDUint, err := strconv.ParseUint(*chatID, 10, 64)
 	if err != nil {
@@ -96,8 +182,9 @@ func (u *ChatUseCaseImpl) GetMessages(ctx context.Context, chatID *string, userI
 
 	// Create request
 	request := &dto.GetMessagesRequest{
-		ChatID: uint(chatIDUint),
-		UserID: *userID,
+		ChatID:         uint(chatIDUint),
+		UserID:         *userID,
+		OrganizationID: *organizationID,
 	}
 
 	// Validate request
@@ -110,8 +197,9 @@ func (u *ChatUseCaseImpl) GetMessages(ctx context.Context, chatID *string, userI
 	defer cancelGetMessages()
 
 	res, err := u.Chat.Service.GetMessages(getMessagesCtx, &proto.GetMessagesRequest{
-		ChatId: int64(chatIDUint),
-		UserId: int64(*userID),
+		ChatId:         int64(chatIDUint),
+		UserId:         int64(*userID),
+		OrganizationId: int64(*organizationID),
 	})
 	
 	if err != nil {
@@ -130,13 +218,7 @@ func (u *ChatUseCaseImpl) GetMessages(ctx context.Context, chatID *string, userI
 
 	messages := make([]dto.MessageData, 0)
 	for _, message := range res.GetMessages() {
-		messages = append(messages, dto.MessageData{
-			ID:      uint(message.GetId()),
-			ChatID:  uint(message.GetChatId()),
-			UserID:  uint(message.GetUserId()),
-			Role:    message.GetRole(),
-			Content: message.GetContent(),
-		})
+		messages = append(messages, *toMessageData(message))
 	}
 
 	return &dto.GetMessagesResponse{
@@ -150,9 +232,8 @@ func (u *ChatUseCaseImpl) getUser(ep *socketio.EventPayload) (*proto.GetProfileR
 		return nil, errors.New("Unauthorized")
 	}
 
-	user, err := u.Auth.Service.GetProfile(context.Background(), &proto.GetProfileRequest{
-		AccessToken: accessToken,
-	})
+	// Socket events are verified locally, the gateway does not call the auth service per event
+	user, err := u.Identity.Verify(context.Background(), accessToken)
 	if err != nil {
 		return nil, err
 	}
@@ -160,6 +241,23 @@ func (u *ChatUseCaseImpl) getUser(ep *socketio.EventPayload) (*proto.GetProfileR
 	return user, nil
 }
 
+// historyEnabled reports whether the socket user keeps a chat history, it
+// defaults to true when the preferences cannot be loaded
+func (u *ChatUseCaseImpl) historyEnabled(ep *socketio.EventPayload) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 5 * time.Second)
+	defer cancel()
+
+	res, err := u.Auth.Service.GetPreferences(ctx, &proto.GetPreferencesRequest{
+		AccessToken: ep.Socket.Conn.Query("token"),
+	})
+	if err != nil {
+		u.Log.Warnf("Failed to get preferences: %v", err)
+		return true
+	}
+
+	return res.GetHistoryEnabled()
+}
+
 func (u *ChatUseCaseImpl) JoinChatRoom(ep *socketio.EventPayload) {
 	// Get user
 	user, err := u.getUser(ep)
@@ -194,12 +292,13 @@ func (u *ChatUseCaseImpl) JoinChatRoom(ep *socketio.EventPayload) {
 	}
 
 	// Get response from chat service
-	getChatCtx, cancelGetChat := context.WithTimeout(context.Background(), 2 * time.Second)
+	getChatCtx, cancelGetChat := context.WithTimeout(client.WithUserID(context.Background(), user.GetId()), 2 * time.Second)
 	defer cancelGetChat()
 
 	_, err = u.Chat.Service.GetChatByIdAndUserId(getChatCtx, &proto.GetChatRequest{
-		ChatId: int64(request.ChatID),
-		UserId: user.GetId(),
+		ChatId:         int64(request.ChatID),
+		UserId:         user.GetId(),
+		OrganizationId: user.GetOrganizationId(),
 	})
 	if err != nil {
 		if s, ok := status.FromError(err); ok {
@@ -220,8 +319,7 @@ func (u *ChatUseCaseImpl) JoinChatRoom(ep *socketio.EventPayload) {
 	}
 
 	// Join chat room
-	room := fmt.Sprintf("chat_%d", request.ChatID)
-	ep.Socket.Join(room)
+	u.Rooms.Join(request.ChatID, user.GetId(), user.GetOrganizationId(), ep.Socket)
 	ep.Socket.Emit("message", fmt.Sprintf("Joined chat room %d", request.ChatID))
 }
 
@@ -254,6 +352,49 @@ func (u *ChatUseCaseImpl) CreateMessage(ep *socketio.EventPayload) {
 		return
 	}
 
+	// Assistant and project are optional and only apply when starting a new chat
+	if request.AssistantID, err = optionalDataID(dataMap, "assistant_id"); err != nil {
+		ep.Socket.Emit("error", "Assistant ID must be a number")
+		return
+	}
+
+	// Assistants shared with the user are bound with their share token
+	if rawShareToken, ok := dataMap["assistant_share_token"]; ok && rawShareToken != nil {
+		if request.AssistantShareToken, ok = rawShareToken.(string); !ok {
+			ep.Socket.Emit("error", "Assistant share token must be a string")
+			return
+		}
+	}
+
+	if request.ProjectID, err = optionalDataID(dataMap, "project_id"); err != nil {
+		ep.Socket.Emit("error", "Project ID must be a number")
+		return
+	}
+
+	// Ephemeral chats are never persisted, their history lives in the socket session
+	if rawEphemeral, ok := dataMap["ephemeral"]; ok && rawEphemeral != nil {
+		ephemeral, ok := rawEphemeral.(bool)
+		if !ok {
+			ep.Socket.Emit("error", "Ephemeral must be a boolean")
+			return
+		}
+		request.Ephemeral = ephemeral
+	}
+
+	if request.Ephemeral && (chatId != 0 || request.ProjectID != 0) {
+		ep.Socket.Emit("error", "Ephemeral chats cannot belong to a chat or project")
+		return
+	}
+
+	// Users who turned chat history off only start temporary personal chats
+	if chatId == 0 && !request.Ephemeral && user.GetOrganizationId() == 0 {
+		request.Ephemeral = !u.historyEnabled(ep)
+		if request.Ephemeral && request.ProjectID != 0 {
+			ep.Socket.Emit("error", "Chat history is turned off, new chats cannot belong to a project")
+			return
+		}
+	}
+
 	request.ChatID = uint(chatId)
 	request.Message = message
 
@@ -265,7 +406,7 @@ func (u *ChatUseCaseImpl) CreateMessage(ep *socketio.EventPayload) {
 	}
 
 	// Craete request message to chat service
-	stream, err := u.Chat.Service.CreateMessage(context.Background())
+	stream, err := u.Chat.Service.CreateMessage(client.WithUserID(context.Background(), user.GetId()))
 	if err != nil {
 		ep.Socket.Emit("error", "Failed to create message")
 		u.Log.Errorf("Failed to create message: %v", err)
@@ -273,11 +414,27 @@ func (u *ChatUseCaseImpl) CreateMessage(ep *socketio.EventPayload) {
 	}
 
 	// Send message to chat service
-	stream.Send(&proto.Message{
+	protoMessage := &proto.Message{
 		ChatId: int64(request.ChatID),
 		UserId: user.GetId(),
 		Message: request.Message,
-	})
+		AssistantId: int64(request.AssistantID),
+		AssistantShareToken: request.AssistantShareToken,
+		ProjectId: int64(request.ProjectID),
+		OrganizationId: user.GetOrganizationId(),
+	}
+
+	// The assistant of an ephemeral chat is fixed by its first message
+	var session EphemeralSession
+	if request.Ephemeral {
+		session = u.Sessions.Get(ep.Socket.Id, user.GetId(), int64(request.AssistantID), request.AssistantShareToken)
+		protoMessage.Ephemeral = true
+		protoMessage.AssistantId = session.AssistantID
+		protoMessage.AssistantShareToken = session.AssistantShareToken
+		protoMessage.History = session.History
+	}
+
+	stream.Send(protoMessage)
 
 	// Close send
 	if err := stream.CloseSend(); err != nil {
@@ -286,28 +443,41 @@ func (u *ChatUseCaseImpl) CreateMessage(ep *socketio.EventPayload) {
 		return
 	}
 
-	if request.ChatID != 0 {
-		room := fmt.Sprintf("chat_%d", request.ChatID)
-		ep.Socket.Join(room)
+	// Prompts and parts of existing chats are broadcast to every socket in the room,
+	// the socket joins it once the chat service has accepted the prompt
+	room := ""
+
+	emit := func(event string, data string) {
+		if room != "" {
+			ep.Socket.To(room).Emit(event, data)
+			return
+		}
+		ep.Socket.Emit(event, data)
 	}
 
 	// Loop get response from chat service
+	reply := ""
 	for {
 		res, err := stream.Recv()
 		if err == io.EOF {
 			break
 		}
 
+		if err != nil {
+			message := "Failed to receive message"
+			if s, ok := status.FromError(err); ok {
+				message = s.Message()
+			}
+			ep.Socket.Emit("error", message)
+			u.Log.Errorf("Failed to receive message: %v", err)
+			break
+		}
+
 		part := &dto.MessagePart{
 			ChatID: uint(res.GetChatId()),
 			Part:   res.GetPart(),
 			Status: res.GetStatus(),
-		}
-
-		if err != nil {
-			ep.Socket.Emit("error", "Failed to receive message")
-			u.Log.Errorf("Failed to receive message: %v", err)
-			break
+			Ephemeral: request.Ephemeral,
 		}
 
 		// Marshal response to bytes
@@ -318,24 +488,326 @@ func (u *ChatUseCaseImpl) CreateMessage(ep *socketio.EventPayload) {
 			break
 		}
 
-		// If response status is start, emit start event to user
+		// If response status is start, share the prompt with the room and emit start event
 		if res.GetStatus() == proto.Status_START {
-			ep.Socket.Emit("message_start", string(resJson))
+			if request.ChatID != 0 {
+				room = u.Rooms.Join(request.ChatID, user.GetId(), user.GetOrganizationId(), ep.Socket)
+			}
+			if room != "" {
+				prompt, _ := json.Marshal(&dto.MessagePrompt{
+					ChatID:  request.ChatID,
+					UserID:  uint(user.GetId()),
+					Name:    user.GetName(),
+					Message: request.Message,
+				})
+				emit("message_prompt", string(prompt))
+			}
+
+			emit("message_start", string(resJson))
 			continue
 		}
 
 		// If response status is END, emit end event and break the loop
 		if res.GetStatus() == proto.Status_END {
-			ep.Socket.Emit("message_end", string(resJson))
+			if request.Ephemeral {
+				u.Sessions.Update(ep.Socket.Id, user.GetId(), func(current *EphemeralSession) {
+					// The first completed turn fixes the assistant of the chat
+					if current.LastUsedAt.IsZero() {
+						current.AssistantID = session.AssistantID
+						current.AssistantShareToken = session.AssistantShareToken
+					}
+
+					// Appended to the current history, turns completed meanwhile are kept
+					current.History = append(current.History,
+						&proto.HistoryMessage{Role: "user", Content: request.Message},
+						&proto.HistoryMessage{Role: "model", Content: reply},
+					)
+				})
+			}
+
+			emit("message_end", string(resJson))
 			break
 		}
 
-		// Send message to user
+		reply += res.GetPart()
+
+		// Send message to the room
 		if request.ChatID != 0 {
 			event := fmt.Sprintf("message_chat_%d", request.ChatID)
-			ep.Socket.Emit(event, string(resJson))
+			emit(event, string(resJson))
 		} else {
-			ep.Socket.Emit("message", string(resJson))
+			emit("message", string(resJson))
 		}
 	}
 }
+
+// CompareMessage streams the replies of several models to the same prompt,
+// every part is tagged with the model that produced it.
+func (u *ChatUseCaseImpl) CompareMessage(ep *socketio.EventPayload) {
+	// Get user
+	user, err := u.getUser(ep)
+	if err != nil {
+		ep.Socket.Emit("error", "Unauthorized")
+		u.Log.Errorf("Failed to get user: %v", err)
+		return
+	}
+
+	// Parse data
+	dataMap, ok := ep.Data[0].(map[string]interface{})
+	if !ok {
+		ep.Socket.Emit("error", "Failed to parse data")
+		return
+	}
+
+	var request = &dto.CompareMessageRequest{}
+	chatId, err := strconv.ParseUint(fmt.Sprintf("%v", dataMap["chat_id"]), 10, 64)
+	if err != nil {
+		ep.Socket.Emit("error", "Chat ID must be a number")
+		return
+	}
+
+	message, ok := dataMap["message"].(string)
+	if !ok {
+		ep.Socket.Emit("error", "Message must be a string")
+		return
+	}
+
+	if request.AssistantID, err = optionalDataID(dataMap, "assistant_id"); err != nil {
+		ep.Socket.Emit("error", "Assistant ID must be a number")
+		return
+	}
+
+	if rawShareToken, ok := dataMap["assistant_share_token"]; ok && rawShareToken != nil {
+		if request.AssistantShareToken, ok = rawShareToken.(string); !ok {
+			ep.Socket.Emit("error", "Assistant share token must be a string")
+			return
+		}
+	}
+
+	if request.ProjectID, err = optionalDataID(dataMap, "project_id"); err != nil {
+		ep.Socket.Emit("error", "Project ID must be a number")
+		return
+	}
+
+	rawTargets, ok := dataMap["targets"].([]interface{})
+	if !ok {
+		ep.Socket.Emit("error", "Targets must be a list")
+		return
+	}
+
+	for _, rawTarget := range rawTargets {
+		target, ok := rawTarget.(map[string]interface{})
+		if !ok {
+			ep.Socket.Emit("error", "Target must be an object")
+			return
+		}
+
+		provider, _ := target["provider"].(string)
+		model, _ := target["model"].(string)
+		request.Targets = append(request.Targets, dto.CompareTarget{
+			Provider: provider,
+			Model:    model,
+		})
+	}
+
+	request.ChatID = uint(chatId)
+	request.Message = message
+
+	// Validate request
+	if errors := helper.Validate(u.Validate, request); len(errors) > 0 {
+		message := fmt.Sprintf("The given data was invalid: %v", errors)
+		ep.Socket.Emit("error", message)
+		return
+	}
+
+	// Create compare request to chat service
+	stream, err := u.Chat.Service.CompareMessage(client.WithUserID(context.Background(), user.GetId()))
+	if err != nil {
+		ep.Socket.Emit("error", "Failed to compare message")
+		u.Log.Errorf("Failed to compare message: %v", err)
+		return
+	}
+
+	protoMessage := &proto.CompareMessageRequest{
+		ChatId: int64(request.ChatID),
+		UserId: user.GetId(),
+		Message: request.Message,
+		AssistantId: int64(request.AssistantID),
+		AssistantShareToken: request.AssistantShareToken,
+		ProjectId: int64(request.ProjectID),
+		OrganizationId: user.GetOrganizationId(),
+	}
+	for _, target := range request.Targets {
+		protoMessage.Targets = append(protoMessage.Targets, &proto.CompareTarget{
+			Provider: target.Provider,
+			Model:    target.Model,
+		})
+	}
+
+	stream.Send(protoMessage)
+
+	// Close send
+	if err := stream.CloseSend(); err != nil {
+		ep.Socket.Emit("error", "Failed to close send")
+		u.Log.Errorf("Failed to close send: %v", err)
+		return
+	}
+
+	// Prompts and parts of existing chats are broadcast to every socket in the room,
+	// the socket joins it once the chat service has accepted the prompt
+	room := ""
+
+	emit := func(event string, data string) {
+		if room != "" {
+			ep.Socket.To(room).Emit(event, data)
+			return
+		}
+		ep.Socket.Emit(event, data)
+	}
+
+	// Loop until every model has ended and the chat service closes the stream
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			message := "Failed to receive message"
+			if s, ok := status.FromError(err); ok {
+				message = s.Message()
+			}
+			ep.Socket.Emit("error", message)
+			u.Log.Errorf("Failed to receive message: %v", err)
+			break
+		}
+
+		part := &dto.MessagePart{
+			ChatID:       uint(res.GetChatId()),
+			Part:         res.GetPart(),
+			Status:       res.GetStatus(),
+			Model:        res.GetModel(),
+			CompareGroup: res.GetCompareGroup(),
+			MessageID:    uint(res.GetMessageId()),
+		}
+
+		// Marshal response to bytes
+		resJson, err := json.Marshal(part)
+		if err != nil {
+			ep.Socket.Emit("error", "Failed to marshal response")
+			u.Log.Errorf("Failed to marshal response: %v", err)
+			break
+		}
+
+		switch res.GetStatus() {
+		case proto.Status_START:
+			if request.ChatID != 0 && room == "" {
+				room = u.Rooms.Join(request.ChatID, user.GetId(), user.GetOrganizationId(), ep.Socket)
+			}
+			if room != "" {
+				prompt, _ := json.Marshal(&dto.MessagePrompt{
+					ChatID:  request.ChatID,
+					UserID:  uint(user.GetId()),
+					Name:    user.GetName(),
+					Message: request.Message,
+				})
+				emit("message_prompt", string(prompt))
+			}
+
+			emit("message_start", string(resJson))
+		case proto.Status_END:
+			emit("message_end", string(resJson))
+		default:
+			if request.ChatID != 0 {
+				emit(fmt.Sprintf("message_chat_%d", request.ChatID), string(resJson))
+			} else {
+				emit("message", string(resJson))
+			}
+		}
+	}
+}
+
+func (u *ChatUseCaseImpl) PreferMessage(ctx context.Context, chatID *string, messageID *string, userID *uint, organizationID *uint) (*dto.MessageData, error) {
+	// Convert chatID and messageID to uint
+	chatIDUint, err := strconv.ParseUint(*chatID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "Chat ID must be a number", nil)
+	}
+
+	messageIDUint, err := strconv.ParseUint(*messageID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "Message ID must be a number", nil)
+	}
+
+	// Get response from chat service
+	preferMessageCtx, cancelPreferMessage := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelPreferMessage()
+
+	res, err := u.Chat.Service.PreferMessage(preferMessageCtx, &proto.PreferMessageRequest{
+		ChatId:         int64(chatIDUint),
+		UserId:         int64(*userID),
+		MessageId:      int64(messageIDUint),
+		OrganizationId: int64(*organizationID),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to prefer message")
+	}
+
+	return toMessageData(res), nil
+}
+
+func (u *ChatUseCaseImpl) Disconnect(ep *socketio.EventPayload) {
+	// Forget the ephemeral chat and the rooms of the socket
+	u.Sessions.Drop(ep.Socket.Id)
+	u.Rooms.DropSocket(ep.Socket.Id)
+}
+
+func toChatData(chat *proto.Chat) *dto.ChatData {
+	return &dto.ChatData{
+		ID:             uint(chat.GetId()),
+		UserID:         uint(chat.GetUserId()),
+		Topic:          chat.GetTopic(),
+		AssistantID:    optionalID(chat.GetAssistantId()),
+		ProjectID:      optionalID(chat.GetProjectId()),
+		OrganizationID: optionalID(chat.GetOrganizationId()),
+		Role:           chat.GetRole(),
+	}
+}
+
+func toMessageData(message *proto.FullMessage) *dto.MessageData {
+	return &dto.MessageData{
+		ID:           uint(message.GetId()),
+		ChatID:       uint(message.GetChatId()),
+		UserID:       uint(message.GetUserId()),
+		Role:         message.GetRole(),
+		Content:      message.GetContent(),
+		Model:        message.GetModel(),
+		CompareGroup: message.GetCompareGroup(),
+		Active:       message.GetActive(),
+	}
+}
+
+// optionalDataID parses an optional id of a socket payload, 0 when it is missing
+func optionalDataID(dataMap map[string]interface{}, key string) (uint, error) {
+	raw, ok := dataMap[key]
+	if !ok || raw == nil {
+		return 0, nil
+	}
+
+	id, err := strconv.ParseUint(fmt.Sprintf("%v", raw), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint(id), nil
+}
+
+// optionalID converts a proto id, where 0 means unset, to an optional id
+func optionalID(id int64) *uint {
+	if id == 0 {
+		return nil
+	}
+
+	value := uint(id)
+	return &value
+}
\ No newline at end of file
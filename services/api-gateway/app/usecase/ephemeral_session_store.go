@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"api-gateway/app/model/proto"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// EphemeralSession is the in-memory history of a temporary chat held by one socket
+type EphemeralSession struct {
+	UserID              int64
+	AssistantID         int64
+	AssistantShareToken string
+	History             []*proto.HistoryMessage
+	LastUsedAt          time.Time
+}
+
+// EphemeralSessionStore keeps temporary chats keyed by socket id. Sessions are
+// dropped on disconnect or once they have been idle longer than the timeout, and
+// their oldest turns are dropped once the history holds too many turns or bytes.
+type EphemeralSessionStore struct {
+	mu          sync.Mutex
+	sessions    map[string]*EphemeralSession
+	idleTimeout time.Duration
+	maxTurns    int
+	maxBytes    int
+}
+
+func NewEphemeralSessionStore(config *viper.Viper) *EphemeralSessionStore {
+	idleTimeout := config.GetDuration("EPHEMERAL_SESSION_IDLE_TIMEOUT")
+	if idleTimeout <= 0 {
+		idleTimeout = 30 * time.Minute
+	}
+
+	maxTurns := config.GetInt("EPHEMERAL_SESSION_MAX_TURNS")
+	if maxTurns <= 0 {
+		maxTurns = 20
+	}
+
+	maxBytes := config.GetInt("EPHEMERAL_SESSION_MAX_BYTES")
+	if maxBytes <= 0 {
+		maxBytes = 64 * 1024
+	}
+
+	store := &EphemeralSessionStore{
+		sessions:    make(map[string]*EphemeralSession),
+		idleTimeout: idleTimeout,
+		maxTurns:    maxTurns,
+		maxBytes:    maxBytes,
+	}
+
+	go store.sweep()
+
+	return store
+}
+
+// Get returns a copy of the session of the socket, or a new one when it does not exist yet
+func (s *EphemeralSessionStore) Get(socketID string, userID int64, assistantID int64, assistantShareToken string) EphemeralSession {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[socketID]
+	if !ok || session.UserID != userID {
+		return EphemeralSession{
+			UserID:              userID,
+			AssistantID:         assistantID,
+			AssistantShareToken: assistantShareToken,
+			History:             []*proto.HistoryMessage{},
+		}
+	}
+
+	history := make([]*proto.HistoryMessage, len(session.History))
+	copy(history, session.History)
+
+	return EphemeralSession{
+		UserID:              session.UserID,
+		AssistantID:         session.AssistantID,
+		AssistantShareToken: session.AssistantShareToken,
+		History:             history,
+		LastUsedAt:          session.LastUsedAt,
+	}
+}
+
+// Update changes the session of the socket under one lock, the history is
+// trimmed afterwards. A session of another user is replaced by a new one.
+func (s *EphemeralSessionStore) Update(socketID string, userID int64, update func(session *EphemeralSession)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[socketID]
+	if !ok || session.UserID != userID {
+		session = &EphemeralSession{UserID: userID}
+		s.sessions[socketID] = session
+	}
+
+	update(session)
+	session.History = s.trim(session.History)
+	session.LastUsedAt = time.Now()
+}
+
+// trim drops the oldest turns, a prompt and its reply, until the history fits the limits
+func (s *EphemeralSessionStore) trim(history []*proto.HistoryMessage) []*proto.HistoryMessage {
+	size := 0
+	for _, message := range history {
+		size += len(message.GetContent())
+	}
+
+	for len(history) > 0 && (len(history) > 2*s.maxTurns || size > s.maxBytes) {
+		turn := min(2, len(history))
+		for _, message := range history[:turn] {
+			size -= len(message.GetContent())
+		}
+		history = history[turn:]
+	}
+
+	return history
+}
+
+// Drop forgets the session of the socket
+func (s *EphemeralSessionStore) Drop(socketID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.sessions, socketID)
+}
+
+func (s *EphemeralSessionStore) sweep() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.mu.Lock()
+		for socketID, session := range s.sessions {
+			if time.Since(session.LastUsedAt) > s.idleTimeout {
+				delete(s.sessions, socketID)
+			}
+		}
+		s.mu.Unlock()
+	}
+}
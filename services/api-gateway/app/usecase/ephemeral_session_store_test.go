@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"api-gateway/app/model/proto"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func appendTurn(store *EphemeralSessionStore, socketID string, userID int64, prompt string) {
+	store.Update(socketID, userID, func(session *EphemeralSession) {
+		session.History = append(session.History,
+			&proto.HistoryMessage{Role: "user", Content: prompt},
+			&proto.HistoryMessage{Role: "model", Content: "reply to " + prompt},
+		)
+	})
+}
+
+func TestEphemeralSessionStore_UpdateConcurrently(t *testing.T) {
+	config := viper.New()
+	config.Set("EPHEMERAL_SESSION_MAX_TURNS", 1000)
+	config.Set("EPHEMERAL_SESSION_MAX_BYTES", 1 << 20)
+	store := NewEphemeralSessionStore(config)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			appendTurn(store, "socket", 1, fmt.Sprintf("prompt %d", i))
+		}(i)
+	}
+	wg.Wait()
+
+	// Every turn is kept and its prompt is directly followed by its reply
+	history := store.Get("socket", 1, 0, "").History
+	assert.Len(t, history, 200)
+	for i := 0; i < len(history); i += 2 {
+		assert.Equal(t, "user", history[i].GetRole())
+		assert.Equal(t, "reply to " + history[i].GetContent(), history[i+1].GetContent())
+	}
+}
+
+func TestEphemeralSessionStore_TrimsOldestTurns(t *testing.T) {
+	config := viper.New()
+	config.Set("EPHEMERAL_SESSION_MAX_TURNS", 2)
+	store := NewEphemeralSessionStore(config)
+
+	for i := 0; i < 3; i++ {
+		appendTurn(store, "socket", 1, fmt.Sprintf("prompt %d", i))
+	}
+
+	history := store.Get("socket", 1, 0, "").History
+	assert.Len(t, history, 4)
+	assert.Equal(t, "prompt 1", history[0].GetContent())
+	assert.Equal(t, "prompt 2", history[2].GetContent())
+}
+
+func TestEphemeralSessionStore_UpdateReplacesSessionOfOtherUser(t *testing.T) {
+	store := NewEphemeralSessionStore(viper.New())
+
+	appendTurn(store, "socket", 1, "prompt")
+	appendTurn(store, "socket", 2, "other prompt")
+
+	assert.Empty(t, store.Get("socket", 1, 0, "").History)
+	assert.Len(t, store.Get("socket", 2, 0, "").History, 2)
+}
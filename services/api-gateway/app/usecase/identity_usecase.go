@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"api-gateway/app/delivery/client"
+	"api-gateway/app/helper"
+	"api-gateway/app/model"
+	"api-gateway/app/model/dto"
+	"api-gateway/app/model/proto"
+	"context"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+type IdentityUseCase interface {
+	GetIdentities(ctx context.Context, accessToken string) (*dto.GetIdentitiesResponse, error)
+	LinkIdentity(ctx context.Context, req *dto.LinkIdentityRequest, accessToken string) (*dto.IdentityData, error)
+	UnlinkIdentity(ctx context.Context, provider *string, accessToken string) error
+}
+
+type IdentityUseCaseImpl struct {
+	Validate *validator.Validate
+	Log      *logrus.Logger
+	Auth     *client.AuthClient
+}
+
+func NewIdentityUseCaseImpl(
+	validate *validator.Validate,
+	log *logrus.Logger,
+	auth *client.AuthClient,
+) *IdentityUseCaseImpl {
+	return &IdentityUseCaseImpl{
+		Validate: validate,
+		Log:      log,
+		Auth:     auth,
+	}
+}
+
+func (u *IdentityUseCaseImpl) GetIdentities(ctx context.Context, accessToken string) (*dto.GetIdentitiesResponse, error) {
+	// Get response from auth service
+	listCtx, cancelList := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelList()
+
+	res, err := u.Auth.Service.ListIdentities(listCtx, &proto.ListIdentitiesRequest{
+		AccessToken: accessToken,
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to get linked accounts")
+	}
+
+	identities := make([]dto.IdentityData, 0)
+	for _, identity := range res.GetIdentities() {
+		identities = append(identities, toIdentityData(identity))
+	}
+
+	providers := res.GetProviders()
+	if providers == nil {
+		providers = make([]string, 0)
+	}
+
+	return &dto.GetIdentitiesResponse{
+		Identities: identities,
+		Providers:  providers,
+	}, nil
+}
+
+func (u *IdentityUseCaseImpl) LinkIdentity(ctx context.Context, req *dto.LinkIdentityRequest, accessToken string) (*dto.IdentityData, error) {
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from auth service
+	linkCtx, cancelLink := context.WithTimeout(ctx, 10 * time.Second)
+	defer cancelLink()
+
+	res, err := u.Auth.Service.LinkIdentity(linkCtx, &proto.LinkIdentityRequest{
+		AccessToken: accessToken,
+		Provider:    req.Provider,
+		IdToken:     req.IdToken,
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to link account")
+	}
+
+	identity := toIdentityData(res)
+	return &identity, nil
+}
+
+func (u *IdentityUseCaseImpl) UnlinkIdentity(ctx context.Context, provider *string, accessToken string) error {
+	// Get response from auth service
+	unlinkCtx, cancelUnlink := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelUnlink()
+
+	_, err := u.Auth.Service.UnlinkIdentity(unlinkCtx, &proto.UnlinkIdentityRequest{
+		AccessToken: accessToken,
+		Provider:    *provider,
+	})
+	if err != nil {
+		return helper.GrpcError(err, "Failed to unlink account")
+	}
+
+	return nil
+}
+
+func toIdentityData(identity *proto.Identity) dto.IdentityData {
+	return dto.IdentityData{
+		Provider:  identity.GetProvider(),
+		Email:     identity.GetEmail(),
+		CreatedAt: identity.GetCreatedAt(),
+	}
+}
\ No newline at end of file
@@ -0,0 +1,436 @@
+package usecase
+
+import (
+	"api-gateway/app/delivery/client"
+	"api-gateway/app/helper"
+	"api-gateway/app/model"
+	"api-gateway/app/model/dto"
+	"api-gateway/app/model/proto"
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+type OrganizationUseCase interface {
+	SwitchOrganization(ctx context.Context, req *dto.SwitchOrganizationRequest, accessToken string) (*dto.LoginResponse, error)
+	CreateOrganization(ctx context.Context, req *dto.CreateOrganizationRequest, accessToken string) (*dto.OrganizationData, error)
+	GetOrganizations(ctx context.Context, accessToken string) (*dto.GetOrganizationsResponse, error)
+	GetOrganization(ctx context.Context, organizationID *string, accessToken string) (*dto.OrganizationData, error)
+	UpdateOrganization(ctx context.Context, organizationID *string, req *dto.UpdateOrganizationRequest, accessToken string) (*dto.OrganizationData, error)
+	DeleteOrganization(ctx context.Context, organizationID *string, clientInfo dto.ClientInfo, accessToken string) error
+	GetOrganizationMembers(ctx context.Context, organizationID *string, accessToken string) (*dto.GetOrganizationMembersResponse, error)
+	UpdateOrganizationMember(ctx context.Context, organizationID *string, userID *string, req *dto.UpdateOrganizationMemberRequest, accessToken string) (*dto.OrganizationMemberData, error)
+	RemoveOrganizationMember(ctx context.Context, organizationID *string, userID *string, clientInfo dto.ClientInfo, accessToken string) error
+	InviteOrganizationMember(ctx context.Context, organizationID *string, req *dto.InviteOrganizationMemberRequest, accessToken string) (*dto.OrganizationInviteData, error)
+	GetOrganizationInvites(ctx context.Context, organizationID *string, accessToken string) (*dto.GetOrganizationInvitesResponse, error)
+	RevokeOrganizationInvite(ctx context.Context, organizationID *string, inviteID *string, accessToken string) error
+	AcceptOrganizationInvite(ctx context.Context, req *dto.AcceptOrganizationInviteRequest, accessToken string) (*dto.OrganizationData, error)
+}
+
+type OrganizationUseCaseImpl struct {
+	Validate *validator.Validate
+	Log      *logrus.Logger
+	Auth     *client.AuthClient
+	Rooms    *ChatRoomRegistry
+}
+
+func NewOrganizationUseCaseImpl(
+	validate *validator.Validate,
+	log *logrus.Logger,
+	auth *client.AuthClient,
+	rooms *ChatRoomRegistry,
+) *OrganizationUseCaseImpl {
+	return &OrganizationUseCaseImpl{
+		Validate: validate,
+		Log:      log,
+		Auth:     auth,
+		Rooms:    rooms,
+	}
+}
+
+// SwitchOrganization answers a new token pair working in the organization,
+// organization 0 switches back to the personal workspace
+func (u *OrganizationUseCaseImpl) SwitchOrganization(ctx context.Context, req *dto.SwitchOrganizationRequest, accessToken string) (*dto.LoginResponse, error) {
+	// Get response from auth service
+	switchCtx, cancelSwitch := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelSwitch()
+
+	res, err := u.Auth.Service.SwitchOrganization(switchCtx, &proto.SwitchOrganizationRequest{
+		AccessToken:    accessToken,
+		OrganizationId: int64(req.OrganizationID),
+		Client:         toClientInfo(req.Client),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to switch organization")
+	}
+
+	return toLoginResponse(res), nil
+}
+
+func (u *OrganizationUseCaseImpl) CreateOrganization(ctx context.Context, req *dto.CreateOrganizationRequest, accessToken string) (*dto.OrganizationData, error) {
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from auth service
+	createCtx, cancelCreate := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelCreate()
+
+	res, err := u.Auth.Service.CreateOrganization(createCtx, &proto.CreateOrganizationRequest{
+		AccessToken: accessToken,
+		Name:        req.Name,
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to create organization")
+	}
+
+	return toOrganizationData(res), nil
+}
+
+func (u *OrganizationUseCaseImpl) GetOrganizations(ctx context.Context, accessToken string) (*dto.GetOrganizationsResponse, error) {
+	// Get response from auth service
+	listCtx, cancelList := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelList()
+
+	res, err := u.Auth.Service.ListOrganizations(listCtx, &proto.ListOrganizationsRequest{
+		AccessToken: accessToken,
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to get organizations")
+	}
+
+	organizations := make([]dto.OrganizationData, 0)
+	for _, organization := range res.GetOrganizations() {
+		organizations = append(organizations, *toOrganizationData(organization))
+	}
+
+	return &dto.GetOrganizationsResponse{
+		Organizations: organizations,
+	}, nil
+}
+
+func (u *OrganizationUseCaseImpl) GetOrganization(ctx context.Context, organizationID *string, accessToken string) (*dto.OrganizationData, error) {
+	// Convert organizationID to uint
+	id, err := strconv.ParseUint(*organizationID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "Organization ID must be a number", nil)
+	}
+
+	// Get response from auth service
+	getCtx, cancelGet := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGet()
+
+	res, err := u.Auth.Service.GetOrganization(getCtx, &proto.GetOrganizationRequest{
+		AccessToken: accessToken,
+		Id:          int64(id),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to get organization")
+	}
+
+	return toOrganizationData(res), nil
+}
+
+func (u *OrganizationUseCaseImpl) UpdateOrganization(ctx context.Context, organizationID *string, req *dto.UpdateOrganizationRequest, accessToken string) (*dto.OrganizationData, error) {
+	// Convert organizationID to uint
+	id, err := strconv.ParseUint(*organizationID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "Organization ID must be a number", nil)
+	}
+
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	request := &proto.UpdateOrganizationRequest{
+		AccessToken:             accessToken,
+		Id:                      int64(id),
+		Name:                    req.Name,
+		MemberDailyMessageQuota: req.MemberDailyMessageQuota,
+	}
+	if req.AllowedModels != nil {
+		request.AllowedModels = &proto.AllowedModels{Models: req.AllowedModels}
+	}
+
+	// Get response from auth service
+	updateCtx, cancelUpdate := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelUpdate()
+
+	res, err := u.Auth.Service.UpdateOrganization(updateCtx, request)
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to update organization")
+	}
+
+	return toOrganizationData(res), nil
+}
+
+func (u *OrganizationUseCaseImpl) DeleteOrganization(ctx context.Context, organizationID *string, clientInfo dto.ClientInfo, accessToken string) error {
+	// Convert organizationID to uint
+	id, err := strconv.ParseUint(*organizationID, 10, 64)
+	if err != nil {
+		return model.NewError(model.StatusBadRequest, "Organization ID must be a number", nil)
+	}
+
+	// Get response from auth service
+	deleteCtx, cancelDelete := context.WithTimeout(ctx, 5 * time.Second)
+	defer cancelDelete()
+
+	_, err = u.Auth.Service.DeleteOrganization(deleteCtx, &proto.DeleteOrganizationRequest{
+		AccessToken: accessToken,
+		Id:          int64(id),
+		Client:      toClientInfo(clientInfo),
+	})
+	if err != nil {
+		return helper.GrpcError(err, "Failed to delete organization")
+	}
+
+	return nil
+}
+
+func (u *OrganizationUseCaseImpl) GetOrganizationMembers(ctx context.Context, organizationID *string, accessToken string) (*dto.GetOrganizationMembersResponse, error) {
+	// Convert organizationID to uint
+	id, err := strconv.ParseUint(*organizationID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "Organization ID must be a number", nil)
+	}
+
+	// Get response from auth service
+	listCtx, cancelList := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelList()
+
+	res, err := u.Auth.Service.ListOrganizationMembers(listCtx, &proto.ListOrganizationMembersRequest{
+		AccessToken:    accessToken,
+		OrganizationId: int64(id),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to get organization members")
+	}
+
+	members := make([]dto.OrganizationMemberData, 0)
+	for _, member := range res.GetMembers() {
+		members = append(members, *toOrganizationMemberData(member))
+	}
+
+	return &dto.GetOrganizationMembersResponse{
+		Members: members,
+	}, nil
+}
+
+func (u *OrganizationUseCaseImpl) UpdateOrganizationMember(ctx context.Context, organizationID *string, userID *string, req *dto.UpdateOrganizationMemberRequest, accessToken string) (*dto.OrganizationMemberData, error) {
+	// Convert organizationID and userID to uint
+	id, err := strconv.ParseUint(*organizationID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "Organization ID must be a number", nil)
+	}
+
+	memberID, err := strconv.ParseUint(*userID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "User ID must be a number", nil)
+	}
+
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from auth service
+	updateCtx, cancelUpdate := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelUpdate()
+
+	res, err := u.Auth.Service.UpdateOrganizationMember(updateCtx, &proto.UpdateOrganizationMemberRequest{
+		AccessToken:    accessToken,
+		OrganizationId: int64(id),
+		UserId:         int64(memberID),
+		Role:           req.Role,
+		Client:         toClientInfo(req.Client),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to update organization member")
+	}
+
+	return toOrganizationMemberData(res), nil
+}
+
+func (u *OrganizationUseCaseImpl) RemoveOrganizationMember(ctx context.Context, organizationID *string, userID *string, clientInfo dto.ClientInfo, accessToken string) error {
+	// Convert organizationID and userID to uint
+	id, err := strconv.ParseUint(*organizationID, 10, 64)
+	if err != nil {
+		return model.NewError(model.StatusBadRequest, "Organization ID must be a number", nil)
+	}
+
+	memberID, err := strconv.ParseUint(*userID, 10, 64)
+	if err != nil {
+		return model.NewError(model.StatusBadRequest, "User ID must be a number", nil)
+	}
+
+	// Get response from auth service
+	removeCtx, cancelRemove := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelRemove()
+
+	_, err = u.Auth.Service.RemoveOrganizationMember(removeCtx, &proto.RemoveOrganizationMemberRequest{
+		AccessToken:    accessToken,
+		OrganizationId: int64(id),
+		UserId:         int64(memberID),
+		Client:         toClientInfo(clientInfo),
+	})
+	if err != nil {
+		return helper.GrpcError(err, "Failed to remove organization member")
+	}
+
+	// Open sockets of the member stop receiving the chats of the organization
+	u.Rooms.EvictOrganization(int64(id), int64(memberID))
+
+	return nil
+}
+
+func (u *OrganizationUseCaseImpl) InviteOrganizationMember(ctx context.Context, organizationID *string, req *dto.InviteOrganizationMemberRequest, accessToken string) (*dto.OrganizationInviteData, error) {
+	// Convert organizationID to uint
+	id, err := strconv.ParseUint(*organizationID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "Organization ID must be a number", nil)
+	}
+
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from auth service, which also emails the invite
+	inviteCtx, cancelInvite := context.WithTimeout(ctx, 5 * time.Second)
+	defer cancelInvite()
+
+	res, err := u.Auth.Service.InviteOrganizationMember(inviteCtx, &proto.InviteOrganizationMemberRequest{
+		AccessToken:    accessToken,
+		OrganizationId: int64(id),
+		Email:          req.Email,
+		Role:           req.Role,
+		Client:         toClientInfo(req.Client),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to invite organization member")
+	}
+
+	return toOrganizationInviteData(res), nil
+}
+
+func (u *OrganizationUseCaseImpl) GetOrganizationInvites(ctx context.Context, organizationID *string, accessToken string) (*dto.GetOrganizationInvitesResponse, error) {
+	// Convert organizationID to uint
+	id, err := strconv.ParseUint(*organizationID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "Organization ID must be a number", nil)
+	}
+
+	// Get response from auth service
+	listCtx, cancelList := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelList()
+
+	res, err := u.Auth.Service.ListOrganizationInvites(listCtx, &proto.ListOrganizationInvitesRequest{
+		AccessToken:    accessToken,
+		OrganizationId: int64(id),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to get organization invites")
+	}
+
+	invites := make([]dto.OrganizationInviteData, 0)
+	for _, invite := range res.GetInvites() {
+		invites = append(invites, *toOrganizationInviteData(invite))
+	}
+
+	return &dto.GetOrganizationInvitesResponse{
+		Invites: invites,
+	}, nil
+}
+
+func (u *OrganizationUseCaseImpl) RevokeOrganizationInvite(ctx context.Context, organizationID *string, inviteID *string, accessToken string) error {
+	// Convert organizationID and inviteID to uint
+	id, err := strconv.ParseUint(*organizationID, 10, 64)
+	if err != nil {
+		return model.NewError(model.StatusBadRequest, "Organization ID must be a number", nil)
+	}
+
+	inviteIDUint, err := strconv.ParseUint(*inviteID, 10, 64)
+	if err != nil {
+		return model.NewError(model.StatusBadRequest, "Invite ID must be a number", nil)
+	}
+
+	// Get response from auth service
+	revokeCtx, cancelRevoke := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelRevoke()
+
+	_, err = u.Auth.Service.RevokeOrganizationInvite(revokeCtx, &proto.RevokeOrganizationInviteRequest{
+		AccessToken:    accessToken,
+		OrganizationId: int64(id),
+		Id:             int64(inviteIDUint),
+	})
+	if err != nil {
+		return helper.GrpcError(err, "Failed to revoke organization invite")
+	}
+
+	return nil
+}
+
+func (u *OrganizationUseCaseImpl) AcceptOrganizationInvite(ctx context.Context, req *dto.AcceptOrganizationInviteRequest, accessToken string) (*dto.OrganizationData, error) {
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from auth service
+	acceptCtx, cancelAccept := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelAccept()
+
+	res, err := u.Auth.Service.AcceptOrganizationInvite(acceptCtx, &proto.AcceptOrganizationInviteRequest{
+		AccessToken: accessToken,
+		Token:       req.Token,
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to accept organization invite")
+	}
+
+	return toOrganizationData(res), nil
+}
+
+func toOrganizationData(organization *proto.Organization) *dto.OrganizationData {
+	allowedModels := organization.GetAllowedModels()
+	if allowedModels == nil {
+		allowedModels = []string{}
+	}
+
+	return &dto.OrganizationData{
+		ID:                      uint(organization.GetId()),
+		Name:                    organization.GetName(),
+		MemberDailyMessageQuota: organization.MemberDailyMessageQuota,
+		AllowedModels:           allowedModels,
+		Role:                    organization.GetRole(),
+		CreatedAt:               organization.GetCreatedAt(),
+	}
+}
+
+func toOrganizationMemberData(member *proto.OrganizationMember) *dto.OrganizationMemberData {
+	return &dto.OrganizationMemberData{
+		UserID:   uint(member.GetUserId()),
+		Name:     member.GetName(),
+		Email:    member.GetEmail(),
+		ImageURL: member.GetImageUrl(),
+		Role:     member.GetRole(),
+		JoinedAt: member.GetJoinedAt(),
+	}
+}
+
+func toOrganizationInviteData(invite *proto.OrganizationInvite) *dto.OrganizationInviteData {
+	return &dto.OrganizationInviteData{
+		ID:               uint(invite.GetId()),
+		OrganizationID:   uint(invite.GetOrganizationId()),
+		OrganizationName: invite.GetOrganizationName(),
+		Email:            invite.GetEmail(),
+		Role:             invite.GetRole(),
+		ExpiresAt:        invite.GetExpiresAt(),
+		CreatedAt:        invite.GetCreatedAt(),
+	}
+}
\ No newline at end of file
@@ -0,0 +1,294 @@
+package usecase
+
+import (
+	"api-gateway/app/delivery/client"
+	"api-gateway/app/helper"
+	"api-gateway/app/model"
+	"api-gateway/app/model/dto"
+	"api-gateway/app/model/proto"
+	"context"
+	"fmt"
+	"mime/multipart"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+type ProfileUseCase interface {
+	GetMe(ctx context.Context, accessToken string) (*dto.MeData, error)
+	UpdateMe(ctx context.Context, req *dto.UpdateMeRequest, accessToken string) (*dto.LoginResponse, error)
+	ChangePassword(ctx context.Context, req *dto.ChangePasswordRequest, accessToken string) (*dto.LoginResponse, error)
+	RequestEmailChange(ctx context.Context, req *dto.RequestEmailChangeRequest, accessToken string) error
+	ConfirmEmailChange(ctx context.Context, req *dto.ConfirmEmailChangeRequest) (*dto.VerifyEmailResponse, error)
+	UpdateAvatar(ctx context.Context, file *multipart.FileHeader, clientInfo dto.ClientInfo, accessToken string) (*dto.LoginResponse, error)
+	DeleteAvatar(ctx context.Context, clientInfo dto.ClientInfo, accessToken string) (*dto.LoginResponse, error)
+	GetPreferences(ctx context.Context, accessToken string) (*dto.PreferencesData, error)
+	UpdatePreferences(ctx context.Context, req *dto.UpdatePreferencesRequest, accessToken string) (*dto.PreferencesData, error)
+}
+
+type ProfileUseCaseImpl struct {
+	Validate *validator.Validate
+	Log      *logrus.Logger
+	Auth     *client.AuthClient
+	Avatars  *AvatarStore
+}
+
+func NewProfileUseCaseImpl(
+	validate *validator.Validate,
+	log *logrus.Logger,
+	auth *client.AuthClient,
+	avatars *AvatarStore,
+) *ProfileUseCaseImpl {
+	return &ProfileUseCaseImpl{
+		Validate: validate,
+		Log:      log,
+		Auth:     auth,
+		Avatars:  avatars,
+	}
+}
+
+func (u *ProfileUseCaseImpl) GetMe(ctx context.Context, accessToken string) (*dto.MeData, error) {
+	// Get response from auth service
+	res, err := u.getMe(ctx, accessToken)
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to get profile")
+	}
+
+	imageURL := res.GetImageUrl()
+	permissions := res.GetPermissions()
+	if permissions == nil {
+		permissions = make([]string, 0)
+	}
+
+	return &dto.MeData{
+		ID:               res.GetId(),
+		Name:             res.GetName(),
+		Email:            res.GetEmail(),
+		ImageURL:         &imageURL,
+		EmailVerified:    res.GetEmailVerified(),
+		HasPassword:      res.GetHasPassword(),
+		TwoFactorEnabled: res.GetTwoFactorEnabled(),
+		Role:             res.GetRole(),
+		Permissions:      permissions,
+		OrganizationID:   optionalID(res.GetOrganizationId()),
+		OrganizationRole: res.GetOrganizationRole(),
+		CreatedAt:        res.GetCreatedAt(),
+	}, nil
+}
+
+func (u *ProfileUseCaseImpl) UpdateMe(ctx context.Context, req *dto.UpdateMeRequest, accessToken string) (*dto.LoginResponse, error) {
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from auth service
+	updateCtx, cancelUpdate := context.WithTimeout(ctx, 5 * time.Second)
+	defer cancelUpdate()
+
+	res, err := u.Auth.Service.UpdateMe(updateCtx, &proto.UpdateMeRequest{
+		AccessToken: accessToken,
+		Name:        &req.Name,
+		Client:      toClientInfo(req.Client),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to update profile")
+	}
+
+	return toLoginResponse(res), nil
+}
+
+func (u *ProfileUseCaseImpl) ChangePassword(ctx context.Context, req *dto.ChangePasswordRequest, accessToken string) (*dto.LoginResponse, error) {
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from auth service
+	changeCtx, cancelChange := context.WithTimeout(ctx, 10 * time.Second)
+	defer cancelChange()
+
+	res, err := u.Auth.Service.ChangePassword(changeCtx, &proto.ChangePasswordRequest{
+		AccessToken:     accessToken,
+		CurrentPassword: req.CurrentPassword,
+		NewPassword:     req.Password,
+		Client:          toClientInfo(req.Client),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to change password")
+	}
+
+	return toLoginResponse(res), nil
+}
+
+func (u *ProfileUseCaseImpl) RequestEmailChange(ctx context.Context, req *dto.RequestEmailChangeRequest, accessToken string) error {
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from auth service
+	requestCtx, cancelRequest := context.WithTimeout(ctx, 30 * time.Second)
+	defer cancelRequest()
+
+	_, err := u.Auth.Service.RequestEmailChange(requestCtx, &proto.RequestEmailChangeRequest{
+		AccessToken:     accessToken,
+		Email:           req.Email,
+		CurrentPassword: req.CurrentPassword,
+		Client:          toClientInfo(req.Client),
+	})
+	if err != nil {
+		return helper.GrpcError(err, "Failed to change email")
+	}
+
+	return nil
+}
+
+func (u *ProfileUseCaseImpl) ConfirmEmailChange(ctx context.Context, req *dto.ConfirmEmailChangeRequest) (*dto.VerifyEmailResponse, error) {
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from auth service
+	confirmCtx, cancelConfirm := context.WithTimeout(ctx, 5 * time.Second)
+	defer cancelConfirm()
+
+	res, err := u.Auth.Service.ConfirmEmailChange(confirmCtx, &proto.ConfirmEmailChangeRequest{
+		Token: req.Token,
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to change email")
+	}
+
+	return &dto.VerifyEmailResponse{
+		Email: res.GetEmail(),
+	}, nil
+}
+
+func (u *ProfileUseCaseImpl) UpdateAvatar(ctx context.Context, file *multipart.FileHeader, clientInfo dto.ClientInfo, accessToken string) (*dto.LoginResponse, error) {
+	// Validate request
+	if file.Size > u.Avatars.MaxBytes() {
+		return nil, model.NewError(model.StatusBadRequest, fmt.Sprintf("Avatar must not be larger than %d KB", u.Avatars.MaxBytes() / 1024), nil)
+	}
+
+	me, err := u.getMe(ctx, accessToken)
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to update avatar")
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "Failed to read avatar", nil)
+	}
+	defer src.Close()
+
+	imageURL, err := u.Avatars.Save(src)
+	if err == ErrInvalidAvatar {
+		return nil, model.NewError(model.StatusBadRequest, "Avatar must be a JPEG, PNG or GIF image", nil)
+	}
+	if err != nil {
+		u.Log.Errorf("Failed to store avatar: %v", err)
+		return nil, model.NewError(model.StatusInternalServerError, "Failed to update avatar", nil)
+	}
+
+	res, err := u.setImageURL(ctx, imageURL, clientInfo, accessToken)
+	if err != nil {
+		if err := u.Avatars.Remove(imageURL); err != nil {
+			u.Log.Errorf("Failed to remove avatar: %v", err)
+		}
+		return nil, helper.GrpcError(err, "Failed to update avatar")
+	}
+
+	if err := u.Avatars.Remove(me.GetImageUrl()); err != nil {
+		u.Log.Errorf("Failed to remove previous avatar: %v", err)
+	}
+
+	return toLoginResponse(res), nil
+}
+
+func (u *ProfileUseCaseImpl) DeleteAvatar(ctx context.Context, clientInfo dto.ClientInfo, accessToken string) (*dto.LoginResponse, error) {
+	me, err := u.getMe(ctx, accessToken)
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to remove avatar")
+	}
+
+	res, err := u.setImageURL(ctx, "", clientInfo, accessToken)
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to remove avatar")
+	}
+
+	if err := u.Avatars.Remove(me.GetImageUrl()); err != nil {
+		u.Log.Errorf("Failed to remove avatar: %v", err)
+	}
+
+	return toLoginResponse(res), nil
+}
+
+func (u *ProfileUseCaseImpl) GetPreferences(ctx context.Context, accessToken string) (*dto.PreferencesData, error) {
+	// Get response from auth service
+	getCtx, cancelGet := context.WithTimeout(ctx, 5 * time.Second)
+	defer cancelGet()
+
+	res, err := u.Auth.Service.GetPreferences(getCtx, &proto.GetPreferencesRequest{
+		AccessToken: accessToken,
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to get preferences")
+	}
+
+	return toPreferencesData(res), nil
+}
+
+func (u *ProfileUseCaseImpl) UpdatePreferences(ctx context.Context, req *dto.UpdatePreferencesRequest, accessToken string) (*dto.PreferencesData, error) {
+	// Get response from auth service
+	updateCtx, cancelUpdate := context.WithTimeout(ctx, 5 * time.Second)
+	defer cancelUpdate()
+
+	res, err := u.Auth.Service.UpdatePreferences(updateCtx, &proto.UpdatePreferencesRequest{
+		AccessToken:      accessToken,
+		DefaultModel:     req.DefaultModel,
+		ResponseLanguage: req.ResponseLanguage,
+		Theme:            req.Theme,
+		Timezone:         req.Timezone,
+		MemoryEnabled:    req.MemoryEnabled,
+		HistoryEnabled:   req.HistoryEnabled,
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to update preferences")
+	}
+
+	return toPreferencesData(res), nil
+}
+
+func (u *ProfileUseCaseImpl) getMe(ctx context.Context, accessToken string) (*proto.Me, error) {
+	getCtx, cancelGet := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGet()
+
+	return u.Auth.Service.GetMe(getCtx, &proto.GetMeRequest{
+		AccessToken: accessToken,
+	})
+}
+
+func (u *ProfileUseCaseImpl) setImageURL(ctx context.Context, imageURL string, clientInfo dto.ClientInfo, accessToken string) (*proto.AuthenticatedResponse, error) {
+	updateCtx, cancelUpdate := context.WithTimeout(ctx, 5 * time.Second)
+	defer cancelUpdate()
+
+	return u.Auth.Service.UpdateMe(updateCtx, &proto.UpdateMeRequest{
+		AccessToken: accessToken,
+		ImageUrl:    &imageURL,
+		Client:      toClientInfo(clientInfo),
+	})
+}
+
+func toPreferencesData(preferences *proto.Preferences) *dto.PreferencesData {
+	return &dto.PreferencesData{
+		DefaultModel:     preferences.GetDefaultModel(),
+		ResponseLanguage: preferences.GetResponseLanguage(),
+		Theme:            preferences.GetTheme(),
+		Timezone:         preferences.GetTimezone(),
+		MemoryEnabled:    preferences.GetMemoryEnabled(),
+		HistoryEnabled:   preferences.GetHistoryEnabled(),
+	}
+}
\ No newline at end of file
@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"api-gateway/app/delivery/client"
+	"api-gateway/app/helper"
+	"api-gateway/app/model"
+	"api-gateway/app/model/dto"
+	"api-gateway/app/model/proto"
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+type ProjectUseCase interface {
+	CreateProject(ctx context.Context, userID *uint, req *dto.ProjectRequest) (*dto.ProjectData, error)
+	GetProjects(ctx context.Context, userID *uint) (*dto.GetProjectsResponse, error)
+	GetProject(ctx context.Context, projectID *string, userID *uint) (*dto.ProjectData, error)
+	UpdateProject(ctx context.Context, projectID *string, userID *uint, req *dto.ProjectRequest) (*dto.ProjectData, error)
+	DeleteProject(ctx context.Context, projectID *string, userID *uint) error
+}
+
+type ProjectUseCaseImpl struct {
+	Validate *validator.Validate
+	Log      *logrus.Logger
+	Chat     *client.ChatClient
+}
+
+func NewProjectUseCaseImpl(
+	validate *validator.Validate,
+	log *logrus.Logger,
+	chat *client.ChatClient,
+) *ProjectUseCaseImpl {
+	return &ProjectUseCaseImpl{
+		Validate: validate,
+		Log:      log,
+		Chat:     chat,
+	}
+}
+
+func (u *ProjectUseCaseImpl) CreateProject(ctx context.Context, userID *uint, req *dto.ProjectRequest) (*dto.ProjectData, error) {
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from chat service
+	createCtx, cancelCreate := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelCreate()
+
+	res, err := u.Chat.Service.CreateProject(createCtx, &proto.CreateProjectRequest{
+		UserId:       int64(*userID),
+		Name:         req.Name,
+		Instructions: req.Instructions,
+		Files:        toProjectFileProtos(req.Files),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to create project")
+	}
+
+	return toProjectData(res), nil
+}
+
+func (u *ProjectUseCaseImpl) GetProjects(ctx context.Context, userID *uint) (*dto.GetProjectsResponse, error) {
+	// Get response from chat service
+	getProjectsCtx, cancelGetProjects := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetProjects()
+
+	res, err := u.Chat.Service.GetProjects(getProjectsCtx, &proto.GetProjectsRequest{
+		UserId: int64(*userID),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to get projects")
+	}
+
+	projects := make([]dto.ProjectData, 0)
+	for _, project := range res.GetProjects() {
+		projects = append(projects, *toProjectData(project))
+	}
+
+	return &dto.GetProjectsResponse{
+		Projects: projects,
+	}, nil
+}
+
+func (u *ProjectUseCaseImpl) GetProject(ctx context.Context, projectID *string, userID *uint) (*dto.ProjectData, error) {
+	// Convert projectID to uint
+	id, err := strconv.ParseUint(*projectID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "Project ID must be a number", nil)
+	}
+
+	// Get response from chat service
+	getProjectCtx, cancelGetProject := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetProject()
+
+	res, err := u.Chat.Service.GetProject(getProjectCtx, &proto.GetProjectRequest{
+		Id:     int64(id),
+		UserId: int64(*userID),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to get project")
+	}
+
+	return toProjectData(res), nil
+}
+
+func (u *ProjectUseCaseImpl) UpdateProject(ctx context.Context, projectID *string, userID *uint, req *dto.ProjectRequest) (*dto.ProjectData, error) {
+	// Convert projectID to uint
+	id, err := strconv.ParseUint(*projectID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "Project ID must be a number", nil)
+	}
+
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from chat service
+	updateCtx, cancelUpdate := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelUpdate()
+
+	res, err := u.Chat.Service.UpdateProject(updateCtx, &proto.UpdateProjectRequest{
+		Id:           int64(id),
+		UserId:       int64(*userID),
+		Name:         req.Name,
+		Instructions: req.Instructions,
+		Files:        toProjectFileProtos(req.Files),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to update project")
+	}
+
+	return toProjectData(res), nil
+}
+
+func (u *ProjectUseCaseImpl) DeleteProject(ctx context.Context, projectID *string, userID *uint) error {
+	// Convert projectID to uint
+	id, err := strconv.ParseUint(*projectID, 10, 64)
+	if err != nil {
+		return model.NewError(model.StatusBadRequest, "Project ID must be a number", nil)
+	}
+
+	// Get response from chat service
+	deleteCtx, cancelDelete := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelDelete()
+
+	_, err = u.Chat.Service.DeleteProject(deleteCtx, &proto.DeleteProjectRequest{
+		Id:     int64(id),
+		UserId: int64(*userID),
+	})
+	if err != nil {
+		return helper.GrpcError(err, "Failed to delete project")
+	}
+
+	return nil
+}
+
+func toProjectFileProtos(files []dto.ProjectFile) []*proto.ProjectFile {
+	res := make([]*proto.ProjectFile, 0, len(files))
+	for _, file := range files {
+		res = append(res, &proto.ProjectFile{
+			Name:    file.Name,
+			Content: file.Content,
+		})
+	}
+
+	return res
+}
+
+func toProjectData(project *proto.Project) *dto.ProjectData {
+	files := make([]dto.ProjectFile, 0, len(project.GetFiles()))
+	for _, file := range project.GetFiles() {
+		files = append(files, dto.ProjectFile{
+			ID:      uint(file.GetId()),
+			Name:    file.GetName(),
+			Content: file.GetContent(),
+		})
+	}
+
+	return &dto.ProjectData{
+		ID:           uint(project.GetId()),
+		UserID:       uint(project.GetUserId()),
+		Name:         project.GetName(),
+		Instructions: project.GetInstructions(),
+		Files:        files,
+	}
+}
@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"api-gateway/app/delivery/client"
+	"api-gateway/app/helper"
+	"api-gateway/app/model"
+	"api-gateway/app/model/dto"
+	"api-gateway/app/model/proto"
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+type PromptTemplateUseCase interface {
+	CreatePromptTemplate(ctx context.Context, userID *uint, organizationID *uint, req *dto.PromptTemplateRequest) (*dto.PromptTemplateData, error)
+	GetPromptTemplates(ctx context.Context, userID *uint, organizationID *uint) (*dto.GetPromptTemplatesResponse, error)
+	UpdatePromptTemplate(ctx context.Context, promptTemplateID *string, userID *uint, req *dto.PromptTemplateRequest) (*dto.PromptTemplateData, error)
+	DeletePromptTemplate(ctx context.Context, promptTemplateID *string, userID *uint) error
+}
+
+type PromptTemplateUseCaseImpl struct {
+	Validate *validator.Validate
+	Log      *logrus.Logger
+	Chat     *client.ChatClient
+}
+
+func NewPromptTemplateUseCaseImpl(
+	validate *validator.Validate,
+	log *logrus.Logger,
+	chat *client.ChatClient,
+) *PromptTemplateUseCaseImpl {
+	return &PromptTemplateUseCaseImpl{
+		Validate: validate,
+		Log:      log,
+		Chat:     chat,
+	}
+}
+
+// CreatePromptTemplate shares the template with the organization the user works in
+func (u *PromptTemplateUseCaseImpl) CreatePromptTemplate(ctx context.Context, userID *uint, organizationID *uint, req *dto.PromptTemplateRequest) (*dto.PromptTemplateData, error) {
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from chat service
+	createCtx, cancelCreate := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelCreate()
+
+	res, err := u.Chat.Service.CreatePromptTemplate(createCtx, &proto.CreatePromptTemplateRequest{
+		UserId:         int64(*userID),
+		OrganizationId: int64(*organizationID),
+		Name:           req.Name,
+		Content:        req.Content,
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to create prompt template")
+	}
+
+	return toPromptTemplateData(res), nil
+}
+
+func (u *PromptTemplateUseCaseImpl) GetPromptTemplates(ctx context.Context, userID *uint, organizationID *uint) (*dto.GetPromptTemplatesResponse, error) {
+	// Get response from chat service
+	getPromptTemplatesCtx, cancelGetPromptTemplates := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetPromptTemplates()
+
+	res, err := u.Chat.Service.GetPromptTemplates(getPromptTemplatesCtx, &proto.GetPromptTemplatesRequest{
+		UserId:         int64(*userID),
+		OrganizationId: int64(*organizationID),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to get prompt templates")
+	}
+
+	promptTemplates := make([]dto.PromptTemplateData, 0)
+	for _, promptTemplate := range res.GetPromptTemplates() {
+		promptTemplates = append(promptTemplates, *toPromptTemplateData(promptTemplate))
+	}
+
+	return &dto.GetPromptTemplatesResponse{
+		PromptTemplates: promptTemplates,
+	}, nil
+}
+
+func (u *PromptTemplateUseCaseImpl) UpdatePromptTemplate(ctx context.Context, promptTemplateID *string, userID *uint, req *dto.PromptTemplateRequest) (*dto.PromptTemplateData, error) {
+	// Convert promptTemplateID to uint
+	id, err := strconv.ParseUint(*promptTemplateID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "Prompt template ID must be a number", nil)
+	}
+
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from chat service
+	updateCtx, cancelUpdate := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelUpdate()
+
+	res, err := u.Chat.Service.UpdatePromptTemplate(updateCtx, &proto.UpdatePromptTemplateRequest{
+		Id:      int64(id),
+		UserId:  int64(*userID),
+		Name:    req.Name,
+		Content: req.Content,
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to update prompt template")
+	}
+
+	return toPromptTemplateData(res), nil
+}
+
+func (u *PromptTemplateUseCaseImpl) DeletePromptTemplate(ctx context.Context, promptTemplateID *string, userID *uint) error {
+	// Convert promptTemplateID to uint
+	id, err := strconv.ParseUint(*promptTemplateID, 10, 64)
+	if err != nil {
+		return model.NewError(model.StatusBadRequest, "Prompt template ID must be a number", nil)
+	}
+
+	// Get response from chat service
+	deleteCtx, cancelDelete := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelDelete()
+
+	_, err = u.Chat.Service.DeletePromptTemplate(deleteCtx, &proto.DeletePromptTemplateRequest{
+		Id:     int64(id),
+		UserId: int64(*userID),
+	})
+	if err != nil {
+		return helper.GrpcError(err, "Failed to delete prompt template")
+	}
+
+	return nil
+}
+
+func toPromptTemplateData(promptTemplate *proto.PromptTemplate) *dto.PromptTemplateData {
+	return &dto.PromptTemplateData{
+		ID:             uint(promptTemplate.GetId()),
+		UserID:         uint(promptTemplate.GetUserId()),
+		OrganizationID: optionalID(promptTemplate.GetOrganizationId()),
+		Name:           promptTemplate.GetName(),
+		Content:        promptTemplate.GetContent(),
+	}
+}
\ No newline at end of file
@@ -0,0 +1,251 @@
+package usecase
+
+import (
+	"api-gateway/app/delivery/client"
+	"api-gateway/app/helper"
+	"api-gateway/app/model"
+	"api-gateway/app/model/dto"
+	"api-gateway/app/model/proto"
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+type ScheduledPromptUseCase interface {
+	CreateScheduledPrompt(ctx context.Context, userID *uint, req *dto.ScheduledPromptRequest) (*dto.ScheduledPromptData, error)
+	GetScheduledPrompts(ctx context.Context, userID *uint) (*dto.GetScheduledPromptsResponse, error)
+	GetScheduledPrompt(ctx context.Context, scheduledPromptID *string, userID *uint) (*dto.ScheduledPromptData, error)
+	UpdateScheduledPrompt(ctx context.Context, scheduledPromptID *string, userID *uint, req *dto.ScheduledPromptRequest) (*dto.ScheduledPromptData, error)
+	DeleteScheduledPrompt(ctx context.Context, scheduledPromptID *string, userID *uint) error
+	GetScheduledPromptRuns(ctx context.Context, scheduledPromptID *string, userID *uint) (*dto.GetScheduledPromptRunsResponse, error)
+}
+
+type ScheduledPromptUseCaseImpl struct {
+	Validate *validator.Validate
+	Log      *logrus.Logger
+	Chat     *client.ChatClient
+}
+
+func NewScheduledPromptUseCaseImpl(
+	validate *validator.Validate,
+	log *logrus.Logger,
+	chat *client.ChatClient,
+) *ScheduledPromptUseCaseImpl {
+	return &ScheduledPromptUseCaseImpl{
+		Validate: validate,
+		Log:      log,
+		Chat:     chat,
+	}
+}
+
+func (u *ScheduledPromptUseCaseImpl) CreateScheduledPrompt(ctx context.Context, userID *uint, req *dto.ScheduledPromptRequest) (*dto.ScheduledPromptData, error) {
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from chat service
+	createCtx, cancelCreate := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelCreate()
+
+	res, err := u.Chat.Service.CreateScheduledPrompt(createCtx, &proto.CreateScheduledPromptRequest{
+		UserId:         int64(*userID),
+		Name:           req.Name,
+		Prompt:         req.Prompt,
+		CronExpression: req.CronExpression,
+		Timezone:       req.Timezone,
+		ChatId:         requestID(req.ChatID),
+		CatchUpPolicy:  req.CatchUpPolicy,
+		Enabled:        enabledOrDefault(req.Enabled),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to create scheduled prompt")
+	}
+
+	return toScheduledPromptData(res), nil
+}
+
+func (u *ScheduledPromptUseCaseImpl) GetScheduledPrompts(ctx context.Context, userID *uint) (*dto.GetScheduledPromptsResponse, error) {
+	// Get response from chat service
+	getScheduledPromptsCtx, cancelGetScheduledPrompts := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetScheduledPrompts()
+
+	res, err := u.Chat.Service.GetScheduledPrompts(getScheduledPromptsCtx, &proto.GetScheduledPromptsRequest{
+		UserId: int64(*userID),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to get scheduled prompts")
+	}
+
+	scheduledPrompts := make([]dto.ScheduledPromptData, 0)
+	for _, scheduledPrompt := range res.GetScheduledPrompts() {
+		scheduledPrompts = append(scheduledPrompts, *toScheduledPromptData(scheduledPrompt))
+	}
+
+	return &dto.GetScheduledPromptsResponse{
+		ScheduledPrompts: scheduledPrompts,
+	}, nil
+}
+
+func (u *ScheduledPromptUseCaseImpl) GetScheduledPrompt(ctx context.Context, scheduledPromptID *string, userID *uint) (*dto.ScheduledPromptData, error) {
+	// Convert scheduledPromptID to uint
+	id, err := strconv.ParseUint(*scheduledPromptID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "Scheduled prompt ID must be a number", nil)
+	}
+
+	// Get response from chat service
+	getScheduledPromptCtx, cancelGetScheduledPrompt := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetScheduledPrompt()
+
+	res, err := u.Chat.Service.GetScheduledPrompt(getScheduledPromptCtx, &proto.GetScheduledPromptRequest{
+		Id:     int64(id),
+		UserId: int64(*userID),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to get scheduled prompt")
+	}
+
+	return toScheduledPromptData(res), nil
+}
+
+func (u *ScheduledPromptUseCaseImpl) UpdateScheduledPrompt(ctx context.Context, scheduledPromptID *string, userID *uint, req *dto.ScheduledPromptRequest) (*dto.ScheduledPromptData, error) {
+	// Convert scheduledPromptID to uint
+	id, err := strconv.ParseUint(*scheduledPromptID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "Scheduled prompt ID must be a number", nil)
+	}
+
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from chat service
+	updateCtx, cancelUpdate := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelUpdate()
+
+	res, err := u.Chat.Service.UpdateScheduledPrompt(updateCtx, &proto.UpdateScheduledPromptRequest{
+		Id:             int64(id),
+		UserId:         int64(*userID),
+		Name:           req.Name,
+		Prompt:         req.Prompt,
+		CronExpression: req.CronExpression,
+		Timezone:       req.Timezone,
+		ChatId:         requestID(req.ChatID),
+		CatchUpPolicy:  req.CatchUpPolicy,
+		Enabled:        enabledOrDefault(req.Enabled),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to update scheduled prompt")
+	}
+
+	return toScheduledPromptData(res), nil
+}
+
+func (u *ScheduledPromptUseCaseImpl) DeleteScheduledPrompt(ctx context.Context, scheduledPromptID *string, userID *uint) error {
+	// Convert scheduledPromptID to uint
+	id, err := strconv.ParseUint(*scheduledPromptID, 10, 64)
+	if err != nil {
+		return model.NewError(model.StatusBadRequest, "Scheduled prompt ID must be a number", nil)
+	}
+
+	// Get response from chat service
+	deleteCtx, cancelDelete := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelDelete()
+
+	_, err = u.Chat.Service.DeleteScheduledPrompt(deleteCtx, &proto.DeleteScheduledPromptRequest{
+		Id:     int64(id),
+		UserId: int64(*userID),
+	})
+	if err != nil {
+		return helper.GrpcError(err, "Failed to delete scheduled prompt")
+	}
+
+	return nil
+}
+
+func (u *ScheduledPromptUseCaseImpl) GetScheduledPromptRuns(ctx context.Context, scheduledPromptID *string, userID *uint) (*dto.GetScheduledPromptRunsResponse, error) {
+	// Convert scheduledPromptID to uint
+	id, err := strconv.ParseUint(*scheduledPromptID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "Scheduled prompt ID must be a number", nil)
+	}
+
+	// Get response from chat service
+	getRunsCtx, cancelGetRuns := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetRuns()
+
+	res, err := u.Chat.Service.GetScheduledPromptRuns(getRunsCtx, &proto.GetScheduledPromptRunsRequest{
+		Id:     int64(id),
+		UserId: int64(*userID),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to get scheduled prompt runs")
+	}
+
+	runs := make([]dto.ScheduledPromptRunData, 0)
+	for _, run := range res.GetRuns() {
+		runs = append(runs, dto.ScheduledPromptRunData{
+			ID:                uint(run.GetId()),
+			ScheduledPromptID: uint(run.GetScheduledPromptId()),
+			ChatID:            optionalID(run.GetChatId()),
+			Status:            run.GetStatus(),
+			ScheduledFor:      optionalTime(run.GetScheduledFor()),
+			StartedAt:         optionalTime(run.GetStartedAt()),
+			FinishedAt:        optionalTime(run.GetFinishedAt()),
+			Error:             run.GetError(),
+		})
+	}
+
+	return &dto.GetScheduledPromptRunsResponse{
+		Runs: runs,
+	}, nil
+}
+
+// requestID converts an optional id to its proto form, where 0 means unset
+func requestID(id *uint) int64 {
+	if id == nil {
+		return 0
+	}
+
+	return int64(*id)
+}
+
+// enabledOrDefault enables scheduled prompts unless the request says otherwise
+func enabledOrDefault(enabled *bool) bool {
+	if enabled == nil {
+		return true
+	}
+
+	return *enabled
+}
+
+// optionalTime converts unix seconds, where 0 means unset, to an optional time
+func optionalTime(unix int64) *time.Time {
+	if unix == 0 {
+		return nil
+	}
+
+	value := time.Unix(unix, 0).UTC()
+	return &value
+}
+
+func toScheduledPromptData(scheduledPrompt *proto.ScheduledPrompt) *dto.ScheduledPromptData {
+	return &dto.ScheduledPromptData{
+		ID:             uint(scheduledPrompt.GetId()),
+		UserID:         uint(scheduledPrompt.GetUserId()),
+		Name:           scheduledPrompt.GetName(),
+		Prompt:         scheduledPrompt.GetPrompt(),
+		CronExpression: scheduledPrompt.GetCronExpression(),
+		Timezone:       scheduledPrompt.GetTimezone(),
+		ChatID:         optionalID(scheduledPrompt.GetChatId()),
+		CatchUpPolicy:  scheduledPrompt.GetCatchUpPolicy(),
+		Enabled:        scheduledPrompt.GetEnabled(),
+		NextRunAt:      optionalTime(scheduledPrompt.GetNextRunAt()),
+		LastRunAt:      optionalTime(scheduledPrompt.GetLastRunAt()),
+	}
+}
@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"api-gateway/app/delivery/client"
+	"api-gateway/app/helper"
+	"api-gateway/app/model"
+	"api-gateway/app/model/dto"
+	"api-gateway/app/model/proto"
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+type SessionUseCase interface {
+	GetSessions(ctx context.Context, accessToken string) (*dto.GetSessionsResponse, error)
+	RevokeSession(ctx context.Context, sessionID *string, accessToken string) error
+}
+
+type SessionUseCaseImpl struct {
+	Validate *validator.Validate
+	Log      *logrus.Logger
+	Auth     *client.AuthClient
+}
+
+func NewSessionUseCaseImpl(
+	validate *validator.Validate,
+	log *logrus.Logger,
+	auth *client.AuthClient,
+) *SessionUseCaseImpl {
+	return &SessionUseCaseImpl{
+		Validate: validate,
+		Log:      log,
+		Auth:     auth,
+	}
+}
+
+func (u *SessionUseCaseImpl) GetSessions(ctx context.Context, accessToken string) (*dto.GetSessionsResponse, error) {
+	// Get response from auth service
+	listCtx, cancelList := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelList()
+
+	res, err := u.Auth.Service.ListSessions(listCtx, &proto.ListSessionsRequest{
+		AccessToken: accessToken,
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to get sessions")
+	}
+
+	sessions := make([]dto.SessionData, 0)
+	for _, session := range res.GetSessions() {
+		sessions = append(sessions, dto.SessionData{
+			ID:         uint(session.GetId()),
+			UserAgent:  session.GetUserAgent(),
+			IPAddress:  session.GetIpAddress(),
+			LastSeenAt: session.GetLastSeenAt(),
+			CreatedAt:  session.GetCreatedAt(),
+			Current:    session.GetCurrent(),
+		})
+	}
+
+	return &dto.GetSessionsResponse{
+		Sessions: sessions,
+	}, nil
+}
+
+func (u *SessionUseCaseImpl) RevokeSession(ctx context.Context, sessionID *string, accessToken string) error {
+	// Convert sessionID to uint
+	id, err := strconv.ParseUint(*sessionID, 10, 64)
+	if err != nil {
+		return model.NewError(model.StatusBadRequest, "Session ID must be a number", nil)
+	}
+
+	// Get response from auth service
+	revokeCtx, cancelRevoke := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelRevoke()
+
+	_, err = u.Auth.Service.RevokeSession(revokeCtx, &proto.RevokeSessionRequest{
+		AccessToken: accessToken,
+		SessionId:   int64(id),
+	})
+	if err != nil {
+		return helper.GrpcError(err, "Failed to revoke session")
+	}
+
+	return nil
+}
\ No newline at end of file
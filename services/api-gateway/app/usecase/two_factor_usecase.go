@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"api-gateway/app/delivery/client"
+	"api-gateway/app/helper"
+	"api-gateway/app/model"
+	"api-gateway/app/model/dto"
+	"api-gateway/app/model/proto"
+	"context"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+type TwoFactorUseCase interface {
+	EnrollTotp(ctx context.Context, accessToken string) (*dto.EnrollTotpResponse, error)
+	ConfirmTotp(ctx context.Context, req *dto.TotpCodeRequest, accessToken string) (*dto.ConfirmTotpResponse, error)
+	DisableTotp(ctx context.Context, req *dto.TotpCodeRequest, accessToken string) error
+	CompleteChallenge(ctx context.Context, req *dto.CompleteTwoFactorLoginRequest) (*dto.LoginResponse, error)
+}
+
+type TwoFactorUseCaseImpl struct {
+	Validate *validator.Validate
+	Log      *logrus.Logger
+	Auth     *client.AuthClient
+}
+
+func NewTwoFactorUseCaseImpl(
+	validate *validator.Validate,
+	log *logrus.Logger,
+	auth *client.AuthClient,
+) *TwoFactorUseCaseImpl {
+	return &TwoFactorUseCaseImpl{
+		Validate: validate,
+		Log:      log,
+		Auth:     auth,
+	}
+}
+
+func (u *TwoFactorUseCaseImpl) EnrollTotp(ctx context.Context, accessToken string) (*dto.EnrollTotpResponse, error) {
+	// Get response from auth service
+	enrollCtx, cancelEnroll := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelEnroll()
+
+	res, err := u.Auth.Service.EnrollTotp(enrollCtx, &proto.EnrollTotpRequest{
+		AccessToken: accessToken,
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to enroll two-factor authentication")
+	}
+
+	return &dto.EnrollTotpResponse{
+		Secret: res.GetSecret(),
+		URI:    res.GetUri(),
+	}, nil
+}
+
+func (u *TwoFactorUseCaseImpl) ConfirmTotp(ctx context.Context, req *dto.TotpCodeRequest, accessToken string) (*dto.ConfirmTotpResponse, error) {
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from auth service
+	confirmCtx, cancelConfirm := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelConfirm()
+
+	res, err := u.Auth.Service.ConfirmTotp(confirmCtx, &proto.ConfirmTotpRequest{
+		AccessToken: accessToken,
+		Code:        req.Code,
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to confirm two-factor authentication")
+	}
+
+	return &dto.ConfirmTotpResponse{
+		RecoveryCodes: res.GetRecoveryCodes(),
+	}, nil
+}
+
+func (u *TwoFactorUseCaseImpl) DisableTotp(ctx context.Context, req *dto.TotpCodeRequest, accessToken string) error {
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from auth service
+	disableCtx, cancelDisable := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelDisable()
+
+	_, err := u.Auth.Service.DisableTotp(disableCtx, &proto.DisableTotpRequest{
+		AccessToken: accessToken,
+		Code:        req.Code,
+	})
+	if err != nil {
+		return helper.GrpcError(err, "Failed to disable two-factor authentication")
+	}
+
+	return nil
+}
+
+func (u *TwoFactorUseCaseImpl) CompleteChallenge(ctx context.Context, req *dto.CompleteTwoFactorLoginRequest) (*dto.LoginResponse, error) {
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from auth service
+	completeCtx, cancelComplete := context.WithTimeout(ctx, 5 * time.Second)
+	defer cancelComplete()
+
+	res, err := u.Auth.Service.CompleteTwoFactorLogin(completeCtx, &proto.CompleteTwoFactorLoginRequest{
+		ChallengeToken: req.ChallengeToken,
+		Code:           req.Code,
+		Client:         toClientInfo(req.Client),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to complete two-factor authentication")
+	}
+
+	return toLoginResponse(res), nil
+}
\ No newline at end of file
@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"api-gateway/app/delivery/client"
+	"api-gateway/app/helper"
+	"api-gateway/app/model"
+	"api-gateway/app/model/dto"
+	"api-gateway/app/model/proto"
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+type WebhookUseCase interface {
+	CreateWebhook(ctx context.Context, userID *uint, req *dto.WebhookRequest) (*dto.WebhookData, error)
+	GetWebhooks(ctx context.Context, userID *uint) (*dto.GetWebhooksResponse, error)
+	GetWebhook(ctx context.Context, webhookID *string, userID *uint) (*dto.WebhookData, error)
+	UpdateWebhook(ctx context.Context, webhookID *string, userID *uint, req *dto.WebhookRequest) (*dto.WebhookData, error)
+	DeleteWebhook(ctx context.Context, webhookID *string, userID *uint) error
+	GetWebhookDeliveries(ctx context.Context, webhookID *string, userID *uint) (*dto.GetWebhookDeliveriesResponse, error)
+	RedeliverWebhookDelivery(ctx context.Context, webhookID *string, deliveryID *string, userID *uint) (*dto.WebhookDeliveryData, error)
+}
+
+type WebhookUseCaseImpl struct {
+	Validate *validator.Validate
+	Log      *logrus.Logger
+	Chat     *client.ChatClient
+}
+
+func NewWebhookUseCaseImpl(
+	validate *validator.Validate,
+	log *logrus.Logger,
+	chat *client.ChatClient,
+) *WebhookUseCaseImpl {
+	return &WebhookUseCaseImpl{
+		Validate: validate,
+		Log:      log,
+		Chat:     chat,
+	}
+}
+
+func (u *WebhookUseCaseImpl) CreateWebhook(ctx context.Context, userID *uint, req *dto.WebhookRequest) (*dto.WebhookData, error) {
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from chat service
+	createCtx, cancelCreate := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelCreate()
+
+	res, err := u.Chat.Service.CreateWebhook(createCtx, &proto.CreateWebhookRequest{
+		UserId:  int64(*userID),
+		Url:     req.URL,
+		Events:  req.Events,
+		Enabled: enabledOrDefault(req.Enabled),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to create webhook")
+	}
+
+	return toWebhookData(res), nil
+}
+
+func (u *WebhookUseCaseImpl) GetWebhooks(ctx context.Context, userID *uint) (*dto.GetWebhooksResponse, error) {
+	// Get response from chat service
+	getWebhooksCtx, cancelGetWebhooks := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetWebhooks()
+
+	res, err := u.Chat.Service.GetWebhooks(getWebhooksCtx, &proto.GetWebhooksRequest{
+		UserId: int64(*userID),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to get webhooks")
+	}
+
+	webhooks := make([]dto.WebhookData, 0)
+	for _, webhook := range res.GetWebhooks() {
+		webhooks = append(webhooks, *toWebhookData(webhook))
+	}
+
+	return &dto.GetWebhooksResponse{
+		Webhooks: webhooks,
+	}, nil
+}
+
+func (u *WebhookUseCaseImpl) GetWebhook(ctx context.Context, webhookID *string, userID *uint) (*dto.WebhookData, error) {
+	// Convert webhookID to uint
+	id, err := strconv.ParseUint(*webhookID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "Webhook ID must be a number", nil)
+	}
+
+	// Get response from chat service
+	getWebhookCtx, cancelGetWebhook := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetWebhook()
+
+	res, err := u.Chat.Service.GetWebhook(getWebhookCtx, &proto.GetWebhookRequest{
+		Id:     int64(id),
+		UserId: int64(*userID),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to get webhook")
+	}
+
+	return toWebhookData(res), nil
+}
+
+func (u *WebhookUseCaseImpl) UpdateWebhook(ctx context.Context, webhookID *string, userID *uint, req *dto.WebhookRequest) (*dto.WebhookData, error) {
+	// Convert webhookID to uint
+	id, err := strconv.ParseUint(*webhookID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "Webhook ID must be a number", nil)
+	}
+
+	// Validate request
+	if errors := helper.Validate(u.Validate, req); len(errors) > 0 {
+		return nil, model.NewError(model.StatusBadRequest, "The given data was invalid", errors)
+	}
+
+	// Get response from chat service
+	updateCtx, cancelUpdate := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelUpdate()
+
+	res, err := u.Chat.Service.UpdateWebhook(updateCtx, &proto.UpdateWebhookRequest{
+		Id:      int64(id),
+		UserId:  int64(*userID),
+		Url:     req.URL,
+		Events:  req.Events,
+		Enabled: enabledOrDefault(req.Enabled),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to update webhook")
+	}
+
+	return toWebhookData(res), nil
+}
+
+func (u *WebhookUseCaseImpl) DeleteWebhook(ctx context.Context, webhookID *string, userID *uint) error {
+	// Convert webhookID to uint
+	id, err := strconv.ParseUint(*webhookID, 10, 64)
+	if err != nil {
+		return model.NewError(model.StatusBadRequest, "Webhook ID must be a number", nil)
+	}
+
+	// Get response from chat service
+	deleteCtx, cancelDelete := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelDelete()
+
+	_, err = u.Chat.Service.DeleteWebhook(deleteCtx, &proto.DeleteWebhookRequest{
+		Id:     int64(id),
+		UserId: int64(*userID),
+	})
+	if err != nil {
+		return helper.GrpcError(err, "Failed to delete webhook")
+	}
+
+	return nil
+}
+
+func (u *WebhookUseCaseImpl) GetWebhookDeliveries(ctx context.Context, webhookID *string, userID *uint) (*dto.GetWebhookDeliveriesResponse, error) {
+	// Convert webhookID to uint
+	id, err := strconv.ParseUint(*webhookID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "Webhook ID must be a number", nil)
+	}
+
+	// Get response from chat service
+	getDeliveriesCtx, cancelGetDeliveries := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetDeliveries()
+
+	res, err := u.Chat.Service.GetWebhookDeliveries(getDeliveriesCtx, &proto.GetWebhookDeliveriesRequest{
+		Id:     int64(id),
+		UserId: int64(*userID),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to get webhook deliveries")
+	}
+
+	deliveries := make([]dto.WebhookDeliveryData, 0)
+	for _, delivery := range res.GetDeliveries() {
+		deliveries = append(deliveries, *toWebhookDeliveryData(delivery))
+	}
+
+	return &dto.GetWebhookDeliveriesResponse{
+		Deliveries: deliveries,
+	}, nil
+}
+
+func (u *WebhookUseCaseImpl) RedeliverWebhookDelivery(ctx context.Context, webhookID *string, deliveryID *string, userID *uint) (*dto.WebhookDeliveryData, error) {
+	// Convert webhookID and deliveryID to uint
+	webhookIDUint, err := strconv.ParseUint(*webhookID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "Webhook ID must be a number", nil)
+	}
+
+	deliveryIDUint, err := strconv.ParseUint(*deliveryID, 10, 64)
+	if err != nil {
+		return nil, model.NewError(model.StatusBadRequest, "Delivery ID must be a number", nil)
+	}
+
+	// Get response from chat service
+	redeliverCtx, cancelRedeliver := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelRedeliver()
+
+	res, err := u.Chat.Service.RedeliverWebhookDelivery(redeliverCtx, &proto.RedeliverWebhookDeliveryRequest{
+		Id:        int64(deliveryIDUint),
+		WebhookId: int64(webhookIDUint),
+		UserId:    int64(*userID),
+	})
+	if err != nil {
+		return nil, helper.GrpcError(err, "Failed to redeliver webhook")
+	}
+
+	return toWebhookDeliveryData(res), nil
+}
+
+func toWebhookData(webhook *proto.Webhook) *dto.WebhookData {
+	return &dto.WebhookData{
+		ID:      uint(webhook.GetId()),
+		UserID:  uint(webhook.GetUserId()),
+		URL:     webhook.GetUrl(),
+		Secret:  webhook.GetSecret(),
+		Events:  webhook.GetEvents(),
+		Enabled: webhook.GetEnabled(),
+	}
+}
+
+func toWebhookDeliveryData(delivery *proto.WebhookDelivery) *dto.WebhookDeliveryData {
+	return &dto.WebhookDeliveryData{
+		ID:             uint(delivery.GetId()),
+		WebhookID:      uint(delivery.GetWebhookId()),
+		Event:          delivery.GetEvent(),
+		Payload:        delivery.GetPayload(),
+		Status:         delivery.GetStatus(),
+		Attempts:       delivery.GetAttempts(),
+		ResponseStatus: delivery.GetResponseStatus(),
+		Error:          delivery.GetError(),
+		NextAttemptAt:  optionalTime(delivery.GetNextAttemptAt()),
+		DeliveredAt:    optionalTime(delivery.GetDeliveredAt()),
+		CreatedAt:      optionalTime(delivery.GetCreatedAt()),
+	}
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"auth-service/app/delivery/client"
 	"auth-service/app/route"
+	"auth-service/app/usecase"
 
 	"github.com/spf13/viper"
 )
@@ -11,16 +12,25 @@ type App struct {
 	Config *viper.Viper
 	GrpcServerRouter *route.GrpcServerRouter
 	UserClient *client.UserClient
+	ChatClient *client.ChatClient
+	KeyRotator *usecase.KeyRotator
+	AccountWorker *usecase.AccountWorker
 }
 
 func NewApp(
 	config *viper.Viper,
 	grpcServerRouter *route.GrpcServerRouter,
 	userClient *client.UserClient,
+	chatClient *client.ChatClient,
+	keyRotator *usecase.KeyRotator,
+	accountWorker *usecase.AccountWorker,
 ) *App {
 	return &App{
 		Config: config,
 		GrpcServerRouter: grpcServerRouter,
 		UserClient: userClient,
+		ChatClient: chatClient,
+		KeyRotator: keyRotator,
+		AccountWorker: accountWorker,
 	}
 }
\ No newline at end of file
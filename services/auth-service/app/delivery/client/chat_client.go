@@ -0,0 +1,55 @@
+package client
+
+import (
+	"auth-service/app/helper"
+	"auth-service/app/model/proto"
+	"fmt"
+
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// ChatClient reaches the chat service for the account exports and deletions.
+// Calls are made for the user with WithUserID, the chat service binds the user from it.
+type ChatClient struct {
+	Service proto.ChatServiceClient
+	Conn *grpc.ClientConn
+}
+
+func NewChatClient(config *viper.Viper) *ChatClient {
+	chatHost := config.GetString("GRPC_CHAT_SERVICE")
+	secure := config.GetBool("GRPC_CHAT_SERVICE_SECURE")
+
+	var opts []grpc.DialOption
+
+	if chatHost != "" {
+		opts = append(opts, grpc.WithAuthority(chatHost))
+	}
+
+	if !secure {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	} else {
+		// Presents the certificate of the service when the server requires mutual TLS
+		tlsConfig, err := helper.ClientTLSConfig(config)
+		if err != nil {
+			panic(err)
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	}
+
+	opts = append(opts, internalIdentityOptions(config)...)
+
+	chatConn, err := grpc.NewClient(chatHost, opts...)
+	if err != nil {
+		panic(fmt.Errorf("error connecting to chat service: %v", err))
+	}
+
+	chatService := proto.NewChatServiceClient(chatConn)
+
+	return &ChatClient{
+		Service: chatService,
+		Conn: chatConn,
+	}
+}
\ No newline at end of file
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"auth-service/app/helper"
+	"context"
+
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// internalServiceName identifies the service in the internal identity tokens it signs
+const internalServiceName = "auth-service"
+
+type userIDKey struct{}
+
+// WithUserID marks the calls made with the context as made on behalf of the user,
+// the user service takes the acting user from the signed identity instead of the request
+func WithUserID(ctx context.Context, userID int64) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+func userIDFromContext(ctx context.Context) int64 {
+	userID, _ := ctx.Value(userIDKey{}).(int64)
+	return userID
+}
+
+// internalIdentityOptions sign every call with INTERNAL_AUTH_SECRET, nothing is
+// attached while the secret is not configured
+func internalIdentityOptions(config *viper.Viper) []grpc.DialOption {
+	secret := []byte(config.GetString("INTERNAL_AUTH_SECRET"))
+	if len(secret) == 0 {
+		return nil
+	}
+
+	sign := func(ctx context.Context, method string) (context.Context, error) {
+		token, err := helper.SignInternalIdentity(secret, internalServiceName, userIDFromContext(ctx), method)
+		if err != nil {
+			return nil, err
+		}
+		return metadata.AppendToOutgoingContext(ctx, helper.InternalIdentityHeader, token), nil
+	}
+
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			ctx, err := sign(ctx, method)
+			if err != nil {
+				return err
+			}
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}),
+		grpc.WithChainStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			ctx, err := sign(ctx, method)
+			if err != nil {
+				return nil, err
+			}
+			return streamer(ctx, desc, cc, method, opts...)
+		}),
+	}
+}
\ No newline at end of file
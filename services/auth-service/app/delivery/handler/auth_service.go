@@ -13,6 +13,64 @@ type AuthService interface {
     LoginWithGoogle(ctx context.Context, req *proto.LoginWithGoogleRequest) (*proto.AuthenticatedResponse, error)
     GetProfile(ctx context.Context, req *proto.GetProfileRequest) (*proto.GetProfileResponse, error)
     Refresh(ctx context.Context, req *proto.RefreshRequest) (*proto.AuthenticatedResponse, error)
+    Logout(ctx context.Context, req *proto.LogoutRequest) (*proto.LogoutResponse, error)
+    LogoutAll(ctx context.Context, req *proto.LogoutAllRequest) (*proto.LogoutResponse, error)
+    GetJwks(ctx context.Context, req *proto.GetJwksRequest) (*proto.GetJwksResponse, error)
+    ListSessions(ctx context.Context, req *proto.ListSessionsRequest) (*proto.ListSessionsResponse, error)
+    RevokeSession(ctx context.Context, req *proto.RevokeSessionRequest) (*proto.LogoutResponse, error)
+    ListRevokedSessions(ctx context.Context, req *proto.ListRevokedSessionsRequest) (*proto.ListRevokedSessionsResponse, error)
+    ResendVerificationEmail(ctx context.Context, req *proto.ResendVerificationEmailRequest) (*proto.EmailSentResponse, error)
+    VerifyEmail(ctx context.Context, req *proto.VerifyEmailRequest) (*proto.VerifyEmailResponse, error)
+    RequestPasswordReset(ctx context.Context, req *proto.RequestPasswordResetRequest) (*proto.EmailSentResponse, error)
+    ResetPassword(ctx context.Context, req *proto.ResetPasswordRequest) (*proto.ResetPasswordResponse, error)
+    EnrollTotp(ctx context.Context, req *proto.EnrollTotpRequest) (*proto.EnrollTotpResponse, error)
+    ConfirmTotp(ctx context.Context, req *proto.ConfirmTotpRequest) (*proto.ConfirmTotpResponse, error)
+    DisableTotp(ctx context.Context, req *proto.DisableTotpRequest) (*proto.DisableTotpResponse, error)
+    CompleteTwoFactorLogin(ctx context.Context, req *proto.CompleteTwoFactorLoginRequest) (*proto.AuthenticatedResponse, error)
+    LoginWithProvider(ctx context.Context, req *proto.LoginWithProviderRequest) (*proto.AuthenticatedResponse, error)
+    ListIdentities(ctx context.Context, req *proto.ListIdentitiesRequest) (*proto.ListIdentitiesResponse, error)
+    LinkIdentity(ctx context.Context, req *proto.LinkIdentityRequest) (*proto.Identity, error)
+    UnlinkIdentity(ctx context.Context, req *proto.UnlinkIdentityRequest) (*proto.UnlinkIdentityResponse, error)
+    CreateApiKey(ctx context.Context, req *proto.CreateApiKeyRequest) (*proto.CreateApiKeyResponse, error)
+    ListApiKeys(ctx context.Context, req *proto.ListApiKeysRequest) (*proto.ListApiKeysResponse, error)
+    GetApiKey(ctx context.Context, req *proto.GetApiKeyRequest) (*proto.ApiKey, error)
+    UpdateApiKey(ctx context.Context, req *proto.UpdateApiKeyRequest) (*proto.ApiKey, error)
+    DeleteApiKey(ctx context.Context, req *proto.DeleteApiKeyRequest) (*proto.DeleteApiKeyResponse, error)
+    ResolveApiKey(ctx context.Context, req *proto.ResolveApiKeyRequest) (*proto.ResolveApiKeyResponse, error)
+    AdminListUsers(ctx context.Context, req *proto.AdminListUsersRequest) (*proto.AdminListUsersResponse, error)
+    AdminSuspendUser(ctx context.Context, req *proto.AdminSuspendUserRequest) (*proto.AdminUser, error)
+    AdminUpdateUserRole(ctx context.Context, req *proto.AdminUpdateUserRoleRequest) (*proto.AdminUser, error)
+    AdminGetUserStats(ctx context.Context, req *proto.AdminGetUserStatsRequest) (*proto.AdminUserStats, error)
+    AdminUnlockUser(ctx context.Context, req *proto.AdminUnlockUserRequest) (*proto.AdminUser, error)
+    RecordAuditEvent(ctx context.Context, req *proto.RecordAuditEventRequest) (*proto.AuditLog, error)
+    AdminListAuditLogs(ctx context.Context, req *proto.AdminListAuditLogsRequest) (*proto.AdminListAuditLogsResponse, error)
+    AdminVerifyAuditLogs(ctx context.Context, req *proto.AdminVerifyAuditLogsRequest) (*proto.AdminVerifyAuditLogsResponse, error)
+    GetMe(ctx context.Context, req *proto.GetMeRequest) (*proto.Me, error)
+    UpdateMe(ctx context.Context, req *proto.UpdateMeRequest) (*proto.AuthenticatedResponse, error)
+    ChangePassword(ctx context.Context, req *proto.ChangePasswordRequest) (*proto.AuthenticatedResponse, error)
+    RequestEmailChange(ctx context.Context, req *proto.RequestEmailChangeRequest) (*proto.EmailSentResponse, error)
+    ConfirmEmailChange(ctx context.Context, req *proto.ConfirmEmailChangeRequest) (*proto.VerifyEmailResponse, error)
+    GetPreferences(ctx context.Context, req *proto.GetPreferencesRequest) (*proto.Preferences, error)
+    UpdatePreferences(ctx context.Context, req *proto.UpdatePreferencesRequest) (*proto.Preferences, error)
+    RequestAccountExport(ctx context.Context, req *proto.RequestAccountExportRequest) (*proto.AccountExport, error)
+    ListAccountExports(ctx context.Context, req *proto.ListAccountExportsRequest) (*proto.ListAccountExportsResponse, error)
+    DownloadAccountExport(req *proto.DownloadAccountExportRequest, stream proto.AuthService_DownloadAccountExportServer) error
+    RequestAccountDeletion(ctx context.Context, req *proto.RequestAccountDeletionRequest) (*proto.AccountDeletion, error)
+    CancelAccountDeletion(ctx context.Context, req *proto.CancelAccountDeletionRequest) (*proto.AccountDeletion, error)
+    AdminListAccountDeletions(ctx context.Context, req *proto.AdminListAccountDeletionsRequest) (*proto.AdminListAccountDeletionsResponse, error)
+    SwitchOrganization(ctx context.Context, req *proto.SwitchOrganizationRequest) (*proto.AuthenticatedResponse, error)
+    CreateOrganization(ctx context.Context, req *proto.CreateOrganizationRequest) (*proto.Organization, error)
+    ListOrganizations(ctx context.Context, req *proto.ListOrganizationsRequest) (*proto.ListOrganizationsResponse, error)
+    GetOrganization(ctx context.Context, req *proto.GetOrganizationRequest) (*proto.Organization, error)
+    UpdateOrganization(ctx context.Context, req *proto.UpdateOrganizationRequest) (*proto.Organization, error)
+    DeleteOrganization(ctx context.Context, req *proto.DeleteOrganizationRequest) (*proto.DeleteOrganizationResponse, error)
+    ListOrganizationMembers(ctx context.Context, req *proto.ListOrganizationMembersRequest) (*proto.ListOrganizationMembersResponse, error)
+    UpdateOrganizationMember(ctx context.Context, req *proto.UpdateOrganizationMemberRequest) (*proto.OrganizationMember, error)
+    RemoveOrganizationMember(ctx context.Context, req *proto.RemoveOrganizationMemberRequest) (*proto.DeleteOrganizationResponse, error)
+    InviteOrganizationMember(ctx context.Context, req *proto.InviteOrganizationMemberRequest) (*proto.OrganizationInvite, error)
+    ListOrganizationInvites(ctx context.Context, req *proto.ListOrganizationInvitesRequest) (*proto.ListOrganizationInvitesResponse, error)
+    RevokeOrganizationInvite(ctx context.Context, req *proto.RevokeOrganizationInviteRequest) (*proto.DeleteOrganizationResponse, error)
+    AcceptOrganizationInvite(ctx context.Context, req *proto.AcceptOrganizationInviteRequest) (*proto.Organization, error)
 }
 
 type AuthServiceImpl struct {
@@ -70,3 +128,521 @@ func (s *AuthServiceImpl) Refresh(ctx context.Context, req *proto.RefreshRequest
 
     return res, nil
 }
+
+func (s *AuthServiceImpl) Logout(ctx context.Context, req *proto.LogoutRequest) (*proto.LogoutResponse, error) {
+    res, err := s.authUseCase.Logout(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) LogoutAll(ctx context.Context, req *proto.LogoutAllRequest) (*proto.LogoutResponse, error) {
+    res, err := s.authUseCase.LogoutAll(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) GetJwks(ctx context.Context, req *proto.GetJwksRequest) (*proto.GetJwksResponse, error) {
+    res, err := s.authUseCase.GetJwks(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+
+func (s *AuthServiceImpl) ListSessions(ctx context.Context, req *proto.ListSessionsRequest) (*proto.ListSessionsResponse, error) {
+    res, err := s.authUseCase.ListSessions(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) RevokeSession(ctx context.Context, req *proto.RevokeSessionRequest) (*proto.LogoutResponse, error) {
+    res, err := s.authUseCase.RevokeSession(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) ListRevokedSessions(ctx context.Context, req *proto.ListRevokedSessionsRequest) (*proto.ListRevokedSessionsResponse, error) {
+    res, err := s.authUseCase.ListRevokedSessions(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) ResendVerificationEmail(ctx context.Context, req *proto.ResendVerificationEmailRequest) (*proto.EmailSentResponse, error) {
+    res, err := s.authUseCase.ResendVerificationEmail(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) VerifyEmail(ctx context.Context, req *proto.VerifyEmailRequest) (*proto.VerifyEmailResponse, error) {
+    res, err := s.authUseCase.VerifyEmail(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) RequestPasswordReset(ctx context.Context, req *proto.RequestPasswordResetRequest) (*proto.EmailSentResponse, error) {
+    res, err := s.authUseCase.RequestPasswordReset(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) ResetPassword(ctx context.Context, req *proto.ResetPasswordRequest) (*proto.ResetPasswordResponse, error) {
+    res, err := s.authUseCase.ResetPassword(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) EnrollTotp(ctx context.Context, req *proto.EnrollTotpRequest) (*proto.EnrollTotpResponse, error) {
+    res, err := s.authUseCase.EnrollTotp(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) ConfirmTotp(ctx context.Context, req *proto.ConfirmTotpRequest) (*proto.ConfirmTotpResponse, error) {
+    res, err := s.authUseCase.ConfirmTotp(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) DisableTotp(ctx context.Context, req *proto.DisableTotpRequest) (*proto.DisableTotpResponse, error) {
+    res, err := s.authUseCase.DisableTotp(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) CompleteTwoFactorLogin(ctx context.Context, req *proto.CompleteTwoFactorLoginRequest) (*proto.AuthenticatedResponse, error) {
+    res, err := s.authUseCase.CompleteTwoFactorLogin(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) LoginWithProvider(ctx context.Context, req *proto.LoginWithProviderRequest) (*proto.AuthenticatedResponse, error) {
+    res, err := s.authUseCase.LoginWithProvider(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) ListIdentities(ctx context.Context, req *proto.ListIdentitiesRequest) (*proto.ListIdentitiesResponse, error) {
+    res, err := s.authUseCase.ListIdentities(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) LinkIdentity(ctx context.Context, req *proto.LinkIdentityRequest) (*proto.Identity, error) {
+    res, err := s.authUseCase.LinkIdentity(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) UnlinkIdentity(ctx context.Context, req *proto.UnlinkIdentityRequest) (*proto.UnlinkIdentityResponse, error) {
+    res, err := s.authUseCase.UnlinkIdentity(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) CreateApiKey(ctx context.Context, req *proto.CreateApiKeyRequest) (*proto.CreateApiKeyResponse, error) {
+    res, err := s.authUseCase.CreateApiKey(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) ListApiKeys(ctx context.Context, req *proto.ListApiKeysRequest) (*proto.ListApiKeysResponse, error) {
+    res, err := s.authUseCase.ListApiKeys(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) GetApiKey(ctx context.Context, req *proto.GetApiKeyRequest) (*proto.ApiKey, error) {
+    res, err := s.authUseCase.GetApiKey(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) UpdateApiKey(ctx context.Context, req *proto.UpdateApiKeyRequest) (*proto.ApiKey, error) {
+    res, err := s.authUseCase.UpdateApiKey(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) DeleteApiKey(ctx context.Context, req *proto.DeleteApiKeyRequest) (*proto.DeleteApiKeyResponse, error) {
+    res, err := s.authUseCase.DeleteApiKey(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) ResolveApiKey(ctx context.Context, req *proto.ResolveApiKeyRequest) (*proto.ResolveApiKeyResponse, error) {
+    res, err := s.authUseCase.ResolveApiKey(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) AdminListUsers(ctx context.Context, req *proto.AdminListUsersRequest) (*proto.AdminListUsersResponse, error) {
+    res, err := s.authUseCase.AdminListUsers(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) AdminSuspendUser(ctx context.Context, req *proto.AdminSuspendUserRequest) (*proto.AdminUser, error) {
+    res, err := s.authUseCase.AdminSuspendUser(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) AdminUpdateUserRole(ctx context.Context, req *proto.AdminUpdateUserRoleRequest) (*proto.AdminUser, error) {
+    res, err := s.authUseCase.AdminUpdateUserRole(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) AdminGetUserStats(ctx context.Context, req *proto.AdminGetUserStatsRequest) (*proto.AdminUserStats, error) {
+    res, err := s.authUseCase.AdminGetUserStats(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) AdminUnlockUser(ctx context.Context, req *proto.AdminUnlockUserRequest) (*proto.AdminUser, error) {
+    res, err := s.authUseCase.AdminUnlockUser(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) RecordAuditEvent(ctx context.Context, req *proto.RecordAuditEventRequest) (*proto.AuditLog, error) {
+    res, err := s.authUseCase.RecordAuditEvent(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) AdminListAuditLogs(ctx context.Context, req *proto.AdminListAuditLogsRequest) (*proto.AdminListAuditLogsResponse, error) {
+    res, err := s.authUseCase.AdminListAuditLogs(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) AdminVerifyAuditLogs(ctx context.Context, req *proto.AdminVerifyAuditLogsRequest) (*proto.AdminVerifyAuditLogsResponse, error) {
+    res, err := s.authUseCase.AdminVerifyAuditLogs(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) GetMe(ctx context.Context, req *proto.GetMeRequest) (*proto.Me, error) {
+    res, err := s.authUseCase.GetMe(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) UpdateMe(ctx context.Context, req *proto.UpdateMeRequest) (*proto.AuthenticatedResponse, error) {
+    res, err := s.authUseCase.UpdateMe(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) ChangePassword(ctx context.Context, req *proto.ChangePasswordRequest) (*proto.AuthenticatedResponse, error) {
+    res, err := s.authUseCase.ChangePassword(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) RequestEmailChange(ctx context.Context, req *proto.RequestEmailChangeRequest) (*proto.EmailSentResponse, error) {
+    res, err := s.authUseCase.RequestEmailChange(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) ConfirmEmailChange(ctx context.Context, req *proto.ConfirmEmailChangeRequest) (*proto.VerifyEmailResponse, error) {
+    res, err := s.authUseCase.ConfirmEmailChange(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) GetPreferences(ctx context.Context, req *proto.GetPreferencesRequest) (*proto.Preferences, error) {
+    res, err := s.authUseCase.GetPreferences(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) UpdatePreferences(ctx context.Context, req *proto.UpdatePreferencesRequest) (*proto.Preferences, error) {
+    res, err := s.authUseCase.UpdatePreferences(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) RequestAccountExport(ctx context.Context, req *proto.RequestAccountExportRequest) (*proto.AccountExport, error) {
+    res, err := s.authUseCase.RequestAccountExport(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) ListAccountExports(ctx context.Context, req *proto.ListAccountExportsRequest) (*proto.ListAccountExportsResponse, error) {
+    res, err := s.authUseCase.ListAccountExports(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) DownloadAccountExport(req *proto.DownloadAccountExportRequest, stream proto.AuthService_DownloadAccountExportServer) error {
+    return s.authUseCase.DownloadAccountExport(req, stream)
+}
+
+func (s *AuthServiceImpl) RequestAccountDeletion(ctx context.Context, req *proto.RequestAccountDeletionRequest) (*proto.AccountDeletion, error) {
+    res, err := s.authUseCase.RequestAccountDeletion(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) CancelAccountDeletion(ctx context.Context, req *proto.CancelAccountDeletionRequest) (*proto.AccountDeletion, error) {
+    res, err := s.authUseCase.CancelAccountDeletion(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) AdminListAccountDeletions(ctx context.Context, req *proto.AdminListAccountDeletionsRequest) (*proto.AdminListAccountDeletionsResponse, error) {
+    res, err := s.authUseCase.AdminListAccountDeletions(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) SwitchOrganization(ctx context.Context, req *proto.SwitchOrganizationRequest) (*proto.AuthenticatedResponse, error) {
+    res, err := s.authUseCase.SwitchOrganization(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) CreateOrganization(ctx context.Context, req *proto.CreateOrganizationRequest) (*proto.Organization, error) {
+    res, err := s.authUseCase.CreateOrganization(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) ListOrganizations(ctx context.Context, req *proto.ListOrganizationsRequest) (*proto.ListOrganizationsResponse, error) {
+    res, err := s.authUseCase.ListOrganizations(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) GetOrganization(ctx context.Context, req *proto.GetOrganizationRequest) (*proto.Organization, error) {
+    res, err := s.authUseCase.GetOrganization(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) UpdateOrganization(ctx context.Context, req *proto.UpdateOrganizationRequest) (*proto.Organization, error) {
+    res, err := s.authUseCase.UpdateOrganization(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) DeleteOrganization(ctx context.Context, req *proto.DeleteOrganizationRequest) (*proto.DeleteOrganizationResponse, error) {
+    res, err := s.authUseCase.DeleteOrganization(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) ListOrganizationMembers(ctx context.Context, req *proto.ListOrganizationMembersRequest) (*proto.ListOrganizationMembersResponse, error) {
+    res, err := s.authUseCase.ListOrganizationMembers(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) UpdateOrganizationMember(ctx context.Context, req *proto.UpdateOrganizationMemberRequest) (*proto.OrganizationMember, error) {
+    res, err := s.authUseCase.UpdateOrganizationMember(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) RemoveOrganizationMember(ctx context.Context, req *proto.RemoveOrganizationMemberRequest) (*proto.DeleteOrganizationResponse, error) {
+    res, err := s.authUseCase.RemoveOrganizationMember(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) InviteOrganizationMember(ctx context.Context, req *proto.InviteOrganizationMemberRequest) (*proto.OrganizationInvite, error) {
+    res, err := s.authUseCase.InviteOrganizationMember(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) ListOrganizationInvites(ctx context.Context, req *proto.ListOrganizationInvitesRequest) (*proto.ListOrganizationInvitesResponse, error) {
+    res, err := s.authUseCase.ListOrganizationInvites(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) RevokeOrganizationInvite(ctx context.Context, req *proto.RevokeOrganizationInviteRequest) (*proto.DeleteOrganizationResponse, error) {
+    res, err := s.authUseCase.RevokeOrganizationInvite(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
+
+func (s *AuthServiceImpl) AcceptOrganizationInvite(ctx context.Context, req *proto.AcceptOrganizationInviteRequest) (*proto.Organization, error) {
+    res, err := s.authUseCase.AcceptOrganizationInvite(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return res, nil
+}
\ No newline at end of file
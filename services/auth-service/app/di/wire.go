@@ -8,6 +8,7 @@ import (
 	"auth-service/app/delivery/client"
 	"auth-service/app/delivery/handler"
 	"auth-service/app/helper"
+	"auth-service/app/repository"
 	"auth-service/app/route"
 	"auth-service/app/usecase"
 
@@ -21,9 +22,46 @@ var authSet = wire.NewSet(
 	wire.Bind(new(handler.AuthService), new(*handler.AuthServiceImpl)),
 )
 
+var repositorySet = wire.NewSet(
+	repository.NewRefreshTokenRepositoryImpl,
+	wire.Bind(new(repository.RefreshTokenRepository), new(*repository.RefreshTokenRepositoryImpl)),
+	repository.NewSessionRepositoryImpl,
+	wire.Bind(new(repository.SessionRepository), new(*repository.SessionRepositoryImpl)),
+	repository.NewVerificationTokenRepositoryImpl,
+	wire.Bind(new(repository.VerificationTokenRepository), new(*repository.VerificationTokenRepositoryImpl)),
+	repository.NewTotpCredentialRepositoryImpl,
+	wire.Bind(new(repository.TotpCredentialRepository), new(*repository.TotpCredentialRepositoryImpl)),
+	repository.NewRecoveryCodeRepositoryImpl,
+	wire.Bind(new(repository.RecoveryCodeRepository), new(*repository.RecoveryCodeRepositoryImpl)),
+	repository.NewUserIdentityRepositoryImpl,
+	wire.Bind(new(repository.UserIdentityRepository), new(*repository.UserIdentityRepositoryImpl)),
+	repository.NewApiKeyRepositoryImpl,
+	wire.Bind(new(repository.ApiKeyRepository), new(*repository.ApiKeyRepositoryImpl)),
+	repository.NewLoginThrottleRepositoryImpl,
+	wire.Bind(new(repository.LoginThrottleRepository), new(*repository.LoginThrottleRepositoryImpl)),
+	repository.NewAuditLogRepositoryImpl,
+	wire.Bind(new(repository.AuditLogRepository), new(*repository.AuditLogRepositoryImpl)),
+	repository.NewSigningKeyRepositoryImpl,
+	wire.Bind(new(repository.SigningKeyRepository), new(*repository.SigningKeyRepositoryImpl)),
+	repository.NewAccountDeletionRepositoryImpl,
+	wire.Bind(new(repository.AccountDeletionRepository), new(*repository.AccountDeletionRepositoryImpl)),
+	repository.NewAccountExportRepositoryImpl,
+	wire.Bind(new(repository.AccountExportRepository), new(*repository.AccountExportRepositoryImpl)),
+)
+
+var keySet = wire.NewSet(
+	helper.NewKeyRing,
+	usecase.NewKeyRotator,
+)
+
+var accountSet = wire.NewSet(
+	usecase.NewAccountWorker,
+)
+
 func InitializedApp() *config.App {
 	wire.Build(
 		config.NewViper,
+		config.NewDatabase,
 		config.NewApp,
 		config.NewGrpcServer,
 		config.NewValidator,
@@ -32,9 +70,14 @@ func InitializedApp() *config.App {
 		wire.Bind(new(helper.JWTHelper), new(*helper.JWTHelperImpl)),
 		helper.NewOauthImpl,
 		wire.Bind(new(helper.OAuth), new(*helper.OAuthImpl)),
+		helper.NewMailer,
 		route.NewGrpcServerRouter,
+		repositorySet,
+		keySet,
 		authSet,
+		accountSet,
 		client.NewUserClient,
+		client.NewChatClient,
 	)
 	return nil
 }
\ No newline at end of file
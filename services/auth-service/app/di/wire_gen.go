@@ -11,6 +11,7 @@ import (
 	"auth-service/app/delivery/client"
 	"auth-service/app/delivery/handler"
 	"auth-service/app/helper"
+	"auth-service/app/repository"
 	"auth-service/app/route"
 	"auth-service/app/usecase"
 	"github.com/google/wire"
@@ -21,18 +22,43 @@ import (
 func InitializedApp() *config.App {
 	logger := config.NewLogger()
 	viper := config.NewViper(logger)
-	server := config.NewGrpcServer(logger)
+	server := config.NewGrpcServer(logger, viper)
 	validate := config.NewValidator()
-	jwtHelperImpl := helper.NewJWTHelperImpl(viper)
+	keyRing := helper.NewKeyRing()
+	jwtHelperImpl := helper.NewJWTHelperImpl(viper, keyRing)
 	userClient := client.NewUserClient(viper)
+	chatClient := client.NewChatClient(viper)
 	oAuthImpl := helper.NewOauthImpl(logger, viper)
-	authUseCaseImpl := usecase.NewAuthUseCaseImpl(validate, jwtHelperImpl, logger, userClient, oAuthImpl)
+	db := config.NewDatabase(viper)
+	refreshTokenRepositoryImpl := repository.NewRefreshTokenRepositoryImpl()
+	sessionRepositoryImpl := repository.NewSessionRepositoryImpl()
+	verificationTokenRepositoryImpl := repository.NewVerificationTokenRepositoryImpl()
+	totpCredentialRepositoryImpl := repository.NewTotpCredentialRepositoryImpl()
+	recoveryCodeRepositoryImpl := repository.NewRecoveryCodeRepositoryImpl()
+	userIdentityRepositoryImpl := repository.NewUserIdentityRepositoryImpl()
+	apiKeyRepositoryImpl := repository.NewApiKeyRepositoryImpl()
+	loginThrottleRepositoryImpl := repository.NewLoginThrottleRepositoryImpl()
+	auditLogRepositoryImpl := repository.NewAuditLogRepositoryImpl()
+	accountDeletionRepositoryImpl := repository.NewAccountDeletionRepositoryImpl()
+	accountExportRepositoryImpl := repository.NewAccountExportRepositoryImpl()
+	mailer := helper.NewMailer(viper, logger)
+	authUseCaseImpl := usecase.NewAuthUseCaseImpl(validate, jwtHelperImpl, logger, userClient, chatClient, oAuthImpl, db, refreshTokenRepositoryImpl, sessionRepositoryImpl, verificationTokenRepositoryImpl, totpCredentialRepositoryImpl, recoveryCodeRepositoryImpl, userIdentityRepositoryImpl, apiKeyRepositoryImpl, loginThrottleRepositoryImpl, auditLogRepositoryImpl, accountDeletionRepositoryImpl, accountExportRepositoryImpl, mailer, viper)
 	authServiceImpl := handler.NewAuthServiceImpl(authUseCaseImpl)
 	grpcServerRouter := route.NewGrpcServerRouter(server, authServiceImpl)
-	app := config.NewApp(viper, grpcServerRouter, userClient)
+	signingKeyRepositoryImpl := repository.NewSigningKeyRepositoryImpl()
+	keyRotator := usecase.NewKeyRotator(db, signingKeyRepositoryImpl, keyRing, logger, viper)
+	accountWorker := usecase.NewAccountWorker(db, authUseCaseImpl, logger, viper)
+	app := config.NewApp(viper, grpcServerRouter, userClient, chatClient, keyRotator, accountWorker)
 	return app
 }
 
 // wire.go:
 
 var authSet = wire.NewSet(usecase.NewAuthUseCaseImpl, wire.Bind(new(usecase.AuthUseCase), new(*usecase.AuthUseCaseImpl)), handler.NewAuthServiceImpl, wire.Bind(new(handler.AuthService), new(*handler.AuthServiceImpl)))
+
+var repositorySet = wire.NewSet(repository.NewRefreshTokenRepositoryImpl, wire.Bind(new(repository.RefreshTokenRepository), new(*repository.RefreshTokenRepositoryImpl)), repository.NewSessionRepositoryImpl, wire.Bind(new(repository.SessionRepository), new(*repository.SessionRepositoryImpl)), repository.NewVerificationTokenRepositoryImpl, wire.Bind(new(repository.VerificationTokenRepository), new(*repository.VerificationTokenRepositoryImpl)), repository.NewTotpCredentialRepositoryImpl, wire.Bind(new(repository.TotpCredentialRepository), new(*repository.TotpCredentialRepositoryImpl)), repository.NewRecoveryCodeRepositoryImpl, wire.Bind(new(repository.RecoveryCodeRepository), new(*repository.RecoveryCodeRepositoryImpl)), repository.NewUserIdentityRepositoryImpl, wire.Bind(new(repository.UserIdentityRepository), new(*repository.UserIdentityRepositoryImpl)), repository.NewApiKeyRepositoryImpl, wire.Bind(new(repository.ApiKeyRepository), new(*repository.ApiKeyRepositoryImpl)), repository.NewLoginThrottleRepositoryImpl, wire.Bind(new(repository.LoginThrottleRepository), new(*repository.LoginThrottleRepositoryImpl)), repository.NewAuditLogRepositoryImpl, wire.Bind(new(repository.AuditLogRepository), new(*repository.AuditLogRepositoryImpl)), repository.NewSigningKeyRepositoryImpl, wire.Bind(new(repository.SigningKeyRepository), new(*repository.SigningKeyRepositoryImpl)), repository.NewAccountDeletionRepositoryImpl, wire.Bind(new(repository.AccountDeletionRepository), new(*repository.AccountDeletionRepositoryImpl)), repository.NewAccountExportRepositoryImpl, wire.Bind(new(repository.AccountExportRepository), new(*repository.AccountExportRepositoryImpl)))
+
+var keySet = wire.NewSet(helper.NewKeyRing, usecase.NewKeyRotator)
+
+
+var accountSet = wire.NewSet(usecase.NewAccountWorker)
\ No newline at end of file
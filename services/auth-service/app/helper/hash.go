@@ -1,6 +1,15 @@
 package helper
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// DummyPasswordHash has the cost of real hashes, it is compared when the account
+// does not exist so unknown emails take as long to answer as wrong passwords
+const DummyPasswordHash = "$2a$14$NyRGHR1p3KbGiqb72mmNC.MHTIeh74khJaIPoXFIaOuzJacsCfVNO"
 
 func HashPassword(password string) (string, error) {
 	bytes, err := bcrypt.GenerateFromPassword([]byte(password), 14)
@@ -10,4 +19,10 @@ func HashPassword(password string) (string, error) {
 func VerifyPassword(hashedPassword, password string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 	return err == nil
+}
+
+// HashToken returns the sha256 hex digest used to store opaque tokens
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
\ No newline at end of file
@@ -1,6 +1,7 @@
 package helper
 
 import (
+	"errors"
 	"strings"
 	"time"
 
@@ -8,111 +9,242 @@ import (
 	"github.com/spf13/viper"
 )
 
+const (
+    // AccessTokenTTL is how long an access token is accepted
+    AccessTokenTTL = time.Hour * 2
+    // RefreshTokenTTL is how long a refresh token may be exchanged for new tokens
+    RefreshTokenTTL = time.Hour * 24
+    // ChallengeTokenTTL is how long the second factor may be entered after the password
+    ChallengeTokenTTL = time.Minute * 5
+)
+
+// TokenClaims are the identity claims carried by access and refresh tokens.
+// SessionID is 0 for tokens issued before sessions existed. Role, Permissions
+// and the organization are only carried by access tokens, services check them
+// again. OrganizationID is 0 in the personal workspace.
+type TokenClaims struct {
+    UserID           uint
+    SessionID        uint
+    Name             string
+    Email            string
+    ImageURL         string
+    Role             string
+    Permissions      []string
+    OrganizationID   uint
+    OrganizationRole string
+}
+
 type JWTHelper interface {
-    GenerateTokens(userID uint, name, email string, imageURL string) (string, string, int64, error)
-    ValidateAccessToken(tokenString string) (uint, string, string, string, error)
-    ValidateRefreshToken(tokenString string) (uint, string, string, string, error)
+    GenerateTokens(claims *TokenClaims) (string, string, int64, error)
+    ValidateAccessToken(tokenString string) (*TokenClaims, error)
+    ValidateRefreshToken(tokenString string) (*TokenClaims, error)
+    GenerateChallengeToken(claims *TokenClaims) (string, int64, error)
+    ValidateChallengeToken(tokenString string) (*TokenClaims, error)
+    JWKS() []JWK
 }
 
 type JWTHelperImpl struct {
     Config *viper.Viper
+    Keys   *KeyRing
 }
 
-func NewJWTHelperImpl(config *viper.Viper) *JWTHelperImpl {
+func NewJWTHelperImpl(config *viper.Viper, keys *KeyRing) *JWTHelperImpl {
     return &JWTHelperImpl{
         Config: config,
+        Keys:   keys,
     }
 }
 
-func (a *JWTHelperImpl) GenerateTokens(userID uint, name, email string, imageURL string) (string, string, int64, error) {
+func (a *JWTHelperImpl) GenerateTokens(claims *TokenClaims) (string, string, int64, error) {
     iss := a.Config.GetString("HOSTNAME")
     aud := strings.Split(a.Config.GetString("AUDIENCES"), ",")
-    exp := time.Now().Add(time.Hour * 2).Unix()
-    expRefresh := time.Now().Add(time.Hour * 24).Unix()
-    sub := userID
+    exp := time.Now().Add(AccessTokenTTL).Unix()
+    expRefresh := time.Now().Add(RefreshTokenTTL).Unix()
+    sub := claims.UserID
 
-    accessKey := a.Config.GetString("JWT_ACCESS_KEY")
-    refreshKey := a.Config.GetString("JWT_REFRESH_KEY")
+    key := a.Keys.Signing()
+    if key == nil {
+        return "", "", 0, errors.New("no signing key loaded")
+    }
 
-    accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256,
+    accessToken := jwt.NewWithClaims(signingMethod(key.Algorithm),
         jwt.MapClaims{
             "iss": iss,
             "aud": aud,
             "exp": exp,
             "sub": sub,
-            "name": name,
-            "email": email,
-            "imageURL": imageURL,
+            "token_use": "access",
+            "sid": claims.SessionID,
+            "name": claims.Name,
+            "email": claims.Email,
+            "imageURL": claims.ImageURL,
+            "role": claims.Role,
+            "permissions": claims.Permissions,
+            "org": claims.OrganizationID,
+            "org_role": claims.OrganizationRole,
         })
+    accessToken.Header["kid"] = key.Kid
 
-    refreshToken := jwt.NewWithClaims(jwt.SigningMethodHS256,
+    // jti keeps every refresh token unique, they are stored and rotated by their hash
+    jti, err := RandomToken(16)
+    if err != nil {
+        return "", "", 0, err
+    }
+
+    refreshToken := jwt.NewWithClaims(signingMethod(key.Algorithm),
         jwt.MapClaims{
+            "jti": jti,
             "iss": iss,
             "aud": aud,
             "exp": expRefresh,
             "sub": sub,
-            "name": name,
-            "email": email,
-            "imageURL": imageURL,
+            "token_use": "refresh",
+            "sid": claims.SessionID,
+            "name": claims.Name,
+            "email": claims.Email,
+            "imageURL": claims.ImageURL,
         })
+    refreshToken.Header["kid"] = key.Kid
 
-    signedAccessToken, err := accessToken.SignedString([]byte(accessKey))
+    signedAccessToken, err := accessToken.SignedString(key.PrivateKey)
     if err != nil {
         return "", "", 0, err
     }
 
-    signedRefreshToken, err := refreshToken.SignedString([]byte(refreshKey))
+    signedRefreshToken, err := refreshToken.SignedString(key.PrivateKey)
     if err != nil {
         return "", "", 0, err
     }
 
-    expiredAt := time.Now().Add(time.Hour * 2).Unix()
+    return signedAccessToken, signedRefreshToken, exp, nil
+}
+
+// GenerateChallengeToken signs the short lived token that proves the password
+// was checked while the second factor is still missing
+func (a *JWTHelperImpl) GenerateChallengeToken(claims *TokenClaims) (string, int64, error) {
+    exp := time.Now().Add(ChallengeTokenTTL).Unix()
+
+    key := a.Keys.Signing()
+    if key == nil {
+        return "", 0, errors.New("no signing key loaded")
+    }
+
+    challengeToken := jwt.NewWithClaims(signingMethod(key.Algorithm),
+        jwt.MapClaims{
+            "iss": a.Config.GetString("HOSTNAME"),
+            "aud": strings.Split(a.Config.GetString("AUDIENCES"), ","),
+            "exp": exp,
+            "sub": claims.UserID,
+            "token_use": "challenge",
+            "name": claims.Name,
+            "email": claims.Email,
+            "imageURL": claims.ImageURL,
+        })
+    challengeToken.Header["kid"] = key.Kid
+
+    signedChallengeToken, err := challengeToken.SignedString(key.PrivateKey)
+    if err != nil {
+        return "", 0, err
+    }
+
+    return signedChallengeToken, exp, nil
+}
+
+func (a *JWTHelperImpl) ValidateAccessToken(tokenString string) (*TokenClaims, error) {
+    return a.validate(tokenString, "access", "JWT_ACCESS_KEY")
+}
+
+func (a *JWTHelperImpl) ValidateRefreshToken(tokenString string) (*TokenClaims, error) {
+    return a.validate(tokenString, "refresh", "JWT_REFRESH_KEY")
+}
 
-    return signedAccessToken, signedRefreshToken, expiredAt, nil
+// ValidateChallengeToken never accepts legacy tokens, challenges did not exist before
+func (a *JWTHelperImpl) ValidateChallengeToken(tokenString string) (*TokenClaims, error) {
+    return a.validate(tokenString, "challenge", "")
 }
 
-func (a *JWTHelperImpl) ValidateAccessToken(tokenString string) (uint, string, string, string, error) {
+// validate verifies the token with the key named by its kid. HS256 tokens
+// issued before asymmetric signing stay valid while legacySecret is set.
+func (a *JWTHelperImpl) validate(tokenString string, tokenUse string, legacySecret string) (*TokenClaims, error) {
+    legacy := false
     token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-        if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+        if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+            if legacySecret == "" {
+                return nil, jwt.ErrInvalidKey
+            }
+            secret := a.Config.GetString(legacySecret)
+            if secret == "" {
+                return nil, jwt.ErrInvalidKey
+            }
+            legacy = true
+            return []byte(secret), nil
+        }
+
+        kid, _ := token.Header["kid"].(string)
+        key, ok := a.Keys.Find(kid)
+        if !ok || key.Algorithm != token.Method.Alg() {
             return nil, jwt.ErrInvalidKey
         }
-        return []byte(a.Config.GetString("JWT_ACCESS_KEY")), nil
-    })
+        return key.PublicKey, nil
+    }, jwt.WithValidMethods([]string{AlgorithmRS256, AlgorithmEdDSA, jwt.SigningMethodHS256.Alg()}))
 
     if err != nil {
-        return 0, "", "", "", err
+        return nil, err
     }
 
-    if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-        userID := uint(claims["sub"].(float64))
-        name := claims["name"].(string)
-        email := claims["email"].(string)
-        imageURL := claims["imageURL"].(string)
-        return userID, name, email, imageURL, nil
+    claims, ok := token.Claims.(jwt.MapClaims)
+    if !ok || !token.Valid {
+        return nil, jwt.ErrInvalidKey
+    }
+
+    // Both token types share the signing keys, the claim tells them apart
+    if !legacy && claims["token_use"] != tokenUse {
+        return nil, jwt.ErrInvalidKey
+    }
+
+    sub, ok := claims["sub"].(float64)
+    if !ok {
+        return nil, jwt.ErrInvalidKey
+    }
+    sid, _ := claims["sid"].(float64)
+    name, _ := claims["name"].(string)
+    email, _ := claims["email"].(string)
+    imageURL, _ := claims["imageURL"].(string)
+    role, _ := claims["role"].(string)
+    org, _ := claims["org"].(float64)
+    orgRole, _ := claims["org_role"].(string)
+
+    permissions := []string{}
+    if values, ok := claims["permissions"].([]interface{}); ok {
+        for _, value := range values {
+            if permission, ok := value.(string); ok {
+                permissions = append(permissions, permission)
+            }
+        }
+    }
+
+    return &TokenClaims{
+        UserID:           uint(sub),
+        SessionID:        uint(sid),
+        Name:             name,
+        Email:            email,
+        ImageURL:         imageURL,
+        Role:             role,
+        Permissions:      permissions,
+        OrganizationID:   uint(org),
+        OrganizationRole: orgRole,
+    }, nil
+}
+
+func signingMethod(algorithm string) jwt.SigningMethod {
+    if algorithm == AlgorithmEdDSA {
+        return jwt.SigningMethodEdDSA
     }
 
-    return 0, "", "", "", jwt.ErrInvalidKey
+    return jwt.SigningMethodRS256
 }
 
-func (a *JWTHelperImpl) ValidateRefreshToken(tokenString string) (uint, string, string, string, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, jwt.ErrInvalidKey
-		}
-		return []byte(a.Config.GetString("JWT_REFRESH_KEY")), nil
-	})
-
-	if err != nil {
-		return 0, "", "", "", err
-	}
-
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		userID := uint(claims["sub"].(float64))
-        name := claims["name"].(string)
-        email := claims["email"].(string)
-        imageURL := claims["imageURL"].(string)
-		return userID, name, email, imageURL, nil
-	}
-
-	return 0, "", "", "", jwt.ErrInvalidKey
+// JWKS returns the public keys that verify the issued tokens
+func (a *JWTHelperImpl) JWKS() []JWK {
+    return a.Keys.JWKS()
 }
\ No newline at end of file
@@ -0,0 +1,206 @@
+package helper
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+)
+
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// SigningKey is an asymmetric key pair identified by the kid header of the tokens it signs
+type SigningKey struct {
+	Kid        string
+	Algorithm  string
+	PrivateKey crypto.Signer
+	PublicKey  crypto.PublicKey
+	CreatedAt  time.Time
+}
+
+// JWK is the public part of a signing key as published in the JWKS document
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// KeyRing holds the key used to sign new tokens and every key that may still verify one.
+type KeyRing struct {
+	mu      sync.RWMutex
+	signing *SigningKey
+	keys    map[string]*SigningKey
+}
+
+func NewKeyRing() *KeyRing {
+	return &KeyRing{
+		keys: make(map[string]*SigningKey),
+	}
+}
+
+// Set replaces the keys of the ring
+func (k *KeyRing) Set(signing *SigningKey, keys []*SigningKey) {
+	byKid := make(map[string]*SigningKey, len(keys))
+	for _, key := range keys {
+		byKid[key.Kid] = key
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.signing = signing
+	k.keys = byKid
+}
+
+// Signing returns the key new tokens are signed with, nil until keys are loaded
+func (k *KeyRing) Signing() *SigningKey {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return k.signing
+}
+
+// Find returns the verification key of a kid
+func (k *KeyRing) Find(kid string) (*SigningKey, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	key, ok := k.keys[kid]
+	return key, ok
+}
+
+// JWKS returns the public keys of the ring
+func (k *KeyRing) JWKS() []JWK {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	jwks := make([]JWK, 0, len(k.keys))
+	for _, key := range k.keys {
+		jwk, err := key.JWK()
+		if err != nil {
+			continue
+		}
+		jwks = append(jwks, jwk)
+	}
+
+	return jwks
+}
+
+// JWK encodes the public key following RFC 7517 and RFC 8037
+func (s *SigningKey) JWK() (JWK, error) {
+	switch pub := s.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA",
+			Kid: s.Kid,
+			Use: "sig",
+			Alg: AlgorithmRS256,
+			N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}, nil
+	case ed25519.PublicKey:
+		return JWK{
+			Kty: "OKP",
+			Kid: s.Kid,
+			Use: "sig",
+			Alg: AlgorithmEdDSA,
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(pub),
+		}, nil
+	default:
+		return JWK{}, errors.New("unsupported public key type")
+	}
+}
+
+// GenerateSigningKey creates a new key pair for the algorithm
+func GenerateSigningKey(algorithm string) (*SigningKey, error) {
+	var signer crypto.Signer
+	switch algorithm {
+	case AlgorithmRS256:
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return nil, err
+		}
+		signer = key
+	case AlgorithmEdDSA:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		signer = key
+	default:
+		return nil, errors.New("unsupported signing algorithm")
+	}
+
+	return newSigningKey(signer, algorithm, time.Now())
+}
+
+// ParseSigningKey reads a PEM encoded PKCS#8 or PKCS#1 private key
+func ParseSigningKey(privateKeyPEM []byte, createdAt time.Time) (*SigningKey, error) {
+	block, _ := pem.Decode(privateKeyPEM)
+	if block == nil {
+		return nil, errors.New("invalid PEM private key")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return newSigningKey(key, AlgorithmRS256, createdAt)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		return newSigningKey(key, AlgorithmRS256, createdAt)
+	case ed25519.PrivateKey:
+		return newSigningKey(key, AlgorithmEdDSA, createdAt)
+	default:
+		return nil, errors.New("unsupported private key type")
+	}
+}
+
+// EncodePrivateKey returns the PEM encoded PKCS#8 private key
+func (s *SigningKey) EncodePrivateKey() (string, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(s.PrivateKey)
+	if err != nil {
+		return "", err
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
+
+func newSigningKey(signer crypto.Signer, algorithm string, createdAt time.Time) (*SigningKey, error) {
+	der, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	// The kid is derived from the public key so every replica agrees on it
+	sum := sha256.Sum256(der)
+
+	return &SigningKey{
+		Kid:        base64.RawURLEncoding.EncodeToString(sum[:16]),
+		Algorithm:  algorithm,
+		PrivateKey: signer,
+		PublicKey:  signer.Public(),
+		CreatedAt:  createdAt,
+	}, nil
+}
@@ -0,0 +1,166 @@
+package helper
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+const (
+	MailDriverSMTP   = "smtp"
+	MailDriverOutbox = "outbox"
+)
+
+// Mail is a plain text email
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Mailer interface {
+	Send(ctx context.Context, mail *Mail) error
+}
+
+// NewMailer returns the mailer of MAIL_DRIVER, the outbox unless smtp is configured
+func NewMailer(config *viper.Viper, log *logrus.Logger) Mailer {
+	from := config.GetString("MAIL_FROM")
+	if from == "" {
+		from = "no-reply@localhost"
+	}
+
+	switch config.GetString("MAIL_DRIVER") {
+	case MailDriverSMTP:
+		return &SMTPMailer{
+			Host:     config.GetString("SMTP_HOST"),
+			Port:     config.GetString("SMTP_PORT"),
+			Username: config.GetString("SMTP_USERNAME"),
+			Password: config.GetString("SMTP_PASSWORD"),
+			From:     from,
+		}
+	case "", MailDriverOutbox:
+		return &OutboxMailer{
+			Dir:  config.GetString("MAIL_OUTBOX_DIR"),
+			From: from,
+			Out:  os.Stdout,
+		}
+	default:
+		log.Panicf("unsupported mail driver %s", config.GetString("MAIL_DRIVER"))
+		return nil
+	}
+}
+
+// SMTPMailer delivers mails through an SMTP server, upgrading to TLS when offered
+type SMTPMailer struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, mail *Mail) error {
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(m.Host, m.Port))
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, m.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.Host}); err != nil {
+			return err
+		}
+	}
+
+	if m.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.Username, m.Password, m.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(m.From); err != nil {
+		return err
+	}
+	if err := client.Rcpt(mail.To); err != nil {
+		return err
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(mail.message(m.From)); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// OutboxMailer keeps mails for local development and tests instead of sending
+// them, as .eml files in Dir or written to Out when no directory is set.
+type OutboxMailer struct {
+	Dir  string
+	From string
+	Out  io.Writer
+	mu   sync.Mutex
+}
+
+func (m *OutboxMailer) Send(ctx context.Context, mail *Mail) error {
+	message := mail.message(m.From)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.Dir == "" {
+		_, err := fmt.Fprintf(m.Out, "----- outbox mail -----\n%s\n-----------------------\n", message)
+		return err
+	}
+
+	if err := os.MkdirAll(m.Dir, 0o755); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%d-%s.eml", time.Now().UnixNano(), strings.NewReplacer("@", "_at_", "/", "_").Replace(mail.To))
+	return os.WriteFile(filepath.Join(m.Dir, name), message, 0o644)
+}
+
+func (m *Mail) message(from string) []byte {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", m.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.ReplaceAll(m.Body, "\n", "\r\n"))
+
+	return buf.Bytes()
+}
\ No newline at end of file
@@ -2,50 +2,348 @@ package helper
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
-	"google.golang.org/api/idtoken"
 )
 
+const (
+	ProviderGoogle = "google"
+	googleIssuer   = "https://accounts.google.com"
+
+	// jwksTTL is how long the keys of a provider are trusted before they are fetched again,
+	// jwksRefreshInterval limits refetching when a token names an unknown kid
+	jwksTTL             = time.Hour
+	jwksRefreshInterval = time.Minute
+)
+
+var ErrUnknownProvider = errors.New("unknown login provider")
+
+// AuthCredential is the identity an OpenID Connect provider vouches for
 type AuthCredential struct {
-	Email string
-	Name  string
-	Picture string
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Picture       string
 }
 
 type OAuth interface {
-	VerifyIdToken(token string) (*AuthCredential, error)
+	Providers() []string
+	VerifyIdToken(ctx context.Context, provider string, token string) (*AuthCredential, error)
 }
 
+// OAuthImpl verifies ID tokens of the OpenID Connect providers listed in OIDC_PROVIDERS.
+// Every provider is configured with OIDC_<PROVIDER>_ISSUER, OIDC_<PROVIDER>_CLIENT_ID and
+// optionally OIDC_<PROVIDER>_JWKS_URL, the JWKS is discovered from the issuer otherwise.
+// GOOGLE_CLIENT_ID alone still configures Google.
 type OAuthImpl struct {
-	Logger *logrus.Logger
-	Config *viper.Viper
+	Logger    *logrus.Logger
+	Config    *viper.Viper
+	Client    *http.Client
+	providers map[string]*oidcProvider
+	names     []string
+}
+
+type oidcProvider struct {
+	ID       string
+	Issuer   string
+	ClientID string
+	JWKSURL  string
+
+	mu        sync.Mutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
 }
 
 func NewOauthImpl(logger *logrus.Logger, config *viper.Viper) *OAuthImpl {
-	return &OAuthImpl{
-		Logger: logger,
-		Config: config,
+	o := &OAuthImpl{
+		Logger:    logger,
+		Config:    config,
+		Client:    &http.Client{Timeout: 5 * time.Second},
+		providers: make(map[string]*oidcProvider),
 	}
+
+	for _, id := range strings.Split(config.GetString("OIDC_PROVIDERS"), ",") {
+		id = strings.ToLower(strings.TrimSpace(id))
+		if id == "" {
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(id, "-", "_")) + "_"
+		provider := &oidcProvider{
+			ID:       id,
+			Issuer:   strings.TrimRight(config.GetString(prefix+"ISSUER"), "/"),
+			ClientID: config.GetString(prefix + "CLIENT_ID"),
+			JWKSURL:  config.GetString(prefix + "JWKS_URL"),
+		}
+		if provider.Issuer == "" && id == ProviderGoogle {
+			provider.Issuer = googleIssuer
+		}
+		if provider.Issuer == "" || provider.ClientID == "" {
+			logger.Warnf("Login provider %s is missing an issuer or client id, skipping it", id)
+			continue
+		}
+
+		o.add(provider)
+	}
+
+	if _, ok := o.providers[ProviderGoogle]; !ok && config.GetString("GOOGLE_CLIENT_ID") != "" {
+		o.add(&oidcProvider{
+			ID:       ProviderGoogle,
+			Issuer:   googleIssuer,
+			ClientID: config.GetString("GOOGLE_CLIENT_ID"),
+		})
+	}
+
+	return o
 }
 
-func (o *OAuthImpl) VerifyIdToken(token string) (*AuthCredential, error) {
-	googleClientId := o.Config.GetString("GOOGLE_CLIENT_ID")
+func (o *OAuthImpl) add(provider *oidcProvider) {
+	o.providers[provider.ID] = provider
+	o.names = append(o.names, provider.ID)
+}
+
+// Providers returns the ids of the configured providers
+func (o *OAuthImpl) Providers() []string {
+	return o.names
+}
+
+func (o *OAuthImpl) VerifyIdToken(ctx context.Context, provider string, token string) (*AuthCredential, error) {
+	p, ok := o.providers[strings.ToLower(provider)]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
 
-	payload, err := idtoken.Validate(context.Background(), token, googleClientId)
+	parsed, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return o.key(ctx, p, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "ES256", "ES384", "EdDSA"}),
+		jwt.WithAudience(p.ClientID),
+		jwt.WithExpirationRequired(),
+	)
 	if err != nil {
-		o.Logger.Error(err)
+		o.Logger.Warnf("Invalid %s id token: %v", p.ID, err)
+		return nil, err
+	}
+
+	claims, ok := parsed.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, jwt.ErrTokenInvalidClaims
+	}
+
+	// Google also issues tokens with the bare host as issuer
+	issuer, _ := claims["iss"].(string)
+	if issuer != p.Issuer && "https://"+issuer != p.Issuer {
+		return nil, jwt.ErrTokenInvalidIssuer
+	}
+
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return nil, jwt.ErrTokenInvalidSubject
+	}
+
+	credential := &AuthCredential{
+		Provider: p.ID,
+		Subject:  subject,
+	}
+	credential.Email, _ = claims["email"].(string)
+	credential.Name, _ = claims["name"].(string)
+	credential.Picture, _ = claims["picture"].(string)
+
+	// Some providers send the flag as a string
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		credential.EmailVerified = verified
+	case string:
+		credential.EmailVerified = verified == "true"
+	}
+
+	return credential, nil
+}
+
+// key returns the verification key of the kid, fetching the JWKS when it is stale or misses the kid
+func (o *OAuthImpl) key(ctx context.Context, p *oidcProvider, kid string) (crypto.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.lookup(kid); ok && time.Since(p.fetchedAt) < jwksTTL {
+		return key, nil
+	}
+
+	if time.Since(p.fetchedAt) >= jwksRefreshInterval {
+		keys, err := o.fetchKeys(ctx, p)
+		if err != nil {
+			o.Logger.Errorf("Failed to fetch keys of %s: %v", p.ID, err)
+		} else {
+			p.keys = keys
+			p.fetchedAt = time.Now()
+		}
+	}
+
+	if key, ok := p.lookup(kid); ok {
+		return key, nil
+	}
+
+	return nil, jwt.ErrInvalidKey
+}
+
+// lookup finds the key of the kid, a token without kid is accepted when the provider has a single key
+func (p *oidcProvider) lookup(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+
+	key, ok := p.keys[kid]
+	return key, ok
+}
+
+func (o *OAuthImpl) fetchKeys(ctx context.Context, p *oidcProvider) (map[string]crypto.PublicKey, error) {
+	if p.JWKSURL == "" {
+		var discovery struct {
+			JWKSURI string `json:"jwks_uri"`
+		}
+		if err := o.getJSON(ctx, p.Issuer+"/.well-known/openid-configuration", &discovery); err != nil {
+			return nil, err
+		}
+		if discovery.JWKSURI == "" {
+			return nil, errors.New("discovery document has no jwks_uri")
+		}
+
+		p.JWKSURL = discovery.JWKSURI
+	}
+
+	var jwks struct {
+		Keys []providerJWK `json:"keys"`
+	}
+	if err := o.getJSON(ctx, p.JWKSURL, &jwks); err != nil {
 		return nil, err
 	}
 
-	email := payload.Claims["email"].(string)
-	name := payload.Claims["name"].(string)
-	picture := payload.Claims["picture"].(string)
+	keys := make(map[string]crypto.PublicKey, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
 
-	return &AuthCredential{
-		Email: email,
-		Name:  name,
-		Picture: picture,
-	}, nil
+		key, err := jwk.PublicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	return keys, nil
+}
+
+func (o *OAuthImpl) getJSON(ctx context.Context, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := o.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returned %d", url, res.StatusCode)
+	}
+
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
+// providerJWK is a key of a provider JWKS, RSA, EC or Ed25519
+type providerJWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k providerJWK) PublicKey() (crypto.PublicKey, error) {
+	decode := base64.RawURLEncoding.DecodeString
+
+	switch k.Kty {
+	case "RSA":
+		n, err := decode(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		default:
+			return nil, fmt.Errorf("unsupported curve %s", k.Crv)
+		}
+
+		x, err := decode(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decode(k.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %s", k.Crv)
+		}
+
+		x, err := decode(k.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key")
+		}
+
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", k.Kty)
+	}
 }
@@ -0,0 +1,165 @@
+package helper
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// TotpDigits and TotpPeriod follow the defaults of authenticator apps
+	TotpDigits = 6
+	TotpPeriod = 30
+	// TotpSkew is how many periods a code may be early or late
+	TotpSkew = 1
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTotpSecret returns a random base32 secret of 160 bits as recommended by RFC 4226
+func GenerateTotpSecret() (string, error) {
+	secret := make([]byte, 20)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+// TotpURI builds the otpauth URI authenticator apps enroll from
+func TotpURI(issuer, account, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(TotpDigits))
+	query.Set("period", fmt.Sprint(TotpPeriod))
+
+	return "otpauth://totp/" + url.PathEscape(issuer+":"+account) + "?" + query.Encode()
+}
+
+// TotpCode computes the RFC 6238 code of the time step
+func TotpCode(secret string, step int64) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", err
+	}
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", TotpDigits, value%1000000), nil
+}
+
+// VerifyTotp checks the code around now and returns the matched time step.
+// Steps up to lastStep are refused so a code cannot be replayed.
+func VerifyTotp(secret, code string, now time.Time, lastStep int64) (int64, bool) {
+	if len(code) != TotpDigits {
+		return 0, false
+	}
+
+	current := now.Unix() / TotpPeriod
+	for step := current - TotpSkew; step <= current+TotpSkew; step++ {
+		if step <= lastStep {
+			continue
+		}
+
+		expected, err := TotpCode(secret, step)
+		if err != nil {
+			return 0, false
+		}
+
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// GenerateRecoveryCode returns a one time code formatted as xxxxx-xxxxx
+func GenerateRecoveryCode() (string, error) {
+	code, err := RandomToken(5)
+	if err != nil {
+		return "", err
+	}
+
+	return code[:5] + "-" + code[5:], nil
+}
+
+// NormalizeRecoveryCode lets recovery codes be typed without the dash or in upper case
+func NormalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+}
+
+// EncryptSecret seals the plaintext with AES-GCM under a key derived from passphrase
+func EncryptSecret(passphrase, plaintext string) (string, error) {
+	gcm, err := secretCipher(passphrase)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptSecret opens a secret sealed by EncryptSecret
+func DecryptSecret(passphrase, ciphertext string) (string, error) {
+	gcm, err := secretCipher(passphrase)
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("invalid encrypted secret")
+	}
+
+	plaintext, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+func secretCipher(passphrase string) (cipher.AEAD, error) {
+	if passphrase == "" {
+		return nil, errors.New("no encryption key configured")
+	}
+
+	key := sha256.Sum256([]byte(passphrase))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
\ No newline at end of file
@@ -11,8 +11,9 @@ type RegisterRequest struct {
     Password string `json:"password" validate:"required"`
 }
 
-type LoginWIthGoogleRequest struct {
-    IdToken string `json:"id_token" validate:"required"`
+type LoginWithProviderRequest struct {
+    Provider string `json:"provider" validate:"required,max=64"`
+    IdToken  string `json:"id_token" validate:"required"`
 }
 
 type GetProfileRequest struct {
@@ -22,3 +23,264 @@ type GetProfileRequest struct {
 type RefreshRequest struct {
     RefreshToken string `json:"refresh_token" validate:"required"`
 }
+
+type LogoutRequest struct {
+    RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type LogoutAllRequest struct {
+    AccessToken string `json:"access_token" validate:"required"`
+}
+
+type ListSessionsRequest struct {
+    AccessToken string `json:"access_token" validate:"required"`
+}
+
+type GetMeRequest struct {
+    AccessToken string `json:"access_token" validate:"required"`
+}
+
+type UpdateMeRequest struct {
+    AccessToken string  `json:"access_token" validate:"required"`
+    Name        *string `json:"name" validate:"omitempty,min=1,max=255"`
+    ImageURL    *string `json:"image_url" validate:"omitempty,max=2048"`
+}
+
+type ChangePasswordRequest struct {
+    AccessToken     string `json:"access_token" validate:"required"`
+    CurrentPassword string `json:"current_password"`
+    NewPassword     string `json:"new_password" validate:"required"`
+}
+
+type RequestEmailChangeRequest struct {
+    AccessToken     string `json:"access_token" validate:"required"`
+    Email           string `json:"email" validate:"required,email,max=255"`
+    CurrentPassword string `json:"current_password"`
+}
+
+type ConfirmEmailChangeRequest struct {
+    Token string `json:"token" validate:"required"`
+}
+
+type GetPreferencesRequest struct {
+    AccessToken string `json:"access_token" validate:"required"`
+}
+
+type UpdatePreferencesRequest struct {
+    AccessToken string `json:"access_token" validate:"required"`
+}
+
+type RevokeSessionRequest struct {
+    AccessToken string `json:"access_token" validate:"required"`
+    SessionID   int64  `json:"session_id" validate:"required"`
+}
+
+type ListRevokedSessionsRequest struct {
+    Since int64 `json:"since" validate:"gte=0"`
+    Limit int32 `json:"limit" validate:"gte=0,lte=1000"`
+}
+
+type ResendVerificationEmailRequest struct {
+    Email string `json:"email" validate:"required,email"`
+}
+
+type VerifyEmailRequest struct {
+    Token string `json:"token" validate:"required"`
+}
+
+type RequestPasswordResetRequest struct {
+    Email string `json:"email" validate:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+    Token    string `json:"token" validate:"required"`
+    Password string `json:"password" validate:"required,min=8"`
+}
+
+type EnrollTotpRequest struct {
+    AccessToken string `json:"access_token" validate:"required"`
+}
+
+type TotpCodeRequest struct {
+    AccessToken string `json:"access_token" validate:"required"`
+    Code        string `json:"code" validate:"required,max=32"`
+}
+
+type CompleteTwoFactorLoginRequest struct {
+    ChallengeToken string `json:"challenge_token" validate:"required"`
+    Code           string `json:"code" validate:"required,max=32"`
+}
+
+
+type ListIdentitiesRequest struct {
+    AccessToken string `json:"access_token" validate:"required"`
+}
+
+type LinkIdentityRequest struct {
+    AccessToken string `json:"access_token" validate:"required"`
+    Provider    string `json:"provider" validate:"required,max=64"`
+    IdToken     string `json:"id_token" validate:"required"`
+}
+
+type UnlinkIdentityRequest struct {
+    AccessToken string `json:"access_token" validate:"required"`
+    Provider    string `json:"provider" validate:"required,max=64"`
+}
+
+type CreateApiKeyRequest struct {
+    AccessToken string   `json:"access_token" validate:"required"`
+    Name        string   `json:"name" validate:"required,max=100"`
+    Scopes      []string `json:"scopes" validate:"required,min=1,max=16"`
+    ExpiresAt   int64    `json:"expires_at" validate:"gte=0"`
+}
+
+type ListApiKeysRequest struct {
+    AccessToken string `json:"access_token" validate:"required"`
+}
+
+type ApiKeyRequest struct {
+    AccessToken string `json:"access_token" validate:"required"`
+    ID          int64  `json:"id" validate:"required,gt=0"`
+}
+
+type UpdateApiKeyRequest struct {
+    AccessToken string   `json:"access_token" validate:"required"`
+    ID          int64    `json:"id" validate:"required,gt=0"`
+    Name        string   `json:"name" validate:"required,max=100"`
+    Scopes      []string `json:"scopes" validate:"required,min=1,max=16"`
+}
+
+type ResolveApiKeyRequest struct {
+    Key string `json:"key" validate:"required,max=128"`
+}
+
+type AdminListUsersRequest struct {
+    AccessToken string `json:"access_token" validate:"required"`
+    Query       string `json:"query" validate:"max=255"`
+    Page        int32  `json:"page" validate:"gte=0"`
+    PageSize    int32  `json:"page_size" validate:"gte=0,lte=100"`
+}
+
+type AdminSuspendUserRequest struct {
+    AccessToken string `json:"access_token" validate:"required"`
+    UserID      int64  `json:"user_id" validate:"required,gt=0"`
+}
+
+type AdminUpdateUserRoleRequest struct {
+    AccessToken string `json:"access_token" validate:"required"`
+    UserID      int64  `json:"user_id" validate:"required,gt=0"`
+    Role        string `json:"role" validate:"required,oneof=user support admin"`
+}
+
+type AdminUnlockUserRequest struct {
+    AccessToken string `json:"access_token" validate:"required"`
+    UserID      int64  `json:"user_id" validate:"required,gt=0"`
+    IPAddress   string `json:"ip_address" validate:"omitempty,ip"`
+}
+
+type AdminGetUserStatsRequest struct {
+    AccessToken string `json:"access_token" validate:"required"`
+    Since       int64  `json:"since" validate:"gte=0"`
+}
+
+type RecordAuditEventRequest struct {
+    Action     string `json:"action" validate:"required,max=100"`
+    TargetType string `json:"target_type" validate:"max=50"`
+    TargetID   string `json:"target_id" validate:"max=320"`
+    Diff       string `json:"diff" validate:"omitempty,json"`
+}
+
+type AdminListAuditLogsRequest struct {
+    AccessToken string `json:"access_token" validate:"required"`
+    Action      string `json:"action" validate:"max=100"`
+    TargetType  string `json:"target_type" validate:"max=50"`
+    TargetID    string `json:"target_id" validate:"max=320"`
+    Since       int64  `json:"since" validate:"gte=0"`
+    Until       int64  `json:"until" validate:"gte=0"`
+    Page        int32  `json:"page" validate:"gte=0"`
+    PageSize    int32  `json:"page_size" validate:"gte=0,lte=500"`
+}
+
+type AdminVerifyAuditLogsRequest struct {
+    AccessToken string `json:"access_token" validate:"required"`
+}
+
+type RequestAccountExportRequest struct {
+    AccessToken string `json:"access_token" validate:"required"`
+}
+
+type ListAccountExportsRequest struct {
+    AccessToken string `json:"access_token" validate:"required"`
+}
+
+type DownloadAccountExportRequest struct {
+    AccessToken string `json:"access_token" validate:"required"`
+    ID          int64  `json:"id" validate:"required,gt=0"`
+}
+
+type RequestAccountDeletionRequest struct {
+    AccessToken     string `json:"access_token" validate:"required"`
+    CurrentPassword string `json:"current_password"`
+}
+
+type CancelAccountDeletionRequest struct {
+    Token string `json:"token" validate:"required"`
+}
+
+type AdminListAccountDeletionsRequest struct {
+    AccessToken string `json:"access_token" validate:"required"`
+    Status      string `json:"status" validate:"omitempty,oneof=scheduled purging purged cancelled"`
+    Page        int32  `json:"page" validate:"gte=0"`
+    PageSize    int32  `json:"page_size" validate:"gte=0,lte=500"`
+}
+
+type SwitchOrganizationRequest struct {
+    AccessToken    string `json:"access_token" validate:"required"`
+    OrganizationID int64  `json:"organization_id" validate:"gte=0"`
+}
+
+type CreateOrganizationRequest struct {
+    AccessToken string `json:"access_token" validate:"required"`
+    Name        string `json:"name" validate:"required,max=255"`
+}
+
+type ListOrganizationsRequest struct {
+    AccessToken string `json:"access_token" validate:"required"`
+}
+
+// OrganizationRequest validates the requests that only name the organization
+type OrganizationRequest struct {
+    AccessToken    string `json:"access_token" validate:"required"`
+    OrganizationID int64  `json:"organization_id" validate:"required,gt=0"`
+}
+
+type UpdateOrganizationMemberRequest struct {
+    AccessToken    string `json:"access_token" validate:"required"`
+    OrganizationID int64  `json:"organization_id" validate:"required,gt=0"`
+    UserID         int64  `json:"user_id" validate:"required,gt=0"`
+    Role           string `json:"role" validate:"required,oneof=owner admin member"`
+}
+
+type RemoveOrganizationMemberRequest struct {
+    AccessToken    string `json:"access_token" validate:"required"`
+    OrganizationID int64  `json:"organization_id" validate:"required,gt=0"`
+    UserID         int64  `json:"user_id" validate:"required,gt=0"`
+}
+
+type InviteOrganizationMemberRequest struct {
+    AccessToken    string `json:"access_token" validate:"required"`
+    OrganizationID int64  `json:"organization_id" validate:"required,gt=0"`
+    Email          string `json:"email" validate:"required,email"`
+    Role           string `json:"role" validate:"omitempty,oneof=admin member"`
+}
+
+type RevokeOrganizationInviteRequest struct {
+    AccessToken    string `json:"access_token" validate:"required"`
+    OrganizationID int64  `json:"organization_id" validate:"required,gt=0"`
+    ID             int64  `json:"id" validate:"required,gt=0"`
+}
+
+type AcceptOrganizationInviteRequest struct {
+    AccessToken string `json:"access_token" validate:"required"`
+    Token       string `json:"token" validate:"required"`
+}
\ No newline at end of file
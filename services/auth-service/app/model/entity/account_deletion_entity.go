@@ -0,0 +1,56 @@
+package entity
+
+import "time"
+
+const (
+	AccountDeletionScheduled = "scheduled"
+	AccountDeletionPurging   = "purging"
+	AccountDeletionPurged    = "purged"
+	AccountDeletionCancelled = "cancelled"
+)
+
+// Services purged by a deletion, in the order they are purged. The user
+// service goes last, the other services still look the user up while purging.
+const (
+	AccountDeletionServiceChat = "chat"
+	AccountDeletionServiceAuth = "auth"
+	AccountDeletionServiceUser = "user"
+)
+
+var AccountDeletionServices = []string{
+	AccountDeletionServiceChat,
+	AccountDeletionServiceAuth,
+	AccountDeletionServiceUser,
+}
+
+const (
+	AccountDeletionStepPending   = "pending"
+	AccountDeletionStepSucceeded = "succeeded"
+)
+
+// AccountDeletion tracks the deletion of an account from the request until every
+// service purged the user, it can be cancelled until the grace period ends
+type AccountDeletion struct {
+	ID          	uint
+	UserID      	uint
+	Email       	*string
+	Status      	string
+	PurgeAfter  	time.Time
+	CancelledAt 	*time.Time
+	PurgedAt    	*time.Time
+	CreatedAt   	time.Time
+	UpdatedAt   	time.Time
+	Steps       	[]AccountDeletionStep `gorm:"foreignKey:AccountDeletionID"`
+}
+
+type AccountDeletionStep struct {
+	ID                	uint
+	AccountDeletionID 	uint
+	Service           	string
+	Status            	string
+	Attempts          	int32
+	LastError         	*string
+	CompletedAt       	*time.Time
+	CreatedAt         	time.Time
+	UpdatedAt         	time.Time
+}
\ No newline at end of file
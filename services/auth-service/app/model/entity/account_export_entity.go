@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+const (
+	AccountExportPending = "pending"
+	AccountExportRunning = "running"
+	AccountExportReady   = "ready"
+	AccountExportFailed  = "failed"
+	AccountExportExpired = "expired"
+)
+
+// AccountExport is a job collecting the data of the user from every service
+// into one zip archive, which is kept until it expires
+type AccountExport struct {
+	ID          	uint
+	UserID      	uint
+	Status      	string
+	Archive     	[]byte
+	Size        	int64
+	Error       	*string
+	Attempts    	int32
+	StartedAt   	*time.Time
+	CompletedAt 	*time.Time
+	ExpiresAt   	*time.Time
+	CreatedAt   	time.Time
+	UpdatedAt   	time.Time
+}
\ No newline at end of file
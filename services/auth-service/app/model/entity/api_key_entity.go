@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+// ApiKeyScopes are the scopes a key can be granted, the gateway maps them to
+// its route groups and reads need :read while every other method needs :write
+var ApiKeyScopes = []string{
+	"chats:read", "chats:write",
+	"assistants:read", "assistants:write",
+	"projects:read", "projects:write",
+	"scheduled-prompts:read", "scheduled-prompts:write",
+	"prompt-templates:read", "prompt-templates:write",
+	"webhooks:read", "webhooks:write",
+}
+
+type ApiKey struct {
+	ID         	uint
+	UserID     	uint
+	Name       	string
+	Prefix     	string
+	KeyHash    	string
+	Scopes     	string
+	ExpiresAt  	*time.Time
+	LastUsedAt 	*time.Time
+	CreatedAt  	time.Time
+	UpdatedAt  	time.Time
+}
\ No newline at end of file
@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// Audit log actions
+const (
+	AuditActionLogin                    = "auth.login"
+	AuditActionLoginFailed              = "auth.login_failed"
+	AuditActionLoginLockout             = "auth.lockout"
+	AuditActionRefresh                  = "auth.refresh"
+	AuditActionLogout                   = "auth.logout"
+	AuditActionPasswordReset            = "user.password_reset"
+	AuditActionPasswordChange           = "user.password_change"
+	AuditActionProfileUpdate            = "user.update"
+	AuditActionEmailChange              = "user.email_change"
+	AuditActionDeletionRequest          = "user.deletion_request"
+	AuditActionDeletionCancel           = "user.deletion_cancel"
+	AuditActionDeletionPurge            = "user.deletion_purge"
+	AuditActionExportRequest            = "user.export_request"
+	AuditActionAdminSuspend             = "admin.user.suspend"
+	AuditActionAdminUpdateRole          = "admin.user.role"
+	AuditActionAdminUnlock              = "admin.user.unlock"
+	AuditActionOrganizationDelete       = "organization.delete"
+	AuditActionOrganizationInvite       = "organization.invite"
+	AuditActionOrganizationMemberRole   = "organization.member.role"
+	AuditActionOrganizationMemberRemove = "organization.member.remove"
+)
+
+// Audit log targets
+const (
+	AuditTargetUser         = "user"
+	AuditTargetSession      = "session"
+	AuditTargetOrganization = "organization"
+)
+
+// AuditLog is one entry of the append-only audit log. Hash covers the entry and
+// the hash of the entry before it, changing or removing an entry breaks the chain.
+type AuditLog struct {
+	ID         	uint
+	Action     	string
+	ActorID    	*uint
+	TargetType 	string
+	TargetID   	string
+	IPAddress  	string
+	UserAgent  	string
+	Diff       	string
+	PrevHash   	string
+	Hash       	string
+	CreatedAt  	time.Time
+}
+
+// AuditLogFilter narrows the audit log search, zero values match every entry
+type AuditLogFilter struct {
+	ActorID    	*uint
+	Action     	string
+	TargetType 	string
+	TargetID   	string
+	Since      	*time.Time
+	Until      	*time.Time
+}
+
+// ComputeHash returns the SHA-256 of the entry chained to PrevHash
+func (l *AuditLog) ComputeHash() string {
+	payload, _ := json.Marshal([]any{
+		l.PrevHash,
+		l.Action,
+		l.ActorID,
+		l.TargetType,
+		l.TargetID,
+		l.IPAddress,
+		l.UserAgent,
+		l.Diff,
+		l.CreatedAt.UnixMicro(),
+	})
+
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
\ No newline at end of file
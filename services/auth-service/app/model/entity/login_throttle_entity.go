@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// Login throttles are kept per account and per IP address
+const (
+	ThrottleSubjectAccount = "account:"
+	ThrottleSubjectIP      = "ip:"
+)
+
+// LoginThrottle counts the recent failed logins of its subject
+type LoginThrottle struct {
+	ID            	uint
+	Subject       	string
+	Failures      	int
+	LockedUntil   	*time.Time
+	LastFailureAt 	time.Time
+	CreatedAt     	time.Time
+	UpdatedAt     	time.Time
+}
\ No newline at end of file
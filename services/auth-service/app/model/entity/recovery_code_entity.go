@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+type RecoveryCode struct {
+	ID        	uint
+	UserID    	uint
+	CodeHash  	string
+	UsedAt    	*time.Time
+	CreatedAt 	time.Time
+}
\ No newline at end of file
@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+type RefreshToken struct {
+	ID        	uint
+	UserID    	uint
+	FamilyID  	string
+	TokenHash 	string
+	ExpiresAt 	time.Time
+	UsedAt    	*time.Time
+	RevokedAt 	*time.Time
+	CreatedAt 	time.Time
+	UpdatedAt 	time.Time
+}
\ No newline at end of file
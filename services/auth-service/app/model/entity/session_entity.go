@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+type Session struct {
+	ID         	uint
+	UserID     	uint
+	FamilyID   	string
+	UserAgent  	string
+	IPAddress  	string
+	// OrganizationID is the organization the session works in, nil for the personal workspace
+	OrganizationID	*uint
+	LastSeenAt 	time.Time
+	RevokedAt  	*time.Time
+	CreatedAt  	time.Time
+	UpdatedAt  	time.Time
+}
\ No newline at end of file
@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+type SigningKey struct {
+	ID        	uint
+	Kid       	string
+	Algorithm 	string
+	PrivateKey	string
+	// RetiredAt is set once the key stops signing, it verifies until ExpiresAt
+	RetiredAt 	*time.Time
+	ExpiresAt 	*time.Time
+	CreatedAt 	time.Time
+	UpdatedAt 	time.Time
+}
\ No newline at end of file
@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+type TotpCredential struct {
+	ID             	uint
+	UserID         	uint
+	Secret         	string
+	ConfirmedAt    	*time.Time
+	LastUsedStep   	int64
+	FailedAttempts 	int
+	LockedUntil    	*time.Time
+	CreatedAt      	time.Time
+	UpdatedAt      	time.Time
+}
\ No newline at end of file
@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+type UserIdentity struct {
+	ID        	uint
+	UserID    	uint
+	Provider  	string
+	Subject   	string
+	Email     	string
+	CreatedAt 	time.Time
+	UpdatedAt 	time.Time
+}
\ No newline at end of file
@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+const (
+	VerificationPurposeVerifyEmail    = "verify_email"
+	VerificationPurposeResetPassword  = "reset_password"
+	VerificationPurposeChangeEmail    = "change_email"
+	VerificationPurposeCancelDeletion = "cancel_deletion"
+)
+
+type VerificationToken struct {
+	ID        	uint
+	UserID    	uint
+	Purpose   	string
+	// Email is the new address of a change_email token
+	Email     	*string
+	TokenHash 	string
+	ExpiresAt 	time.Time
+	UsedAt    	*time.Time
+	CreatedAt 	time.Time
+}
\ No newline at end of file
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AccountDeletionRepository interface {
+    FindActiveByUserIDForUpdate(tx *gorm.DB, deletion *entity.AccountDeletion, userID int) error
+    FindAllDue(tx *gorm.DB, deletions *[]entity.AccountDeletion, now time.Time, limit int) error
+    Search(tx *gorm.DB, deletions *[]entity.AccountDeletion, status string, offset int, limit int) error
+    CountSearch(tx *gorm.DB, count *int64, status string) error
+    Create(tx *gorm.DB, deletion *entity.AccountDeletion) error
+    Update(tx *gorm.DB, deletion *entity.AccountDeletion) error
+    UpdateStep(tx *gorm.DB, step *entity.AccountDeletionStep) error
+    TryAdvisoryLock(tx *gorm.DB, key int64) (bool, error)
+}
+
+type AccountDeletionRepositoryImpl struct {}
+
+func NewAccountDeletionRepositoryImpl() *AccountDeletionRepositoryImpl {
+    return &AccountDeletionRepositoryImpl{}
+}
+
+// FindActiveByUserIDForUpdate locks the deletion of the user that is not purged or cancelled yet
+func (r *AccountDeletionRepositoryImpl) FindActiveByUserIDForUpdate(tx *gorm.DB, deletion *entity.AccountDeletion, userID int) error {
+    return tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+        Where("user_id = ? AND status IN ?", userID, []string{entity.AccountDeletionScheduled, entity.AccountDeletionPurging}).
+        First(deletion).Error
+}
+
+// FindAllDue lists the deletions whose grace period ended and that are not purged yet
+func (r *AccountDeletionRepositoryImpl) FindAllDue(tx *gorm.DB, deletions *[]entity.AccountDeletion, now time.Time, limit int) error {
+    return tx.Preload("Steps").
+        Where("status IN ? AND purge_after <= ?", []string{entity.AccountDeletionScheduled, entity.AccountDeletionPurging}, now).
+        Order("purge_after ASC").
+        Limit(limit).
+        Find(deletions).Error
+}
+
+func (r *AccountDeletionRepositoryImpl) Search(tx *gorm.DB, deletions *[]entity.AccountDeletion, status string, offset int, limit int) error {
+    return searchAccountDeletions(tx, status).Preload("Steps").Order("id DESC").Offset(offset).Limit(limit).Find(deletions).Error
+}
+
+func (r *AccountDeletionRepositoryImpl) CountSearch(tx *gorm.DB, count *int64, status string) error {
+    return searchAccountDeletions(tx, status).Count(count).Error
+}
+
+// Create stores the deletion together with its steps
+func (r *AccountDeletionRepositoryImpl) Create(tx *gorm.DB, deletion *entity.AccountDeletion) error {
+    return tx.Create(deletion).Error
+}
+
+func (r *AccountDeletionRepositoryImpl) Update(tx *gorm.DB, deletion *entity.AccountDeletion) error {
+    return tx.Omit(clause.Associations).Save(deletion).Error
+}
+
+func (r *AccountDeletionRepositoryImpl) UpdateStep(tx *gorm.DB, step *entity.AccountDeletionStep) error {
+    return tx.Save(step).Error
+}
+
+// TryAdvisoryLock takes a transaction scoped advisory lock, released on commit or rollback
+func (r *AccountDeletionRepositoryImpl) TryAdvisoryLock(tx *gorm.DB, key int64) (bool, error) {
+    var locked bool
+    err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", key).Scan(&locked).Error
+    return locked, err
+}
+
+func searchAccountDeletions(tx *gorm.DB, status string) *gorm.DB {
+    tx = tx.Model(&entity.AccountDeletion{})
+    if status != "" {
+        tx = tx.Where("status = ?", status)
+    }
+
+    return tx
+}
\ No newline at end of file
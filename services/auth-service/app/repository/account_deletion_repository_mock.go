@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type AccountDeletionRepositoryMock struct {
+    mock.Mock
+}
+
+func (m *AccountDeletionRepositoryMock) FindActiveByUserIDForUpdate(tx *gorm.DB, deletion *entity.AccountDeletion, userID int) error {
+    args := m.Called(tx, deletion, userID)
+    return args.Error(0)
+}
+
+func (m *AccountDeletionRepositoryMock) FindAllDue(tx *gorm.DB, deletions *[]entity.AccountDeletion, now time.Time, limit int) error {
+    args := m.Called(tx, deletions, now, limit)
+    return args.Error(0)
+}
+
+func (m *AccountDeletionRepositoryMock) Search(tx *gorm.DB, deletions *[]entity.AccountDeletion, status string, offset int, limit int) error {
+    args := m.Called(tx, deletions, status, offset, limit)
+    return args.Error(0)
+}
+
+func (m *AccountDeletionRepositoryMock) CountSearch(tx *gorm.DB, count *int64, status string) error {
+    args := m.Called(tx, count, status)
+    return args.Error(0)
+}
+
+func (m *AccountDeletionRepositoryMock) Create(tx *gorm.DB, deletion *entity.AccountDeletion) error {
+    args := m.Called(tx, deletion)
+    return args.Error(0)
+}
+
+func (m *AccountDeletionRepositoryMock) Update(tx *gorm.DB, deletion *entity.AccountDeletion) error {
+    args := m.Called(tx, deletion)
+    return args.Error(0)
+}
+
+func (m *AccountDeletionRepositoryMock) UpdateStep(tx *gorm.DB, step *entity.AccountDeletionStep) error {
+    args := m.Called(tx, step)
+    return args.Error(0)
+}
+
+func (m *AccountDeletionRepositoryMock) TryAdvisoryLock(tx *gorm.DB, key int64) (bool, error) {
+    args := m.Called(tx, key)
+    return args.Bool(0), args.Error(1)
+}
\ No newline at end of file
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestAccountDeletionRepositoryImpl_FindActiveByUserIDForUpdate(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewAccountDeletionRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "account_deletions" WHERE user_id = $1 AND status IN ($2,$3) ORDER BY "account_deletions"."id" LIMIT $4 FOR UPDATE`)).
+        WithArgs(1, entity.AccountDeletionScheduled, entity.AccountDeletionPurging, 1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status"}).
+            AddRow(1, 1, entity.AccountDeletionScheduled))
+
+    var deletion entity.AccountDeletion
+    err := repo.FindActiveByUserIDForUpdate(db, &deletion, 1)
+
+    assert.NoError(t, err)
+    assert.Equal(t, entity.AccountDeletionScheduled, deletion.Status)
+}
+
+func TestAccountDeletionRepositoryImpl_FindActiveByUserIDForUpdate_NotFound(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewAccountDeletionRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "account_deletions" WHERE user_id = $1 AND status IN ($2,$3) ORDER BY "account_deletions"."id" LIMIT $4 FOR UPDATE`)).
+        WithArgs(1, entity.AccountDeletionScheduled, entity.AccountDeletionPurging, 1).
+        WillReturnError(gorm.ErrRecordNotFound)
+
+    var deletion entity.AccountDeletion
+    err := repo.FindActiveByUserIDForUpdate(db, &deletion, 1)
+
+    assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestAccountDeletionRepositoryImpl_FindAllDue(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewAccountDeletionRepositoryImpl()
+
+    now := time.Now()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "account_deletions" WHERE status IN ($1,$2) AND purge_after <= $3 ORDER BY purge_after ASC LIMIT $4`)).
+        WithArgs(entity.AccountDeletionScheduled, entity.AccountDeletionPurging, now, 10).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status"}).
+            AddRow(1, 1, entity.AccountDeletionScheduled))
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "account_deletion_steps" WHERE "account_deletion_steps"."account_deletion_id" = $1`)).
+        WithArgs(1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "account_deletion_id", "service", "status"}).
+            AddRow(1, 1, entity.AccountDeletionServiceChat, entity.AccountDeletionStepSucceeded).
+            AddRow(2, 1, entity.AccountDeletionServiceAuth, entity.AccountDeletionStepPending))
+
+    var deletions []entity.AccountDeletion
+    err := repo.FindAllDue(db, &deletions, now, 10)
+
+    assert.NoError(t, err)
+    assert.Len(t, deletions, 1)
+    assert.Len(t, deletions[0].Steps, 2)
+}
+
+func TestAccountDeletionRepositoryImpl_Search(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewAccountDeletionRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "account_deletions" WHERE status = $1 ORDER BY id DESC LIMIT $2`)).
+        WithArgs(entity.AccountDeletionPurging, 20).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status"}).
+            AddRow(2, 3, entity.AccountDeletionPurging))
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "account_deletion_steps" WHERE "account_deletion_steps"."account_deletion_id" = $1`)).
+        WithArgs(2).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "account_deletion_id", "service", "status"}))
+
+    var deletions []entity.AccountDeletion
+    err := repo.Search(db, &deletions, entity.AccountDeletionPurging, 0, 20)
+
+    assert.NoError(t, err)
+    assert.Len(t, deletions, 1)
+    assert.Equal(t, uint(3), deletions[0].UserID)
+}
+
+func TestAccountDeletionRepositoryImpl_CountSearch(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewAccountDeletionRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "account_deletions"`)).
+        WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+
+    var count int64
+    err := repo.CountSearch(db, &count, "")
+
+    assert.NoError(t, err)
+    assert.Equal(t, int64(4), count)
+}
+
+func TestAccountDeletionRepositoryImpl_Create(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewAccountDeletionRepositoryImpl()
+
+    email := "user@example.com"
+    deletion := &entity.AccountDeletion{
+        UserID:     1,
+        Email:      &email,
+        Status:     entity.AccountDeletionScheduled,
+        PurgeAfter: time.Now().Add(30 * 24 * time.Hour),
+        Steps: []entity.AccountDeletionStep{
+            {Service: entity.AccountDeletionServiceChat, Status: entity.AccountDeletionStepPending},
+        },
+    }
+
+    mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "account_deletions" ("user_id","email","status","purge_after","cancelled_at","purged_at","created_at","updated_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING "id"`)).
+        WithArgs(1, email, entity.AccountDeletionScheduled, deletion.PurgeAfter, nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+        WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+    mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "account_deletion_steps" ("account_deletion_id","service","status","attempts","last_error","completed_at","created_at","updated_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) ON CONFLICT ("id") DO UPDATE SET "account_deletion_id"="excluded"."account_deletion_id" RETURNING "id"`)).
+        WithArgs(1, entity.AccountDeletionServiceChat, entity.AccountDeletionStepPending, 0, nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+        WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+    err := repo.Create(db, deletion)
+
+    assert.NoError(t, err)
+    assert.Equal(t, uint(1), deletion.ID)
+    assert.Equal(t, uint(1), deletion.Steps[0].AccountDeletionID)
+}
+
+func TestAccountDeletionRepositoryImpl_Update(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewAccountDeletionRepositoryImpl()
+
+    now := time.Now()
+    deletion := &entity.AccountDeletion{
+        ID:         1,
+        UserID:     1,
+        Status:     entity.AccountDeletionPurged,
+        PurgeAfter: now,
+        PurgedAt:   &now,
+        Steps:      []entity.AccountDeletionStep{{ID: 1, AccountDeletionID: 1}},
+    }
+
+    mock.ExpectExec(regexp.QuoteMeta(`UPDATE "account_deletions" SET "user_id"=$1,"email"=$2,"status"=$3,"purge_after"=$4,"cancelled_at"=$5,"purged_at"=$6,"created_at"=$7,"updated_at"=$8 WHERE "id" = $9`)).
+        WithArgs(1, nil, entity.AccountDeletionPurged, now, nil, now, sqlmock.AnyArg(), sqlmock.AnyArg(), 1).
+        WillReturnResult(sqlmock.NewResult(1, 1))
+
+    err := repo.Update(db, deletion)
+
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAccountDeletionRepositoryImpl_UpdateStep(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewAccountDeletionRepositoryImpl()
+
+    lastError := "chat service unavailable"
+    step := &entity.AccountDeletionStep{
+        ID:                1,
+        AccountDeletionID: 1,
+        Service:           entity.AccountDeletionServiceChat,
+        Status:            entity.AccountDeletionStepPending,
+        Attempts:          2,
+        LastError:         &lastError,
+    }
+
+    mock.ExpectExec(regexp.QuoteMeta(`UPDATE "account_deletion_steps" SET "account_deletion_id"=$1,"service"=$2,"status"=$3,"attempts"=$4,"last_error"=$5,"completed_at"=$6,"created_at"=$7,"updated_at"=$8 WHERE "id" = $9`)).
+        WithArgs(1, entity.AccountDeletionServiceChat, entity.AccountDeletionStepPending, 2, lastError, nil, sqlmock.AnyArg(), sqlmock.AnyArg(), 1).
+        WillReturnResult(sqlmock.NewResult(1, 1))
+
+    err := repo.UpdateStep(db, step)
+
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAccountDeletionRepositoryImpl_TryAdvisoryLock(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewAccountDeletionRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT pg_try_advisory_xact_lock($1)`)).
+        WithArgs(int64(42)).
+        WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(true))
+
+    locked, err := repo.TryAdvisoryLock(db, 42)
+
+    assert.NoError(t, err)
+    assert.True(t, locked)
+}
\ No newline at end of file
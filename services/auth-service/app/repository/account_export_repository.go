@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AccountExportRepository interface {
+    FindByIDAndUserID(tx *gorm.DB, export *entity.AccountExport, id int, userID int) error
+    FindAllByUserID(tx *gorm.DB, exports *[]entity.AccountExport, userID int) error
+    CountActiveByUserID(tx *gorm.DB, count *int64, userID int) error
+    FindAllDueForUpdate(tx *gorm.DB, exports *[]entity.AccountExport, staleBefore time.Time, limit int) error
+    Create(tx *gorm.DB, export *entity.AccountExport) error
+    Update(tx *gorm.DB, export *entity.AccountExport) error
+    ExpireAll(tx *gorm.DB, now time.Time) error
+    DeleteAllByUserID(tx *gorm.DB, userID int) error
+}
+
+type AccountExportRepositoryImpl struct {}
+
+func NewAccountExportRepositoryImpl() *AccountExportRepositoryImpl {
+    return &AccountExportRepositoryImpl{}
+}
+
+// FindByIDAndUserID loads the export with its archive
+func (r *AccountExportRepositoryImpl) FindByIDAndUserID(tx *gorm.DB, export *entity.AccountExport, id int, userID int) error {
+    return tx.Where("id = ? AND user_id = ?", id, userID).First(export).Error
+}
+
+// FindAllByUserID lists the exports of the user without their archives, newest first
+func (r *AccountExportRepositoryImpl) FindAllByUserID(tx *gorm.DB, exports *[]entity.AccountExport, userID int) error {
+    return tx.Omit("archive").Where("user_id = ?", userID).Order("id DESC").Find(exports).Error
+}
+
+// CountActiveByUserID counts the exports of the user that are waiting or running
+func (r *AccountExportRepositoryImpl) CountActiveByUserID(tx *gorm.DB, count *int64, userID int) error {
+    return tx.Model(&entity.AccountExport{}).
+        Where("user_id = ? AND status IN ?", userID, []string{entity.AccountExportPending, entity.AccountExportRunning}).
+        Count(count).Error
+}
+
+// FindAllDueForUpdate claims the pending exports and the running ones started
+// before staleBefore, whose worker stopped, skipping the ones claimed elsewhere
+func (r *AccountExportRepositoryImpl) FindAllDueForUpdate(tx *gorm.DB, exports *[]entity.AccountExport, staleBefore time.Time, limit int) error {
+    return tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+        Omit("archive").
+        Where("status = ? OR (status = ? AND started_at < ?)", entity.AccountExportPending, entity.AccountExportRunning, staleBefore).
+        Order("id ASC").
+        Limit(limit).
+        Find(exports).Error
+}
+
+func (r *AccountExportRepositoryImpl) Create(tx *gorm.DB, export *entity.AccountExport) error {
+    return tx.Create(export).Error
+}
+
+func (r *AccountExportRepositoryImpl) Update(tx *gorm.DB, export *entity.AccountExport) error {
+    return tx.Save(export).Error
+}
+
+// ExpireAll drops the archives of the ready exports that expired
+func (r *AccountExportRepositoryImpl) ExpireAll(tx *gorm.DB, now time.Time) error {
+    return tx.Model(&entity.AccountExport{}).
+        Where("status = ? AND expires_at <= ?", entity.AccountExportReady, now).
+        Updates(map[string]any{"status": entity.AccountExportExpired, "archive": nil}).Error
+}
+
+func (r *AccountExportRepositoryImpl) DeleteAllByUserID(tx *gorm.DB, userID int) error {
+    return tx.Where("user_id = ?", userID).Delete(&entity.AccountExport{}).Error
+}
\ No newline at end of file
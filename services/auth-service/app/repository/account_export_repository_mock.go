@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type AccountExportRepositoryMock struct {
+    mock.Mock
+}
+
+func (m *AccountExportRepositoryMock) FindByIDAndUserID(tx *gorm.DB, export *entity.AccountExport, id int, userID int) error {
+    args := m.Called(tx, export, id, userID)
+    return args.Error(0)
+}
+
+func (m *AccountExportRepositoryMock) FindAllByUserID(tx *gorm.DB, exports *[]entity.AccountExport, userID int) error {
+    args := m.Called(tx, exports, userID)
+    return args.Error(0)
+}
+
+func (m *AccountExportRepositoryMock) CountActiveByUserID(tx *gorm.DB, count *int64, userID int) error {
+    args := m.Called(tx, count, userID)
+    return args.Error(0)
+}
+
+func (m *AccountExportRepositoryMock) FindAllDueForUpdate(tx *gorm.DB, exports *[]entity.AccountExport, staleBefore time.Time, limit int) error {
+    args := m.Called(tx, exports, staleBefore, limit)
+    return args.Error(0)
+}
+
+func (m *AccountExportRepositoryMock) Create(tx *gorm.DB, export *entity.AccountExport) error {
+    args := m.Called(tx, export)
+    return args.Error(0)
+}
+
+func (m *AccountExportRepositoryMock) Update(tx *gorm.DB, export *entity.AccountExport) error {
+    args := m.Called(tx, export)
+    return args.Error(0)
+}
+
+func (m *AccountExportRepositoryMock) ExpireAll(tx *gorm.DB, now time.Time) error {
+    args := m.Called(tx, now)
+    return args.Error(0)
+}
+
+func (m *AccountExportRepositoryMock) DeleteAllByUserID(tx *gorm.DB, userID int) error {
+    args := m.Called(tx, userID)
+    return args.Error(0)
+}
\ No newline at end of file
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestAccountExportRepositoryImpl_FindByIDAndUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewAccountExportRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "account_exports" WHERE id = $1 AND user_id = $2 ORDER BY "account_exports"."id" LIMIT $3`)).
+        WithArgs(1, 2, 1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status", "archive", "size"}).
+            AddRow(1, 2, entity.AccountExportReady, []byte("PK"), 2))
+
+    var export entity.AccountExport
+    err := repo.FindByIDAndUserID(db, &export, 1, 2)
+
+    assert.NoError(t, err)
+    assert.Equal(t, []byte("PK"), export.Archive)
+}
+
+func TestAccountExportRepositoryImpl_FindByIDAndUserID_NotFound(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewAccountExportRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "account_exports" WHERE id = $1 AND user_id = $2 ORDER BY "account_exports"."id" LIMIT $3`)).
+        WithArgs(1, 2, 1).
+        WillReturnError(gorm.ErrRecordNotFound)
+
+    var export entity.AccountExport
+    err := repo.FindByIDAndUserID(db, &export, 1, 2)
+
+    assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestAccountExportRepositoryImpl_FindAllByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewAccountExportRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT "account_exports"."id","account_exports"."user_id","account_exports"."status","account_exports"."size","account_exports"."error","account_exports"."attempts","account_exports"."started_at","account_exports"."completed_at","account_exports"."expires_at","account_exports"."created_at","account_exports"."updated_at" FROM "account_exports" WHERE user_id = $1 ORDER BY id DESC`)).
+        WithArgs(1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status"}).
+            AddRow(2, 1, entity.AccountExportPending).
+            AddRow(1, 1, entity.AccountExportReady))
+
+    var exports []entity.AccountExport
+    err := repo.FindAllByUserID(db, &exports, 1)
+
+    assert.NoError(t, err)
+    assert.Len(t, exports, 2)
+}
+
+func TestAccountExportRepositoryImpl_CountActiveByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewAccountExportRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "account_exports" WHERE user_id = $1 AND status IN ($2,$3)`)).
+        WithArgs(1, entity.AccountExportPending, entity.AccountExportRunning).
+        WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+    var count int64
+    err := repo.CountActiveByUserID(db, &count, 1)
+
+    assert.NoError(t, err)
+    assert.Equal(t, int64(1), count)
+}
+
+func TestAccountExportRepositoryImpl_FindAllDueForUpdate(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewAccountExportRepositoryImpl()
+
+    staleBefore := time.Now().Add(-time.Hour)
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT "account_exports"."id","account_exports"."user_id","account_exports"."status","account_exports"."size","account_exports"."error","account_exports"."attempts","account_exports"."started_at","account_exports"."completed_at","account_exports"."expires_at","account_exports"."created_at","account_exports"."updated_at" FROM "account_exports" WHERE status = $1 OR (status = $2 AND started_at < $3) ORDER BY id ASC LIMIT $4 FOR UPDATE SKIP LOCKED`)).
+        WithArgs(entity.AccountExportPending, entity.AccountExportRunning, staleBefore, 5).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status"}).
+            AddRow(1, 1, entity.AccountExportPending))
+
+    var exports []entity.AccountExport
+    err := repo.FindAllDueForUpdate(db, &exports, staleBefore, 5)
+
+    assert.NoError(t, err)
+    assert.Len(t, exports, 1)
+}
+
+func TestAccountExportRepositoryImpl_Create(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewAccountExportRepositoryImpl()
+
+    export := &entity.AccountExport{
+        UserID: 1,
+        Status: entity.AccountExportPending,
+    }
+
+    mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "account_exports" ("user_id","status","archive","size","error","attempts","started_at","completed_at","expires_at","created_at","updated_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11) RETURNING "id"`)).
+        WithArgs(1, entity.AccountExportPending, sqlmock.AnyArg(), 0, nil, 0, nil, nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+        WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+    err := repo.Create(db, export)
+
+    assert.NoError(t, err)
+    assert.Equal(t, uint(1), export.ID)
+}
+
+func TestAccountExportRepositoryImpl_ExpireAll(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewAccountExportRepositoryImpl()
+
+    now := time.Now()
+
+    mock.ExpectExec(regexp.QuoteMeta(`UPDATE "account_exports" SET "archive"=$1,"status"=$2,"updated_at"=$3 WHERE status = $4 AND expires_at <= $5`)).
+        WithArgs(nil, entity.AccountExportExpired, sqlmock.AnyArg(), entity.AccountExportReady, now).
+        WillReturnResult(sqlmock.NewResult(0, 2))
+
+    err := repo.ExpireAll(db, now)
+
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAccountExportRepositoryImpl_DeleteAllByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewAccountExportRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "account_exports" WHERE user_id = $1`)).
+        WithArgs(1).
+        WillReturnResult(sqlmock.NewResult(0, 1))
+
+    err := repo.DeleteAllByUserID(db, 1)
+
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
\ No newline at end of file
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ApiKeyRepository interface {
+    FindByIDAndUserID(tx *gorm.DB, apiKey *entity.ApiKey, id int, userID int) error
+    FindByKeyHash(tx *gorm.DB, apiKey *entity.ApiKey, keyHash string) error
+    FindAllByUserID(tx *gorm.DB, apiKeys *[]entity.ApiKey, userID int) error
+    CountByUserID(tx *gorm.DB, count *int64, userID int) error
+    Create(tx *gorm.DB, apiKey *entity.ApiKey) error
+    Update(tx *gorm.DB, apiKey *entity.ApiKey) error
+    Delete(tx *gorm.DB, apiKey *entity.ApiKey) error
+    Touch(tx *gorm.DB, id int, lastUsedAt time.Time) error
+    DeleteAllByUserID(tx *gorm.DB, userID int) error
+}
+
+type ApiKeyRepositoryImpl struct {}
+
+func NewApiKeyRepositoryImpl() *ApiKeyRepositoryImpl {
+    return &ApiKeyRepositoryImpl{}
+}
+
+func (r *ApiKeyRepositoryImpl) FindByIDAndUserID(tx *gorm.DB, apiKey *entity.ApiKey, id int, userID int) error {
+    return tx.Where("id = ? AND user_id = ?", id, userID).First(apiKey).Error
+}
+
+func (r *ApiKeyRepositoryImpl) FindByKeyHash(tx *gorm.DB, apiKey *entity.ApiKey, keyHash string) error {
+    return tx.Where("key_hash = ?", keyHash).First(apiKey).Error
+}
+
+func (r *ApiKeyRepositoryImpl) FindAllByUserID(tx *gorm.DB, apiKeys *[]entity.ApiKey, userID int) error {
+    return tx.Where("user_id = ?", userID).Order("created_at DESC").Find(apiKeys).Error
+}
+
+func (r *ApiKeyRepositoryImpl) CountByUserID(tx *gorm.DB, count *int64, userID int) error {
+    return tx.Model(&entity.ApiKey{}).Where("user_id = ?", userID).Count(count).Error
+}
+
+func (r *ApiKeyRepositoryImpl) Create(tx *gorm.DB, apiKey *entity.ApiKey) error {
+    return tx.Create(apiKey).Error
+}
+
+func (r *ApiKeyRepositoryImpl) Update(tx *gorm.DB, apiKey *entity.ApiKey) error {
+    return tx.Save(apiKey).Error
+}
+
+func (r *ApiKeyRepositoryImpl) Delete(tx *gorm.DB, apiKey *entity.ApiKey) error {
+    return tx.Delete(apiKey).Error
+}
+
+// Touch records the use of a key without rewriting the rest of it
+func (r *ApiKeyRepositoryImpl) Touch(tx *gorm.DB, id int, lastUsedAt time.Time) error {
+    return tx.Model(&entity.ApiKey{}).Where("id = ?", id).Update("last_used_at", lastUsedAt).Error
+}
+
+func (r *ApiKeyRepositoryImpl) DeleteAllByUserID(tx *gorm.DB, userID int) error {
+    return tx.Where("user_id = ?", userID).Delete(&entity.ApiKey{}).Error
+}
\ No newline at end of file
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type ApiKeyRepositoryMock struct {
+    mock.Mock
+}
+
+func (m *ApiKeyRepositoryMock) FindByIDAndUserID(tx *gorm.DB, apiKey *entity.ApiKey, id int, userID int) error {
+    args := m.Called(tx, apiKey, id, userID)
+    return args.Error(0)
+}
+
+func (m *ApiKeyRepositoryMock) FindByKeyHash(tx *gorm.DB, apiKey *entity.ApiKey, keyHash string) error {
+    args := m.Called(tx, apiKey, keyHash)
+    return args.Error(0)
+}
+
+func (m *ApiKeyRepositoryMock) FindAllByUserID(tx *gorm.DB, apiKeys *[]entity.ApiKey, userID int) error {
+    args := m.Called(tx, apiKeys, userID)
+    return args.Error(0)
+}
+
+func (m *ApiKeyRepositoryMock) CountByUserID(tx *gorm.DB, count *int64, userID int) error {
+    args := m.Called(tx, count, userID)
+    return args.Error(0)
+}
+
+func (m *ApiKeyRepositoryMock) Create(tx *gorm.DB, apiKey *entity.ApiKey) error {
+    args := m.Called(tx, apiKey)
+    return args.Error(0)
+}
+
+func (m *ApiKeyRepositoryMock) Update(tx *gorm.DB, apiKey *entity.ApiKey) error {
+    args := m.Called(tx, apiKey)
+    return args.Error(0)
+}
+
+func (m *ApiKeyRepositoryMock) Delete(tx *gorm.DB, apiKey *entity.ApiKey) error {
+    args := m.Called(tx, apiKey)
+    return args.Error(0)
+}
+
+func (m *ApiKeyRepositoryMock) Touch(tx *gorm.DB, id int, lastUsedAt time.Time) error {
+    args := m.Called(tx, id, lastUsedAt)
+    return args.Error(0)
+}
+
+func (m *ApiKeyRepositoryMock) DeleteAllByUserID(tx *gorm.DB, userID int) error {
+    args := m.Called(tx, userID)
+    return args.Error(0)
+}
\ No newline at end of file
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestApiKeyRepositoryImpl_FindByIDAndUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewApiKeyRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "api_keys" WHERE id = $1 AND user_id = $2 ORDER BY "api_keys"."id" LIMIT $3`)).
+        WithArgs(1, 2, 1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "prefix", "scopes"}).
+            AddRow(1, 2, "Scripts", "sk_1a2b3c4d", "chats:read"))
+
+    var apiKey entity.ApiKey
+    err := repo.FindByIDAndUserID(db, &apiKey, 1, 2)
+
+    assert.NoError(t, err)
+    assert.Equal(t, "Scripts", apiKey.Name)
+}
+
+func TestApiKeyRepositoryImpl_FindByKeyHash_NotFound(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewApiKeyRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "api_keys" WHERE key_hash = $1 ORDER BY "api_keys"."id" LIMIT $2`)).
+        WithArgs("hash", 1).
+        WillReturnError(gorm.ErrRecordNotFound)
+
+    var apiKey entity.ApiKey
+    err := repo.FindByKeyHash(db, &apiKey, "hash")
+
+    assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestApiKeyRepositoryImpl_FindAllByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewApiKeyRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "api_keys" WHERE user_id = $1 ORDER BY created_at DESC`)).
+        WithArgs(1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name"}).
+            AddRow(2, 1, "CI").
+            AddRow(1, 1, "Scripts"))
+
+    var apiKeys []entity.ApiKey
+    err := repo.FindAllByUserID(db, &apiKeys, 1)
+
+    assert.NoError(t, err)
+    assert.Len(t, apiKeys, 2)
+}
+
+func TestApiKeyRepositoryImpl_CountByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewApiKeyRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "api_keys" WHERE user_id = $1`)).
+        WithArgs(1).
+        WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+    var count int64
+    err := repo.CountByUserID(db, &count, 1)
+
+    assert.NoError(t, err)
+    assert.Equal(t, int64(3), count)
+}
+
+func TestApiKeyRepositoryImpl_Create(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewApiKeyRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "api_keys" ("user_id","name","prefix","key_hash","scopes","expires_at","last_used_at","created_at","updated_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING "id"`)).
+        WithArgs(1, "Scripts", "sk_1a2b3c4d", "hash", "chats:read,chats:write", nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+        WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+    apiKey := &entity.ApiKey{
+        UserID:  1,
+        Name:    "Scripts",
+        Prefix:  "sk_1a2b3c4d",
+        KeyHash: "hash",
+        Scopes:  "chats:read,chats:write",
+    }
+    err := repo.Create(db, apiKey)
+
+    assert.NoError(t, err)
+    assert.Equal(t, uint(1), apiKey.ID)
+}
+
+func TestApiKeyRepositoryImpl_Delete(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewApiKeyRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "api_keys" WHERE "api_keys"."id" = $1`)).
+        WithArgs(1).
+        WillReturnResult(sqlmock.NewResult(0, 1))
+
+    err := repo.Delete(db, &entity.ApiKey{ID: 1})
+
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestApiKeyRepositoryImpl_Touch(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewApiKeyRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`UPDATE "api_keys" SET "last_used_at"=$1,"updated_at"=$2 WHERE id = $3`)).
+        WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 1).
+        WillReturnResult(sqlmock.NewResult(0, 1))
+
+    err := repo.Touch(db, 1, time.Now())
+
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestApiKeyRepositoryImpl_DeleteAllByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewApiKeyRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "api_keys" WHERE user_id = $1`)).
+        WithArgs(1).
+        WillReturnResult(sqlmock.NewResult(0, 2))
+
+    err := repo.DeleteAllByUserID(db, 1)
+
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
\ No newline at end of file
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// auditLogChainLock is the advisory lock serializing appends, each entry has to
+// chain to the entry committed right before it
+const auditLogChainLock = 4_460_118_001
+
+type AuditLogRepository interface {
+    Append(tx *gorm.DB, log *entity.AuditLog) error
+    Search(tx *gorm.DB, logs *[]entity.AuditLog, filter *entity.AuditLogFilter, offset int, limit int) error
+    CountSearch(tx *gorm.DB, count *int64, filter *entity.AuditLogFilter) error
+    FindAllAfterID(tx *gorm.DB, logs *[]entity.AuditLog, afterID uint, limit int) error
+}
+
+type AuditLogRepositoryImpl struct {}
+
+func NewAuditLogRepositoryImpl() *AuditLogRepositoryImpl {
+    return &AuditLogRepositoryImpl{}
+}
+
+// Append chains the entry to the last one and stores it. Within a transaction
+// the lock is held until it ends, the entry is only appended if it commits.
+func (r *AuditLogRepositoryImpl) Append(tx *gorm.DB, log *entity.AuditLog) error {
+    return tx.Transaction(func(tx *gorm.DB) error {
+        if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", auditLogChainLock).Error; err != nil {
+            return err
+        }
+
+        last := new(entity.AuditLog)
+        if err := tx.Select("hash").Order("id DESC").Limit(1).Find(last).Error; err != nil {
+            return err
+        }
+
+        // The database keeps microseconds, the hash has to match what is read back
+        if log.CreatedAt.IsZero() {
+            log.CreatedAt = time.Now()
+        }
+        log.CreatedAt = log.CreatedAt.UTC().Truncate(time.Microsecond)
+        if log.Diff == "" {
+            log.Diff = "{}"
+        }
+
+        log.PrevHash = last.Hash
+        log.Hash = log.ComputeHash()
+
+        return tx.Create(log).Error
+    })
+}
+
+func (r *AuditLogRepositoryImpl) Search(tx *gorm.DB, logs *[]entity.AuditLog, filter *entity.AuditLogFilter, offset int, limit int) error {
+    return searchAuditLogs(tx, filter).Order("id DESC").Offset(offset).Limit(limit).Find(logs).Error
+}
+
+func (r *AuditLogRepositoryImpl) CountSearch(tx *gorm.DB, count *int64, filter *entity.AuditLogFilter) error {
+    return searchAuditLogs(tx, filter).Count(count).Error
+}
+
+// FindAllAfterID walks the chain in the order it was written
+func (r *AuditLogRepositoryImpl) FindAllAfterID(tx *gorm.DB, logs *[]entity.AuditLog, afterID uint, limit int) error {
+    return tx.Where("id > ?", afterID).Order("id ASC").Limit(limit).Find(logs).Error
+}
+
+func searchAuditLogs(tx *gorm.DB, filter *entity.AuditLogFilter) *gorm.DB {
+    tx = tx.Model(&entity.AuditLog{})
+    if filter.ActorID != nil {
+        tx = tx.Where("actor_id = ?", *filter.ActorID)
+    }
+    if filter.Action != "" {
+        tx = tx.Where("action = ?", filter.Action)
+    }
+    if filter.TargetType != "" {
+        tx = tx.Where("target_type = ?", filter.TargetType)
+    }
+    if filter.TargetID != "" {
+        tx = tx.Where("target_id = ?", filter.TargetID)
+    }
+    if filter.Since != nil {
+        tx = tx.Where("created_at >= ?", *filter.Since)
+    }
+    if filter.Until != nil {
+        tx = tx.Where("created_at < ?", *filter.Until)
+    }
+
+    return tx
+}
\ No newline at end of file
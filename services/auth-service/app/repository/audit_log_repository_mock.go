@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type AuditLogRepositoryMock struct {
+    mock.Mock
+}
+
+func (m *AuditLogRepositoryMock) Append(tx *gorm.DB, log *entity.AuditLog) error {
+    args := m.Called(tx, log)
+    return args.Error(0)
+}
+
+func (m *AuditLogRepositoryMock) Search(tx *gorm.DB, logs *[]entity.AuditLog, filter *entity.AuditLogFilter, offset int, limit int) error {
+    args := m.Called(tx, logs, filter, offset, limit)
+    return args.Error(0)
+}
+
+func (m *AuditLogRepositoryMock) CountSearch(tx *gorm.DB, count *int64, filter *entity.AuditLogFilter) error {
+    args := m.Called(tx, count, filter)
+    return args.Error(0)
+}
+
+func (m *AuditLogRepositoryMock) FindAllAfterID(tx *gorm.DB, logs *[]entity.AuditLog, afterID uint, limit int) error {
+    args := m.Called(tx, logs, afterID, limit)
+    return args.Error(0)
+}
\ No newline at end of file
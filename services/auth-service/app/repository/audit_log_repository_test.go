@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditLogRepositoryImpl_Append(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewAuditLogRepositoryImpl()
+
+    prevHash := "4f1c3e3a2b9d8c7f6e5d4c3b2a1908f7e6d5c4b3a29180f7e6d5c4b3a2918070"
+
+    mock.ExpectBegin()
+    mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_xact_lock($1)`)).
+        WithArgs(auditLogChainLock).
+        WillReturnResult(sqlmock.NewResult(0, 0))
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT "hash" FROM "audit_logs" ORDER BY id DESC LIMIT $1`)).
+        WithArgs(1).
+        WillReturnRows(sqlmock.NewRows([]string{"hash"}).AddRow(prevHash))
+    mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "audit_logs" ("action","actor_id","target_type","target_id","ip_address","user_agent","diff","prev_hash","hash","created_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) RETURNING "id"`)).
+        WithArgs(entity.AuditActionLogin, 1, entity.AuditTargetUser, "1", "127.0.0.1", "curl/8.0", "{}", prevHash, sqlmock.AnyArg(), sqlmock.AnyArg()).
+        WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+    mock.ExpectCommit()
+
+    actorID := uint(1)
+    log := &entity.AuditLog{
+        Action:     entity.AuditActionLogin,
+        ActorID:    &actorID,
+        TargetType: entity.AuditTargetUser,
+        TargetID:   "1",
+        IPAddress:  "127.0.0.1",
+        UserAgent:  "curl/8.0",
+    }
+    err := repo.Append(db, log)
+
+    assert.NoError(t, err)
+    assert.Equal(t, uint(2), log.ID)
+    assert.Equal(t, prevHash, log.PrevHash)
+    assert.Equal(t, log.ComputeHash(), log.Hash)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuditLogRepositoryImpl_Append_First(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewAuditLogRepositoryImpl()
+
+    mock.ExpectBegin()
+    mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_xact_lock($1)`)).
+        WithArgs(auditLogChainLock).
+        WillReturnResult(sqlmock.NewResult(0, 0))
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT "hash" FROM "audit_logs" ORDER BY id DESC LIMIT $1`)).
+        WithArgs(1).
+        WillReturnRows(sqlmock.NewRows([]string{"hash"}))
+    mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "audit_logs"`)).
+        WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+    mock.ExpectCommit()
+
+    log := &entity.AuditLog{Action: entity.AuditActionLoginFailed}
+    err := repo.Append(db, log)
+
+    assert.NoError(t, err)
+    assert.Empty(t, log.PrevHash)
+    assert.Len(t, log.Hash, 64)
+}
+
+func TestAuditLogRepositoryImpl_Search(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewAuditLogRepositoryImpl()
+
+    actorID := uint(1)
+    since := time.Now().Add(-time.Hour)
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "audit_logs" WHERE actor_id = $1 AND action = $2 AND created_at >= $3 ORDER BY id DESC LIMIT $4 OFFSET $5`)).
+        WithArgs(1, entity.AuditActionLogin, since, 20, 20).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "action", "actor_id"}).
+            AddRow(3, entity.AuditActionLogin, 1))
+
+    var logs []entity.AuditLog
+    err := repo.Search(db, &logs, &entity.AuditLogFilter{ActorID: &actorID, Action: entity.AuditActionLogin, Since: &since}, 20, 20)
+
+    assert.NoError(t, err)
+    assert.Len(t, logs, 1)
+    assert.Equal(t, uint(3), logs[0].ID)
+}
+
+func TestAuditLogRepositoryImpl_CountSearch(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewAuditLogRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "audit_logs" WHERE target_type = $1 AND target_id = $2`)).
+        WithArgs(entity.AuditTargetUser, "1").
+        WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+
+    var count int64
+    err := repo.CountSearch(db, &count, &entity.AuditLogFilter{TargetType: entity.AuditTargetUser, TargetID: "1"})
+
+    assert.NoError(t, err)
+    assert.Equal(t, int64(4), count)
+}
+
+func TestAuditLogRepositoryImpl_FindAllAfterID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewAuditLogRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "audit_logs" WHERE id > $1 ORDER BY id ASC LIMIT $2`)).
+        WithArgs(10, 100).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "hash"}).
+            AddRow(11, "a").
+            AddRow(12, "b"))
+
+    var logs []entity.AuditLog
+    err := repo.FindAllAfterID(db, &logs, 10, 100)
+
+    assert.NoError(t, err)
+    assert.Len(t, logs, 2)
+}
\ No newline at end of file
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LoginThrottleRepository interface {
+    FindAllBySubjects(tx *gorm.DB, throttles *[]entity.LoginThrottle, subjects []string) error
+    FindOrCreateForUpdate(tx *gorm.DB, throttle *entity.LoginThrottle, subject string) error
+    Update(tx *gorm.DB, throttle *entity.LoginThrottle) error
+    DeleteBySubject(tx *gorm.DB, subject string) error
+}
+
+type LoginThrottleRepositoryImpl struct {}
+
+func NewLoginThrottleRepositoryImpl() *LoginThrottleRepositoryImpl {
+    return &LoginThrottleRepositoryImpl{}
+}
+
+func (r *LoginThrottleRepositoryImpl) FindAllBySubjects(tx *gorm.DB, throttles *[]entity.LoginThrottle, subjects []string) error {
+    return tx.Where("subject IN ?", subjects).Find(throttles).Error
+}
+
+// FindOrCreateForUpdate locks the throttle of the subject, concurrent failures are counted one after another
+func (r *LoginThrottleRepositoryImpl) FindOrCreateForUpdate(tx *gorm.DB, throttle *entity.LoginThrottle, subject string) error {
+    if err := tx.Clauses(clause.OnConflict{
+        Columns:   []clause.Column{{Name: "subject"}},
+        DoNothing: true,
+    }).Create(&entity.LoginThrottle{Subject: subject, LastFailureAt: time.Now()}).Error; err != nil {
+        return err
+    }
+
+    return tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+        Where("subject = ?", subject).
+        First(throttle).Error
+}
+
+func (r *LoginThrottleRepositoryImpl) Update(tx *gorm.DB, throttle *entity.LoginThrottle) error {
+    return tx.Save(throttle).Error
+}
+
+func (r *LoginThrottleRepositoryImpl) DeleteBySubject(tx *gorm.DB, subject string) error {
+    return tx.Where("subject = ?", subject).Delete(&entity.LoginThrottle{}).Error
+}
\ No newline at end of file
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type LoginThrottleRepositoryMock struct {
+    mock.Mock
+}
+
+func (m *LoginThrottleRepositoryMock) FindAllBySubjects(tx *gorm.DB, throttles *[]entity.LoginThrottle, subjects []string) error {
+    args := m.Called(tx, throttles, subjects)
+    return args.Error(0)
+}
+
+func (m *LoginThrottleRepositoryMock) FindOrCreateForUpdate(tx *gorm.DB, throttle *entity.LoginThrottle, subject string) error {
+    args := m.Called(tx, throttle, subject)
+    return args.Error(0)
+}
+
+func (m *LoginThrottleRepositoryMock) Update(tx *gorm.DB, throttle *entity.LoginThrottle) error {
+    args := m.Called(tx, throttle)
+    return args.Error(0)
+}
+
+func (m *LoginThrottleRepositoryMock) DeleteBySubject(tx *gorm.DB, subject string) error {
+    args := m.Called(tx, subject)
+    return args.Error(0)
+}
\ No newline at end of file
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginThrottleRepositoryImpl_FindAllBySubjects(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewLoginThrottleRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "login_throttles" WHERE subject IN ($1,$2)`)).
+        WithArgs("account:test@example.com", "ip:127.0.0.1").
+        WillReturnRows(sqlmock.NewRows([]string{"id", "subject", "failures", "locked_until"}).
+            AddRow(1, "account:test@example.com", 5, time.Now().Add(time.Minute)))
+
+    var throttles []entity.LoginThrottle
+    err := repo.FindAllBySubjects(db, &throttles, []string{"account:test@example.com", "ip:127.0.0.1"})
+
+    assert.NoError(t, err)
+    assert.Len(t, throttles, 1)
+    assert.NotNil(t, throttles[0].LockedUntil)
+}
+
+func TestLoginThrottleRepositoryImpl_FindOrCreateForUpdate(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewLoginThrottleRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "login_throttles" ("subject","failures","locked_until","last_failure_at","created_at","updated_at") VALUES ($1,$2,$3,$4,$5,$6) ON CONFLICT ("subject") DO NOTHING RETURNING "id"`)).
+        WithArgs("ip:127.0.0.1", 0, nil, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+        WillReturnRows(sqlmock.NewRows([]string{"id"}))
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "login_throttles" WHERE subject = $1 ORDER BY "login_throttles"."id" LIMIT $2 FOR UPDATE`)).
+        WithArgs("ip:127.0.0.1", 1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "subject", "failures"}).
+            AddRow(1, "ip:127.0.0.1", 3))
+
+    var throttle entity.LoginThrottle
+    err := repo.FindOrCreateForUpdate(db, &throttle, "ip:127.0.0.1")
+
+    assert.NoError(t, err)
+    assert.Equal(t, 3, throttle.Failures)
+}
+
+func TestLoginThrottleRepositoryImpl_DeleteBySubject(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewLoginThrottleRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "login_throttles" WHERE subject = $1`)).
+        WithArgs("account:test@example.com").
+        WillReturnResult(sqlmock.NewResult(0, 1))
+
+    err := repo.DeleteBySubject(db, "account:test@example.com")
+
+    assert.NoError(t, err)
+}
\ No newline at end of file
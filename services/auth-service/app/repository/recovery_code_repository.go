@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RecoveryCodeRepository interface {
+    FindUnusedForUpdate(tx *gorm.DB, code *entity.RecoveryCode, userID int, codeHash string) error
+    CountUnusedByUserID(tx *gorm.DB, count *int64, userID int) error
+    CreateAll(tx *gorm.DB, codes *[]entity.RecoveryCode) error
+    Update(tx *gorm.DB, code *entity.RecoveryCode) error
+    DeleteAllByUserID(tx *gorm.DB, userID int) error
+}
+
+type RecoveryCodeRepositoryImpl struct {}
+
+func NewRecoveryCodeRepositoryImpl() *RecoveryCodeRepositoryImpl {
+    return &RecoveryCodeRepositoryImpl{}
+}
+
+func (r *RecoveryCodeRepositoryImpl) FindUnusedForUpdate(tx *gorm.DB, code *entity.RecoveryCode, userID int, codeHash string) error {
+    return tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+        Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+        First(code).Error
+}
+
+func (r *RecoveryCodeRepositoryImpl) CountUnusedByUserID(tx *gorm.DB, count *int64, userID int) error {
+    return tx.Model(&entity.RecoveryCode{}).Where("user_id = ? AND used_at IS NULL", userID).Count(count).Error
+}
+
+func (r *RecoveryCodeRepositoryImpl) CreateAll(tx *gorm.DB, codes *[]entity.RecoveryCode) error {
+    return tx.Create(codes).Error
+}
+
+func (r *RecoveryCodeRepositoryImpl) Update(tx *gorm.DB, code *entity.RecoveryCode) error {
+    return tx.Save(code).Error
+}
+
+func (r *RecoveryCodeRepositoryImpl) DeleteAllByUserID(tx *gorm.DB, userID int) error {
+    return tx.Where("user_id = ?", userID).Delete(&entity.RecoveryCode{}).Error
+}
\ No newline at end of file
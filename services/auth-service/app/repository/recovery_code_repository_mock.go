@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type RecoveryCodeRepositoryMock struct {
+    mock.Mock
+}
+
+func (m *RecoveryCodeRepositoryMock) FindUnusedForUpdate(tx *gorm.DB, code *entity.RecoveryCode, userID int, codeHash string) error {
+    args := m.Called(tx, code, userID, codeHash)
+    return args.Error(0)
+}
+
+func (m *RecoveryCodeRepositoryMock) CountUnusedByUserID(tx *gorm.DB, count *int64, userID int) error {
+    args := m.Called(tx, count, userID)
+    return args.Error(0)
+}
+
+func (m *RecoveryCodeRepositoryMock) CreateAll(tx *gorm.DB, codes *[]entity.RecoveryCode) error {
+    args := m.Called(tx, codes)
+    return args.Error(0)
+}
+
+func (m *RecoveryCodeRepositoryMock) Update(tx *gorm.DB, code *entity.RecoveryCode) error {
+    args := m.Called(tx, code)
+    return args.Error(0)
+}
+
+func (m *RecoveryCodeRepositoryMock) DeleteAllByUserID(tx *gorm.DB, userID int) error {
+    args := m.Called(tx, userID)
+    return args.Error(0)
+}
\ No newline at end of file
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestRecoveryCodeRepositoryImpl_FindUnusedForUpdate(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewRecoveryCodeRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "recovery_codes" WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL ORDER BY "recovery_codes"."id" LIMIT $3 FOR UPDATE`)).
+        WithArgs(1, "hash", 1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "code_hash"}).AddRow(1, 1, "hash"))
+
+    var code entity.RecoveryCode
+    err := repo.FindUnusedForUpdate(db, &code, 1, "hash")
+
+    assert.NoError(t, err)
+    assert.Equal(t, uint(1), code.ID)
+}
+
+func TestRecoveryCodeRepositoryImpl_FindUnusedForUpdate_NotFound(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewRecoveryCodeRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "recovery_codes" WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL ORDER BY "recovery_codes"."id" LIMIT $3 FOR UPDATE`)).
+        WithArgs(1, "hash", 1).
+        WillReturnError(gorm.ErrRecordNotFound)
+
+    var code entity.RecoveryCode
+    err := repo.FindUnusedForUpdate(db, &code, 1, "hash")
+
+    assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestRecoveryCodeRepositoryImpl_CountUnusedByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewRecoveryCodeRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "recovery_codes" WHERE user_id = $1 AND used_at IS NULL`)).
+        WithArgs(1).
+        WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(8))
+
+    var count int64
+    err := repo.CountUnusedByUserID(db, &count, 1)
+
+    assert.NoError(t, err)
+    assert.Equal(t, int64(8), count)
+}
+
+func TestRecoveryCodeRepositoryImpl_CreateAll(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewRecoveryCodeRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "recovery_codes" ("user_id","code_hash","used_at","created_at") VALUES ($1,$2,$3,$4),($5,$6,$7,$8) RETURNING "id"`)).
+        WithArgs(1, "hash-1", nil, sqlmock.AnyArg(), 1, "hash-2", nil, sqlmock.AnyArg()).
+        WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+
+    codes := []entity.RecoveryCode{
+        {UserID: 1, CodeHash: "hash-1"},
+        {UserID: 1, CodeHash: "hash-2"},
+    }
+    err := repo.CreateAll(db, &codes)
+
+    assert.NoError(t, err)
+    assert.Equal(t, uint(2), codes[1].ID)
+}
+
+func TestRecoveryCodeRepositoryImpl_DeleteAllByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewRecoveryCodeRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "recovery_codes" WHERE user_id = $1`)).
+        WithArgs(1).
+        WillReturnResult(sqlmock.NewResult(0, 10))
+
+    err := repo.DeleteAllByUserID(db, 1)
+
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
\ No newline at end of file
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RefreshTokenRepository interface {
+    FindByTokenHashForUpdate(tx *gorm.DB, token *entity.RefreshToken, tokenHash string) error
+    Create(tx *gorm.DB, token *entity.RefreshToken) error
+    Update(tx *gorm.DB, token *entity.RefreshToken) error
+    RevokeFamily(tx *gorm.DB, familyID string) error
+    RevokeAllByUserID(tx *gorm.DB, userID int) error
+    DeleteAllByUserID(tx *gorm.DB, userID int) error
+}
+
+type RefreshTokenRepositoryImpl struct {}
+
+func NewRefreshTokenRepositoryImpl() *RefreshTokenRepositoryImpl {
+    return &RefreshTokenRepositoryImpl{}
+}
+
+// FindByTokenHashForUpdate locks the token so concurrent refreshes of the same token are serialized
+func (r *RefreshTokenRepositoryImpl) FindByTokenHashForUpdate(tx *gorm.DB, token *entity.RefreshToken, tokenHash string) error {
+    return tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("token_hash = ?", tokenHash).First(token).Error
+}
+
+func (r *RefreshTokenRepositoryImpl) Create(tx *gorm.DB, token *entity.RefreshToken) error {
+    return tx.Create(token).Error
+}
+
+func (r *RefreshTokenRepositoryImpl) Update(tx *gorm.DB, token *entity.RefreshToken) error {
+    return tx.Save(token).Error
+}
+
+func (r *RefreshTokenRepositoryImpl) RevokeFamily(tx *gorm.DB, familyID string) error {
+    return tx.Model(&entity.RefreshToken{}).
+        Where("family_id = ? AND revoked_at IS NULL", familyID).
+        Update("revoked_at", time.Now()).Error
+}
+
+func (r *RefreshTokenRepositoryImpl) RevokeAllByUserID(tx *gorm.DB, userID int) error {
+    return tx.Model(&entity.RefreshToken{}).
+        Where("user_id = ? AND revoked_at IS NULL", userID).
+        Update("revoked_at", time.Now()).Error
+}
+
+func (r *RefreshTokenRepositoryImpl) DeleteAllByUserID(tx *gorm.DB, userID int) error {
+    return tx.Where("user_id = ?", userID).Delete(&entity.RefreshToken{}).Error
+}
\ No newline at end of file
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type RefreshTokenRepositoryMock struct {
+    mock.Mock
+}
+
+func (m *RefreshTokenRepositoryMock) FindByTokenHashForUpdate(tx *gorm.DB, token *entity.RefreshToken, tokenHash string) error {
+    args := m.Called(tx, token, tokenHash)
+    return args.Error(0)
+}
+
+func (m *RefreshTokenRepositoryMock) Create(tx *gorm.DB, token *entity.RefreshToken) error {
+    args := m.Called(tx, token)
+    return args.Error(0)
+}
+
+func (m *RefreshTokenRepositoryMock) Update(tx *gorm.DB, token *entity.RefreshToken) error {
+    args := m.Called(tx, token)
+    return args.Error(0)
+}
+
+func (m *RefreshTokenRepositoryMock) RevokeFamily(tx *gorm.DB, familyID string) error {
+    args := m.Called(tx, familyID)
+    return args.Error(0)
+}
+
+func (m *RefreshTokenRepositoryMock) RevokeAllByUserID(tx *gorm.DB, userID int) error {
+    args := m.Called(tx, userID)
+    return args.Error(0)
+}
+
+func (m *RefreshTokenRepositoryMock) DeleteAllByUserID(tx *gorm.DB, userID int) error {
+    args := m.Called(tx, userID)
+    return args.Error(0)
+}
\ No newline at end of file
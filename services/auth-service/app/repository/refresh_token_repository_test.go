@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestRefreshTokenRepositoryImpl_FindByTokenHashForUpdate(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewRefreshTokenRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "refresh_tokens" WHERE token_hash = $1 ORDER BY "refresh_tokens"."id" LIMIT $2 FOR UPDATE`)).
+        WithArgs("hash", 1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "family_id", "token_hash", "expires_at"}).
+            AddRow(1, 1, "family", "hash", time.Now().Add(time.Hour)))
+
+    var token entity.RefreshToken
+    err := repo.FindByTokenHashForUpdate(db, &token, "hash")
+
+    assert.NoError(t, err)
+    assert.Equal(t, "family", token.FamilyID)
+}
+
+func TestRefreshTokenRepositoryImpl_FindByTokenHashForUpdate_NotFound(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewRefreshTokenRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "refresh_tokens" WHERE token_hash = $1 ORDER BY "refresh_tokens"."id" LIMIT $2 FOR UPDATE`)).
+        WithArgs("hash", 1).
+        WillReturnError(gorm.ErrRecordNotFound)
+
+    var token entity.RefreshToken
+    err := repo.FindByTokenHashForUpdate(db, &token, "hash")
+
+    assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestRefreshTokenRepositoryImpl_Create(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewRefreshTokenRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "refresh_tokens" ("user_id","family_id","token_hash","expires_at","used_at","revoked_at","created_at","updated_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING "id"`)).
+        WithArgs(1, "family", "hash", sqlmock.AnyArg(), nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+        WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+    token := &entity.RefreshToken{
+        UserID:    1,
+        FamilyID:  "family",
+        TokenHash: "hash",
+        ExpiresAt: time.Now().Add(time.Hour),
+    }
+    err := repo.Create(db, token)
+
+    assert.NoError(t, err)
+    assert.Equal(t, uint(1), token.ID)
+}
+
+func TestRefreshTokenRepositoryImpl_RevokeFamily(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewRefreshTokenRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`UPDATE "refresh_tokens" SET "revoked_at"=$1,"updated_at"=$2 WHERE family_id = $3 AND revoked_at IS NULL`)).
+        WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "family").
+        WillReturnResult(sqlmock.NewResult(0, 2))
+
+    err := repo.RevokeFamily(db, "family")
+
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRefreshTokenRepositoryImpl_RevokeAllByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewRefreshTokenRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`UPDATE "refresh_tokens" SET "revoked_at"=$1,"updated_at"=$2 WHERE user_id = $3 AND revoked_at IS NULL`)).
+        WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 1).
+        WillReturnResult(sqlmock.NewResult(0, 3))
+
+    err := repo.RevokeAllByUserID(db, 1)
+
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRefreshTokenRepositoryImpl_DeleteAllByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewRefreshTokenRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "refresh_tokens" WHERE user_id = $1`)).
+        WithArgs(1).
+        WillReturnResult(sqlmock.NewResult(0, 2))
+
+    err := repo.DeleteAllByUserID(db, 1)
+
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
\ No newline at end of file
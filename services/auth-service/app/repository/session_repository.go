@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type SessionRepository interface {
+    FindByID(tx *gorm.DB, session *entity.Session, id int) error
+    FindByFamilyID(tx *gorm.DB, session *entity.Session, familyID string) error
+    FindAllActiveByUserID(tx *gorm.DB, sessions *[]entity.Session, userID int) error
+    Create(tx *gorm.DB, session *entity.Session) error
+    Update(tx *gorm.DB, session *entity.Session) error
+    Touch(tx *gorm.DB, id int, lastSeenAt time.Time) error
+    RevokeAllByUserID(tx *gorm.DB, userID int) error
+    FindAllRevokedSince(tx *gorm.DB, sessions *[]entity.Session, since time.Time, limit int) error
+    FindAllByUserID(tx *gorm.DB, sessions *[]entity.Session, userID int) error
+    DeleteAllByUserID(tx *gorm.DB, userID int) error
+}
+
+type SessionRepositoryImpl struct {}
+
+func NewSessionRepositoryImpl() *SessionRepositoryImpl {
+    return &SessionRepositoryImpl{}
+}
+
+func (r *SessionRepositoryImpl) FindByID(tx *gorm.DB, session *entity.Session, id int) error {
+    return tx.Where("id = ?", id).First(session).Error
+}
+
+func (r *SessionRepositoryImpl) FindByFamilyID(tx *gorm.DB, session *entity.Session, familyID string) error {
+    return tx.Where("family_id = ?", familyID).First(session).Error
+}
+
+func (r *SessionRepositoryImpl) FindAllActiveByUserID(tx *gorm.DB, sessions *[]entity.Session, userID int) error {
+    return tx.Where("user_id = ? AND revoked_at IS NULL", userID).Order("last_seen_at DESC").Find(sessions).Error
+}
+
+func (r *SessionRepositoryImpl) Create(tx *gorm.DB, session *entity.Session) error {
+    return tx.Create(session).Error
+}
+
+func (r *SessionRepositoryImpl) Update(tx *gorm.DB, session *entity.Session) error {
+    return tx.Save(session).Error
+}
+
+// Touch records activity without rewriting the rest of the session
+func (r *SessionRepositoryImpl) Touch(tx *gorm.DB, id int, lastSeenAt time.Time) error {
+    return tx.Model(&entity.Session{}).Where("id = ?", id).Update("last_seen_at", lastSeenAt).Error
+}
+
+func (r *SessionRepositoryImpl) RevokeAllByUserID(tx *gorm.DB, userID int) error {
+    return tx.Model(&entity.Session{}).
+        Where("user_id = ? AND revoked_at IS NULL", userID).
+        Update("revoked_at", time.Now()).Error
+}
+
+// FindAllRevokedSince lists the sessions revoked at or after since, oldest first
+func (r *SessionRepositoryImpl) FindAllRevokedSince(tx *gorm.DB, sessions *[]entity.Session, since time.Time, limit int) error {
+    return tx.Where("revoked_at >= ?", since).Order("revoked_at ASC").Limit(limit).Find(sessions).Error
+}
+
+// FindAllByUserID lists the active and revoked sessions of the user, newest first
+func (r *SessionRepositoryImpl) FindAllByUserID(tx *gorm.DB, sessions *[]entity.Session, userID int) error {
+    return tx.Where("user_id = ?", userID).Order("created_at DESC").Find(sessions).Error
+}
+
+func (r *SessionRepositoryImpl) DeleteAllByUserID(tx *gorm.DB, userID int) error {
+    return tx.Where("user_id = ?", userID).Delete(&entity.Session{}).Error
+}
\ No newline at end of file
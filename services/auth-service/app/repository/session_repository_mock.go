@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type SessionRepositoryMock struct {
+    mock.Mock
+}
+
+func (m *SessionRepositoryMock) FindByID(tx *gorm.DB, session *entity.Session, id int) error {
+    args := m.Called(tx, session, id)
+    return args.Error(0)
+}
+
+func (m *SessionRepositoryMock) FindByFamilyID(tx *gorm.DB, session *entity.Session, familyID string) error {
+    args := m.Called(tx, session, familyID)
+    return args.Error(0)
+}
+
+func (m *SessionRepositoryMock) FindAllActiveByUserID(tx *gorm.DB, sessions *[]entity.Session, userID int) error {
+    args := m.Called(tx, sessions, userID)
+    return args.Error(0)
+}
+
+func (m *SessionRepositoryMock) Create(tx *gorm.DB, session *entity.Session) error {
+    args := m.Called(tx, session)
+    return args.Error(0)
+}
+
+func (m *SessionRepositoryMock) Update(tx *gorm.DB, session *entity.Session) error {
+    args := m.Called(tx, session)
+    return args.Error(0)
+}
+
+func (m *SessionRepositoryMock) Touch(tx *gorm.DB, id int, lastSeenAt time.Time) error {
+    args := m.Called(tx, id, lastSeenAt)
+    return args.Error(0)
+}
+
+func (m *SessionRepositoryMock) RevokeAllByUserID(tx *gorm.DB, userID int) error {
+    args := m.Called(tx, userID)
+    return args.Error(0)
+}
+
+func (m *SessionRepositoryMock) FindAllRevokedSince(tx *gorm.DB, sessions *[]entity.Session, since time.Time, limit int) error {
+    args := m.Called(tx, sessions, since, limit)
+    return args.Error(0)
+}
+
+func (m *SessionRepositoryMock) FindAllByUserID(tx *gorm.DB, sessions *[]entity.Session, userID int) error {
+    args := m.Called(tx, sessions, userID)
+    return args.Error(0)
+}
+
+func (m *SessionRepositoryMock) DeleteAllByUserID(tx *gorm.DB, userID int) error {
+    args := m.Called(tx, userID)
+    return args.Error(0)
+}
\ No newline at end of file
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestSessionRepositoryImpl_FindByID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewSessionRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "sessions" WHERE id = $1 ORDER BY "sessions"."id" LIMIT $2`)).
+        WithArgs(1, 1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "family_id", "user_agent", "ip_address"}).
+            AddRow(1, 1, "family", "Mozilla/5.0", "127.0.0.1"))
+
+    var session entity.Session
+    err := repo.FindByID(db, &session, 1)
+
+    assert.NoError(t, err)
+    assert.Equal(t, "family", session.FamilyID)
+    assert.Equal(t, "127.0.0.1", session.IPAddress)
+}
+
+func TestSessionRepositoryImpl_FindByFamilyID_NotFound(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewSessionRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "sessions" WHERE family_id = $1 ORDER BY "sessions"."id" LIMIT $2`)).
+        WithArgs("family", 1).
+        WillReturnError(gorm.ErrRecordNotFound)
+
+    var session entity.Session
+    err := repo.FindByFamilyID(db, &session, "family")
+
+    assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestSessionRepositoryImpl_FindAllActiveByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewSessionRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "sessions" WHERE user_id = $1 AND revoked_at IS NULL ORDER BY last_seen_at DESC`)).
+        WithArgs(1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "family_id"}).
+            AddRow(2, 1, "family-2").
+            AddRow(1, 1, "family-1"))
+
+    var sessions []entity.Session
+    err := repo.FindAllActiveByUserID(db, &sessions, 1)
+
+    assert.NoError(t, err)
+    assert.Len(t, sessions, 2)
+}
+
+func TestSessionRepositoryImpl_Create(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewSessionRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "sessions" ("user_id","family_id","user_agent","ip_address","organization_id","last_seen_at","revoked_at","created_at","updated_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING "id"`)).
+        WithArgs(1, "family", "Mozilla/5.0", "127.0.0.1", nil, sqlmock.AnyArg(), nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+        WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+    session := &entity.Session{
+        UserID:     1,
+        FamilyID:   "family",
+        UserAgent:  "Mozilla/5.0",
+        IPAddress:  "127.0.0.1",
+        LastSeenAt: time.Now(),
+    }
+    err := repo.Create(db, session)
+
+    assert.NoError(t, err)
+    assert.Equal(t, uint(1), session.ID)
+}
+
+func TestSessionRepositoryImpl_Touch(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewSessionRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`UPDATE "sessions" SET "last_seen_at"=$1,"updated_at"=$2 WHERE id = $3`)).
+        WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 1).
+        WillReturnResult(sqlmock.NewResult(0, 1))
+
+    err := repo.Touch(db, 1, time.Now())
+
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSessionRepositoryImpl_RevokeAllByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewSessionRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`UPDATE "sessions" SET "revoked_at"=$1,"updated_at"=$2 WHERE user_id = $3 AND revoked_at IS NULL`)).
+        WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 1).
+        WillReturnResult(sqlmock.NewResult(0, 2))
+
+    err := repo.RevokeAllByUserID(db, 1)
+
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSessionRepositoryImpl_FindAllRevokedSince(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewSessionRepositoryImpl()
+    since := time.Now().Add(-time.Hour)
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "sessions" WHERE revoked_at >= $1 ORDER BY revoked_at ASC LIMIT $2`)).
+        WithArgs(since, 100).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "family_id", "revoked_at"}).
+            AddRow(1, 1, "family-1", since.Add(time.Minute)).
+            AddRow(2, 1, "family-2", since.Add(2 * time.Minute)))
+
+    var sessions []entity.Session
+    err := repo.FindAllRevokedSince(db, &sessions, since, 100)
+
+    assert.NoError(t, err)
+    assert.Len(t, sessions, 2)
+    assert.NotNil(t, sessions[1].RevokedAt)
+}
+
+func TestSessionRepositoryImpl_FindAllByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewSessionRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "sessions" WHERE user_id = $1 ORDER BY created_at DESC`)).
+        WithArgs(1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "family_id", "revoked_at"}).
+            AddRow(2, 1, "current", nil).
+            AddRow(1, 1, "revoked", time.Now()))
+
+    var sessions []entity.Session
+    err := repo.FindAllByUserID(db, &sessions, 1)
+
+    assert.NoError(t, err)
+    assert.Len(t, sessions, 2)
+    assert.NotNil(t, sessions[1].RevokedAt)
+}
+
+func TestSessionRepositoryImpl_DeleteAllByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewSessionRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "sessions" WHERE user_id = $1`)).
+        WithArgs(1).
+        WillReturnResult(sqlmock.NewResult(0, 2))
+
+    err := repo.DeleteAllByUserID(db, 1)
+
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
\ No newline at end of file
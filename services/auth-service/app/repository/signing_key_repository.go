@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type SigningKeyRepository interface {
+    FindAllUnexpired(tx *gorm.DB, keys *[]entity.SigningKey, now time.Time) error
+    Create(tx *gorm.DB, key *entity.SigningKey) error
+    RetireAllActive(tx *gorm.DB, now time.Time, expiresAt time.Time) error
+    TryAdvisoryLock(tx *gorm.DB, key int64) (bool, error)
+}
+
+type SigningKeyRepositoryImpl struct {}
+
+func NewSigningKeyRepositoryImpl() *SigningKeyRepositoryImpl {
+    return &SigningKeyRepositoryImpl{}
+}
+
+// FindAllUnexpired returns the keys that may still verify tokens, newest first
+func (r *SigningKeyRepositoryImpl) FindAllUnexpired(tx *gorm.DB, keys *[]entity.SigningKey, now time.Time) error {
+    return tx.Where("expires_at IS NULL OR expires_at > ?", now).Order("created_at DESC").Find(keys).Error
+}
+
+func (r *SigningKeyRepositoryImpl) Create(tx *gorm.DB, key *entity.SigningKey) error {
+    return tx.Create(key).Error
+}
+
+// RetireAllActive stops every active key from signing, they keep verifying until expiresAt
+func (r *SigningKeyRepositoryImpl) RetireAllActive(tx *gorm.DB, now time.Time, expiresAt time.Time) error {
+    return tx.Model(&entity.SigningKey{}).
+        Where("retired_at IS NULL").
+        Updates(map[string]interface{}{"retired_at": now, "expires_at": expiresAt}).Error
+}
+
+// TryAdvisoryLock takes a transaction scoped advisory lock, released on commit or rollback
+func (r *SigningKeyRepositoryImpl) TryAdvisoryLock(tx *gorm.DB, key int64) (bool, error) {
+    var locked bool
+    err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", key).Scan(&locked).Error
+    return locked, err
+}
\ No newline at end of file
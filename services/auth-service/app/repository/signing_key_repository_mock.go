@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type SigningKeyRepositoryMock struct {
+    mock.Mock
+}
+
+func (m *SigningKeyRepositoryMock) FindAllUnexpired(tx *gorm.DB, keys *[]entity.SigningKey, now time.Time) error {
+    args := m.Called(tx, keys, now)
+    return args.Error(0)
+}
+
+func (m *SigningKeyRepositoryMock) Create(tx *gorm.DB, key *entity.SigningKey) error {
+    args := m.Called(tx, key)
+    return args.Error(0)
+}
+
+func (m *SigningKeyRepositoryMock) RetireAllActive(tx *gorm.DB, now time.Time, expiresAt time.Time) error {
+    args := m.Called(tx, now, expiresAt)
+    return args.Error(0)
+}
+
+func (m *SigningKeyRepositoryMock) TryAdvisoryLock(tx *gorm.DB, key int64) (bool, error) {
+    args := m.Called(tx, key)
+    return args.Bool(0), args.Error(1)
+}
\ No newline at end of file
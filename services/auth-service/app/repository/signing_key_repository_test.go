@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSigningKeyRepositoryImpl_FindAllUnexpired(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewSigningKeyRepositoryImpl()
+
+    now := time.Now()
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "signing_keys" WHERE expires_at IS NULL OR expires_at > $1 ORDER BY created_at DESC`)).
+        WithArgs(now).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "kid", "algorithm", "private_key", "created_at"}).
+            AddRow(2, "new", "EdDSA", "pem", now).
+            AddRow(1, "old", "RS256", "pem", now.Add(-time.Hour)))
+
+    var keys []entity.SigningKey
+    err := repo.FindAllUnexpired(db, &keys, now)
+
+    assert.NoError(t, err)
+    assert.Len(t, keys, 2)
+    assert.Equal(t, "new", keys[0].Kid)
+}
+
+func TestSigningKeyRepositoryImpl_Create(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewSigningKeyRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "signing_keys" ("kid","algorithm","private_key","retired_at","expires_at","created_at","updated_at") VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING "id"`)).
+        WithArgs("kid", "EdDSA", "pem", nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+        WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+    key := &entity.SigningKey{
+        Kid:        "kid",
+        Algorithm:  "EdDSA",
+        PrivateKey: "pem",
+    }
+    err := repo.Create(db, key)
+
+    assert.NoError(t, err)
+    assert.Equal(t, uint(1), key.ID)
+}
+
+func TestSigningKeyRepositoryImpl_RetireAllActive(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewSigningKeyRepositoryImpl()
+
+    now := time.Now()
+    expiresAt := now.Add(time.Hour)
+    mock.ExpectExec(regexp.QuoteMeta(`UPDATE "signing_keys" SET "expires_at"=$1,"retired_at"=$2,"updated_at"=$3 WHERE retired_at IS NULL`)).
+        WithArgs(expiresAt, now, sqlmock.AnyArg()).
+        WillReturnResult(sqlmock.NewResult(0, 1))
+
+    err := repo.RetireAllActive(db, now, expiresAt)
+
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSigningKeyRepositoryImpl_TryAdvisoryLock(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewSigningKeyRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT pg_try_advisory_xact_lock($1)`)).
+        WithArgs(int64(42)).
+        WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(true))
+
+    locked, err := repo.TryAdvisoryLock(db, 42)
+
+    assert.NoError(t, err)
+    assert.True(t, locked)
+}
\ No newline at end of file
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TotpCredentialRepository interface {
+    FindByUserID(tx *gorm.DB, credential *entity.TotpCredential, userID int) error
+    FindByUserIDForUpdate(tx *gorm.DB, credential *entity.TotpCredential, userID int) error
+    Create(tx *gorm.DB, credential *entity.TotpCredential) error
+    Update(tx *gorm.DB, credential *entity.TotpCredential) error
+    DeleteByUserID(tx *gorm.DB, userID int) error
+}
+
+type TotpCredentialRepositoryImpl struct {}
+
+func NewTotpCredentialRepositoryImpl() *TotpCredentialRepositoryImpl {
+    return &TotpCredentialRepositoryImpl{}
+}
+
+func (r *TotpCredentialRepositoryImpl) FindByUserID(tx *gorm.DB, credential *entity.TotpCredential, userID int) error {
+    return tx.Where("user_id = ?", userID).First(credential).Error
+}
+
+// FindByUserIDForUpdate serializes code checks so a code and the attempt counter are used once
+func (r *TotpCredentialRepositoryImpl) FindByUserIDForUpdate(tx *gorm.DB, credential *entity.TotpCredential, userID int) error {
+    return tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("user_id = ?", userID).First(credential).Error
+}
+
+func (r *TotpCredentialRepositoryImpl) Create(tx *gorm.DB, credential *entity.TotpCredential) error {
+    return tx.Create(credential).Error
+}
+
+func (r *TotpCredentialRepositoryImpl) Update(tx *gorm.DB, credential *entity.TotpCredential) error {
+    return tx.Save(credential).Error
+}
+
+func (r *TotpCredentialRepositoryImpl) DeleteByUserID(tx *gorm.DB, userID int) error {
+    return tx.Where("user_id = ?", userID).Delete(&entity.TotpCredential{}).Error
+}
\ No newline at end of file
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type TotpCredentialRepositoryMock struct {
+    mock.Mock
+}
+
+func (m *TotpCredentialRepositoryMock) FindByUserID(tx *gorm.DB, credential *entity.TotpCredential, userID int) error {
+    args := m.Called(tx, credential, userID)
+    return args.Error(0)
+}
+
+func (m *TotpCredentialRepositoryMock) FindByUserIDForUpdate(tx *gorm.DB, credential *entity.TotpCredential, userID int) error {
+    args := m.Called(tx, credential, userID)
+    return args.Error(0)
+}
+
+func (m *TotpCredentialRepositoryMock) Create(tx *gorm.DB, credential *entity.TotpCredential) error {
+    args := m.Called(tx, credential)
+    return args.Error(0)
+}
+
+func (m *TotpCredentialRepositoryMock) Update(tx *gorm.DB, credential *entity.TotpCredential) error {
+    args := m.Called(tx, credential)
+    return args.Error(0)
+}
+
+func (m *TotpCredentialRepositoryMock) DeleteByUserID(tx *gorm.DB, userID int) error {
+    args := m.Called(tx, userID)
+    return args.Error(0)
+}
\ No newline at end of file
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestTotpCredentialRepositoryImpl_FindByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewTotpCredentialRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "totp_credentials" WHERE user_id = $1 ORDER BY "totp_credentials"."id" LIMIT $2`)).
+        WithArgs(1, 1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "secret", "last_used_step"}).
+            AddRow(1, 1, "sealed", 100))
+
+    var credential entity.TotpCredential
+    err := repo.FindByUserID(db, &credential, 1)
+
+    assert.NoError(t, err)
+    assert.Equal(t, int64(100), credential.LastUsedStep)
+}
+
+func TestTotpCredentialRepositoryImpl_FindByUserIDForUpdate_NotFound(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewTotpCredentialRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "totp_credentials" WHERE user_id = $1 ORDER BY "totp_credentials"."id" LIMIT $2 FOR UPDATE`)).
+        WithArgs(1, 1).
+        WillReturnError(gorm.ErrRecordNotFound)
+
+    var credential entity.TotpCredential
+    err := repo.FindByUserIDForUpdate(db, &credential, 1)
+
+    assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestTotpCredentialRepositoryImpl_Create(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewTotpCredentialRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "totp_credentials" ("user_id","secret","confirmed_at","last_used_step","failed_attempts","locked_until","created_at","updated_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING "id"`)).
+        WithArgs(1, "sealed", nil, 0, 0, nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+        WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+    credential := &entity.TotpCredential{
+        UserID: 1,
+        Secret: "sealed",
+    }
+    err := repo.Create(db, credential)
+
+    assert.NoError(t, err)
+    assert.Equal(t, uint(1), credential.ID)
+}
+
+func TestTotpCredentialRepositoryImpl_DeleteByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewTotpCredentialRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "totp_credentials" WHERE user_id = $1`)).
+        WithArgs(1).
+        WillReturnResult(sqlmock.NewResult(0, 1))
+
+    err := repo.DeleteByUserID(db, 1)
+
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
\ No newline at end of file
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+
+	"gorm.io/gorm"
+)
+
+type UserIdentityRepository interface {
+    FindByProviderAndSubject(tx *gorm.DB, identity *entity.UserIdentity, provider string, subject string) error
+    FindByUserIDAndProvider(tx *gorm.DB, identity *entity.UserIdentity, userID int, provider string) error
+    FindAllByUserID(tx *gorm.DB, identities *[]entity.UserIdentity, userID int) error
+    Create(tx *gorm.DB, identity *entity.UserIdentity) error
+    Delete(tx *gorm.DB, identity *entity.UserIdentity) error
+    DeleteAllByUserID(tx *gorm.DB, userID int) error
+}
+
+type UserIdentityRepositoryImpl struct {}
+
+func NewUserIdentityRepositoryImpl() *UserIdentityRepositoryImpl {
+    return &UserIdentityRepositoryImpl{}
+}
+
+func (r *UserIdentityRepositoryImpl) FindByProviderAndSubject(tx *gorm.DB, identity *entity.UserIdentity, provider string, subject string) error {
+    return tx.Where("provider = ? AND subject = ?", provider, subject).First(identity).Error
+}
+
+func (r *UserIdentityRepositoryImpl) FindByUserIDAndProvider(tx *gorm.DB, identity *entity.UserIdentity, userID int, provider string) error {
+    return tx.Where("user_id = ? AND provider = ?", userID, provider).First(identity).Error
+}
+
+func (r *UserIdentityRepositoryImpl) FindAllByUserID(tx *gorm.DB, identities *[]entity.UserIdentity, userID int) error {
+    return tx.Where("user_id = ?", userID).Order("created_at ASC").Find(identities).Error
+}
+
+func (r *UserIdentityRepositoryImpl) Create(tx *gorm.DB, identity *entity.UserIdentity) error {
+    return tx.Create(identity).Error
+}
+
+func (r *UserIdentityRepositoryImpl) Delete(tx *gorm.DB, identity *entity.UserIdentity) error {
+    return tx.Delete(identity).Error
+}
+
+func (r *UserIdentityRepositoryImpl) DeleteAllByUserID(tx *gorm.DB, userID int) error {
+    return tx.Where("user_id = ?", userID).Delete(&entity.UserIdentity{}).Error
+}
\ No newline at end of file
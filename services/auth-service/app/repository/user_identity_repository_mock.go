@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type UserIdentityRepositoryMock struct {
+    mock.Mock
+}
+
+func (m *UserIdentityRepositoryMock) FindByProviderAndSubject(tx *gorm.DB, identity *entity.UserIdentity, provider string, subject string) error {
+    args := m.Called(tx, identity, provider, subject)
+    return args.Error(0)
+}
+
+func (m *UserIdentityRepositoryMock) FindByUserIDAndProvider(tx *gorm.DB, identity *entity.UserIdentity, userID int, provider string) error {
+    args := m.Called(tx, identity, userID, provider)
+    return args.Error(0)
+}
+
+func (m *UserIdentityRepositoryMock) FindAllByUserID(tx *gorm.DB, identities *[]entity.UserIdentity, userID int) error {
+    args := m.Called(tx, identities, userID)
+    return args.Error(0)
+}
+
+func (m *UserIdentityRepositoryMock) Create(tx *gorm.DB, identity *entity.UserIdentity) error {
+    args := m.Called(tx, identity)
+    return args.Error(0)
+}
+
+func (m *UserIdentityRepositoryMock) Delete(tx *gorm.DB, identity *entity.UserIdentity) error {
+    args := m.Called(tx, identity)
+    return args.Error(0)
+}
+
+func (m *UserIdentityRepositoryMock) DeleteAllByUserID(tx *gorm.DB, userID int) error {
+    args := m.Called(tx, userID)
+    return args.Error(0)
+}
\ No newline at end of file
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestUserIdentityRepositoryImpl_FindByProviderAndSubject(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewUserIdentityRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_identities" WHERE provider = $1 AND subject = $2 ORDER BY "user_identities"."id" LIMIT $3`)).
+        WithArgs("google", "subject", 1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "provider", "subject", "email"}).
+            AddRow(1, 2, "google", "subject", "john@example.com"))
+
+    var identity entity.UserIdentity
+    err := repo.FindByProviderAndSubject(db, &identity, "google", "subject")
+
+    assert.NoError(t, err)
+    assert.Equal(t, uint(2), identity.UserID)
+}
+
+func TestUserIdentityRepositoryImpl_FindByUserIDAndProvider_NotFound(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewUserIdentityRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_identities" WHERE user_id = $1 AND provider = $2 ORDER BY "user_identities"."id" LIMIT $3`)).
+        WithArgs(1, "keycloak", 1).
+        WillReturnError(gorm.ErrRecordNotFound)
+
+    var identity entity.UserIdentity
+    err := repo.FindByUserIDAndProvider(db, &identity, 1, "keycloak")
+
+    assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestUserIdentityRepositoryImpl_FindAllByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewUserIdentityRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_identities" WHERE user_id = $1 ORDER BY created_at ASC`)).
+        WithArgs(1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "provider", "subject"}).
+            AddRow(1, 1, "google", "google-subject").
+            AddRow(2, 1, "keycloak", "keycloak-subject"))
+
+    var identities []entity.UserIdentity
+    err := repo.FindAllByUserID(db, &identities, 1)
+
+    assert.NoError(t, err)
+    assert.Len(t, identities, 2)
+}
+
+func TestUserIdentityRepositoryImpl_Create(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewUserIdentityRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "user_identities" ("user_id","provider","subject","email","created_at","updated_at") VALUES ($1,$2,$3,$4,$5,$6) RETURNING "id"`)).
+        WithArgs(1, "google", "subject", "john@example.com", sqlmock.AnyArg(), sqlmock.AnyArg()).
+        WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+    identity := &entity.UserIdentity{
+        UserID:   1,
+        Provider: "google",
+        Subject:  "subject",
+        Email:    "john@example.com",
+    }
+    err := repo.Create(db, identity)
+
+    assert.NoError(t, err)
+    assert.Equal(t, uint(1), identity.ID)
+}
+
+func TestUserIdentityRepositoryImpl_Delete(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewUserIdentityRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "user_identities" WHERE "user_identities"."id" = $1`)).
+        WithArgs(1).
+        WillReturnResult(sqlmock.NewResult(0, 1))
+
+    err := repo.Delete(db, &entity.UserIdentity{ID: 1})
+
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserIdentityRepositoryImpl_DeleteAllByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewUserIdentityRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "user_identities" WHERE user_id = $1`)).
+        WithArgs(1).
+        WillReturnResult(sqlmock.NewResult(0, 2))
+
+    err := repo.DeleteAllByUserID(db, 1)
+
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
\ No newline at end of file
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type VerificationTokenRepository interface {
+    FindByTokenHashForUpdate(tx *gorm.DB, token *entity.VerificationToken, purpose string, tokenHash string) error
+    Create(tx *gorm.DB, token *entity.VerificationToken) error
+    Update(tx *gorm.DB, token *entity.VerificationToken) error
+    ConsumeAllByUserID(tx *gorm.DB, userID int, purpose string) error
+    DeleteAllByUserID(tx *gorm.DB, userID int) error
+}
+
+type VerificationTokenRepositoryImpl struct {}
+
+func NewVerificationTokenRepositoryImpl() *VerificationTokenRepositoryImpl {
+    return &VerificationTokenRepositoryImpl{}
+}
+
+// FindByTokenHashForUpdate locks the token so it can only be redeemed once
+func (r *VerificationTokenRepositoryImpl) FindByTokenHashForUpdate(tx *gorm.DB, token *entity.VerificationToken, purpose string, tokenHash string) error {
+    return tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+        Where("purpose = ? AND token_hash = ?", purpose, tokenHash).
+        First(token).Error
+}
+
+func (r *VerificationTokenRepositoryImpl) Create(tx *gorm.DB, token *entity.VerificationToken) error {
+    return tx.Create(token).Error
+}
+
+func (r *VerificationTokenRepositoryImpl) Update(tx *gorm.DB, token *entity.VerificationToken) error {
+    return tx.Save(token).Error
+}
+
+// ConsumeAllByUserID invalidates the outstanding tokens so only the newest one works
+func (r *VerificationTokenRepositoryImpl) ConsumeAllByUserID(tx *gorm.DB, userID int, purpose string) error {
+    return tx.Model(&entity.VerificationToken{}).
+        Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+        Update("used_at", time.Now()).Error
+}
+
+func (r *VerificationTokenRepositoryImpl) DeleteAllByUserID(tx *gorm.DB, userID int) error {
+    return tx.Where("user_id = ?", userID).Delete(&entity.VerificationToken{}).Error
+}
\ No newline at end of file
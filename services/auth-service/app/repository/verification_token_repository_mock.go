@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type VerificationTokenRepositoryMock struct {
+    mock.Mock
+}
+
+func (m *VerificationTokenRepositoryMock) FindByTokenHashForUpdate(tx *gorm.DB, token *entity.VerificationToken, purpose string, tokenHash string) error {
+    args := m.Called(tx, token, purpose, tokenHash)
+    return args.Error(0)
+}
+
+func (m *VerificationTokenRepositoryMock) Create(tx *gorm.DB, token *entity.VerificationToken) error {
+    args := m.Called(tx, token)
+    return args.Error(0)
+}
+
+func (m *VerificationTokenRepositoryMock) Update(tx *gorm.DB, token *entity.VerificationToken) error {
+    args := m.Called(tx, token)
+    return args.Error(0)
+}
+
+func (m *VerificationTokenRepositoryMock) ConsumeAllByUserID(tx *gorm.DB, userID int, purpose string) error {
+    args := m.Called(tx, userID, purpose)
+    return args.Error(0)
+}
+
+func (m *VerificationTokenRepositoryMock) DeleteAllByUserID(tx *gorm.DB, userID int) error {
+    args := m.Called(tx, userID)
+    return args.Error(0)
+}
\ No newline at end of file
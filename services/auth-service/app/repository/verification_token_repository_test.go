@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestVerificationTokenRepositoryImpl_FindByTokenHashForUpdate(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewVerificationTokenRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "verification_tokens" WHERE purpose = $1 AND token_hash = $2 ORDER BY "verification_tokens"."id" LIMIT $3 FOR UPDATE`)).
+        WithArgs(entity.VerificationPurposeVerifyEmail, "hash", 1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "purpose", "token_hash", "expires_at"}).
+            AddRow(1, 1, entity.VerificationPurposeVerifyEmail, "hash", time.Now().Add(time.Hour)))
+
+    var token entity.VerificationToken
+    err := repo.FindByTokenHashForUpdate(db, &token, entity.VerificationPurposeVerifyEmail, "hash")
+
+    assert.NoError(t, err)
+    assert.Equal(t, uint(1), token.UserID)
+}
+
+func TestVerificationTokenRepositoryImpl_FindByTokenHashForUpdate_NotFound(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewVerificationTokenRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "verification_tokens" WHERE purpose = $1 AND token_hash = $2 ORDER BY "verification_tokens"."id" LIMIT $3 FOR UPDATE`)).
+        WithArgs(entity.VerificationPurposeResetPassword, "hash", 1).
+        WillReturnError(gorm.ErrRecordNotFound)
+
+    var token entity.VerificationToken
+    err := repo.FindByTokenHashForUpdate(db, &token, entity.VerificationPurposeResetPassword, "hash")
+
+    assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestVerificationTokenRepositoryImpl_Create(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewVerificationTokenRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "verification_tokens" ("user_id","purpose","email","token_hash","expires_at","used_at","created_at") VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING "id"`)).
+        WithArgs(1, entity.VerificationPurposeVerifyEmail, nil, "hash", sqlmock.AnyArg(), nil, sqlmock.AnyArg()).
+        WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+    token := &entity.VerificationToken{
+        UserID:    1,
+        Purpose:   entity.VerificationPurposeVerifyEmail,
+        TokenHash: "hash",
+        ExpiresAt: time.Now().Add(time.Hour),
+    }
+    err := repo.Create(db, token)
+
+    assert.NoError(t, err)
+    assert.Equal(t, uint(1), token.ID)
+}
+
+func TestVerificationTokenRepositoryImpl_ConsumeAllByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewVerificationTokenRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`UPDATE "verification_tokens" SET "used_at"=$1 WHERE user_id = $2 AND purpose = $3 AND used_at IS NULL`)).
+        WithArgs(sqlmock.AnyArg(), 1, entity.VerificationPurposeResetPassword).
+        WillReturnResult(sqlmock.NewResult(0, 1))
+
+    err := repo.ConsumeAllByUserID(db, 1, entity.VerificationPurposeResetPassword)
+
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestVerificationTokenRepositoryImpl_DeleteAllByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewVerificationTokenRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "verification_tokens" WHERE user_id = $1`)).
+        WithArgs(1).
+        WillReturnResult(sqlmock.NewResult(0, 2))
+
+    err := repo.DeleteAllByUserID(db, 1)
+
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
\ No newline at end of file
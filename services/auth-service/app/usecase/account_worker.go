@@ -0,0 +1,581 @@
+package usecase
+
+import (
+	"archive/zip"
+	"auth-service/app/delivery/client"
+	"auth-service/app/helper"
+	"auth-service/app/model/entity"
+	"auth-service/app/model/proto"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+// accountDeletionLockKey identifies the advisory lock held by the replica that
+// purges the due deletions, so the steps of a deletion never run twice at once.
+const accountDeletionLockKey int64 = 20261019092
+
+const (
+	// accountExportTimeout bounds building one archive, running exports older
+	// than it are considered abandoned and claimed again
+	accountExportTimeout = 10 * time.Minute
+	accountExportMaxAttempts = 3
+	accountWorkerBatch = 10
+)
+
+// AccountWorker builds the requested exports and purges the deletions whose
+// grace period ended. Every step of a deletion is recorded on its own, a failed
+// step is retried on the next tick and the later steps wait for it.
+type AccountWorker struct {
+	DB          	*gorm.DB
+	AuthUseCase 	*AuthUseCaseImpl
+	Log         	*logrus.Logger
+	Interval    	time.Duration
+	ExportTTL   	time.Duration
+}
+
+func NewAccountWorker(db *gorm.DB, authUseCase *AuthUseCaseImpl, log *logrus.Logger, config *viper.Viper) *AccountWorker {
+	interval := config.GetDuration("ACCOUNT_WORKER_INTERVAL")
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	exportTTL := config.GetDuration("ACCOUNT_EXPORT_TTL")
+	if exportTTL <= 0 {
+		exportTTL = 7 * 24 * time.Hour
+	}
+
+	return &AccountWorker{
+		DB:          db,
+		AuthUseCase: authUseCase,
+		Log:         log,
+		Interval:    interval,
+		ExportTTL:   exportTTL,
+	}
+}
+
+// Start runs the exports and deletions on every tick until the context is done.
+func (w *AccountWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.tick(ctx)
+		}
+	}
+}
+
+func (w *AccountWorker) tick(ctx context.Context) {
+	if err := w.AuthUseCase.AccountExportRepository.ExpireAll(w.DB.WithContext(ctx), time.Now()); err != nil {
+		w.Log.Errorf("Failed to expire account exports: %v", err)
+	}
+
+	if err := w.runExports(ctx); err != nil {
+		w.Log.Errorf("Failed to run account exports: %v", err)
+	}
+
+	if err := w.runDeletions(ctx); err != nil {
+		w.Log.Errorf("Failed to run account deletions: %v", err)
+	}
+}
+
+func (w *AccountWorker) runExports(ctx context.Context) error {
+	exports, err := w.claimExports(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := range exports {
+		w.export(ctx, &exports[i])
+	}
+
+	return nil
+}
+
+// claimExports marks the due exports as running, so the other replicas skip them
+func (w *AccountWorker) claimExports(ctx context.Context) ([]entity.AccountExport, error) {
+	repo := w.AuthUseCase.AccountExportRepository
+
+	tx := w.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	now := time.Now()
+
+	var exports []entity.AccountExport
+	if err := repo.FindAllDueForUpdate(tx, &exports, now.Add(-accountExportTimeout), accountWorkerBatch); err != nil {
+		return nil, err
+	}
+
+	for i := range exports {
+		exports[i].Status = entity.AccountExportRunning
+		exports[i].StartedAt = &now
+		exports[i].Attempts++
+		if err := repo.Update(tx, &exports[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return exports, tx.Commit().Error
+}
+
+func (w *AccountWorker) export(ctx context.Context, export *entity.AccountExport) {
+	repo := w.AuthUseCase.AccountExportRepository
+
+	exportCtx, cancelExport := context.WithTimeout(ctx, accountExportTimeout)
+	defer cancelExport()
+
+	user, archive, err := w.buildArchive(exportCtx, export.UserID)
+	if err != nil {
+		w.Log.Errorf("Failed to build account export %d: %v", export.ID, err)
+
+		message := "Failed to collect the data of the account"
+		export.Error = &message
+		export.StartedAt = nil
+		export.Status = entity.AccountExportPending
+		if export.Attempts >= accountExportMaxAttempts {
+			export.Status = entity.AccountExportFailed
+		}
+		if err := repo.Update(w.DB.WithContext(ctx), export); err != nil {
+			w.Log.Errorf("Failed to update account export %d: %v", export.ID, err)
+			return
+		}
+
+		if export.Status == entity.AccountExportFailed && user != nil {
+			w.notify(ctx, &helper.Mail{
+				To:      user.GetEmail(),
+				Subject: "Your data export failed",
+				Body: fmt.Sprintf(
+					"Hi %s,\n\nWe could not put together the copy of your data you asked for. Please request a new export.\n",
+					user.GetName(),
+				),
+			})
+		}
+		return
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(w.ExportTTL)
+	export.Status = entity.AccountExportReady
+	export.Archive = archive
+	export.Size = int64(len(archive))
+	export.Error = nil
+	export.CompletedAt = &now
+	export.ExpiresAt = &expiresAt
+	if err := repo.Update(w.DB.WithContext(ctx), export); err != nil {
+		w.Log.Errorf("Failed to update account export %d: %v", export.ID, err)
+		return
+	}
+
+	w.notify(ctx, &helper.Mail{
+		To:      user.GetEmail(),
+		Subject: "Your data export is ready",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nThe copy of your data you asked for is ready. Download it from the settings of your account before %s.\n",
+			user.GetName(), expiresAt.UTC().Format("January 2, 2006"),
+		),
+	})
+}
+
+// buildArchive collects the data of every service into a zip archive. The user
+// is returned whenever it could be loaded, so failures can be emailed too.
+func (w *AccountWorker) buildArchive(ctx context.Context, userID uint) (*proto.GetUserResponse, []byte, error) {
+	a := w.AuthUseCase
+	db := w.DB.WithContext(ctx)
+
+	user, err := a.User.Service.GetUserByID(ctx, &proto.GetUserByIDRequest{Id: int64(userID)})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	preferences, err := a.User.Service.GetPreferences(ctx, &proto.GetUserPreferencesRequest{UserId: int64(userID)})
+	if err != nil {
+		return user, nil, err
+	}
+
+	var sessions []entity.Session
+	if err := a.SessionRepository.FindAllByUserID(db, &sessions, int(userID)); err != nil {
+		return user, nil, err
+	}
+
+	var identities []entity.UserIdentity
+	if err := a.UserIdentityRepository.FindAllByUserID(db, &identities, int(userID)); err != nil {
+		return user, nil, err
+	}
+
+	var apiKeys []entity.ApiKey
+	if err := a.ApiKeyRepository.FindAllByUserID(db, &apiKeys, int(userID)); err != nil {
+		return user, nil, err
+	}
+
+	buf := new(bytes.Buffer)
+	archive := zip.NewWriter(buf)
+
+	files := []struct {
+		name string
+		data any
+	}{
+		{"profile.json", toExportProfile(user)},
+		{"preferences.json", toExportPreferences(preferences)},
+		{"auth/sessions.json", toExportSessions(sessions)},
+		{"auth/identities.json", toExportIdentities(identities)},
+		{"auth/api_keys.json", toExportApiKeys(apiKeys)},
+	}
+	for _, file := range files {
+		if err := writeArchiveJSON(archive, file.name, file.data); err != nil {
+			return user, nil, err
+		}
+	}
+
+	stream, err := a.Chat.Service.ExportUserData(client.WithUserID(ctx, int64(userID)), &proto.ExportUserDataRequest{UserId: int64(userID)})
+	if err != nil {
+		return user, nil, err
+	}
+	for {
+		file, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return user, nil, err
+		}
+
+		f, err := archive.Create(path.Join("chat", path.Clean("/" + file.GetPath())))
+		if err != nil {
+			return user, nil, err
+		}
+		if _, err := f.Write(file.GetContent()); err != nil {
+			return user, nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return user, nil, err
+	}
+
+	return user, buf.Bytes(), nil
+}
+
+func (w *AccountWorker) runDeletions(ctx context.Context) error {
+	repo := w.AuthUseCase.AccountDeletionRepository
+
+	// The lock is held on its own transaction while each step commits separately
+	lockTx := w.DB.WithContext(ctx).Begin()
+	defer lockTx.Rollback()
+
+	locked, err := repo.TryAdvisoryLock(lockTx, accountDeletionLockKey)
+	if err != nil {
+		return err
+	}
+	if !locked {
+		return nil
+	}
+
+	var deletions []entity.AccountDeletion
+	if err := repo.FindAllDue(w.DB.WithContext(ctx), &deletions, time.Now(), accountWorkerBatch); err != nil {
+		return err
+	}
+
+	for i := range deletions {
+		if err := w.purge(ctx, &deletions[i]); err != nil {
+			w.Log.Errorf("Failed to purge account deletion %d: %v", deletions[i].ID, err)
+		}
+	}
+
+	return nil
+}
+
+// purge runs the steps of the deletion that did not succeed yet, in the order
+// of AccountDeletionServices, and completes the deletion once all succeeded
+func (w *AccountWorker) purge(ctx context.Context, deletion *entity.AccountDeletion) error {
+	repo := w.AuthUseCase.AccountDeletionRepository
+
+	if deletion.Status == entity.AccountDeletionScheduled {
+		started, err := w.startPurge(ctx, deletion)
+		if err != nil || !started {
+			return err
+		}
+	}
+
+	slices.SortFunc(deletion.Steps, func(a, b entity.AccountDeletionStep) int {
+		return slices.Index(entity.AccountDeletionServices, a.Service) - slices.Index(entity.AccountDeletionServices, b.Service)
+	})
+
+	for i := range deletion.Steps {
+		step := &deletion.Steps[i]
+		if step.Status == entity.AccountDeletionStepSucceeded {
+			continue
+		}
+
+		step.Attempts++
+		if err := w.purgeService(ctx, step.Service, deletion.UserID); err != nil {
+			message := err.Error()
+			step.LastError = &message
+			if err := repo.UpdateStep(w.DB.WithContext(ctx), step); err != nil {
+				w.Log.Errorf("Failed to update account deletion step %d: %v", step.ID, err)
+			}
+			return fmt.Errorf("%s: %w", step.Service, err)
+		}
+
+		now := time.Now()
+		step.Status = entity.AccountDeletionStepSucceeded
+		step.LastError = nil
+		step.CompletedAt = &now
+		if err := repo.UpdateStep(w.DB.WithContext(ctx), step); err != nil {
+			return err
+		}
+	}
+
+	email := deletion.Email
+
+	tx := w.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	now := time.Now()
+	deletion.Status = entity.AccountDeletionPurged
+	deletion.PurgedAt = &now
+	deletion.Email = nil
+	if err := repo.Update(tx, deletion); err != nil {
+		return err
+	}
+
+	if err := w.AuthUseCase.audit(tx, &entity.AuditLog{
+		Action:     entity.AuditActionDeletionPurge,
+		TargetType: entity.AuditTargetUser,
+		TargetID:   auditTargetID(int64(deletion.UserID)),
+		Diff:       auditDiff(map[string]any{"deletion_id": deletion.ID}),
+	}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+
+	if email != nil {
+		w.notify(ctx, &helper.Mail{
+			To:      *email,
+			Subject: "Your account was deleted",
+			Body:    "Hi,\n\nYour account and all of its data were deleted. Thank you for having been with us.\n",
+		})
+	}
+
+	return nil
+}
+
+// startPurge moves the deletion to purging unless it was cancelled meanwhile,
+// from then on it can no longer be cancelled
+func (w *AccountWorker) startPurge(ctx context.Context, deletion *entity.AccountDeletion) (bool, error) {
+	repo := w.AuthUseCase.AccountDeletionRepository
+
+	tx := w.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	current := new(entity.AccountDeletion)
+	if err := repo.FindActiveByUserIDForUpdate(tx, current, int(deletion.UserID)); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	if current.ID != deletion.ID || current.Status != entity.AccountDeletionScheduled {
+		return false, nil
+	}
+
+	deletion.Status = entity.AccountDeletionPurging
+	if err := repo.Update(tx, deletion); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit().Error
+}
+
+// purgeService removes the data of the user from one service, every service
+// succeeds when there is nothing left so a step can be retried
+func (w *AccountWorker) purgeService(ctx context.Context, service string, userID uint) error {
+	a := w.AuthUseCase
+
+	purgeCtx, cancelPurge := context.WithTimeout(ctx, time.Minute)
+	defer cancelPurge()
+
+	switch service {
+	case entity.AccountDeletionServiceChat:
+		_, err := a.Chat.Service.PurgeUserData(client.WithUserID(purgeCtx, int64(userID)), &proto.PurgeUserDataRequest{UserId: int64(userID)})
+		return err
+	case entity.AccountDeletionServiceAuth:
+		return w.purgeAuth(purgeCtx, userID)
+	case entity.AccountDeletionServiceUser:
+		_, err := a.User.Service.PurgeUser(purgeCtx, &proto.PurgeUserRequest{Id: int64(userID)})
+		return err
+	}
+
+	return fmt.Errorf("unknown service %q", service)
+}
+
+// purgeAuth removes everything this service keeps about the user, except the
+// audit log and the deletion itself
+func (w *AccountWorker) purgeAuth(ctx context.Context, userID uint) error {
+	a := w.AuthUseCase
+
+	tx := w.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	deletes := []func(tx *gorm.DB, userID int) error{
+		a.RefreshTokenRepository.DeleteAllByUserID,
+		a.SessionRepository.DeleteAllByUserID,
+		a.VerificationTokenRepository.DeleteAllByUserID,
+		a.TotpCredentialRepository.DeleteByUserID,
+		a.RecoveryCodeRepository.DeleteAllByUserID,
+		a.UserIdentityRepository.DeleteAllByUserID,
+		a.ApiKeyRepository.DeleteAllByUserID,
+		a.AccountExportRepository.DeleteAllByUserID,
+	}
+	for _, deleteAll := range deletes {
+		if err := deleteAll(tx, int(userID)); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+func (w *AccountWorker) notify(ctx context.Context, mail *helper.Mail) {
+	if err := w.AuthUseCase.sendMail(ctx, mail); err != nil {
+		w.Log.Errorf("Failed to send %q email: %v", mail.Subject, err)
+	}
+}
+
+func writeArchiveJSON(archive *zip.Writer, name string, data any) error {
+	f, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(f)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(data)
+}
+
+type exportProfile struct {
+	ID            int64   `json:"id"`
+	Name          string  `json:"name"`
+	Email         string  `json:"email"`
+	ImageURL      *string `json:"image_url"`
+	Role          string  `json:"role"`
+	EmailVerified bool    `json:"email_verified"`
+	CreatedAt     int64   `json:"created_at"`
+}
+
+type exportPreferences struct {
+	DefaultModel     string `json:"default_model"`
+	ResponseLanguage string `json:"response_language"`
+	Theme            string `json:"theme"`
+	Timezone         string `json:"timezone"`
+	MemoryEnabled    bool   `json:"memory_enabled"`
+	HistoryEnabled   bool   `json:"history_enabled"`
+}
+
+type exportSession struct {
+	ID         uint       `json:"id"`
+	UserAgent  string     `json:"user_agent"`
+	IPAddress  string     `json:"ip_address"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type exportIdentity struct {
+	Provider  string    `json:"provider"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// exportApiKey leaves out the key hash, only the prefix identifies the key
+type exportApiKey struct {
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func toExportProfile(user *proto.GetUserResponse) *exportProfile {
+	return &exportProfile{
+		ID:            user.GetId(),
+		Name:          user.GetName(),
+		Email:         user.GetEmail(),
+		ImageURL:      user.ImageUrl,
+		Role:          user.GetRole(),
+		EmailVerified: user.GetEmailVerified(),
+		CreatedAt:     user.GetCreatedAt(),
+	}
+}
+
+func toExportPreferences(preferences *proto.UserPreferences) *exportPreferences {
+	return &exportPreferences{
+		DefaultModel:     preferences.GetDefaultModel(),
+		ResponseLanguage: preferences.GetResponseLanguage(),
+		Theme:            preferences.GetTheme(),
+		Timezone:         preferences.GetTimezone(),
+		MemoryEnabled:    preferences.GetMemoryEnabled(),
+		HistoryEnabled:   preferences.GetHistoryEnabled(),
+	}
+}
+
+func toExportSessions(sessions []entity.Session) []exportSession {
+	res := make([]exportSession, 0, len(sessions))
+	for _, session := range sessions {
+		res = append(res, exportSession{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			LastSeenAt: session.LastSeenAt,
+			RevokedAt:  session.RevokedAt,
+			CreatedAt:  session.CreatedAt,
+		})
+	}
+
+	return res
+}
+
+func toExportIdentities(identities []entity.UserIdentity) []exportIdentity {
+	res := make([]exportIdentity, 0, len(identities))
+	for _, identity := range identities {
+		res = append(res, exportIdentity{
+			Provider:  identity.Provider,
+			Email:     identity.Email,
+			CreatedAt: identity.CreatedAt,
+		})
+	}
+
+	return res
+}
+
+func toExportApiKeys(apiKeys []entity.ApiKey) []exportApiKey {
+	res := make([]exportApiKey, 0, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		res = append(res, exportApiKey{
+			Name:       apiKey.Name,
+			Prefix:     apiKey.Prefix,
+			Scopes:     splitScopes(apiKey.Scopes),
+			ExpiresAt:  apiKey.ExpiresAt,
+			LastUsedAt: apiKey.LastUsedAt,
+			CreatedAt:  apiKey.CreatedAt,
+		})
+	}
+
+	return res
+}
\ No newline at end of file
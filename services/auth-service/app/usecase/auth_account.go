@@ -0,0 +1,485 @@
+package usecase
+
+import (
+	"auth-service/app/helper"
+	"auth-service/app/model/dto"
+	"auth-service/app/model/entity"
+	"auth-service/app/model/proto"
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// permissionUsersRead allows listing the users and their account deletions
+const permissionUsersRead = "users:read"
+
+// accountExportChunkSize keeps every message of a download well below the gRPC limit
+const accountExportChunkSize = 1 << 20
+
+// RequestAccountExport queues an export, the worker builds the archive and
+// emails the user once it can be downloaded
+func (a *AuthUseCaseImpl) RequestAccountExport(ctx context.Context, req *proto.RequestAccountExportRequest) (*proto.AccountExport, error) {
+	// Create request
+	request := &dto.RequestAccountExportRequest{
+		AccessToken: req.GetAccessToken(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	tx := a.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	var active int64
+	if err := a.AccountExportRepository.CountActiveByUserID(tx, &active, int(claims.UserID)); err != nil {
+		a.Log.Errorf("Failed to count account exports: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to request export")
+	}
+	if active > 0 {
+		return nil, status.Error(codes.FailedPrecondition, "An export is already in progress")
+	}
+
+	export := &entity.AccountExport{
+		UserID: claims.UserID,
+		Status: entity.AccountExportPending,
+	}
+	if err := a.AccountExportRepository.Create(tx, export); err != nil {
+		a.Log.Errorf("Failed to create account export: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to request export")
+	}
+
+	if err := a.audit(tx, &entity.AuditLog{
+		Action:     entity.AuditActionExportRequest,
+		ActorID:    auditActor(int64(claims.UserID)),
+		TargetType: entity.AuditTargetUser,
+		TargetID:   auditTargetID(int64(claims.UserID)),
+		IPAddress:  req.GetClient().GetIpAddress(),
+		UserAgent:  req.GetClient().GetUserAgent(),
+		Diff:       auditDiff(map[string]any{"export_id": export.ID}),
+	}); err != nil {
+		return nil, status.Error(codes.Internal, "Failed to request export")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, status.Error(codes.Internal, "Failed to request export")
+	}
+
+	return toAccountExport(export), nil
+}
+
+func (a *AuthUseCaseImpl) ListAccountExports(ctx context.Context, req *proto.ListAccountExportsRequest) (*proto.ListAccountExportsResponse, error) {
+	// Create request
+	request := &dto.ListAccountExportsRequest{
+		AccessToken: req.GetAccessToken(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	var exports []entity.AccountExport
+	if err := a.AccountExportRepository.FindAllByUserID(a.DB.WithContext(ctx), &exports, int(claims.UserID)); err != nil {
+		a.Log.Errorf("Failed to list account exports: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to list exports")
+	}
+
+	res := &proto.ListAccountExportsResponse{
+		Exports: make([]*proto.AccountExport, 0, len(exports)),
+	}
+	for i := range exports {
+		res.Exports = append(res.Exports, toAccountExport(&exports[i]))
+	}
+
+	return res, nil
+}
+
+// DownloadAccountExport streams the archive in chunks, the first one carries
+// the file name and the size of the whole archive
+func (a *AuthUseCaseImpl) DownloadAccountExport(req *proto.DownloadAccountExportRequest, stream proto.AuthService_DownloadAccountExportServer) error {
+	ctx := stream.Context()
+
+	// Create request
+	request := &dto.DownloadAccountExportRequest{
+		AccessToken: req.GetAccessToken(),
+		ID:          req.GetId(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return err
+	}
+
+	export := new(entity.AccountExport)
+	if err := a.AccountExportRepository.FindByIDAndUserID(a.DB.WithContext(ctx), export, int(request.ID), int(claims.UserID)); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return status.Error(codes.NotFound, "Export not found")
+		}
+
+		a.Log.Errorf("Failed to get account export: %v", err)
+		return status.Error(codes.Internal, "Failed to download export")
+	}
+
+	if export.Status != entity.AccountExportReady || (export.ExpiresAt != nil && time.Now().After(*export.ExpiresAt)) {
+		return status.Error(codes.FailedPrecondition, "Export is not ready for download")
+	}
+
+	for offset := 0; offset == 0 || offset < len(export.Archive); offset += accountExportChunkSize {
+		chunk := &proto.AccountExportChunk{
+			Content: export.Archive[offset:min(offset + accountExportChunkSize, len(export.Archive))],
+		}
+		if offset == 0 {
+			chunk.Filename = fmt.Sprintf("account-export-%d.zip", export.ID)
+			chunk.Size = int64(len(export.Archive))
+		}
+
+		if err := stream.Send(chunk); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RequestAccountDeletion signs the user out everywhere and soft deletes the
+// account right away, the worker purges it once the grace period ended unless
+// the user opens the emailed link to cancel it
+func (a *AuthUseCaseImpl) RequestAccountDeletion(ctx context.Context, req *proto.RequestAccountDeletionRequest) (*proto.AccountDeletion, error) {
+	// Create request
+	request := &dto.RequestAccountDeletionRequest{
+		AccessToken:     req.GetAccessToken(),
+		CurrentPassword: req.GetCurrentPassword(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := a.currentUser(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.confirmPassword(ctx, user, req.GetCurrentPassword(), req.GetClient()); err != nil {
+		return nil, err
+	}
+
+	grace := a.ttl("ACCOUNT_DELETION_GRACE", 30 * 24 * time.Hour)
+
+	tx := a.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	err = a.AccountDeletionRepository.FindActiveByUserIDForUpdate(tx, new(entity.AccountDeletion), int(claims.UserID))
+	if err == nil {
+		return nil, status.Error(codes.FailedPrecondition, "Account deletion is already scheduled")
+	}
+	if err != gorm.ErrRecordNotFound {
+		a.Log.Errorf("Failed to get account deletion: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to delete account")
+	}
+
+	email := user.GetEmail()
+	deletion := &entity.AccountDeletion{
+		UserID:     claims.UserID,
+		Email:      &email,
+		Status:     entity.AccountDeletionScheduled,
+		PurgeAfter: time.Now().Add(grace),
+	}
+	for _, service := range entity.AccountDeletionServices {
+		deletion.Steps = append(deletion.Steps, entity.AccountDeletionStep{
+			Service: service,
+			Status:  entity.AccountDeletionStepPending,
+		})
+	}
+	if err := a.AccountDeletionRepository.Create(tx, deletion); err != nil {
+		a.Log.Errorf("Failed to create account deletion: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to delete account")
+	}
+
+	if err := a.SessionRepository.RevokeAllByUserID(tx, int(claims.UserID)); err != nil {
+		a.Log.Errorf("Failed to revoke sessions: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to delete account")
+	}
+
+	if err := a.RefreshTokenRepository.RevokeAllByUserID(tx, int(claims.UserID)); err != nil {
+		a.Log.Errorf("Failed to revoke refresh tokens: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to delete account")
+	}
+
+	if err := a.audit(tx, &entity.AuditLog{
+		Action:     entity.AuditActionDeletionRequest,
+		ActorID:    auditActor(user.GetId()),
+		TargetType: entity.AuditTargetUser,
+		TargetID:   auditTargetID(user.GetId()),
+		IPAddress:  req.GetClient().GetIpAddress(),
+		UserAgent:  req.GetClient().GetUserAgent(),
+		Diff:       auditDiff(map[string]any{"purge_after": deletion.PurgeAfter.Unix()}),
+	}); err != nil {
+		return nil, status.Error(codes.Internal, "Failed to delete account")
+	}
+
+	deleteUserCtx, cancelDeleteUser := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelDeleteUser()
+
+	if _, err := a.User.Service.DeleteUser(deleteUserCtx, &proto.DeleteUserRequest{Id: user.GetId()}); err != nil {
+		return nil, a.userServiceError(err, "Failed to delete account")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		// Nothing tracks the deletion, so the user must not stay deleted
+		if _, err := a.User.Service.RestoreUser(ctx, &proto.RestoreUserRequest{Id: user.GetId()}); err != nil {
+			a.Log.Errorf("Failed to restore user %d: %v", user.GetId(), err)
+		}
+		return nil, status.Error(codes.Internal, "Failed to delete account")
+	}
+
+	token, err := a.storeToken(ctx, &entity.VerificationToken{
+		UserID:  claims.UserID,
+		Purpose: entity.VerificationPurposeCancelDeletion,
+	}, grace)
+	if err != nil {
+		a.Log.Errorf("Failed to create cancel deletion token: %v", err)
+		return toAccountDeletion(deletion), nil
+	}
+
+	if err := a.sendMail(ctx, &helper.Mail{
+		To:      email,
+		Subject: "Your account is scheduled for deletion",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nYour account and all of its data will be deleted on %s.\n\nIf you change your mind, open this link before then to keep your account:\n\n%s\n",
+			user.GetName(), deletion.PurgeAfter.UTC().Format("January 2, 2006"), a.link("/cancel-account-deletion", token),
+		),
+	}); err != nil {
+		a.Log.Errorf("Failed to send account deletion email: %v", err)
+	}
+
+	return toAccountDeletion(deletion), nil
+}
+
+// CancelAccountDeletion restores the account with the emailed token, the user
+// signs in again afterwards since every session was revoked
+func (a *AuthUseCaseImpl) CancelAccountDeletion(ctx context.Context, req *proto.CancelAccountDeletionRequest) (*proto.AccountDeletion, error) {
+	// Create request
+	request := &dto.CancelAccountDeletionRequest{
+		Token: req.GetToken(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	tx := a.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	stored, err := a.redeemToken(tx, entity.VerificationPurposeCancelDeletion, req.GetToken())
+	if err != nil {
+		return nil, err
+	}
+
+	deletion := new(entity.AccountDeletion)
+	if err := a.AccountDeletionRepository.FindActiveByUserIDForUpdate(tx, deletion, int(stored.UserID)); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, status.Error(codes.FailedPrecondition, "Account deletion is not scheduled")
+		}
+
+		a.Log.Errorf("Failed to get account deletion: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to cancel account deletion")
+	}
+
+	// Purging cannot be undone once it started
+	if deletion.Status != entity.AccountDeletionScheduled {
+		return nil, status.Error(codes.FailedPrecondition, "Account deletion is already in progress")
+	}
+
+	restoreUserCtx, cancelRestoreUser := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelRestoreUser()
+
+	user, err := a.User.Service.RestoreUser(restoreUserCtx, &proto.RestoreUserRequest{Id: int64(deletion.UserID)})
+	if err != nil {
+		return nil, a.userServiceError(err, "Failed to cancel account deletion")
+	}
+
+	now := time.Now()
+	deletion.Status = entity.AccountDeletionCancelled
+	deletion.CancelledAt = &now
+	deletion.Email = nil
+	if err := a.AccountDeletionRepository.Update(tx, deletion); err != nil {
+		a.Log.Errorf("Failed to update account deletion: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to cancel account deletion")
+	}
+
+	if err := a.audit(tx, &entity.AuditLog{
+		Action:     entity.AuditActionDeletionCancel,
+		ActorID:    auditActor(user.GetId()),
+		TargetType: entity.AuditTargetUser,
+		TargetID:   auditTargetID(user.GetId()),
+		Diff:       auditDiff(map[string]any{"deletion_id": deletion.ID}),
+	}); err != nil {
+		return nil, status.Error(codes.Internal, "Failed to cancel account deletion")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, status.Error(codes.Internal, "Failed to cancel account deletion")
+	}
+
+	if err := a.sendMail(ctx, &helper.Mail{
+		To:      user.GetEmail(),
+		Subject: "Your account was restored",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nThe deletion of your account was cancelled and you can sign in again.\n",
+			user.GetName(),
+		),
+	}); err != nil {
+		a.Log.Errorf("Failed to send account restored email: %v", err)
+	}
+
+	return toAccountDeletion(deletion), nil
+}
+
+func (a *AuthUseCaseImpl) AdminListAccountDeletions(ctx context.Context, req *proto.AdminListAccountDeletionsRequest) (*proto.AdminListAccountDeletionsResponse, error) {
+	// Create request
+	request := &dto.AdminListAccountDeletionsRequest{
+		AccessToken: req.GetAccessToken(),
+		Status:      req.GetStatus(),
+		Page:        req.GetPage(),
+		PageSize:    req.GetPageSize(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+	if request.Page == 0 {
+		request.Page = 1
+	}
+	if request.PageSize == 0 {
+		request.PageSize = 20
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := a.requirePermission(ctx, claims.UserID, permissionUsersRead, "Failed to list account deletions"); err != nil {
+		return nil, err
+	}
+
+	db := a.DB.WithContext(ctx)
+
+	var total int64
+	if err := a.AccountDeletionRepository.CountSearch(db, &total, request.Status); err != nil {
+		a.Log.Errorf("Failed to count account deletions: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to list account deletions")
+	}
+
+	var deletions []entity.AccountDeletion
+	offset := int((request.Page - 1) * request.PageSize)
+	if err := a.AccountDeletionRepository.Search(db, &deletions, request.Status, offset, int(request.PageSize)); err != nil {
+		a.Log.Errorf("Failed to list account deletions: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to list account deletions")
+	}
+
+	res := &proto.AdminListAccountDeletionsResponse{
+		Deletions: make([]*proto.AccountDeletion, 0, len(deletions)),
+		Total:     total,
+	}
+	for i := range deletions {
+		res.Deletions = append(res.Deletions, toAccountDeletion(&deletions[i]))
+	}
+
+	return res, nil
+}
+
+func toAccountExport(export *entity.AccountExport) *proto.AccountExport {
+	res := &proto.AccountExport{
+		Id:        int64(export.ID),
+		Status:    export.Status,
+		Size:      export.Size,
+		CreatedAt: export.CreatedAt.Unix(),
+	}
+	if export.Error != nil {
+		res.Error = *export.Error
+	}
+	if export.CompletedAt != nil {
+		res.CompletedAt = export.CompletedAt.Unix()
+	}
+	if export.ExpiresAt != nil {
+		res.ExpiresAt = export.ExpiresAt.Unix()
+	}
+
+	return res
+}
+
+func toAccountDeletion(deletion *entity.AccountDeletion) *proto.AccountDeletion {
+	res := &proto.AccountDeletion{
+		Id:         int64(deletion.ID),
+		UserId:     int64(deletion.UserID),
+		Status:     deletion.Status,
+		PurgeAfter: deletion.PurgeAfter.Unix(),
+		CreatedAt:  deletion.CreatedAt.Unix(),
+		Steps:      make([]*proto.AccountDeletionStep, 0, len(deletion.Steps)),
+	}
+	if deletion.CancelledAt != nil {
+		res.CancelledAt = deletion.CancelledAt.Unix()
+	}
+	if deletion.PurgedAt != nil {
+		res.PurgedAt = deletion.PurgedAt.Unix()
+	}
+
+	for _, step := range deletion.Steps {
+		s := &proto.AccountDeletionStep{
+			Service:  step.Service,
+			Status:   step.Status,
+			Attempts: step.Attempts,
+		}
+		if step.LastError != nil {
+			s.LastError = *step.LastError
+		}
+		if step.CompletedAt != nil {
+			s.CompletedAt = step.CompletedAt.Unix()
+		}
+		res.Steps = append(res.Steps, s)
+	}
+
+	return res
+}
\ No newline at end of file
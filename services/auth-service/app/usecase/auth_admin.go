@@ -0,0 +1,342 @@
+package usecase
+
+import (
+	"auth-service/app/delivery/client"
+	"auth-service/app/helper"
+	"auth-service/app/model/dto"
+	"auth-service/app/model/entity"
+	"auth-service/app/model/proto"
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Admin requests are authenticated here and authorized by the user service,
+// which checks the permissions of the current role of the acting user. Calls
+// are signed for the acting user with WithUserID, the actorId follows from it.
+
+// permissionUsersSuspend also allows unlocking accounts locked out by failed logins
+const permissionUsersSuspend = "users:suspend"
+
+func (a *AuthUseCaseImpl) AdminListUsers(ctx context.Context, req *proto.AdminListUsersRequest) (*proto.AdminListUsersResponse, error) {
+	// Create request
+	request := &dto.AdminListUsersRequest{
+		AccessToken: req.GetAccessToken(),
+		Query:       req.GetQuery(),
+		Page:        req.GetPage(),
+		PageSize:    req.GetPageSize(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	listUsersCtx, cancelListUsers := context.WithTimeout(client.WithUserID(ctx, int64(claims.UserID)), 2 * time.Second)
+	defer cancelListUsers()
+
+	users, err := a.User.Service.ListUsers(listUsersCtx, &proto.ListUsersRequest{
+		ActorId:  int64(claims.UserID),
+		Query:    req.GetQuery(),
+		Page:     req.GetPage(),
+		PageSize: req.GetPageSize(),
+	})
+	if err != nil {
+		return nil, a.userServiceError(err, "Failed to list users")
+	}
+
+	res := &proto.AdminListUsersResponse{
+		Users: make([]*proto.AdminUser, 0, len(users.GetUsers())),
+		Total: users.GetTotal(),
+	}
+	for _, user := range users.GetUsers() {
+		res.Users = append(res.Users, toAdminUser(user))
+	}
+
+	return res, nil
+}
+
+// AdminSuspendUser suspends or restores the account. Suspension also revokes
+// every session of the user, so its access tokens stop working right away.
+func (a *AuthUseCaseImpl) AdminSuspendUser(ctx context.Context, req *proto.AdminSuspendUserRequest) (*proto.AdminUser, error) {
+	// Create request
+	request := &dto.AdminSuspendUserRequest{
+		AccessToken: req.GetAccessToken(),
+		UserID:      req.GetUserId(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	suspendUserCtx, cancelSuspendUser := context.WithTimeout(client.WithUserID(ctx, int64(claims.UserID)), 2 * time.Second)
+	defer cancelSuspendUser()
+
+	// The previous state goes into the audit log
+	previous, err := a.User.Service.GetUserByID(suspendUserCtx, &proto.GetUserByIDRequest{Id: req.GetUserId()})
+	if err != nil {
+		return nil, a.userServiceError(err, "Failed to suspend user")
+	}
+
+	user, err := a.User.Service.SuspendUser(suspendUserCtx, &proto.SuspendUserRequest{
+		ActorId:   int64(claims.UserID),
+		Id:        req.GetUserId(),
+		Suspended: req.GetSuspended(),
+	})
+	if err != nil {
+		return nil, a.userServiceError(err, "Failed to suspend user")
+	}
+
+	if user.GetSuspended() {
+		if err := a.revokeUserSessions(ctx, user.GetId(), "Failed to suspend user"); err != nil {
+			return nil, err
+		}
+	}
+
+	a.auditEvent(ctx, &entity.AuditLog{
+		Action:     entity.AuditActionAdminSuspend,
+		ActorID:    auditActor(int64(claims.UserID)),
+		TargetType: entity.AuditTargetUser,
+		TargetID:   auditTargetID(user.GetId()),
+		IPAddress:  req.GetClient().GetIpAddress(),
+		UserAgent:  req.GetClient().GetUserAgent(),
+		Diff:       auditDiff(map[string]any{"suspended": auditChange{Old: previous.GetSuspended(), New: user.GetSuspended()}}),
+	})
+
+	return toAdminUser(user), nil
+}
+
+// AdminUpdateUserRole changes the role of the user. Its sessions are revoked,
+// so tokens carrying the permissions of the old role stop working right away.
+func (a *AuthUseCaseImpl) AdminUpdateUserRole(ctx context.Context, req *proto.AdminUpdateUserRoleRequest) (*proto.AdminUser, error) {
+	// Create request
+	request := &dto.AdminUpdateUserRoleRequest{
+		AccessToken: req.GetAccessToken(),
+		UserID:      req.GetUserId(),
+		Role:        req.GetRole(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	updateRoleCtx, cancelUpdateRole := context.WithTimeout(client.WithUserID(ctx, int64(claims.UserID)), 2 * time.Second)
+	defer cancelUpdateRole()
+
+	// The previous role goes into the audit log
+	previous, err := a.User.Service.GetUserByID(updateRoleCtx, &proto.GetUserByIDRequest{Id: req.GetUserId()})
+	if err != nil {
+		return nil, a.userServiceError(err, "Failed to update user role")
+	}
+
+	user, err := a.User.Service.UpdateUserRole(updateRoleCtx, &proto.UpdateUserRoleRequest{
+		ActorId: int64(claims.UserID),
+		Id:      req.GetUserId(),
+		Role:    req.GetRole(),
+	})
+	if err != nil {
+		return nil, a.userServiceError(err, "Failed to update user role")
+	}
+
+	if previous.GetRole() != user.GetRole() {
+		if err := a.revokeUserSessions(ctx, user.GetId(), "Failed to update user role"); err != nil {
+			return nil, err
+		}
+	}
+
+	a.auditEvent(ctx, &entity.AuditLog{
+		Action:     entity.AuditActionAdminUpdateRole,
+		ActorID:    auditActor(int64(claims.UserID)),
+		TargetType: entity.AuditTargetUser,
+		TargetID:   auditTargetID(user.GetId()),
+		IPAddress:  req.GetClient().GetIpAddress(),
+		UserAgent:  req.GetClient().GetUserAgent(),
+		Diff:       auditDiff(map[string]any{"role": auditChange{Old: previous.GetRole(), New: user.GetRole()}}),
+	})
+
+	return toAdminUser(user), nil
+}
+
+func (a *AuthUseCaseImpl) AdminGetUserStats(ctx context.Context, req *proto.AdminGetUserStatsRequest) (*proto.AdminUserStats, error) {
+	// Create request
+	request := &dto.AdminGetUserStatsRequest{
+		AccessToken: req.GetAccessToken(),
+		Since:       req.GetSince(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	getStatsCtx, cancelGetStats := context.WithTimeout(client.WithUserID(ctx, int64(claims.UserID)), 2 * time.Second)
+	defer cancelGetStats()
+
+	stats, err := a.User.Service.GetUserStats(getStatsCtx, &proto.GetUserStatsRequest{
+		ActorId: int64(claims.UserID),
+		Since:   req.GetSince(),
+	})
+	if err != nil {
+		return nil, a.userServiceError(err, "Failed to get user stats")
+	}
+
+	return &proto.AdminUserStats{
+		Total:     stats.GetTotal(),
+		Verified:  stats.GetVerified(),
+		Suspended: stats.GetSuspended(),
+		Created:   stats.GetCreated(),
+	}, nil
+}
+
+// AdminUnlockUser clears the failed logins of the account and optionally of an
+// IP address. Throttles live in this service, so the permission is checked here
+// against the current role of the acting user.
+func (a *AuthUseCaseImpl) AdminUnlockUser(ctx context.Context, req *proto.AdminUnlockUserRequest) (*proto.AdminUser, error) {
+	// Create request
+	request := &dto.AdminUnlockUserRequest{
+		AccessToken: req.GetAccessToken(),
+		UserID:      req.GetUserId(),
+		IPAddress:   req.GetIpAddress(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	actor, err := a.requirePermission(ctx, claims.UserID, permissionUsersSuspend, "Failed to unlock user")
+	if err != nil {
+		return nil, err
+	}
+
+	getUserCtx, cancelGetUser := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetUser()
+
+	user, err := a.User.Service.GetUserByID(getUserCtx, &proto.GetUserByIDRequest{Id: req.GetUserId()})
+	if err != nil {
+		return nil, a.userServiceError(err, "Failed to unlock user")
+	}
+
+	tx := a.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	for _, subject := range loginSubjects(user.GetEmail(), req.GetIpAddress()) {
+		if err := a.LoginThrottleRepository.DeleteBySubject(tx, subject); err != nil {
+			a.Log.Errorf("Failed to delete login throttle: %v", err)
+			return nil, status.Error(codes.Internal, "Failed to unlock user")
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, status.Error(codes.Internal, "Failed to unlock user")
+	}
+
+	a.securityEvent(SecurityEventLoginUnlock, logrus.Fields{
+		"subject":  entity.ThrottleSubjectAccount + user.GetEmail(),
+		"user_id":  user.GetId(),
+		"actor_id": actor.GetId(),
+		"ip":       req.GetIpAddress(),
+	})
+
+	a.auditEvent(ctx, &entity.AuditLog{
+		Action:     entity.AuditActionAdminUnlock,
+		ActorID:    auditActor(actor.GetId()),
+		TargetType: entity.AuditTargetUser,
+		TargetID:   auditTargetID(user.GetId()),
+		IPAddress:  req.GetClient().GetIpAddress(),
+		UserAgent:  req.GetClient().GetUserAgent(),
+		Diff:       auditDiff(map[string]any{"unlocked_ip_address": req.GetIpAddress()}),
+	})
+
+	return toAdminUser(user), nil
+}
+
+// revokeUserSessions revokes every session and refresh token of the user. The
+// services verifying access tokens locally drop them once they poll the revoked sessions.
+func (a *AuthUseCaseImpl) revokeUserSessions(ctx context.Context, userID int64, message string) error {
+	tx := a.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := a.SessionRepository.RevokeAllByUserID(tx, int(userID)); err != nil {
+		a.Log.Errorf("Failed to revoke sessions: %v", err)
+		return status.Error(codes.Internal, message)
+	}
+
+	if err := a.RefreshTokenRepository.RevokeAllByUserID(tx, int(userID)); err != nil {
+		a.Log.Errorf("Failed to revoke refresh tokens: %v", err)
+		return status.Error(codes.Internal, message)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return status.Error(codes.Internal, message)
+	}
+
+	return nil
+}
+
+// userServiceError forwards the errors of the user service meant for the
+// caller and hides every other one behind the message
+func (a *AuthUseCaseImpl) userServiceError(err error, message string) error {
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.InvalidArgument, codes.NotFound, codes.PermissionDenied, codes.FailedPrecondition, codes.AlreadyExists:
+			return status.Error(s.Code(), s.Message())
+		}
+	}
+
+	a.Log.Errorf("%s: %v", message, err)
+	return status.Error(codes.Internal, message)
+}
+
+func toAdminUser(user *proto.GetUserResponse) *proto.AdminUser {
+	return &proto.AdminUser{
+		Id:            user.GetId(),
+		Name:          user.GetName(),
+		Email:         user.GetEmail(),
+		ImageUrl:      user.GetImageUrl(),
+		Role:          user.GetRole(),
+		EmailVerified: user.GetEmailVerified(),
+		Suspended:     user.GetSuspended(),
+		CreatedAt:     user.GetCreatedAt(),
+	}
+}
\ No newline at end of file
@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"auth-service/app/helper"
+	"auth-service/app/model/entity"
+	"auth-service/app/model/proto"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// expectRoleUpdate lets admin 1 change the role of user 2 from previous to role
+func expectRoleUpdate(mocks *authUseCaseMocks, previous string, role string) {
+    mocks.jwt.On("ValidateAccessToken", "access").Return(&helper.TokenClaims{UserID: 1}, nil)
+    mocks.user.On("GetUserByID", int64(2)).Return(&proto.GetUserResponse{Id: 2, Role: previous}, nil)
+    mocks.user.On("UpdateUserRole", mock.MatchedBy(func(req *proto.UpdateUserRoleRequest) bool {
+        return req.GetActorId() == 1 && req.GetId() == 2 && req.GetRole() == role
+    })).Return(&proto.GetUserResponse{Id: 2, Role: role}, nil)
+    mocks.auditLogs.On("Append", mock.Anything, mock.MatchedBy(func(log *entity.AuditLog) bool {
+        return log.Action == entity.AuditActionAdminUpdateRole && log.TargetID == "2"
+    })).Return(nil)
+}
+
+func TestAuthUseCaseImpl_AdminUpdateUserRole_RevokesSessions(t *testing.T) {
+    usecase, mocks := setupAuthUseCase(t)
+    expectRoleUpdate(mocks, "admin", "user")
+
+    mocks.db.ExpectBegin()
+    mocks.sessions.On("RevokeAllByUserID", mock.Anything, 2).Return(nil)
+    mocks.refreshTokens.On("RevokeAllByUserID", mock.Anything, 2).Return(nil)
+    mocks.db.ExpectCommit()
+
+    res, err := usecase.AdminUpdateUserRole(context.Background(), &proto.AdminUpdateUserRoleRequest{
+        AccessToken: "access",
+        UserId:      2,
+        Role:        "user",
+    })
+
+    assert.NoError(t, err)
+    assert.Equal(t, "user", res.GetRole())
+    mocks.assertExpectations(t)
+}
+
+func TestAuthUseCaseImpl_AdminUpdateUserRole_SameRoleKeepsSessions(t *testing.T) {
+    usecase, mocks := setupAuthUseCase(t)
+    expectRoleUpdate(mocks, "support", "support")
+
+    _, err := usecase.AdminUpdateUserRole(context.Background(), &proto.AdminUpdateUserRoleRequest{
+        AccessToken: "access",
+        UserId:      2,
+        Role:        "support",
+    })
+
+    assert.NoError(t, err)
+    mocks.sessions.AssertNotCalled(t, "RevokeAllByUserID", mock.Anything, mock.Anything)
+    mocks.assertExpectations(t)
+}
@@ -0,0 +1,370 @@
+package usecase
+
+import (
+	"auth-service/app/helper"
+	"auth-service/app/model/dto"
+	"auth-service/app/model/entity"
+	"auth-service/app/model/proto"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+const (
+	// ApiKeyPrefix starts every API key so the gateway can tell keys from JWTs
+	ApiKeyPrefix = "sk_"
+	// apiKeyDisplayLength characters of a key are kept in clear to recognize it
+	apiKeyDisplayLength = 11
+	maxApiKeysPerUser   = 25
+	apiKeyTouchInterval = time.Minute
+)
+
+func (a *AuthUseCaseImpl) CreateApiKey(ctx context.Context, req *proto.CreateApiKeyRequest) (*proto.CreateApiKeyResponse, error) {
+	// Create request
+	request := &dto.CreateApiKeyRequest{
+		AccessToken: req.GetAccessToken(),
+		Name:        req.GetName(),
+		Scopes:      req.GetScopes(),
+		ExpiresAt:   req.GetExpiresAt(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	scopes, err := normalizeScopes(req.GetScopes())
+	if err != nil {
+		return nil, err
+	}
+
+	var expiresAt *time.Time
+	if req.GetExpiresAt() > 0 {
+		expiry := time.Unix(req.GetExpiresAt(), 0)
+		if expiry.Before(time.Now()) {
+			return nil, status.Error(codes.InvalidArgument, "Expiry must be in the future")
+		}
+		expiresAt = &expiry
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	tx := a.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	var count int64
+	if err := a.ApiKeyRepository.CountByUserID(tx, &count, int(claims.UserID)); err != nil {
+		a.Log.Errorf("Failed to count api keys: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to create api key")
+	}
+	if count >= maxApiKeysPerUser {
+		return nil, status.Error(codes.ResourceExhausted, fmt.Sprintf("An account can have at most %d api keys", maxApiKeysPerUser))
+	}
+
+	secret, err := helper.RandomToken(32)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to create api key")
+	}
+	key := ApiKeyPrefix + secret
+
+	// Only the hash of the key is stored, it is shown once
+	apiKey := &entity.ApiKey{
+		UserID:    claims.UserID,
+		Name:      req.GetName(),
+		Prefix:    key[:apiKeyDisplayLength],
+		KeyHash:   helper.HashToken(key),
+		Scopes:    strings.Join(scopes, ","),
+		ExpiresAt: expiresAt,
+	}
+	if err := a.ApiKeyRepository.Create(tx, apiKey); err != nil {
+		a.Log.Errorf("Failed to create api key: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to create api key")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, status.Error(codes.Internal, "Failed to create api key")
+	}
+
+	return &proto.CreateApiKeyResponse{
+		ApiKey: toApiKey(apiKey),
+		Key:    key,
+	}, nil
+}
+
+func (a *AuthUseCaseImpl) ListApiKeys(ctx context.Context, req *proto.ListApiKeysRequest) (*proto.ListApiKeysResponse, error) {
+	// Create request
+	request := &dto.ListApiKeysRequest{
+		AccessToken: req.GetAccessToken(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	apiKeys := new([]entity.ApiKey)
+	if err := a.ApiKeyRepository.FindAllByUserID(a.DB.WithContext(ctx), apiKeys, int(claims.UserID)); err != nil {
+		a.Log.Errorf("Failed to get api keys: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to get api keys")
+	}
+
+	res := &proto.ListApiKeysResponse{
+		ApiKeys: make([]*proto.ApiKey, 0),
+	}
+
+	for _, apiKey := range *apiKeys {
+		res.ApiKeys = append(res.ApiKeys, toApiKey(&apiKey))
+	}
+
+	return res, nil
+}
+
+func (a *AuthUseCaseImpl) GetApiKey(ctx context.Context, req *proto.GetApiKeyRequest) (*proto.ApiKey, error) {
+	// Create request
+	request := &dto.ApiKeyRequest{
+		AccessToken: req.GetAccessToken(),
+		ID:          req.GetId(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey, err := a.findApiKey(a.DB.WithContext(ctx), req.GetId(), claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toApiKey(apiKey), nil
+}
+
+func (a *AuthUseCaseImpl) UpdateApiKey(ctx context.Context, req *proto.UpdateApiKeyRequest) (*proto.ApiKey, error) {
+	// Create request
+	request := &dto.UpdateApiKeyRequest{
+		AccessToken: req.GetAccessToken(),
+		ID:          req.GetId(),
+		Name:        req.GetName(),
+		Scopes:      req.GetScopes(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	scopes, err := normalizeScopes(req.GetScopes())
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	tx := a.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	apiKey, err := a.findApiKey(tx, req.GetId(), claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey.Name = req.GetName()
+	apiKey.Scopes = strings.Join(scopes, ",")
+	if err := a.ApiKeyRepository.Update(tx, apiKey); err != nil {
+		a.Log.Errorf("Failed to update api key: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to update api key")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, status.Error(codes.Internal, "Failed to update api key")
+	}
+
+	return toApiKey(apiKey), nil
+}
+
+func (a *AuthUseCaseImpl) DeleteApiKey(ctx context.Context, req *proto.DeleteApiKeyRequest) (*proto.DeleteApiKeyResponse, error) {
+	// Create request
+	request := &dto.ApiKeyRequest{
+		AccessToken: req.GetAccessToken(),
+		ID:          req.GetId(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	tx := a.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	apiKey, err := a.findApiKey(tx, req.GetId(), claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.ApiKeyRepository.Delete(tx, apiKey); err != nil {
+		a.Log.Errorf("Failed to delete api key: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to delete api key")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, status.Error(codes.Internal, "Failed to delete api key")
+	}
+
+	return &proto.DeleteApiKeyResponse{}, nil
+}
+
+// ResolveApiKey returns the owner and scopes of a valid key, the gateway calls
+// it for every request authenticated by a key
+func (a *AuthUseCaseImpl) ResolveApiKey(ctx context.Context, req *proto.ResolveApiKeyRequest) (*proto.ResolveApiKeyResponse, error) {
+	// Create request
+	request := &dto.ResolveApiKeyRequest{
+		Key: req.GetKey(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	if !strings.HasPrefix(req.GetKey(), ApiKeyPrefix) {
+		return nil, status.Error(codes.Unauthenticated, "Invalid api key")
+	}
+
+	apiKey := new(entity.ApiKey)
+	if err := a.ApiKeyRepository.FindByKeyHash(a.DB.WithContext(ctx), apiKey, helper.HashToken(req.GetKey())); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, status.Error(codes.Unauthenticated, "Invalid api key")
+		}
+
+		a.Log.Errorf("Failed to get api key: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to resolve api key")
+	}
+
+	now := time.Now()
+	if apiKey.ExpiresAt != nil && now.After(*apiKey.ExpiresAt) {
+		return nil, status.Error(codes.Unauthenticated, "Api key expired")
+	}
+
+	getUserCtx, cancelGetUser := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetUser()
+
+	user, err := a.User.Service.GetUserByID(getUserCtx, &proto.GetUserByIDRequest{Id: int64(apiKey.UserID)})
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "User not found")
+	}
+
+	if user.GetSuspended() {
+		return nil, status.Error(codes.PermissionDenied, "Account is suspended")
+	}
+
+	// Keep the last used time of the key roughly up to date
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) > apiKeyTouchInterval {
+		if err := a.ApiKeyRepository.Touch(a.DB.WithContext(ctx), int(apiKey.ID), now); err != nil {
+			a.Log.Errorf("Failed to update api key last used: %v", err)
+		}
+	}
+
+	return &proto.ResolveApiKeyResponse{
+		Id:       user.GetId(),
+		Name:     user.GetName(),
+		Email:    user.GetEmail(),
+		ImageUrl: user.GetImageUrl(),
+		ApiKeyId: int64(apiKey.ID),
+		Scopes:   splitScopes(apiKey.Scopes),
+	}, nil
+}
+
+func (a *AuthUseCaseImpl) findApiKey(tx *gorm.DB, id int64, userID uint) (*entity.ApiKey, error) {
+	apiKey := new(entity.ApiKey)
+	if err := a.ApiKeyRepository.FindByIDAndUserID(tx, apiKey, int(id), int(userID)); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, status.Error(codes.NotFound, "Api key not found")
+		}
+
+		a.Log.Errorf("Failed to get api key: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to get api key")
+	}
+
+	return apiKey, nil
+}
+
+// normalizeScopes rejects unknown scopes and drops duplicates
+func normalizeScopes(scopes []string) ([]string, error) {
+	normalized := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if !slices.Contains(entity.ApiKeyScopes, scope) {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("Unknown scope %s", scope))
+		}
+		if !slices.Contains(normalized, scope) {
+			normalized = append(normalized, scope)
+		}
+	}
+
+	return normalized, nil
+}
+
+func splitScopes(scopes string) []string {
+	if scopes == "" {
+		return make([]string, 0)
+	}
+
+	return strings.Split(scopes, ",")
+}
+
+func toApiKey(apiKey *entity.ApiKey) *proto.ApiKey {
+	res := &proto.ApiKey{
+		Id:        int64(apiKey.ID),
+		Name:      apiKey.Name,
+		Prefix:    apiKey.Prefix,
+		Scopes:    splitScopes(apiKey.Scopes),
+		CreatedAt: apiKey.CreatedAt.Unix(),
+	}
+	if apiKey.ExpiresAt != nil {
+		res.ExpiresAt = apiKey.ExpiresAt.Unix()
+	}
+	if apiKey.LastUsedAt != nil {
+		res.LastUsedAt = apiKey.LastUsedAt.Unix()
+	}
+
+	return res
+}
\ No newline at end of file
@@ -0,0 +1,272 @@
+package usecase
+
+import (
+	"auth-service/app/helper"
+	"auth-service/app/model/dto"
+	"auth-service/app/model/entity"
+	"auth-service/app/model/proto"
+	"context"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// permissionAuditRead allows listing, exporting and verifying the audit log
+const permissionAuditRead = "audit:read"
+
+// auditVerifyBatch is how many entries are read at once while walking the chain
+const auditVerifyBatch = 500
+
+// auditRedacted stands in for secrets in diffs, only the fact they changed is recorded
+const auditRedacted = "[redacted]"
+
+// auditChange is the value of a field before and after the change, diffs map field names to it
+type auditChange struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+// RecordAuditEvent appends events of the other services, the gateway records
+// chat deletes and shares once the chat service accepted them
+func (a *AuthUseCaseImpl) RecordAuditEvent(ctx context.Context, req *proto.RecordAuditEventRequest) (*proto.AuditLog, error) {
+	// Create request
+	request := &dto.RecordAuditEventRequest{
+		Action:     req.GetAction(),
+		TargetType: req.GetTargetType(),
+		TargetID:   req.GetTargetId(),
+		Diff:       req.GetDiff(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	log := &entity.AuditLog{
+		Action:     req.GetAction(),
+		ActorID:    auditActor(req.GetActorId()),
+		TargetType: req.GetTargetType(),
+		TargetID:   req.GetTargetId(),
+		IPAddress:  req.GetClient().GetIpAddress(),
+		UserAgent:  req.GetClient().GetUserAgent(),
+		Diff:       req.GetDiff(),
+	}
+	if err := a.AuditLogRepository.Append(a.DB.WithContext(ctx), log); err != nil {
+		a.Log.Errorf("Failed to append audit log: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to record audit event")
+	}
+
+	return toAuditLog(log), nil
+}
+
+func (a *AuthUseCaseImpl) AdminListAuditLogs(ctx context.Context, req *proto.AdminListAuditLogsRequest) (*proto.AdminListAuditLogsResponse, error) {
+	// Create request
+	request := &dto.AdminListAuditLogsRequest{
+		AccessToken: req.GetAccessToken(),
+		Action:      req.GetAction(),
+		TargetType:  req.GetTargetType(),
+		TargetID:    req.GetTargetId(),
+		Since:       req.GetSince(),
+		Until:       req.GetUntil(),
+		Page:        req.GetPage(),
+		PageSize:    req.GetPageSize(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+	if request.Page == 0 {
+		request.Page = 1
+	}
+	if request.PageSize == 0 {
+		request.PageSize = 20
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := a.requirePermission(ctx, claims.UserID, permissionAuditRead, "Failed to list audit logs"); err != nil {
+		return nil, err
+	}
+
+	filter := &entity.AuditLogFilter{
+		ActorID:    auditActor(req.GetActorId()),
+		Action:     request.Action,
+		TargetType: request.TargetType,
+		TargetID:   request.TargetID,
+	}
+	if request.Since > 0 {
+		since := time.Unix(request.Since, 0).UTC()
+		filter.Since = &since
+	}
+	if request.Until > 0 {
+		until := time.Unix(request.Until, 0).UTC()
+		filter.Until = &until
+	}
+
+	db := a.DB.WithContext(ctx)
+
+	var total int64
+	if err := a.AuditLogRepository.CountSearch(db, &total, filter); err != nil {
+		a.Log.Errorf("Failed to count audit logs: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to list audit logs")
+	}
+
+	var logs []entity.AuditLog
+	offset := int((request.Page - 1) * request.PageSize)
+	if err := a.AuditLogRepository.Search(db, &logs, filter, offset, int(request.PageSize)); err != nil {
+		a.Log.Errorf("Failed to list audit logs: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to list audit logs")
+	}
+
+	res := &proto.AdminListAuditLogsResponse{
+		Logs:  make([]*proto.AuditLog, 0, len(logs)),
+		Total: total,
+	}
+	for i := range logs {
+		res.Logs = append(res.Logs, toAuditLog(&logs[i]))
+	}
+
+	return res, nil
+}
+
+// AdminVerifyAuditLogs recomputes the hash chain from the first entry and reports
+// the first entry that was changed, or that follows a removed one
+func (a *AuthUseCaseImpl) AdminVerifyAuditLogs(ctx context.Context, req *proto.AdminVerifyAuditLogsRequest) (*proto.AdminVerifyAuditLogsResponse, error) {
+	// Create request
+	request := &dto.AdminVerifyAuditLogsRequest{
+		AccessToken: req.GetAccessToken(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := a.requirePermission(ctx, claims.UserID, permissionAuditRead, "Failed to verify audit logs"); err != nil {
+		return nil, err
+	}
+
+	db := a.DB.WithContext(ctx)
+
+	res := &proto.AdminVerifyAuditLogsResponse{Valid: true}
+	prevHash, afterID := "", uint(0)
+	for {
+		var logs []entity.AuditLog
+		if err := a.AuditLogRepository.FindAllAfterID(db, &logs, afterID, auditVerifyBatch); err != nil {
+			a.Log.Errorf("Failed to read audit logs: %v", err)
+			return nil, status.Error(codes.Internal, "Failed to verify audit logs")
+		}
+
+		for _, log := range logs {
+			if log.PrevHash != prevHash || log.ComputeHash() != log.Hash {
+				res.Valid = false
+				res.BrokenId = int64(log.ID)
+				return res, nil
+			}
+
+			res.Checked++
+			prevHash, afterID = log.Hash, log.ID
+		}
+
+		if len(logs) < auditVerifyBatch {
+			return res, nil
+		}
+	}
+}
+
+// requirePermission checks the current role of the acting user, for admin
+// requests served by this service instead of the user service
+func (a *AuthUseCaseImpl) requirePermission(ctx context.Context, userID uint, permission string, message string) (*proto.GetUserResponse, error) {
+	getUserCtx, cancelGetUser := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetUser()
+
+	actor, err := a.User.Service.GetUserByID(getUserCtx, &proto.GetUserByIDRequest{Id: int64(userID)})
+	if err != nil {
+		return nil, a.userServiceError(err, message)
+	}
+	if actor.GetSuspended() || !slices.Contains(actor.GetPermissions(), permission) {
+		return nil, status.Error(codes.PermissionDenied, "Missing permission "+permission)
+	}
+
+	return actor, nil
+}
+
+// audit appends the entry with tx, within a transaction it is only kept if the
+// transaction commits
+func (a *AuthUseCaseImpl) audit(tx *gorm.DB, log *entity.AuditLog) error {
+	if err := a.AuditLogRepository.Append(tx, log); err != nil {
+		a.Log.Errorf("Failed to append audit log: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// auditEvent appends the entry for an action that already happened, a failure
+// is logged and does not fail the request
+func (a *AuthUseCaseImpl) auditEvent(ctx context.Context, log *entity.AuditLog) {
+	_ = a.audit(a.DB.WithContext(ctx), log)
+}
+
+// auditDiff encodes the changed fields
+func auditDiff(fields map[string]any) string {
+	diff, err := json.Marshal(fields)
+	if err != nil {
+		return "{}"
+	}
+
+	return string(diff)
+}
+
+// auditActor is nil for events without an acting user
+func auditActor(userID int64) *uint {
+	if userID <= 0 {
+		return nil
+	}
+
+	actorID := uint(userID)
+	return &actorID
+}
+
+func auditTargetID(userID int64) string {
+	return strconv.FormatInt(userID, 10)
+}
+
+func toAuditLog(log *entity.AuditLog) *proto.AuditLog {
+	res := &proto.AuditLog{
+		Id:         int64(log.ID),
+		Action:     log.Action,
+		TargetType: log.TargetType,
+		TargetId:   log.TargetID,
+		IpAddress:  log.IPAddress,
+		UserAgent:  log.UserAgent,
+		Diff:       log.Diff,
+		PrevHash:   log.PrevHash,
+		Hash:       log.Hash,
+		CreatedAt:  log.CreatedAt.Unix(),
+	}
+	if log.ActorID != nil {
+		res.ActorId = int64(*log.ActorID)
+	}
+
+	return res
+}
\ No newline at end of file
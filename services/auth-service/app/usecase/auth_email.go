@@ -0,0 +1,322 @@
+package usecase
+
+import (
+	"auth-service/app/helper"
+	"auth-service/app/model/dto"
+	"auth-service/app/model/entity"
+	"auth-service/app/model/proto"
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+const (
+	EmailVerificationOptional = "optional"
+	EmailVerificationRequired = "required"
+)
+
+func (a *AuthUseCaseImpl) ResendVerificationEmail(ctx context.Context, req *proto.ResendVerificationEmailRequest) (*proto.EmailSentResponse, error) {
+	// Create request
+	request := &dto.ResendVerificationEmailRequest{
+		Email: req.GetEmail(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	getUserCtx, cancelGetUser := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetUser()
+
+	// Unknown and verified addresses get the same answer so accounts cannot be probed
+	user, err := a.User.Service.GetUserByEmail(getUserCtx, &proto.GetUserByEmailRequest{Email: req.GetEmail()})
+	if err != nil || user.GetEmailVerified() {
+		return &proto.EmailSentResponse{}, nil
+	}
+
+	if err := a.sendVerificationEmail(ctx, uint(user.GetId()), user.GetName(), user.GetEmail()); err != nil {
+		a.Log.Errorf("Failed to send verification email: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to send verification email")
+	}
+
+	return &proto.EmailSentResponse{}, nil
+}
+
+func (a *AuthUseCaseImpl) VerifyEmail(ctx context.Context, req *proto.VerifyEmailRequest) (*proto.VerifyEmailResponse, error) {
+	// Create request
+	request := &dto.VerifyEmailRequest{
+		Token: req.GetToken(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	tx := a.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	stored, err := a.redeemToken(tx, entity.VerificationPurposeVerifyEmail, req.GetToken())
+	if err != nil {
+		return nil, err
+	}
+
+	verifyCtx, cancelVerify := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelVerify()
+
+	user, err := a.User.Service.VerifyUserEmail(verifyCtx, &proto.VerifyUserEmailRequest{Id: int64(stored.UserID)})
+	if err != nil {
+		a.Log.Errorf("Failed to verify email: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to verify email")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, status.Error(codes.Internal, "Failed to verify email")
+	}
+
+	return &proto.VerifyEmailResponse{
+		Email: user.GetEmail(),
+	}, nil
+}
+
+func (a *AuthUseCaseImpl) RequestPasswordReset(ctx context.Context, req *proto.RequestPasswordResetRequest) (*proto.EmailSentResponse, error) {
+	// Create request
+	request := &dto.RequestPasswordResetRequest{
+		Email: req.GetEmail(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	getUserCtx, cancelGetUser := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetUser()
+
+	// Unknown addresses get the same answer so accounts cannot be probed
+	user, err := a.User.Service.GetUserByEmail(getUserCtx, &proto.GetUserByEmailRequest{Email: req.GetEmail()})
+	if err != nil {
+		return &proto.EmailSentResponse{}, nil
+	}
+
+	token, err := a.createToken(ctx, uint(user.GetId()), entity.VerificationPurposeResetPassword, a.ttl("PASSWORD_RESET_TTL", time.Hour))
+	if err != nil {
+		a.Log.Errorf("Failed to create password reset token: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to send password reset email")
+	}
+
+	if err := a.sendMail(ctx, &helper.Mail{
+		To:      user.GetEmail(),
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nSomeone asked to reset the password of your account. Choose a new password here:\n\n%s\n\nIf it was not you, you can ignore this email.\n",
+			user.GetName(), a.link("/reset-password", token),
+		),
+	}); err != nil {
+		a.Log.Errorf("Failed to send password reset email: %v", err)
+	}
+
+	return &proto.EmailSentResponse{}, nil
+}
+
+func (a *AuthUseCaseImpl) ResetPassword(ctx context.Context, req *proto.ResetPasswordRequest) (*proto.ResetPasswordResponse, error) {
+	// Create request
+	request := &dto.ResetPasswordRequest{
+		Token:    req.GetToken(),
+		Password: req.GetPassword(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	tx := a.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	stored, err := a.redeemToken(tx, entity.VerificationPurposeResetPassword, req.GetToken())
+	if err != nil {
+		return nil, err
+	}
+
+	getUserCtx, cancelGetUser := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetUser()
+
+	user, err := a.User.Service.GetUserByID(getUserCtx, &proto.GetUserByIDRequest{Id: int64(stored.UserID)})
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "User not found")
+	}
+
+	updateUserCtx, cancelUpdateUser := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelUpdateUser()
+
+	password := req.GetPassword()
+	if _, err := a.User.Service.UpdateUser(updateUserCtx, &proto.UpdateUserRequest{
+		Id:       user.GetId(),
+		Name:     user.GetName(),
+		Email:    user.GetEmail(),
+		ImageUrl: user.ImageUrl,
+		Password: &password,
+	}); err != nil {
+		// The password policy of the user service rejected the password
+		if status.Code(err) == codes.InvalidArgument {
+			return nil, err
+		}
+
+		a.Log.Errorf("Failed to update password: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to reset password")
+	}
+
+	// Opening the reset link proves the address belongs to the user
+	if !user.GetEmailVerified() {
+		if _, err := a.User.Service.VerifyUserEmail(ctx, &proto.VerifyUserEmailRequest{Id: user.GetId()}); err != nil {
+			a.Log.Errorf("Failed to verify email: %v", err)
+		}
+	}
+
+	// Whoever knew the old password is signed out everywhere
+	if err := a.SessionRepository.RevokeAllByUserID(tx, int(stored.UserID)); err != nil {
+		a.Log.Errorf("Failed to revoke sessions: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to reset password")
+	}
+
+	if err := a.RefreshTokenRepository.RevokeAllByUserID(tx, int(stored.UserID)); err != nil {
+		a.Log.Errorf("Failed to revoke refresh tokens: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to reset password")
+	}
+
+	diff := map[string]any{"password": auditChange{Old: auditRedacted, New: auditRedacted}}
+	if !user.GetEmailVerified() {
+		diff["email_verified"] = auditChange{Old: false, New: true}
+	}
+	if err := a.audit(tx, &entity.AuditLog{
+		Action:     entity.AuditActionPasswordReset,
+		ActorID:    auditActor(user.GetId()),
+		TargetType: entity.AuditTargetUser,
+		TargetID:   auditTargetID(user.GetId()),
+		Diff:       auditDiff(diff),
+	}); err != nil {
+		return nil, status.Error(codes.Internal, "Failed to reset password")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, status.Error(codes.Internal, "Failed to reset password")
+	}
+
+	return &proto.ResetPasswordResponse{}, nil
+}
+
+// emailVerificationRequired reports whether EMAIL_VERIFICATION keeps unverified accounts from signing in
+func (a *AuthUseCaseImpl) emailVerificationRequired() bool {
+	return strings.EqualFold(a.Config.GetString("EMAIL_VERIFICATION"), EmailVerificationRequired)
+}
+
+func (a *AuthUseCaseImpl) sendVerificationEmail(ctx context.Context, userID uint, name, email string) error {
+	token, err := a.createToken(ctx, userID, entity.VerificationPurposeVerifyEmail, a.ttl("EMAIL_VERIFICATION_TTL", 24 * time.Hour))
+	if err != nil {
+		return err
+	}
+
+	return a.sendMail(ctx, &helper.Mail{
+		To:      email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nPlease confirm your email address by opening this link:\n\n%s\n\nIf you did not create an account, you can ignore this email.\n",
+			name, a.link("/verify-email", token),
+		),
+	})
+}
+
+// createToken stores a new single use token of the purpose and invalidates
+// the previous ones, only the hash of the returned token is kept.
+func (a *AuthUseCaseImpl) createToken(ctx context.Context, userID uint, purpose string, ttl time.Duration) (string, error) {
+	return a.storeToken(ctx, &entity.VerificationToken{
+		UserID:  userID,
+		Purpose: purpose,
+	}, ttl)
+}
+
+// storeToken is createToken for tokens that carry more than the user and purpose
+func (a *AuthUseCaseImpl) storeToken(ctx context.Context, stored *entity.VerificationToken, ttl time.Duration) (string, error) {
+	token, err := helper.RandomToken(32)
+	if err != nil {
+		return "", err
+	}
+
+	tx := a.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := a.VerificationTokenRepository.ConsumeAllByUserID(tx, int(stored.UserID), stored.Purpose); err != nil {
+		return "", err
+	}
+
+	stored.TokenHash = helper.HashToken(token)
+	stored.ExpiresAt = time.Now().Add(ttl)
+	if err := a.VerificationTokenRepository.Create(tx, stored); err != nil {
+		return "", err
+	}
+
+	return token, tx.Commit().Error
+}
+
+// redeemToken marks a valid token of the purpose as used within the transaction
+func (a *AuthUseCaseImpl) redeemToken(tx *gorm.DB, purpose string, token string) (*entity.VerificationToken, error) {
+	stored := new(entity.VerificationToken)
+	if err := a.VerificationTokenRepository.FindByTokenHashForUpdate(tx, stored, purpose, helper.HashToken(token)); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, status.Error(codes.InvalidArgument, "Invalid or expired token")
+		}
+
+		a.Log.Errorf("Failed to get verification token: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to verify token")
+	}
+
+	now := time.Now()
+	if stored.UsedAt != nil || now.After(stored.ExpiresAt) {
+		return nil, status.Error(codes.InvalidArgument, "Invalid or expired token")
+	}
+
+	stored.UsedAt = &now
+	if err := a.VerificationTokenRepository.Update(tx, stored); err != nil {
+		a.Log.Errorf("Failed to update verification token: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to verify token")
+	}
+
+	return stored, nil
+}
+
+func (a *AuthUseCaseImpl) sendMail(ctx context.Context, mail *helper.Mail) error {
+	sendCtx, cancelSend := context.WithTimeout(ctx, 10 * time.Second)
+	defer cancelSend()
+
+	return a.Mailer.Send(sendCtx, mail)
+}
+
+// link builds the frontend URL of APP_URL that redeems the token
+func (a *AuthUseCaseImpl) link(path string, token string) string {
+	appURL := strings.TrimRight(a.Config.GetString("APP_URL"), "/")
+	if appURL == "" {
+		appURL = "http://localhost:3000"
+	}
+
+	return appURL + path + "?token=" + url.QueryEscape(token)
+}
+
+func (a *AuthUseCaseImpl) ttl(key string, fallback time.Duration) time.Duration {
+	if ttl := a.Config.GetDuration(key); ttl > 0 {
+		return ttl
+	}
+
+	return fallback
+}
\ No newline at end of file
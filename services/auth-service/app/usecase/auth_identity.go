@@ -0,0 +1,390 @@
+package usecase
+
+import (
+	"auth-service/app/helper"
+	"auth-service/app/model/dto"
+	"auth-service/app/model/entity"
+	"auth-service/app/model/proto"
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func (a *AuthUseCaseImpl) LoginWithProvider(ctx context.Context, req *proto.LoginWithProviderRequest) (*proto.AuthenticatedResponse, error) {
+	// Create request
+	request := &dto.LoginWithProviderRequest{
+		Provider: req.GetProvider(),
+		IdToken:  req.GetIdToken(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Verify id token
+	credential, err := a.verifyIdToken(ctx, req.GetProvider(), req.GetIdToken())
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := a.providerUser(ctx, credential)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unverified accounts cannot sign in when verification is required
+	if !user.GetEmailVerified() && a.emailVerificationRequired() {
+		return nil, status.Error(codes.FailedPrecondition, "Email address is not verified")
+	}
+
+	// The provider only replaces the password, a confirmed second factor is still required
+	challenge, err := a.twoFactorChallenge(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if challenge != nil {
+		return challenge, nil
+	}
+
+	// Create JWT
+	token, err := a.login(ctx, user, req.GetClient())
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.AuthenticatedResponse{
+		Name:          user.GetName(),
+		Email:         user.GetEmail(),
+		ImageUrl:      user.GetImageUrl(),
+		Token:         token,
+		EmailVerified: user.GetEmailVerified(),
+	}, nil
+}
+
+func (a *AuthUseCaseImpl) ListIdentities(ctx context.Context, req *proto.ListIdentitiesRequest) (*proto.ListIdentitiesResponse, error) {
+	// Create request
+	request := &dto.ListIdentitiesRequest{
+		AccessToken: req.GetAccessToken(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	identities := new([]entity.UserIdentity)
+	if err := a.UserIdentityRepository.FindAllByUserID(a.DB.WithContext(ctx), identities, int(claims.UserID)); err != nil {
+		a.Log.Errorf("Failed to get identities: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to get identities")
+	}
+
+	res := &proto.ListIdentitiesResponse{
+		Identities: make([]*proto.Identity, 0),
+		Providers:  a.Oauth.Providers(),
+	}
+
+	for _, identity := range *identities {
+		res.Identities = append(res.Identities, toIdentity(&identity))
+	}
+
+	return res, nil
+}
+
+func (a *AuthUseCaseImpl) LinkIdentity(ctx context.Context, req *proto.LinkIdentityRequest) (*proto.Identity, error) {
+	// Create request
+	request := &dto.LinkIdentityRequest{
+		AccessToken: req.GetAccessToken(),
+		Provider:    req.GetProvider(),
+		IdToken:     req.GetIdToken(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify id token
+	credential, err := a.verifyIdToken(ctx, req.GetProvider(), req.GetIdToken())
+	if err != nil {
+		return nil, err
+	}
+
+	tx := a.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	existing := new(entity.UserIdentity)
+	err = a.UserIdentityRepository.FindByProviderAndSubject(tx, existing, credential.Provider, credential.Subject)
+	if err == nil {
+		if existing.UserID == claims.UserID {
+			return nil, status.Error(codes.AlreadyExists, "The account is already linked")
+		}
+
+		return nil, status.Error(codes.AlreadyExists, "The account is linked to another user")
+	}
+	if err != gorm.ErrRecordNotFound {
+		a.Log.Errorf("Failed to get identity: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to link account")
+	}
+
+	err = a.UserIdentityRepository.FindByUserIDAndProvider(tx, existing, int(claims.UserID), credential.Provider)
+	if err == nil {
+		return nil, status.Error(codes.AlreadyExists, "Another account of the provider is already linked")
+	}
+	if err != gorm.ErrRecordNotFound {
+		a.Log.Errorf("Failed to get identity: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to link account")
+	}
+
+	identity := &entity.UserIdentity{
+		UserID:   claims.UserID,
+		Provider: credential.Provider,
+		Subject:  credential.Subject,
+		Email:    credential.Email,
+	}
+	if err := a.UserIdentityRepository.Create(tx, identity); err != nil {
+		a.Log.Errorf("Failed to create identity: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to link account")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, status.Error(codes.Internal, "Failed to link account")
+	}
+
+	return toIdentity(identity), nil
+}
+
+func (a *AuthUseCaseImpl) UnlinkIdentity(ctx context.Context, req *proto.UnlinkIdentityRequest) (*proto.UnlinkIdentityResponse, error) {
+	// Create request
+	request := &dto.UnlinkIdentityRequest{
+		AccessToken: req.GetAccessToken(),
+		Provider:    req.GetProvider(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	tx := a.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	identities := new([]entity.UserIdentity)
+	if err := a.UserIdentityRepository.FindAllByUserID(tx, identities, int(claims.UserID)); err != nil {
+		a.Log.Errorf("Failed to get identities: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to unlink account")
+	}
+
+	var identity *entity.UserIdentity
+	for i := range *identities {
+		if (*identities)[i].Provider == strings.ToLower(req.GetProvider()) {
+			identity = &(*identities)[i]
+		}
+	}
+	if identity == nil {
+		return nil, status.Error(codes.NotFound, "No account of the provider is linked")
+	}
+
+	getUserCtx, cancelGetUser := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetUser()
+
+	user, err := a.User.Service.GetUserByID(getUserCtx, &proto.GetUserByIDRequest{Id: int64(claims.UserID)})
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "User not found")
+	}
+
+	// The user has to keep a way to sign in
+	if !hasPassword(user) && len(*identities) == 1 {
+		return nil, status.Error(codes.FailedPrecondition, "Set a password before unlinking the last linked account")
+	}
+
+	if err := a.UserIdentityRepository.Delete(tx, identity); err != nil {
+		a.Log.Errorf("Failed to delete identity: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to unlink account")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, status.Error(codes.Internal, "Failed to unlink account")
+	}
+
+	return &proto.UnlinkIdentityResponse{}, nil
+}
+
+func (a *AuthUseCaseImpl) verifyIdToken(ctx context.Context, provider string, idToken string) (*helper.AuthCredential, error) {
+	verifyCtx, cancelVerify := context.WithTimeout(ctx, 5 * time.Second)
+	defer cancelVerify()
+
+	credential, err := a.Oauth.VerifyIdToken(verifyCtx, provider, idToken)
+	if err != nil {
+		if err == helper.ErrUnknownProvider {
+			return nil, status.Error(codes.NotFound, "Unknown login provider")
+		}
+
+		return nil, status.Error(codes.InvalidArgument, "Invalid id token")
+	}
+
+	return credential, nil
+}
+
+// providerUser returns the user linked to the credential, creating one for a
+// new email address. An existing account is never taken over by its email
+// alone, it has to link the provider first. Accounts created by provider
+// logins before identities were stored have no password and no identity,
+// they are linked on their next login with a verified email.
+func (a *AuthUseCaseImpl) providerUser(ctx context.Context, credential *helper.AuthCredential) (*proto.GetUserResponse, error) {
+	identity := new(entity.UserIdentity)
+	err := a.UserIdentityRepository.FindByProviderAndSubject(a.DB.WithContext(ctx), identity, credential.Provider, credential.Subject)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		a.Log.Errorf("Failed to get identity: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to login")
+	}
+
+	if err == nil {
+		getUserCtx, cancelGetUser := context.WithTimeout(ctx, 2 * time.Second)
+		defer cancelGetUser()
+
+		user, err := a.User.Service.GetUserByID(getUserCtx, &proto.GetUserByIDRequest{Id: int64(identity.UserID)})
+		if err != nil {
+			return nil, status.Error(codes.NotFound, "User not found")
+		}
+
+		// The provider vouches for the address it shares
+		if !user.GetEmailVerified() && credential.EmailVerified && strings.EqualFold(user.GetEmail(), credential.Email) {
+			return a.markEmailVerified(ctx, user)
+		}
+
+		return user, nil
+	}
+
+	if credential.Email == "" {
+		return nil, status.Error(codes.InvalidArgument, "The provider did not share an email address")
+	}
+
+	getUserCtx, cancelGetUser := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetUser()
+
+	user, err := a.User.Service.GetUserByEmail(getUserCtx, &proto.GetUserByEmailRequest{Email: credential.Email})
+	if err == nil {
+		identities := new([]entity.UserIdentity)
+		if err := a.UserIdentityRepository.FindAllByUserID(a.DB.WithContext(ctx), identities, int(user.GetId())); err != nil {
+			a.Log.Errorf("Failed to get identities: %v", err)
+			return nil, status.Error(codes.Internal, "Failed to login")
+		}
+
+		if hasPassword(user) || len(*identities) > 0 || !credential.EmailVerified {
+			return nil, status.Error(codes.FailedPrecondition, "An account with this email already exists, sign in and link the provider from your account")
+		}
+
+		if err := a.createIdentity(ctx, uint(user.GetId()), credential); err != nil {
+			return nil, err
+		}
+
+		if !user.GetEmailVerified() {
+			return a.markEmailVerified(ctx, user)
+		}
+
+		return user, nil
+	}
+
+	// If user does not exist, create user
+	createUserCtx, cancelCreateUser := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelCreateUser()
+
+	name := credential.Name
+	if name == "" {
+		name = strings.Split(credential.Email, "@")[0]
+	}
+
+	user, err = a.User.Service.CreateUser(createUserCtx, &proto.CreateUserRequest{
+		Name:          name,
+		Email:         credential.Email,
+		ImageUrl:      &credential.Picture,
+		EmailVerified: credential.EmailVerified,
+	})
+	if err != nil {
+		if s, ok := status.FromError(err); ok {
+			if s.Code() == codes.InvalidArgument {
+				return nil, status.Error(codes.InvalidArgument, s.Message())
+			}
+			if s.Code() == codes.AlreadyExists {
+				return nil, status.Error(codes.AlreadyExists, s.Message())
+			}
+			return nil, status.Error(codes.Internal, s.Message())
+		}
+
+		return nil, status.Error(codes.Internal, "Failed to create user")
+	}
+
+	if err := a.createIdentity(ctx, uint(user.GetId()), credential); err != nil {
+		return nil, err
+	}
+
+	if !user.GetEmailVerified() {
+		if err := a.sendVerificationEmail(ctx, uint(user.GetId()), user.GetName(), user.GetEmail()); err != nil {
+			a.Log.Errorf("Failed to send verification email: %v", err)
+		}
+	}
+
+	return user, nil
+}
+
+func (a *AuthUseCaseImpl) createIdentity(ctx context.Context, userID uint, credential *helper.AuthCredential) error {
+	if err := a.UserIdentityRepository.Create(a.DB.WithContext(ctx), &entity.UserIdentity{
+		UserID:   userID,
+		Provider: credential.Provider,
+		Subject:  credential.Subject,
+		Email:    credential.Email,
+	}); err != nil {
+		a.Log.Errorf("Failed to create identity: %v", err)
+		return status.Error(codes.Internal, "Failed to login")
+	}
+
+	return nil
+}
+
+func (a *AuthUseCaseImpl) markEmailVerified(ctx context.Context, user *proto.GetUserResponse) (*proto.GetUserResponse, error) {
+	verifyCtx, cancelVerify := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelVerify()
+
+	verified, err := a.User.Service.VerifyUserEmail(verifyCtx, &proto.VerifyUserEmailRequest{Id: user.GetId()})
+	if err != nil {
+		a.Log.Errorf("Failed to verify email: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to verify email")
+	}
+
+	return verified, nil
+}
+
+func toIdentity(identity *entity.UserIdentity) *proto.Identity {
+	return &proto.Identity{
+		Provider:  identity.Provider,
+		Email:     identity.Email,
+		CreatedAt: identity.CreatedAt.Unix(),
+	}
+}
\ No newline at end of file
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"auth-service/app/helper"
+	"auth-service/app/model/entity"
+	"auth-service/app/model/proto"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// expectProviderUser links the credential of the provider to user 1
+func expectProviderUser(mocks *authUseCaseMocks, provider string) {
+    mocks.oauth.On("VerifyIdToken", provider, "id-token").
+        Return(&helper.AuthCredential{Provider: provider, Subject: "subject", Email: "john@example.com", EmailVerified: true}, nil)
+    mocks.identities.On("FindByProviderAndSubject", mock.Anything, mock.Anything, provider, "subject").
+        Run(func(args mock.Arguments) {
+            *args.Get(1).(*entity.UserIdentity) = entity.UserIdentity{UserID: 1, Provider: provider, Subject: "subject"}
+        }).
+        Return(nil)
+    mocks.user.On("GetUserByID", int64(1)).
+        Return(&proto.GetUserResponse{Id: 1, Name: "John", Email: "john@example.com", EmailVerified: true}, nil)
+}
+
+// expectConfirmedTotp gives user 1 a confirmed second factor
+func expectConfirmedTotp(mocks *authUseCaseMocks) {
+    confirmedAt := time.Now()
+    mocks.totpCredentials.On("FindByUserID", mock.Anything, mock.Anything, 1).
+        Run(func(args mock.Arguments) {
+            *args.Get(1).(*entity.TotpCredential) = entity.TotpCredential{ID: 1, UserID: 1, ConfirmedAt: &confirmedAt}
+        }).
+        Return(nil)
+    mocks.jwt.On("GenerateChallengeToken", mock.MatchedBy(func(claims *helper.TokenClaims) bool {
+        return claims.UserID == 1
+    })).Return("challenge", int64(100), nil)
+}
+
+func TestAuthUseCaseImpl_LoginWithProvider_RequiresSecondFactor(t *testing.T) {
+    usecase, mocks := setupAuthUseCase(t)
+    expectProviderUser(mocks, "github")
+    expectConfirmedTotp(mocks)
+
+    res, err := usecase.LoginWithProvider(context.Background(), &proto.LoginWithProviderRequest{
+        Provider: "github",
+        IdToken:  "id-token",
+    })
+
+    assert.NoError(t, err)
+    assert.True(t, res.GetTwoFactorRequired())
+    assert.Equal(t, "challenge", res.GetChallengeToken())
+    assert.Nil(t, res.GetToken())
+    mocks.jwt.AssertNotCalled(t, "GenerateTokens", mock.Anything)
+    mocks.sessions.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+    mocks.assertExpectations(t)
+}
+
+func TestAuthUseCaseImpl_LoginWithGoogle_RequiresSecondFactor(t *testing.T) {
+    usecase, mocks := setupAuthUseCase(t)
+    expectProviderUser(mocks, helper.ProviderGoogle)
+    expectConfirmedTotp(mocks)
+
+    res, err := usecase.LoginWithGoogle(context.Background(), &proto.LoginWithGoogleRequest{IdToken: "id-token"})
+
+    assert.NoError(t, err)
+    assert.True(t, res.GetTwoFactorRequired())
+    assert.Nil(t, res.GetToken())
+    mocks.jwt.AssertNotCalled(t, "GenerateTokens", mock.Anything)
+    mocks.assertExpectations(t)
+}
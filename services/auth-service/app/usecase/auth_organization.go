@@ -0,0 +1,647 @@
+package usecase
+
+import (
+	"auth-service/app/delivery/client"
+	"auth-service/app/helper"
+	"auth-service/app/model/dto"
+	"auth-service/app/model/entity"
+	"auth-service/app/model/proto"
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// Organizations are owned by the user service, which checks the role of the
+// acting user in them. Calls are signed for the acting user with WithUserID.
+
+// SwitchOrganization moves the session into the organization and re-issues its token pair,
+// the access tokens of the session carry the organization until it is switched again
+func (a *AuthUseCaseImpl) SwitchOrganization(ctx context.Context, req *proto.SwitchOrganizationRequest) (*proto.AuthenticatedResponse, error) {
+	// Create request
+	request := &dto.SwitchOrganizationRequest{
+		AccessToken:    req.GetAccessToken(),
+		OrganizationID: req.GetOrganizationId(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, session, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := a.currentUser(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var organizationID *uint
+	if req.GetOrganizationId() > 0 {
+		organization, err := a.membership(ctx, claims.UserID, uint(req.GetOrganizationId()))
+		if err != nil {
+			return nil, a.userServiceError(err, "Failed to switch organization")
+		}
+
+		id := uint(organization.GetId())
+		organizationID = &id
+	}
+
+	tx := a.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	session, err = a.currentSession(tx, session, claims.UserID, req.GetClient())
+	if err != nil {
+		a.Log.Errorf("Failed to create session: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to switch organization")
+	}
+
+	session.OrganizationID = organizationID
+	session.LastSeenAt = time.Now()
+	if err := a.SessionRepository.Update(tx, session); err != nil {
+		a.Log.Errorf("Failed to update session: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to switch organization")
+	}
+
+	token, err := a.issueTokens(ctx, tx, session, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, status.Error(codes.Internal, "Failed to switch organization")
+	}
+
+	return &proto.AuthenticatedResponse{
+		Name:          user.GetName(),
+		Email:         user.GetEmail(),
+		ImageUrl:      user.GetImageUrl(),
+		Token:         token,
+		EmailVerified: user.GetEmailVerified(),
+	}, nil
+}
+
+func (a *AuthUseCaseImpl) CreateOrganization(ctx context.Context, req *proto.CreateOrganizationRequest) (*proto.Organization, error) {
+	// Create request
+	request := &dto.CreateOrganizationRequest{
+		AccessToken: req.GetAccessToken(),
+		Name:        req.GetName(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	createCtx, cancelCreate := context.WithTimeout(client.WithUserID(ctx, int64(claims.UserID)), 2 * time.Second)
+	defer cancelCreate()
+
+	organization, err := a.User.Service.CreateOrganization(createCtx, &proto.CreateUserOrganizationRequest{
+		ActorId: int64(claims.UserID),
+		Name:    req.GetName(),
+	})
+	if err != nil {
+		return nil, a.userServiceError(err, "Failed to create organization")
+	}
+
+	return toOrganization(organization), nil
+}
+
+func (a *AuthUseCaseImpl) ListOrganizations(ctx context.Context, req *proto.ListOrganizationsRequest) (*proto.ListOrganizationsResponse, error) {
+	// Create request
+	request := &dto.ListOrganizationsRequest{
+		AccessToken: req.GetAccessToken(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	listCtx, cancelList := context.WithTimeout(client.WithUserID(ctx, int64(claims.UserID)), 2 * time.Second)
+	defer cancelList()
+
+	organizations, err := a.User.Service.ListOrganizations(listCtx, &proto.ListUserOrganizationsRequest{
+		ActorId: int64(claims.UserID),
+	})
+	if err != nil {
+		return nil, a.userServiceError(err, "Failed to list organizations")
+	}
+
+	res := &proto.ListOrganizationsResponse{
+		Organizations: make([]*proto.Organization, 0, len(organizations.GetOrganizations())),
+	}
+	for _, organization := range organizations.GetOrganizations() {
+		res.Organizations = append(res.Organizations, toOrganization(organization))
+	}
+
+	return res, nil
+}
+
+func (a *AuthUseCaseImpl) GetOrganization(ctx context.Context, req *proto.GetOrganizationRequest) (*proto.Organization, error) {
+	// Create request
+	request := &dto.OrganizationRequest{
+		AccessToken:    req.GetAccessToken(),
+		OrganizationID: req.GetId(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	organization, err := a.membership(ctx, claims.UserID, uint(req.GetId()))
+	if err != nil {
+		return nil, a.userServiceError(err, "Failed to get organization")
+	}
+
+	return toOrganization(organization), nil
+}
+
+// UpdateOrganization changes the settings of the organization, members see new
+// quotas and allowed models with their next prompt
+func (a *AuthUseCaseImpl) UpdateOrganization(ctx context.Context, req *proto.UpdateOrganizationRequest) (*proto.Organization, error) {
+	// Create request
+	request := &dto.OrganizationRequest{
+		AccessToken:    req.GetAccessToken(),
+		OrganizationID: req.GetId(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	updateCtx, cancelUpdate := context.WithTimeout(client.WithUserID(ctx, int64(claims.UserID)), 2 * time.Second)
+	defer cancelUpdate()
+
+	update := &proto.UpdateUserOrganizationRequest{
+		ActorId:                 int64(claims.UserID),
+		Id:                      req.GetId(),
+		Name:                    req.Name,
+		MemberDailyMessageQuota: req.MemberDailyMessageQuota,
+	}
+	if req.AllowedModels != nil {
+		update.AllowedModels = &proto.UserOrganizationModels{Models: req.GetAllowedModels().GetModels()}
+	}
+
+	organization, err := a.User.Service.UpdateOrganization(updateCtx, update)
+	if err != nil {
+		return nil, a.userServiceError(err, "Failed to update organization")
+	}
+
+	return toOrganization(organization), nil
+}
+
+// DeleteOrganization removes the organization together with its workspace, sessions
+// working in it fall back to the personal workspace
+func (a *AuthUseCaseImpl) DeleteOrganization(ctx context.Context, req *proto.DeleteOrganizationRequest) (*proto.DeleteOrganizationResponse, error) {
+	// Create request
+	request := &dto.OrganizationRequest{
+		AccessToken:    req.GetAccessToken(),
+		OrganizationID: req.GetId(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	deleteCtx, cancelDelete := context.WithTimeout(client.WithUserID(ctx, int64(claims.UserID)), 2 * time.Second)
+	defer cancelDelete()
+
+	if _, err := a.User.Service.DeleteOrganization(deleteCtx, &proto.DeleteUserOrganizationRequest{
+		ActorId: int64(claims.UserID),
+		Id:      req.GetId(),
+	}); err != nil {
+		return nil, a.userServiceError(err, "Failed to delete organization")
+	}
+
+	a.auditEvent(ctx, &entity.AuditLog{
+		Action:     entity.AuditActionOrganizationDelete,
+		ActorID:    auditActor(int64(claims.UserID)),
+		TargetType: entity.AuditTargetOrganization,
+		TargetID:   auditTargetID(req.GetId()),
+		IPAddress:  req.GetClient().GetIpAddress(),
+		UserAgent:  req.GetClient().GetUserAgent(),
+	})
+
+	return &proto.DeleteOrganizationResponse{}, nil
+}
+
+func (a *AuthUseCaseImpl) ListOrganizationMembers(ctx context.Context, req *proto.ListOrganizationMembersRequest) (*proto.ListOrganizationMembersResponse, error) {
+	// Create request
+	request := &dto.OrganizationRequest{
+		AccessToken:    req.GetAccessToken(),
+		OrganizationID: req.GetOrganizationId(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	listCtx, cancelList := context.WithTimeout(client.WithUserID(ctx, int64(claims.UserID)), 2 * time.Second)
+	defer cancelList()
+
+	members, err := a.User.Service.ListOrganizationMembers(listCtx, &proto.ListUserOrganizationMembersRequest{
+		ActorId:        int64(claims.UserID),
+		OrganizationId: req.GetOrganizationId(),
+	})
+	if err != nil {
+		return nil, a.userServiceError(err, "Failed to list organization members")
+	}
+
+	res := &proto.ListOrganizationMembersResponse{
+		Members: make([]*proto.OrganizationMember, 0, len(members.GetMembers())),
+	}
+	for _, member := range members.GetMembers() {
+		res.Members = append(res.Members, toOrganizationMember(member))
+	}
+
+	return res, nil
+}
+
+// UpdateOrganizationMember changes the role of the member, access tokens carry
+// the new role from the next refresh
+func (a *AuthUseCaseImpl) UpdateOrganizationMember(ctx context.Context, req *proto.UpdateOrganizationMemberRequest) (*proto.OrganizationMember, error) {
+	// Create request
+	request := &dto.UpdateOrganizationMemberRequest{
+		AccessToken:    req.GetAccessToken(),
+		OrganizationID: req.GetOrganizationId(),
+		UserID:         req.GetUserId(),
+		Role:           req.GetRole(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	updateCtx, cancelUpdate := context.WithTimeout(client.WithUserID(ctx, int64(claims.UserID)), 2 * time.Second)
+	defer cancelUpdate()
+
+	member, err := a.User.Service.UpdateOrganizationMember(updateCtx, &proto.UpdateUserOrganizationMemberRequest{
+		ActorId:        int64(claims.UserID),
+		OrganizationId: req.GetOrganizationId(),
+		UserId:         req.GetUserId(),
+		Role:           req.GetRole(),
+	})
+	if err != nil {
+		return nil, a.userServiceError(err, "Failed to update organization member")
+	}
+
+	a.auditEvent(ctx, &entity.AuditLog{
+		Action:     entity.AuditActionOrganizationMemberRole,
+		ActorID:    auditActor(int64(claims.UserID)),
+		TargetType: entity.AuditTargetOrganization,
+		TargetID:   auditTargetID(req.GetOrganizationId()),
+		IPAddress:  req.GetClient().GetIpAddress(),
+		UserAgent:  req.GetClient().GetUserAgent(),
+		Diff:       auditDiff(map[string]any{"user_id": member.GetUserId(), "role": member.GetRole()}),
+	})
+
+	return toOrganizationMember(member), nil
+}
+
+// RemoveOrganizationMember removes the member, or lets members leave when they
+// remove themselves. Sessions of the member working in the organization fall
+// back to the personal workspace with their next refresh.
+func (a *AuthUseCaseImpl) RemoveOrganizationMember(ctx context.Context, req *proto.RemoveOrganizationMemberRequest) (*proto.DeleteOrganizationResponse, error) {
+	// Create request
+	request := &dto.RemoveOrganizationMemberRequest{
+		AccessToken:    req.GetAccessToken(),
+		OrganizationID: req.GetOrganizationId(),
+		UserID:         req.GetUserId(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	removeCtx, cancelRemove := context.WithTimeout(client.WithUserID(ctx, int64(claims.UserID)), 2 * time.Second)
+	defer cancelRemove()
+
+	if _, err := a.User.Service.RemoveOrganizationMember(removeCtx, &proto.RemoveUserOrganizationMemberRequest{
+		ActorId:        int64(claims.UserID),
+		OrganizationId: req.GetOrganizationId(),
+		UserId:         req.GetUserId(),
+	}); err != nil {
+		return nil, a.userServiceError(err, "Failed to remove organization member")
+	}
+
+	a.auditEvent(ctx, &entity.AuditLog{
+		Action:     entity.AuditActionOrganizationMemberRemove,
+		ActorID:    auditActor(int64(claims.UserID)),
+		TargetType: entity.AuditTargetOrganization,
+		TargetID:   auditTargetID(req.GetOrganizationId()),
+		IPAddress:  req.GetClient().GetIpAddress(),
+		UserAgent:  req.GetClient().GetUserAgent(),
+		Diff:       auditDiff(map[string]any{"user_id": req.GetUserId()}),
+	})
+
+	return &proto.DeleteOrganizationResponse{}, nil
+}
+
+// InviteOrganizationMember emails the invite, the user service only keeps the
+// hash of its token
+func (a *AuthUseCaseImpl) InviteOrganizationMember(ctx context.Context, req *proto.InviteOrganizationMemberRequest) (*proto.OrganizationInvite, error) {
+	// Create request
+	request := &dto.InviteOrganizationMemberRequest{
+		AccessToken:    req.GetAccessToken(),
+		OrganizationID: req.GetOrganizationId(),
+		Email:          req.GetEmail(),
+		Role:           req.GetRole(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := helper.RandomToken(32)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to invite organization member")
+	}
+
+	inviteCtx, cancelInvite := context.WithTimeout(client.WithUserID(ctx, int64(claims.UserID)), 2 * time.Second)
+	defer cancelInvite()
+
+	invite, err := a.User.Service.CreateOrganizationInvite(inviteCtx, &proto.CreateUserOrganizationInviteRequest{
+		ActorId:        int64(claims.UserID),
+		OrganizationId: req.GetOrganizationId(),
+		Email:          req.GetEmail(),
+		Role:           req.GetRole(),
+		TokenHash:      helper.HashToken(token),
+		ExpiresAt:      time.Now().Add(a.ttl("ORGANIZATION_INVITE_TTL", 7 * 24 * time.Hour)).Unix(),
+	})
+	if err != nil {
+		return nil, a.userServiceError(err, "Failed to invite organization member")
+	}
+
+	if err := a.sendMail(ctx, &helper.Mail{
+		To:      invite.GetEmail(),
+		Subject: fmt.Sprintf("You are invited to join %s", invite.GetOrganizationName()),
+		Body: fmt.Sprintf(
+			"Hi,\n\n%s invited you to join %s. Sign in with this email address and open this link to accept the invite:\n\n%s\n\nThe invite expires on %s.\n",
+			claims.Name, invite.GetOrganizationName(), a.link("/accept-invite", token),
+			time.Unix(invite.GetExpiresAt(), 0).UTC().Format("January 2, 2006"),
+		),
+	}); err != nil {
+		a.Log.Errorf("Failed to send organization invite email: %v", err)
+	}
+
+	a.auditEvent(ctx, &entity.AuditLog{
+		Action:     entity.AuditActionOrganizationInvite,
+		ActorID:    auditActor(int64(claims.UserID)),
+		TargetType: entity.AuditTargetOrganization,
+		TargetID:   auditTargetID(req.GetOrganizationId()),
+		IPAddress:  req.GetClient().GetIpAddress(),
+		UserAgent:  req.GetClient().GetUserAgent(),
+		Diff:       auditDiff(map[string]any{"email": invite.GetEmail(), "role": invite.GetRole()}),
+	})
+
+	return toOrganizationInvite(invite), nil
+}
+
+func (a *AuthUseCaseImpl) ListOrganizationInvites(ctx context.Context, req *proto.ListOrganizationInvitesRequest) (*proto.ListOrganizationInvitesResponse, error) {
+	// Create request
+	request := &dto.OrganizationRequest{
+		AccessToken:    req.GetAccessToken(),
+		OrganizationID: req.GetOrganizationId(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	listCtx, cancelList := context.WithTimeout(client.WithUserID(ctx, int64(claims.UserID)), 2 * time.Second)
+	defer cancelList()
+
+	invites, err := a.User.Service.ListOrganizationInvites(listCtx, &proto.ListUserOrganizationInvitesRequest{
+		ActorId:        int64(claims.UserID),
+		OrganizationId: req.GetOrganizationId(),
+	})
+	if err != nil {
+		return nil, a.userServiceError(err, "Failed to list organization invites")
+	}
+
+	res := &proto.ListOrganizationInvitesResponse{
+		Invites: make([]*proto.OrganizationInvite, 0, len(invites.GetInvites())),
+	}
+	for _, invite := range invites.GetInvites() {
+		res.Invites = append(res.Invites, toOrganizationInvite(invite))
+	}
+
+	return res, nil
+}
+
+func (a *AuthUseCaseImpl) RevokeOrganizationInvite(ctx context.Context, req *proto.RevokeOrganizationInviteRequest) (*proto.DeleteOrganizationResponse, error) {
+	// Create request
+	request := &dto.RevokeOrganizationInviteRequest{
+		AccessToken:    req.GetAccessToken(),
+		OrganizationID: req.GetOrganizationId(),
+		ID:             req.GetId(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	revokeCtx, cancelRevoke := context.WithTimeout(client.WithUserID(ctx, int64(claims.UserID)), 2 * time.Second)
+	defer cancelRevoke()
+
+	if _, err := a.User.Service.RevokeOrganizationInvite(revokeCtx, &proto.RevokeUserOrganizationInviteRequest{
+		ActorId:        int64(claims.UserID),
+		OrganizationId: req.GetOrganizationId(),
+		Id:             req.GetId(),
+	}); err != nil {
+		return nil, a.userServiceError(err, "Failed to revoke organization invite")
+	}
+
+	return &proto.DeleteOrganizationResponse{}, nil
+}
+
+// AcceptOrganizationInvite adds the signed in user to the organization of the emailed invite
+func (a *AuthUseCaseImpl) AcceptOrganizationInvite(ctx context.Context, req *proto.AcceptOrganizationInviteRequest) (*proto.Organization, error) {
+	// Create request
+	request := &dto.AcceptOrganizationInviteRequest{
+		AccessToken: req.GetAccessToken(),
+		Token:       req.GetToken(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	acceptCtx, cancelAccept := context.WithTimeout(client.WithUserID(ctx, int64(claims.UserID)), 2 * time.Second)
+	defer cancelAccept()
+
+	organization, err := a.User.Service.AcceptOrganizationInvite(acceptCtx, &proto.AcceptUserOrganizationInviteRequest{
+		ActorId:   int64(claims.UserID),
+		TokenHash: helper.HashToken(req.GetToken()),
+	})
+	if err != nil {
+		return nil, a.userServiceError(err, "Failed to accept organization invite")
+	}
+
+	return toOrganization(organization), nil
+}
+
+// membership returns the organization with the role of the user in it, NotFound
+// when the user is not a member
+func (a *AuthUseCaseImpl) membership(ctx context.Context, userID uint, organizationID uint) (*proto.UserOrganization, error) {
+	getMembershipCtx, cancelGetMembership := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetMembership()
+
+	return a.User.Service.GetOrganizationMembership(getMembershipCtx, &proto.GetUserOrganizationRequest{
+		OrganizationId: int64(organizationID),
+		UserId:         int64(userID),
+	})
+}
+
+// sessionOrganization returns the organization the session works in, nil for the
+// personal workspace. Sessions of members removed since are moved back to it.
+func (a *AuthUseCaseImpl) sessionOrganization(ctx context.Context, tx *gorm.DB, session *entity.Session) (*proto.UserOrganization, error) {
+	if session.OrganizationID == nil {
+		return nil, nil
+	}
+
+	organization, err := a.membership(ctx, session.UserID, *session.OrganizationID)
+	if status.Code(err) == codes.NotFound {
+		session.OrganizationID = nil
+		return nil, a.SessionRepository.Update(tx, session)
+	}
+
+	return organization, err
+}
+
+func toOrganization(organization *proto.UserOrganization) *proto.Organization {
+	return &proto.Organization{
+		Id:                      organization.GetId(),
+		Name:                    organization.GetName(),
+		MemberDailyMessageQuota: organization.MemberDailyMessageQuota,
+		AllowedModels:           organization.GetAllowedModels(),
+		Role:                    organization.GetRole(),
+		CreatedAt:               organization.GetCreatedAt(),
+	}
+}
+
+func toOrganizationMember(member *proto.UserOrganizationMember) *proto.OrganizationMember {
+	return &proto.OrganizationMember{
+		UserId:   member.GetUserId(),
+		Name:     member.GetName(),
+		Email:    member.GetEmail(),
+		ImageUrl: member.GetImageUrl(),
+		Role:     member.GetRole(),
+		JoinedAt: member.GetJoinedAt(),
+	}
+}
+
+func toOrganizationInvite(invite *proto.UserOrganizationInvite) *proto.OrganizationInvite {
+	return &proto.OrganizationInvite{
+		Id:               invite.GetId(),
+		OrganizationId:   invite.GetOrganizationId(),
+		OrganizationName: invite.GetOrganizationName(),
+		Email:            invite.GetEmail(),
+		Role:             invite.GetRole(),
+		ExpiresAt:        invite.GetExpiresAt(),
+		CreatedAt:        invite.GetCreatedAt(),
+	}
+}
\ No newline at end of file
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"auth-service/app/helper"
+	"auth-service/app/model/dto"
+	"auth-service/app/model/proto"
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (a *AuthUseCaseImpl) GetPreferences(ctx context.Context, req *proto.GetPreferencesRequest) (*proto.Preferences, error) {
+	// Create request
+	request := &dto.GetPreferencesRequest{
+		AccessToken: req.GetAccessToken(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	getPreferencesCtx, cancelGetPreferences := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetPreferences()
+
+	preferences, err := a.User.Service.GetPreferences(getPreferencesCtx, &proto.GetUserPreferencesRequest{
+		UserId: int64(claims.UserID),
+	})
+	if err != nil {
+		return nil, a.userServiceError(err, "Failed to get preferences")
+	}
+
+	return toPreferences(preferences), nil
+}
+
+// UpdatePreferences leaves validating the settings to the user service which owns their schema
+func (a *AuthUseCaseImpl) UpdatePreferences(ctx context.Context, req *proto.UpdatePreferencesRequest) (*proto.Preferences, error) {
+	// Create request
+	request := &dto.UpdatePreferencesRequest{
+		AccessToken: req.GetAccessToken(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	updatePreferencesCtx, cancelUpdatePreferences := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelUpdatePreferences()
+
+	preferences, err := a.User.Service.UpdatePreferences(updatePreferencesCtx, &proto.UpdateUserPreferencesRequest{
+		UserId:           int64(claims.UserID),
+		DefaultModel:     req.DefaultModel,
+		ResponseLanguage: req.ResponseLanguage,
+		Theme:            req.Theme,
+		Timezone:         req.Timezone,
+		MemoryEnabled:    req.MemoryEnabled,
+		HistoryEnabled:   req.HistoryEnabled,
+	})
+	if err != nil {
+		return nil, a.userServiceError(err, "Failed to update preferences")
+	}
+
+	return toPreferences(preferences), nil
+}
+
+func toPreferences(preferences *proto.UserPreferences) *proto.Preferences {
+	return &proto.Preferences{
+		DefaultModel:     preferences.GetDefaultModel(),
+		ResponseLanguage: preferences.GetResponseLanguage(),
+		Theme:            preferences.GetTheme(),
+		Timezone:         preferences.GetTimezone(),
+		MemoryEnabled:    preferences.GetMemoryEnabled(),
+		HistoryEnabled:   preferences.GetHistoryEnabled(),
+	}
+}
\ No newline at end of file
@@ -0,0 +1,499 @@
+package usecase
+
+import (
+	"auth-service/app/helper"
+	"auth-service/app/model/dto"
+	"auth-service/app/model/entity"
+	"auth-service/app/model/proto"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func (a *AuthUseCaseImpl) GetMe(ctx context.Context, req *proto.GetMeRequest) (*proto.Me, error) {
+	// Create request
+	request := &dto.GetMeRequest{
+		AccessToken: req.GetAccessToken(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := a.currentUser(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	credential := new(entity.TotpCredential)
+	err = a.TotpCredentialRepository.FindByUserID(a.DB.WithContext(ctx), credential, int(claims.UserID))
+	if err != nil && err != gorm.ErrRecordNotFound {
+		a.Log.Errorf("Failed to get TOTP credential: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to get profile")
+	}
+
+	return &proto.Me{
+		Id:               user.GetId(),
+		Name:             user.GetName(),
+		Email:            user.GetEmail(),
+		ImageUrl:         user.GetImageUrl(),
+		EmailVerified:    user.GetEmailVerified(),
+		HasPassword:      hasPassword(user),
+		TwoFactorEnabled: err == nil && credential.ConfirmedAt != nil,
+		Role:             user.GetRole(),
+		Permissions:      user.GetPermissions(),
+		CreatedAt:        user.GetCreatedAt(),
+		OrganizationId:   int64(claims.OrganizationID),
+		OrganizationRole: claims.OrganizationRole,
+	}, nil
+}
+
+func (a *AuthUseCaseImpl) UpdateMe(ctx context.Context, req *proto.UpdateMeRequest) (*proto.AuthenticatedResponse, error) {
+	// Create request
+	request := &dto.UpdateMeRequest{
+		AccessToken: req.GetAccessToken(),
+		Name:        req.Name,
+		ImageURL:    req.ImageUrl,
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	if req.Name == nil && req.ImageUrl == nil {
+		return nil, status.Error(codes.InvalidArgument, "Nothing to update")
+	}
+
+	// Validate JWT and its session
+	claims, session, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := a.currentUser(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	name := user.GetName()
+	if req.Name != nil {
+		name = strings.TrimSpace(req.GetName())
+		if name == "" {
+			return nil, status.Error(codes.InvalidArgument, "Name is required")
+		}
+	}
+
+	imageUrl := user.ImageUrl
+	if req.ImageUrl != nil {
+		imageUrl = req.ImageUrl
+	}
+
+	updateUserCtx, cancelUpdateUser := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelUpdateUser()
+
+	updated, err := a.User.Service.UpdateUser(updateUserCtx, &proto.UpdateUserRequest{
+		Id:       user.GetId(),
+		Name:     name,
+		Email:    user.GetEmail(),
+		ImageUrl: imageUrl,
+	})
+	if err != nil {
+		return nil, a.userServiceError(err, "Failed to update profile")
+	}
+
+	diff := map[string]any{}
+	if updated.GetName() != user.GetName() {
+		diff["name"] = auditChange{Old: user.GetName(), New: updated.GetName()}
+	}
+	if updated.GetImageUrl() != user.GetImageUrl() {
+		diff["image_url"] = auditChange{Old: user.GetImageUrl(), New: updated.GetImageUrl()}
+	}
+
+	// Re-issue the token pair so the claims carry the new profile
+	tx := a.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	session, err = a.currentSession(tx, session, claims.UserID, req.GetClient())
+	if err != nil {
+		a.Log.Errorf("Failed to create session: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to update profile")
+	}
+
+	token, err := a.issueTokens(ctx, tx, session, updated)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.audit(tx, &entity.AuditLog{
+		Action:     entity.AuditActionProfileUpdate,
+		ActorID:    auditActor(user.GetId()),
+		TargetType: entity.AuditTargetUser,
+		TargetID:   auditTargetID(user.GetId()),
+		IPAddress:  req.GetClient().GetIpAddress(),
+		UserAgent:  req.GetClient().GetUserAgent(),
+		Diff:       auditDiff(diff),
+	}); err != nil {
+		return nil, status.Error(codes.Internal, "Failed to update profile")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, status.Error(codes.Internal, "Failed to update profile")
+	}
+
+	return &proto.AuthenticatedResponse{
+		Name:          updated.GetName(),
+		Email:         updated.GetEmail(),
+		ImageUrl:      updated.GetImageUrl(),
+		Token:         token,
+		EmailVerified: updated.GetEmailVerified(),
+	}, nil
+}
+
+func (a *AuthUseCaseImpl) ChangePassword(ctx context.Context, req *proto.ChangePasswordRequest) (*proto.AuthenticatedResponse, error) {
+	// Create request
+	request := &dto.ChangePasswordRequest{
+		AccessToken:     req.GetAccessToken(),
+		CurrentPassword: req.GetCurrentPassword(),
+		NewPassword:     req.GetNewPassword(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, session, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := a.currentUser(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.confirmPassword(ctx, user, req.GetCurrentPassword(), req.GetClient()); err != nil {
+		return nil, err
+	}
+
+	updateUserCtx, cancelUpdateUser := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelUpdateUser()
+
+	password := req.GetNewPassword()
+	if _, err := a.User.Service.UpdateUser(updateUserCtx, &proto.UpdateUserRequest{
+		Id:       user.GetId(),
+		Name:     user.GetName(),
+		Email:    user.GetEmail(),
+		ImageUrl: user.ImageUrl,
+		Password: &password,
+	}); err != nil {
+		return nil, a.userServiceError(err, "Failed to change password")
+	}
+
+	tx := a.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	session, err = a.currentSession(tx, session, claims.UserID, req.GetClient())
+	if err != nil {
+		a.Log.Errorf("Failed to create session: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to change password")
+	}
+
+	// Every other device is signed out, this one keeps a new token pair
+	sessions := new([]entity.Session)
+	if err := a.SessionRepository.FindAllActiveByUserID(tx, sessions, int(claims.UserID)); err != nil {
+		a.Log.Errorf("Failed to get sessions: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to change password")
+	}
+
+	revoked := 0
+	for i := range *sessions {
+		if (*sessions)[i].ID == session.ID {
+			continue
+		}
+
+		if err := a.revokeSession(tx, &(*sessions)[i]); err != nil {
+			a.Log.Errorf("Failed to revoke session: %v", err)
+			return nil, status.Error(codes.Internal, "Failed to change password")
+		}
+		revoked++
+	}
+
+	token, err := a.issueTokens(ctx, tx, session, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.audit(tx, &entity.AuditLog{
+		Action:     entity.AuditActionPasswordChange,
+		ActorID:    auditActor(user.GetId()),
+		TargetType: entity.AuditTargetUser,
+		TargetID:   auditTargetID(user.GetId()),
+		IPAddress:  req.GetClient().GetIpAddress(),
+		UserAgent:  req.GetClient().GetUserAgent(),
+		Diff: auditDiff(map[string]any{
+			"password":         auditChange{Old: auditRedacted, New: auditRedacted},
+			"sessions_revoked": revoked,
+		}),
+	}); err != nil {
+		return nil, status.Error(codes.Internal, "Failed to change password")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, status.Error(codes.Internal, "Failed to change password")
+	}
+
+	if err := a.sendMail(ctx, &helper.Mail{
+		To:      user.GetEmail(),
+		Subject: "Your password was changed",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nThe password of your account was just changed and your other devices were signed out.\n\nIf this was not you, reset your password right away.\n",
+			user.GetName(),
+		),
+	}); err != nil {
+		a.Log.Errorf("Failed to send password change email: %v", err)
+	}
+
+	return &proto.AuthenticatedResponse{
+		Name:          user.GetName(),
+		Email:         user.GetEmail(),
+		ImageUrl:      user.GetImageUrl(),
+		Token:         token,
+		EmailVerified: user.GetEmailVerified(),
+	}, nil
+}
+
+func (a *AuthUseCaseImpl) RequestEmailChange(ctx context.Context, req *proto.RequestEmailChangeRequest) (*proto.EmailSentResponse, error) {
+	// Create request
+	request := &dto.RequestEmailChangeRequest{
+		AccessToken:     req.GetAccessToken(),
+		Email:           strings.TrimSpace(req.GetEmail()),
+		CurrentPassword: req.GetCurrentPassword(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := a.currentUser(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.EqualFold(request.Email, user.GetEmail()) {
+		return nil, status.Error(codes.InvalidArgument, "Email address is unchanged")
+	}
+
+	if err := a.confirmPassword(ctx, user, req.GetCurrentPassword(), req.GetClient()); err != nil {
+		return nil, err
+	}
+
+	getUserCtx, cancelGetUser := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetUser()
+
+	_, err = a.User.Service.GetUserByEmail(getUserCtx, &proto.GetUserByEmailRequest{Email: request.Email})
+	if err == nil {
+		return nil, status.Error(codes.AlreadyExists, "Email already exists")
+	}
+	if status.Code(err) != codes.NotFound {
+		a.Log.Errorf("Failed to get user: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to change email")
+	}
+
+	token, err := a.storeToken(ctx, &entity.VerificationToken{
+		UserID:  uint(user.GetId()),
+		Purpose: entity.VerificationPurposeChangeEmail,
+		Email:   &request.Email,
+	}, a.ttl("EMAIL_CHANGE_TTL", 24 * time.Hour))
+	if err != nil {
+		a.Log.Errorf("Failed to create email change token: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to send verification email")
+	}
+
+	if err := a.sendMail(ctx, &helper.Mail{
+		To:      request.Email,
+		Subject: "Confirm your new email address",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nPlease confirm the new email address of your account by opening this link:\n\n%s\n\nIf you did not ask for this change, you can ignore this email.\n",
+			user.GetName(), a.link("/confirm-email-change", token),
+		),
+	}); err != nil {
+		a.Log.Errorf("Failed to send email change email: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to send verification email")
+	}
+
+	// The current address learns about the change before it happens
+	if err := a.sendMail(ctx, &helper.Mail{
+		To:      user.GetEmail(),
+		Subject: "Your email address is about to change",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nSomeone asked to change the email address of your account to %s. The change takes effect once the new address is confirmed.\n\nIf this was not you, change your password right away.\n",
+			user.GetName(), request.Email,
+		),
+	}); err != nil {
+		a.Log.Errorf("Failed to send email change notice: %v", err)
+	}
+
+	return &proto.EmailSentResponse{}, nil
+}
+
+func (a *AuthUseCaseImpl) ConfirmEmailChange(ctx context.Context, req *proto.ConfirmEmailChangeRequest) (*proto.VerifyEmailResponse, error) {
+	// Create request
+	request := &dto.ConfirmEmailChangeRequest{
+		Token: req.GetToken(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	tx := a.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	stored, err := a.redeemToken(tx, entity.VerificationPurposeChangeEmail, req.GetToken())
+	if err != nil {
+		return nil, err
+	}
+	if stored.Email == nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid or expired token")
+	}
+
+	user, err := a.currentUser(ctx, stored.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	updateUserCtx, cancelUpdateUser := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelUpdateUser()
+
+	// The address may have been taken since the change was requested
+	if _, err := a.User.Service.UpdateUser(updateUserCtx, &proto.UpdateUserRequest{
+		Id:       user.GetId(),
+		Name:     user.GetName(),
+		Email:    *stored.Email,
+		ImageUrl: user.ImageUrl,
+	}); err != nil {
+		if status.Code(err) == codes.AlreadyExists {
+			return nil, err
+		}
+
+		return nil, a.userServiceError(err, "Failed to change email")
+	}
+
+	// Opening the link proves the new address belongs to the user
+	updated, err := a.markEmailVerified(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.audit(tx, &entity.AuditLog{
+		Action:     entity.AuditActionEmailChange,
+		ActorID:    auditActor(user.GetId()),
+		TargetType: entity.AuditTargetUser,
+		TargetID:   auditTargetID(user.GetId()),
+		Diff:       auditDiff(map[string]any{"email": auditChange{Old: user.GetEmail(), New: updated.GetEmail()}}),
+	}); err != nil {
+		return nil, status.Error(codes.Internal, "Failed to change email")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, status.Error(codes.Internal, "Failed to change email")
+	}
+
+	return &proto.VerifyEmailResponse{
+		Email: updated.GetEmail(),
+	}, nil
+}
+
+func (a *AuthUseCaseImpl) currentUser(ctx context.Context, userID uint) (*proto.GetUserResponse, error) {
+	getUserCtx, cancelGetUser := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetUser()
+
+	user, err := a.User.Service.GetUserByID(getUserCtx, &proto.GetUserByIDRequest{Id: int64(userID)})
+	if err != nil {
+		return nil, a.userServiceError(err, "Failed to get user")
+	}
+
+	return user, nil
+}
+
+// confirmPassword asks for the current password before sensitive changes, wrong
+// guesses count towards the login throttle. Accounts without a password skip it.
+func (a *AuthUseCaseImpl) confirmPassword(ctx context.Context, user *proto.GetUserResponse, password string, client *proto.ClientInfo) error {
+	if !hasPassword(user) {
+		return nil
+	}
+
+	if password == "" {
+		return status.Error(codes.InvalidArgument, "Current password is required")
+	}
+
+	subjects := loginSubjects(user.GetEmail(), client.GetIpAddress())
+	if err := a.checkLoginThrottle(ctx, subjects); err != nil {
+		return err
+	}
+
+	_, err := a.verifyCredentials(ctx, user.GetEmail(), password)
+	if err == errInvalidCredentials {
+		if a.recordLoginFailure(ctx, subjects) {
+			a.sendLockoutEmail(ctx, user.GetEmail())
+		}
+		return status.Error(codes.InvalidArgument, "Current password is incorrect")
+	}
+	if err != nil {
+		return err
+	}
+
+	a.clearLoginFailures(ctx, user.GetEmail())
+	return nil
+}
+
+// currentSession returns the session of the access token. Tokens issued before
+// sessions existed start a new one so the re-issued tokens belong to a session.
+func (a *AuthUseCaseImpl) currentSession(tx *gorm.DB, session *entity.Session, userID uint, client *proto.ClientInfo) (*entity.Session, error) {
+	if session != nil {
+		return session, nil
+	}
+
+	familyID, err := helper.RandomToken(16)
+	if err != nil {
+		return nil, err
+	}
+
+	session = &entity.Session{
+		UserID:     userID,
+		FamilyID:   familyID,
+		UserAgent:  client.GetUserAgent(),
+		IPAddress:  client.GetIpAddress(),
+		LastSeenAt: time.Now(),
+	}
+
+	return session, a.SessionRepository.Create(tx, session)
+}
\ No newline at end of file
@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"auth-service/app/helper"
+	"auth-service/app/model/entity"
+	"auth-service/app/model/proto"
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Failed logins of an account or an IP address are counted within the window,
+	// the subject is locked out for the lockout duration once it reaches the maximum
+	defaultLoginMaxAttempts   = 5
+	defaultLoginIPMaxAttempts = 50
+	defaultLoginAttemptWindow = 15 * time.Minute
+	defaultLoginLockout       = 15 * time.Minute
+
+	SecurityEventLoginLockout = "login.lockout"
+	SecurityEventLoginUnlock  = "login.unlock"
+)
+
+// errInvalidCredentials answers unknown emails and wrong passwords alike
+var errInvalidCredentials = status.Error(codes.Unauthenticated, "Invalid email or password")
+
+// loginSubjects returns the throttle subjects of the attempt, the IP address is unknown for some clients
+func loginSubjects(email string, ipAddress string) []string {
+	subjects := []string{entity.ThrottleSubjectAccount + strings.ToLower(strings.TrimSpace(email))}
+	if ipAddress != "" {
+		subjects = append(subjects, entity.ThrottleSubjectIP+ipAddress)
+	}
+
+	return subjects
+}
+
+// checkLoginThrottle refuses the attempt while the account or the IP address waits out a delay or a lockout
+func (a *AuthUseCaseImpl) checkLoginThrottle(ctx context.Context, subjects []string) error {
+	var throttles []entity.LoginThrottle
+	if err := a.LoginThrottleRepository.FindAllBySubjects(a.DB.WithContext(ctx), &throttles, subjects); err != nil {
+		a.Log.Errorf("Failed to get login throttles: %v", err)
+		return status.Error(codes.Internal, "Failed to login")
+	}
+
+	var wait time.Duration
+	for _, throttle := range throttles {
+		if throttle.LockedUntil != nil {
+			wait = max(wait, time.Until(*throttle.LockedUntil))
+		}
+	}
+
+	if wait > 0 {
+		return status.Errorf(codes.ResourceExhausted, "Too many failed attempts, try again in %d seconds", int(math.Ceil(wait.Seconds())))
+	}
+
+	return nil
+}
+
+// recordLoginFailure counts the failure for every subject and delays the next
+// attempt, the first failures are free, then the delay doubles until lockout.
+// It reports whether the account has just been locked out.
+func (a *AuthUseCaseImpl) recordLoginFailure(ctx context.Context, subjects []string) bool {
+	tx := a.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	now := time.Now()
+	window := a.ttl("LOGIN_ATTEMPT_WINDOW", defaultLoginAttemptWindow)
+	lockout := a.ttl("LOGIN_LOCKOUT_DURATION", defaultLoginLockout)
+	accountLocked := false
+
+	for _, subject := range subjects {
+		throttle := new(entity.LoginThrottle)
+		if err := a.LoginThrottleRepository.FindOrCreateForUpdate(tx, throttle, subject); err != nil {
+			a.Log.Errorf("Failed to get login throttle: %v", err)
+			return false
+		}
+
+		maxAttempts := a.maxLoginAttempts(subject)
+
+		// Old failures and served lockouts no longer count
+		expired := throttle.LockedUntil != nil && now.After(*throttle.LockedUntil) && throttle.Failures >= maxAttempts
+		if expired || now.Sub(throttle.LastFailureAt) > window {
+			throttle.Failures = 0
+		}
+
+		throttle.Failures++
+		throttle.LastFailureAt = now
+		throttle.LockedUntil = nil
+		if delay := loginDelay(throttle.Failures, maxAttempts, lockout); delay > 0 {
+			lockedUntil := now.Add(delay)
+			throttle.LockedUntil = &lockedUntil
+		}
+
+		if err := a.LoginThrottleRepository.Update(tx, throttle); err != nil {
+			a.Log.Errorf("Failed to update login throttle: %v", err)
+			return false
+		}
+
+		if throttle.Failures == maxAttempts {
+			a.securityEvent(SecurityEventLoginLockout, logrus.Fields{
+				"subject":      subject,
+				"failures":     throttle.Failures,
+				"locked_until": throttle.LockedUntil.Unix(),
+			})
+			accountLocked = accountLocked || strings.HasPrefix(subject, entity.ThrottleSubjectAccount)
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		a.Log.Errorf("Failed to record login failure: %v", err)
+		return false
+	}
+
+	return accountLocked
+}
+
+// clearLoginFailures forgets the failures of the account after a successful login,
+// the failures of the IP address are kept so one valid account cannot reset them
+func (a *AuthUseCaseImpl) clearLoginFailures(ctx context.Context, email string) {
+	subject := loginSubjects(email, "")[0]
+	if err := a.LoginThrottleRepository.DeleteBySubject(a.DB.WithContext(ctx), subject); err != nil {
+		a.Log.Errorf("Failed to clear login throttle: %v", err)
+	}
+}
+
+func (a *AuthUseCaseImpl) maxLoginAttempts(subject string) int {
+	if strings.HasPrefix(subject, entity.ThrottleSubjectIP) {
+		if attempts := a.Config.GetInt("LOGIN_IP_MAX_ATTEMPTS"); attempts > 0 {
+			return attempts
+		}
+		return defaultLoginIPMaxAttempts
+	}
+
+	if attempts := a.Config.GetInt("LOGIN_MAX_ATTEMPTS"); attempts > 0 {
+		return attempts
+	}
+	return defaultLoginMaxAttempts
+}
+
+// loginDelay is nothing for the first half of the attempts, then doubles from one second, capped by the lockout
+func loginDelay(failures int, maxAttempts int, lockout time.Duration) time.Duration {
+	if failures >= maxAttempts {
+		return lockout
+	}
+
+	free := maxAttempts / 2
+	if failures <= free {
+		return 0
+	}
+
+	shift := min(failures-free-1, 30)
+	return min(time.Second<<shift, lockout)
+}
+
+// sendLockoutEmail tells the owner the account is locked, nobody else learns the account exists
+func (a *AuthUseCaseImpl) sendLockoutEmail(ctx context.Context, email string) {
+	getUserCtx, cancelGetUser := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetUser()
+
+	user, err := a.User.Service.GetUserByEmail(getUserCtx, &proto.GetUserByEmailRequest{Email: email})
+	if err != nil {
+		return
+	}
+
+	lockout := a.ttl("LOGIN_LOCKOUT_DURATION", defaultLoginLockout)
+
+	if err := a.sendMail(ctx, &helper.Mail{
+		To:      user.GetEmail(),
+		Subject: "Your account was temporarily locked",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nWe locked your account for %s after several failed sign in attempts.\n\nIf this was not you, reset your password once the lock expires.\n",
+			user.GetName(), lockout,
+		),
+	}); err != nil {
+		a.Log.Errorf("Failed to send lockout email: %v", err)
+	}
+}
+
+// securityEvent emits a structured log entry that log pipelines alert on
+func (a *AuthUseCaseImpl) securityEvent(event string, fields logrus.Fields) {
+	fields["security_event"] = event
+	a.Log.WithFields(fields).Warn("Security event")
+}
\ No newline at end of file
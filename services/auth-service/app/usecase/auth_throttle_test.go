@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"auth-service/app/model/entity"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLoginDelay_Escalates(t *testing.T) {
+    lockout := 15 * time.Minute
+
+    assert.Equal(t, time.Duration(0), loginDelay(1, 5, lockout))
+    assert.Equal(t, time.Duration(0), loginDelay(2, 5, lockout))
+    assert.Equal(t, time.Second, loginDelay(3, 5, lockout))
+    assert.Equal(t, 2 * time.Second, loginDelay(4, 5, lockout))
+    assert.Equal(t, lockout, loginDelay(5, 5, lockout))
+    assert.Equal(t, lockout, loginDelay(8, 5, lockout))
+
+    // Delays never exceed the lockout
+    assert.Equal(t, lockout, loginDelay(49, 50, lockout))
+}
+
+// expectLoginThrottle loads the throttle of the subject and captures its update
+func expectLoginThrottle(mocks *authUseCaseMocks, throttle entity.LoginThrottle) *entity.LoginThrottle {
+    updated := new(entity.LoginThrottle)
+
+    mocks.loginThrottles.On("FindOrCreateForUpdate", mock.Anything, mock.Anything, throttle.Subject).
+        Run(func(args mock.Arguments) {
+            *args.Get(1).(*entity.LoginThrottle) = throttle
+        }).
+        Return(nil)
+    mocks.loginThrottles.On("Update", mock.Anything, mock.MatchedBy(func(t *entity.LoginThrottle) bool {
+        return t.Subject == throttle.Subject
+    })).
+        Run(func(args mock.Arguments) {
+            *updated = *args.Get(1).(*entity.LoginThrottle)
+        }).
+        Return(nil)
+
+    return updated
+}
+
+func TestAuthUseCaseImpl_RecordLoginFailure_LocksAccount(t *testing.T) {
+    usecase, mocks := setupAuthUseCase(t)
+
+    lockedUntil := time.Now().Add(2 * time.Second)
+    account := expectLoginThrottle(mocks, entity.LoginThrottle{
+        Subject:       "account:john@example.com",
+        Failures:      defaultLoginMaxAttempts - 1,
+        LockedUntil:   &lockedUntil,
+        LastFailureAt: time.Now().Add(-time.Second),
+    })
+    ip := expectLoginThrottle(mocks, entity.LoginThrottle{
+        Subject:       "ip:127.0.0.1",
+        Failures:      3,
+        LastFailureAt: time.Now().Add(-time.Second),
+    })
+
+    mocks.db.ExpectBegin()
+    mocks.db.ExpectCommit()
+
+    locked := usecase.recordLoginFailure(context.Background(), loginSubjects("John@Example.com", "127.0.0.1"))
+
+    assert.True(t, locked)
+    assert.Equal(t, defaultLoginMaxAttempts, account.Failures)
+    assert.WithinDuration(t, time.Now().Add(defaultLoginLockout), *account.LockedUntil, time.Second)
+
+    // The IP address allows more attempts, it is only delayed
+    assert.Equal(t, 4, ip.Failures)
+    assert.Nil(t, ip.LockedUntil)
+    mocks.assertExpectations(t)
+}
+
+func TestAuthUseCaseImpl_RecordLoginFailure_ResetsServedLockout(t *testing.T) {
+    usecase, mocks := setupAuthUseCase(t)
+    // The failures are still within the window, only the lockout was served
+    usecase.Config.Set("LOGIN_ATTEMPT_WINDOW", time.Hour)
+
+    lockedUntil := time.Now().Add(-time.Minute)
+    account := expectLoginThrottle(mocks, entity.LoginThrottle{
+        Subject:       "account:john@example.com",
+        Failures:      defaultLoginMaxAttempts,
+        LockedUntil:   &lockedUntil,
+        LastFailureAt: time.Now().Add(-defaultLoginLockout),
+    })
+
+    mocks.db.ExpectBegin()
+    mocks.db.ExpectCommit()
+
+    locked := usecase.recordLoginFailure(context.Background(), loginSubjects("john@example.com", ""))
+
+    assert.False(t, locked)
+    assert.Equal(t, 1, account.Failures)
+    assert.Nil(t, account.LockedUntil)
+    mocks.assertExpectations(t)
+}
+
+func TestAuthUseCaseImpl_CheckLoginThrottle_RefusesLockedSubject(t *testing.T) {
+    usecase, mocks := setupAuthUseCase(t)
+
+    lockedUntil := time.Now().Add(time.Minute)
+    subjects := loginSubjects("john@example.com", "127.0.0.1")
+    mocks.loginThrottles.On("FindAllBySubjects", mock.Anything, mock.Anything, subjects).
+        Run(func(args mock.Arguments) {
+            *args.Get(1).(*[]entity.LoginThrottle) = []entity.LoginThrottle{
+                {Subject: subjects[0], Failures: 1},
+                {Subject: subjects[1], Failures: 60, LockedUntil: &lockedUntil},
+            }
+        }).
+        Return(nil)
+
+    err := usecase.checkLoginThrottle(context.Background(), subjects)
+
+    assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+    mocks.assertExpectations(t)
+}
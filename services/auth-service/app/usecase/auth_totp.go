@@ -0,0 +1,421 @@
+package usecase
+
+import (
+	"auth-service/app/helper"
+	"auth-service/app/model/dto"
+	"auth-service/app/model/entity"
+	"auth-service/app/model/proto"
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+const (
+	// totpMaxAttempts invalid codes in a row lock the second factor for totpLockDuration
+	totpMaxAttempts  = 5
+	totpLockDuration = 5 * time.Minute
+	// recoveryCodeCount is how many recovery codes are issued when TOTP is confirmed
+	recoveryCodeCount = 10
+)
+
+func (a *AuthUseCaseImpl) EnrollTotp(ctx context.Context, req *proto.EnrollTotpRequest) (*proto.EnrollTotpResponse, error) {
+	// Create request
+	request := &dto.EnrollTotpRequest{
+		AccessToken: req.GetAccessToken(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	encryptionKey := a.Config.GetString("TOTP_ENCRYPTION_KEY")
+	if encryptionKey == "" {
+		return nil, status.Error(codes.FailedPrecondition, "Two-factor authentication is not configured")
+	}
+
+	tx := a.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	credential := new(entity.TotpCredential)
+	err = a.TotpCredentialRepository.FindByUserIDForUpdate(tx, credential, int(claims.UserID))
+	if err != nil && err != gorm.ErrRecordNotFound {
+		a.Log.Errorf("Failed to get totp credential: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to enroll two-factor authentication")
+	}
+
+	if err == nil && credential.ConfirmedAt != nil {
+		return nil, status.Error(codes.AlreadyExists, "Two-factor authentication is already enabled")
+	}
+
+	secret, err := helper.GenerateTotpSecret()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to enroll two-factor authentication")
+	}
+
+	sealed, err := helper.EncryptSecret(encryptionKey, secret)
+	if err != nil {
+		a.Log.Errorf("Failed to encrypt totp secret: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to enroll two-factor authentication")
+	}
+
+	// Enrolling again replaces a secret that was never confirmed
+	credential.UserID = claims.UserID
+	credential.Secret = sealed
+	credential.LastUsedStep = 0
+	credential.FailedAttempts = 0
+	credential.LockedUntil = nil
+
+	if credential.ID == 0 {
+		err = a.TotpCredentialRepository.Create(tx, credential)
+	} else {
+		err = a.TotpCredentialRepository.Update(tx, credential)
+	}
+	if err != nil {
+		a.Log.Errorf("Failed to save totp credential: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to enroll two-factor authentication")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, status.Error(codes.Internal, "Failed to enroll two-factor authentication")
+	}
+
+	issuer := a.Config.GetString("TOTP_ISSUER")
+	if issuer == "" {
+		issuer = a.Config.GetString("APP_NAME")
+	}
+
+	return &proto.EnrollTotpResponse{
+		Secret: secret,
+		Uri:    helper.TotpURI(issuer, claims.Email, secret),
+	}, nil
+}
+
+func (a *AuthUseCaseImpl) ConfirmTotp(ctx context.Context, req *proto.ConfirmTotpRequest) (*proto.ConfirmTotpResponse, error) {
+	// Create request
+	request := &dto.TotpCodeRequest{
+		AccessToken: req.GetAccessToken(),
+		Code:        req.GetCode(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	tx := a.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	credential := new(entity.TotpCredential)
+	if err := a.TotpCredentialRepository.FindByUserIDForUpdate(tx, credential, int(claims.UserID)); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, status.Error(codes.FailedPrecondition, "Two-factor authentication is not enrolled")
+		}
+
+		a.Log.Errorf("Failed to get totp credential: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to confirm two-factor authentication")
+	}
+
+	if credential.ConfirmedAt != nil {
+		return nil, status.Error(codes.AlreadyExists, "Two-factor authentication is already enabled")
+	}
+
+	// Recovery codes do not exist before the authenticator proved it works
+	if err := a.verifySecondFactor(tx, credential, req.GetCode(), false); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	credential.ConfirmedAt = &now
+	if err := a.TotpCredentialRepository.Update(tx, credential); err != nil {
+		a.Log.Errorf("Failed to confirm totp credential: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to confirm two-factor authentication")
+	}
+
+	recoveryCodes, err := a.replaceRecoveryCodes(tx, claims.UserID)
+	if err != nil {
+		a.Log.Errorf("Failed to create recovery codes: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to confirm two-factor authentication")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, status.Error(codes.Internal, "Failed to confirm two-factor authentication")
+	}
+
+	return &proto.ConfirmTotpResponse{
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+func (a *AuthUseCaseImpl) DisableTotp(ctx context.Context, req *proto.DisableTotpRequest) (*proto.DisableTotpResponse, error) {
+	// Create request
+	request := &dto.TotpCodeRequest{
+		AccessToken: req.GetAccessToken(),
+		Code:        req.GetCode(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	tx := a.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	credential := new(entity.TotpCredential)
+	if err := a.TotpCredentialRepository.FindByUserIDForUpdate(tx, credential, int(claims.UserID)); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, status.Error(codes.FailedPrecondition, "Two-factor authentication is not enabled")
+		}
+
+		a.Log.Errorf("Failed to get totp credential: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to disable two-factor authentication")
+	}
+
+	// An enrollment that was never confirmed can be dropped without a code
+	if credential.ConfirmedAt != nil {
+		if err := a.verifySecondFactor(tx, credential, req.GetCode(), true); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := a.TotpCredentialRepository.DeleteByUserID(tx, int(claims.UserID)); err != nil {
+		a.Log.Errorf("Failed to delete totp credential: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to disable two-factor authentication")
+	}
+
+	if err := a.RecoveryCodeRepository.DeleteAllByUserID(tx, int(claims.UserID)); err != nil {
+		a.Log.Errorf("Failed to delete recovery codes: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to disable two-factor authentication")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, status.Error(codes.Internal, "Failed to disable two-factor authentication")
+	}
+
+	return &proto.DisableTotpResponse{}, nil
+}
+
+func (a *AuthUseCaseImpl) CompleteTwoFactorLogin(ctx context.Context, req *proto.CompleteTwoFactorLoginRequest) (*proto.AuthenticatedResponse, error) {
+	// Create request
+	request := &dto.CompleteTwoFactorLoginRequest{
+		ChallengeToken: req.GetChallengeToken(),
+		Code:           req.GetCode(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// The challenge proves the password was checked
+	claims, err := a.Jwt.ValidateChallengeToken(req.GetChallengeToken())
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "Invalid challenge token")
+	}
+
+	tx := a.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	credential := new(entity.TotpCredential)
+	if err := a.TotpCredentialRepository.FindByUserIDForUpdate(tx, credential, int(claims.UserID)); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, status.Error(codes.Unauthenticated, "Invalid challenge token")
+		}
+
+		a.Log.Errorf("Failed to get totp credential: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to verify code")
+	}
+
+	if credential.ConfirmedAt == nil {
+		return nil, status.Error(codes.Unauthenticated, "Invalid challenge token")
+	}
+
+	if err := a.verifySecondFactor(tx, credential, req.GetCode(), true); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, status.Error(codes.Internal, "Failed to verify code")
+	}
+
+	getUserCtx, cancelGetUser := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetUser()
+
+	user, err := a.User.Service.GetUserByID(getUserCtx, &proto.GetUserByIDRequest{Id: int64(claims.UserID)})
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "User not found")
+	}
+
+	// Create JWT
+	token, err := a.login(ctx, user, req.GetClient())
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.AuthenticatedResponse{
+		Name:          user.GetName(),
+		Email:         user.GetEmail(),
+		ImageUrl:      user.GetImageUrl(),
+		Token:         token,
+		EmailVerified: user.GetEmailVerified(),
+	}, nil
+}
+
+// twoFactorChallenge returns the challenge answered instead of tokens when the
+// user confirmed a second factor, nil when the password is enough.
+func (a *AuthUseCaseImpl) twoFactorChallenge(ctx context.Context, user *proto.GetUserResponse) (*proto.AuthenticatedResponse, error) {
+	credential := new(entity.TotpCredential)
+	if err := a.TotpCredentialRepository.FindByUserID(a.DB.WithContext(ctx), credential, int(user.GetId())); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		a.Log.Errorf("Failed to get totp credential: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to login")
+	}
+
+	if credential.ConfirmedAt == nil {
+		return nil, nil
+	}
+
+	challengeToken, expiredAt, err := a.Jwt.GenerateChallengeToken(&helper.TokenClaims{
+		UserID:   uint(user.GetId()),
+		Name:     user.GetName(),
+		Email:    user.GetEmail(),
+		ImageURL: user.GetImageUrl(),
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to generate tokens")
+	}
+
+	return &proto.AuthenticatedResponse{
+		Name:               user.GetName(),
+		Email:              user.GetEmail(),
+		ImageUrl:           user.GetImageUrl(),
+		EmailVerified:      user.GetEmailVerified(),
+		TwoFactorRequired:  true,
+		ChallengeToken:     challengeToken,
+		ChallengeExpiredAt: expiredAt,
+	}, nil
+}
+
+// verifySecondFactor accepts a TOTP code, or an unused recovery code when
+// allowed. Failures are counted on the credential and committed with the
+// transaction, too many in a row lock the credential for a while.
+func (a *AuthUseCaseImpl) verifySecondFactor(tx *gorm.DB, credential *entity.TotpCredential, code string, allowRecovery bool) error {
+	now := time.Now()
+	if credential.LockedUntil != nil && now.Before(*credential.LockedUntil) {
+		return status.Error(codes.ResourceExhausted, "Too many invalid codes, try again later")
+	}
+
+	secret, err := helper.DecryptSecret(a.Config.GetString("TOTP_ENCRYPTION_KEY"), credential.Secret)
+	if err != nil {
+		a.Log.Errorf("Failed to decrypt totp secret: %v", err)
+		return status.Error(codes.Internal, "Failed to verify code")
+	}
+
+	verified := false
+	if step, ok := helper.VerifyTotp(secret, strings.TrimSpace(code), now, credential.LastUsedStep); ok {
+		credential.LastUsedStep = step
+		verified = true
+	} else if allowRecovery {
+		recoveryCode := new(entity.RecoveryCode)
+		err := a.RecoveryCodeRepository.FindUnusedForUpdate(tx, recoveryCode, int(credential.UserID), helper.HashToken(helper.NormalizeRecoveryCode(code)))
+		if err != nil && err != gorm.ErrRecordNotFound {
+			a.Log.Errorf("Failed to get recovery code: %v", err)
+			return status.Error(codes.Internal, "Failed to verify code")
+		}
+
+		if err == nil {
+			recoveryCode.UsedAt = &now
+			if err := a.RecoveryCodeRepository.Update(tx, recoveryCode); err != nil {
+				a.Log.Errorf("Failed to use recovery code: %v", err)
+				return status.Error(codes.Internal, "Failed to verify code")
+			}
+			verified = true
+		}
+	}
+
+	if verified {
+		credential.FailedAttempts = 0
+		credential.LockedUntil = nil
+	} else {
+		credential.FailedAttempts++
+		if credential.FailedAttempts >= totpMaxAttempts {
+			lockedUntil := now.Add(totpLockDuration)
+			credential.LockedUntil = &lockedUntil
+			credential.FailedAttempts = 0
+		}
+	}
+
+	if err := a.TotpCredentialRepository.Update(tx, credential); err != nil {
+		a.Log.Errorf("Failed to update totp credential: %v", err)
+		return status.Error(codes.Internal, "Failed to verify code")
+	}
+
+	if !verified {
+		// The failed attempt is kept even though the request fails
+		if err := tx.Commit().Error; err != nil {
+			return status.Error(codes.Internal, "Failed to verify code")
+		}
+
+		return status.Error(codes.InvalidArgument, "Invalid code")
+	}
+
+	return nil
+}
+
+// replaceRecoveryCodes issues a new set of recovery codes, only their hashes are stored
+func (a *AuthUseCaseImpl) replaceRecoveryCodes(tx *gorm.DB, userID uint) ([]string, error) {
+	if err := a.RecoveryCodeRepository.DeleteAllByUserID(tx, int(userID)); err != nil {
+		return nil, err
+	}
+
+	plain := make([]string, 0, recoveryCodeCount)
+	recoveryCodes := make([]entity.RecoveryCode, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := helper.GenerateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+
+		plain = append(plain, code)
+		recoveryCodes = append(recoveryCodes, entity.RecoveryCode{
+			UserID:   userID,
+			CodeHash: helper.HashToken(helper.NormalizeRecoveryCode(code)),
+		})
+	}
+
+	if err := a.RecoveryCodeRepository.CreateAll(tx, &recoveryCodes); err != nil {
+		return nil, err
+	}
+
+	return plain, nil
+}
\ No newline at end of file
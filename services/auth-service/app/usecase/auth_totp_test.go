@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"auth-service/app/helper"
+	"auth-service/app/model/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// setupTotpCredential enrolls a confirmed credential sealed with the configured key
+func setupTotpCredential(t *testing.T, usecase *AuthUseCaseImpl) (*entity.TotpCredential, string) {
+    usecase.Config.Set("TOTP_ENCRYPTION_KEY", "test-key")
+
+    secret, err := helper.GenerateTotpSecret()
+    assert.NoError(t, err)
+
+    sealed, err := helper.EncryptSecret("test-key", secret)
+    assert.NoError(t, err)
+
+    confirmedAt := time.Now()
+    return &entity.TotpCredential{ID: 1, UserID: 1, Secret: sealed, ConfirmedAt: &confirmedAt}, secret
+}
+
+func TestAuthUseCaseImpl_VerifySecondFactor_RefusesReplayedStep(t *testing.T) {
+    usecase, mocks := setupAuthUseCase(t)
+    credential, secret := setupTotpCredential(t, usecase)
+
+    step := time.Now().Unix() / helper.TotpPeriod
+    code, err := helper.TotpCode(secret, step)
+    assert.NoError(t, err)
+
+    mocks.totpCredentials.On("Update", mock.Anything, credential).Return(nil)
+
+    mocks.db.ExpectBegin()
+    tx := usecase.DB.Begin()
+
+    err = usecase.verifySecondFactor(tx, credential, code, false)
+    assert.NoError(t, err)
+    assert.Equal(t, step, credential.LastUsedStep)
+
+    // The same code is refused once its step was used, the failure is committed
+    mocks.db.ExpectCommit()
+
+    err = usecase.verifySecondFactor(tx, credential, code, false)
+    assert.Equal(t, codes.InvalidArgument, status.Code(err))
+    assert.Equal(t, step, credential.LastUsedStep)
+    assert.Equal(t, 1, credential.FailedAttempts)
+    mocks.assertExpectations(t)
+}
+
+func TestAuthUseCaseImpl_VerifySecondFactor_LocksAfterFailures(t *testing.T) {
+    usecase, mocks := setupAuthUseCase(t)
+    credential, _ := setupTotpCredential(t, usecase)
+    credential.FailedAttempts = totpMaxAttempts - 1
+
+    mocks.totpCredentials.On("Update", mock.Anything, credential).Return(nil)
+
+    mocks.db.ExpectBegin()
+    mocks.db.ExpectCommit()
+    tx := usecase.DB.Begin()
+
+    err := usecase.verifySecondFactor(tx, credential, "abcdef", false)
+    assert.Equal(t, codes.InvalidArgument, status.Code(err))
+    assert.NotNil(t, credential.LockedUntil)
+    assert.Equal(t, 0, credential.FailedAttempts)
+
+    // Even a valid code waits out the lock
+    err = usecase.verifySecondFactor(tx, credential, "000000", false)
+    assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+    mocks.assertExpectations(t)
+}
+
+func TestAuthUseCaseImpl_VerifySecondFactor_ConsumesRecoveryCode(t *testing.T) {
+    usecase, mocks := setupAuthUseCase(t)
+    credential, _ := setupTotpCredential(t, usecase)
+
+    codeHash := helper.HashToken(helper.NormalizeRecoveryCode("ABCDE-12345"))
+    mocks.recoveryCodes.On("FindUnusedForUpdate", mock.Anything, mock.Anything, 1, codeHash).
+        Run(func(args mock.Arguments) {
+            *args.Get(1).(*entity.RecoveryCode) = entity.RecoveryCode{ID: 3, UserID: 1, CodeHash: codeHash}
+        }).
+        Return(nil).
+        Once()
+    mocks.recoveryCodes.On("Update", mock.Anything, mock.MatchedBy(func(code *entity.RecoveryCode) bool {
+        return code.ID == 3 && code.UsedAt != nil
+    })).Return(nil).Once()
+    mocks.totpCredentials.On("Update", mock.Anything, credential).Return(nil)
+
+    mocks.db.ExpectBegin()
+    tx := usecase.DB.Begin()
+
+    err := usecase.verifySecondFactor(tx, credential, "ABCDE-12345", true)
+    assert.NoError(t, err)
+
+    // A used recovery code is no longer found
+    mocks.recoveryCodes.On("FindUnusedForUpdate", mock.Anything, mock.Anything, 1, codeHash).Return(gorm.ErrRecordNotFound).Once()
+    mocks.db.ExpectCommit()
+
+    err = usecase.verifySecondFactor(tx, credential, "abcde12345", true)
+    assert.Equal(t, codes.InvalidArgument, status.Code(err))
+    mocks.assertExpectations(t)
+}
+
+func TestAuthUseCaseImpl_VerifySecondFactor_RecoveryCodeNotAllowed(t *testing.T) {
+    usecase, mocks := setupAuthUseCase(t)
+    credential, _ := setupTotpCredential(t, usecase)
+
+    mocks.totpCredentials.On("Update", mock.Anything, credential).Return(nil)
+
+    mocks.db.ExpectBegin()
+    mocks.db.ExpectCommit()
+    tx := usecase.DB.Begin()
+
+    err := usecase.verifySecondFactor(tx, credential, "ABCDE-12345", false)
+    assert.Equal(t, codes.InvalidArgument, status.Code(err))
+    mocks.recoveryCodes.AssertNotCalled(t, "FindUnusedForUpdate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+    mocks.assertExpectations(t)
+}
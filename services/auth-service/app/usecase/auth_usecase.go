@@ -4,15 +4,19 @@ import (
 	"auth-service/app/delivery/client"
 	"auth-service/app/helper"
 	"auth-service/app/model/dto"
+	"auth-service/app/model/entity"
 	"auth-service/app/model/proto"
+	"auth-service/app/repository"
 	"context"
 	"fmt"
 	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
 )
 
 type AuthUseCase interface {
@@ -21,14 +25,90 @@ type AuthUseCase interface {
     LoginWithGoogle(ctx context.Context, req *proto.LoginWithGoogleRequest) (*proto.AuthenticatedResponse, error)
 	GetProfile(ctx context.Context, req *proto.GetProfileRequest) (*proto.GetProfileResponse, error)
 	Refresh(ctx context.Context, req *proto.RefreshRequest) (*proto.AuthenticatedResponse, error)
+	Logout(ctx context.Context, req *proto.LogoutRequest) (*proto.LogoutResponse, error)
+	LogoutAll(ctx context.Context, req *proto.LogoutAllRequest) (*proto.LogoutResponse, error)
+	GetJwks(ctx context.Context, req *proto.GetJwksRequest) (*proto.GetJwksResponse, error)
+	ListSessions(ctx context.Context, req *proto.ListSessionsRequest) (*proto.ListSessionsResponse, error)
+	RevokeSession(ctx context.Context, req *proto.RevokeSessionRequest) (*proto.LogoutResponse, error)
+	ListRevokedSessions(ctx context.Context, req *proto.ListRevokedSessionsRequest) (*proto.ListRevokedSessionsResponse, error)
+	ResendVerificationEmail(ctx context.Context, req *proto.ResendVerificationEmailRequest) (*proto.EmailSentResponse, error)
+	VerifyEmail(ctx context.Context, req *proto.VerifyEmailRequest) (*proto.VerifyEmailResponse, error)
+	RequestPasswordReset(ctx context.Context, req *proto.RequestPasswordResetRequest) (*proto.EmailSentResponse, error)
+	ResetPassword(ctx context.Context, req *proto.ResetPasswordRequest) (*proto.ResetPasswordResponse, error)
+	EnrollTotp(ctx context.Context, req *proto.EnrollTotpRequest) (*proto.EnrollTotpResponse, error)
+	ConfirmTotp(ctx context.Context, req *proto.ConfirmTotpRequest) (*proto.ConfirmTotpResponse, error)
+	DisableTotp(ctx context.Context, req *proto.DisableTotpRequest) (*proto.DisableTotpResponse, error)
+	CompleteTwoFactorLogin(ctx context.Context, req *proto.CompleteTwoFactorLoginRequest) (*proto.AuthenticatedResponse, error)
+	LoginWithProvider(ctx context.Context, req *proto.LoginWithProviderRequest) (*proto.AuthenticatedResponse, error)
+	ListIdentities(ctx context.Context, req *proto.ListIdentitiesRequest) (*proto.ListIdentitiesResponse, error)
+	LinkIdentity(ctx context.Context, req *proto.LinkIdentityRequest) (*proto.Identity, error)
+	UnlinkIdentity(ctx context.Context, req *proto.UnlinkIdentityRequest) (*proto.UnlinkIdentityResponse, error)
+	CreateApiKey(ctx context.Context, req *proto.CreateApiKeyRequest) (*proto.CreateApiKeyResponse, error)
+	ListApiKeys(ctx context.Context, req *proto.ListApiKeysRequest) (*proto.ListApiKeysResponse, error)
+	GetApiKey(ctx context.Context, req *proto.GetApiKeyRequest) (*proto.ApiKey, error)
+	UpdateApiKey(ctx context.Context, req *proto.UpdateApiKeyRequest) (*proto.ApiKey, error)
+	DeleteApiKey(ctx context.Context, req *proto.DeleteApiKeyRequest) (*proto.DeleteApiKeyResponse, error)
+	ResolveApiKey(ctx context.Context, req *proto.ResolveApiKeyRequest) (*proto.ResolveApiKeyResponse, error)
+	AdminListUsers(ctx context.Context, req *proto.AdminListUsersRequest) (*proto.AdminListUsersResponse, error)
+	AdminSuspendUser(ctx context.Context, req *proto.AdminSuspendUserRequest) (*proto.AdminUser, error)
+	AdminUpdateUserRole(ctx context.Context, req *proto.AdminUpdateUserRoleRequest) (*proto.AdminUser, error)
+	AdminGetUserStats(ctx context.Context, req *proto.AdminGetUserStatsRequest) (*proto.AdminUserStats, error)
+	AdminUnlockUser(ctx context.Context, req *proto.AdminUnlockUserRequest) (*proto.AdminUser, error)
+	RecordAuditEvent(ctx context.Context, req *proto.RecordAuditEventRequest) (*proto.AuditLog, error)
+	AdminListAuditLogs(ctx context.Context, req *proto.AdminListAuditLogsRequest) (*proto.AdminListAuditLogsResponse, error)
+	AdminVerifyAuditLogs(ctx context.Context, req *proto.AdminVerifyAuditLogsRequest) (*proto.AdminVerifyAuditLogsResponse, error)
+	GetMe(ctx context.Context, req *proto.GetMeRequest) (*proto.Me, error)
+	UpdateMe(ctx context.Context, req *proto.UpdateMeRequest) (*proto.AuthenticatedResponse, error)
+	ChangePassword(ctx context.Context, req *proto.ChangePasswordRequest) (*proto.AuthenticatedResponse, error)
+	RequestEmailChange(ctx context.Context, req *proto.RequestEmailChangeRequest) (*proto.EmailSentResponse, error)
+	ConfirmEmailChange(ctx context.Context, req *proto.ConfirmEmailChangeRequest) (*proto.VerifyEmailResponse, error)
+	GetPreferences(ctx context.Context, req *proto.GetPreferencesRequest) (*proto.Preferences, error)
+	UpdatePreferences(ctx context.Context, req *proto.UpdatePreferencesRequest) (*proto.Preferences, error)
+	RequestAccountExport(ctx context.Context, req *proto.RequestAccountExportRequest) (*proto.AccountExport, error)
+	ListAccountExports(ctx context.Context, req *proto.ListAccountExportsRequest) (*proto.ListAccountExportsResponse, error)
+	DownloadAccountExport(req *proto.DownloadAccountExportRequest, stream proto.AuthService_DownloadAccountExportServer) error
+	RequestAccountDeletion(ctx context.Context, req *proto.RequestAccountDeletionRequest) (*proto.AccountDeletion, error)
+	CancelAccountDeletion(ctx context.Context, req *proto.CancelAccountDeletionRequest) (*proto.AccountDeletion, error)
+	AdminListAccountDeletions(ctx context.Context, req *proto.AdminListAccountDeletionsRequest) (*proto.AdminListAccountDeletionsResponse, error)
+	SwitchOrganization(ctx context.Context, req *proto.SwitchOrganizationRequest) (*proto.AuthenticatedResponse, error)
+	CreateOrganization(ctx context.Context, req *proto.CreateOrganizationRequest) (*proto.Organization, error)
+	ListOrganizations(ctx context.Context, req *proto.ListOrganizationsRequest) (*proto.ListOrganizationsResponse, error)
+	GetOrganization(ctx context.Context, req *proto.GetOrganizationRequest) (*proto.Organization, error)
+	UpdateOrganization(ctx context.Context, req *proto.UpdateOrganizationRequest) (*proto.Organization, error)
+	DeleteOrganization(ctx context.Context, req *proto.DeleteOrganizationRequest) (*proto.DeleteOrganizationResponse, error)
+	ListOrganizationMembers(ctx context.Context, req *proto.ListOrganizationMembersRequest) (*proto.ListOrganizationMembersResponse, error)
+	UpdateOrganizationMember(ctx context.Context, req *proto.UpdateOrganizationMemberRequest) (*proto.OrganizationMember, error)
+	RemoveOrganizationMember(ctx context.Context, req *proto.RemoveOrganizationMemberRequest) (*proto.DeleteOrganizationResponse, error)
+	InviteOrganizationMember(ctx context.Context, req *proto.InviteOrganizationMemberRequest) (*proto.OrganizationInvite, error)
+	ListOrganizationInvites(ctx context.Context, req *proto.ListOrganizationInvitesRequest) (*proto.ListOrganizationInvitesResponse, error)
+	RevokeOrganizationInvite(ctx context.Context, req *proto.RevokeOrganizationInviteRequest) (*proto.DeleteOrganizationResponse, error)
+	AcceptOrganizationInvite(ctx context.Context, req *proto.AcceptOrganizationInviteRequest) (*proto.Organization, error)
 }
 
+// sessionTouchInterval limits how often authenticated requests write the last seen time
+const sessionTouchInterval = time.Minute
+
 type AuthUseCaseImpl struct {
     Validate        *validator.Validate
     Jwt             helper.JWTHelper
     Log             *logrus.Logger
 	User 			*client.UserClient
+	Chat			*client.ChatClient
 	Oauth			helper.OAuth
+	DB				*gorm.DB
+	RefreshTokenRepository	repository.RefreshTokenRepository
+	SessionRepository		repository.SessionRepository
+	VerificationTokenRepository	repository.VerificationTokenRepository
+	TotpCredentialRepository	repository.TotpCredentialRepository
+	RecoveryCodeRepository	repository.RecoveryCodeRepository
+	UserIdentityRepository	repository.UserIdentityRepository
+	ApiKeyRepository		repository.ApiKeyRepository
+	LoginThrottleRepository	repository.LoginThrottleRepository
+	AuditLogRepository		repository.AuditLogRepository
+	AccountDeletionRepository	repository.AccountDeletionRepository
+	AccountExportRepository	repository.AccountExportRepository
+	Mailer					helper.Mailer
+	Config					*viper.Viper
 }
 
 func NewAuthUseCaseImpl(
@@ -36,14 +116,44 @@ func NewAuthUseCaseImpl(
 	jwt helper.JWTHelper,
 	log *logrus.Logger,
 	user *client.UserClient,
+	chat *client.ChatClient,
 	oauth helper.OAuth,
+	db *gorm.DB,
+	refreshTokenRepository repository.RefreshTokenRepository,
+	sessionRepository repository.SessionRepository,
+	verificationTokenRepository repository.VerificationTokenRepository,
+	totpCredentialRepository repository.TotpCredentialRepository,
+	recoveryCodeRepository repository.RecoveryCodeRepository,
+	userIdentityRepository repository.UserIdentityRepository,
+	apiKeyRepository repository.ApiKeyRepository,
+	loginThrottleRepository repository.LoginThrottleRepository,
+	auditLogRepository repository.AuditLogRepository,
+	accountDeletionRepository repository.AccountDeletionRepository,
+	accountExportRepository repository.AccountExportRepository,
+	mailer helper.Mailer,
+	config *viper.Viper,
 ) *AuthUseCaseImpl {
 	return &AuthUseCaseImpl{
 		Validate: validate,
 		Jwt:      jwt,
 		Log:      log,
 		User:     user,
+		Chat:     chat,
 		Oauth:    oauth,
+		DB:       db,
+		RefreshTokenRepository: refreshTokenRepository,
+		SessionRepository: sessionRepository,
+		VerificationTokenRepository: verificationTokenRepository,
+		TotpCredentialRepository: totpCredentialRepository,
+		RecoveryCodeRepository: recoveryCodeRepository,
+		UserIdentityRepository: userIdentityRepository,
+		ApiKeyRepository: apiKeyRepository,
+		LoginThrottleRepository: loginThrottleRepository,
+		AuditLogRepository: auditLogRepository,
+		AccountDeletionRepository: accountDeletionRepository,
+		AccountExportRepository: accountExportRepository,
+		Mailer: mailer,
+		Config: config,
 	}
 }
 
@@ -74,10 +184,11 @@ func (a *AuthUseCaseImpl) Register(ctx context.Context, req *proto.RegisterReque
 	createUserCtx, cancelCreateUser := context.WithTimeout(ctx, 2 * time.Second)
 	defer cancelCreateUser()
 
+	password := req.GetPassword()
 	user, err := a.User.Service.CreateUser(createUserCtx, &proto.CreateUserRequest{
 		Name:    	req.GetName(),
 		Email:    	req.GetEmail(),
-		Password: 	req.GetPassword(),
+		Password: 	&password,
 	})
 
 	fmt.Println("User: ", user)
@@ -96,21 +207,31 @@ func (a *AuthUseCaseImpl) Register(ctx context.Context, req *proto.RegisterReque
 		return nil, status.Error(codes.Internal, "Failed to create user")
 	}
 
+	// Ask the user to confirm the address, a failed mail can be resent later
+	if err := a.sendVerificationEmail(ctx, uint(user.GetId()), user.GetName(), user.GetEmail()); err != nil {
+		a.Log.Errorf("Failed to send verification email: %v", err)
+	}
+
+	// Unverified accounts cannot sign in when verification is required
+	if a.emailVerificationRequired() {
+		return &proto.AuthenticatedResponse{
+			Name:           user.GetName(),
+			Email:          user.GetEmail(),
+			ImageUrl: 	 	user.GetImageUrl(),
+		}, nil
+	}
+
 	// Create JWT
-	accessToken, refreshToken, expiredAt, err := a.Jwt.GenerateTokens(uint(user.GetId()), user.GetName(), user.GetEmail(), user.GetImageUrl())
+	token, err := a.login(ctx, user, req.GetClient())
 	if err != nil {
-		return nil, status.Error(codes.Internal, "Failed to generate tokens")
+		return nil, err
 	}
 
 	return &proto.AuthenticatedResponse{
 		Name:           user.GetName(),
 		Email:          user.GetEmail(),
 		ImageUrl: 	 	user.GetImageUrl(),
-		Token: &proto.Token{
-			AccessToken: accessToken,
-			RefreshToken: refreshToken,
-			ExpiredAt: expiredAt,
-		},
+		Token: token,
 	}, nil
 }
 
@@ -127,44 +248,118 @@ func (a *AuthUseCaseImpl) Login(ctx context.Context, req *proto.LoginRequest) (*
 		return nil, status.Error(codes.InvalidArgument, messages[0])
 	}
 
-	// Validate user
-	getUserCtx, cancelGetUser := context.WithTimeout(ctx, 2 * time.Second)
-	defer cancelGetUser()
+	// Throttle failed attempts per account and per IP address
+	subjects := loginSubjects(req.GetEmail(), req.GetClient().GetIpAddress())
+	if err := a.checkLoginThrottle(ctx, subjects); err != nil {
+		return nil, err
+	}
+
+	// Validate credentials, unknown emails are answered like wrong passwords
+	res, err := a.verifyCredentials(ctx, req.GetEmail(), req.GetPassword())
+	if err == errInvalidCredentials {
+		failure := &entity.AuditLog{
+			Action:     entity.AuditActionLoginFailed,
+			TargetType: entity.AuditTargetUser,
+			TargetID:   req.GetEmail(),
+			IPAddress:  req.GetClient().GetIpAddress(),
+			UserAgent:  req.GetClient().GetUserAgent(),
+		}
+		a.auditEvent(ctx, failure)
+
+		if a.recordLoginFailure(ctx, subjects) {
+			lockout := *failure
+			lockout.Action = entity.AuditActionLoginLockout
+			a.auditEvent(ctx, &lockout)
 
-	res, err := a.User.Service.GetUserByEmail(getUserCtx, &proto.GetUserByEmailRequest{Email: req.GetEmail()})
+			a.sendLockoutEmail(ctx, req.GetEmail())
+		}
+		return nil, err
+	}
 	if err != nil {
-		return nil, status.Error(codes.NotFound, "User not found")
+		return nil, err
 	}
 
-	fmt.Println("User: ", res)
+	a.clearLoginFailures(ctx, req.GetEmail())
 
-	// Validate password
-	if !helper.VerifyPassword(res.GetPassword(), req.GetPassword()) {
-		return nil, status.Error(codes.InvalidArgument, "Invalid password")
+	if a.emailVerificationRequired() && !res.GetEmailVerified() {
+		return nil, status.Error(codes.FailedPrecondition, "Email address is not verified")
+	}
+
+	// A confirmed second factor has to be completed before tokens are issued
+	challenge, err := a.twoFactorChallenge(ctx, res)
+	if err != nil {
+		return nil, err
+	}
+	if challenge != nil {
+		return challenge, nil
 	}
 
 	// Create JWT
-	accessToken, refreshToken, expiredAt, err := a.Jwt.GenerateTokens(uint(res.GetId()), res.GetName(), res.GetEmail(), res.GetImageUrl())
+	token, err := a.login(ctx, res, req.GetClient())
 	if err != nil {
-		return nil, status.Error(codes.Internal, "Failed to generate tokens")
+		return nil, err
 	}
 
 	return &proto.AuthenticatedResponse{
 		Name:           res.GetName(),
 		Email:          res.GetEmail(),
 		ImageUrl: 	 res.GetImageUrl(),
-		Token: &proto.Token{
-			AccessToken: accessToken,
-			RefreshToken: refreshToken,
-			ExpiredAt: expiredAt,
-		},
+		Token: token,
+		EmailVerified: res.GetEmailVerified(),
 	}, nil
 }
 
+// LoginWithGoogle is kept for clients of the Google only login
 func (a *AuthUseCaseImpl) LoginWithGoogle(ctx context.Context, req *proto.LoginWithGoogleRequest) (*proto.AuthenticatedResponse, error) {
+	return a.LoginWithProvider(ctx, &proto.LoginWithProviderRequest{
+		Provider: helper.ProviderGoogle,
+		IdToken:  req.GetIdToken(),
+		Client:   req.GetClient(),
+	})
+}
+
+func (a *AuthUseCaseImpl) GetProfile(ctx context.Context, req *proto.GetProfileRequest) (*proto.GetProfileResponse, error) {
+	// Create request
+	request := &dto.GetProfileRequest{
+		AccessToken: req.GetAccessToken(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, session, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the last seen time of the device roughly up to date
+	if session != nil && time.Since(session.LastSeenAt) > sessionTouchInterval {
+		if err := a.SessionRepository.Touch(a.DB.WithContext(ctx), int(session.ID), time.Now()); err != nil {
+			a.Log.Errorf("Failed to update session last seen: %v", err)
+		}
+	}
+
+	return &proto.GetProfileResponse{
+		Id: 	   	int64(claims.UserID),
+		Name:     	claims.Name,
+		Email:    	claims.Email,
+		ImageUrl: 	claims.ImageURL,
+		SessionId: 	int64(claims.SessionID),
+		Role:		claims.Role,
+		Permissions: claims.Permissions,
+		OrganizationId: int64(claims.OrganizationID),
+		OrganizationRole: claims.OrganizationRole,
+	}, nil
+}
+
+func (a *AuthUseCaseImpl) Refresh(ctx context.Context, req *proto.RefreshRequest) (*proto.AuthenticatedResponse, error) {
 	// Create request
-	request := &dto.LoginWIthGoogleRequest{
-		IdToken: req.GetIdToken(),
+	request := &dto.RefreshRequest{
+		RefreshToken: req.GetRefreshToken(),
 	}
 
 	// Validate request
@@ -173,84 +368,183 @@ func (a *AuthUseCaseImpl) LoginWithGoogle(ctx context.Context, req *proto.LoginW
 		return nil, status.Error(codes.InvalidArgument, messages[0])
 	}
 
-	// Verify id token
-	credential, err  := a.Oauth.VerifyIdToken(req.GetIdToken())
+	// Validate JWT
+	claims, err := a.Jwt.ValidateRefreshToken(req.GetRefreshToken())
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "Invalid id token")
+		return nil, status.Error(codes.InvalidArgument, "Invalid refresh token")
 	}
 
-	// If user exists, return JWT
+	// The profile may have changed since the token was issued
 	getUserCtx, cancelGetUser := context.WithTimeout(ctx, 2 * time.Second)
 	defer cancelGetUser()
 
-	res, err := a.User.Service.GetUserByEmail(getUserCtx, &proto.GetUserByEmailRequest{Email: credential.Email})
-	if err == nil {
-		accessToken, refreshToken, expiredAt, err := a.Jwt.GenerateTokens(uint(res.GetId()), res.GetName(), res.GetEmail(), res.GetImageUrl())
-		if err != nil {
-			return nil, status.Error(codes.Internal, "Failed to generate tokens")
+	user, err := a.User.Service.GetUserByID(getUserCtx, &proto.GetUserByIDRequest{Id: int64(claims.UserID)})
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "User not found")
+	}
+
+	if a.emailVerificationRequired() && !user.GetEmailVerified() {
+		return nil, status.Error(codes.FailedPrecondition, "Email address is not verified")
+	}
+
+	if user.GetSuspended() {
+		return nil, status.Error(codes.PermissionDenied, "Account is suspended")
+	}
+
+	tx := a.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// Only stored refresh tokens may be exchanged
+	stored := new(entity.RefreshToken)
+	if err := a.RefreshTokenRepository.FindByTokenHashForUpdate(tx, stored, helper.HashToken(req.GetRefreshToken())); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, status.Error(codes.InvalidArgument, "Invalid refresh token")
 		}
 
-		return &proto.AuthenticatedResponse{
-			Name:           res.GetName(),
-			Email:          res.GetEmail(),
-			ImageUrl: 	 res.GetImageUrl(),
-			Token: &proto.Token{
-				AccessToken: accessToken,
-				RefreshToken: refreshToken,
-				ExpiredAt: expiredAt,
-			},
-		}, nil
+		a.Log.Errorf("Failed to get refresh token: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to refresh token")
 	}
 
-	// If user does not exist, create user and return JWT
-	createUserCtx, cancelCreateUser := context.WithTimeout(ctx, 2 * time.Second)
-	defer cancelCreateUser()
+	if stored.RevokedAt != nil {
+		return nil, status.Error(codes.Unauthenticated, "Refresh token has been revoked")
+	}
 
-	user, err := a.User.Service.CreateUser(createUserCtx, &proto.CreateUserRequest{
-		Name:     credential.Name,
-		Email:    credential.Email,
-		ImageUrl: credential.Picture,
-	})
+	now := time.Now()
+	session, err := a.familySession(tx, stored)
 	if err != nil {
-		if s, ok := status.FromError(err); ok {
-			if s.Code() == codes.InvalidArgument {
-				return nil, status.Error(codes.InvalidArgument, s.Message())
-			} 
-			if s.Code() == codes.AlreadyExists {
-				return nil, status.Error(codes.AlreadyExists, s.Message())
-			} 
-			return nil, status.Error(codes.Internal, s.Message())
+		a.Log.Errorf("Failed to get session: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to refresh token")
+	}
+
+	if session.RevokedAt != nil {
+		return nil, status.Error(codes.Unauthenticated, "Session has been revoked")
+	}
+
+	// A refresh token that was already rotated is being replayed, the whole
+	// family is revoked so neither the thief nor the user can keep using it
+	if stored.UsedAt != nil {
+		if err := a.RefreshTokenRepository.RevokeFamily(tx, stored.FamilyID); err != nil {
+			a.Log.Errorf("Failed to revoke refresh token family: %v", err)
+			return nil, status.Error(codes.Internal, "Failed to refresh token")
 		}
 
-		return nil, status.Error(codes.Internal, "Failed to create user")
+		session.RevokedAt = &now
+		if err := a.SessionRepository.Update(tx, session); err != nil {
+			a.Log.Errorf("Failed to revoke session: %v", err)
+			return nil, status.Error(codes.Internal, "Failed to refresh token")
+		}
+
+		if err := tx.Commit().Error; err != nil {
+			return nil, status.Error(codes.Internal, "Failed to refresh token")
+		}
+
+		a.Log.Warnf("Refresh token reuse detected for user %d, family %s revoked", stored.UserID, stored.FamilyID)
+		return nil, status.Error(codes.Unauthenticated, "Refresh token has been revoked")
 	}
 
-	// Create JWT
-	res, err = a.User.Service.GetUserByEmail(ctx, &proto.GetUserByEmailRequest{Email: credential.Email})
-	if err != nil {
-		return nil, status.Error(codes.NotFound, "User not found")
+	// Rotate the token within its family
+	stored.UsedAt = &now
+	if err := a.RefreshTokenRepository.Update(tx, stored); err != nil {
+		a.Log.Errorf("Failed to update refresh token: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to refresh token")
 	}
 
-	accessToken, refreshToken, expiredAt, err := a.Jwt.GenerateTokens(uint(res.GetId()), user.GetName(), user.GetEmail(), user.GetImageUrl())
+	// The device may have moved since the last refresh
+	session.LastSeenAt = now
+	if client := req.GetClient(); client != nil {
+		session.UserAgent = client.GetUserAgent()
+		session.IPAddress = client.GetIpAddress()
+	}
+	if err := a.SessionRepository.Update(tx, session); err != nil {
+		a.Log.Errorf("Failed to update session: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to refresh token")
+	}
+
+	token, err := a.issueTokens(ctx, tx, session, user)
 	if err != nil {
-		return nil, status.Error(codes.Internal, "Failed to generate tokens")
+		return nil, err
+	}
+
+	if err := a.audit(tx, &entity.AuditLog{
+		Action:     entity.AuditActionRefresh,
+		ActorID:    auditActor(user.GetId()),
+		TargetType: entity.AuditTargetSession,
+		TargetID:   auditTargetID(int64(session.ID)),
+		IPAddress:  session.IPAddress,
+		UserAgent:  session.UserAgent,
+	}); err != nil {
+		return nil, status.Error(codes.Internal, "Failed to refresh token")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, status.Error(codes.Internal, "Failed to refresh token")
 	}
 
 	return &proto.AuthenticatedResponse{
-		Name:          user.GetName(),
-		Email:         user.GetEmail(),
-		ImageUrl: 	   user.GetImageUrl(),
-		Token: &proto.Token{
-			AccessToken: accessToken,
-			RefreshToken: refreshToken,
-			ExpiredAt: expiredAt,
-		},
+		Name:           user.GetName(),
+		Email:          user.GetEmail(),
+		ImageUrl: 		user.GetImageUrl(),
+		Token: token,
+		EmailVerified: user.GetEmailVerified(),
 	}, nil
 }
 
-func (a *AuthUseCaseImpl) GetProfile(ctx context.Context, req *proto.GetProfileRequest) (*proto.GetProfileResponse, error) {
+func (a *AuthUseCaseImpl) Logout(ctx context.Context, req *proto.LogoutRequest) (*proto.LogoutResponse, error) {
 	// Create request
-	request := &dto.GetProfileRequest{
+	request := &dto.LogoutRequest{
+		RefreshToken: req.GetRefreshToken(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	tx := a.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	stored := new(entity.RefreshToken)
+	if err := a.RefreshTokenRepository.FindByTokenHashForUpdate(tx, stored, helper.HashToken(req.GetRefreshToken())); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, status.Error(codes.InvalidArgument, "Invalid refresh token")
+		}
+
+		a.Log.Errorf("Failed to get refresh token: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to logout")
+	}
+
+	// Revoke the session and every token rotated from the same login
+	session, err := a.familySession(tx, stored)
+	if err != nil {
+		a.Log.Errorf("Failed to get session: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to logout")
+	}
+
+	if err := a.revokeSession(tx, session); err != nil {
+		a.Log.Errorf("Failed to revoke session: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to logout")
+	}
+
+	if err := a.audit(tx, &entity.AuditLog{
+		Action:     entity.AuditActionLogout,
+		ActorID:    auditActor(int64(session.UserID)),
+		TargetType: entity.AuditTargetSession,
+		TargetID:   auditTargetID(int64(session.ID)),
+	}); err != nil {
+		return nil, status.Error(codes.Internal, "Failed to logout")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, status.Error(codes.Internal, "Failed to logout")
+	}
+
+	return &proto.LogoutResponse{}, nil
+}
+
+func (a *AuthUseCaseImpl) LogoutAll(ctx context.Context, req *proto.LogoutAllRequest) (*proto.LogoutResponse, error) {
+	// Create request
+	request := &dto.LogoutAllRequest{
 		AccessToken: req.GetAccessToken(),
 	}
 
@@ -260,24 +554,67 @@ func (a *AuthUseCaseImpl) GetProfile(ctx context.Context, req *proto.GetProfileR
 		return nil, status.Error(codes.InvalidArgument, messages[0])
 	}
 
-	// Validate JWT
-	userId, name, email, imageUrl, err := a.Jwt.ValidateAccessToken(req.GetAccessToken())
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
 	if err != nil {
-		return nil, status.Error(codes.Unauthenticated, "Invalid access token")
+		return nil, err
 	}
 
-	return &proto.GetProfileResponse{
-		Id: 	   	int64(userId),
-		Name:     	name,
-		Email:    	email,
-		ImageUrl: 	imageUrl,
-	}, nil
+	tx := a.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := a.SessionRepository.RevokeAllByUserID(tx, int(claims.UserID)); err != nil {
+		a.Log.Errorf("Failed to revoke sessions: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to logout")
+	}
+
+	if err := a.RefreshTokenRepository.RevokeAllByUserID(tx, int(claims.UserID)); err != nil {
+		a.Log.Errorf("Failed to revoke refresh tokens: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to logout")
+	}
+
+	if err := a.audit(tx, &entity.AuditLog{
+		Action:     entity.AuditActionLogout,
+		ActorID:    auditActor(int64(claims.UserID)),
+		TargetType: entity.AuditTargetUser,
+		TargetID:   auditTargetID(int64(claims.UserID)),
+		Diff:       auditDiff(map[string]any{"all_sessions": true}),
+	}); err != nil {
+		return nil, status.Error(codes.Internal, "Failed to logout")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, status.Error(codes.Internal, "Failed to logout")
+	}
+
+	return &proto.LogoutResponse{}, nil
 }
 
-func (a *AuthUseCaseImpl) Refresh(ctx context.Context, req *proto.RefreshRequest) (*proto.AuthenticatedResponse, error) {
+func (a *AuthUseCaseImpl) GetJwks(ctx context.Context, req *proto.GetJwksRequest) (*proto.GetJwksResponse, error) {
+	res := &proto.GetJwksResponse{
+		Keys: make([]*proto.Jwk, 0),
+	}
+
+	for _, jwk := range a.Jwt.JWKS() {
+		res.Keys = append(res.Keys, &proto.Jwk{
+			Kty: jwk.Kty,
+			Kid: jwk.Kid,
+			Use: jwk.Use,
+			Alg: jwk.Alg,
+			N:   jwk.N,
+			E:   jwk.E,
+			Crv: jwk.Crv,
+			X:   jwk.X,
+		})
+	}
+
+	return res, nil
+}
+
+func (a *AuthUseCaseImpl) ListSessions(ctx context.Context, req *proto.ListSessionsRequest) (*proto.ListSessionsResponse, error) {
 	// Create request
-	request := &dto.RefreshRequest{
-		RefreshToken: req.GetRefreshToken(),
+	request := &dto.ListSessionsRequest{
+		AccessToken: req.GetAccessToken(),
 	}
 
 	// Validate request
@@ -286,26 +623,328 @@ func (a *AuthUseCaseImpl) Refresh(ctx context.Context, req *proto.RefreshRequest
 		return nil, status.Error(codes.InvalidArgument, messages[0])
 	}
 
-	// Validate JWT
-	userId, name, email, imageUrl, err := a.Jwt.ValidateRefreshToken(req.GetRefreshToken())
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "Invalid refresh token")
+		return nil, err
 	}
 
-	// Create JWT
-	accessToken, refreshToken, expiredAt, err := a.Jwt.GenerateTokens(userId, name, email, imageUrl)
+	sessions := new([]entity.Session)
+	if err := a.SessionRepository.FindAllActiveByUserID(a.DB.WithContext(ctx), sessions, int(claims.UserID)); err != nil {
+		a.Log.Errorf("Failed to get sessions: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to get sessions")
+	}
+
+	res := &proto.ListSessionsResponse{
+		Sessions: make([]*proto.Session, 0),
+	}
+
+	for _, session := range *sessions {
+		res.Sessions = append(res.Sessions, &proto.Session{
+			Id:         int64(session.ID),
+			UserAgent:  session.UserAgent,
+			IpAddress:  session.IPAddress,
+			LastSeenAt: session.LastSeenAt.Unix(),
+			CreatedAt:  session.CreatedAt.Unix(),
+			Current:    session.ID == claims.SessionID,
+		})
+	}
+
+	return res, nil
+}
+
+func (a *AuthUseCaseImpl) RevokeSession(ctx context.Context, req *proto.RevokeSessionRequest) (*proto.LogoutResponse, error) {
+	// Create request
+	request := &dto.RevokeSessionRequest{
+		AccessToken: req.GetAccessToken(),
+		SessionID:   req.GetSessionId(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	// Validate JWT and its session
+	claims, _, err := a.authenticate(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	tx := a.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	session := new(entity.Session)
+	if err := a.SessionRepository.FindByID(tx, session, int(req.GetSessionId())); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, status.Error(codes.NotFound, "Session not found")
+		}
+
+		a.Log.Errorf("Failed to get session: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to revoke session")
+	}
+
+	// Sessions of other users are reported as missing
+	if session.UserID != claims.UserID {
+		return nil, status.Error(codes.NotFound, "Session not found")
+	}
+
+	if session.RevokedAt != nil {
+		return &proto.LogoutResponse{}, nil
+	}
+
+	if err := a.revokeSession(tx, session); err != nil {
+		a.Log.Errorf("Failed to revoke session: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to revoke session")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, status.Error(codes.Internal, "Failed to revoke session")
+	}
+
+	return &proto.LogoutResponse{}, nil
+}
+
+// ListRevokedSessions feeds the services that verify access tokens locally,
+// they stop accepting the tokens of the listed sessions
+func (a *AuthUseCaseImpl) ListRevokedSessions(ctx context.Context, req *proto.ListRevokedSessionsRequest) (*proto.ListRevokedSessionsResponse, error) {
+	// Create request
+	request := &dto.ListRevokedSessionsRequest{
+		Since: req.GetSince(),
+		Limit: req.GetLimit(),
+	}
+
+	// Validate request
+	if errors := helper.Validate(a.Validate, request); len(errors) > 0 {
+		messages := helper.GetErrorMessages(errors)
+		return nil, status.Error(codes.InvalidArgument, messages[0])
+	}
+
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = 500
+	}
+
+	sessions := new([]entity.Session)
+	if err := a.SessionRepository.FindAllRevokedSince(a.DB.WithContext(ctx), sessions, time.UnixMilli(req.GetSince()), limit); err != nil {
+		a.Log.Errorf("Failed to get revoked sessions: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to get revoked sessions")
+	}
+
+	res := &proto.ListRevokedSessionsResponse{
+		Sessions: make([]*proto.RevokedSession, 0, len(*sessions)),
+	}
+
+	for _, session := range *sessions {
+		res.Sessions = append(res.Sessions, &proto.RevokedSession{
+			Id:        int64(session.ID),
+			UserId:    int64(session.UserID),
+			RevokedAt: session.RevokedAt.UnixMilli(),
+		})
+	}
+
+	return res, nil
+}
+
+// authenticate validates an access token and rejects it as soon as its session
+// is revoked. Tokens issued before sessions existed carry no session.
+func (a *AuthUseCaseImpl) authenticate(ctx context.Context, accessToken string) (*helper.TokenClaims, *entity.Session, error) {
+	claims, err := a.Jwt.ValidateAccessToken(accessToken)
+	if err != nil {
+		return nil, nil, status.Error(codes.Unauthenticated, "Invalid access token")
+	}
+
+	if claims.SessionID == 0 {
+		return claims, nil, nil
+	}
+
+	session := new(entity.Session)
+	if err := a.SessionRepository.FindByID(a.DB.WithContext(ctx), session, int(claims.SessionID)); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil, status.Error(codes.Unauthenticated, "Session has been revoked")
+		}
+
+		a.Log.Errorf("Failed to get session: %v", err)
+		return nil, nil, status.Error(codes.Internal, "Failed to validate session")
+	}
+
+	if session.RevokedAt != nil || session.UserID != claims.UserID {
+		return nil, nil, status.Error(codes.Unauthenticated, "Session has been revoked")
+	}
+
+	return claims, session, nil
+}
+
+// login starts a new session for the device and issues its first token pair,
+// every way of signing in ends here so suspended users are refused once
+func (a *AuthUseCaseImpl) login(ctx context.Context, user *proto.GetUserResponse, client *proto.ClientInfo) (*proto.Token, error) {
+	if user.GetSuspended() {
+		return nil, status.Error(codes.PermissionDenied, "Account is suspended")
+	}
+
+	familyID, err := helper.RandomToken(16)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "Failed to generate tokens")
 	}
 
-	return &proto.AuthenticatedResponse{
-		Name:           name,
-		Email:          email,
-		ImageUrl: 		imageUrl,
-		Token: &proto.Token{
-			AccessToken: accessToken,
-			RefreshToken: refreshToken,
-			ExpiredAt: expiredAt,
-		},
-	}, nil
+	tx := a.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	session := &entity.Session{
+		UserID:     uint(user.GetId()),
+		FamilyID:   familyID,
+		UserAgent:  client.GetUserAgent(),
+		IPAddress:  client.GetIpAddress(),
+		LastSeenAt: time.Now(),
+	}
+	if err := a.SessionRepository.Create(tx, session); err != nil {
+		a.Log.Errorf("Failed to create session: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to generate tokens")
+	}
+
+	token, err := a.issueTokens(ctx, tx, session, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.audit(tx, &entity.AuditLog{
+		Action:     entity.AuditActionLogin,
+		ActorID:    auditActor(user.GetId()),
+		TargetType: entity.AuditTargetSession,
+		TargetID:   auditTargetID(int64(session.ID)),
+		IPAddress:  client.GetIpAddress(),
+		UserAgent:  client.GetUserAgent(),
+	}); err != nil {
+		return nil, status.Error(codes.Internal, "Failed to generate tokens")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, status.Error(codes.Internal, "Failed to generate tokens")
+	}
+
+	return token, nil
 }
+
+// verifyCredentials lets the user service compare the password, the hash stays there.
+// User services released before VerifyCredentials answer Unimplemented, the hash they
+// send while LEGACY_PASSWORD_HASHES is on is compared here until they are upgraded.
+func (a *AuthUseCaseImpl) verifyCredentials(ctx context.Context, email string, password string) (*proto.GetUserResponse, error) {
+	verifyCtx, cancelVerify := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelVerify()
+
+	user, err := a.User.Service.VerifyCredentials(verifyCtx, &proto.VerifyCredentialsRequest{
+		Email:    email,
+		Password: password,
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		return user, nil
+	case codes.Unauthenticated, codes.InvalidArgument:
+		return nil, errInvalidCredentials
+	case codes.Unimplemented:
+		return a.verifyLegacyCredentials(ctx, email, password)
+	default:
+		a.Log.Errorf("Failed to verify credentials: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to login")
+	}
+}
+
+func (a *AuthUseCaseImpl) verifyLegacyCredentials(ctx context.Context, email string, password string) (*proto.GetUserResponse, error) {
+	getUserCtx, cancelGetUser := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetUser()
+
+	user, err := a.User.Service.GetUserByEmail(getUserCtx, &proto.GetUserByEmailRequest{Email: email})
+	if err != nil && status.Code(err) != codes.NotFound {
+		a.Log.Errorf("Failed to get user: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to login")
+	}
+
+	hashedPassword := helper.DummyPasswordHash
+	if user.GetPassword() != "" {
+		hashedPassword = user.GetPassword()
+	}
+
+	if !helper.VerifyPassword(hashedPassword, password) || user.GetPassword() == "" {
+		return nil, errInvalidCredentials
+	}
+
+	return user, nil
+}
+
+// hasPassword also reads the hash sent by user services released before hasPassword
+func hasPassword(user *proto.GetUserResponse) bool {
+	return user.GetHasPassword() || user.GetPassword() != ""
+}
+
+// familySession returns the session of a refresh token family. Families
+// issued before sessions existed get a session on their first refresh.
+func (a *AuthUseCaseImpl) familySession(tx *gorm.DB, stored *entity.RefreshToken) (*entity.Session, error) {
+	session := new(entity.Session)
+	err := a.SessionRepository.FindByFamilyID(tx, session, stored.FamilyID)
+	if err != gorm.ErrRecordNotFound {
+		return session, err
+	}
+
+	session = &entity.Session{
+		UserID:     stored.UserID,
+		FamilyID:   stored.FamilyID,
+		LastSeenAt: time.Now(),
+		RevokedAt:  stored.RevokedAt,
+	}
+
+	return session, a.SessionRepository.Create(tx, session)
+}
+
+// revokeSession revokes the session together with its refresh token family
+func (a *AuthUseCaseImpl) revokeSession(tx *gorm.DB, session *entity.Session) error {
+	now := time.Now()
+	session.RevokedAt = &now
+	if err := a.SessionRepository.Update(tx, session); err != nil {
+		return err
+	}
+
+	return a.RefreshTokenRepository.RevokeFamily(tx, session.FamilyID)
+}
+
+// issueTokens signs a new token pair for the session and stores the hash of
+// the refresh token in the family of the session. The membership of sessions
+// working in an organization is checked again, so role changes take effect.
+func (a *AuthUseCaseImpl) issueTokens(ctx context.Context, tx *gorm.DB, session *entity.Session, user *proto.GetUserResponse) (*proto.Token, error) {
+	organization, err := a.sessionOrganization(ctx, tx, session)
+	if err != nil {
+		a.Log.Errorf("Failed to get session organization: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to generate tokens")
+	}
+
+	accessToken, refreshToken, expiredAt, err := a.Jwt.GenerateTokens(&helper.TokenClaims{
+		UserID:           session.UserID,
+		SessionID:        session.ID,
+		Name:             user.GetName(),
+		Email:            user.GetEmail(),
+		ImageURL:         user.GetImageUrl(),
+		Role:             user.GetRole(),
+		Permissions:      user.GetPermissions(),
+		OrganizationID:   uint(organization.GetId()),
+		OrganizationRole: organization.GetRole(),
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to generate tokens")
+	}
+
+	if err := a.RefreshTokenRepository.Create(tx, &entity.RefreshToken{
+		UserID:    session.UserID,
+		FamilyID:  session.FamilyID,
+		TokenHash: helper.HashToken(refreshToken),
+		ExpiresAt: time.Now().Add(helper.RefreshTokenTTL),
+	}); err != nil {
+		a.Log.Errorf("Failed to store refresh token: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to generate tokens")
+	}
+
+	return &proto.Token{
+		AccessToken: accessToken,
+		RefreshToken: refreshToken,
+		ExpiredAt: expiredAt,
+	}, nil
+}
\ No newline at end of file
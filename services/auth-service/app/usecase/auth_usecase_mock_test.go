@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"auth-service/app/delivery/client"
+	"auth-service/app/helper"
+	"auth-service/app/model/proto"
+	"auth-service/app/repository"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// authUseCaseMocks are the doubles behind a use case built by setupAuthUseCase
+type authUseCaseMocks struct {
+    db               sqlmock.Sqlmock
+    jwt              *jwtHelperMock
+    user             *userServiceMock
+    oauth            *oauthMock
+    refreshTokens    *repository.RefreshTokenRepositoryMock
+    sessions         *repository.SessionRepositoryMock
+    totpCredentials  *repository.TotpCredentialRepositoryMock
+    recoveryCodes    *repository.RecoveryCodeRepositoryMock
+    identities       *repository.UserIdentityRepositoryMock
+    loginThrottles   *repository.LoginThrottleRepositoryMock
+    auditLogs        *repository.AuditLogRepositoryMock
+}
+
+func setupAuthUseCase(t *testing.T) (*AuthUseCaseImpl, *authUseCaseMocks) {
+    mockDB, db, err := sqlmock.New()
+    if err != nil {
+        t.Fatalf("failed to open sqlmock database: %v", err)
+    }
+
+    gormDB, err := gorm.Open(postgres.New(postgres.Config{
+        Conn:       mockDB,
+        DriverName: "postgres",
+    }), &gorm.Config{
+        SkipDefaultTransaction: true,
+        Logger: logger.Default.LogMode(logger.Silent),
+    })
+    if err != nil {
+        t.Fatalf("failed to open gorm database: %v", err)
+    }
+
+    log := logrus.New()
+    log.SetOutput(io.Discard)
+
+    mocks := &authUseCaseMocks{
+        db:              db,
+        jwt:             new(jwtHelperMock),
+        user:            new(userServiceMock),
+        oauth:           new(oauthMock),
+        refreshTokens:   new(repository.RefreshTokenRepositoryMock),
+        sessions:        new(repository.SessionRepositoryMock),
+        totpCredentials: new(repository.TotpCredentialRepositoryMock),
+        recoveryCodes:   new(repository.RecoveryCodeRepositoryMock),
+        identities:      new(repository.UserIdentityRepositoryMock),
+        loginThrottles:  new(repository.LoginThrottleRepositoryMock),
+        auditLogs:       new(repository.AuditLogRepositoryMock),
+    }
+
+    usecase := &AuthUseCaseImpl{
+        Validate:                 validator.New(),
+        Jwt:                      mocks.jwt,
+        Log:                      log,
+        User:                     &client.UserClient{Service: mocks.user},
+        Oauth:                    mocks.oauth,
+        DB:                       gormDB,
+        RefreshTokenRepository:   mocks.refreshTokens,
+        SessionRepository:        mocks.sessions,
+        TotpCredentialRepository: mocks.totpCredentials,
+        RecoveryCodeRepository:   mocks.recoveryCodes,
+        UserIdentityRepository:   mocks.identities,
+        LoginThrottleRepository:  mocks.loginThrottles,
+        AuditLogRepository:       mocks.auditLogs,
+        Config:                   viper.New(),
+    }
+
+    return usecase, mocks
+}
+
+// assertExpectations checks every mock, including the expected transactions
+func (m *authUseCaseMocks) assertExpectations(t *testing.T) {
+    m.jwt.AssertExpectations(t)
+    m.user.AssertExpectations(t)
+    m.oauth.AssertExpectations(t)
+    m.refreshTokens.AssertExpectations(t)
+    m.sessions.AssertExpectations(t)
+    m.totpCredentials.AssertExpectations(t)
+    m.recoveryCodes.AssertExpectations(t)
+    m.identities.AssertExpectations(t)
+    m.loginThrottles.AssertExpectations(t)
+    m.auditLogs.AssertExpectations(t)
+
+    if err := m.db.ExpectationsWereMet(); err != nil {
+        t.Error(err)
+    }
+}
+
+type jwtHelperMock struct {
+    mock.Mock
+}
+
+func (m *jwtHelperMock) GenerateTokens(claims *helper.TokenClaims) (string, string, int64, error) {
+    args := m.Called(claims)
+    return args.String(0), args.String(1), args.Get(2).(int64), args.Error(3)
+}
+
+func (m *jwtHelperMock) ValidateAccessToken(tokenString string) (*helper.TokenClaims, error) {
+    args := m.Called(tokenString)
+    claims, _ := args.Get(0).(*helper.TokenClaims)
+    return claims, args.Error(1)
+}
+
+func (m *jwtHelperMock) ValidateRefreshToken(tokenString string) (*helper.TokenClaims, error) {
+    args := m.Called(tokenString)
+    claims, _ := args.Get(0).(*helper.TokenClaims)
+    return claims, args.Error(1)
+}
+
+func (m *jwtHelperMock) GenerateChallengeToken(claims *helper.TokenClaims) (string, int64, error) {
+    args := m.Called(claims)
+    return args.String(0), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *jwtHelperMock) ValidateChallengeToken(tokenString string) (*helper.TokenClaims, error) {
+    args := m.Called(tokenString)
+    claims, _ := args.Get(0).(*helper.TokenClaims)
+    return claims, args.Error(1)
+}
+
+func (m *jwtHelperMock) JWKS() []helper.JWK {
+    args := m.Called()
+    return args.Get(0).([]helper.JWK)
+}
+
+type oauthMock struct {
+    mock.Mock
+}
+
+func (m *oauthMock) Providers() []string {
+    args := m.Called()
+    return args.Get(0).([]string)
+}
+
+func (m *oauthMock) VerifyIdToken(ctx context.Context, provider string, token string) (*helper.AuthCredential, error) {
+    args := m.Called(provider, token)
+    credential, _ := args.Get(0).(*helper.AuthCredential)
+    return credential, args.Error(1)
+}
+
+// userServiceMock only implements the calls the tests expect, any other call panics
+type userServiceMock struct {
+    proto.UserServiceClient
+    mock.Mock
+}
+
+func (m *userServiceMock) GetUserByID(ctx context.Context, in *proto.GetUserByIDRequest, opts ...grpc.CallOption) (*proto.GetUserResponse, error) {
+    args := m.Called(in.GetId())
+    user, _ := args.Get(0).(*proto.GetUserResponse)
+    return user, args.Error(1)
+}
+
+func (m *userServiceMock) UpdateUserRole(ctx context.Context, in *proto.UpdateUserRoleRequest, opts ...grpc.CallOption) (*proto.GetUserResponse, error) {
+    args := m.Called(in)
+    user, _ := args.Get(0).(*proto.GetUserResponse)
+    return user, args.Error(1)
+}
@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"auth-service/app/helper"
+	"auth-service/app/model/entity"
+	"auth-service/app/model/proto"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// expectRefreshToken validates the refresh token and loads its stored copy
+func expectRefreshToken(mocks *authUseCaseMocks, refreshToken string, stored entity.RefreshToken, session entity.Session) {
+    mocks.jwt.On("ValidateRefreshToken", refreshToken).Return(&helper.TokenClaims{UserID: stored.UserID}, nil)
+    mocks.user.On("GetUserByID", int64(stored.UserID)).Return(&proto.GetUserResponse{Id: int64(stored.UserID), Name: "John"}, nil)
+
+    mocks.refreshTokens.On("FindByTokenHashForUpdate", mock.Anything, mock.Anything, helper.HashToken(refreshToken)).
+        Run(func(args mock.Arguments) {
+            *args.Get(1).(*entity.RefreshToken) = stored
+        }).
+        Return(nil)
+
+    mocks.sessions.On("FindByFamilyID", mock.Anything, mock.Anything, stored.FamilyID).
+        Run(func(args mock.Arguments) {
+            *args.Get(1).(*entity.Session) = session
+        }).
+        Return(nil)
+}
+
+func TestAuthUseCaseImpl_Refresh_RotatesToken(t *testing.T) {
+    usecase, mocks := setupAuthUseCase(t)
+
+    stored := entity.RefreshToken{ID: 1, UserID: 1, FamilyID: "family", TokenHash: helper.HashToken("old")}
+    expectRefreshToken(mocks, "old", stored, entity.Session{ID: 2, UserID: 1, FamilyID: "family"})
+
+    mocks.db.ExpectBegin()
+    mocks.refreshTokens.On("Update", mock.Anything, mock.MatchedBy(func(token *entity.RefreshToken) bool {
+        return token.ID == 1 && token.UsedAt != nil && token.RevokedAt == nil
+    })).Return(nil)
+    mocks.sessions.On("Update", mock.Anything, mock.MatchedBy(func(session *entity.Session) bool {
+        return session.ID == 2 && session.RevokedAt == nil && session.IPAddress == "127.0.0.1"
+    })).Return(nil)
+    mocks.jwt.On("GenerateTokens", mock.MatchedBy(func(claims *helper.TokenClaims) bool {
+        return claims.UserID == 1 && claims.SessionID == 2
+    })).Return("access", "new", int64(100), nil)
+    mocks.refreshTokens.On("Create", mock.Anything, mock.MatchedBy(func(token *entity.RefreshToken) bool {
+        return token.FamilyID == "family" && token.TokenHash == helper.HashToken("new")
+    })).Return(nil)
+    mocks.auditLogs.On("Append", mock.Anything, mock.MatchedBy(func(log *entity.AuditLog) bool {
+        return log.Action == entity.AuditActionRefresh
+    })).Return(nil)
+    mocks.db.ExpectCommit()
+
+    res, err := usecase.Refresh(context.Background(), &proto.RefreshRequest{
+        RefreshToken: "old",
+        Client:       &proto.ClientInfo{IpAddress: "127.0.0.1"},
+    })
+
+    assert.NoError(t, err)
+    assert.Equal(t, "access", res.GetToken().GetAccessToken())
+    assert.Equal(t, "new", res.GetToken().GetRefreshToken())
+    mocks.assertExpectations(t)
+}
+
+func TestAuthUseCaseImpl_Refresh_ReuseRevokesFamily(t *testing.T) {
+    usecase, mocks := setupAuthUseCase(t)
+
+    usedAt := time.Now().Add(-time.Minute)
+    stored := entity.RefreshToken{ID: 1, UserID: 1, FamilyID: "family", UsedAt: &usedAt}
+    expectRefreshToken(mocks, "old", stored, entity.Session{ID: 2, UserID: 1, FamilyID: "family"})
+
+    mocks.db.ExpectBegin()
+    mocks.refreshTokens.On("RevokeFamily", mock.Anything, "family").Return(nil)
+    mocks.sessions.On("Update", mock.Anything, mock.MatchedBy(func(session *entity.Session) bool {
+        return session.ID == 2 && session.RevokedAt != nil
+    })).Return(nil)
+    mocks.db.ExpectCommit()
+
+    res, err := usecase.Refresh(context.Background(), &proto.RefreshRequest{RefreshToken: "old"})
+
+    assert.Nil(t, res)
+    assert.Equal(t, codes.Unauthenticated, status.Code(err))
+    mocks.jwt.AssertNotCalled(t, "GenerateTokens", mock.Anything)
+    mocks.refreshTokens.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+    mocks.assertExpectations(t)
+}
+
+func TestAuthUseCaseImpl_Refresh_RevokedToken(t *testing.T) {
+    usecase, mocks := setupAuthUseCase(t)
+
+    revokedAt := time.Now().Add(-time.Minute)
+    stored := entity.RefreshToken{ID: 1, UserID: 1, FamilyID: "family", RevokedAt: &revokedAt}
+
+    mocks.jwt.On("ValidateRefreshToken", "old").Return(&helper.TokenClaims{UserID: 1}, nil)
+    mocks.user.On("GetUserByID", int64(1)).Return(&proto.GetUserResponse{Id: 1}, nil)
+
+    mocks.db.ExpectBegin()
+    mocks.refreshTokens.On("FindByTokenHashForUpdate", mock.Anything, mock.Anything, helper.HashToken("old")).
+        Run(func(args mock.Arguments) {
+            *args.Get(1).(*entity.RefreshToken) = stored
+        }).
+        Return(nil)
+    mocks.db.ExpectRollback()
+
+    res, err := usecase.Refresh(context.Background(), &proto.RefreshRequest{RefreshToken: "old"})
+
+    assert.Nil(t, res)
+    assert.Equal(t, codes.Unauthenticated, status.Code(err))
+    mocks.refreshTokens.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+    mocks.assertExpectations(t)
+}
+
+func TestAuthUseCaseImpl_Refresh_RevokedSession(t *testing.T) {
+    usecase, mocks := setupAuthUseCase(t)
+
+    revokedAt := time.Now().Add(-time.Minute)
+    stored := entity.RefreshToken{ID: 1, UserID: 1, FamilyID: "family"}
+    expectRefreshToken(mocks, "old", stored, entity.Session{ID: 2, UserID: 1, FamilyID: "family", RevokedAt: &revokedAt})
+
+    mocks.db.ExpectBegin()
+    mocks.db.ExpectRollback()
+
+    res, err := usecase.Refresh(context.Background(), &proto.RefreshRequest{RefreshToken: "old"})
+
+    assert.Nil(t, res)
+    assert.Equal(t, codes.Unauthenticated, status.Code(err))
+    mocks.refreshTokens.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+    mocks.assertExpectations(t)
+}
+
+func TestAuthUseCaseImpl_Logout_RevokesSessionAndFamily(t *testing.T) {
+    usecase, mocks := setupAuthUseCase(t)
+
+    mocks.db.ExpectBegin()
+    mocks.refreshTokens.On("FindByTokenHashForUpdate", mock.Anything, mock.Anything, helper.HashToken("refresh")).
+        Run(func(args mock.Arguments) {
+            *args.Get(1).(*entity.RefreshToken) = entity.RefreshToken{ID: 1, UserID: 1, FamilyID: "family"}
+        }).
+        Return(nil)
+    mocks.sessions.On("FindByFamilyID", mock.Anything, mock.Anything, "family").
+        Run(func(args mock.Arguments) {
+            *args.Get(1).(*entity.Session) = entity.Session{ID: 2, UserID: 1, FamilyID: "family"}
+        }).
+        Return(nil)
+    mocks.sessions.On("Update", mock.Anything, mock.MatchedBy(func(session *entity.Session) bool {
+        return session.ID == 2 && session.RevokedAt != nil
+    })).Return(nil)
+    mocks.refreshTokens.On("RevokeFamily", mock.Anything, "family").Return(nil)
+    mocks.auditLogs.On("Append", mock.Anything, mock.MatchedBy(func(log *entity.AuditLog) bool {
+        return log.Action == entity.AuditActionLogout && log.TargetID == "2"
+    })).Return(nil)
+    mocks.db.ExpectCommit()
+
+    _, err := usecase.Logout(context.Background(), &proto.LogoutRequest{RefreshToken: "refresh"})
+
+    assert.NoError(t, err)
+    mocks.assertExpectations(t)
+}
+
+func TestAuthUseCaseImpl_LogoutAll_RevokesEverySession(t *testing.T) {
+    usecase, mocks := setupAuthUseCase(t)
+
+    mocks.jwt.On("ValidateAccessToken", "access").Return(&helper.TokenClaims{UserID: 1, SessionID: 2}, nil)
+    mocks.sessions.On("FindByID", mock.Anything, mock.Anything, 2).
+        Run(func(args mock.Arguments) {
+            *args.Get(1).(*entity.Session) = entity.Session{ID: 2, UserID: 1, FamilyID: "family"}
+        }).
+        Return(nil)
+
+    mocks.db.ExpectBegin()
+    mocks.sessions.On("RevokeAllByUserID", mock.Anything, 1).Return(nil)
+    mocks.refreshTokens.On("RevokeAllByUserID", mock.Anything, 1).Return(nil)
+    mocks.auditLogs.On("Append", mock.Anything, mock.MatchedBy(func(log *entity.AuditLog) bool {
+        return log.Action == entity.AuditActionLogout && log.TargetType == entity.AuditTargetUser
+    })).Return(nil)
+    mocks.db.ExpectCommit()
+
+    _, err := usecase.LogoutAll(context.Background(), &proto.LogoutAllRequest{AccessToken: "access"})
+
+    assert.NoError(t, err)
+    mocks.assertExpectations(t)
+}
+
+func TestAuthUseCaseImpl_LogoutAll_RevokedSession(t *testing.T) {
+    usecase, mocks := setupAuthUseCase(t)
+
+    revokedAt := time.Now().Add(-time.Minute)
+    mocks.jwt.On("ValidateAccessToken", "access").Return(&helper.TokenClaims{UserID: 1, SessionID: 2}, nil)
+    mocks.sessions.On("FindByID", mock.Anything, mock.Anything, 2).
+        Run(func(args mock.Arguments) {
+            *args.Get(1).(*entity.Session) = entity.Session{ID: 2, UserID: 1, RevokedAt: &revokedAt}
+        }).
+        Return(nil)
+
+    _, err := usecase.LogoutAll(context.Background(), &proto.LogoutAllRequest{AccessToken: "access"})
+
+    assert.Equal(t, codes.Unauthenticated, status.Code(err))
+    mocks.sessions.AssertNotCalled(t, "RevokeAllByUserID", mock.Anything, mock.Anything)
+    mocks.assertExpectations(t)
+}
@@ -0,0 +1,245 @@
+package usecase
+
+import (
+	"auth-service/app/helper"
+	"auth-service/app/model/entity"
+	"auth-service/app/repository"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+// keyRotationLockKey identifies the advisory lock held by the replica that
+// generates the next signing key, so only one key is created per rotation.
+const keyRotationLockKey int64 = 20261019070
+
+// KeyRotator loads the signing keys into the key ring. Keys come from the PEM
+// files of JWT_SIGNING_KEY_FILES, the first one signing, or are generated into
+// the database and rotated every JWT_KEY_ROTATION_INTERVAL.
+type KeyRotator struct {
+	DB                   	*gorm.DB
+	SigningKeyRepository 	repository.SigningKeyRepository
+	Keys                 	*helper.KeyRing
+	Log                  	*logrus.Logger
+	Algorithm            	string
+	KeyFiles             	[]string
+	RotationInterval     	time.Duration
+	RefreshInterval      	time.Duration
+	Propagation          	time.Duration
+	Retention            	time.Duration
+}
+
+func NewKeyRotator(
+	db *gorm.DB,
+	signingKeyRepository repository.SigningKeyRepository,
+	keys *helper.KeyRing,
+	log *logrus.Logger,
+	config *viper.Viper,
+) *KeyRotator {
+	algorithm := config.GetString("JWT_SIGNING_ALGORITHM")
+	if algorithm == "" {
+		algorithm = helper.AlgorithmRS256
+	}
+
+	keyFiles := make([]string, 0)
+	for _, file := range strings.Split(config.GetString("JWT_SIGNING_KEY_FILES"), ",") {
+		if file = strings.TrimSpace(file); file != "" {
+			keyFiles = append(keyFiles, file)
+		}
+	}
+
+	rotationInterval := config.GetDuration("JWT_KEY_ROTATION_INTERVAL")
+	if rotationInterval <= 0 {
+		rotationInterval = 30 * 24 * time.Hour
+	}
+
+	refreshInterval := config.GetDuration("JWT_KEY_REFRESH_INTERVAL")
+	if refreshInterval <= 0 {
+		refreshInterval = time.Minute
+	}
+
+	// A new key only signs once every replica had the chance to load it
+	propagation := 2 * refreshInterval
+
+	retention := config.GetDuration("JWT_KEY_RETENTION")
+	if retention <= 0 {
+		retention = helper.RefreshTokenTTL + propagation
+	}
+
+	rotator := &KeyRotator{
+		DB:                   db,
+		SigningKeyRepository: signingKeyRepository,
+		Keys:                 keys,
+		Log:                  log,
+		Algorithm:            algorithm,
+		KeyFiles:             keyFiles,
+		RotationInterval:     rotationInterval,
+		RefreshInterval:      refreshInterval,
+		Propagation:          propagation,
+		Retention:            retention,
+	}
+
+	// Tokens cannot be issued or verified without keys. On a first start
+	// another replica may hold the lock while it generates the first key.
+	var err error
+	for attempt := 0; attempt < 5; attempt++ {
+		if err = rotator.Load(context.Background()); err == nil {
+			break
+		}
+		time.Sleep(time.Second)
+	}
+	if err != nil {
+		log.WithError(err).Panic("failed to load signing keys")
+	}
+
+	return rotator
+}
+
+// Start rotates and reloads the database keys on every tick until the context is done.
+func (r *KeyRotator) Start(ctx context.Context) {
+	if len(r.KeyFiles) > 0 {
+		return
+	}
+
+	ticker := time.NewTicker(r.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Load(ctx); err != nil {
+				r.Log.Errorf("Failed to load signing keys: %v", err)
+			}
+		}
+	}
+}
+
+// Load fills the key ring from the key files, or from the database after
+// rotating the active key when it is due.
+func (r *KeyRotator) Load(ctx context.Context) error {
+	if len(r.KeyFiles) > 0 {
+		return r.loadFiles()
+	}
+
+	if err := r.rotate(ctx, time.Now().UTC()); err != nil {
+		return err
+	}
+
+	dbKeys := new([]entity.SigningKey)
+	if err := r.SigningKeyRepository.FindAllUnexpired(r.DB.WithContext(ctx), dbKeys, time.Now().UTC()); err != nil {
+		return err
+	}
+
+	keys := make([]*helper.SigningKey, 0, len(*dbKeys))
+	for _, dbKey := range *dbKeys {
+		key, err := helper.ParseSigningKey([]byte(dbKey.PrivateKey), dbKey.CreatedAt)
+		if err != nil {
+			r.Log.Errorf("Failed to parse signing key %s: %v", dbKey.Kid, err)
+			continue
+		}
+		keys = append(keys, key)
+	}
+
+	if len(keys) == 0 {
+		return fmt.Errorf("no signing key available")
+	}
+
+	// Keys are newest first, the newest propagated key signs. The oldest key
+	// keeps signing while the first generated key is still propagating.
+	signing := keys[len(keys)-1]
+	for _, key := range keys {
+		if time.Since(key.CreatedAt) >= r.Propagation {
+			signing = key
+			break
+		}
+	}
+
+	r.Keys.Set(signing, keys)
+
+	return nil
+}
+
+func (r *KeyRotator) loadFiles() error {
+	keys := make([]*helper.SigningKey, 0, len(r.KeyFiles))
+	for _, file := range r.KeyFiles {
+		privateKeyPEM, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		key, err := helper.ParseSigningKey(privateKeyPEM, time.Time{})
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		keys = append(keys, key)
+	}
+
+	r.Keys.Set(keys[0], keys)
+
+	return nil
+}
+
+// rotate generates a new key when there is no active key or the active key
+// is older than the rotation interval. Replaced keys verify until retention ends.
+func (r *KeyRotator) rotate(ctx context.Context, now time.Time) error {
+	tx := r.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	locked, err := r.SigningKeyRepository.TryAdvisoryLock(tx, keyRotationLockKey)
+	if err != nil {
+		return err
+	}
+	if !locked {
+		return nil
+	}
+
+	dbKeys := new([]entity.SigningKey)
+	if err := r.SigningKeyRepository.FindAllUnexpired(tx, dbKeys, now); err != nil {
+		return err
+	}
+
+	for _, dbKey := range *dbKeys {
+		if dbKey.RetiredAt == nil && now.Sub(dbKey.CreatedAt) < r.RotationInterval {
+			return nil
+		}
+	}
+
+	key, err := helper.GenerateSigningKey(r.Algorithm)
+	if err != nil {
+		return err
+	}
+
+	privateKeyPEM, err := key.EncodePrivateKey()
+	if err != nil {
+		return err
+	}
+
+	if err := r.SigningKeyRepository.RetireAllActive(tx, now, now.Add(r.Retention)); err != nil {
+		return err
+	}
+
+	if err := r.SigningKeyRepository.Create(tx, &entity.SigningKey{
+		Kid:        key.Kid,
+		Algorithm:  key.Algorithm,
+		PrivateKey: privateKeyPEM,
+		CreatedAt:  now,
+	}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+
+	r.Log.Infof("Rotated signing key, new kid %s", key.Kid)
+
+	return nil
+}
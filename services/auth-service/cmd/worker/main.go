@@ -2,6 +2,7 @@ package main
 
 import (
 	"auth-service/app/di"
+	"context"
 	"fmt"
 	"log"
 	"net"
@@ -13,8 +14,17 @@ func main() {
 	config := app.Config
 	server := app.GrpcServerRouter.GrpcServer
 	userClient := app.UserClient
+	chatClient := app.ChatClient
 
 	defer userClient.Conn.Close()
+	defer chatClient.Conn.Close()
+
+	// Rotate and reload signing keys, build exports and purge deleted accounts in the background
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go app.KeyRotator.Start(ctx)
+	go app.AccountWorker.Start(ctx)
 
 	port := config.GetString("PORT")
 	if port == "" {
@@ -0,0 +1,9 @@
+CREATE TABLE login_throttles (
+    id BIGSERIAL PRIMARY KEY,
+    subject VARCHAR(320) UNIQUE NOT NULL,
+    failures INT NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP DEFAULT NULL,
+    last_failure_at TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'utc'),
+    created_at TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'utc'),
+    updated_at TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'utc')
+);
\ No newline at end of file
@@ -0,0 +1 @@
+CREATE INDEX sessions_revoked_at_index ON sessions (revoked_at) WHERE revoked_at IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1,32 @@
+CREATE TABLE audit_logs (
+    id BIGSERIAL PRIMARY KEY,
+    action VARCHAR(100) NOT NULL,
+    actor_id BIGINT DEFAULT NULL,
+    target_type VARCHAR(50) NOT NULL DEFAULT '',
+    target_id VARCHAR(320) NOT NULL DEFAULT '',
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    -- JSON keeps the text as written, the hash covers it byte for byte
+    diff JSON NOT NULL DEFAULT '{}',
+    prev_hash VARCHAR(64) NOT NULL DEFAULT '',
+    hash VARCHAR(64) UNIQUE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'utc')
+);
+
+CREATE INDEX audit_logs_actor_id_index ON audit_logs (actor_id, id);
+CREATE INDEX audit_logs_target_index ON audit_logs (target_type, target_id, id);
+CREATE INDEX audit_logs_action_index ON audit_logs (action, id);
+CREATE INDEX audit_logs_created_at_index ON audit_logs (created_at);
+
+-- The log is append-only, rows can neither be changed nor removed
+CREATE FUNCTION audit_logs_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_logs_no_update BEFORE UPDATE OR DELETE ON audit_logs
+    FOR EACH ROW EXECUTE FUNCTION audit_logs_append_only();
+
+CREATE TRIGGER audit_logs_no_truncate BEFORE TRUNCATE ON audit_logs
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_logs_append_only();
\ No newline at end of file
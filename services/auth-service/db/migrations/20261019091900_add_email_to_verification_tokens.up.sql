@@ -0,0 +1,4 @@
+-- Email changes keep the new address on the token until it is confirmed
+ALTER TABLE verification_tokens ADD COLUMN email VARCHAR(255) DEFAULT NULL;
+ALTER TABLE verification_tokens DROP CONSTRAINT IF EXISTS verification_tokens_purpose_check;
+ALTER TABLE verification_tokens ADD CONSTRAINT verification_tokens_purpose_check CHECK (purpose IN ('verify_email', 'reset_password', 'change_email'));
\ No newline at end of file
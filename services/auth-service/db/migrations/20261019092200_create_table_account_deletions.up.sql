@@ -0,0 +1,32 @@
+-- user_id has no foreign key, the deletion outlives the user it purges
+CREATE TABLE account_deletions (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    -- Kept until the farewell email is sent, cleared once the account is purged
+    email VARCHAR(255) DEFAULT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'scheduled' CHECK (status IN ('scheduled', 'purging', 'purged', 'cancelled')),
+    purge_after TIMESTAMP NOT NULL,
+    cancelled_at TIMESTAMP DEFAULT NULL,
+    purged_at TIMESTAMP DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'utc'),
+    updated_at TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'utc')
+);
+
+CREATE UNIQUE INDEX account_deletions_active_user_id_index ON account_deletions (user_id) WHERE status IN ('scheduled', 'purging');
+CREATE INDEX account_deletions_status_purge_after_index ON account_deletions (status, purge_after);
+
+CREATE TABLE account_deletion_steps (
+    id BIGSERIAL PRIMARY KEY,
+    account_deletion_id BIGINT NOT NULL REFERENCES account_deletions(id) ON DELETE CASCADE,
+    service VARCHAR(16) NOT NULL CHECK (service IN ('chat', 'auth', 'user')),
+    status VARCHAR(16) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded')),
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT DEFAULT NULL,
+    completed_at TIMESTAMP DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'utc'),
+    updated_at TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'utc'),
+    UNIQUE (account_deletion_id, service)
+);
+
+ALTER TABLE verification_tokens DROP CONSTRAINT IF EXISTS verification_tokens_purpose_check;
+ALTER TABLE verification_tokens ADD CONSTRAINT verification_tokens_purpose_check CHECK (purpose IN ('verify_email', 'reset_password', 'change_email', 'cancel_deletion'));
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS account_exports;
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- The organization the session works in, NULL is the personal workspace of the user
+ALTER TABLE sessions ADD COLUMN organization_id BIGINT DEFAULT NULL REFERENCES organizations(id) ON DELETE SET NULL;
\ No newline at end of file
@@ -12,6 +12,7 @@ require (
 	github.com/spf13/viper v1.19.0
 	github.com/stretchr/testify v1.10.0
 	golang.org/x/crypto v0.31.0
+	google.golang.org/api v0.213.0
 	google.golang.org/grpc v1.69.0
 	google.golang.org/protobuf v1.36.0
 	gorm.io/driver/postgres v1.5.9
@@ -34,7 +35,6 @@ require (
 	go.opentelemetry.io/otel/metric v1.33.0 // indirect
 	go.opentelemetry.io/otel/trace v1.33.0 // indirect
 	golang.org/x/oauth2 v0.24.0 // indirect
-	google.golang.org/api v0.213.0 // indirect
 )
 
 require (
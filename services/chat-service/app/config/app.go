@@ -1,7 +1,9 @@
 package config
 
 import (
+	"auth-service/app/delivery/client"
 	"auth-service/app/route"
+	"auth-service/app/usecase"
 
 	"github.com/spf13/viper"
 	"gorm.io/gorm"
@@ -11,16 +13,25 @@ type App struct {
 	GrpcServerRouter *route.GrpcServerRouter
 	Config *viper.Viper
 	Gorm *gorm.DB
+	UserClient *client.UserClient
+	Scheduler *usecase.Scheduler
+	WebhookDispatcher *usecase.WebhookDispatcher
 }
 
 func NewApp(
 	grpcServerRouter *route.GrpcServerRouter,
 	config *viper.Viper,
 	gormDB *gorm.DB,
+	userClient *client.UserClient,
+	scheduler *usecase.Scheduler,
+	webhookDispatcher *usecase.WebhookDispatcher,
 ) *App {
 	return &App{
 		GrpcServerRouter: grpcServerRouter,
 		Config: config,
 		Gorm: gormDB,
+		UserClient: userClient,
+		Scheduler: scheduler,
+		WebhookDispatcher: webhookDispatcher,
 	}
 }
\ No newline at end of file
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"auth-service/app/helper"
+	"context"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	protov2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// internalUserField of every chat request is the calling user, it comes from the signed identity
+const internalUserField protoreflect.Name = "userId"
+
+// internalIdentity requires every call to carry an identity signed with INTERNAL_AUTH_SECRET.
+// The user of the identity overwrites internalUserField of the requests, so callers
+// cannot act for another user by changing the body. Calls are only trusted without
+// the secret when INTERNAL_AUTH_DISABLED is set, for local development.
+type internalIdentity struct {
+	secret   []byte
+	disabled bool
+}
+
+func newInternalIdentity(config *viper.Viper, log *logrus.Logger) *internalIdentity {
+	if config.GetBool("INTERNAL_AUTH_DISABLED") {
+		log.Warn("INTERNAL_AUTH_DISABLED is set, gRPC calls are not authenticated")
+		return &internalIdentity{disabled: true}
+	}
+
+	secret := []byte(config.GetString("INTERNAL_AUTH_SECRET"))
+	if len(secret) == 0 {
+		log.Fatal("INTERNAL_AUTH_SECRET is not set, set INTERNAL_AUTH_DISABLED=true to run without it")
+	}
+
+	return &internalIdentity{secret: secret}
+}
+
+func (i *internalIdentity) verify(ctx context.Context, method string) (*helper.InternalIdentity, error) {
+	// Reflection only describes the API, it stays open for tooling
+	if i.disabled || strings.HasPrefix(method, "/grpc.reflection.") {
+		return nil, nil
+	}
+
+	values := metadata.ValueFromIncomingContext(ctx, helper.InternalIdentityHeader)
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "Missing internal identity")
+	}
+
+	identity, err := helper.VerifyInternalIdentity(i.secret, values[0], method)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "Invalid internal identity")
+	}
+
+	return identity, nil
+}
+
+// bind sets the user field of the request to the user of the identity, zero for calls made by services
+func (i *internalIdentity) bind(identity *helper.InternalIdentity, req any) {
+	if identity == nil || internalUserField == "" {
+		return
+	}
+
+	message, ok := req.(protov2.Message)
+	if !ok {
+		return
+	}
+
+	reflected := message.ProtoReflect()
+	field := reflected.Descriptor().Fields().ByName(internalUserField)
+	if field == nil || field.Kind() != protoreflect.Int64Kind {
+		return
+	}
+
+	reflected.Set(field, protoreflect.ValueOfInt64(identity.UserID))
+}
+
+func (i *internalIdentity) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	identity, err := i.verify(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	i.bind(identity, req)
+	return handler(ctx, req)
+}
+
+func (i *internalIdentity) StreamInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	identity, err := i.verify(stream.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &identityServerStream{ServerStream: stream, bind: func(req any) {
+		i.bind(identity, req)
+	}})
+}
+
+// identityServerStream binds every message received on the stream
+type identityServerStream struct {
+	grpc.ServerStream
+	bind func(req any)
+}
+
+func (s *identityServerStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	s.bind(m)
+	return nil
+}
\ No newline at end of file
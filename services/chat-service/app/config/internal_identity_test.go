@@ -0,0 +1,78 @@
+package config
+
+import (
+	"auth-service/app/helper"
+	"auth-service/app/model/proto"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const getChatsMethod = "/proto.ChatService/GetChats"
+
+// callWithIdentity runs the interceptor with the token and returns the request the handler received
+func callWithIdentity(identity *internalIdentity, token string, method string, req *proto.GetChatsRequest) (*proto.GetChatsRequest, error) {
+    ctx := context.Background()
+    if token != "" {
+        ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(helper.InternalIdentityHeader, token))
+    }
+
+    var received *proto.GetChatsRequest
+    _, err := identity.UnaryInterceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req any) (any, error) {
+        received = req.(*proto.GetChatsRequest)
+        return nil, nil
+    })
+
+    return received, err
+}
+
+func TestInternalIdentity_BindsSignedUser(t *testing.T) {
+    identity := &internalIdentity{secret: []byte("secret")}
+
+    token, err := helper.SignInternalIdentity([]byte("secret"), "api-gateway", 7, getChatsMethod)
+    assert.NoError(t, err)
+
+    // The user in the body is replaced by the signed one
+    received, err := callWithIdentity(identity, token, getChatsMethod, &proto.GetChatsRequest{UserId: 9})
+
+    assert.NoError(t, err)
+    assert.Equal(t, int64(7), received.GetUserId())
+}
+
+func TestInternalIdentity_RefusesInvalidIdentity(t *testing.T) {
+    identity := &internalIdentity{secret: []byte("secret")}
+
+    otherSecret, err := helper.SignInternalIdentity([]byte("other"), "api-gateway", 7, getChatsMethod)
+    assert.NoError(t, err)
+
+    otherMethod, err := helper.SignInternalIdentity([]byte("secret"), "api-gateway", 7, "/proto.ChatService/GetMessages")
+    assert.NoError(t, err)
+
+    for name, token := range map[string]string{
+        "missing":      "",
+        "other secret": otherSecret,
+        "other method": otherMethod,
+        "malformed":    "not-a-token",
+    } {
+        t.Run(name, func(t *testing.T) {
+            received, err := callWithIdentity(identity, token, getChatsMethod, &proto.GetChatsRequest{UserId: 9})
+
+            assert.Nil(t, received)
+            assert.Equal(t, codes.Unauthenticated, status.Code(err))
+        })
+    }
+}
+
+func TestInternalIdentity_Disabled(t *testing.T) {
+    identity := &internalIdentity{disabled: true}
+
+    received, err := callWithIdentity(identity, "", getChatsMethod, &proto.GetChatsRequest{UserId: 9})
+
+    assert.NoError(t, err)
+    assert.Equal(t, int64(9), received.GetUserId())
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"auth-service/app/helper"
+	"auth-service/app/model/proto"
+	"fmt"
+
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type UserClient struct {
+	Service proto.UserServiceClient
+	Conn *grpc.ClientConn
+}
+
+func NewUserClient(config *viper.Viper) *UserClient {
+	userHost := config.GetString("GRPC_USER_SERVICE")
+	secure := config.GetBool("GRPC_USER_SERVICE_SECURE")
+
+	var opts []grpc.DialOption
+
+	if userHost != "" {
+		opts = append(opts, grpc.WithAuthority(userHost))
+	}
+
+	if !secure {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	} else {
+		// Presents the certificate of the service when the server requires mutual TLS
+		tlsConfig, err := helper.ClientTLSConfig(config)
+		if err != nil {
+			panic(err)
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	}
+
+	opts = append(opts, internalIdentityOptions(config)...)
+
+	userConn, err := grpc.NewClient(userHost, opts...)
+	if err != nil {
+		panic(fmt.Errorf("error connecting to user service: %v", err))
+	}
+
+	userService := proto.NewUserServiceClient(userConn)
+
+	return &UserClient{
+		Service: userService,
+		Conn: userConn,
+	}
+}
\ No newline at end of file
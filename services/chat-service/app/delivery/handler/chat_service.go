@@ -12,21 +12,86 @@ type ChatService interface {
     GetChatByIdAndUserId(ctx context.Context, req *proto.GetChatRequest) (*proto.GetChatResponse, error)
     GetMessages(ctx context.Context, req *proto.GetMessagesRequest) (*proto.GetMessagesResponse, error)
     CreateMessage(cmr proto.ChatService_CreateMessageServer) error
+    CreateAssistant(ctx context.Context, req *proto.CreateAssistantRequest) (*proto.Assistant, error)
+    GetAssistants(ctx context.Context, req *proto.GetAssistantsRequest) (*proto.GetAssistantsResponse, error)
+    GetAssistant(ctx context.Context, req *proto.GetAssistantRequest) (*proto.Assistant, error)
+    UpdateAssistant(ctx context.Context, req *proto.UpdateAssistantRequest) (*proto.Assistant, error)
+    DeleteAssistant(ctx context.Context, req *proto.DeleteAssistantRequest) (*proto.Empty, error)
+    ShareAssistant(ctx context.Context, req *proto.ShareAssistantRequest) (*proto.Assistant, error)
+    GetSharedAssistant(ctx context.Context, req *proto.GetSharedAssistantRequest) (*proto.Assistant, error)
+    CreateProject(ctx context.Context, req *proto.CreateProjectRequest) (*proto.Project, error)
+    GetProjects(ctx context.Context, req *proto.GetProjectsRequest) (*proto.GetProjectsResponse, error)
+    GetProject(ctx context.Context, req *proto.GetProjectRequest) (*proto.Project, error)
+    UpdateProject(ctx context.Context, req *proto.UpdateProjectRequest) (*proto.Project, error)
+    DeleteProject(ctx context.Context, req *proto.DeleteProjectRequest) (*proto.Empty, error)
+    MoveChat(ctx context.Context, req *proto.MoveChatRequest) (*proto.Chat, error)
+    InviteChatMember(ctx context.Context, req *proto.InviteChatMemberRequest) (*proto.ChatMember, error)
+    GetChatMembers(ctx context.Context, req *proto.GetChatMembersRequest) (*proto.GetChatMembersResponse, error)
+    UpdateChatMember(ctx context.Context, req *proto.UpdateChatMemberRequest) (*proto.ChatMember, error)
+    RemoveChatMember(ctx context.Context, req *proto.RemoveChatMemberRequest) (*proto.Empty, error)
+    CreateScheduledPrompt(ctx context.Context, req *proto.CreateScheduledPromptRequest) (*proto.ScheduledPrompt, error)
+    GetScheduledPrompts(ctx context.Context, req *proto.GetScheduledPromptsRequest) (*proto.GetScheduledPromptsResponse, error)
+    GetScheduledPrompt(ctx context.Context, req *proto.GetScheduledPromptRequest) (*proto.ScheduledPrompt, error)
+    UpdateScheduledPrompt(ctx context.Context, req *proto.UpdateScheduledPromptRequest) (*proto.ScheduledPrompt, error)
+    DeleteScheduledPrompt(ctx context.Context, req *proto.DeleteScheduledPromptRequest) (*proto.Empty, error)
+    GetScheduledPromptRuns(ctx context.Context, req *proto.GetScheduledPromptRunsRequest) (*proto.GetScheduledPromptRunsResponse, error)
+    DeleteChat(ctx context.Context, req *proto.DeleteChatRequest) (*proto.Empty, error)
+    CreateWebhook(ctx context.Context, req *proto.CreateWebhookRequest) (*proto.Webhook, error)
+    GetWebhooks(ctx context.Context, req *proto.GetWebhooksRequest) (*proto.GetWebhooksResponse, error)
+    GetWebhook(ctx context.Context, req *proto.GetWebhookRequest) (*proto.Webhook, error)
+    UpdateWebhook(ctx context.Context, req *proto.UpdateWebhookRequest) (*proto.Webhook, error)
+    DeleteWebhook(ctx context.Context, req *proto.DeleteWebhookRequest) (*proto.Empty, error)
+    GetWebhookDeliveries(ctx context.Context, req *proto.GetWebhookDeliveriesRequest) (*proto.GetWebhookDeliveriesResponse, error)
+    RedeliverWebhookDelivery(ctx context.Context, req *proto.RedeliverWebhookDeliveryRequest) (*proto.WebhookDelivery, error)
+    CompareMessage(cmr proto.ChatService_CompareMessageServer) error
+    PreferMessage(ctx context.Context, req *proto.PreferMessageRequest) (*proto.FullMessage, error)
+    GetUsageReport(ctx context.Context, req *proto.GetReportRequest) (*proto.UsageReport, error)
+    GetFeedbackReport(ctx context.Context, req *proto.GetReportRequest) (*proto.FeedbackReport, error)
+    ExportUserData(req *proto.ExportUserDataRequest, stream proto.ChatService_ExportUserDataServer) error
+    PurgeUserData(ctx context.Context, req *proto.PurgeUserDataRequest) (*proto.Empty, error)
+    CreatePromptTemplate(ctx context.Context, req *proto.CreatePromptTemplateRequest) (*proto.PromptTemplate, error)
+    GetPromptTemplates(ctx context.Context, req *proto.GetPromptTemplatesRequest) (*proto.GetPromptTemplatesResponse, error)
+    UpdatePromptTemplate(ctx context.Context, req *proto.UpdatePromptTemplateRequest) (*proto.PromptTemplate, error)
+    DeletePromptTemplate(ctx context.Context, req *proto.DeletePromptTemplateRequest) (*proto.Empty, error)
 }
 
 type ChatServiceImpl struct {
     proto.UnimplementedChatServiceServer
     chatUseCase usecase.ChatUseCase
     messageUseCase usecase.MessageUseCase
+    assistantUseCase usecase.AssistantUseCase
+    projectUseCase usecase.ProjectUseCase
+    chatMemberUseCase usecase.ChatMemberUseCase
+    scheduledPromptUseCase usecase.ScheduledPromptUseCase
+    webhookUseCase usecase.WebhookUseCase
+    reportUseCase usecase.ReportUseCase
+    accountUseCase usecase.AccountUseCase
+    promptTemplateUseCase usecase.PromptTemplateUseCase
 }
 
 func NewChatServiceImpl(
     chatUseCase usecase.ChatUseCase,
     messageUseCase usecase.MessageUseCase,
+    assistantUseCase usecase.AssistantUseCase,
+    projectUseCase usecase.ProjectUseCase,
+    chatMemberUseCase usecase.ChatMemberUseCase,
+    scheduledPromptUseCase usecase.ScheduledPromptUseCase,
+    webhookUseCase usecase.WebhookUseCase,
+    reportUseCase usecase.ReportUseCase,
+    accountUseCase usecase.AccountUseCase,
+    promptTemplateUseCase usecase.PromptTemplateUseCase,
 ) *ChatServiceImpl {
     return &ChatServiceImpl{
         chatUseCase: chatUseCase,
         messageUseCase: messageUseCase,
+        assistantUseCase: assistantUseCase,
+        projectUseCase: projectUseCase,
+        chatMemberUseCase: chatMemberUseCase,
+        scheduledPromptUseCase: scheduledPromptUseCase,
+        webhookUseCase: webhookUseCase,
+        reportUseCase: reportUseCase,
+        accountUseCase: accountUseCase,
+        promptTemplateUseCase: promptTemplateUseCase,
     }
 }
 
@@ -60,3 +125,362 @@ func (s *ChatServiceImpl) GetMessages(ctx context.Context, req *proto.GetMessage
 func (s *ChatServiceImpl) CreateMessage(cmr proto.ChatService_CreateMessageServer) error {
     return s.messageUseCase.CreateMessage(cmr)
 }
+
+func (s *ChatServiceImpl) CreateAssistant(ctx context.Context, req *proto.CreateAssistantRequest) (*proto.Assistant, error) {
+    assistant, err := s.assistantUseCase.CreateAssistant(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return assistant, nil
+}
+
+func (s *ChatServiceImpl) GetAssistants(ctx context.Context, req *proto.GetAssistantsRequest) (*proto.GetAssistantsResponse, error) {
+    assistants, err := s.assistantUseCase.GetAssistants(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return assistants, nil
+}
+
+func (s *ChatServiceImpl) GetAssistant(ctx context.Context, req *proto.GetAssistantRequest) (*proto.Assistant, error) {
+    assistant, err := s.assistantUseCase.GetAssistant(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return assistant, nil
+}
+
+func (s *ChatServiceImpl) UpdateAssistant(ctx context.Context, req *proto.UpdateAssistantRequest) (*proto.Assistant, error) {
+    assistant, err := s.assistantUseCase.UpdateAssistant(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return assistant, nil
+}
+
+func (s *ChatServiceImpl) DeleteAssistant(ctx context.Context, req *proto.DeleteAssistantRequest) (*proto.Empty, error) {
+    empty, err := s.assistantUseCase.DeleteAssistant(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return empty, nil
+}
+
+func (s *ChatServiceImpl) ShareAssistant(ctx context.Context, req *proto.ShareAssistantRequest) (*proto.Assistant, error) {
+    assistant, err := s.assistantUseCase.ShareAssistant(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return assistant, nil
+}
+
+func (s *ChatServiceImpl) GetSharedAssistant(ctx context.Context, req *proto.GetSharedAssistantRequest) (*proto.Assistant, error) {
+    assistant, err := s.assistantUseCase.GetSharedAssistant(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return assistant, nil
+}
+
+func (s *ChatServiceImpl) CreateProject(ctx context.Context, req *proto.CreateProjectRequest) (*proto.Project, error) {
+    project, err := s.projectUseCase.CreateProject(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return project, nil
+}
+
+func (s *ChatServiceImpl) GetProjects(ctx context.Context, req *proto.GetProjectsRequest) (*proto.GetProjectsResponse, error) {
+    projects, err := s.projectUseCase.GetProjects(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return projects, nil
+}
+
+func (s *ChatServiceImpl) GetProject(ctx context.Context, req *proto.GetProjectRequest) (*proto.Project, error) {
+    project, err := s.projectUseCase.GetProject(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return project, nil
+}
+
+func (s *ChatServiceImpl) UpdateProject(ctx context.Context, req *proto.UpdateProjectRequest) (*proto.Project, error) {
+    project, err := s.projectUseCase.UpdateProject(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return project, nil
+}
+
+func (s *ChatServiceImpl) DeleteProject(ctx context.Context, req *proto.DeleteProjectRequest) (*proto.Empty, error) {
+    empty, err := s.projectUseCase.DeleteProject(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return empty, nil
+}
+
+func (s *ChatServiceImpl) MoveChat(ctx context.Context, req *proto.MoveChatRequest) (*proto.Chat, error) {
+    chat, err := s.chatUseCase.MoveChat(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return chat, nil
+}
+
+func (s *ChatServiceImpl) InviteChatMember(ctx context.Context, req *proto.InviteChatMemberRequest) (*proto.ChatMember, error) {
+    member, err := s.chatMemberUseCase.InviteChatMember(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return member, nil
+}
+
+func (s *ChatServiceImpl) GetChatMembers(ctx context.Context, req *proto.GetChatMembersRequest) (*proto.GetChatMembersResponse, error) {
+    members, err := s.chatMemberUseCase.GetChatMembers(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return members, nil
+}
+
+func (s *ChatServiceImpl) UpdateChatMember(ctx context.Context, req *proto.UpdateChatMemberRequest) (*proto.ChatMember, error) {
+    member, err := s.chatMemberUseCase.UpdateChatMember(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return member, nil
+}
+
+func (s *ChatServiceImpl) RemoveChatMember(ctx context.Context, req *proto.RemoveChatMemberRequest) (*proto.Empty, error) {
+    empty, err := s.chatMemberUseCase.RemoveChatMember(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return empty, nil
+}
+
+func (s *ChatServiceImpl) CreateScheduledPrompt(ctx context.Context, req *proto.CreateScheduledPromptRequest) (*proto.ScheduledPrompt, error) {
+    scheduledPrompt, err := s.scheduledPromptUseCase.CreateScheduledPrompt(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return scheduledPrompt, nil
+}
+
+func (s *ChatServiceImpl) GetScheduledPrompts(ctx context.Context, req *proto.GetScheduledPromptsRequest) (*proto.GetScheduledPromptsResponse, error) {
+    scheduledPrompts, err := s.scheduledPromptUseCase.GetScheduledPrompts(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return scheduledPrompts, nil
+}
+
+func (s *ChatServiceImpl) GetScheduledPrompt(ctx context.Context, req *proto.GetScheduledPromptRequest) (*proto.ScheduledPrompt, error) {
+    scheduledPrompt, err := s.scheduledPromptUseCase.GetScheduledPrompt(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return scheduledPrompt, nil
+}
+
+func (s *ChatServiceImpl) UpdateScheduledPrompt(ctx context.Context, req *proto.UpdateScheduledPromptRequest) (*proto.ScheduledPrompt, error) {
+    scheduledPrompt, err := s.scheduledPromptUseCase.UpdateScheduledPrompt(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return scheduledPrompt, nil
+}
+
+func (s *ChatServiceImpl) DeleteScheduledPrompt(ctx context.Context, req *proto.DeleteScheduledPromptRequest) (*proto.Empty, error) {
+    empty, err := s.scheduledPromptUseCase.DeleteScheduledPrompt(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return empty, nil
+}
+
+func (s *ChatServiceImpl) GetScheduledPromptRuns(ctx context.Context, req *proto.GetScheduledPromptRunsRequest) (*proto.GetScheduledPromptRunsResponse, error) {
+    runs, err := s.scheduledPromptUseCase.GetScheduledPromptRuns(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return runs, nil
+}
+
+func (s *ChatServiceImpl) DeleteChat(ctx context.Context, req *proto.DeleteChatRequest) (*proto.Empty, error) {
+    empty, err := s.chatUseCase.DeleteChat(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return empty, nil
+}
+
+func (s *ChatServiceImpl) CreateWebhook(ctx context.Context, req *proto.CreateWebhookRequest) (*proto.Webhook, error) {
+    webhook, err := s.webhookUseCase.CreateWebhook(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return webhook, nil
+}
+
+func (s *ChatServiceImpl) GetWebhooks(ctx context.Context, req *proto.GetWebhooksRequest) (*proto.GetWebhooksResponse, error) {
+    webhooks, err := s.webhookUseCase.GetWebhooks(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return webhooks, nil
+}
+
+func (s *ChatServiceImpl) GetWebhook(ctx context.Context, req *proto.GetWebhookRequest) (*proto.Webhook, error) {
+    webhook, err := s.webhookUseCase.GetWebhook(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return webhook, nil
+}
+
+func (s *ChatServiceImpl) UpdateWebhook(ctx context.Context, req *proto.UpdateWebhookRequest) (*proto.Webhook, error) {
+    webhook, err := s.webhookUseCase.UpdateWebhook(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return webhook, nil
+}
+
+func (s *ChatServiceImpl) DeleteWebhook(ctx context.Context, req *proto.DeleteWebhookRequest) (*proto.Empty, error) {
+    empty, err := s.webhookUseCase.DeleteWebhook(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return empty, nil
+}
+
+func (s *ChatServiceImpl) GetWebhookDeliveries(ctx context.Context, req *proto.GetWebhookDeliveriesRequest) (*proto.GetWebhookDeliveriesResponse, error) {
+    deliveries, err := s.webhookUseCase.GetWebhookDeliveries(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return deliveries, nil
+}
+
+func (s *ChatServiceImpl) RedeliverWebhookDelivery(ctx context.Context, req *proto.RedeliverWebhookDeliveryRequest) (*proto.WebhookDelivery, error) {
+    delivery, err := s.webhookUseCase.RedeliverWebhookDelivery(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return delivery, nil
+}
+
+func (s *ChatServiceImpl) CompareMessage(cmr proto.ChatService_CompareMessageServer) error {
+    return s.messageUseCase.CompareMessage(cmr)
+}
+
+func (s *ChatServiceImpl) PreferMessage(ctx context.Context, req *proto.PreferMessageRequest) (*proto.FullMessage, error) {
+    message, err := s.messageUseCase.PreferMessage(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return message, nil
+}
+
+func (s *ChatServiceImpl) GetUsageReport(ctx context.Context, req *proto.GetReportRequest) (*proto.UsageReport, error) {
+    report, err := s.reportUseCase.GetUsageReport(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return report, nil
+}
+
+func (s *ChatServiceImpl) GetFeedbackReport(ctx context.Context, req *proto.GetReportRequest) (*proto.FeedbackReport, error) {
+    report, err := s.reportUseCase.GetFeedbackReport(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return report, nil
+}
+
+func (s *ChatServiceImpl) ExportUserData(req *proto.ExportUserDataRequest, stream proto.ChatService_ExportUserDataServer) error {
+    return s.accountUseCase.ExportUserData(req, stream)
+}
+
+func (s *ChatServiceImpl) PurgeUserData(ctx context.Context, req *proto.PurgeUserDataRequest) (*proto.Empty, error) {
+    empty, err := s.accountUseCase.PurgeUserData(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return empty, nil
+}
+
+func (s *ChatServiceImpl) CreatePromptTemplate(ctx context.Context, req *proto.CreatePromptTemplateRequest) (*proto.PromptTemplate, error) {
+    promptTemplate, err := s.promptTemplateUseCase.CreatePromptTemplate(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return promptTemplate, nil
+}
+
+func (s *ChatServiceImpl) GetPromptTemplates(ctx context.Context, req *proto.GetPromptTemplatesRequest) (*proto.GetPromptTemplatesResponse, error) {
+    promptTemplates, err := s.promptTemplateUseCase.GetPromptTemplates(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return promptTemplates, nil
+}
+
+func (s *ChatServiceImpl) UpdatePromptTemplate(ctx context.Context, req *proto.UpdatePromptTemplateRequest) (*proto.PromptTemplate, error) {
+    promptTemplate, err := s.promptTemplateUseCase.UpdatePromptTemplate(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return promptTemplate, nil
+}
+
+func (s *ChatServiceImpl) DeletePromptTemplate(ctx context.Context, req *proto.DeletePromptTemplateRequest) (*proto.Empty, error) {
+    empty, err := s.promptTemplateUseCase.DeletePromptTemplate(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return empty, nil
+}
\ No newline at end of file
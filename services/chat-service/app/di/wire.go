@@ -5,6 +5,7 @@ package di
 
 import (
 	"auth-service/app/config"
+	"auth-service/app/delivery/client"
 	"auth-service/app/delivery/handler"
 	"auth-service/app/repository"
 	"auth-service/app/route"
@@ -27,6 +28,66 @@ var messageSet = wire.NewSet(
 	wire.Bind(new(usecase.MessageUseCase), new(*usecase.MessageUseCaseImpl)),
 )
 
+var assistantSet = wire.NewSet(
+	repository.NewAssistantRepositoryImpl,
+	wire.Bind(new(repository.AssistantRepository), new(*repository.AssistantRepositoryImpl)),
+	usecase.NewAssistantUseCaseImpl,
+	wire.Bind(new(usecase.AssistantUseCase), new(*usecase.AssistantUseCaseImpl)),
+)
+
+var projectSet = wire.NewSet(
+	repository.NewProjectRepositoryImpl,
+	wire.Bind(new(repository.ProjectRepository), new(*repository.ProjectRepositoryImpl)),
+	usecase.NewProjectUseCaseImpl,
+	wire.Bind(new(usecase.ProjectUseCase), new(*usecase.ProjectUseCaseImpl)),
+)
+
+var chatMemberSet = wire.NewSet(
+	repository.NewChatMemberRepositoryImpl,
+	wire.Bind(new(repository.ChatMemberRepository), new(*repository.ChatMemberRepositoryImpl)),
+	usecase.NewChatMemberUseCaseImpl,
+	wire.Bind(new(usecase.ChatMemberUseCase), new(*usecase.ChatMemberUseCaseImpl)),
+)
+
+var scheduledPromptSet = wire.NewSet(
+	repository.NewScheduledPromptRepositoryImpl,
+	wire.Bind(new(repository.ScheduledPromptRepository), new(*repository.ScheduledPromptRepositoryImpl)),
+	usecase.NewScheduledPromptUseCaseImpl,
+	wire.Bind(new(usecase.ScheduledPromptUseCase), new(*usecase.ScheduledPromptUseCaseImpl)),
+	usecase.NewScheduler,
+)
+
+var webhookSet = wire.NewSet(
+	repository.NewWebhookRepositoryImpl,
+	wire.Bind(new(repository.WebhookRepository), new(*repository.WebhookRepositoryImpl)),
+	usecase.NewWebhookUseCaseImpl,
+	wire.Bind(new(usecase.WebhookUseCase), new(*usecase.WebhookUseCaseImpl)),
+	usecase.NewWebhookDispatcher,
+)
+
+var reportSet = wire.NewSet(
+	repository.NewReportRepositoryImpl,
+	wire.Bind(new(repository.ReportRepository), new(*repository.ReportRepositoryImpl)),
+	usecase.NewReportUseCaseImpl,
+	wire.Bind(new(usecase.ReportUseCase), new(*usecase.ReportUseCaseImpl)),
+)
+
+var promptTemplateSet = wire.NewSet(
+	repository.NewPromptTemplateRepositoryImpl,
+	wire.Bind(new(repository.PromptTemplateRepository), new(*repository.PromptTemplateRepositoryImpl)),
+	usecase.NewPromptTemplateUseCaseImpl,
+	wire.Bind(new(usecase.PromptTemplateUseCase), new(*usecase.PromptTemplateUseCaseImpl)),
+)
+
+var accountSet = wire.NewSet(
+	usecase.NewAccountUseCaseImpl,
+	wire.Bind(new(usecase.AccountUseCase), new(*usecase.AccountUseCaseImpl)),
+)
+
+var grpcClientSet = wire.NewSet(
+	client.NewUserClient,
+)
+
 var serviceSet = wire.NewSet(
 	handler.NewChatServiceImpl,
 	wire.Bind(new(handler.ChatService), new(*handler.ChatServiceImpl)),
@@ -44,6 +105,15 @@ func InitializedApp() *config.App {
 		route.NewGrpcServerRouter,
 		chatSet,
 		messageSet,
+		assistantSet,
+		projectSet,
+		chatMemberSet,
+		scheduledPromptSet,
+		webhookSet,
+		reportSet,
+		promptTemplateSet,
+		accountSet,
+		grpcClientSet,
 		serviceSet,
 	)
 	return nil
@@ -8,6 +8,7 @@ package di
 
 import (
 	"auth-service/app/config"
+	"auth-service/app/delivery/client"
 	"auth-service/app/delivery/handler"
 	"auth-service/app/repository"
 	"auth-service/app/route"
@@ -19,18 +20,36 @@ import (
 
 func InitializedApp() *config.App {
 	logger := config.NewLogger()
-	server := config.NewGrpcServer(logger)
 	viper := config.NewViper(logger)
+	server := config.NewGrpcServer(logger, viper)
 	db := config.NewDatabase(viper)
 	chatRepositoryImpl := repository.NewChatRepositoryImpl()
+	projectRepositoryImpl := repository.NewProjectRepositoryImpl()
+	chatMemberRepositoryImpl := repository.NewChatMemberRepositoryImpl()
+	webhookRepositoryImpl := repository.NewWebhookRepositoryImpl()
+	userClient := client.NewUserClient(viper)
 	validate := config.NewValidator()
-	chatUseCaseImpl := usecase.NewChatUseCaseImpl(db, chatRepositoryImpl, logger, validate)
+	chatUseCaseImpl := usecase.NewChatUseCaseImpl(db, chatRepositoryImpl, projectRepositoryImpl, chatMemberRepositoryImpl, webhookRepositoryImpl, userClient, logger, validate)
 	messageRepositoryImpl := repository.NewMessageRepositoryImpl()
-	client := config.NewGemini(viper, logger)
-	messageUseCaseImpl := usecase.NewMessageUseCaseImpl(db, messageRepositoryImpl, chatRepositoryImpl, client, logger, validate)
-	chatServiceImpl := handler.NewChatServiceImpl(chatUseCaseImpl, messageUseCaseImpl)
+	assistantRepositoryImpl := repository.NewAssistantRepositoryImpl()
+	genaiClient := config.NewGemini(viper, logger)
+	messageUseCaseImpl := usecase.NewMessageUseCaseImpl(db, messageRepositoryImpl, chatRepositoryImpl, assistantRepositoryImpl, projectRepositoryImpl, chatMemberRepositoryImpl, webhookRepositoryImpl, userClient, genaiClient, logger, validate)
+	assistantUseCaseImpl := usecase.NewAssistantUseCaseImpl(db, assistantRepositoryImpl, userClient, logger, validate)
+	projectUseCaseImpl := usecase.NewProjectUseCaseImpl(db, projectRepositoryImpl, logger, validate)
+	chatMemberUseCaseImpl := usecase.NewChatMemberUseCaseImpl(db, chatRepositoryImpl, chatMemberRepositoryImpl, userClient, logger, validate)
+	scheduledPromptRepositoryImpl := repository.NewScheduledPromptRepositoryImpl()
+	scheduledPromptUseCaseImpl := usecase.NewScheduledPromptUseCaseImpl(db, scheduledPromptRepositoryImpl, chatRepositoryImpl, chatMemberRepositoryImpl, logger, validate)
+	webhookUseCaseImpl := usecase.NewWebhookUseCaseImpl(db, webhookRepositoryImpl, logger, validate)
+	reportRepositoryImpl := repository.NewReportRepositoryImpl()
+	reportUseCaseImpl := usecase.NewReportUseCaseImpl(db, reportRepositoryImpl, userClient, logger, validate)
+	promptTemplateRepositoryImpl := repository.NewPromptTemplateRepositoryImpl()
+	accountUseCaseImpl := usecase.NewAccountUseCaseImpl(db, chatRepositoryImpl, messageRepositoryImpl, chatMemberRepositoryImpl, projectRepositoryImpl, assistantRepositoryImpl, scheduledPromptRepositoryImpl, webhookRepositoryImpl, promptTemplateRepositoryImpl, userClient, logger, validate)
+	promptTemplateUseCaseImpl := usecase.NewPromptTemplateUseCaseImpl(db, promptTemplateRepositoryImpl, userClient, logger, validate)
+	chatServiceImpl := handler.NewChatServiceImpl(chatUseCaseImpl, messageUseCaseImpl, assistantUseCaseImpl, projectUseCaseImpl, chatMemberUseCaseImpl, scheduledPromptUseCaseImpl, webhookUseCaseImpl, reportUseCaseImpl, accountUseCaseImpl, promptTemplateUseCaseImpl)
 	grpcServerRouter := route.NewGrpcServerRouter(server, chatServiceImpl)
-	app := config.NewApp(grpcServerRouter, viper, db)
+	scheduler := usecase.NewScheduler(db, scheduledPromptRepositoryImpl, messageUseCaseImpl, logger, viper)
+	webhookDispatcher := usecase.NewWebhookDispatcher(db, webhookRepositoryImpl, logger, viper)
+	app := config.NewApp(grpcServerRouter, viper, db, userClient, scheduler, webhookDispatcher)
 	return app
 }
 
@@ -40,4 +59,22 @@ var chatSet = wire.NewSet(repository.NewChatRepositoryImpl, wire.Bind(new(reposi
 
 var messageSet = wire.NewSet(repository.NewMessageRepositoryImpl, wire.Bind(new(repository.MessageRepository), new(*repository.MessageRepositoryImpl)), usecase.NewMessageUseCaseImpl, wire.Bind(new(usecase.MessageUseCase), new(*usecase.MessageUseCaseImpl)))
 
+var assistantSet = wire.NewSet(repository.NewAssistantRepositoryImpl, wire.Bind(new(repository.AssistantRepository), new(*repository.AssistantRepositoryImpl)), usecase.NewAssistantUseCaseImpl, wire.Bind(new(usecase.AssistantUseCase), new(*usecase.AssistantUseCaseImpl)))
+
+var projectSet = wire.NewSet(repository.NewProjectRepositoryImpl, wire.Bind(new(repository.ProjectRepository), new(*repository.ProjectRepositoryImpl)), usecase.NewProjectUseCaseImpl, wire.Bind(new(usecase.ProjectUseCase), new(*usecase.ProjectUseCaseImpl)))
+
+var chatMemberSet = wire.NewSet(repository.NewChatMemberRepositoryImpl, wire.Bind(new(repository.ChatMemberRepository), new(*repository.ChatMemberRepositoryImpl)), usecase.NewChatMemberUseCaseImpl, wire.Bind(new(usecase.ChatMemberUseCase), new(*usecase.ChatMemberUseCaseImpl)))
+
+var scheduledPromptSet = wire.NewSet(repository.NewScheduledPromptRepositoryImpl, wire.Bind(new(repository.ScheduledPromptRepository), new(*repository.ScheduledPromptRepositoryImpl)), usecase.NewScheduledPromptUseCaseImpl, wire.Bind(new(usecase.ScheduledPromptUseCase), new(*usecase.ScheduledPromptUseCaseImpl)), usecase.NewScheduler)
+
+var webhookSet = wire.NewSet(repository.NewWebhookRepositoryImpl, wire.Bind(new(repository.WebhookRepository), new(*repository.WebhookRepositoryImpl)), usecase.NewWebhookUseCaseImpl, wire.Bind(new(usecase.WebhookUseCase), new(*usecase.WebhookUseCaseImpl)), usecase.NewWebhookDispatcher)
+
+var reportSet = wire.NewSet(repository.NewReportRepositoryImpl, wire.Bind(new(repository.ReportRepository), new(*repository.ReportRepositoryImpl)), usecase.NewReportUseCaseImpl, wire.Bind(new(usecase.ReportUseCase), new(*usecase.ReportUseCaseImpl)))
+
+var promptTemplateSet = wire.NewSet(repository.NewPromptTemplateRepositoryImpl, wire.Bind(new(repository.PromptTemplateRepository), new(*repository.PromptTemplateRepositoryImpl)), usecase.NewPromptTemplateUseCaseImpl, wire.Bind(new(usecase.PromptTemplateUseCase), new(*usecase.PromptTemplateUseCaseImpl)))
+
+var accountSet = wire.NewSet(usecase.NewAccountUseCaseImpl, wire.Bind(new(usecase.AccountUseCase), new(*usecase.AccountUseCaseImpl)))
+
+var grpcClientSet = wire.NewSet(client.NewUserClient)
+
 var serviceSet = wire.NewSet(handler.NewChatServiceImpl, wire.Bind(new(handler.ChatService), new(*handler.ChatServiceImpl)))
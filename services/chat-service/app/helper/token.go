@@ -0,0 +1,16 @@
+package helper
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// RandomToken returns a hex encoded random string built from n random bytes.
+func RandomToken(n int) (string, error) {
+	bytes := make([]byte, n)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(bytes), nil
+}
@@ -0,0 +1,9 @@
+package dto
+
+type ExportUserDataRequest struct {
+    UserID uint `json:"user_id" validate:"required"`
+}
+
+type PurgeUserDataRequest struct {
+    UserID uint `json:"user_id" validate:"required"`
+}
\ No newline at end of file
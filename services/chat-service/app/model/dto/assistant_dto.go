@@ -0,0 +1,54 @@
+package dto
+
+type AssistantDocument struct {
+    Name    string `json:"name" validate:"required,max=255"`
+    Content string `json:"content" validate:"required"`
+}
+
+type CreateAssistantRequest struct {
+    UserID          uint                `json:"user_id" validate:"required"`
+    Name            string              `json:"name" validate:"required,max=255"`
+    Description     string              `json:"description" validate:"omitempty"`
+    Instructions    string              `json:"instructions" validate:"required"`
+    Model           string              `json:"model" validate:"omitempty,max=255"`
+    Temperature     *float32            `json:"temperature" validate:"omitempty,min=0,max=2"`
+    TopP            *float32            `json:"top_p" validate:"omitempty,min=0,max=1"`
+    MaxOutputTokens *int32              `json:"max_output_tokens" validate:"omitempty,min=1"`
+    Tools           []string            `json:"tools" validate:"omitempty,dive,oneof=code_execution"`
+    Documents       []AssistantDocument `json:"documents" validate:"omitempty,dive"`
+    OrganizationID  uint                `json:"organization_id" validate:"omitempty"`
+}
+
+type UpdateAssistantRequest struct {
+    ID              uint                `json:"id" validate:"required"`
+    UserID          uint                `json:"user_id" validate:"required"`
+    Name            string              `json:"name" validate:"required,max=255"`
+    Description     string              `json:"description" validate:"omitempty"`
+    Instructions    string              `json:"instructions" validate:"required"`
+    Model           string              `json:"model" validate:"omitempty,max=255"`
+    Temperature     *float32            `json:"temperature" validate:"omitempty,min=0,max=2"`
+    TopP            *float32            `json:"top_p" validate:"omitempty,min=0,max=1"`
+    MaxOutputTokens *int32              `json:"max_output_tokens" validate:"omitempty,min=1"`
+    Tools           []string            `json:"tools" validate:"omitempty,dive,oneof=code_execution"`
+    Documents       []AssistantDocument `json:"documents" validate:"omitempty,dive"`
+}
+
+type GetAssistantsRequest struct {
+    UserID         uint `json:"user_id" validate:"required"`
+    OrganizationID uint `json:"organization_id" validate:"omitempty"`
+}
+
+type GetAssistantRequest struct {
+    ID     uint `json:"id" validate:"required"`
+    UserID uint `json:"user_id" validate:"required"`
+}
+
+type ShareAssistantRequest struct {
+    ID      uint `json:"id" validate:"required"`
+    UserID  uint `json:"user_id" validate:"required"`
+    Enabled bool `json:"enabled"`
+}
+
+type GetSharedAssistantRequest struct {
+    ShareToken string `json:"share_token" validate:"required"`
+}
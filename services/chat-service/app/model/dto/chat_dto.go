@@ -2,9 +2,18 @@ package dto
 
 type GetChatsRequest struct {
     UserID uint `json:"user_id" validate:"required"`
+    ProjectID *uint `json:"project_id" validate:"omitempty"`
+    OrganizationID uint `json:"organization_id" validate:"omitempty"`
 }
 
 type GetChatRequest struct {
     ChatID uint `json:"chat_id" validate:"required"`
     UserID uint `json:"user_id" validate:"required"`
+    OrganizationID uint `json:"organization_id" validate:"omitempty"`
+}
+
+type MoveChatRequest struct {
+    ChatID uint `json:"chat_id" validate:"required"`
+    UserID uint `json:"user_id" validate:"required"`
+    ProjectID uint `json:"project_id" validate:"omitempty"`
 }
\ No newline at end of file
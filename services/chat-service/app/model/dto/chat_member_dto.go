@@ -0,0 +1,26 @@
+package dto
+
+type InviteChatMemberRequest struct {
+    ChatID uint `json:"chat_id" validate:"required"`
+    UserID uint `json:"user_id" validate:"required"`
+    Email string `json:"email" validate:"required,email"`
+    Role string `json:"role" validate:"required,oneof=viewer editor"`
+}
+
+type GetChatMembersRequest struct {
+    ChatID uint `json:"chat_id" validate:"required"`
+    UserID uint `json:"user_id" validate:"required"`
+}
+
+type UpdateChatMemberRequest struct {
+    ChatID uint `json:"chat_id" validate:"required"`
+    UserID uint `json:"user_id" validate:"required"`
+    MemberID uint `json:"member_id" validate:"required"`
+    Role string `json:"role" validate:"required,oneof=viewer editor"`
+}
+
+type RemoveChatMemberRequest struct {
+    ChatID uint `json:"chat_id" validate:"required"`
+    UserID uint `json:"user_id" validate:"required"`
+    MemberID uint `json:"member_id" validate:"required"`
+}
@@ -4,9 +4,36 @@ type CreateMessageRequest struct {
     ChatID uint `json:"chat_id" validate:"omitempty"`
     UserID uint `json:"user_id" validate:"required"`
     Message string `json:"message" validate:"required"`
+    AssistantID uint `json:"assistant_id" validate:"omitempty"`
+    AssistantShareToken string `json:"assistant_share_token" validate:"omitempty,max=255"`
+    ProjectID uint `json:"project_id" validate:"omitempty"`
+    OrganizationID uint `json:"organization_id" validate:"omitempty"`
 }
 
 type GetMessagesRequest struct {
     ChatID uint `json:"chat_id" validate:"required"`
     UserID uint `json:"user_id" validate:"required"`
+    OrganizationID uint `json:"organization_id" validate:"omitempty"`
+}
+type CompareMessageRequest struct {
+    ChatID uint `json:"chat_id" validate:"omitempty"`
+    UserID uint `json:"user_id" validate:"required"`
+    Message string `json:"message" validate:"required"`
+    AssistantID uint `json:"assistant_id" validate:"omitempty"`
+    AssistantShareToken string `json:"assistant_share_token" validate:"omitempty,max=255"`
+    ProjectID uint `json:"project_id" validate:"omitempty"`
+    OrganizationID uint `json:"organization_id" validate:"omitempty"`
+    Targets []CompareTarget `json:"targets" validate:"required,min=2,max=4,unique=Model,dive"`
+}
+
+type CompareTarget struct {
+    Provider string `json:"provider" validate:"required,oneof=gemini"`
+    Model string `json:"model" validate:"required,max=255"`
+}
+
+type PreferMessageRequest struct {
+    ChatID uint `json:"chat_id" validate:"required"`
+    UserID uint `json:"user_id" validate:"required"`
+    MessageID uint `json:"message_id" validate:"required"`
+    OrganizationID uint `json:"organization_id" validate:"omitempty"`
 }
\ No newline at end of file
@@ -0,0 +1,30 @@
+package dto
+
+type ProjectFile struct {
+    Name    string `json:"name" validate:"required,max=255"`
+    Content string `json:"content" validate:"required"`
+}
+
+type CreateProjectRequest struct {
+    UserID       uint          `json:"user_id" validate:"required"`
+    Name         string        `json:"name" validate:"required,max=255"`
+    Instructions string        `json:"instructions" validate:"omitempty"`
+    Files        []ProjectFile `json:"files" validate:"omitempty,dive"`
+}
+
+type UpdateProjectRequest struct {
+    ID           uint          `json:"id" validate:"required"`
+    UserID       uint          `json:"user_id" validate:"required"`
+    Name         string        `json:"name" validate:"required,max=255"`
+    Instructions string        `json:"instructions" validate:"omitempty"`
+    Files        []ProjectFile `json:"files" validate:"omitempty,dive"`
+}
+
+type GetProjectsRequest struct {
+    UserID uint `json:"user_id" validate:"required"`
+}
+
+type GetProjectRequest struct {
+    ID     uint `json:"id" validate:"required"`
+    UserID uint `json:"user_id" validate:"required"`
+}
@@ -0,0 +1,25 @@
+package dto
+
+type CreatePromptTemplateRequest struct {
+    UserID         uint   `json:"user_id" validate:"required"`
+    OrganizationID uint   `json:"organization_id" validate:"omitempty"`
+    Name           string `json:"name" validate:"required,max=255"`
+    Content        string `json:"content" validate:"required"`
+}
+
+type UpdatePromptTemplateRequest struct {
+    ID      uint   `json:"id" validate:"required"`
+    UserID  uint   `json:"user_id" validate:"required"`
+    Name    string `json:"name" validate:"required,max=255"`
+    Content string `json:"content" validate:"required"`
+}
+
+type GetPromptTemplatesRequest struct {
+    UserID         uint `json:"user_id" validate:"required"`
+    OrganizationID uint `json:"organization_id" validate:"omitempty"`
+}
+
+type GetPromptTemplateRequest struct {
+    ID     uint `json:"id" validate:"required"`
+    UserID uint `json:"user_id" validate:"required"`
+}
@@ -0,0 +1,6 @@
+package dto
+
+type GetReportRequest struct {
+    UserID uint  `json:"user_id" validate:"required"`
+    Since  int64 `json:"since" validate:"gte=0"`
+}
\ No newline at end of file
@@ -0,0 +1,33 @@
+package dto
+
+type CreateScheduledPromptRequest struct {
+    UserID         uint   `json:"user_id" validate:"required"`
+    Name           string `json:"name" validate:"required,max=255"`
+    Prompt         string `json:"prompt" validate:"required"`
+    CronExpression string `json:"cron_expression" validate:"required,max=255"`
+    Timezone       string `json:"timezone" validate:"omitempty,max=64"`
+    ChatID         uint   `json:"chat_id" validate:"omitempty"`
+    CatchUpPolicy  string `json:"catch_up_policy" validate:"omitempty,oneof=skip run_once"`
+    Enabled        bool   `json:"enabled"`
+}
+
+type UpdateScheduledPromptRequest struct {
+    ID             uint   `json:"id" validate:"required"`
+    UserID         uint   `json:"user_id" validate:"required"`
+    Name           string `json:"name" validate:"required,max=255"`
+    Prompt         string `json:"prompt" validate:"required"`
+    CronExpression string `json:"cron_expression" validate:"required,max=255"`
+    Timezone       string `json:"timezone" validate:"omitempty,max=64"`
+    ChatID         uint   `json:"chat_id" validate:"omitempty"`
+    CatchUpPolicy  string `json:"catch_up_policy" validate:"omitempty,oneof=skip run_once"`
+    Enabled        bool   `json:"enabled"`
+}
+
+type GetScheduledPromptsRequest struct {
+    UserID uint `json:"user_id" validate:"required"`
+}
+
+type GetScheduledPromptRequest struct {
+    ID     uint `json:"id" validate:"required"`
+    UserID uint `json:"user_id" validate:"required"`
+}
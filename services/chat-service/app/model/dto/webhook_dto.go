@@ -0,0 +1,31 @@
+package dto
+
+type CreateWebhookRequest struct {
+    UserID  uint     `json:"user_id" validate:"required"`
+    URL     string   `json:"url" validate:"required,url,startswith=https://,max=2048"`
+    Events  []string `json:"events" validate:"required,min=1,dive,oneof=chat.created message.completed chat.deleted feedback.created"`
+    Enabled bool     `json:"enabled"`
+}
+
+type UpdateWebhookRequest struct {
+    ID      uint     `json:"id" validate:"required"`
+    UserID  uint     `json:"user_id" validate:"required"`
+    URL     string   `json:"url" validate:"required,url,startswith=https://,max=2048"`
+    Events  []string `json:"events" validate:"required,min=1,dive,oneof=chat.created message.completed chat.deleted feedback.created"`
+    Enabled bool     `json:"enabled"`
+}
+
+type GetWebhooksRequest struct {
+    UserID uint `json:"user_id" validate:"required"`
+}
+
+type GetWebhookRequest struct {
+    ID     uint `json:"id" validate:"required"`
+    UserID uint `json:"user_id" validate:"required"`
+}
+
+type RedeliverWebhookDeliveryRequest struct {
+    ID        uint `json:"id" validate:"required"`
+    WebhookID uint `json:"webhook_id" validate:"required"`
+    UserID    uint `json:"user_id" validate:"required"`
+}
@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+type Assistant struct {
+	ID              	uint
+	UserID          	uint
+	OrganizationID  	*uint
+	Name            	string
+	Description     	*string
+	Instructions    	string
+	Model           	string
+	Temperature     	*float32
+	TopP            	*float32
+	MaxOutputTokens 	*int32
+	Tools           	[]string `gorm:"serializer:json"`
+	ShareToken      	*string
+	CreatedAt       	time.Time
+	UpdatedAt       	time.Time
+	Documents       	[]AssistantDocument `gorm:"foreignKey:AssistantID"`
+}
+
+type AssistantDocument struct {
+	ID          	uint
+	AssistantID 	uint
+	Name        	string
+	Content     	string
+	CreatedAt   	time.Time
+	UpdatedAt   	time.Time
+}
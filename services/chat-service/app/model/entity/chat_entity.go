@@ -6,6 +6,9 @@ type Chat struct {
 	ID        	uint   
 	UserID		uint
 	Topic 	 	*string
+	AssistantID	*uint
+	ProjectID	*uint
+	OrganizationID	*uint
 	CreatedAt 	time.Time
 	UpdatedAt 	time.Time
 	Messages	[]Message `gorm:"foreignKey:ChatID"`
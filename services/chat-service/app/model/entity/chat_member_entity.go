@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+type ChatMember struct {
+	ID        	uint
+	ChatID    	uint
+	UserID    	uint
+	Email     	string
+	Role      	string
+	InvitedBy 	*uint
+	CreatedAt 	time.Time
+	UpdatedAt 	time.Time
+	Chat      	Chat `gorm:"foreignKey:ChatID"`
+}
@@ -9,6 +9,22 @@ type Message struct {
 	UserID		uint
 	Role		string
 	Content		string
+	// Model and CompareGroup are set on the sibling replies of a comparison,
+	// only the active sibling is part of the chat history
+	Model		*string
+	CompareGroup	*string
+	Active		bool
+	CreatedAt 	time.Time
+	UpdatedAt 	time.Time
+}
+
+type MessagePreference struct {
+	ID        	uint
+	ChatID    	uint
+	UserID    	uint
+	CompareGroup	string
+	MessageID 	uint
+	Model     	string
 	CreatedAt 	time.Time
 	UpdatedAt 	time.Time
 }
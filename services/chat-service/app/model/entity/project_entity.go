@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+type Project struct {
+	ID           	uint
+	UserID       	uint
+	Name         	string
+	Instructions 	*string
+	CreatedAt    	time.Time
+	UpdatedAt    	time.Time
+	Files        	[]ProjectFile `gorm:"foreignKey:ProjectID"`
+}
+
+type ProjectFile struct {
+	ID        	uint
+	ProjectID 	uint
+	Name      	string
+	Content   	string
+	CreatedAt 	time.Time
+	UpdatedAt 	time.Time
+}
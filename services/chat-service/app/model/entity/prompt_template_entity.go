@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+type PromptTemplate struct {
+	ID             	uint
+	UserID         	uint
+	OrganizationID 	*uint
+	Name           	string
+	Content        	string
+	CreatedAt      	time.Time
+	UpdatedAt      	time.Time
+}
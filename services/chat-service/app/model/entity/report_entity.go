@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// The report rows are aggregates over messages and preferences, not tables
+
+type UsageTotals struct {
+	Messages	int64
+	Prompts		int64
+	Replies		int64
+	Chats		int64
+	ActiveUsers	int64
+}
+
+type UsageDay struct {
+	Day		time.Time
+	Messages	int64
+	ActiveUsers	int64
+}
+
+type UserUsage struct {
+	UserID	uint
+	Prompts	int64
+}
+
+type ModelFeedback struct {
+	Model		string
+	Shown		int64
+	Preferred	int64
+}
\ No newline at end of file
@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+type ScheduledPrompt struct {
+	ID             	uint
+	UserID         	uint
+	ChatID         	*uint
+	Name           	string
+	Prompt         	string
+	CronExpression 	string
+	Timezone       	string
+	CatchUpPolicy  	string
+	Enabled        	bool
+	NextRunAt      	*time.Time
+	LastRunAt      	*time.Time
+	CreatedAt      	time.Time
+	UpdatedAt      	time.Time
+}
+
+type ScheduledPromptRun struct {
+	ID                	uint
+	ScheduledPromptID 	uint
+	ChatID            	*uint
+	Status            	string
+	ScheduledFor      	time.Time
+	StartedAt         	*time.Time
+	FinishedAt        	*time.Time
+	Error             	*string
+	CreatedAt         	time.Time
+	UpdatedAt         	time.Time
+}
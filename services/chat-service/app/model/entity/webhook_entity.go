@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+type Webhook struct {
+	ID        	uint
+	UserID    	uint
+	URL       	string
+	Secret    	string
+	Events    	[]string `gorm:"serializer:json"`
+	Enabled   	bool
+	CreatedAt 	time.Time
+	UpdatedAt 	time.Time
+}
+
+type WebhookDelivery struct {
+	ID             	uint
+	WebhookID      	uint
+	Event          	string
+	Payload        	string
+	Status         	string
+	Attempts       	int32
+	ResponseStatus 	*int32
+	Error          	*string
+	NextAttemptAt  	time.Time
+	DeliveredAt    	*time.Time
+	CreatedAt      	time.Time
+	UpdatedAt      	time.Time
+	Webhook        	Webhook `gorm:"foreignKey:WebhookID"`
+}
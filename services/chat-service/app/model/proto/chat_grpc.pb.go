@@ -19,10 +19,51 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ChatService_GetChats_FullMethodName             = "/proto.ChatService/GetChats"
-	ChatService_GetChatByIdAndUserId_FullMethodName = "/proto.ChatService/GetChatByIdAndUserId"
-	ChatService_CreateMessage_FullMethodName        = "/proto.ChatService/CreateMessage"
-	ChatService_GetMessages_FullMethodName          = "/proto.ChatService/GetMessages"
+	ChatService_GetChats_FullMethodName                 = "/proto.ChatService/GetChats"
+	ChatService_GetChatByIdAndUserId_FullMethodName     = "/proto.ChatService/GetChatByIdAndUserId"
+	ChatService_CreateMessage_FullMethodName            = "/proto.ChatService/CreateMessage"
+	ChatService_GetMessages_FullMethodName              = "/proto.ChatService/GetMessages"
+	ChatService_CreateAssistant_FullMethodName          = "/proto.ChatService/CreateAssistant"
+	ChatService_GetAssistants_FullMethodName            = "/proto.ChatService/GetAssistants"
+	ChatService_GetAssistant_FullMethodName             = "/proto.ChatService/GetAssistant"
+	ChatService_UpdateAssistant_FullMethodName          = "/proto.ChatService/UpdateAssistant"
+	ChatService_DeleteAssistant_FullMethodName          = "/proto.ChatService/DeleteAssistant"
+	ChatService_ShareAssistant_FullMethodName           = "/proto.ChatService/ShareAssistant"
+	ChatService_GetSharedAssistant_FullMethodName       = "/proto.ChatService/GetSharedAssistant"
+	ChatService_CreateProject_FullMethodName            = "/proto.ChatService/CreateProject"
+	ChatService_GetProjects_FullMethodName              = "/proto.ChatService/GetProjects"
+	ChatService_GetProject_FullMethodName               = "/proto.ChatService/GetProject"
+	ChatService_UpdateProject_FullMethodName            = "/proto.ChatService/UpdateProject"
+	ChatService_DeleteProject_FullMethodName            = "/proto.ChatService/DeleteProject"
+	ChatService_MoveChat_FullMethodName                 = "/proto.ChatService/MoveChat"
+	ChatService_InviteChatMember_FullMethodName         = "/proto.ChatService/InviteChatMember"
+	ChatService_GetChatMembers_FullMethodName           = "/proto.ChatService/GetChatMembers"
+	ChatService_UpdateChatMember_FullMethodName         = "/proto.ChatService/UpdateChatMember"
+	ChatService_RemoveChatMember_FullMethodName         = "/proto.ChatService/RemoveChatMember"
+	ChatService_CreateScheduledPrompt_FullMethodName    = "/proto.ChatService/CreateScheduledPrompt"
+	ChatService_GetScheduledPrompts_FullMethodName      = "/proto.ChatService/GetScheduledPrompts"
+	ChatService_GetScheduledPrompt_FullMethodName       = "/proto.ChatService/GetScheduledPrompt"
+	ChatService_UpdateScheduledPrompt_FullMethodName    = "/proto.ChatService/UpdateScheduledPrompt"
+	ChatService_DeleteScheduledPrompt_FullMethodName    = "/proto.ChatService/DeleteScheduledPrompt"
+	ChatService_GetScheduledPromptRuns_FullMethodName   = "/proto.ChatService/GetScheduledPromptRuns"
+	ChatService_DeleteChat_FullMethodName               = "/proto.ChatService/DeleteChat"
+	ChatService_CreateWebhook_FullMethodName            = "/proto.ChatService/CreateWebhook"
+	ChatService_GetWebhooks_FullMethodName              = "/proto.ChatService/GetWebhooks"
+	ChatService_GetWebhook_FullMethodName               = "/proto.ChatService/GetWebhook"
+	ChatService_UpdateWebhook_FullMethodName            = "/proto.ChatService/UpdateWebhook"
+	ChatService_DeleteWebhook_FullMethodName            = "/proto.ChatService/DeleteWebhook"
+	ChatService_GetWebhookDeliveries_FullMethodName     = "/proto.ChatService/GetWebhookDeliveries"
+	ChatService_RedeliverWebhookDelivery_FullMethodName = "/proto.ChatService/RedeliverWebhookDelivery"
+	ChatService_CompareMessage_FullMethodName           = "/proto.ChatService/CompareMessage"
+	ChatService_PreferMessage_FullMethodName            = "/proto.ChatService/PreferMessage"
+	ChatService_GetUsageReport_FullMethodName           = "/proto.ChatService/GetUsageReport"
+	ChatService_GetFeedbackReport_FullMethodName        = "/proto.ChatService/GetFeedbackReport"
+	ChatService_ExportUserData_FullMethodName           = "/proto.ChatService/ExportUserData"
+	ChatService_PurgeUserData_FullMethodName            = "/proto.ChatService/PurgeUserData"
+	ChatService_CreatePromptTemplate_FullMethodName     = "/proto.ChatService/CreatePromptTemplate"
+	ChatService_GetPromptTemplates_FullMethodName       = "/proto.ChatService/GetPromptTemplates"
+	ChatService_UpdatePromptTemplate_FullMethodName     = "/proto.ChatService/UpdatePromptTemplate"
+	ChatService_DeletePromptTemplate_FullMethodName     = "/proto.ChatService/DeletePromptTemplate"
 )
 
 // ChatServiceClient is the client API for ChatService service.
@@ -33,6 +74,47 @@ type ChatServiceClient interface {
 	GetChatByIdAndUserId(ctx context.Context, in *GetChatRequest, opts ...grpc.CallOption) (*GetChatResponse, error)
 	CreateMessage(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[Message, Part], error)
 	GetMessages(ctx context.Context, in *GetMessagesRequest, opts ...grpc.CallOption) (*GetMessagesResponse, error)
+	CreateAssistant(ctx context.Context, in *CreateAssistantRequest, opts ...grpc.CallOption) (*Assistant, error)
+	GetAssistants(ctx context.Context, in *GetAssistantsRequest, opts ...grpc.CallOption) (*GetAssistantsResponse, error)
+	GetAssistant(ctx context.Context, in *GetAssistantRequest, opts ...grpc.CallOption) (*Assistant, error)
+	UpdateAssistant(ctx context.Context, in *UpdateAssistantRequest, opts ...grpc.CallOption) (*Assistant, error)
+	DeleteAssistant(ctx context.Context, in *DeleteAssistantRequest, opts ...grpc.CallOption) (*Empty, error)
+	ShareAssistant(ctx context.Context, in *ShareAssistantRequest, opts ...grpc.CallOption) (*Assistant, error)
+	GetSharedAssistant(ctx context.Context, in *GetSharedAssistantRequest, opts ...grpc.CallOption) (*Assistant, error)
+	CreateProject(ctx context.Context, in *CreateProjectRequest, opts ...grpc.CallOption) (*Project, error)
+	GetProjects(ctx context.Context, in *GetProjectsRequest, opts ...grpc.CallOption) (*GetProjectsResponse, error)
+	GetProject(ctx context.Context, in *GetProjectRequest, opts ...grpc.CallOption) (*Project, error)
+	UpdateProject(ctx context.Context, in *UpdateProjectRequest, opts ...grpc.CallOption) (*Project, error)
+	DeleteProject(ctx context.Context, in *DeleteProjectRequest, opts ...grpc.CallOption) (*Empty, error)
+	MoveChat(ctx context.Context, in *MoveChatRequest, opts ...grpc.CallOption) (*Chat, error)
+	InviteChatMember(ctx context.Context, in *InviteChatMemberRequest, opts ...grpc.CallOption) (*ChatMember, error)
+	GetChatMembers(ctx context.Context, in *GetChatMembersRequest, opts ...grpc.CallOption) (*GetChatMembersResponse, error)
+	UpdateChatMember(ctx context.Context, in *UpdateChatMemberRequest, opts ...grpc.CallOption) (*ChatMember, error)
+	RemoveChatMember(ctx context.Context, in *RemoveChatMemberRequest, opts ...grpc.CallOption) (*Empty, error)
+	CreateScheduledPrompt(ctx context.Context, in *CreateScheduledPromptRequest, opts ...grpc.CallOption) (*ScheduledPrompt, error)
+	GetScheduledPrompts(ctx context.Context, in *GetScheduledPromptsRequest, opts ...grpc.CallOption) (*GetScheduledPromptsResponse, error)
+	GetScheduledPrompt(ctx context.Context, in *GetScheduledPromptRequest, opts ...grpc.CallOption) (*ScheduledPrompt, error)
+	UpdateScheduledPrompt(ctx context.Context, in *UpdateScheduledPromptRequest, opts ...grpc.CallOption) (*ScheduledPrompt, error)
+	DeleteScheduledPrompt(ctx context.Context, in *DeleteScheduledPromptRequest, opts ...grpc.CallOption) (*Empty, error)
+	GetScheduledPromptRuns(ctx context.Context, in *GetScheduledPromptRunsRequest, opts ...grpc.CallOption) (*GetScheduledPromptRunsResponse, error)
+	DeleteChat(ctx context.Context, in *DeleteChatRequest, opts ...grpc.CallOption) (*Empty, error)
+	CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*Webhook, error)
+	GetWebhooks(ctx context.Context, in *GetWebhooksRequest, opts ...grpc.CallOption) (*GetWebhooksResponse, error)
+	GetWebhook(ctx context.Context, in *GetWebhookRequest, opts ...grpc.CallOption) (*Webhook, error)
+	UpdateWebhook(ctx context.Context, in *UpdateWebhookRequest, opts ...grpc.CallOption) (*Webhook, error)
+	DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*Empty, error)
+	GetWebhookDeliveries(ctx context.Context, in *GetWebhookDeliveriesRequest, opts ...grpc.CallOption) (*GetWebhookDeliveriesResponse, error)
+	RedeliverWebhookDelivery(ctx context.Context, in *RedeliverWebhookDeliveryRequest, opts ...grpc.CallOption) (*WebhookDelivery, error)
+	CompareMessage(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[CompareMessageRequest, Part], error)
+	PreferMessage(ctx context.Context, in *PreferMessageRequest, opts ...grpc.CallOption) (*FullMessage, error)
+	GetUsageReport(ctx context.Context, in *GetReportRequest, opts ...grpc.CallOption) (*UsageReport, error)
+	GetFeedbackReport(ctx context.Context, in *GetReportRequest, opts ...grpc.CallOption) (*FeedbackReport, error)
+	ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportFile], error)
+	PurgeUserData(ctx context.Context, in *PurgeUserDataRequest, opts ...grpc.CallOption) (*Empty, error)
+	CreatePromptTemplate(ctx context.Context, in *CreatePromptTemplateRequest, opts ...grpc.CallOption) (*PromptTemplate, error)
+	GetPromptTemplates(ctx context.Context, in *GetPromptTemplatesRequest, opts ...grpc.CallOption) (*GetPromptTemplatesResponse, error)
+	UpdatePromptTemplate(ctx context.Context, in *UpdatePromptTemplateRequest, opts ...grpc.CallOption) (*PromptTemplate, error)
+	DeletePromptTemplate(ctx context.Context, in *DeletePromptTemplateRequest, opts ...grpc.CallOption) (*Empty, error)
 }
 
 type chatServiceClient struct {
@@ -86,6 +168,428 @@ func (c *chatServiceClient) GetMessages(ctx context.Context, in *GetMessagesRequ
 	return out, nil
 }
 
+func (c *chatServiceClient) CreateAssistant(ctx context.Context, in *CreateAssistantRequest, opts ...grpc.CallOption) (*Assistant, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Assistant)
+	err := c.cc.Invoke(ctx, ChatService_CreateAssistant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) GetAssistants(ctx context.Context, in *GetAssistantsRequest, opts ...grpc.CallOption) (*GetAssistantsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAssistantsResponse)
+	err := c.cc.Invoke(ctx, ChatService_GetAssistants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) GetAssistant(ctx context.Context, in *GetAssistantRequest, opts ...grpc.CallOption) (*Assistant, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Assistant)
+	err := c.cc.Invoke(ctx, ChatService_GetAssistant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) UpdateAssistant(ctx context.Context, in *UpdateAssistantRequest, opts ...grpc.CallOption) (*Assistant, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Assistant)
+	err := c.cc.Invoke(ctx, ChatService_UpdateAssistant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) DeleteAssistant(ctx context.Context, in *DeleteAssistantRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ChatService_DeleteAssistant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ShareAssistant(ctx context.Context, in *ShareAssistantRequest, opts ...grpc.CallOption) (*Assistant, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Assistant)
+	err := c.cc.Invoke(ctx, ChatService_ShareAssistant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) GetSharedAssistant(ctx context.Context, in *GetSharedAssistantRequest, opts ...grpc.CallOption) (*Assistant, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Assistant)
+	err := c.cc.Invoke(ctx, ChatService_GetSharedAssistant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) CreateProject(ctx context.Context, in *CreateProjectRequest, opts ...grpc.CallOption) (*Project, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Project)
+	err := c.cc.Invoke(ctx, ChatService_CreateProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) GetProjects(ctx context.Context, in *GetProjectsRequest, opts ...grpc.CallOption) (*GetProjectsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProjectsResponse)
+	err := c.cc.Invoke(ctx, ChatService_GetProjects_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) GetProject(ctx context.Context, in *GetProjectRequest, opts ...grpc.CallOption) (*Project, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Project)
+	err := c.cc.Invoke(ctx, ChatService_GetProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) UpdateProject(ctx context.Context, in *UpdateProjectRequest, opts ...grpc.CallOption) (*Project, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Project)
+	err := c.cc.Invoke(ctx, ChatService_UpdateProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) DeleteProject(ctx context.Context, in *DeleteProjectRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ChatService_DeleteProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) MoveChat(ctx context.Context, in *MoveChatRequest, opts ...grpc.CallOption) (*Chat, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Chat)
+	err := c.cc.Invoke(ctx, ChatService_MoveChat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) InviteChatMember(ctx context.Context, in *InviteChatMemberRequest, opts ...grpc.CallOption) (*ChatMember, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChatMember)
+	err := c.cc.Invoke(ctx, ChatService_InviteChatMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) GetChatMembers(ctx context.Context, in *GetChatMembersRequest, opts ...grpc.CallOption) (*GetChatMembersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetChatMembersResponse)
+	err := c.cc.Invoke(ctx, ChatService_GetChatMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) UpdateChatMember(ctx context.Context, in *UpdateChatMemberRequest, opts ...grpc.CallOption) (*ChatMember, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChatMember)
+	err := c.cc.Invoke(ctx, ChatService_UpdateChatMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) RemoveChatMember(ctx context.Context, in *RemoveChatMemberRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ChatService_RemoveChatMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) CreateScheduledPrompt(ctx context.Context, in *CreateScheduledPromptRequest, opts ...grpc.CallOption) (*ScheduledPrompt, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduledPrompt)
+	err := c.cc.Invoke(ctx, ChatService_CreateScheduledPrompt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) GetScheduledPrompts(ctx context.Context, in *GetScheduledPromptsRequest, opts ...grpc.CallOption) (*GetScheduledPromptsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetScheduledPromptsResponse)
+	err := c.cc.Invoke(ctx, ChatService_GetScheduledPrompts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) GetScheduledPrompt(ctx context.Context, in *GetScheduledPromptRequest, opts ...grpc.CallOption) (*ScheduledPrompt, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduledPrompt)
+	err := c.cc.Invoke(ctx, ChatService_GetScheduledPrompt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) UpdateScheduledPrompt(ctx context.Context, in *UpdateScheduledPromptRequest, opts ...grpc.CallOption) (*ScheduledPrompt, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduledPrompt)
+	err := c.cc.Invoke(ctx, ChatService_UpdateScheduledPrompt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) DeleteScheduledPrompt(ctx context.Context, in *DeleteScheduledPromptRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ChatService_DeleteScheduledPrompt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) GetScheduledPromptRuns(ctx context.Context, in *GetScheduledPromptRunsRequest, opts ...grpc.CallOption) (*GetScheduledPromptRunsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetScheduledPromptRunsResponse)
+	err := c.cc.Invoke(ctx, ChatService_GetScheduledPromptRuns_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) DeleteChat(ctx context.Context, in *DeleteChatRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ChatService_DeleteChat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*Webhook, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, ChatService_CreateWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) GetWebhooks(ctx context.Context, in *GetWebhooksRequest, opts ...grpc.CallOption) (*GetWebhooksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetWebhooksResponse)
+	err := c.cc.Invoke(ctx, ChatService_GetWebhooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) GetWebhook(ctx context.Context, in *GetWebhookRequest, opts ...grpc.CallOption) (*Webhook, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, ChatService_GetWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) UpdateWebhook(ctx context.Context, in *UpdateWebhookRequest, opts ...grpc.CallOption) (*Webhook, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, ChatService_UpdateWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ChatService_DeleteWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) GetWebhookDeliveries(ctx context.Context, in *GetWebhookDeliveriesRequest, opts ...grpc.CallOption) (*GetWebhookDeliveriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, ChatService_GetWebhookDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) RedeliverWebhookDelivery(ctx context.Context, in *RedeliverWebhookDeliveryRequest, opts ...grpc.CallOption) (*WebhookDelivery, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookDelivery)
+	err := c.cc.Invoke(ctx, ChatService_RedeliverWebhookDelivery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) CompareMessage(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[CompareMessageRequest, Part], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ChatService_ServiceDesc.Streams[1], ChatService_CompareMessage_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CompareMessageRequest, Part]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChatService_CompareMessageClient = grpc.BidiStreamingClient[CompareMessageRequest, Part]
+
+func (c *chatServiceClient) PreferMessage(ctx context.Context, in *PreferMessageRequest, opts ...grpc.CallOption) (*FullMessage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FullMessage)
+	err := c.cc.Invoke(ctx, ChatService_PreferMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) GetUsageReport(ctx context.Context, in *GetReportRequest, opts ...grpc.CallOption) (*UsageReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UsageReport)
+	err := c.cc.Invoke(ctx, ChatService_GetUsageReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) GetFeedbackReport(ctx context.Context, in *GetReportRequest, opts ...grpc.CallOption) (*FeedbackReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FeedbackReport)
+	err := c.cc.Invoke(ctx, ChatService_GetFeedbackReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportFile], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ChatService_ServiceDesc.Streams[2], ChatService_ExportUserData_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportUserDataRequest, ExportFile]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChatService_ExportUserDataClient = grpc.ServerStreamingClient[ExportFile]
+
+func (c *chatServiceClient) PurgeUserData(ctx context.Context, in *PurgeUserDataRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ChatService_PurgeUserData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) CreatePromptTemplate(ctx context.Context, in *CreatePromptTemplateRequest, opts ...grpc.CallOption) (*PromptTemplate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PromptTemplate)
+	err := c.cc.Invoke(ctx, ChatService_CreatePromptTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) GetPromptTemplates(ctx context.Context, in *GetPromptTemplatesRequest, opts ...grpc.CallOption) (*GetPromptTemplatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPromptTemplatesResponse)
+	err := c.cc.Invoke(ctx, ChatService_GetPromptTemplates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) UpdatePromptTemplate(ctx context.Context, in *UpdatePromptTemplateRequest, opts ...grpc.CallOption) (*PromptTemplate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PromptTemplate)
+	err := c.cc.Invoke(ctx, ChatService_UpdatePromptTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) DeletePromptTemplate(ctx context.Context, in *DeletePromptTemplateRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ChatService_DeletePromptTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ChatServiceServer is the server API for ChatService service.
 // All implementations must embed UnimplementedChatServiceServer
 // for forward compatibility.
@@ -94,106 +598,990 @@ type ChatServiceServer interface {
 	GetChatByIdAndUserId(context.Context, *GetChatRequest) (*GetChatResponse, error)
 	CreateMessage(grpc.BidiStreamingServer[Message, Part]) error
 	GetMessages(context.Context, *GetMessagesRequest) (*GetMessagesResponse, error)
+	CreateAssistant(context.Context, *CreateAssistantRequest) (*Assistant, error)
+	GetAssistants(context.Context, *GetAssistantsRequest) (*GetAssistantsResponse, error)
+	GetAssistant(context.Context, *GetAssistantRequest) (*Assistant, error)
+	UpdateAssistant(context.Context, *UpdateAssistantRequest) (*Assistant, error)
+	DeleteAssistant(context.Context, *DeleteAssistantRequest) (*Empty, error)
+	ShareAssistant(context.Context, *ShareAssistantRequest) (*Assistant, error)
+	GetSharedAssistant(context.Context, *GetSharedAssistantRequest) (*Assistant, error)
+	CreateProject(context.Context, *CreateProjectRequest) (*Project, error)
+	GetProjects(context.Context, *GetProjectsRequest) (*GetProjectsResponse, error)
+	GetProject(context.Context, *GetProjectRequest) (*Project, error)
+	UpdateProject(context.Context, *UpdateProjectRequest) (*Project, error)
+	DeleteProject(context.Context, *DeleteProjectRequest) (*Empty, error)
+	MoveChat(context.Context, *MoveChatRequest) (*Chat, error)
+	InviteChatMember(context.Context, *InviteChatMemberRequest) (*ChatMember, error)
+	GetChatMembers(context.Context, *GetChatMembersRequest) (*GetChatMembersResponse, error)
+	UpdateChatMember(context.Context, *UpdateChatMemberRequest) (*ChatMember, error)
+	RemoveChatMember(context.Context, *RemoveChatMemberRequest) (*Empty, error)
+	CreateScheduledPrompt(context.Context, *CreateScheduledPromptRequest) (*ScheduledPrompt, error)
+	GetScheduledPrompts(context.Context, *GetScheduledPromptsRequest) (*GetScheduledPromptsResponse, error)
+	GetScheduledPrompt(context.Context, *GetScheduledPromptRequest) (*ScheduledPrompt, error)
+	UpdateScheduledPrompt(context.Context, *UpdateScheduledPromptRequest) (*ScheduledPrompt, error)
+	DeleteScheduledPrompt(context.Context, *DeleteScheduledPromptRequest) (*Empty, error)
+	GetScheduledPromptRuns(context.Context, *GetScheduledPromptRunsRequest) (*GetScheduledPromptRunsResponse, error)
+	DeleteChat(context.Context, *DeleteChatRequest) (*Empty, error)
+	CreateWebhook(context.Context, *CreateWebhookRequest) (*Webhook, error)
+	GetWebhooks(context.Context, *GetWebhooksRequest) (*GetWebhooksResponse, error)
+	GetWebhook(context.Context, *GetWebhookRequest) (*Webhook, error)
+	UpdateWebhook(context.Context, *UpdateWebhookRequest) (*Webhook, error)
+	DeleteWebhook(context.Context, *DeleteWebhookRequest) (*Empty, error)
+	GetWebhookDeliveries(context.Context, *GetWebhookDeliveriesRequest) (*GetWebhookDeliveriesResponse, error)
+	RedeliverWebhookDelivery(context.Context, *RedeliverWebhookDeliveryRequest) (*WebhookDelivery, error)
+	CompareMessage(grpc.BidiStreamingServer[CompareMessageRequest, Part]) error
+	PreferMessage(context.Context, *PreferMessageRequest) (*FullMessage, error)
+	GetUsageReport(context.Context, *GetReportRequest) (*UsageReport, error)
+	GetFeedbackReport(context.Context, *GetReportRequest) (*FeedbackReport, error)
+	ExportUserData(*ExportUserDataRequest, grpc.ServerStreamingServer[ExportFile]) error
+	PurgeUserData(context.Context, *PurgeUserDataRequest) (*Empty, error)
+	CreatePromptTemplate(context.Context, *CreatePromptTemplateRequest) (*PromptTemplate, error)
+	GetPromptTemplates(context.Context, *GetPromptTemplatesRequest) (*GetPromptTemplatesResponse, error)
+	UpdatePromptTemplate(context.Context, *UpdatePromptTemplateRequest) (*PromptTemplate, error)
+	DeletePromptTemplate(context.Context, *DeletePromptTemplateRequest) (*Empty, error)
+	mustEmbedUnimplementedChatServiceServer()
+}
+
+// UnimplementedChatServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedChatServiceServer struct{}
+
+func (UnimplementedChatServiceServer) GetChats(context.Context, *GetChatsRequest) (*GetChatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChats not implemented")
+}
+func (UnimplementedChatServiceServer) GetChatByIdAndUserId(context.Context, *GetChatRequest) (*GetChatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChatByIdAndUserId not implemented")
+}
+func (UnimplementedChatServiceServer) CreateMessage(grpc.BidiStreamingServer[Message, Part]) error {
+	return status.Errorf(codes.Unimplemented, "method CreateMessage not implemented")
+}
+func (UnimplementedChatServiceServer) GetMessages(context.Context, *GetMessagesRequest) (*GetMessagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMessages not implemented")
+}
+func (UnimplementedChatServiceServer) CreateAssistant(context.Context, *CreateAssistantRequest) (*Assistant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAssistant not implemented")
+}
+func (UnimplementedChatServiceServer) GetAssistants(context.Context, *GetAssistantsRequest) (*GetAssistantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAssistants not implemented")
+}
+func (UnimplementedChatServiceServer) GetAssistant(context.Context, *GetAssistantRequest) (*Assistant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAssistant not implemented")
+}
+func (UnimplementedChatServiceServer) UpdateAssistant(context.Context, *UpdateAssistantRequest) (*Assistant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAssistant not implemented")
+}
+func (UnimplementedChatServiceServer) DeleteAssistant(context.Context, *DeleteAssistantRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAssistant not implemented")
+}
+func (UnimplementedChatServiceServer) ShareAssistant(context.Context, *ShareAssistantRequest) (*Assistant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareAssistant not implemented")
+}
+func (UnimplementedChatServiceServer) GetSharedAssistant(context.Context, *GetSharedAssistantRequest) (*Assistant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSharedAssistant not implemented")
+}
+func (UnimplementedChatServiceServer) CreateProject(context.Context, *CreateProjectRequest) (*Project, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateProject not implemented")
+}
+func (UnimplementedChatServiceServer) GetProjects(context.Context, *GetProjectsRequest) (*GetProjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProjects not implemented")
+}
+func (UnimplementedChatServiceServer) GetProject(context.Context, *GetProjectRequest) (*Project, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProject not implemented")
+}
+func (UnimplementedChatServiceServer) UpdateProject(context.Context, *UpdateProjectRequest) (*Project, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProject not implemented")
+}
+func (UnimplementedChatServiceServer) DeleteProject(context.Context, *DeleteProjectRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteProject not implemented")
+}
+func (UnimplementedChatServiceServer) MoveChat(context.Context, *MoveChatRequest) (*Chat, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveChat not implemented")
+}
+func (UnimplementedChatServiceServer) InviteChatMember(context.Context, *InviteChatMemberRequest) (*ChatMember, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InviteChatMember not implemented")
+}
+func (UnimplementedChatServiceServer) GetChatMembers(context.Context, *GetChatMembersRequest) (*GetChatMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChatMembers not implemented")
+}
+func (UnimplementedChatServiceServer) UpdateChatMember(context.Context, *UpdateChatMemberRequest) (*ChatMember, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateChatMember not implemented")
+}
+func (UnimplementedChatServiceServer) RemoveChatMember(context.Context, *RemoveChatMemberRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveChatMember not implemented")
+}
+func (UnimplementedChatServiceServer) CreateScheduledPrompt(context.Context, *CreateScheduledPromptRequest) (*ScheduledPrompt, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateScheduledPrompt not implemented")
+}
+func (UnimplementedChatServiceServer) GetScheduledPrompts(context.Context, *GetScheduledPromptsRequest) (*GetScheduledPromptsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScheduledPrompts not implemented")
+}
+func (UnimplementedChatServiceServer) GetScheduledPrompt(context.Context, *GetScheduledPromptRequest) (*ScheduledPrompt, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScheduledPrompt not implemented")
+}
+func (UnimplementedChatServiceServer) UpdateScheduledPrompt(context.Context, *UpdateScheduledPromptRequest) (*ScheduledPrompt, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateScheduledPrompt not implemented")
+}
+func (UnimplementedChatServiceServer) DeleteScheduledPrompt(context.Context, *DeleteScheduledPromptRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteScheduledPrompt not implemented")
+}
+func (UnimplementedChatServiceServer) GetScheduledPromptRuns(context.Context, *GetScheduledPromptRunsRequest) (*GetScheduledPromptRunsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScheduledPromptRuns not implemented")
+}
+func (UnimplementedChatServiceServer) DeleteChat(context.Context, *DeleteChatRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteChat not implemented")
+}
+func (UnimplementedChatServiceServer) CreateWebhook(context.Context, *CreateWebhookRequest) (*Webhook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (UnimplementedChatServiceServer) GetWebhooks(context.Context, *GetWebhooksRequest) (*GetWebhooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWebhooks not implemented")
+}
+func (UnimplementedChatServiceServer) GetWebhook(context.Context, *GetWebhookRequest) (*Webhook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWebhook not implemented")
+}
+func (UnimplementedChatServiceServer) UpdateWebhook(context.Context, *UpdateWebhookRequest) (*Webhook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateWebhook not implemented")
+}
+func (UnimplementedChatServiceServer) DeleteWebhook(context.Context, *DeleteWebhookRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedChatServiceServer) GetWebhookDeliveries(context.Context, *GetWebhookDeliveriesRequest) (*GetWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWebhookDeliveries not implemented")
+}
+func (UnimplementedChatServiceServer) RedeliverWebhookDelivery(context.Context, *RedeliverWebhookDeliveryRequest) (*WebhookDelivery, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedeliverWebhookDelivery not implemented")
+}
+func (UnimplementedChatServiceServer) CompareMessage(grpc.BidiStreamingServer[CompareMessageRequest, Part]) error {
+	return status.Errorf(codes.Unimplemented, "method CompareMessage not implemented")
+}
+func (UnimplementedChatServiceServer) PreferMessage(context.Context, *PreferMessageRequest) (*FullMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreferMessage not implemented")
+}
+func (UnimplementedChatServiceServer) GetUsageReport(context.Context, *GetReportRequest) (*UsageReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsageReport not implemented")
+}
+func (UnimplementedChatServiceServer) GetFeedbackReport(context.Context, *GetReportRequest) (*FeedbackReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFeedbackReport not implemented")
+}
+func (UnimplementedChatServiceServer) ExportUserData(*ExportUserDataRequest, grpc.ServerStreamingServer[ExportFile]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportUserData not implemented")
+}
+func (UnimplementedChatServiceServer) PurgeUserData(context.Context, *PurgeUserDataRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeUserData not implemented")
+}
+func (UnimplementedChatServiceServer) CreatePromptTemplate(context.Context, *CreatePromptTemplateRequest) (*PromptTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePromptTemplate not implemented")
+}
+func (UnimplementedChatServiceServer) GetPromptTemplates(context.Context, *GetPromptTemplatesRequest) (*GetPromptTemplatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPromptTemplates not implemented")
+}
+func (UnimplementedChatServiceServer) UpdatePromptTemplate(context.Context, *UpdatePromptTemplateRequest) (*PromptTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePromptTemplate not implemented")
+}
+func (UnimplementedChatServiceServer) DeletePromptTemplate(context.Context, *DeletePromptTemplateRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePromptTemplate not implemented")
+}
+func (UnimplementedChatServiceServer) mustEmbedUnimplementedChatServiceServer() {}
+func (UnimplementedChatServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeChatServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ChatServiceServer will
+// result in compilation errors.
+type UnsafeChatServiceServer interface {
 	mustEmbedUnimplementedChatServiceServer()
 }
 
-// UnimplementedChatServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedChatServiceServer struct{}
+func RegisterChatServiceServer(s grpc.ServiceRegistrar, srv ChatServiceServer) {
+	// If the following call pancis, it indicates UnimplementedChatServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ChatService_ServiceDesc, srv)
+}
+
+func _ChatService_GetChats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetChats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetChats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetChats(ctx, req.(*GetChatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_GetChatByIdAndUserId_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetChatByIdAndUserId(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetChatByIdAndUserId_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetChatByIdAndUserId(ctx, req.(*GetChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_CreateMessage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ChatServiceServer).CreateMessage(&grpc.GenericServerStream[Message, Part]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChatService_CreateMessageServer = grpc.BidiStreamingServer[Message, Part]
+
+func _ChatService_GetMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetMessages(ctx, req.(*GetMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_CreateAssistant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAssistantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).CreateAssistant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_CreateAssistant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).CreateAssistant(ctx, req.(*CreateAssistantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_GetAssistants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAssistantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetAssistants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetAssistants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetAssistants(ctx, req.(*GetAssistantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_GetAssistant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAssistantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetAssistant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetAssistant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetAssistant(ctx, req.(*GetAssistantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_UpdateAssistant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAssistantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).UpdateAssistant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_UpdateAssistant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).UpdateAssistant(ctx, req.(*UpdateAssistantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_DeleteAssistant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAssistantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).DeleteAssistant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_DeleteAssistant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).DeleteAssistant(ctx, req.(*DeleteAssistantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ShareAssistant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareAssistantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ShareAssistant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ShareAssistant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ShareAssistant(ctx, req.(*ShareAssistantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_GetSharedAssistant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSharedAssistantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetSharedAssistant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetSharedAssistant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetSharedAssistant(ctx, req.(*GetSharedAssistantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_CreateProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).CreateProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_CreateProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).CreateProject(ctx, req.(*CreateProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_GetProjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetProjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetProjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetProjects(ctx, req.(*GetProjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_GetProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetProject(ctx, req.(*GetProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_UpdateProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).UpdateProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_UpdateProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).UpdateProject(ctx, req.(*UpdateProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_DeleteProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).DeleteProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_DeleteProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).DeleteProject(ctx, req.(*DeleteProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_MoveChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).MoveChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_MoveChat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).MoveChat(ctx, req.(*MoveChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_InviteChatMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InviteChatMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).InviteChatMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_InviteChatMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).InviteChatMember(ctx, req.(*InviteChatMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_GetChatMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChatMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetChatMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetChatMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetChatMembers(ctx, req.(*GetChatMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_UpdateChatMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateChatMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).UpdateChatMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_UpdateChatMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).UpdateChatMember(ctx, req.(*UpdateChatMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_RemoveChatMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveChatMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).RemoveChatMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_RemoveChatMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).RemoveChatMember(ctx, req.(*RemoveChatMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_CreateScheduledPrompt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateScheduledPromptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).CreateScheduledPrompt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_CreateScheduledPrompt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).CreateScheduledPrompt(ctx, req.(*CreateScheduledPromptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_GetScheduledPrompts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScheduledPromptsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetScheduledPrompts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetScheduledPrompts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetScheduledPrompts(ctx, req.(*GetScheduledPromptsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_GetScheduledPrompt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScheduledPromptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetScheduledPrompt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetScheduledPrompt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetScheduledPrompt(ctx, req.(*GetScheduledPromptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_UpdateScheduledPrompt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateScheduledPromptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).UpdateScheduledPrompt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_UpdateScheduledPrompt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).UpdateScheduledPrompt(ctx, req.(*UpdateScheduledPromptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_DeleteScheduledPrompt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteScheduledPromptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).DeleteScheduledPrompt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_DeleteScheduledPrompt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).DeleteScheduledPrompt(ctx, req.(*DeleteScheduledPromptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_GetScheduledPromptRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScheduledPromptRunsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetScheduledPromptRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetScheduledPromptRuns_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetScheduledPromptRuns(ctx, req.(*GetScheduledPromptRunsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_DeleteChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).DeleteChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_DeleteChat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).DeleteChat(ctx, req.(*DeleteChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_CreateWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).CreateWebhook(ctx, req.(*CreateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_GetWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWebhooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetWebhooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetWebhooks(ctx, req.(*GetWebhooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_GetWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetWebhook(ctx, req.(*GetWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-func (UnimplementedChatServiceServer) GetChats(context.Context, *GetChatsRequest) (*GetChatsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetChats not implemented")
+func _ChatService_UpdateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).UpdateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_UpdateWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).UpdateWebhook(ctx, req.(*UpdateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedChatServiceServer) GetChatByIdAndUserId(context.Context, *GetChatRequest) (*GetChatResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetChatByIdAndUserId not implemented")
+
+func _ChatService_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_DeleteWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).DeleteWebhook(ctx, req.(*DeleteWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedChatServiceServer) CreateMessage(grpc.BidiStreamingServer[Message, Part]) error {
-	return status.Errorf(codes.Unimplemented, "method CreateMessage not implemented")
+
+func _ChatService_GetWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetWebhookDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetWebhookDeliveries(ctx, req.(*GetWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedChatServiceServer) GetMessages(context.Context, *GetMessagesRequest) (*GetMessagesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetMessages not implemented")
+
+func _ChatService_RedeliverWebhookDelivery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeliverWebhookDeliveryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).RedeliverWebhookDelivery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_RedeliverWebhookDelivery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).RedeliverWebhookDelivery(ctx, req.(*RedeliverWebhookDeliveryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedChatServiceServer) mustEmbedUnimplementedChatServiceServer() {}
-func (UnimplementedChatServiceServer) testEmbeddedByValue()                     {}
 
-// UnsafeChatServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to ChatServiceServer will
-// result in compilation errors.
-type UnsafeChatServiceServer interface {
-	mustEmbedUnimplementedChatServiceServer()
+func _ChatService_CompareMessage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ChatServiceServer).CompareMessage(&grpc.GenericServerStream[CompareMessageRequest, Part]{ServerStream: stream})
 }
 
-func RegisterChatServiceServer(s grpc.ServiceRegistrar, srv ChatServiceServer) {
-	// If the following call pancis, it indicates UnimplementedChatServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChatService_CompareMessageServer = grpc.BidiStreamingServer[CompareMessageRequest, Part]
+
+func _ChatService_PreferMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreferMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&ChatService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(ChatServiceServer).PreferMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_PreferMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).PreferMessage(ctx, req.(*PreferMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_GetChats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetChatsRequest)
+func _ChatService_GetUsageReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReportRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).GetChats(ctx, in)
+		return srv.(ChatServiceServer).GetUsageReport(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_GetChats_FullMethodName,
+		FullMethod: ChatService_GetUsageReport_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).GetChats(ctx, req.(*GetChatsRequest))
+		return srv.(ChatServiceServer).GetUsageReport(ctx, req.(*GetReportRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_GetChatByIdAndUserId_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetChatRequest)
+func _ChatService_GetFeedbackReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReportRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).GetChatByIdAndUserId(ctx, in)
+		return srv.(ChatServiceServer).GetFeedbackReport(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_GetChatByIdAndUserId_FullMethodName,
+		FullMethod: ChatService_GetFeedbackReport_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).GetChatByIdAndUserId(ctx, req.(*GetChatRequest))
+		return srv.(ChatServiceServer).GetFeedbackReport(ctx, req.(*GetReportRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_CreateMessage_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(ChatServiceServer).CreateMessage(&grpc.GenericServerStream[Message, Part]{ServerStream: stream})
+func _ChatService_ExportUserData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportUserDataRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ChatServiceServer).ExportUserData(m, &grpc.GenericServerStream[ExportUserDataRequest, ExportFile]{ServerStream: stream})
 }
 
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type ChatService_CreateMessageServer = grpc.BidiStreamingServer[Message, Part]
+type ChatService_ExportUserDataServer = grpc.ServerStreamingServer[ExportFile]
 
-func _ChatService_GetMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetMessagesRequest)
+func _ChatService_PurgeUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeUserDataRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).GetMessages(ctx, in)
+		return srv.(ChatServiceServer).PurgeUserData(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_GetMessages_FullMethodName,
+		FullMethod: ChatService_PurgeUserData_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).GetMessages(ctx, req.(*GetMessagesRequest))
+		return srv.(ChatServiceServer).PurgeUserData(ctx, req.(*PurgeUserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_CreatePromptTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePromptTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).CreatePromptTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_CreatePromptTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).CreatePromptTemplate(ctx, req.(*CreatePromptTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_GetPromptTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPromptTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetPromptTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetPromptTemplates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetPromptTemplates(ctx, req.(*GetPromptTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_UpdatePromptTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePromptTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).UpdatePromptTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_UpdatePromptTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).UpdatePromptTemplate(ctx, req.(*UpdatePromptTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_DeletePromptTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePromptTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).DeletePromptTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_DeletePromptTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).DeletePromptTemplate(ctx, req.(*DeletePromptTemplateRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -217,6 +1605,162 @@ var ChatService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetMessages",
 			Handler:    _ChatService_GetMessages_Handler,
 		},
+		{
+			MethodName: "CreateAssistant",
+			Handler:    _ChatService_CreateAssistant_Handler,
+		},
+		{
+			MethodName: "GetAssistants",
+			Handler:    _ChatService_GetAssistants_Handler,
+		},
+		{
+			MethodName: "GetAssistant",
+			Handler:    _ChatService_GetAssistant_Handler,
+		},
+		{
+			MethodName: "UpdateAssistant",
+			Handler:    _ChatService_UpdateAssistant_Handler,
+		},
+		{
+			MethodName: "DeleteAssistant",
+			Handler:    _ChatService_DeleteAssistant_Handler,
+		},
+		{
+			MethodName: "ShareAssistant",
+			Handler:    _ChatService_ShareAssistant_Handler,
+		},
+		{
+			MethodName: "GetSharedAssistant",
+			Handler:    _ChatService_GetSharedAssistant_Handler,
+		},
+		{
+			MethodName: "CreateProject",
+			Handler:    _ChatService_CreateProject_Handler,
+		},
+		{
+			MethodName: "GetProjects",
+			Handler:    _ChatService_GetProjects_Handler,
+		},
+		{
+			MethodName: "GetProject",
+			Handler:    _ChatService_GetProject_Handler,
+		},
+		{
+			MethodName: "UpdateProject",
+			Handler:    _ChatService_UpdateProject_Handler,
+		},
+		{
+			MethodName: "DeleteProject",
+			Handler:    _ChatService_DeleteProject_Handler,
+		},
+		{
+			MethodName: "MoveChat",
+			Handler:    _ChatService_MoveChat_Handler,
+		},
+		{
+			MethodName: "InviteChatMember",
+			Handler:    _ChatService_InviteChatMember_Handler,
+		},
+		{
+			MethodName: "GetChatMembers",
+			Handler:    _ChatService_GetChatMembers_Handler,
+		},
+		{
+			MethodName: "UpdateChatMember",
+			Handler:    _ChatService_UpdateChatMember_Handler,
+		},
+		{
+			MethodName: "RemoveChatMember",
+			Handler:    _ChatService_RemoveChatMember_Handler,
+		},
+		{
+			MethodName: "CreateScheduledPrompt",
+			Handler:    _ChatService_CreateScheduledPrompt_Handler,
+		},
+		{
+			MethodName: "GetScheduledPrompts",
+			Handler:    _ChatService_GetScheduledPrompts_Handler,
+		},
+		{
+			MethodName: "GetScheduledPrompt",
+			Handler:    _ChatService_GetScheduledPrompt_Handler,
+		},
+		{
+			MethodName: "UpdateScheduledPrompt",
+			Handler:    _ChatService_UpdateScheduledPrompt_Handler,
+		},
+		{
+			MethodName: "DeleteScheduledPrompt",
+			Handler:    _ChatService_DeleteScheduledPrompt_Handler,
+		},
+		{
+			MethodName: "GetScheduledPromptRuns",
+			Handler:    _ChatService_GetScheduledPromptRuns_Handler,
+		},
+		{
+			MethodName: "DeleteChat",
+			Handler:    _ChatService_DeleteChat_Handler,
+		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _ChatService_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "GetWebhooks",
+			Handler:    _ChatService_GetWebhooks_Handler,
+		},
+		{
+			MethodName: "GetWebhook",
+			Handler:    _ChatService_GetWebhook_Handler,
+		},
+		{
+			MethodName: "UpdateWebhook",
+			Handler:    _ChatService_UpdateWebhook_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _ChatService_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "GetWebhookDeliveries",
+			Handler:    _ChatService_GetWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "RedeliverWebhookDelivery",
+			Handler:    _ChatService_RedeliverWebhookDelivery_Handler,
+		},
+		{
+			MethodName: "PreferMessage",
+			Handler:    _ChatService_PreferMessage_Handler,
+		},
+		{
+			MethodName: "GetUsageReport",
+			Handler:    _ChatService_GetUsageReport_Handler,
+		},
+		{
+			MethodName: "GetFeedbackReport",
+			Handler:    _ChatService_GetFeedbackReport_Handler,
+		},
+		{
+			MethodName: "PurgeUserData",
+			Handler:    _ChatService_PurgeUserData_Handler,
+		},
+		{
+			MethodName: "CreatePromptTemplate",
+			Handler:    _ChatService_CreatePromptTemplate_Handler,
+		},
+		{
+			MethodName: "GetPromptTemplates",
+			Handler:    _ChatService_GetPromptTemplates_Handler,
+		},
+		{
+			MethodName: "UpdatePromptTemplate",
+			Handler:    _ChatService_UpdatePromptTemplate_Handler,
+		},
+		{
+			MethodName: "DeletePromptTemplate",
+			Handler:    _ChatService_DeletePromptTemplate_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -225,6 +1769,17 @@ var ChatService_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "CompareMessage",
+			Handler:       _ChatService_CompareMessage_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportUserData",
+			Handler:       _ChatService_ExportUserData_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "app/model/proto/chat.proto",
 }
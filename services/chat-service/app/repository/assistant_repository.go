@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+
+	"gorm.io/gorm"
+)
+
+type AssistantRepository interface {
+    FindByID(tx *gorm.DB, assistant *entity.Assistant, id int) error
+    FindByIDAndUserID(tx *gorm.DB, assistant *entity.Assistant, id, userID int) error
+    FindByShareToken(tx *gorm.DB, assistant *entity.Assistant, shareToken string) error
+    FindAllByUserID(tx *gorm.DB, assistants *[]entity.Assistant, userID int) error
+    FindAllPersonalByUserID(tx *gorm.DB, assistants *[]entity.Assistant, userID int) error
+    FindAllByOrganizationID(tx *gorm.DB, assistants *[]entity.Assistant, organizationID int) error
+    Create(tx *gorm.DB, assistant *entity.Assistant) error
+    Update(tx *gorm.DB, assistant *entity.Assistant) error
+    Delete(tx *gorm.DB, assistant *entity.Assistant) error
+    DeleteDocuments(tx *gorm.DB, assistantID int) error
+    DeleteAllByUserID(tx *gorm.DB, userID int) error
+    TransferAllByUserIDAndOrganizationID(tx *gorm.DB, userID, organizationID, toUserID int) error
+}
+
+type AssistantRepositoryImpl struct {}
+
+func NewAssistantRepositoryImpl() *AssistantRepositoryImpl {
+    return &AssistantRepositoryImpl{}
+}
+
+func (r *AssistantRepositoryImpl) FindByID(tx *gorm.DB, assistant *entity.Assistant, id int) error {
+    return tx.Model(&entity.Assistant{}).
+        Preload("Documents").
+        Where("id = ?", id).
+        First(&assistant).Error
+}
+
+func (r *AssistantRepositoryImpl) FindByIDAndUserID(tx *gorm.DB, assistant *entity.Assistant, id, userID int) error {
+    return tx.Model(&entity.Assistant{}).
+        Preload("Documents").
+        Where("id = ? AND user_id = ?", id, userID).
+        First(&assistant).Error
+}
+
+func (r *AssistantRepositoryImpl) FindByShareToken(tx *gorm.DB, assistant *entity.Assistant, shareToken string) error {
+    return tx.Model(&entity.Assistant{}).
+        Where("share_token = ?", shareToken).
+        First(&assistant).Error
+}
+
+func (r *AssistantRepositoryImpl) FindAllByUserID(tx *gorm.DB, assistants *[]entity.Assistant, userID int) error {
+    return tx.Model(&entity.Assistant{}).Where("user_id = ?", userID).Find(&assistants).Error
+}
+
+// FindAllPersonalByUserID lists the assistants of the user outside any organization
+func (r *AssistantRepositoryImpl) FindAllPersonalByUserID(tx *gorm.DB, assistants *[]entity.Assistant, userID int) error {
+    return tx.Model(&entity.Assistant{}).Where("user_id = ? AND organization_id IS NULL", userID).Find(&assistants).Error
+}
+
+func (r *AssistantRepositoryImpl) FindAllByOrganizationID(tx *gorm.DB, assistants *[]entity.Assistant, organizationID int) error {
+    return tx.Model(&entity.Assistant{}).Where("organization_id = ?", organizationID).Find(&assistants).Error
+}
+
+func (r *AssistantRepositoryImpl) Create(tx *gorm.DB, assistant *entity.Assistant) error {
+    return tx.Create(assistant).Error
+}
+
+func (r *AssistantRepositoryImpl) Update(tx *gorm.DB, assistant *entity.Assistant) error {
+    return tx.Save(assistant).Error
+}
+
+func (r *AssistantRepositoryImpl) Delete(tx *gorm.DB, assistant *entity.Assistant) error {
+    return tx.Delete(assistant).Error
+}
+
+func (r *AssistantRepositoryImpl) DeleteDocuments(tx *gorm.DB, assistantID int) error {
+    return tx.Where("assistant_id = ?", assistantID).Delete(&entity.AssistantDocument{}).Error
+}
+
+// DeleteAllByUserID removes the personal assistants of the user
+func (r *AssistantRepositoryImpl) DeleteAllByUserID(tx *gorm.DB, userID int) error {
+    return tx.Where("user_id = ? AND organization_id IS NULL", userID).Delete(&entity.Assistant{}).Error
+}
+
+// TransferAllByUserIDAndOrganizationID hands the assistants of the user in the organization over to toUserID
+func (r *AssistantRepositoryImpl) TransferAllByUserIDAndOrganizationID(tx *gorm.DB, userID, organizationID, toUserID int) error {
+    return tx.Model(&entity.Assistant{}).
+        Where("user_id = ? AND organization_id = ?", userID, organizationID).
+        Update("user_id", toUserID).Error
+}
\ No newline at end of file
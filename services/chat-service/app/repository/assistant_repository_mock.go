@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type AssistantRepositoryMock struct {
+    mock.Mock
+}
+
+func (m *AssistantRepositoryMock) FindByID(tx *gorm.DB, assistant *entity.Assistant, id int) error {
+    args := m.Called(tx, assistant, id)
+    return args.Error(0)
+}
+
+func (m *AssistantRepositoryMock) FindByIDAndUserID(tx *gorm.DB, assistant *entity.Assistant, id, userID int) error {
+    args := m.Called(tx, assistant, id, userID)
+    return args.Error(0)
+}
+
+func (m *AssistantRepositoryMock) FindByShareToken(tx *gorm.DB, assistant *entity.Assistant, shareToken string) error {
+    args := m.Called(tx, assistant, shareToken)
+    return args.Error(0)
+}
+
+func (m *AssistantRepositoryMock) FindAllByUserID(tx *gorm.DB, assistants *[]entity.Assistant, userID int) error {
+    args := m.Called(tx, assistants, userID)
+    return args.Error(0)
+}
+
+func (m *AssistantRepositoryMock) FindAllPersonalByUserID(tx *gorm.DB, assistants *[]entity.Assistant, userID int) error {
+    args := m.Called(tx, assistants, userID)
+    return args.Error(0)
+}
+
+func (m *AssistantRepositoryMock) FindAllByOrganizationID(tx *gorm.DB, assistants *[]entity.Assistant, organizationID int) error {
+    args := m.Called(tx, assistants, organizationID)
+    return args.Error(0)
+}
+
+func (m *AssistantRepositoryMock) Create(tx *gorm.DB, assistant *entity.Assistant) error {
+    args := m.Called(tx, assistant)
+    return args.Error(0)
+}
+
+func (m *AssistantRepositoryMock) Update(tx *gorm.DB, assistant *entity.Assistant) error {
+    args := m.Called(tx, assistant)
+    return args.Error(0)
+}
+
+func (m *AssistantRepositoryMock) Delete(tx *gorm.DB, assistant *entity.Assistant) error {
+    args := m.Called(tx, assistant)
+    return args.Error(0)
+}
+
+func (m *AssistantRepositoryMock) DeleteDocuments(tx *gorm.DB, assistantID int) error {
+    args := m.Called(tx, assistantID)
+    return args.Error(0)
+}
+
+func (m *AssistantRepositoryMock) DeleteAllByUserID(tx *gorm.DB, userID int) error {
+    args := m.Called(tx, userID)
+    return args.Error(0)
+}
+
+func (m *AssistantRepositoryMock) TransferAllByUserIDAndOrganizationID(tx *gorm.DB, userID, organizationID, toUserID int) error {
+    args := m.Called(tx, userID, organizationID, toUserID)
+    return args.Error(0)
+}
\ No newline at end of file
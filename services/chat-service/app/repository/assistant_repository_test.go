@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestAssistantRepositoryImpl_FindByIDAndUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewAssistantRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "assistants" WHERE id = $1 AND user_id = $2 ORDER BY "assistants"."id" LIMIT $3`)).
+        WithArgs(1, 1, 1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "instructions", "model", "tools", "created_at", "updated_at"}).
+            AddRow(1, 1, "Go reviewer", "Review Go code", "gemini-1.5-flash", `["code_execution"]`, time.Now(), time.Now()))
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "assistant_documents" WHERE "assistant_documents"."assistant_id" = $1`)).
+        WithArgs(1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "assistant_id", "name", "content"}).
+            AddRow(1, 1, "Style guide", "Use gofmt"))
+
+    assistant := &entity.Assistant{}
+    err := repo.FindByIDAndUserID(db, assistant, 1, 1)
+
+    assert.NoError(t, err)
+    assert.Equal(t, uint(1), assistant.ID)
+    assert.Equal(t, []string{"code_execution"}, assistant.Tools)
+    assert.Len(t, assistant.Documents, 1)
+}
+
+func TestAssistantRepositoryImpl_FindByIDAndUserID_Error(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewAssistantRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "assistants" WHERE id = $1 AND user_id = $2 ORDER BY "assistants"."id" LIMIT $3`)).
+        WithArgs(1, 2, 1).
+        WillReturnError(gorm.ErrRecordNotFound)
+
+    assistant := &entity.Assistant{}
+    err := repo.FindByIDAndUserID(db, assistant, 1, 2)
+
+    assert.Error(t, err)
+}
+
+func TestAssistantRepositoryImpl_FindByShareToken(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewAssistantRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "assistants" WHERE share_token = $1 ORDER BY "assistants"."id" LIMIT $2`)).
+        WithArgs("token", 1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "share_token"}).
+            AddRow(1, 1, "SQL helper", "token"))
+
+    assistant := &entity.Assistant{}
+    err := repo.FindByShareToken(db, assistant, "token")
+
+    assert.NoError(t, err)
+    assert.Equal(t, "SQL helper", assistant.Name)
+}
+
+func TestAssistantRepositoryImpl_FindAllByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewAssistantRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "assistants" WHERE user_id = $1`)).
+        WithArgs(1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name"}).
+            AddRow(1, 1, "Go reviewer").
+            AddRow(2, 1, "SQL helper"))
+
+    var assistants []entity.Assistant
+    err := repo.FindAllByUserID(db, &assistants, 1)
+
+    assert.NoError(t, err)
+    assert.Len(t, assistants, 2)
+}
+
+func TestAssistantRepositoryImpl_FindAllPersonalByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewAssistantRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "assistants" WHERE user_id = $1 AND organization_id IS NULL`)).
+        WithArgs(1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name"}).
+            AddRow(1, 1, "Go reviewer"))
+
+    var assistants []entity.Assistant
+    err := repo.FindAllPersonalByUserID(db, &assistants, 1)
+
+    assert.NoError(t, err)
+    assert.Len(t, assistants, 1)
+}
+
+func TestAssistantRepositoryImpl_FindAllByOrganizationID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewAssistantRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "assistants" WHERE organization_id = $1`)).
+        WithArgs(3).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "organization_id", "name"}).
+            AddRow(1, 1, 3, "Support bot").
+            AddRow(2, 2, 3, "Release notes"))
+
+    var assistants []entity.Assistant
+    err := repo.FindAllByOrganizationID(db, &assistants, 3)
+
+    assert.NoError(t, err)
+    assert.Len(t, assistants, 2)
+}
+
+func TestAssistantRepositoryImpl_Delete(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewAssistantRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "assistants" WHERE "assistants"."id" = $1`)).
+        WithArgs(1).
+        WillReturnResult(sqlmock.NewResult(0, 1))
+
+    err := repo.Delete(db, &entity.Assistant{ID: 1})
+
+    assert.NoError(t, err)
+}
+
+func TestAssistantRepositoryImpl_DeleteDocuments(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewAssistantRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "assistant_documents" WHERE assistant_id = $1`)).
+        WithArgs(1).
+        WillReturnResult(sqlmock.NewResult(0, 2))
+
+    err := repo.DeleteDocuments(db, 1)
+
+    assert.NoError(t, err)
+}
+
+func TestAssistantRepositoryImpl_DeleteAllByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewAssistantRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "assistants" WHERE user_id = $1 AND organization_id IS NULL`)).
+        WithArgs(2).
+        WillReturnResult(sqlmock.NewResult(0, 3))
+
+    err := repo.DeleteAllByUserID(db, 2)
+
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAssistantRepositoryImpl_TransferAllByUserIDAndOrganizationID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewAssistantRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`UPDATE "assistants" SET "user_id"=$1,"updated_at"=$2 WHERE user_id = $3 AND organization_id = $4`)).
+        WithArgs(3, sqlmock.AnyArg(), 2, 5).
+        WillReturnResult(sqlmock.NewResult(0, 2))
+
+    err := repo.TransferAllByUserIDAndOrganizationID(db, 2, 5, 3)
+
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
\ No newline at end of file
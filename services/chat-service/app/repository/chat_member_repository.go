@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+
+	"gorm.io/gorm"
+)
+
+type ChatMemberRepository interface {
+    FindByChatIDAndUserID(tx *gorm.DB, member *entity.ChatMember, chatID, userID int) error
+    FindAllByChatID(tx *gorm.DB, members *[]entity.ChatMember, chatID int) error
+    FindAllByUserID(tx *gorm.DB, members *[]entity.ChatMember, userID int) error
+    Create(tx *gorm.DB, member *entity.ChatMember) error
+    Update(tx *gorm.DB, member *entity.ChatMember) error
+    Delete(tx *gorm.DB, member *entity.ChatMember) error
+    DeleteAllByUserID(tx *gorm.DB, userID int) error
+}
+
+type ChatMemberRepositoryImpl struct {}
+
+func NewChatMemberRepositoryImpl() *ChatMemberRepositoryImpl {
+    return &ChatMemberRepositoryImpl{}
+}
+
+func (r *ChatMemberRepositoryImpl) FindByChatIDAndUserID(tx *gorm.DB, member *entity.ChatMember, chatID, userID int) error {
+    return tx.Model(&entity.ChatMember{}).
+        Where("chat_id = ? AND user_id = ?", chatID, userID).
+        First(&member).Error
+}
+
+func (r *ChatMemberRepositoryImpl) FindAllByChatID(tx *gorm.DB, members *[]entity.ChatMember, chatID int) error {
+    return tx.Model(&entity.ChatMember{}).Where("chat_id = ?", chatID).Find(&members).Error
+}
+
+// FindAllByUserID lists the memberships of a user together with the shared chats
+func (r *ChatMemberRepositoryImpl) FindAllByUserID(tx *gorm.DB, members *[]entity.ChatMember, userID int) error {
+    return tx.Model(&entity.ChatMember{}).
+        Preload("Chat").
+        Where("user_id = ?", userID).
+        Find(&members).Error
+}
+
+func (r *ChatMemberRepositoryImpl) Create(tx *gorm.DB, member *entity.ChatMember) error {
+    return tx.Omit("Chat").Create(member).Error
+}
+
+func (r *ChatMemberRepositoryImpl) Update(tx *gorm.DB, member *entity.ChatMember) error {
+    return tx.Omit("Chat").Save(member).Error
+}
+
+func (r *ChatMemberRepositoryImpl) Delete(tx *gorm.DB, member *entity.ChatMember) error {
+    return tx.Delete(member).Error
+}
+
+func (r *ChatMemberRepositoryImpl) DeleteAllByUserID(tx *gorm.DB, userID int) error {
+    return tx.Where("user_id = ?", userID).Delete(&entity.ChatMember{}).Error
+}
\ No newline at end of file
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type ChatMemberRepositoryMock struct {
+    mock.Mock
+}
+
+func (m *ChatMemberRepositoryMock) FindByChatIDAndUserID(tx *gorm.DB, member *entity.ChatMember, chatID, userID int) error {
+    args := m.Called(tx, member, chatID, userID)
+    return args.Error(0)
+}
+
+func (m *ChatMemberRepositoryMock) FindAllByChatID(tx *gorm.DB, members *[]entity.ChatMember, chatID int) error {
+    args := m.Called(tx, members, chatID)
+    return args.Error(0)
+}
+
+func (m *ChatMemberRepositoryMock) FindAllByUserID(tx *gorm.DB, members *[]entity.ChatMember, userID int) error {
+    args := m.Called(tx, members, userID)
+    return args.Error(0)
+}
+
+func (m *ChatMemberRepositoryMock) Create(tx *gorm.DB, member *entity.ChatMember) error {
+    args := m.Called(tx, member)
+    return args.Error(0)
+}
+
+func (m *ChatMemberRepositoryMock) Update(tx *gorm.DB, member *entity.ChatMember) error {
+    args := m.Called(tx, member)
+    return args.Error(0)
+}
+
+func (m *ChatMemberRepositoryMock) Delete(tx *gorm.DB, member *entity.ChatMember) error {
+    args := m.Called(tx, member)
+    return args.Error(0)
+}
+
+func (m *ChatMemberRepositoryMock) DeleteAllByUserID(tx *gorm.DB, userID int) error {
+    args := m.Called(tx, userID)
+    return args.Error(0)
+}
\ No newline at end of file
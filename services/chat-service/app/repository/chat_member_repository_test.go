@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestChatMemberRepositoryImpl_FindByChatIDAndUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewChatMemberRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "chat_members" WHERE chat_id = $1 AND user_id = $2 ORDER BY "chat_members"."id" LIMIT $3`)).
+        WithArgs(1, 2, 1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "chat_id", "user_id", "email", "role"}).
+            AddRow(1, 1, 2, "jane@example.com", "editor"))
+
+    member := &entity.ChatMember{}
+    err := repo.FindByChatIDAndUserID(db, member, 1, 2)
+
+    assert.NoError(t, err)
+    assert.Equal(t, "editor", member.Role)
+}
+
+func TestChatMemberRepositoryImpl_FindByChatIDAndUserID_Error(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewChatMemberRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "chat_members" WHERE chat_id = $1 AND user_id = $2 ORDER BY "chat_members"."id" LIMIT $3`)).
+        WithArgs(1, 3, 1).
+        WillReturnError(gorm.ErrRecordNotFound)
+
+    member := &entity.ChatMember{}
+    err := repo.FindByChatIDAndUserID(db, member, 1, 3)
+
+    assert.Error(t, err)
+}
+
+func TestChatMemberRepositoryImpl_FindAllByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewChatMemberRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "chat_members" WHERE user_id = $1`)).
+        WithArgs(2).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "chat_id", "user_id", "email", "role"}).
+            AddRow(1, 1, 2, "jane@example.com", "viewer"))
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "chats" WHERE "chats"."id" = $1`)).
+        WithArgs(1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "topic"}).
+            AddRow(1, 1, "Trip plan"))
+
+    var members []entity.ChatMember
+    err := repo.FindAllByUserID(db, &members, 2)
+
+    assert.NoError(t, err)
+    assert.Len(t, members, 1)
+    assert.Equal(t, uint(1), members[0].Chat.UserID)
+}
+
+func TestChatMemberRepositoryImpl_DeleteAllByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewChatMemberRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "chat_members" WHERE user_id = $1`)).
+        WithArgs(2).
+        WillReturnResult(sqlmock.NewResult(0, 3))
+
+    err := repo.DeleteAllByUserID(db, 2)
+
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
\ No newline at end of file
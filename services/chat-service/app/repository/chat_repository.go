@@ -9,9 +9,17 @@ import (
 type ChatRepository interface {
     FindByID(tx *gorm.DB, chat *entity.Chat, id int) error
     FindByIDAndUserID(tx *gorm.DB, chat *entity.Chat, id, userID int) error
+    FindByIDWithMessages(tx *gorm.DB, chat *entity.Chat, id int) error
     FindAllByUserID(tx *gorm.DB, chats *[]entity.Chat, userID int) error
+    FindAllPersonalByUserID(tx *gorm.DB, chats *[]entity.Chat, userID int) error
+    FindAllByUserIDAndProjectID(tx *gorm.DB, chats *[]entity.Chat, userID, projectID int) error
+    FindAllByOrganizationID(tx *gorm.DB, chats *[]entity.Chat, organizationID int) error
+    FindRecentTopicsByUserID(tx *gorm.DB, chats *[]entity.Chat, userID, exceptID, limit int) error
     Create(tx *gorm.DB, user *entity.Chat) error
     Update(tx *gorm.DB, user *entity.Chat) error
+    Delete(tx *gorm.DB, chat *entity.Chat) error
+    DeleteAllByUserID(tx *gorm.DB, userID int) error
+    TransferAllByUserIDAndOrganizationID(tx *gorm.DB, userID, organizationID, toUserID int) error
 }
 
 type ChatRepositoryImpl struct {}
@@ -31,14 +39,68 @@ func (r *ChatRepositoryImpl) FindByIDAndUserID(tx *gorm.DB, chat *entity.Chat, i
         First(&chat).Error
 }
 
+func (r *ChatRepositoryImpl) FindByIDWithMessages(tx *gorm.DB, chat *entity.Chat, id int) error {
+    return tx.Model(&entity.Chat{}).
+        Preload("Messages").
+        Where("id = ?", id).
+        First(&chat).Error
+}
+
 func (r *ChatRepositoryImpl) FindAllByUserID(tx *gorm.DB, chats *[]entity.Chat, userID int) error {
     return tx.Model(&entity.Chat{}).Where("user_id = ?", userID).Find(&chats).Error
 }
 
+// FindAllPersonalByUserID lists the chats of the user outside any organization
+func (r *ChatRepositoryImpl) FindAllPersonalByUserID(tx *gorm.DB, chats *[]entity.Chat, userID int) error {
+    return tx.Model(&entity.Chat{}).Where("user_id = ? AND organization_id IS NULL", userID).Find(&chats).Error
+}
+
+// FindAllByUserIDAndProjectID lists the chats of a project, projectID 0 lists personal chats outside any project
+func (r *ChatRepositoryImpl) FindAllByUserIDAndProjectID(tx *gorm.DB, chats *[]entity.Chat, userID, projectID int) error {
+    query := tx.Model(&entity.Chat{}).Where("user_id = ?", userID)
+    if projectID == 0 {
+        query = query.Where("project_id IS NULL").Where("organization_id IS NULL")
+    } else {
+        query = query.Where("project_id = ?", projectID)
+    }
+
+    return query.Find(&chats).Error
+}
+
+// FindAllByOrganizationID lists the chats of an organization, whoever started them
+func (r *ChatRepositoryImpl) FindAllByOrganizationID(tx *gorm.DB, chats *[]entity.Chat, organizationID int) error {
+    return tx.Model(&entity.Chat{}).Where("organization_id = ?", organizationID).Find(&chats).Error
+}
+
+// FindRecentTopicsByUserID lists the latest personal chats of the user that have a topic, except one chat
+func (r *ChatRepositoryImpl) FindRecentTopicsByUserID(tx *gorm.DB, chats *[]entity.Chat, userID, exceptID, limit int) error {
+    return tx.Model(&entity.Chat{}).
+        Where("user_id = ? AND organization_id IS NULL AND topic IS NOT NULL AND id <> ?", userID, exceptID).
+        Order("updated_at DESC").
+        Limit(limit).
+        Find(&chats).Error
+}
+
 func (r *ChatRepositoryImpl) Create(tx *gorm.DB, chat *entity.Chat) error {
     return tx.Create(chat).Error
 }
 
 func (r *ChatRepositoryImpl) Update(tx *gorm.DB, chat *entity.Chat) error {
     return tx.Save(chat).Error
+}
+
+func (r *ChatRepositoryImpl) Delete(tx *gorm.DB, chat *entity.Chat) error {
+    return tx.Delete(chat).Error
+}
+
+// DeleteAllByUserID removes the personal chats owned by the user, their messages and members go with them
+func (r *ChatRepositoryImpl) DeleteAllByUserID(tx *gorm.DB, userID int) error {
+    return tx.Where("user_id = ? AND organization_id IS NULL", userID).Delete(&entity.Chat{}).Error
+}
+
+// TransferAllByUserIDAndOrganizationID hands the chats of the user in the organization over to toUserID
+func (r *ChatRepositoryImpl) TransferAllByUserIDAndOrganizationID(tx *gorm.DB, userID, organizationID, toUserID int) error {
+    return tx.Model(&entity.Chat{}).
+        Where("user_id = ? AND organization_id = ?", userID, organizationID).
+        Update("user_id", toUserID).Error
 }
\ No newline at end of file
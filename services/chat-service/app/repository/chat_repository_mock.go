@@ -16,11 +16,41 @@ func (m *ChatRepositoryMock) FindByID(tx *gorm.DB, chat *entity.Chat, id int) er
     return args.Error(0)
 }
 
+func (m *ChatRepositoryMock) FindByIDAndUserID(tx *gorm.DB, chat *entity.Chat, id, userID int) error {
+    args := m.Called(tx, chat, id, userID)
+    return args.Error(0)
+}
+
+func (m *ChatRepositoryMock) FindByIDWithMessages(tx *gorm.DB, chat *entity.Chat, id int) error {
+    args := m.Called(tx, chat, id)
+    return args.Error(0)
+}
+
 func (m *ChatRepositoryMock) FindAllByUserID(tx *gorm.DB, chats *[]entity.Chat, userID int) error {
     args := m.Called(tx, chats, userID)
     return args.Error(0)
 }
 
+func (m *ChatRepositoryMock) FindAllPersonalByUserID(tx *gorm.DB, chats *[]entity.Chat, userID int) error {
+    args := m.Called(tx, chats, userID)
+    return args.Error(0)
+}
+
+func (m *ChatRepositoryMock) FindRecentTopicsByUserID(tx *gorm.DB, chats *[]entity.Chat, userID, exceptID, limit int) error {
+    args := m.Called(tx, chats, userID, exceptID, limit)
+    return args.Error(0)
+}
+
+func (m *ChatRepositoryMock) FindAllByUserIDAndProjectID(tx *gorm.DB, chats *[]entity.Chat, userID, projectID int) error {
+    args := m.Called(tx, chats, userID, projectID)
+    return args.Error(0)
+}
+
+func (m *ChatRepositoryMock) FindAllByOrganizationID(tx *gorm.DB, chats *[]entity.Chat, organizationID int) error {
+    args := m.Called(tx, chats, organizationID)
+    return args.Error(0)
+}
+
 func (m *ChatRepositoryMock) Create(tx *gorm.DB, chat *entity.Chat) error {
     args := m.Called(tx, chat)
     return args.Error(0)
@@ -29,4 +59,19 @@ func (m *ChatRepositoryMock) Create(tx *gorm.DB, chat *entity.Chat) error {
 func (m *ChatRepositoryMock) Update(tx *gorm.DB, chat *entity.Chat) error {
     args := m.Called(tx, chat)
     return args.Error(0)
+}
+
+func (m *ChatRepositoryMock) Delete(tx *gorm.DB, chat *entity.Chat) error {
+    args := m.Called(tx, chat)
+    return args.Error(0)
+}
+
+func (m *ChatRepositoryMock) DeleteAllByUserID(tx *gorm.DB, userID int) error {
+    args := m.Called(tx, userID)
+    return args.Error(0)
+}
+
+func (m *ChatRepositoryMock) TransferAllByUserIDAndOrganizationID(tx *gorm.DB, userID, organizationID, toUserID int) error {
+    args := m.Called(tx, userID, organizationID, toUserID)
+    return args.Error(0)
 }
\ No newline at end of file
@@ -153,4 +153,129 @@ func TestChatRepositoryImpl_Update_Error(t *testing.T) {
     err := repo.Update(db, chat)
 
     assert.Error(t, err)
+}
+func TestChatRepositoryImpl_FindAllByUserIDAndProjectID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewChatRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "chats" WHERE user_id = $1 AND project_id = $2`)).
+        WithArgs(1, 2).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "project_id"}).
+            AddRow(1, 1, 2))
+
+    var chats []entity.Chat
+    err := repo.FindAllByUserIDAndProjectID(db, &chats, 1, 2)
+
+    assert.NoError(t, err)
+    assert.Len(t, chats, 1)
+}
+
+func TestChatRepositoryImpl_FindAllByUserIDAndProjectID_Ungrouped(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewChatRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "chats" WHERE user_id = $1 AND project_id IS NULL AND organization_id IS NULL`)).
+        WithArgs(1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).
+            AddRow(1, 1))
+
+    var chats []entity.Chat
+    err := repo.FindAllByUserIDAndProjectID(db, &chats, 1, 0)
+
+    assert.NoError(t, err)
+    assert.Len(t, chats, 1)
+}
+
+func TestChatRepositoryImpl_FindAllPersonalByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewChatRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "chats" WHERE user_id = $1 AND organization_id IS NULL`)).
+        WithArgs(1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).
+            AddRow(1, 1).
+            AddRow(2, 1))
+
+    var chats []entity.Chat
+    err := repo.FindAllPersonalByUserID(db, &chats, 1)
+
+    assert.NoError(t, err)
+    assert.Len(t, chats, 2)
+}
+
+func TestChatRepositoryImpl_FindAllByOrganizationID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewChatRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "chats" WHERE organization_id = $1`)).
+        WithArgs(3).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "organization_id"}).
+            AddRow(1, 1, 3).
+            AddRow(2, 2, 3))
+
+    var chats []entity.Chat
+    err := repo.FindAllByOrganizationID(db, &chats, 3)
+
+    assert.NoError(t, err)
+    assert.Len(t, chats, 2)
+    assert.Equal(t, uint(3), *chats[1].OrganizationID)
+}
+
+func TestChatRepositoryImpl_FindRecentTopicsByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewChatRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "chats" WHERE user_id = $1 AND organization_id IS NULL AND topic IS NOT NULL AND id <> $2 ORDER BY updated_at DESC LIMIT $3`)).
+        WithArgs(1, 5, 10).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "topic"}).
+            AddRow(4, 1, "Go generics").
+            AddRow(2, 1, "Trip to Bali"))
+
+    var chats []entity.Chat
+    err := repo.FindRecentTopicsByUserID(db, &chats, 1, 5, 10)
+
+    assert.NoError(t, err)
+    assert.Len(t, chats, 2)
+    assert.Equal(t, "Go generics", *chats[0].Topic)
+}
+
+func TestChatRepositoryImpl_Delete(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewChatRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "chats" WHERE "chats"."id" = $1`)).
+        WithArgs(1).
+        WillReturnResult(sqlmock.NewResult(0, 1))
+
+    err := repo.Delete(db, &entity.Chat{ID: 1})
+
+    assert.NoError(t, err)
+}
+
+func TestChatRepositoryImpl_DeleteAllByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewChatRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "chats" WHERE user_id = $1 AND organization_id IS NULL`)).
+        WithArgs(2).
+        WillReturnResult(sqlmock.NewResult(0, 3))
+
+    err := repo.DeleteAllByUserID(db, 2)
+
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestChatRepositoryImpl_TransferAllByUserIDAndOrganizationID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewChatRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`UPDATE "chats" SET "user_id"=$1,"updated_at"=$2 WHERE user_id = $3 AND organization_id = $4`)).
+        WithArgs(3, sqlmock.AnyArg(), 2, 5).
+        WillReturnResult(sqlmock.NewResult(0, 2))
+
+    err := repo.TransferAllByUserIDAndOrganizationID(db, 2, 5, 3)
+
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
 }
\ No newline at end of file
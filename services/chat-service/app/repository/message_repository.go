@@ -2,13 +2,22 @@ package repository
 
 import (
 	"auth-service/app/model/entity"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type MessageRepository interface {
     FindAllByChatID(tx *gorm.DB, messages *[]entity.Message, chatID int) error
+    FindAllActiveByChatID(tx *gorm.DB, messages *[]entity.Message, chatID int) error
+    FindByIDAndChatID(tx *gorm.DB, message *entity.Message, id int, chatID int) error
+    CountPromptsByUserIDAndOrganizationID(tx *gorm.DB, count *int64, userID, organizationID int, since time.Time) error
     Create(tx *gorm.DB, message *entity.Message) error
+    ActivateInCompareGroup(tx *gorm.DB, compareGroup string, id int) error
+    SavePreference(tx *gorm.DB, preference *entity.MessagePreference) error
+    DeleteAllByUserID(tx *gorm.DB, userID int) error
+    TransferAllByUserIDAndOrganizationID(tx *gorm.DB, userID, organizationID, toUserID int) error
 }
 
 type MessageRepositoryImpl struct {}
@@ -21,6 +30,53 @@ func (r *MessageRepositoryImpl) FindAllByChatID(tx *gorm.DB, messages *[]entity.
     return tx.Model(&entity.Message{}).Where("chat_id = ?", chatID).Find(&messages).Error
 }
 
+func (r *MessageRepositoryImpl) FindAllActiveByChatID(tx *gorm.DB, messages *[]entity.Message, chatID int) error {
+    return tx.Model(&entity.Message{}).Where("chat_id = ? AND active = ?", chatID, true).Order("id").Find(&messages).Error
+}
+
+func (r *MessageRepositoryImpl) FindByIDAndChatID(tx *gorm.DB, message *entity.Message, id int, chatID int) error {
+    return tx.Where("id = ? AND chat_id = ?", id, chatID).First(message).Error
+}
+
+// CountPromptsByUserIDAndOrganizationID counts the prompts sent by the user in the chats of the organization
+func (r *MessageRepositoryImpl) CountPromptsByUserIDAndOrganizationID(tx *gorm.DB, count *int64, userID, organizationID int, since time.Time) error {
+    return tx.Model(&entity.Message{}).
+        Joins("JOIN chats ON chats.id = messages.chat_id").
+        Where("messages.user_id = ? AND messages.role = 'user' AND chats.organization_id = ? AND messages.created_at >= ?", userID, organizationID, since).
+        Count(count).Error
+}
+
 func (r *MessageRepositoryImpl) Create(tx *gorm.DB, message *entity.Message) error {
     return tx.Create(message).Error
+}
+
+// ActivateInCompareGroup makes the message the only active sibling of its comparison
+func (r *MessageRepositoryImpl) ActivateInCompareGroup(tx *gorm.DB, compareGroup string, id int) error {
+    return tx.Model(&entity.Message{}).
+        Where("compare_group = ?", compareGroup).
+        Update("active", gorm.Expr("id = ?", id)).Error
+}
+
+// SavePreference records the choice of the user, replacing an earlier choice in the same comparison
+func (r *MessageRepositoryImpl) SavePreference(tx *gorm.DB, preference *entity.MessagePreference) error {
+    return tx.Clauses(clause.OnConflict{
+        Columns:   []clause.Column{{Name: "compare_group"}, {Name: "user_id"}},
+        DoUpdates: clause.AssignmentColumns([]string{"message_id", "model", "updated_at"}),
+    }).Create(preference).Error
+}
+
+// TransferAllByUserIDAndOrganizationID hands the messages of the user in the chats of the organization over to toUserID
+func (r *MessageRepositoryImpl) TransferAllByUserIDAndOrganizationID(tx *gorm.DB, userID, organizationID, toUserID int) error {
+    return tx.Model(&entity.Message{}).
+        Where("user_id = ? AND chat_id IN (?)", userID, tx.Model(&entity.Chat{}).Select("id").Where("organization_id = ?", organizationID)).
+        Update("user_id", toUserID).Error
+}
+
+// DeleteAllByUserID removes the messages of the user and their preferences, in any chat
+func (r *MessageRepositoryImpl) DeleteAllByUserID(tx *gorm.DB, userID int) error {
+    if err := tx.Where("user_id = ?", userID).Delete(&entity.MessagePreference{}).Error; err != nil {
+        return err
+    }
+
+    return tx.Where("user_id = ?", userID).Delete(&entity.Message{}).Error
 }
\ No newline at end of file
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"auth-service/app/model/entity"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
@@ -16,7 +17,42 @@ func (m *MessageRepositoryMock) FindAllByChatID(tx *gorm.DB, messages *[]entity.
     return args.Error(0)
 }
 
+func (m *MessageRepositoryMock) FindAllActiveByChatID(tx *gorm.DB, messages *[]entity.Message, chatID int) error {
+    args := m.Called(tx, messages, chatID)
+    return args.Error(0)
+}
+
+func (m *MessageRepositoryMock) FindByIDAndChatID(tx *gorm.DB, message *entity.Message, id int, chatID int) error {
+    args := m.Called(tx, message, id, chatID)
+    return args.Error(0)
+}
+
+func (m *MessageRepositoryMock) CountPromptsByUserIDAndOrganizationID(tx *gorm.DB, count *int64, userID, organizationID int, since time.Time) error {
+    args := m.Called(tx, count, userID, organizationID, since)
+    return args.Error(0)
+}
+
 func (m *MessageRepositoryMock) Create(tx *gorm.DB, message *entity.Message) error {
     args := m.Called(tx, message)
     return args.Error(0)
+}
+
+func (m *MessageRepositoryMock) ActivateInCompareGroup(tx *gorm.DB, compareGroup string, id int) error {
+    args := m.Called(tx, compareGroup, id)
+    return args.Error(0)
+}
+
+func (m *MessageRepositoryMock) SavePreference(tx *gorm.DB, preference *entity.MessagePreference) error {
+    args := m.Called(tx, preference)
+    return args.Error(0)
+}
+
+func (m *MessageRepositoryMock) DeleteAllByUserID(tx *gorm.DB, userID int) error {
+    args := m.Called(tx, userID)
+    return args.Error(0)
+}
+
+func (m *MessageRepositoryMock) TransferAllByUserIDAndOrganizationID(tx *gorm.DB, userID, organizationID, toUserID int) error {
+    args := m.Called(tx, userID, organizationID, toUserID)
+    return args.Error(0)
 }
\ No newline at end of file
@@ -81,4 +81,103 @@ func TestMessageRepositoryImpl_Create_Error(t *testing.T) {
     err := repo.Create(db, message)
 
     assert.Error(t, err)
+}
+func TestMessageRepositoryImpl_FindAllActiveByChatID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewMessageRepositoryImpl()
+
+    chatID := 1
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "messages" WHERE chat_id = $1 AND active = $2 ORDER BY id`)).
+        WithArgs(chatID, true).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "chat_id", "content", "active"}).
+            AddRow(1, chatID, "Hello", true))
+
+    var messages []entity.Message
+    err := repo.FindAllActiveByChatID(db, &messages, chatID)
+
+    assert.NoError(t, err)
+    assert.Len(t, messages, 1)
+    assert.True(t, messages[0].Active)
+}
+
+func TestMessageRepositoryImpl_CountPromptsByUserIDAndOrganizationID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewMessageRepositoryImpl()
+    since := time.Now().Truncate(24 * time.Hour)
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "messages" JOIN chats ON chats.id = messages.chat_id WHERE messages.user_id = $1 AND messages.role = 'user' AND chats.organization_id = $2 AND messages.created_at >= $3`)).
+        WithArgs(1, 3, since).
+        WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+
+    var count int64
+    err := repo.CountPromptsByUserIDAndOrganizationID(db, &count, 1, 3, since)
+
+    assert.NoError(t, err)
+    assert.Equal(t, int64(12), count)
+}
+
+func TestMessageRepositoryImpl_ActivateInCompareGroup(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewMessageRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`UPDATE "messages" SET "active"=id = $1,"updated_at"=$2 WHERE compare_group = $3`)).
+        WithArgs(2, sqlmock.AnyArg(), "group").
+        WillReturnResult(sqlmock.NewResult(0, 2))
+
+    err := repo.ActivateInCompareGroup(db, "group", 2)
+
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMessageRepositoryImpl_SavePreference(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewMessageRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "message_preferences" ("chat_id","user_id","compare_group","message_id","model","created_at","updated_at") VALUES ($1,$2,$3,$4,$5,$6,$7) ON CONFLICT ("compare_group","user_id") DO UPDATE SET "message_id"="excluded"."message_id","model"="excluded"."model","updated_at"="excluded"."updated_at" RETURNING "id"`)).
+        WithArgs(1, 1, "group", 2, "gemini-1.5-pro", sqlmock.AnyArg(), sqlmock.AnyArg()).
+        WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+    preference := &entity.MessagePreference{
+        ChatID:       1,
+        UserID:       1,
+        CompareGroup: "group",
+        MessageID:    2,
+        Model:        "gemini-1.5-pro",
+    }
+    err := repo.SavePreference(db, preference)
+
+    assert.NoError(t, err)
+    assert.Equal(t, uint(1), preference.ID)
+}
+
+func TestMessageRepositoryImpl_DeleteAllByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewMessageRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "message_preferences" WHERE user_id = $1`)).
+        WithArgs(2).
+        WillReturnResult(sqlmock.NewResult(0, 1))
+    mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "messages" WHERE user_id = $1`)).
+        WithArgs(2).
+        WillReturnResult(sqlmock.NewResult(0, 3))
+
+    err := repo.DeleteAllByUserID(db, 2)
+
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMessageRepositoryImpl_TransferAllByUserIDAndOrganizationID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewMessageRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`UPDATE "messages" SET "user_id"=$1,"updated_at"=$2 WHERE user_id = $3 AND chat_id IN (SELECT "id" FROM "chats" WHERE organization_id = $4)`)).
+        WithArgs(3, sqlmock.AnyArg(), 2, 5).
+        WillReturnResult(sqlmock.NewResult(0, 2))
+
+    err := repo.TransferAllByUserIDAndOrganizationID(db, 2, 5, 3)
+
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
 }
\ No newline at end of file
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+
+	"gorm.io/gorm"
+)
+
+type ProjectRepository interface {
+    FindByIDAndUserID(tx *gorm.DB, project *entity.Project, id, userID int) error
+    FindAllByUserID(tx *gorm.DB, projects *[]entity.Project, userID int) error
+    Create(tx *gorm.DB, project *entity.Project) error
+    Update(tx *gorm.DB, project *entity.Project) error
+    Delete(tx *gorm.DB, project *entity.Project) error
+    DeleteFiles(tx *gorm.DB, projectID int) error
+    DeleteAllByUserID(tx *gorm.DB, userID int) error
+}
+
+type ProjectRepositoryImpl struct {}
+
+func NewProjectRepositoryImpl() *ProjectRepositoryImpl {
+    return &ProjectRepositoryImpl{}
+}
+
+func (r *ProjectRepositoryImpl) FindByIDAndUserID(tx *gorm.DB, project *entity.Project, id, userID int) error {
+    return tx.Model(&entity.Project{}).
+        Preload("Files").
+        Where("id = ? AND user_id = ?", id, userID).
+        First(&project).Error
+}
+
+func (r *ProjectRepositoryImpl) FindAllByUserID(tx *gorm.DB, projects *[]entity.Project, userID int) error {
+    return tx.Model(&entity.Project{}).Where("user_id = ?", userID).Order("name").Find(&projects).Error
+}
+
+func (r *ProjectRepositoryImpl) Create(tx *gorm.DB, project *entity.Project) error {
+    return tx.Create(project).Error
+}
+
+func (r *ProjectRepositoryImpl) Update(tx *gorm.DB, project *entity.Project) error {
+    return tx.Save(project).Error
+}
+
+func (r *ProjectRepositoryImpl) Delete(tx *gorm.DB, project *entity.Project) error {
+    return tx.Delete(project).Error
+}
+
+func (r *ProjectRepositoryImpl) DeleteFiles(tx *gorm.DB, projectID int) error {
+    return tx.Where("project_id = ?", projectID).Delete(&entity.ProjectFile{}).Error
+}
+
+func (r *ProjectRepositoryImpl) DeleteAllByUserID(tx *gorm.DB, userID int) error {
+    return tx.Where("user_id = ?", userID).Delete(&entity.Project{}).Error
+}
\ No newline at end of file
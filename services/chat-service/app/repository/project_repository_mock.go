@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type ProjectRepositoryMock struct {
+    mock.Mock
+}
+
+func (m *ProjectRepositoryMock) FindByIDAndUserID(tx *gorm.DB, project *entity.Project, id, userID int) error {
+    args := m.Called(tx, project, id, userID)
+    return args.Error(0)
+}
+
+func (m *ProjectRepositoryMock) FindAllByUserID(tx *gorm.DB, projects *[]entity.Project, userID int) error {
+    args := m.Called(tx, projects, userID)
+    return args.Error(0)
+}
+
+func (m *ProjectRepositoryMock) Create(tx *gorm.DB, project *entity.Project) error {
+    args := m.Called(tx, project)
+    return args.Error(0)
+}
+
+func (m *ProjectRepositoryMock) Update(tx *gorm.DB, project *entity.Project) error {
+    args := m.Called(tx, project)
+    return args.Error(0)
+}
+
+func (m *ProjectRepositoryMock) Delete(tx *gorm.DB, project *entity.Project) error {
+    args := m.Called(tx, project)
+    return args.Error(0)
+}
+
+func (m *ProjectRepositoryMock) DeleteFiles(tx *gorm.DB, projectID int) error {
+    args := m.Called(tx, projectID)
+    return args.Error(0)
+}
+
+func (m *ProjectRepositoryMock) DeleteAllByUserID(tx *gorm.DB, userID int) error {
+    args := m.Called(tx, userID)
+    return args.Error(0)
+}
\ No newline at end of file
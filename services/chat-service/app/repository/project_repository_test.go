@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestProjectRepositoryImpl_FindByIDAndUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewProjectRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "projects" WHERE id = $1 AND user_id = $2 ORDER BY "projects"."id" LIMIT $3`)).
+        WithArgs(1, 1, 1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "instructions", "created_at", "updated_at"}).
+            AddRow(1, 1, "Thesis", "Answer in formal English", time.Now(), time.Now()))
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "project_files" WHERE "project_files"."project_id" = $1`)).
+        WithArgs(1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "project_id", "name", "content"}).
+            AddRow(1, 1, "Outline", "Chapter 1"))
+
+    project := &entity.Project{}
+    err := repo.FindByIDAndUserID(db, project, 1, 1)
+
+    assert.NoError(t, err)
+    assert.Equal(t, "Thesis", project.Name)
+    assert.Len(t, project.Files, 1)
+}
+
+func TestProjectRepositoryImpl_FindByIDAndUserID_Error(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewProjectRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "projects" WHERE id = $1 AND user_id = $2 ORDER BY "projects"."id" LIMIT $3`)).
+        WithArgs(1, 2, 1).
+        WillReturnError(gorm.ErrRecordNotFound)
+
+    project := &entity.Project{}
+    err := repo.FindByIDAndUserID(db, project, 1, 2)
+
+    assert.Error(t, err)
+}
+
+func TestProjectRepositoryImpl_FindAllByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewProjectRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "projects" WHERE user_id = $1 ORDER BY name`)).
+        WithArgs(1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name"}).
+            AddRow(1, 1, "Thesis").
+            AddRow(2, 1, "Work"))
+
+    var projects []entity.Project
+    err := repo.FindAllByUserID(db, &projects, 1)
+
+    assert.NoError(t, err)
+    assert.Len(t, projects, 2)
+}
+
+func TestProjectRepositoryImpl_DeleteFiles(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewProjectRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "project_files" WHERE project_id = $1`)).
+        WithArgs(1).
+        WillReturnResult(sqlmock.NewResult(0, 2))
+
+    err := repo.DeleteFiles(db, 1)
+
+    assert.NoError(t, err)
+}
+
+func TestProjectRepositoryImpl_DeleteAllByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewProjectRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "projects" WHERE user_id = $1`)).
+        WithArgs(2).
+        WillReturnResult(sqlmock.NewResult(0, 3))
+
+    err := repo.DeleteAllByUserID(db, 2)
+
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
\ No newline at end of file
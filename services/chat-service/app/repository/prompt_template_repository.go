@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+
+	"gorm.io/gorm"
+)
+
+type PromptTemplateRepository interface {
+    FindByIDAndUserID(tx *gorm.DB, promptTemplate *entity.PromptTemplate, id, userID int) error
+    FindAllByUserID(tx *gorm.DB, promptTemplates *[]entity.PromptTemplate, userID int) error
+    FindAllPersonalByUserID(tx *gorm.DB, promptTemplates *[]entity.PromptTemplate, userID int) error
+    FindAllByOrganizationID(tx *gorm.DB, promptTemplates *[]entity.PromptTemplate, organizationID int) error
+    Create(tx *gorm.DB, promptTemplate *entity.PromptTemplate) error
+    Update(tx *gorm.DB, promptTemplate *entity.PromptTemplate) error
+    Delete(tx *gorm.DB, promptTemplate *entity.PromptTemplate) error
+    DeleteAllByUserID(tx *gorm.DB, userID int) error
+    TransferAllByUserIDAndOrganizationID(tx *gorm.DB, userID, organizationID, toUserID int) error
+}
+
+type PromptTemplateRepositoryImpl struct {}
+
+func NewPromptTemplateRepositoryImpl() *PromptTemplateRepositoryImpl {
+    return &PromptTemplateRepositoryImpl{}
+}
+
+func (r *PromptTemplateRepositoryImpl) FindByIDAndUserID(tx *gorm.DB, promptTemplate *entity.PromptTemplate, id, userID int) error {
+    return tx.Model(&entity.PromptTemplate{}).Where("id = ? AND user_id = ?", id, userID).First(&promptTemplate).Error
+}
+
+func (r *PromptTemplateRepositoryImpl) FindAllByUserID(tx *gorm.DB, promptTemplates *[]entity.PromptTemplate, userID int) error {
+    return tx.Model(&entity.PromptTemplate{}).Where("user_id = ?", userID).Order("name").Find(&promptTemplates).Error
+}
+
+// FindAllPersonalByUserID lists the templates of the user outside any organization
+func (r *PromptTemplateRepositoryImpl) FindAllPersonalByUserID(tx *gorm.DB, promptTemplates *[]entity.PromptTemplate, userID int) error {
+    return tx.Model(&entity.PromptTemplate{}).
+        Where("user_id = ? AND organization_id IS NULL", userID).
+        Order("name").
+        Find(&promptTemplates).Error
+}
+
+func (r *PromptTemplateRepositoryImpl) FindAllByOrganizationID(tx *gorm.DB, promptTemplates *[]entity.PromptTemplate, organizationID int) error {
+    return tx.Model(&entity.PromptTemplate{}).Where("organization_id = ?", organizationID).Order("name").Find(&promptTemplates).Error
+}
+
+func (r *PromptTemplateRepositoryImpl) Create(tx *gorm.DB, promptTemplate *entity.PromptTemplate) error {
+    return tx.Create(promptTemplate).Error
+}
+
+func (r *PromptTemplateRepositoryImpl) Update(tx *gorm.DB, promptTemplate *entity.PromptTemplate) error {
+    return tx.Save(promptTemplate).Error
+}
+
+func (r *PromptTemplateRepositoryImpl) Delete(tx *gorm.DB, promptTemplate *entity.PromptTemplate) error {
+    return tx.Delete(promptTemplate).Error
+}
+
+// DeleteAllByUserID removes the personal prompt templates of the user
+func (r *PromptTemplateRepositoryImpl) DeleteAllByUserID(tx *gorm.DB, userID int) error {
+    return tx.Where("user_id = ? AND organization_id IS NULL", userID).Delete(&entity.PromptTemplate{}).Error
+}
+
+// TransferAllByUserIDAndOrganizationID hands the prompt templates of the user in the organization over to toUserID
+func (r *PromptTemplateRepositoryImpl) TransferAllByUserIDAndOrganizationID(tx *gorm.DB, userID, organizationID, toUserID int) error {
+    return tx.Model(&entity.PromptTemplate{}).
+        Where("user_id = ? AND organization_id = ?", userID, organizationID).
+        Update("user_id", toUserID).Error
+}
\ No newline at end of file
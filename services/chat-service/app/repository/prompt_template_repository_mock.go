@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type PromptTemplateRepositoryMock struct {
+    mock.Mock
+}
+
+func (m *PromptTemplateRepositoryMock) FindByIDAndUserID(tx *gorm.DB, promptTemplate *entity.PromptTemplate, id, userID int) error {
+    args := m.Called(tx, promptTemplate, id, userID)
+    return args.Error(0)
+}
+
+func (m *PromptTemplateRepositoryMock) FindAllByUserID(tx *gorm.DB, promptTemplates *[]entity.PromptTemplate, userID int) error {
+    args := m.Called(tx, promptTemplates, userID)
+    return args.Error(0)
+}
+
+func (m *PromptTemplateRepositoryMock) FindAllPersonalByUserID(tx *gorm.DB, promptTemplates *[]entity.PromptTemplate, userID int) error {
+    args := m.Called(tx, promptTemplates, userID)
+    return args.Error(0)
+}
+
+func (m *PromptTemplateRepositoryMock) FindAllByOrganizationID(tx *gorm.DB, promptTemplates *[]entity.PromptTemplate, organizationID int) error {
+    args := m.Called(tx, promptTemplates, organizationID)
+    return args.Error(0)
+}
+
+func (m *PromptTemplateRepositoryMock) Create(tx *gorm.DB, promptTemplate *entity.PromptTemplate) error {
+    args := m.Called(tx, promptTemplate)
+    return args.Error(0)
+}
+
+func (m *PromptTemplateRepositoryMock) Update(tx *gorm.DB, promptTemplate *entity.PromptTemplate) error {
+    args := m.Called(tx, promptTemplate)
+    return args.Error(0)
+}
+
+func (m *PromptTemplateRepositoryMock) Delete(tx *gorm.DB, promptTemplate *entity.PromptTemplate) error {
+    args := m.Called(tx, promptTemplate)
+    return args.Error(0)
+}
+
+func (m *PromptTemplateRepositoryMock) DeleteAllByUserID(tx *gorm.DB, userID int) error {
+    args := m.Called(tx, userID)
+    return args.Error(0)
+}
+
+func (m *PromptTemplateRepositoryMock) TransferAllByUserIDAndOrganizationID(tx *gorm.DB, userID, organizationID, toUserID int) error {
+    args := m.Called(tx, userID, organizationID, toUserID)
+    return args.Error(0)
+}
\ No newline at end of file
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestPromptTemplateRepositoryImpl_FindByIDAndUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewPromptTemplateRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "prompt_templates" WHERE id = $1 AND user_id = $2 ORDER BY "prompt_templates"."id" LIMIT $3`)).
+        WithArgs(1, 1, 1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "content"}).
+            AddRow(1, 1, "Code review", "Review the following diff"))
+
+    promptTemplate := &entity.PromptTemplate{}
+    err := repo.FindByIDAndUserID(db, promptTemplate, 1, 1)
+
+    assert.NoError(t, err)
+    assert.Equal(t, "Code review", promptTemplate.Name)
+}
+
+func TestPromptTemplateRepositoryImpl_FindByIDAndUserID_Error(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewPromptTemplateRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "prompt_templates" WHERE id = $1 AND user_id = $2 ORDER BY "prompt_templates"."id" LIMIT $3`)).
+        WithArgs(1, 2, 1).
+        WillReturnError(gorm.ErrRecordNotFound)
+
+    promptTemplate := &entity.PromptTemplate{}
+    err := repo.FindByIDAndUserID(db, promptTemplate, 1, 2)
+
+    assert.Error(t, err)
+}
+
+func TestPromptTemplateRepositoryImpl_FindAllPersonalByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewPromptTemplateRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "prompt_templates" WHERE user_id = $1 AND organization_id IS NULL ORDER BY name`)).
+        WithArgs(1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name"}).
+            AddRow(1, 1, "Code review").
+            AddRow(2, 1, "Summary"))
+
+    var promptTemplates []entity.PromptTemplate
+    err := repo.FindAllPersonalByUserID(db, &promptTemplates, 1)
+
+    assert.NoError(t, err)
+    assert.Len(t, promptTemplates, 2)
+}
+
+func TestPromptTemplateRepositoryImpl_FindAllByOrganizationID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewPromptTemplateRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "prompt_templates" WHERE organization_id = $1 ORDER BY name`)).
+        WithArgs(3).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "organization_id", "name"}).
+            AddRow(1, 1, 3, "Incident report").
+            AddRow(2, 2, 3, "Release notes"))
+
+    var promptTemplates []entity.PromptTemplate
+    err := repo.FindAllByOrganizationID(db, &promptTemplates, 3)
+
+    assert.NoError(t, err)
+    assert.Len(t, promptTemplates, 2)
+}
+
+func TestPromptTemplateRepositoryImpl_Delete(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewPromptTemplateRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "prompt_templates" WHERE "prompt_templates"."id" = $1`)).
+        WithArgs(1).
+        WillReturnResult(sqlmock.NewResult(0, 1))
+
+    err := repo.Delete(db, &entity.PromptTemplate{ID: 1})
+
+    assert.NoError(t, err)
+}
+
+func TestPromptTemplateRepositoryImpl_DeleteAllByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewPromptTemplateRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "prompt_templates" WHERE user_id = $1 AND organization_id IS NULL`)).
+        WithArgs(1).
+        WillReturnResult(sqlmock.NewResult(0, 2))
+
+    err := repo.DeleteAllByUserID(db, 1)
+
+    assert.NoError(t, err)
+}
+
+func TestPromptTemplateRepositoryImpl_TransferAllByUserIDAndOrganizationID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewPromptTemplateRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`UPDATE "prompt_templates" SET "user_id"=$1,"updated_at"=$2 WHERE user_id = $3 AND organization_id = $4`)).
+        WithArgs(3, sqlmock.AnyArg(), 2, 5).
+        WillReturnResult(sqlmock.NewResult(0, 2))
+
+    err := repo.TransferAllByUserIDAndOrganizationID(db, 2, 5, 3)
+
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
\ No newline at end of file
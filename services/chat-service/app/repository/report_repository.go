@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ReportRepository interface {
+    UsageTotals(tx *gorm.DB, totals *entity.UsageTotals, since time.Time) error
+    UsagePerDay(tx *gorm.DB, days *[]entity.UsageDay, since time.Time) error
+    TopUsers(tx *gorm.DB, users *[]entity.UserUsage, since time.Time, limit int) error
+    CountComparisons(tx *gorm.DB, count *int64, since time.Time) error
+    CountPreferences(tx *gorm.DB, count *int64, since time.Time) error
+    ModelFeedback(tx *gorm.DB, models *[]entity.ModelFeedback, since time.Time) error
+}
+
+type ReportRepositoryImpl struct {}
+
+func NewReportRepositoryImpl() *ReportRepositoryImpl {
+    return &ReportRepositoryImpl{}
+}
+
+// UsageTotals counts prompts sent by users and replies of the models, active users sent at least one prompt
+func (r *ReportRepositoryImpl) UsageTotals(tx *gorm.DB, totals *entity.UsageTotals, since time.Time) error {
+    return tx.Model(&entity.Message{}).
+        Select("COUNT(*) AS messages, COUNT(*) FILTER (WHERE role = 'user') AS prompts, COUNT(*) FILTER (WHERE role = 'model') AS replies, COUNT(DISTINCT chat_id) AS chats, COUNT(DISTINCT user_id) FILTER (WHERE role = 'user') AS active_users").
+        Where("created_at >= ?", since).
+        Scan(totals).Error
+}
+
+func (r *ReportRepositoryImpl) UsagePerDay(tx *gorm.DB, days *[]entity.UsageDay, since time.Time) error {
+    return tx.Model(&entity.Message{}).
+        Select("DATE(created_at) AS day, COUNT(*) AS messages, COUNT(DISTINCT user_id) FILTER (WHERE role = 'user') AS active_users").
+        Where("created_at >= ?", since).
+        Group("DATE(created_at)").
+        Order("day").
+        Scan(days).Error
+}
+
+func (r *ReportRepositoryImpl) TopUsers(tx *gorm.DB, users *[]entity.UserUsage, since time.Time, limit int) error {
+    return tx.Model(&entity.Message{}).
+        Select("user_id, COUNT(*) AS prompts").
+        Where("role = 'user' AND created_at >= ?", since).
+        Group("user_id").
+        Order("prompts DESC").
+        Limit(limit).
+        Scan(users).Error
+}
+
+func (r *ReportRepositoryImpl) CountComparisons(tx *gorm.DB, count *int64, since time.Time) error {
+    return tx.Model(&entity.Message{}).
+        Where("compare_group IS NOT NULL AND created_at >= ?", since).
+        Distinct("compare_group").
+        Count(count).Error
+}
+
+func (r *ReportRepositoryImpl) CountPreferences(tx *gorm.DB, count *int64, since time.Time) error {
+    return tx.Model(&entity.MessagePreference{}).Where("created_at >= ?", since).Count(count).Error
+}
+
+// ModelFeedback counts how often every model replied in a comparison and how often its reply was preferred
+func (r *ReportRepositoryImpl) ModelFeedback(tx *gorm.DB, models *[]entity.ModelFeedback, since time.Time) error {
+    return tx.Model(&entity.Message{}).
+        Select("messages.model AS model, COUNT(DISTINCT messages.id) AS shown, COUNT(message_preferences.id) AS preferred").
+        Joins("LEFT JOIN message_preferences ON message_preferences.message_id = messages.id").
+        Where("messages.compare_group IS NOT NULL AND messages.role = 'model' AND messages.created_at >= ?", since).
+        Group("messages.model").
+        Order("preferred DESC, shown DESC").
+        Scan(models).Error
+}
\ No newline at end of file
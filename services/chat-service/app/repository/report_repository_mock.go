@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type ReportRepositoryMock struct {
+    mock.Mock
+}
+
+func (m *ReportRepositoryMock) UsageTotals(tx *gorm.DB, totals *entity.UsageTotals, since time.Time) error {
+    args := m.Called(tx, totals, since)
+    return args.Error(0)
+}
+
+func (m *ReportRepositoryMock) UsagePerDay(tx *gorm.DB, days *[]entity.UsageDay, since time.Time) error {
+    args := m.Called(tx, days, since)
+    return args.Error(0)
+}
+
+func (m *ReportRepositoryMock) TopUsers(tx *gorm.DB, users *[]entity.UserUsage, since time.Time, limit int) error {
+    args := m.Called(tx, users, since, limit)
+    return args.Error(0)
+}
+
+func (m *ReportRepositoryMock) CountComparisons(tx *gorm.DB, count *int64, since time.Time) error {
+    args := m.Called(tx, count, since)
+    return args.Error(0)
+}
+
+func (m *ReportRepositoryMock) CountPreferences(tx *gorm.DB, count *int64, since time.Time) error {
+    args := m.Called(tx, count, since)
+    return args.Error(0)
+}
+
+func (m *ReportRepositoryMock) ModelFeedback(tx *gorm.DB, models *[]entity.ModelFeedback, since time.Time) error {
+    args := m.Called(tx, models, since)
+    return args.Error(0)
+}
\ No newline at end of file
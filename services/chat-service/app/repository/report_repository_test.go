@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportRepositoryImpl_UsageTotals(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewReportRepositoryImpl()
+    since := time.Now().AddDate(0, 0, -30)
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS messages, COUNT(*) FILTER (WHERE role = 'user') AS prompts, COUNT(*) FILTER (WHERE role = 'model') AS replies, COUNT(DISTINCT chat_id) AS chats, COUNT(DISTINCT user_id) FILTER (WHERE role = 'user') AS active_users FROM "messages" WHERE created_at >= $1`)).
+        WithArgs(since).
+        WillReturnRows(sqlmock.NewRows([]string{"messages", "prompts", "replies", "chats", "active_users"}).
+            AddRow(20, 10, 10, 4, 3))
+
+    var totals entity.UsageTotals
+    err := repo.UsageTotals(db, &totals, since)
+
+    assert.NoError(t, err)
+    assert.Equal(t, int64(20), totals.Messages)
+    assert.Equal(t, int64(3), totals.ActiveUsers)
+}
+
+func TestReportRepositoryImpl_UsagePerDay(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewReportRepositoryImpl()
+    since := time.Now().AddDate(0, 0, -30)
+    day := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT DATE(created_at) AS day, COUNT(*) AS messages, COUNT(DISTINCT user_id) FILTER (WHERE role = 'user') AS active_users FROM "messages" WHERE created_at >= $1 GROUP BY DATE(created_at) ORDER BY day`)).
+        WithArgs(since).
+        WillReturnRows(sqlmock.NewRows([]string{"day", "messages", "active_users"}).
+            AddRow(day, 12, 2))
+
+    var days []entity.UsageDay
+    err := repo.UsagePerDay(db, &days, since)
+
+    assert.NoError(t, err)
+    assert.Len(t, days, 1)
+    assert.Equal(t, day, days[0].Day)
+}
+
+func TestReportRepositoryImpl_TopUsers(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewReportRepositoryImpl()
+    since := time.Now().AddDate(0, 0, -30)
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT user_id, COUNT(*) AS prompts FROM "messages" WHERE role = 'user' AND created_at >= $1 GROUP BY "user_id" ORDER BY prompts DESC LIMIT $2`)).
+        WithArgs(since, 10).
+        WillReturnRows(sqlmock.NewRows([]string{"user_id", "prompts"}).
+            AddRow(1, 7).
+            AddRow(2, 3))
+
+    var users []entity.UserUsage
+    err := repo.TopUsers(db, &users, since, 10)
+
+    assert.NoError(t, err)
+    assert.Len(t, users, 2)
+    assert.Equal(t, uint(1), users[0].UserID)
+}
+
+func TestReportRepositoryImpl_CountComparisons(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewReportRepositoryImpl()
+    since := time.Now().AddDate(0, 0, -30)
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(DISTINCT("compare_group")) FROM "messages" WHERE compare_group IS NOT NULL AND created_at >= $1`)).
+        WithArgs(since).
+        WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+
+    var count int64
+    err := repo.CountComparisons(db, &count, since)
+
+    assert.NoError(t, err)
+    assert.Equal(t, int64(5), count)
+}
+
+func TestReportRepositoryImpl_CountPreferences(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewReportRepositoryImpl()
+    since := time.Now().AddDate(0, 0, -30)
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "message_preferences" WHERE created_at >= $1`)).
+        WithArgs(since).
+        WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+
+    var count int64
+    err := repo.CountPreferences(db, &count, since)
+
+    assert.NoError(t, err)
+    assert.Equal(t, int64(4), count)
+}
+
+func TestReportRepositoryImpl_ModelFeedback(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewReportRepositoryImpl()
+    since := time.Now().AddDate(0, 0, -30)
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT messages.model AS model, COUNT(DISTINCT messages.id) AS shown, COUNT(message_preferences.id) AS preferred FROM "messages" LEFT JOIN message_preferences ON message_preferences.message_id = messages.id WHERE messages.compare_group IS NOT NULL AND messages.role = 'model' AND messages.created_at >= $1 GROUP BY "messages"."model" ORDER BY preferred DESC, shown DESC`)).
+        WithArgs(since).
+        WillReturnRows(sqlmock.NewRows([]string{"model", "shown", "preferred"}).
+            AddRow("gemini-1.5-pro", 5, 3).
+            AddRow("gemini-1.5-flash", 5, 1))
+
+    var models []entity.ModelFeedback
+    err := repo.ModelFeedback(db, &models, since)
+
+    assert.NoError(t, err)
+    assert.Len(t, models, 2)
+    assert.Equal(t, int64(3), models[0].Preferred)
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ScheduledPromptRepository interface {
+    FindByIDAndUserID(tx *gorm.DB, scheduledPrompt *entity.ScheduledPrompt, id, userID int) error
+    FindAllByUserID(tx *gorm.DB, scheduledPrompts *[]entity.ScheduledPrompt, userID int) error
+    FindAllDue(tx *gorm.DB, scheduledPrompts *[]entity.ScheduledPrompt, now time.Time) error
+    Create(tx *gorm.DB, scheduledPrompt *entity.ScheduledPrompt) error
+    Update(tx *gorm.DB, scheduledPrompt *entity.ScheduledPrompt) error
+    Delete(tx *gorm.DB, scheduledPrompt *entity.ScheduledPrompt) error
+    FindAllRunsByScheduledPromptID(tx *gorm.DB, runs *[]entity.ScheduledPromptRun, scheduledPromptID int) error
+    CreateRun(tx *gorm.DB, run *entity.ScheduledPromptRun) error
+    UpdateRun(tx *gorm.DB, run *entity.ScheduledPromptRun) error
+    TryAdvisoryLock(tx *gorm.DB, key int64) (bool, error)
+    DeleteAllByUserID(tx *gorm.DB, userID int) error
+}
+
+type ScheduledPromptRepositoryImpl struct {}
+
+func NewScheduledPromptRepositoryImpl() *ScheduledPromptRepositoryImpl {
+    return &ScheduledPromptRepositoryImpl{}
+}
+
+func (r *ScheduledPromptRepositoryImpl) FindByIDAndUserID(tx *gorm.DB, scheduledPrompt *entity.ScheduledPrompt, id, userID int) error {
+    return tx.Model(&entity.ScheduledPrompt{}).Where("id = ? AND user_id = ?", id, userID).First(&scheduledPrompt).Error
+}
+
+func (r *ScheduledPromptRepositoryImpl) FindAllByUserID(tx *gorm.DB, scheduledPrompts *[]entity.ScheduledPrompt, userID int) error {
+    return tx.Model(&entity.ScheduledPrompt{}).Where("user_id = ?", userID).Order("name").Find(&scheduledPrompts).Error
+}
+
+func (r *ScheduledPromptRepositoryImpl) FindAllDue(tx *gorm.DB, scheduledPrompts *[]entity.ScheduledPrompt, now time.Time) error {
+    return tx.Model(&entity.ScheduledPrompt{}).
+        Where("enabled = ? AND next_run_at <= ?", true, now).
+        Order("next_run_at").
+        Find(&scheduledPrompts).Error
+}
+
+func (r *ScheduledPromptRepositoryImpl) Create(tx *gorm.DB, scheduledPrompt *entity.ScheduledPrompt) error {
+    return tx.Create(scheduledPrompt).Error
+}
+
+func (r *ScheduledPromptRepositoryImpl) Update(tx *gorm.DB, scheduledPrompt *entity.ScheduledPrompt) error {
+    return tx.Save(scheduledPrompt).Error
+}
+
+func (r *ScheduledPromptRepositoryImpl) Delete(tx *gorm.DB, scheduledPrompt *entity.ScheduledPrompt) error {
+    return tx.Delete(scheduledPrompt).Error
+}
+
+func (r *ScheduledPromptRepositoryImpl) FindAllRunsByScheduledPromptID(tx *gorm.DB, runs *[]entity.ScheduledPromptRun, scheduledPromptID int) error {
+    return tx.Model(&entity.ScheduledPromptRun{}).
+        Where("scheduled_prompt_id = ?", scheduledPromptID).
+        Order("scheduled_for DESC").
+        Limit(50).
+        Find(&runs).Error
+}
+
+func (r *ScheduledPromptRepositoryImpl) CreateRun(tx *gorm.DB, run *entity.ScheduledPromptRun) error {
+    return tx.Create(run).Error
+}
+
+func (r *ScheduledPromptRepositoryImpl) UpdateRun(tx *gorm.DB, run *entity.ScheduledPromptRun) error {
+    return tx.Save(run).Error
+}
+
+// TryAdvisoryLock takes a transaction scoped advisory lock, it is released on commit or rollback
+func (r *ScheduledPromptRepositoryImpl) TryAdvisoryLock(tx *gorm.DB, key int64) (bool, error) {
+    var locked bool
+    err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", key).Scan(&locked).Error
+    return locked, err
+}
+
+func (r *ScheduledPromptRepositoryImpl) DeleteAllByUserID(tx *gorm.DB, userID int) error {
+    return tx.Where("user_id = ?", userID).Delete(&entity.ScheduledPrompt{}).Error
+}
\ No newline at end of file
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type ScheduledPromptRepositoryMock struct {
+    mock.Mock
+}
+
+func (m *ScheduledPromptRepositoryMock) FindByIDAndUserID(tx *gorm.DB, scheduledPrompt *entity.ScheduledPrompt, id, userID int) error {
+    args := m.Called(tx, scheduledPrompt, id, userID)
+    return args.Error(0)
+}
+
+func (m *ScheduledPromptRepositoryMock) FindAllByUserID(tx *gorm.DB, scheduledPrompts *[]entity.ScheduledPrompt, userID int) error {
+    args := m.Called(tx, scheduledPrompts, userID)
+    return args.Error(0)
+}
+
+func (m *ScheduledPromptRepositoryMock) FindAllDue(tx *gorm.DB, scheduledPrompts *[]entity.ScheduledPrompt, now time.Time) error {
+    args := m.Called(tx, scheduledPrompts, now)
+    return args.Error(0)
+}
+
+func (m *ScheduledPromptRepositoryMock) Create(tx *gorm.DB, scheduledPrompt *entity.ScheduledPrompt) error {
+    args := m.Called(tx, scheduledPrompt)
+    return args.Error(0)
+}
+
+func (m *ScheduledPromptRepositoryMock) Update(tx *gorm.DB, scheduledPrompt *entity.ScheduledPrompt) error {
+    args := m.Called(tx, scheduledPrompt)
+    return args.Error(0)
+}
+
+func (m *ScheduledPromptRepositoryMock) Delete(tx *gorm.DB, scheduledPrompt *entity.ScheduledPrompt) error {
+    args := m.Called(tx, scheduledPrompt)
+    return args.Error(0)
+}
+
+func (m *ScheduledPromptRepositoryMock) FindAllRunsByScheduledPromptID(tx *gorm.DB, runs *[]entity.ScheduledPromptRun, scheduledPromptID int) error {
+    args := m.Called(tx, runs, scheduledPromptID)
+    return args.Error(0)
+}
+
+func (m *ScheduledPromptRepositoryMock) CreateRun(tx *gorm.DB, run *entity.ScheduledPromptRun) error {
+    args := m.Called(tx, run)
+    return args.Error(0)
+}
+
+func (m *ScheduledPromptRepositoryMock) UpdateRun(tx *gorm.DB, run *entity.ScheduledPromptRun) error {
+    args := m.Called(tx, run)
+    return args.Error(0)
+}
+
+func (m *ScheduledPromptRepositoryMock) TryAdvisoryLock(tx *gorm.DB, key int64) (bool, error) {
+    args := m.Called(tx, key)
+    return args.Bool(0), args.Error(1)
+}
+
+func (m *ScheduledPromptRepositoryMock) DeleteAllByUserID(tx *gorm.DB, userID int) error {
+    args := m.Called(tx, userID)
+    return args.Error(0)
+}
\ No newline at end of file
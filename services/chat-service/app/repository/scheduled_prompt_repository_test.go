@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestScheduledPromptRepositoryImpl_FindByIDAndUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewScheduledPromptRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "scheduled_prompts" WHERE id = $1 AND user_id = $2 ORDER BY "scheduled_prompts"."id" LIMIT $3`)).
+        WithArgs(1, 1, 1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "prompt", "cron_expression", "timezone", "catch_up_policy", "enabled"}).
+            AddRow(1, 1, "Release notes", "Summarize the release notes", "0 9 * * 1", "Asia/Jakarta", "skip", true))
+
+    scheduledPrompt := &entity.ScheduledPrompt{}
+    err := repo.FindByIDAndUserID(db, scheduledPrompt, 1, 1)
+
+    assert.NoError(t, err)
+    assert.Equal(t, "0 9 * * 1", scheduledPrompt.CronExpression)
+    assert.Equal(t, "Asia/Jakarta", scheduledPrompt.Timezone)
+}
+
+func TestScheduledPromptRepositoryImpl_FindByIDAndUserID_Error(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewScheduledPromptRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "scheduled_prompts" WHERE id = $1 AND user_id = $2 ORDER BY "scheduled_prompts"."id" LIMIT $3`)).
+        WithArgs(1, 2, 1).
+        WillReturnError(gorm.ErrRecordNotFound)
+
+    scheduledPrompt := &entity.ScheduledPrompt{}
+    err := repo.FindByIDAndUserID(db, scheduledPrompt, 1, 2)
+
+    assert.Error(t, err)
+}
+
+func TestScheduledPromptRepositoryImpl_FindAllDue(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewScheduledPromptRepositoryImpl()
+    now := time.Now()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "scheduled_prompts" WHERE enabled = $1 AND next_run_at <= $2 ORDER BY next_run_at`)).
+        WithArgs(true, now).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "next_run_at"}).
+            AddRow(1, 1, "Release notes", now.Add(-time.Minute)).
+            AddRow(2, 3, "Standup", now.Add(-time.Hour)))
+
+    var scheduledPrompts []entity.ScheduledPrompt
+    err := repo.FindAllDue(db, &scheduledPrompts, now)
+
+    assert.NoError(t, err)
+    assert.Len(t, scheduledPrompts, 2)
+}
+
+func TestScheduledPromptRepositoryImpl_FindAllRunsByScheduledPromptID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewScheduledPromptRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "scheduled_prompt_runs" WHERE scheduled_prompt_id = $1 ORDER BY scheduled_for DESC LIMIT $2`)).
+        WithArgs(1, 50).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "scheduled_prompt_id", "status", "scheduled_for"}).
+            AddRow(2, 1, "succeeded", time.Now()).
+            AddRow(1, 1, "skipped", time.Now().Add(-7 * 24 * time.Hour)))
+
+    var runs []entity.ScheduledPromptRun
+    err := repo.FindAllRunsByScheduledPromptID(db, &runs, 1)
+
+    assert.NoError(t, err)
+    assert.Len(t, runs, 2)
+    assert.Equal(t, "succeeded", runs[0].Status)
+}
+
+func TestScheduledPromptRepositoryImpl_TryAdvisoryLock(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewScheduledPromptRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT pg_try_advisory_xact_lock($1)`)).
+        WithArgs(42).
+        WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(true))
+
+    locked, err := repo.TryAdvisoryLock(db, 42)
+
+    assert.NoError(t, err)
+    assert.True(t, locked)
+}
+
+func TestScheduledPromptRepositoryImpl_DeleteAllByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewScheduledPromptRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "scheduled_prompts" WHERE user_id = $1`)).
+        WithArgs(2).
+        WillReturnResult(sqlmock.NewResult(0, 3))
+
+    err := repo.DeleteAllByUserID(db, 2)
+
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
\ No newline at end of file
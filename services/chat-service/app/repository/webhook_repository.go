@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookRepository interface {
+    FindByIDAndUserID(tx *gorm.DB, webhook *entity.Webhook, id, userID int) error
+    FindAllByUserID(tx *gorm.DB, webhooks *[]entity.Webhook, userID int) error
+    FindAllSubscribed(tx *gorm.DB, webhooks *[]entity.Webhook, userID int, event string) error
+    Create(tx *gorm.DB, webhook *entity.Webhook) error
+    Update(tx *gorm.DB, webhook *entity.Webhook) error
+    Delete(tx *gorm.DB, webhook *entity.Webhook) error
+    FindDeliveryByIDAndWebhookID(tx *gorm.DB, delivery *entity.WebhookDelivery, id, webhookID int) error
+    FindAllDeliveriesByWebhookID(tx *gorm.DB, deliveries *[]entity.WebhookDelivery, webhookID int) error
+    FindAllDueDeliveries(tx *gorm.DB, deliveries *[]entity.WebhookDelivery, now time.Time, limit int) error
+    CreateDelivery(tx *gorm.DB, delivery *entity.WebhookDelivery) error
+    UpdateDelivery(tx *gorm.DB, delivery *entity.WebhookDelivery) error
+    DeleteAllByUserID(tx *gorm.DB, userID int) error
+}
+
+type WebhookRepositoryImpl struct {}
+
+func NewWebhookRepositoryImpl() *WebhookRepositoryImpl {
+    return &WebhookRepositoryImpl{}
+}
+
+func (r *WebhookRepositoryImpl) FindByIDAndUserID(tx *gorm.DB, webhook *entity.Webhook, id, userID int) error {
+    return tx.Model(&entity.Webhook{}).Where("id = ? AND user_id = ?", id, userID).First(&webhook).Error
+}
+
+func (r *WebhookRepositoryImpl) FindAllByUserID(tx *gorm.DB, webhooks *[]entity.Webhook, userID int) error {
+    return tx.Model(&entity.Webhook{}).Where("user_id = ?", userID).Order("id").Find(&webhooks).Error
+}
+
+// FindAllSubscribed lists the enabled webhooks of the user subscribed to the event
+func (r *WebhookRepositoryImpl) FindAllSubscribed(tx *gorm.DB, webhooks *[]entity.Webhook, userID int, event string) error {
+    events, err := json.Marshal([]string{event})
+    if err != nil {
+        return err
+    }
+
+    return tx.Model(&entity.Webhook{}).
+        Where("user_id = ? AND enabled = ? AND events @> ?", userID, true, string(events)).
+        Find(&webhooks).Error
+}
+
+func (r *WebhookRepositoryImpl) Create(tx *gorm.DB, webhook *entity.Webhook) error {
+    return tx.Create(webhook).Error
+}
+
+func (r *WebhookRepositoryImpl) Update(tx *gorm.DB, webhook *entity.Webhook) error {
+    return tx.Save(webhook).Error
+}
+
+func (r *WebhookRepositoryImpl) Delete(tx *gorm.DB, webhook *entity.Webhook) error {
+    return tx.Delete(webhook).Error
+}
+
+func (r *WebhookRepositoryImpl) FindDeliveryByIDAndWebhookID(tx *gorm.DB, delivery *entity.WebhookDelivery, id, webhookID int) error {
+    return tx.Model(&entity.WebhookDelivery{}).Where("id = ? AND webhook_id = ?", id, webhookID).First(&delivery).Error
+}
+
+func (r *WebhookRepositoryImpl) FindAllDeliveriesByWebhookID(tx *gorm.DB, deliveries *[]entity.WebhookDelivery, webhookID int) error {
+    return tx.Model(&entity.WebhookDelivery{}).
+        Where("webhook_id = ?", webhookID).
+        Order("id DESC").
+        Limit(50).
+        Find(&deliveries).Error
+}
+
+// FindAllDueDeliveries locks pending deliveries whose next attempt is due, rows
+// locked by another replica are skipped
+func (r *WebhookRepositoryImpl) FindAllDueDeliveries(tx *gorm.DB, deliveries *[]entity.WebhookDelivery, now time.Time, limit int) error {
+    return tx.Model(&entity.WebhookDelivery{}).
+        Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+        Preload("Webhook").
+        Where("status = ? AND next_attempt_at <= ?", "pending", now).
+        Order("next_attempt_at").
+        Limit(limit).
+        Find(&deliveries).Error
+}
+
+func (r *WebhookRepositoryImpl) CreateDelivery(tx *gorm.DB, delivery *entity.WebhookDelivery) error {
+    return tx.Omit("Webhook").Create(delivery).Error
+}
+
+func (r *WebhookRepositoryImpl) UpdateDelivery(tx *gorm.DB, delivery *entity.WebhookDelivery) error {
+    return tx.Omit("Webhook").Save(delivery).Error
+}
+
+func (r *WebhookRepositoryImpl) DeleteAllByUserID(tx *gorm.DB, userID int) error {
+    return tx.Where("user_id = ?", userID).Delete(&entity.Webhook{}).Error
+}
\ No newline at end of file
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type WebhookRepositoryMock struct {
+    mock.Mock
+}
+
+func (m *WebhookRepositoryMock) FindByIDAndUserID(tx *gorm.DB, webhook *entity.Webhook, id, userID int) error {
+    args := m.Called(tx, webhook, id, userID)
+    return args.Error(0)
+}
+
+func (m *WebhookRepositoryMock) FindAllByUserID(tx *gorm.DB, webhooks *[]entity.Webhook, userID int) error {
+    args := m.Called(tx, webhooks, userID)
+    return args.Error(0)
+}
+
+func (m *WebhookRepositoryMock) FindAllSubscribed(tx *gorm.DB, webhooks *[]entity.Webhook, userID int, event string) error {
+    args := m.Called(tx, webhooks, userID, event)
+    return args.Error(0)
+}
+
+func (m *WebhookRepositoryMock) Create(tx *gorm.DB, webhook *entity.Webhook) error {
+    args := m.Called(tx, webhook)
+    return args.Error(0)
+}
+
+func (m *WebhookRepositoryMock) Update(tx *gorm.DB, webhook *entity.Webhook) error {
+    args := m.Called(tx, webhook)
+    return args.Error(0)
+}
+
+func (m *WebhookRepositoryMock) Delete(tx *gorm.DB, webhook *entity.Webhook) error {
+    args := m.Called(tx, webhook)
+    return args.Error(0)
+}
+
+func (m *WebhookRepositoryMock) FindDeliveryByIDAndWebhookID(tx *gorm.DB, delivery *entity.WebhookDelivery, id, webhookID int) error {
+    args := m.Called(tx, delivery, id, webhookID)
+    return args.Error(0)
+}
+
+func (m *WebhookRepositoryMock) FindAllDeliveriesByWebhookID(tx *gorm.DB, deliveries *[]entity.WebhookDelivery, webhookID int) error {
+    args := m.Called(tx, deliveries, webhookID)
+    return args.Error(0)
+}
+
+func (m *WebhookRepositoryMock) FindAllDueDeliveries(tx *gorm.DB, deliveries *[]entity.WebhookDelivery, now time.Time, limit int) error {
+    args := m.Called(tx, deliveries, now, limit)
+    return args.Error(0)
+}
+
+func (m *WebhookRepositoryMock) CreateDelivery(tx *gorm.DB, delivery *entity.WebhookDelivery) error {
+    args := m.Called(tx, delivery)
+    return args.Error(0)
+}
+
+func (m *WebhookRepositoryMock) UpdateDelivery(tx *gorm.DB, delivery *entity.WebhookDelivery) error {
+    args := m.Called(tx, delivery)
+    return args.Error(0)
+}
+
+func (m *WebhookRepositoryMock) DeleteAllByUserID(tx *gorm.DB, userID int) error {
+    args := m.Called(tx, userID)
+    return args.Error(0)
+}
\ No newline at end of file
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"auth-service/app/model/entity"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookRepositoryImpl_FindByIDAndUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewWebhookRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "webhooks" WHERE id = $1 AND user_id = $2 ORDER BY "webhooks"."id" LIMIT $3`)).
+        WithArgs(1, 1, 1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "url", "secret", "events", "enabled"}).
+            AddRow(1, 1, "https://example.com/hooks", "secret", `["message.completed"]`, true))
+
+    webhook := &entity.Webhook{}
+    err := repo.FindByIDAndUserID(db, webhook, 1, 1)
+
+    assert.NoError(t, err)
+    assert.Equal(t, "https://example.com/hooks", webhook.URL)
+    assert.Equal(t, []string{"message.completed"}, webhook.Events)
+}
+
+func TestWebhookRepositoryImpl_FindAllSubscribed(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewWebhookRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "webhooks" WHERE user_id = $1 AND enabled = $2 AND events @> $3`)).
+        WithArgs(1, true, `["chat.created"]`).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "url", "events"}).
+            AddRow(1, 1, "https://example.com/hooks", `["chat.created","chat.deleted"]`))
+
+    var webhooks []entity.Webhook
+    err := repo.FindAllSubscribed(db, &webhooks, 1, "chat.created")
+
+    assert.NoError(t, err)
+    assert.Len(t, webhooks, 1)
+}
+
+func TestWebhookRepositoryImpl_FindAllDueDeliveries(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewWebhookRepositoryImpl()
+    now := time.Now()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "webhook_deliveries" WHERE status = $1 AND next_attempt_at <= $2 ORDER BY next_attempt_at LIMIT $3 FOR UPDATE SKIP LOCKED`)).
+        WithArgs("pending", now, 20).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "webhook_id", "event", "payload", "status", "attempts"}).
+            AddRow(1, 1, "chat.created", `{}`, "pending", 0))
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "webhooks" WHERE "webhooks"."id" = $1`)).
+        WithArgs(1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "url", "secret"}).
+            AddRow(1, 1, "https://example.com/hooks", "secret"))
+
+    var deliveries []entity.WebhookDelivery
+    err := repo.FindAllDueDeliveries(db, &deliveries, now, 20)
+
+    assert.NoError(t, err)
+    assert.Len(t, deliveries, 1)
+    assert.Equal(t, "secret", deliveries[0].Webhook.Secret)
+}
+
+func TestWebhookRepositoryImpl_FindAllDeliveriesByWebhookID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewWebhookRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "webhook_deliveries" WHERE webhook_id = $1 ORDER BY id DESC LIMIT $2`)).
+        WithArgs(1, 50).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "webhook_id", "event", "status"}).
+            AddRow(2, 1, "message.completed", "failed").
+            AddRow(1, 1, "chat.created", "succeeded"))
+
+    var deliveries []entity.WebhookDelivery
+    err := repo.FindAllDeliveriesByWebhookID(db, &deliveries, 1)
+
+    assert.NoError(t, err)
+    assert.Len(t, deliveries, 2)
+}
+
+func TestWebhookRepositoryImpl_DeleteAllByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewWebhookRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "webhooks" WHERE user_id = $1`)).
+        WithArgs(2).
+        WillReturnResult(sqlmock.NewResult(0, 3))
+
+    err := repo.DeleteAllByUserID(db, 2)
+
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
\ No newline at end of file
@@ -0,0 +1,539 @@
+package usecase
+
+import (
+	"auth-service/app/delivery/client"
+	"auth-service/app/model/dto"
+	"auth-service/app/model/entity"
+	"auth-service/app/model/proto"
+	"auth-service/app/repository"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+type AccountUseCase interface {
+	ExportUserData(edr *proto.ExportUserDataRequest, stream proto.ChatService_ExportUserDataServer) error
+	PurgeUserData(ctx context.Context, pdr *proto.PurgeUserDataRequest) (*proto.Empty, error)
+}
+
+type AccountUseCaseImpl struct {
+	DB                        	*gorm.DB
+	ChatRepository            	repository.ChatRepository
+	MessageRepository         	repository.MessageRepository
+	ChatMemberRepository      	repository.ChatMemberRepository
+	ProjectRepository         	repository.ProjectRepository
+	AssistantRepository       	repository.AssistantRepository
+	ScheduledPromptRepository 	repository.ScheduledPromptRepository
+	WebhookRepository         	repository.WebhookRepository
+	PromptTemplateRepository  	repository.PromptTemplateRepository
+	UserClient                	*client.UserClient
+	Log                       	*logrus.Logger
+	Validate                  	*validator.Validate
+}
+
+func NewAccountUseCaseImpl(
+	db *gorm.DB,
+	chatRepository repository.ChatRepository,
+	messageRepository repository.MessageRepository,
+	chatMemberRepository repository.ChatMemberRepository,
+	projectRepository repository.ProjectRepository,
+	assistantRepository repository.AssistantRepository,
+	scheduledPromptRepository repository.ScheduledPromptRepository,
+	webhookRepository repository.WebhookRepository,
+	promptTemplateRepository repository.PromptTemplateRepository,
+	userClient *client.UserClient,
+	log *logrus.Logger,
+	validate *validator.Validate,
+) *AccountUseCaseImpl {
+	return &AccountUseCaseImpl{
+		DB:                        db,
+		ChatRepository:            chatRepository,
+		MessageRepository:         messageRepository,
+		ChatMemberRepository:      chatMemberRepository,
+		ProjectRepository:         projectRepository,
+		AssistantRepository:       assistantRepository,
+		ScheduledPromptRepository: scheduledPromptRepository,
+		WebhookRepository:         webhookRepository,
+		PromptTemplateRepository:  promptTemplateRepository,
+		UserClient:                userClient,
+		Log:                       log,
+		Validate:                  validate,
+	}
+}
+
+// Export documents, they are written for the user so they carry readable times and no secrets
+type exportMessage struct {
+	ID           uint      `json:"id"`
+	UserID       uint      `json:"user_id"`
+	Role         string    `json:"role"`
+	Content      string    `json:"content"`
+	Model        *string   `json:"model,omitempty"`
+	CompareGroup *string   `json:"compare_group,omitempty"`
+	Active       bool      `json:"active"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type exportChat struct {
+	ID             uint            `json:"id"`
+	Topic          *string         `json:"topic"`
+	AssistantID    *uint           `json:"assistant_id,omitempty"`
+	ProjectID      *uint           `json:"project_id,omitempty"`
+	OrganizationID *uint           `json:"organization_id,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+	Messages       []exportMessage `json:"messages"`
+}
+
+type exportSharedChat struct {
+	ChatID    uint            `json:"chat_id"`
+	Topic     *string         `json:"topic"`
+	Role      string          `json:"role"`
+	JoinedAt  time.Time       `json:"joined_at"`
+	Messages  []exportMessage `json:"messages"`
+}
+
+type exportDocument struct {
+	Name      string    `json:"name"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type exportProject struct {
+	ID           uint             `json:"id"`
+	Name         string           `json:"name"`
+	Instructions *string          `json:"instructions"`
+	CreatedAt    time.Time        `json:"created_at"`
+	Files        []exportDocument `json:"files"`
+}
+
+type exportAssistant struct {
+	ID              uint             `json:"id"`
+	Name            string           `json:"name"`
+	Description     *string          `json:"description"`
+	Instructions    string           `json:"instructions"`
+	Model           string           `json:"model"`
+	Temperature     *float32         `json:"temperature"`
+	TopP            *float32         `json:"top_p"`
+	MaxOutputTokens *int32           `json:"max_output_tokens"`
+	Tools           []string         `json:"tools"`
+	Shared          bool             `json:"shared"`
+	CreatedAt       time.Time        `json:"created_at"`
+	Documents       []exportDocument `json:"documents"`
+}
+
+type exportScheduledPromptRun struct {
+	Status       string     `json:"status"`
+	ChatID       *uint      `json:"chat_id,omitempty"`
+	ScheduledFor time.Time  `json:"scheduled_for"`
+	FinishedAt   *time.Time `json:"finished_at,omitempty"`
+	Error        *string    `json:"error,omitempty"`
+}
+
+type exportScheduledPrompt struct {
+	ID             uint                       `json:"id"`
+	Name           string                     `json:"name"`
+	Prompt         string                     `json:"prompt"`
+	CronExpression string                     `json:"cron_expression"`
+	Timezone       string                     `json:"timezone"`
+	ChatID         *uint                      `json:"chat_id,omitempty"`
+	Enabled        bool                       `json:"enabled"`
+	CreatedAt      time.Time                  `json:"created_at"`
+	Runs           []exportScheduledPromptRun `json:"runs"`
+}
+
+type exportWebhook struct {
+	ID        uint      `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Enabled   bool      `json:"enabled"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type exportPromptTemplate struct {
+	ID             uint      `json:"id"`
+	OrganizationID *uint     `json:"organization_id,omitempty"`
+	Name           string    `json:"name"`
+	Content        string    `json:"content"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// ExportUserData streams one JSON document per chat and one per other kind of data the user owns
+func (c *AccountUseCaseImpl) ExportUserData(edr *proto.ExportUserDataRequest, stream proto.ChatService_ExportUserDataServer) error {
+	// create request
+	req := &dto.ExportUserDataRequest{
+		UserID: uint(edr.GetUserId()),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, edr); err != nil {
+		return err
+	}
+
+	db := c.DB.WithContext(stream.Context())
+	userID := int(req.UserID)
+
+	send := func(path string, document any) error {
+		content, err := json.MarshalIndent(document, "", "  ")
+		if err != nil {
+			c.Log.Errorf("Failed to encode %s: %v", path, err)
+			return status.Error(codes.Internal, "An error occurred while exporting user data")
+		}
+
+		return stream.Send(&proto.ExportFile{Path: path, Content: content})
+	}
+
+	var chats []entity.Chat
+	if err := c.ChatRepository.FindAllByUserID(db, &chats, userID); err != nil {
+		c.Log.Errorf("Failed to get chats: %v", err)
+		return status.Error(codes.Internal, "An error occurred while exporting user data")
+	}
+	for _, chat := range chats {
+		var messages []entity.Message
+		if err := c.MessageRepository.FindAllByChatID(db, &messages, int(chat.ID)); err != nil {
+			c.Log.Errorf("Failed to get messages: %v", err)
+			return status.Error(codes.Internal, "An error occurred while exporting user data")
+		}
+
+		document := exportChat{
+			ID:             chat.ID,
+			Topic:          chat.Topic,
+			AssistantID:    chat.AssistantID,
+			ProjectID:      chat.ProjectID,
+			OrganizationID: chat.OrganizationID,
+			CreatedAt:      chat.CreatedAt,
+			UpdatedAt:      chat.UpdatedAt,
+			Messages:       toExportMessages(messages, 0),
+		}
+		if err := send(fmt.Sprintf("chats/%d.json", chat.ID), document); err != nil {
+			return err
+		}
+	}
+
+	// Chats shared with the user belong to their owners, only the messages of the user are exported
+	var members []entity.ChatMember
+	if err := c.ChatMemberRepository.FindAllByUserID(db, &members, userID); err != nil {
+		c.Log.Errorf("Failed to get chat memberships: %v", err)
+		return status.Error(codes.Internal, "An error occurred while exporting user data")
+	}
+	sharedChats := make([]exportSharedChat, 0, len(members))
+	for _, member := range members {
+		var messages []entity.Message
+		if err := c.MessageRepository.FindAllByChatID(db, &messages, int(member.ChatID)); err != nil {
+			c.Log.Errorf("Failed to get messages: %v", err)
+			return status.Error(codes.Internal, "An error occurred while exporting user data")
+		}
+
+		sharedChats = append(sharedChats, exportSharedChat{
+			ChatID:   member.ChatID,
+			Topic:    member.Chat.Topic,
+			Role:     member.Role,
+			JoinedAt: member.CreatedAt,
+			Messages: toExportMessages(messages, member.UserID),
+		})
+	}
+	if err := send("shared_chats.json", sharedChats); err != nil {
+		return err
+	}
+
+	var projects []entity.Project
+	if err := c.ProjectRepository.FindAllByUserID(db, &projects, userID); err != nil {
+		c.Log.Errorf("Failed to get projects: %v", err)
+		return status.Error(codes.Internal, "An error occurred while exporting user data")
+	}
+	exportProjects := make([]exportProject, 0, len(projects))
+	for _, listed := range projects {
+		project := new(entity.Project)
+		if err := c.ProjectRepository.FindByIDAndUserID(db, project, int(listed.ID), userID); err != nil {
+			c.Log.Errorf("Failed to get project: %v", err)
+			return status.Error(codes.Internal, "An error occurred while exporting user data")
+		}
+
+		files := make([]exportDocument, 0, len(project.Files))
+		for _, file := range project.Files {
+			files = append(files, exportDocument{Name: file.Name, Content: file.Content, CreatedAt: file.CreatedAt})
+		}
+		exportProjects = append(exportProjects, exportProject{
+			ID:           project.ID,
+			Name:         project.Name,
+			Instructions: project.Instructions,
+			CreatedAt:    project.CreatedAt,
+			Files:        files,
+		})
+	}
+	if err := send("projects.json", exportProjects); err != nil {
+		return err
+	}
+
+	var assistants []entity.Assistant
+	if err := c.AssistantRepository.FindAllByUserID(db, &assistants, userID); err != nil {
+		c.Log.Errorf("Failed to get assistants: %v", err)
+		return status.Error(codes.Internal, "An error occurred while exporting user data")
+	}
+	exportAssistants := make([]exportAssistant, 0, len(assistants))
+	for _, listed := range assistants {
+		assistant := new(entity.Assistant)
+		if err := c.AssistantRepository.FindByIDAndUserID(db, assistant, int(listed.ID), userID); err != nil {
+			c.Log.Errorf("Failed to get assistant: %v", err)
+			return status.Error(codes.Internal, "An error occurred while exporting user data")
+		}
+
+		documents := make([]exportDocument, 0, len(assistant.Documents))
+		for _, document := range assistant.Documents {
+			documents = append(documents, exportDocument{Name: document.Name, Content: document.Content, CreatedAt: document.CreatedAt})
+		}
+		exportAssistants = append(exportAssistants, exportAssistant{
+			ID:              assistant.ID,
+			Name:            assistant.Name,
+			Description:     assistant.Description,
+			Instructions:    assistant.Instructions,
+			Model:           assistant.Model,
+			Temperature:     assistant.Temperature,
+			TopP:            assistant.TopP,
+			MaxOutputTokens: assistant.MaxOutputTokens,
+			Tools:           assistant.Tools,
+			Shared:          assistant.ShareToken != nil,
+			CreatedAt:       assistant.CreatedAt,
+			Documents:       documents,
+		})
+	}
+	if err := send("assistants.json", exportAssistants); err != nil {
+		return err
+	}
+
+	var scheduledPrompts []entity.ScheduledPrompt
+	if err := c.ScheduledPromptRepository.FindAllByUserID(db, &scheduledPrompts, userID); err != nil {
+		c.Log.Errorf("Failed to get scheduled prompts: %v", err)
+		return status.Error(codes.Internal, "An error occurred while exporting user data")
+	}
+	exportScheduledPrompts := make([]exportScheduledPrompt, 0, len(scheduledPrompts))
+	for _, scheduledPrompt := range scheduledPrompts {
+		var runs []entity.ScheduledPromptRun
+		if err := c.ScheduledPromptRepository.FindAllRunsByScheduledPromptID(db, &runs, int(scheduledPrompt.ID)); err != nil {
+			c.Log.Errorf("Failed to get scheduled prompt runs: %v", err)
+			return status.Error(codes.Internal, "An error occurred while exporting user data")
+		}
+
+		exportRuns := make([]exportScheduledPromptRun, 0, len(runs))
+		for _, run := range runs {
+			exportRuns = append(exportRuns, exportScheduledPromptRun{
+				Status:       run.Status,
+				ChatID:       run.ChatID,
+				ScheduledFor: run.ScheduledFor,
+				FinishedAt:   run.FinishedAt,
+				Error:        run.Error,
+			})
+		}
+		exportScheduledPrompts = append(exportScheduledPrompts, exportScheduledPrompt{
+			ID:             scheduledPrompt.ID,
+			Name:           scheduledPrompt.Name,
+			Prompt:         scheduledPrompt.Prompt,
+			CronExpression: scheduledPrompt.CronExpression,
+			Timezone:       scheduledPrompt.Timezone,
+			ChatID:         scheduledPrompt.ChatID,
+			Enabled:        scheduledPrompt.Enabled,
+			CreatedAt:      scheduledPrompt.CreatedAt,
+			Runs:           exportRuns,
+		})
+	}
+	if err := send("scheduled_prompts.json", exportScheduledPrompts); err != nil {
+		return err
+	}
+
+	var webhooks []entity.Webhook
+	if err := c.WebhookRepository.FindAllByUserID(db, &webhooks, userID); err != nil {
+		c.Log.Errorf("Failed to get webhooks: %v", err)
+		return status.Error(codes.Internal, "An error occurred while exporting user data")
+	}
+	exportWebhooks := make([]exportWebhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		exportWebhooks = append(exportWebhooks, exportWebhook{
+			ID:        webhook.ID,
+			URL:       webhook.URL,
+			Events:    webhook.Events,
+			Enabled:   webhook.Enabled,
+			CreatedAt: webhook.CreatedAt,
+		})
+	}
+
+	if err := send("webhooks.json", exportWebhooks); err != nil {
+		return err
+	}
+
+	var promptTemplates []entity.PromptTemplate
+	if err := c.PromptTemplateRepository.FindAllByUserID(db, &promptTemplates, userID); err != nil {
+		c.Log.Errorf("Failed to get prompt templates: %v", err)
+		return status.Error(codes.Internal, "An error occurred while exporting user data")
+	}
+	exportPromptTemplates := make([]exportPromptTemplate, 0, len(promptTemplates))
+	for _, promptTemplate := range promptTemplates {
+		exportPromptTemplates = append(exportPromptTemplates, exportPromptTemplate{
+			ID:             promptTemplate.ID,
+			OrganizationID: promptTemplate.OrganizationID,
+			Name:           promptTemplate.Name,
+			Content:        promptTemplate.Content,
+			CreatedAt:      promptTemplate.CreatedAt,
+		})
+	}
+
+	return send("prompt_templates.json", exportPromptTemplates)
+}
+
+// PurgeUserData deletes the data of the user in one transaction, shared chats of the user go for every member.
+// Chats, assistants and prompt templates of an organization stay with it, handed over to another member.
+func (c *AccountUseCaseImpl) PurgeUserData(ctx context.Context, pdr *proto.PurgeUserDataRequest) (*proto.Empty, error) {
+	// create request
+	req := &dto.PurgeUserDataRequest{
+		UserID: uint(pdr.GetUserId()),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, pdr); err != nil {
+		return nil, err
+	}
+
+	successors, err := c.organizationSuccessors(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	userID := int(req.UserID)
+
+	// The data of an organization left without members goes with the user
+	transfers := []struct {
+		name     string
+		transfer func(tx *gorm.DB, userID, organizationID, toUserID int) error
+	}{
+		{"chats", c.ChatRepository.TransferAllByUserIDAndOrganizationID},
+		{"messages", c.MessageRepository.TransferAllByUserIDAndOrganizationID},
+		{"assistants", c.AssistantRepository.TransferAllByUserIDAndOrganizationID},
+		{"prompt templates", c.PromptTemplateRepository.TransferAllByUserIDAndOrganizationID},
+	}
+	for organizationID, successorID := range successors {
+		for _, t := range transfers {
+			if err := t.transfer(tx, userID, int(organizationID), int(successorID)); err != nil {
+				c.Log.Errorf("Failed to transfer %s: %v", t.name, err)
+				return nil, status.Error(codes.Internal, "An error occurred while purging user data")
+			}
+		}
+	}
+
+	// Runs and deliveries go with their prompts and webhooks, members and messages with their chats
+	purges := []struct {
+		name  string
+		purge func(tx *gorm.DB, userID int) error
+	}{
+		{"chat memberships", c.ChatMemberRepository.DeleteAllByUserID},
+		{"messages", c.MessageRepository.DeleteAllByUserID},
+		{"scheduled prompts", c.ScheduledPromptRepository.DeleteAllByUserID},
+		{"chats", c.ChatRepository.DeleteAllByUserID},
+		{"webhooks", c.WebhookRepository.DeleteAllByUserID},
+		{"prompt templates", c.PromptTemplateRepository.DeleteAllByUserID},
+		{"projects", c.ProjectRepository.DeleteAllByUserID},
+		{"assistants", c.AssistantRepository.DeleteAllByUserID},
+	}
+	for _, p := range purges {
+		if err := p.purge(tx, userID); err != nil {
+			c.Log.Errorf("Failed to purge %s: %v", p.name, err)
+			return nil, status.Error(codes.Internal, "An error occurred while purging user data")
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Errorf("Failed to commit transaction: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while purging user data")
+	}
+
+	return &proto.Empty{}, nil
+}
+
+// organizationSuccessors picks, in every organization of the user, the member
+// taking over the data of the user: the first owner, else the first admin,
+// else the first member. Organizations without other members are left out.
+func (c *AccountUseCaseImpl) organizationSuccessors(ctx context.Context, userID uint) (map[uint]uint, error) {
+	listCtx, cancelList := context.WithTimeout(ctx, 5 * time.Second)
+	defer cancelList()
+
+	organizations, err := c.UserClient.Service.ListOrganizations(listCtx, &proto.ListUserOrganizationsRequest{
+		ActorId: int64(userID),
+	})
+	if err != nil {
+		c.Log.Errorf("Failed to list organizations: %v", err)
+		return nil, status.Error(codes.Unavailable, "An error occurred while listing organizations")
+	}
+
+	successors := make(map[uint]uint)
+	for _, organization := range organizations.GetOrganizations() {
+		members, err := c.UserClient.Service.ListOrganizationMembers(listCtx, &proto.ListUserOrganizationMembersRequest{
+			ActorId:        int64(userID),
+			OrganizationId: organization.GetId(),
+		})
+		if err != nil {
+			c.Log.Errorf("Failed to list organization members: %v", err)
+			return nil, status.Error(codes.Unavailable, "An error occurred while listing organization members")
+		}
+
+		if successorID := organizationSuccessor(members.GetMembers(), userID); successorID != 0 {
+			successors[uint(organization.GetId())] = successorID
+		}
+	}
+
+	return successors, nil
+}
+
+// organizationSuccessor returns the first member with the highest role, 0 when the user is alone.
+func organizationSuccessor(members []*proto.UserOrganizationMember, userID uint) uint {
+	rank := func(member *proto.UserOrganizationMember) int {
+		switch member.GetRole() {
+		case "owner":
+			return 0
+		case "admin":
+			return 1
+		}
+		return 2
+	}
+
+	var successor *proto.UserOrganizationMember
+	for _, member := range members {
+		if uint(member.GetUserId()) == userID {
+			continue
+		}
+		if successor == nil || rank(member) < rank(successor) {
+			successor = member
+		}
+	}
+
+	return uint(successor.GetUserId())
+}
+
+// toExportMessages converts the messages of a chat, a userID other than 0 keeps only the messages of that user
+func toExportMessages(messages []entity.Message, userID uint) []exportMessage {
+	res := make([]exportMessage, 0, len(messages))
+	for _, message := range messages {
+		if userID != 0 && message.UserID != userID {
+			continue
+		}
+
+		res = append(res, exportMessage{
+			ID:           message.ID,
+			UserID:       message.UserID,
+			Role:         message.Role,
+			Content:      message.Content,
+			Model:        message.Model,
+			CompareGroup: message.CompareGroup,
+			Active:       message.Active,
+			CreatedAt:    message.CreatedAt,
+		})
+	}
+
+	return res
+}
\ No newline at end of file
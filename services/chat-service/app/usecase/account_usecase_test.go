@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"auth-service/app/model/proto"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrganizationSuccessor(t *testing.T) {
+    members := []*proto.UserOrganizationMember{
+        {UserId: 2, Role: "owner"},
+        {UserId: 3, Role: "member"},
+        {UserId: 4, Role: "admin"},
+        {UserId: 5, Role: "owner"},
+    }
+
+    // The first other owner takes over from the owner leaving
+    assert.Equal(t, uint(5), organizationSuccessor(members, 2))
+    assert.Equal(t, uint(2), organizationSuccessor(members, 3))
+
+    // Without another owner or admin any member takes over
+    assert.Equal(t, uint(3), organizationSuccessor(members[:2], 2))
+
+    // A user alone in the organization has no successor
+    assert.Equal(t, uint(0), organizationSuccessor(members[:1], 2))
+}
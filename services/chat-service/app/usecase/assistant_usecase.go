@@ -0,0 +1,425 @@
+package usecase
+
+import (
+	"auth-service/app/delivery/client"
+	"auth-service/app/helper"
+	"auth-service/app/model/dto"
+	"auth-service/app/model/entity"
+	"auth-service/app/model/proto"
+	"auth-service/app/repository"
+	"context"
+	"slices"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+const DefaultModel = "gemini-1.5-flash"
+
+type AssistantUseCase interface {
+	CreateAssistant(ctx context.Context, car *proto.CreateAssistantRequest) (*proto.Assistant, error)
+	GetAssistants(ctx context.Context, gar *proto.GetAssistantsRequest) (*proto.GetAssistantsResponse, error)
+	GetAssistant(ctx context.Context, gar *proto.GetAssistantRequest) (*proto.Assistant, error)
+	UpdateAssistant(ctx context.Context, uar *proto.UpdateAssistantRequest) (*proto.Assistant, error)
+	DeleteAssistant(ctx context.Context, dar *proto.DeleteAssistantRequest) (*proto.Empty, error)
+	ShareAssistant(ctx context.Context, sar *proto.ShareAssistantRequest) (*proto.Assistant, error)
+	GetSharedAssistant(ctx context.Context, gsr *proto.GetSharedAssistantRequest) (*proto.Assistant, error)
+}
+
+type AssistantUseCaseImpl struct {
+	DB                  *gorm.DB
+	AssistantRepository repository.AssistantRepository
+	UserClient          *client.UserClient
+	Log                 *logrus.Logger
+	Validate            *validator.Validate
+}
+
+func NewAssistantUseCaseImpl(
+	db *gorm.DB,
+	assistantRepository repository.AssistantRepository,
+	userClient *client.UserClient,
+	log *logrus.Logger,
+	validate *validator.Validate,
+) *AssistantUseCaseImpl {
+	return &AssistantUseCaseImpl{
+		DB:                  db,
+		AssistantRepository: assistantRepository,
+		UserClient:          userClient,
+		Log:                 log,
+		Validate:            validate,
+	}
+}
+
+func (c *AssistantUseCaseImpl) CreateAssistant(ctx context.Context, car *proto.CreateAssistantRequest) (*proto.Assistant, error) {
+	// create request
+	req := &dto.CreateAssistantRequest{
+		UserID:          uint(car.GetUserId()),
+		Name:            car.GetName(),
+		Description:     car.GetDescription(),
+		Instructions:    car.GetInstructions(),
+		Model:           car.GetModel(),
+		Temperature:     car.Temperature,
+		TopP:            car.TopP,
+		MaxOutputTokens: car.MaxOutputTokens,
+		Tools:           car.GetTools(),
+		Documents:       toAssistantDocumentDtos(car.GetDocuments()),
+		OrganizationID:  uint(car.GetOrganizationId()),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, car); err != nil {
+		return nil, err
+	}
+
+	organization, err := organizationMembership(ctx, c.UserClient, c.Log, req.UserID, req.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if allowedModels := organization.GetAllowedModels(); len(allowedModels) > 0 && !slices.Contains(allowedModels, modelOrDefault(req.Model)) {
+		return nil, status.Errorf(codes.PermissionDenied, "Model %s is not allowed in the organization", modelOrDefault(req.Model))
+	}
+
+	assistant := &entity.Assistant{
+		UserID:          req.UserID,
+		Name:            req.Name,
+		Description:     nullableString(req.Description),
+		Instructions:    req.Instructions,
+		Model:           modelOrDefault(req.Model),
+		Temperature:     req.Temperature,
+		TopP:            req.TopP,
+		MaxOutputTokens: req.MaxOutputTokens,
+		Tools:           toolsOrEmpty(req.Tools),
+		Documents:       toAssistantDocumentEntities(req.Documents),
+	}
+	if organization != nil {
+		organizationID := uint(organization.GetId())
+		assistant.OrganizationID = &organizationID
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	// create assistant
+	if err := c.AssistantRepository.Create(db, assistant); err != nil {
+		c.Log.Errorf("Failed to create assistant: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while creating assistant")
+	}
+
+	return toAssistantProto(assistant, true), nil
+}
+
+func (c *AssistantUseCaseImpl) GetAssistants(ctx context.Context, gar *proto.GetAssistantsRequest) (*proto.GetAssistantsResponse, error) {
+	// create request
+	req := &dto.GetAssistantsRequest{
+		UserID:         uint(gar.GetUserId()),
+		OrganizationID: uint(gar.GetOrganizationId()),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, gar); err != nil {
+		return nil, err
+	}
+
+	organization, err := organizationMembership(ctx, c.UserClient, c.Log, req.UserID, req.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	// get assistants, every member sees the assistants of the organization
+	assistants := new([]entity.Assistant)
+	if organization != nil {
+		err = c.AssistantRepository.FindAllByOrganizationID(db, assistants, int(organization.GetId()))
+	} else {
+		err = c.AssistantRepository.FindAllPersonalByUserID(db, assistants, int(req.UserID))
+	}
+	if err != nil {
+		c.Log.Errorf("Failed to get assistants: %v", err)
+		return nil, status.Error(codes.NotFound, "Assistants not found")
+	}
+
+	// create response
+	res := &proto.GetAssistantsResponse{
+		Assistants: make([]*proto.Assistant, 0),
+	}
+
+	// Only the author of an organization assistant sees its instructions and knowledge
+	for i := range *assistants {
+		assistant := &(*assistants)[i]
+		res.Assistants = append(res.Assistants, toAssistantProto(assistant, assistant.UserID == req.UserID))
+	}
+
+	return res, nil
+}
+
+func (c *AssistantUseCaseImpl) GetAssistant(ctx context.Context, gar *proto.GetAssistantRequest) (*proto.Assistant, error) {
+	// create request
+	req := &dto.GetAssistantRequest{
+		ID:     uint(gar.GetId()),
+		UserID: uint(gar.GetUserId()),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, gar); err != nil {
+		return nil, err
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	// get assistant
+	assistant := new(entity.Assistant)
+	if err := c.AssistantRepository.FindByIDAndUserID(db, assistant, int(req.ID), int(req.UserID)); err != nil {
+		c.Log.Errorf("Failed to get assistant: %v", err)
+		return nil, status.Error(codes.NotFound, "Assistant not found")
+	}
+
+	return toAssistantProto(assistant, true), nil
+}
+
+func (c *AssistantUseCaseImpl) UpdateAssistant(ctx context.Context, uar *proto.UpdateAssistantRequest) (*proto.Assistant, error) {
+	// create request
+	req := &dto.UpdateAssistantRequest{
+		ID:              uint(uar.GetId()),
+		UserID:          uint(uar.GetUserId()),
+		Name:            uar.GetName(),
+		Description:     uar.GetDescription(),
+		Instructions:    uar.GetInstructions(),
+		Model:           uar.GetModel(),
+		Temperature:     uar.Temperature,
+		TopP:            uar.TopP,
+		MaxOutputTokens: uar.MaxOutputTokens,
+		Tools:           uar.GetTools(),
+		Documents:       toAssistantDocumentDtos(uar.GetDocuments()),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, uar); err != nil {
+		return nil, err
+	}
+
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// get assistant
+	assistant := new(entity.Assistant)
+	if err := c.AssistantRepository.FindByIDAndUserID(tx, assistant, int(req.ID), int(req.UserID)); err != nil {
+		c.Log.Errorf("Failed to get assistant: %v", err)
+		return nil, status.Error(codes.NotFound, "Assistant not found")
+	}
+
+	// replace knowledge documents
+	if err := c.AssistantRepository.DeleteDocuments(tx, int(assistant.ID)); err != nil {
+		c.Log.Errorf("Failed to delete assistant documents: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while updating assistant")
+	}
+
+	assistant.Name = req.Name
+	assistant.Description = nullableString(req.Description)
+	assistant.Instructions = req.Instructions
+	assistant.Model = modelOrDefault(req.Model)
+	assistant.Temperature = req.Temperature
+	assistant.TopP = req.TopP
+	assistant.MaxOutputTokens = req.MaxOutputTokens
+	assistant.Tools = toolsOrEmpty(req.Tools)
+	assistant.Documents = toAssistantDocumentEntities(req.Documents)
+
+	// update assistant
+	if err := c.AssistantRepository.Update(tx, assistant); err != nil {
+		c.Log.Errorf("Failed to update assistant: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while updating assistant")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, status.Error(codes.Internal, "An error occurred while committing transaction")
+	}
+
+	return toAssistantProto(assistant, true), nil
+}
+
+func (c *AssistantUseCaseImpl) DeleteAssistant(ctx context.Context, dar *proto.DeleteAssistantRequest) (*proto.Empty, error) {
+	// create request
+	req := &dto.GetAssistantRequest{
+		ID:     uint(dar.GetId()),
+		UserID: uint(dar.GetUserId()),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, dar); err != nil {
+		return nil, err
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	// get assistant
+	assistant := new(entity.Assistant)
+	if err := c.AssistantRepository.FindByIDAndUserID(db, assistant, int(req.ID), int(req.UserID)); err != nil {
+		c.Log.Errorf("Failed to get assistant: %v", err)
+		return nil, status.Error(codes.NotFound, "Assistant not found")
+	}
+
+	// delete assistant, documents cascade and bound chats fall back to the default model
+	if err := c.AssistantRepository.Delete(db, assistant); err != nil {
+		c.Log.Errorf("Failed to delete assistant: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while deleting assistant")
+	}
+
+	return &proto.Empty{}, nil
+}
+
+func (c *AssistantUseCaseImpl) ShareAssistant(ctx context.Context, sar *proto.ShareAssistantRequest) (*proto.Assistant, error) {
+	// create request
+	req := &dto.ShareAssistantRequest{
+		ID:      uint(sar.GetId()),
+		UserID:  uint(sar.GetUserId()),
+		Enabled: sar.GetEnabled(),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, sar); err != nil {
+		return nil, err
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	// get assistant
+	assistant := new(entity.Assistant)
+	if err := c.AssistantRepository.FindByIDAndUserID(db, assistant, int(req.ID), int(req.UserID)); err != nil {
+		c.Log.Errorf("Failed to get assistant: %v", err)
+		return nil, status.Error(codes.NotFound, "Assistant not found")
+	}
+
+	// Enabling keeps an existing link stable, disabling revokes it
+	if req.Enabled && assistant.ShareToken == nil {
+		shareToken, err := helper.RandomToken(16)
+		if err != nil {
+			c.Log.Errorf("Failed to generate share token: %v", err)
+			return nil, status.Error(codes.Internal, "An error occurred while sharing assistant")
+		}
+		assistant.ShareToken = &shareToken
+	}
+	if !req.Enabled {
+		assistant.ShareToken = nil
+	}
+
+	// Documents are saved separately, avoid upserting them again
+	if err := c.AssistantRepository.Update(db.Omit("Documents"), assistant); err != nil {
+		c.Log.Errorf("Failed to update assistant: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while sharing assistant")
+	}
+
+	return toAssistantProto(assistant, true), nil
+}
+
+func (c *AssistantUseCaseImpl) GetSharedAssistant(ctx context.Context, gsr *proto.GetSharedAssistantRequest) (*proto.Assistant, error) {
+	// create request
+	req := &dto.GetSharedAssistantRequest{
+		ShareToken: gsr.GetShareToken(),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, gsr); err != nil {
+		return nil, err
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	// get assistant
+	assistant := new(entity.Assistant)
+	if err := c.AssistantRepository.FindByShareToken(db, assistant, req.ShareToken); err != nil {
+		c.Log.Errorf("Failed to get shared assistant: %v", err)
+		return nil, status.Error(codes.NotFound, "Assistant not found")
+	}
+
+	return toAssistantProto(assistant, false), nil
+}
+
+// toAssistantProto converts an assistant entity, owners also receive the
+// instructions, knowledge documents and share token.
+func toAssistantProto(assistant *entity.Assistant, owner bool) *proto.Assistant {
+	res := &proto.Assistant{
+		Id:              int64(assistant.ID),
+		UserId:          int64(assistant.UserID),
+		OrganizationId:  nullableID(assistant.OrganizationID),
+		Name:            assistant.Name,
+		Model:           assistant.Model,
+		Temperature:     assistant.Temperature,
+		TopP:            assistant.TopP,
+		MaxOutputTokens: assistant.MaxOutputTokens,
+		Tools:           assistant.Tools,
+		Documents:       make([]*proto.AssistantDocument, 0),
+	}
+
+	if assistant.Description != nil {
+		res.Description = *assistant.Description
+	}
+
+	if !owner {
+		return res
+	}
+
+	res.Instructions = assistant.Instructions
+	if assistant.ShareToken != nil {
+		res.ShareToken = *assistant.ShareToken
+	}
+
+	for _, document := range assistant.Documents {
+		res.Documents = append(res.Documents, &proto.AssistantDocument{
+			Id:      int64(document.ID),
+			Name:    document.Name,
+			Content: document.Content,
+		})
+	}
+
+	return res
+}
+
+func toAssistantDocumentDtos(documents []*proto.AssistantDocument) []dto.AssistantDocument {
+	res := make([]dto.AssistantDocument, 0, len(documents))
+	for _, document := range documents {
+		res = append(res, dto.AssistantDocument{
+			Name:    document.GetName(),
+			Content: document.GetContent(),
+		})
+	}
+
+	return res
+}
+
+func toAssistantDocumentEntities(documents []dto.AssistantDocument) []entity.AssistantDocument {
+	res := make([]entity.AssistantDocument, 0, len(documents))
+	for _, document := range documents {
+		res = append(res, entity.AssistantDocument{
+			Name:    document.Name,
+			Content: document.Content,
+		})
+	}
+
+	return res
+}
+
+func nullableString(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
+
+func modelOrDefault(model string) string {
+	if model == "" {
+		return DefaultModel
+	}
+
+	return model
+}
+
+func toolsOrEmpty(tools []string) []string {
+	if tools == nil {
+		return []string{}
+	}
+
+	return tools
+}
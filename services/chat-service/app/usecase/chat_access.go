@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"auth-service/app/delivery/client"
+	"auth-service/app/model/entity"
+	"auth-service/app/model/proto"
+	"auth-service/app/repository"
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+const (
+	RoleOwner  = "owner"
+	RoleEditor = "editor"
+	RoleViewer = "viewer"
+)
+
+// chatRole resolves the role of the user in the chat, either as its owner, as
+// an invited member or as a member of the organization owning the chat. The
+// membership of organizationID must have been verified, 0 is the personal
+// workspace. Chats of an organization are only reached from it, even by their
+// owner. An error means the user has no access to the chat.
+func chatRole(tx *gorm.DB, chatMemberRepository repository.ChatMemberRepository, chat *entity.Chat, userID uint, organizationID uint) (string, error) {
+	if chat.OrganizationID != nil && *chat.OrganizationID != organizationID {
+		return "", gorm.ErrRecordNotFound
+	}
+
+	if chat.UserID == userID {
+		return RoleOwner, nil
+	}
+
+	if chat.OrganizationID != nil && *chat.OrganizationID == organizationID {
+		return RoleEditor, nil
+	}
+
+	member := new(entity.ChatMember)
+	if err := chatMemberRepository.FindByChatIDAndUserID(tx, member, int(chat.ID), int(userID)); err != nil {
+		return "", err
+	}
+
+	return member.Role, nil
+}
+
+// canWrite reports whether the role may send prompts to the chat.
+func canWrite(role string) bool {
+	return role == RoleOwner || role == RoleEditor
+}
+
+// organizationMembership returns the organization the request is made in, nil
+// for the personal workspace. Tokens keep their organization until they
+// expire, so the membership is checked again on every request.
+func organizationMembership(ctx context.Context, userClient *client.UserClient, log *logrus.Logger, userID uint, organizationID uint) (*proto.UserOrganization, error) {
+	if organizationID == 0 {
+		return nil, nil
+	}
+
+	getMembershipCtx, cancelGetMembership := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetMembership()
+
+	organization, err := userClient.Service.GetOrganizationMembership(getMembershipCtx, &proto.GetUserOrganizationRequest{
+		OrganizationId: int64(organizationID),
+		UserId:         int64(userID),
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Error(codes.PermissionDenied, "You are not a member of the organization")
+		}
+
+		log.Errorf("Failed to get organization membership: %v", err)
+		return nil, status.Error(codes.Unavailable, "An error occurred while checking organization membership")
+	}
+
+	return organization, nil
+}
\ No newline at end of file
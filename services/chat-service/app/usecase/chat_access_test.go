@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"auth-service/app/model/entity"
+	"auth-service/app/repository"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChatRole_OrganizationChat(t *testing.T) {
+    chatMemberRepository := new(repository.ChatMemberRepositoryMock)
+    organizationID := uint(5)
+    chat := &entity.Chat{ID: 1, UserID: 2, OrganizationID: &organizationID}
+
+    role, err := chatRole(nil, chatMemberRepository, chat, 2, 5)
+    assert.NoError(t, err)
+    assert.Equal(t, RoleOwner, role)
+
+    role, err = chatRole(nil, chatMemberRepository, chat, 3, 5)
+    assert.NoError(t, err)
+    assert.Equal(t, RoleEditor, role)
+
+    // Outside of the organization even the owner has no access
+    for _, workspace := range []uint{0, 6} {
+        _, err = chatRole(nil, chatMemberRepository, chat, 2, workspace)
+        assert.Error(t, err)
+    }
+}
@@ -0,0 +1,251 @@
+package usecase
+
+import (
+	"auth-service/app/delivery/client"
+	"auth-service/app/model/dto"
+	"auth-service/app/model/entity"
+	"auth-service/app/model/proto"
+	"auth-service/app/repository"
+	"context"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+type ChatMemberUseCase interface {
+	InviteChatMember(ctx context.Context, icr *proto.InviteChatMemberRequest) (*proto.ChatMember, error)
+	GetChatMembers(ctx context.Context, gcr *proto.GetChatMembersRequest) (*proto.GetChatMembersResponse, error)
+	UpdateChatMember(ctx context.Context, ucr *proto.UpdateChatMemberRequest) (*proto.ChatMember, error)
+	RemoveChatMember(ctx context.Context, rcr *proto.RemoveChatMemberRequest) (*proto.Empty, error)
+}
+
+type ChatMemberUseCaseImpl struct {
+	DB                   	*gorm.DB
+	ChatRepository       	repository.ChatRepository
+	ChatMemberRepository 	repository.ChatMemberRepository
+	UserClient           	*client.UserClient
+	Log                  	*logrus.Logger
+	Validate             	*validator.Validate
+}
+
+func NewChatMemberUseCaseImpl(
+	db *gorm.DB,
+	chatRepository repository.ChatRepository,
+	chatMemberRepository repository.ChatMemberRepository,
+	userClient *client.UserClient,
+	log *logrus.Logger,
+	validate *validator.Validate,
+) *ChatMemberUseCaseImpl {
+	return &ChatMemberUseCaseImpl{
+		DB:                   db,
+		ChatRepository:       chatRepository,
+		ChatMemberRepository: chatMemberRepository,
+		UserClient:           userClient,
+		Log:                  log,
+		Validate:             validate,
+	}
+}
+
+func (c *ChatMemberUseCaseImpl) InviteChatMember(ctx context.Context, icr *proto.InviteChatMemberRequest) (*proto.ChatMember, error) {
+	// create request
+	req := &dto.InviteChatMemberRequest{
+		ChatID: uint(icr.GetChatId()),
+		UserID: uint(icr.GetUserId()),
+		Email:  icr.GetEmail(),
+		Role:   icr.GetRole(),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, icr); err != nil {
+		return nil, err
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	// only the owner manages members
+	chat := new(entity.Chat)
+	if err := c.ChatRepository.FindByIDAndUserID(db, chat, int(req.ChatID), int(req.UserID)); err != nil {
+		c.Log.Errorf("Failed to get chat: %v", err)
+		return nil, status.Error(codes.NotFound, "Chat not found")
+	}
+
+	// resolve the invited user
+	getUserCtx, cancelGetUser := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetUser()
+
+	user, err := c.UserClient.Service.GetUserByEmail(getUserCtx, &proto.GetUserByEmailRequest{
+		Email: req.Email,
+	})
+	if err != nil {
+		c.Log.Errorf("Failed to get invited user: %v", err)
+		return nil, status.Error(codes.NotFound, "User not found")
+	}
+
+	if uint(user.GetId()) == chat.UserID {
+		return nil, status.Error(codes.InvalidArgument, "The owner is already part of the chat")
+	}
+
+	// check existing membership
+	member := new(entity.ChatMember)
+	if err := c.ChatMemberRepository.FindByChatIDAndUserID(db, member, int(chat.ID), int(user.GetId())); err == nil {
+		return nil, status.Error(codes.AlreadyExists, "User is already a member of the chat")
+	}
+
+	member = &entity.ChatMember{
+		ChatID:    chat.ID,
+		UserID:    uint(user.GetId()),
+		Email:     user.GetEmail(),
+		Role:      req.Role,
+		InvitedBy: &req.UserID,
+	}
+
+	// create member
+	if err := c.ChatMemberRepository.Create(db, member); err != nil {
+		c.Log.Errorf("Failed to create chat member: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while inviting member")
+	}
+
+	return toChatMemberProto(member), nil
+}
+
+func (c *ChatMemberUseCaseImpl) GetChatMembers(ctx context.Context, gcr *proto.GetChatMembersRequest) (*proto.GetChatMembersResponse, error) {
+	// create request
+	req := &dto.GetChatMembersRequest{
+		ChatID: uint(gcr.GetChatId()),
+		UserID: uint(gcr.GetUserId()),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, gcr); err != nil {
+		return nil, err
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	// every participant may see the members
+	chat := new(entity.Chat)
+	if err := c.ChatRepository.FindByID(db, chat, int(req.ChatID)); err != nil {
+		c.Log.Errorf("Failed to get chat: %v", err)
+		return nil, status.Error(codes.NotFound, "Chat not found")
+	}
+
+	if _, err := chatRole(db, c.ChatMemberRepository, chat, req.UserID, 0); err != nil {
+		return nil, status.Error(codes.NotFound, "Chat not found")
+	}
+
+	// get members
+	members := new([]entity.ChatMember)
+	if err := c.ChatMemberRepository.FindAllByChatID(db, members, int(chat.ID)); err != nil {
+		c.Log.Errorf("Failed to get chat members: %v", err)
+		return nil, status.Error(codes.NotFound, "Members not found")
+	}
+
+	// create response
+	res := &proto.GetChatMembersResponse{
+		Members: make([]*proto.ChatMember, 0),
+	}
+
+	for i := range *members {
+		res.Members = append(res.Members, toChatMemberProto(&(*members)[i]))
+	}
+
+	return res, nil
+}
+
+func (c *ChatMemberUseCaseImpl) UpdateChatMember(ctx context.Context, ucr *proto.UpdateChatMemberRequest) (*proto.ChatMember, error) {
+	// create request
+	req := &dto.UpdateChatMemberRequest{
+		ChatID:   uint(ucr.GetChatId()),
+		UserID:   uint(ucr.GetUserId()),
+		MemberID: uint(ucr.GetMemberId()),
+		Role:     ucr.GetRole(),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, ucr); err != nil {
+		return nil, err
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	// only the owner manages members
+	chat := new(entity.Chat)
+	if err := c.ChatRepository.FindByIDAndUserID(db, chat, int(req.ChatID), int(req.UserID)); err != nil {
+		c.Log.Errorf("Failed to get chat: %v", err)
+		return nil, status.Error(codes.NotFound, "Chat not found")
+	}
+
+	// get member
+	member := new(entity.ChatMember)
+	if err := c.ChatMemberRepository.FindByChatIDAndUserID(db, member, int(chat.ID), int(req.MemberID)); err != nil {
+		c.Log.Errorf("Failed to get chat member: %v", err)
+		return nil, status.Error(codes.NotFound, "Member not found")
+	}
+
+	member.Role = req.Role
+
+	// update member
+	if err := c.ChatMemberRepository.Update(db, member); err != nil {
+		c.Log.Errorf("Failed to update chat member: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while updating member")
+	}
+
+	return toChatMemberProto(member), nil
+}
+
+func (c *ChatMemberUseCaseImpl) RemoveChatMember(ctx context.Context, rcr *proto.RemoveChatMemberRequest) (*proto.Empty, error) {
+	// create request
+	req := &dto.RemoveChatMemberRequest{
+		ChatID:   uint(rcr.GetChatId()),
+		UserID:   uint(rcr.GetUserId()),
+		MemberID: uint(rcr.GetMemberId()),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, rcr); err != nil {
+		return nil, err
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	// get chat
+	chat := new(entity.Chat)
+	if err := c.ChatRepository.FindByID(db, chat, int(req.ChatID)); err != nil {
+		c.Log.Errorf("Failed to get chat: %v", err)
+		return nil, status.Error(codes.NotFound, "Chat not found")
+	}
+
+	// the owner removes anyone, members may only leave
+	if chat.UserID != req.UserID && req.MemberID != req.UserID {
+		return nil, status.Error(codes.NotFound, "Chat not found")
+	}
+
+	// get member
+	member := new(entity.ChatMember)
+	if err := c.ChatMemberRepository.FindByChatIDAndUserID(db, member, int(chat.ID), int(req.MemberID)); err != nil {
+		c.Log.Errorf("Failed to get chat member: %v", err)
+		return nil, status.Error(codes.NotFound, "Member not found")
+	}
+
+	// delete member
+	if err := c.ChatMemberRepository.Delete(db, member); err != nil {
+		c.Log.Errorf("Failed to delete chat member: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while removing member")
+	}
+
+	return &proto.Empty{}, nil
+}
+
+func toChatMemberProto(member *entity.ChatMember) *proto.ChatMember {
+	return &proto.ChatMember{
+		Id:     int64(member.ID),
+		ChatId: int64(member.ChatID),
+		UserId: int64(member.UserID),
+		Email:  member.Email,
+		Role:   member.Role,
+	}
+}
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"auth-service/app/delivery/client"
 	"auth-service/app/helper"
 	"auth-service/app/model/dto"
 	"auth-service/app/model/entity"
@@ -18,11 +19,17 @@ import (
 type ChatUseCase interface {
 	GetChats(ctx context.Context, gcr *proto.GetChatsRequest) (*proto.GetChatsResponse, error)
 	GetChatByIdAndUserId(ctx context.Context, gcr *proto.GetChatRequest) (*proto.GetChatResponse, error)
+	MoveChat(ctx context.Context, mcr *proto.MoveChatRequest) (*proto.Chat, error)
+	DeleteChat(ctx context.Context, dcr *proto.DeleteChatRequest) (*proto.Empty, error)
 }
 
 type ChatUseCaseImpl struct {
 	DB              *gorm.DB
 	ChatRepository  repository.ChatRepository
+	ProjectRepository repository.ProjectRepository
+	ChatMemberRepository repository.ChatMemberRepository
+	WebhookRepository repository.WebhookRepository
+	UserClient      *client.UserClient
 	Log             *logrus.Logger
 	Validate        *validator.Validate
 }
@@ -30,12 +37,20 @@ type ChatUseCaseImpl struct {
 func NewChatUseCaseImpl(
 	db *gorm.DB,
 	chatRepository repository.ChatRepository,
+	projectRepository repository.ProjectRepository,
+	chatMemberRepository repository.ChatMemberRepository,
+	webhookRepository repository.WebhookRepository,
+	userClient *client.UserClient,
 	log *logrus.Logger,
 	validate *validator.Validate,
 ) *ChatUseCaseImpl {
 	return &ChatUseCaseImpl{
 		DB:             db,
 		ChatRepository: chatRepository,
+		ProjectRepository: projectRepository,
+		ChatMemberRepository: chatMemberRepository,
+		WebhookRepository: webhookRepository,
+		UserClient:     userClient,
 		Log:            log,
 		Validate:       validate,
 	}
@@ -45,6 +60,11 @@ func (c *ChatUseCaseImpl) GetChats(ctx context.Context, gcr *proto.GetChatsReque
 	// create request
 	req := &dto.GetChatsRequest{
 		UserID: uint(gcr.GetUserId()),
+		OrganizationID: uint(gcr.GetOrganizationId()),
+	}
+	if gcr.ProjectId != nil {
+		projectID := uint(gcr.GetProjectId())
+		req.ProjectID = &projectID
 	}
 
 	// validate request
@@ -60,11 +80,26 @@ func (c *ChatUseCaseImpl) GetChats(ctx context.Context, gcr *proto.GetChatsReque
 		return nil, err.Err()
 	}
 
+	organization, err := organizationMembership(ctx, c.UserClient, c.Log, req.UserID, req.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if organization != nil && req.ProjectID != nil {
+		return nil, status.Error(codes.InvalidArgument, "Projects are not available in organizations")
+	}
+
 	db := c.DB.WithContext(ctx)
 
-	// get chats
+	// get chats, every member sees the chats of the organization
 	chats := new([]entity.Chat)
-	if err := c.ChatRepository.FindAllByUserID(db, chats, int(req.UserID)); err != nil {
+	if organization != nil {
+		err = c.ChatRepository.FindAllByOrganizationID(db, chats, int(organization.GetId()))
+	} else if req.ProjectID != nil {
+		err = c.ChatRepository.FindAllByUserIDAndProjectID(db, chats, int(req.UserID), int(*req.ProjectID))
+	} else {
+		err = c.ChatRepository.FindAllPersonalByUserID(db, chats, int(req.UserID))
+	}
+	if err != nil {
 		c.Log.Errorf("Failed to get chats: %v", err)
 		return nil, status.Error(codes.NotFound, "Chats not found")
 	}
@@ -75,11 +110,25 @@ func (c *ChatUseCaseImpl) GetChats(ctx context.Context, gcr *proto.GetChatsReque
 	}
 
 	for _, chat := range *chats {
-		res.Chats = append(res.Chats, &proto.Chat{
-			Id:     int64(chat.ID),
-			UserId: int64(chat.UserID),
-			Topic: *chat.Topic,
-		})
+		role := RoleOwner
+		if chat.UserID != req.UserID {
+			role = RoleEditor
+		}
+
+		res.Chats = append(res.Chats, toChatProto(&chat, role))
+	}
+
+	// Chats shared with the user are listed in the personal workspace, outside any project filter
+	if organization == nil && req.ProjectID == nil {
+		members := new([]entity.ChatMember)
+		if err := c.ChatMemberRepository.FindAllByUserID(db, members, int(req.UserID)); err != nil {
+			c.Log.Errorf("Failed to get shared chats: %v", err)
+			return nil, status.Error(codes.NotFound, "Chats not found")
+		}
+
+		for _, member := range *members {
+			res.Chats = append(res.Chats, toChatProto(&member.Chat, member.Role))
+		}
 	}
 
 	return res, nil
@@ -90,6 +139,7 @@ func (c *ChatUseCaseImpl) GetChatByIdAndUserId(ctx context.Context, gcr *proto.G
 	req := &dto.GetChatRequest{
 		ChatID: uint(gcr.GetChatId()),
 		UserID: uint(gcr.GetUserId()),
+		OrganizationID: uint(gcr.GetOrganizationId()),
 	}
 
 	// validate request
@@ -105,37 +155,161 @@ func (c *ChatUseCaseImpl) GetChatByIdAndUserId(ctx context.Context, gcr *proto.G
 		return nil, err.Err()
 	}
 
+	organization, err := organizationMembership(ctx, c.UserClient, c.Log, req.UserID, req.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
 	db := c.DB.WithContext(ctx)
 
 	// get chat
 	chat := new(entity.Chat)
-	if err := c.ChatRepository.FindByIDAndUserID(db, chat, int(req.ChatID), int(req.UserID)); err != nil {
+	if err := c.ChatRepository.FindByIDWithMessages(db, chat, int(req.ChatID)); err != nil {
 		c.Log.Errorf("Failed to get chat: %v", err)
 		return nil, status.Error(codes.NotFound, "Chat not found")
 	}
 
+	// owners and members may read the chat
+	role, err := chatRole(db, c.ChatMemberRepository, chat, req.UserID, uint(organization.GetId()))
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "Chat not found")
+	}
+
 	// get messages
 	messages := make([]*proto.FullMessage, 0)
-	for _, message := range chat.Messages {
-		messages = append(messages, &proto.FullMessage{
-			Id:     int64(message.ID),
-			ChatId: int64(message.ChatID),
-			UserId: int64(message.UserID),
-			Role:  message.Role,
-			Content: message.Content,
-		})
+	for i := range chat.Messages {
+		messages = append(messages, toFullMessage(&chat.Messages[i]))
 	}
 
 
 	// create response
 	res := &proto.GetChatResponse{
-		Chat: &proto.Chat{
-			Id:     int64(chat.ID),
-			UserId: int64(chat.UserID),
-			Topic: *chat.Topic,
-		},
+		Chat: toChatProto(chat, role),
 		Messages: messages,
 	}
 
 	return res, nil
 }
+
+func (c *ChatUseCaseImpl) MoveChat(ctx context.Context, mcr *proto.MoveChatRequest) (*proto.Chat, error) {
+	// create request
+	req := &dto.MoveChatRequest{
+		ChatID: uint(mcr.GetChatId()),
+		UserID: uint(mcr.GetUserId()),
+		ProjectID: uint(mcr.GetProjectId()),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, mcr); err != nil {
+		return nil, err
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	// get chat
+	chat := new(entity.Chat)
+	if err := c.ChatRepository.FindByIDAndUserID(db, chat, int(req.ChatID), int(req.UserID)); err != nil {
+		c.Log.Errorf("Failed to get chat: %v", err)
+		return nil, status.Error(codes.NotFound, "Chat not found")
+	}
+
+	// move chat into the project, or out of any project
+	chat.ProjectID = nil
+	if req.ProjectID != 0 {
+		if chat.OrganizationID != nil {
+			return nil, status.Error(codes.InvalidArgument, "Projects are not available in organizations")
+		}
+
+		project := new(entity.Project)
+		if err := c.ProjectRepository.FindByIDAndUserID(db, project, int(req.ProjectID), int(req.UserID)); err != nil {
+			c.Log.Errorf("Failed to get project: %v", err)
+			return nil, status.Error(codes.NotFound, "Project not found")
+		}
+
+		chat.ProjectID = &project.ID
+	}
+
+	// Messages were preloaded, avoid upserting them again
+	if err := c.ChatRepository.Update(db.Omit("Messages"), chat); err != nil {
+		c.Log.Errorf("Failed to move chat: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while moving chat")
+	}
+
+	return toChatProto(chat, RoleOwner), nil
+}
+
+func (c *ChatUseCaseImpl) DeleteChat(ctx context.Context, dcr *proto.DeleteChatRequest) (*proto.Empty, error) {
+	// create request
+	req := &dto.GetChatRequest{
+		ChatID: uint(dcr.GetChatId()),
+		UserID: uint(dcr.GetUserId()),
+		OrganizationID: uint(dcr.GetOrganizationId()),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, dcr); err != nil {
+		return nil, err
+	}
+
+	organization, err := organizationMembership(ctx, c.UserClient, c.Log, req.UserID, req.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// only the owner deletes the chat, from the workspace it belongs to
+	chat := new(entity.Chat)
+	if err := c.ChatRepository.FindByIDAndUserID(tx, chat, int(req.ChatID), int(req.UserID)); err != nil {
+		c.Log.Errorf("Failed to get chat: %v", err)
+		return nil, status.Error(codes.NotFound, "Chat not found")
+	}
+
+	if role, err := chatRole(tx, c.ChatMemberRepository, chat, req.UserID, uint(organization.GetId())); err != nil || role != RoleOwner {
+		return nil, status.Error(codes.NotFound, "Chat not found")
+	}
+
+	// delete chat, its messages and members cascade
+	if err := c.ChatRepository.Delete(tx, chat); err != nil {
+		c.Log.Errorf("Failed to delete chat: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while deleting chat")
+	}
+
+	if err := enqueueWebhookEvent(tx, c.WebhookRepository, chat.UserID, EventChatDeleted, toChatEventData(chat)); err != nil {
+		c.Log.Errorf("Failed to enqueue webhook event: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while deleting chat")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, status.Error(codes.Internal, "An error occurred while committing transaction")
+	}
+
+	return &proto.Empty{}, nil
+}
+
+func toChatProto(chat *entity.Chat, role string) *proto.Chat {
+	res := &proto.Chat{
+		Id:     int64(chat.ID),
+		UserId: int64(chat.UserID),
+		AssistantId: nullableID(chat.AssistantID),
+		ProjectId: nullableID(chat.ProjectID),
+		OrganizationId: nullableID(chat.OrganizationID),
+		Role: role,
+	}
+
+	if chat.Topic != nil {
+		res.Topic = *chat.Topic
+	}
+
+	return res
+}
+
+// nullableID converts an optional foreign key to its proto form, where 0 means unset.
+func nullableID(id *uint) int64 {
+	if id == nil {
+		return 0
+	}
+
+	return int64(*id)
+}
\ No newline at end of file
@@ -0,0 +1,325 @@
+package usecase
+
+import (
+	"auth-service/app/helper"
+	"auth-service/app/model/dto"
+	"auth-service/app/model/entity"
+	"auth-service/app/model/proto"
+	"context"
+	"io"
+	"sync"
+
+	"github.com/google/generative-ai-go/genai"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// CompareMessage answers every prompt with all requested models at once. The
+// replies are streamed concurrently, each part tagged with its model, and are
+// stored as sibling model messages sharing a compare group. The reply of the
+// first model stays the active branch until the user prefers another one.
+func (c *MessageUseCaseImpl) CompareMessage(cmr proto.ChatService_CompareMessageServer) error {
+	for {
+		// receive message
+		message, err := cmr.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			c.Log.Error("Failed to receive client message", err)
+			return status.Error(codes.Internal, "An error occurred while receiving message")
+		}
+
+		// create request
+		req := &dto.CompareMessageRequest{
+			ChatID: uint(message.GetChatId()),
+			UserID: uint(message.GetUserId()),
+			Message: message.GetMessage(),
+			AssistantID: uint(message.GetAssistantId()),
+			AssistantShareToken: message.GetAssistantShareToken(),
+			ProjectID: uint(message.GetProjectId()),
+			OrganizationID: uint(message.GetOrganizationId()),
+			Targets: make([]dto.CompareTarget, 0),
+		}
+		for _, target := range message.GetTargets() {
+			req.Targets = append(req.Targets, dto.CompareTarget{
+				Provider: target.GetProvider(),
+				Model: target.GetModel(),
+			})
+		}
+
+		// validate request
+		if err := validate(c.Validate, c.Log, req, message); err != nil {
+			return err
+		}
+
+		if err := c.compareReplies(cmr, req); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *MessageUseCaseImpl) compareReplies(cmr proto.ChatService_CompareMessageServer, req *dto.CompareMessageRequest) error {
+	ctx := cmr.Context()
+	organization, err := organizationMembership(ctx, c.UserClient, c.Log, req.UserID, req.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	preferences := c.userPreferences(ctx, req.UserID)
+	if err := checkHistoryEnabled(req.ChatID, organization, preferences); err != nil {
+		return err
+	}
+
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	chat, err := c.resolveChat(tx, req.ChatID, req.UserID, req.AssistantID, req.AssistantShareToken, req.ProjectID, organization)
+	if err != nil {
+		return err
+	}
+
+	if organization != nil {
+		models := make([]string, 0, len(req.Targets))
+		for _, target := range req.Targets {
+			models = append(models, target.Model)
+		}
+
+		if err := c.checkOrganizationLimits(tx, organization, req.UserID, models...); err != nil {
+			return err
+		}
+	}
+
+	history, err := c.chatHistory(tx, chat)
+	if err != nil {
+		return err
+	}
+
+	// Every model starts from the same history
+	sessions := make([]*genai.ChatSession, len(req.Targets))
+	for i, target := range req.Targets {
+		model, err := c.newGenerativeModel(tx, chat, req.UserID, target.Model, preferences)
+		if err != nil {
+			c.Log.Error("Failed to configure model", err)
+			return status.Error(codes.Internal, "An error occurred while configuring model")
+		}
+
+		sessions[i] = model.StartChat()
+		sessions[i].History = make([]*genai.Content, len(history))
+		copy(sessions[i].History, history)
+	}
+
+	compareGroup, err := helper.RandomToken(16)
+	if err != nil {
+		c.Log.Error("Failed to generate compare group", err)
+		return status.Error(codes.Internal, "An error occurred while comparing models")
+	}
+
+	// grpc streams must not be written concurrently
+	var mu sync.Mutex
+	send := func(part *proto.Part) {
+		mu.Lock()
+		defer mu.Unlock()
+		cmr.Send(part)
+	}
+
+	send(&proto.Part{
+		ChatId: int64(chat.ID),
+		Part:  "Starting chat...",
+		Status: proto.Status_START,
+		CompareGroup: compareGroup,
+	})
+
+	replies := make([]string, len(req.Targets))
+	errs := make([]error, len(req.Targets))
+
+	var wg sync.WaitGroup
+	for i, target := range req.Targets {
+		wg.Add(1)
+		go func(i int, model string) {
+			defer wg.Done()
+
+			replies[i], errs[i] = c.streamReply(ctx, sessions[i], req.Message, func(part string) {
+				send(&proto.Part{
+					ChatId: int64(chat.ID),
+					Part:  part,
+					Status: proto.Status_PROGRESS,
+					Model: model,
+					CompareGroup: compareGroup,
+				})
+			})
+		}(i, target.Model)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	// Store the prompt once and every reply as a sibling
+	userMsg := &entity.Message{
+		ChatID: chat.ID,
+		UserID: req.UserID,
+		Role:  "user",
+		Content: req.Message,
+		Active: true,
+	}
+	if err := c.MessageRepository.Create(tx, userMsg); err != nil {
+		c.Log.Error("Failed to create user message", err)
+		return status.Error(codes.Internal, "An error occurred while creating user message")
+	}
+
+	respMsgs := make([]*entity.Message, len(req.Targets))
+	for i, target := range req.Targets {
+		respMsgs[i] = &entity.Message{
+			ChatID: chat.ID,
+			UserID: req.UserID,
+			Role:  "model",
+			Content: replies[i],
+			Model: &req.Targets[i].Model,
+			CompareGroup: &compareGroup,
+			Active: i == 0,
+		}
+		if err := c.MessageRepository.Create(tx, respMsgs[i]); err != nil {
+			c.Log.Error("Failed to create model message", err)
+			return status.Error(codes.Internal, "An error occurred while creating model message")
+		}
+
+		send(&proto.Part{
+			ChatId: int64(chat.ID),
+			Part:  "Chat ended.",
+			Status: proto.Status_END,
+			Model: target.Model,
+			CompareGroup: compareGroup,
+			MessageId: int64(respMsgs[i].ID),
+		})
+	}
+
+	// Webhooks are notified of the reply that joined the chat history
+	if err := enqueueWebhookEvent(tx, c.WebhookRepository, chat.UserID, EventMessageCompleted, &messageEventData{
+		ChatID:    chat.ID,
+		MessageID: respMsgs[0].ID,
+		UserID:    req.UserID,
+		Prompt:    req.Message,
+		Content:   replies[0],
+	}); err != nil {
+		c.Log.Error("Failed to enqueue webhook event", err)
+		return status.Error(codes.Internal, "An error occurred while creating model message")
+	}
+
+	history = append(history,
+		&genai.Content{Parts: []genai.Part{genai.Text(req.Message)}, Role: "user"},
+		&genai.Content{Parts: []genai.Part{genai.Text(replies[0])}, Role: "model"},
+	)
+	if err := c.summarizeNewChat(ctx, tx, chat, history); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return status.Error(codes.Internal, "An error occurred while committing transaction")
+	}
+
+	return nil
+}
+
+// PreferMessage makes the chosen reply of a comparison the active branch of
+// the chat and records the choice as a preference of the user.
+func (c *MessageUseCaseImpl) PreferMessage(ctx context.Context, pmr *proto.PreferMessageRequest) (*proto.FullMessage, error) {
+	// create request
+	req := &dto.PreferMessageRequest{
+		ChatID: uint(pmr.GetChatId()),
+		UserID: uint(pmr.GetUserId()),
+		MessageID: uint(pmr.GetMessageId()),
+		OrganizationID: uint(pmr.GetOrganizationId()),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, pmr); err != nil {
+		return nil, err
+	}
+
+	organization, err := organizationMembership(ctx, c.UserClient, c.Log, req.UserID, req.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	chat := new(entity.Chat)
+	if err := c.ChatRepository.FindByID(tx, chat, int(req.ChatID)); err != nil {
+		return nil, status.Error(codes.NotFound, "Chat not found")
+	}
+
+	// Picking a branch changes the chat, so viewers may not do it
+	role, err := chatRole(tx, c.ChatMemberRepository, chat, req.UserID, uint(organization.GetId()))
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "Chat not found")
+	}
+	if !canWrite(role) {
+		return nil, status.Error(codes.PermissionDenied, "Viewers cannot prefer messages")
+	}
+
+	message := new(entity.Message)
+	if err := c.MessageRepository.FindByIDAndChatID(tx, message, int(req.MessageID), int(req.ChatID)); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, status.Error(codes.NotFound, "Message not found")
+		}
+
+		c.Log.Error("Failed to get message", err)
+		return nil, status.Error(codes.Internal, "An error occurred while getting message")
+	}
+
+	if message.CompareGroup == nil || message.Model == nil {
+		return nil, status.Error(codes.FailedPrecondition, "Message is not part of a comparison")
+	}
+
+	if err := c.MessageRepository.ActivateInCompareGroup(tx, *message.CompareGroup, int(message.ID)); err != nil {
+		c.Log.Error("Failed to activate message", err)
+		return nil, status.Error(codes.Internal, "An error occurred while preferring message")
+	}
+
+	if err := c.MessageRepository.SavePreference(tx, &entity.MessagePreference{
+		ChatID: chat.ID,
+		UserID: req.UserID,
+		CompareGroup: *message.CompareGroup,
+		MessageID: message.ID,
+		Model: *message.Model,
+	}); err != nil {
+		c.Log.Error("Failed to save preference", err)
+		return nil, status.Error(codes.Internal, "An error occurred while preferring message")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, status.Error(codes.Internal, "An error occurred while committing transaction")
+	}
+
+	message.Active = true
+
+	return toFullMessage(message), nil
+}
+
+func toFullMessage(message *entity.Message) *proto.FullMessage {
+	res := &proto.FullMessage{
+		Id:     int64(message.ID),
+		ChatId: int64(message.ChatID),
+		UserId: int64(message.UserID),
+		Role:  message.Role,
+		Content: message.Content,
+		Active: message.Active,
+	}
+	if message.Model != nil {
+		res.Model = *message.Model
+	}
+	if message.CompareGroup != nil {
+		res.CompareGroup = *message.CompareGroup
+	}
+
+	return res
+}
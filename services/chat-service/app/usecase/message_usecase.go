@@ -1,33 +1,52 @@
 package usecase
 
 import (
+	"auth-service/app/delivery/client"
 	"auth-service/app/helper"
 	"auth-service/app/model/dto"
 	"auth-service/app/model/entity"
 	"auth-service/app/model/proto"
 	"auth-service/app/repository"
 	"context"
+	"crypto/subtle"
 	"fmt"
 	"io"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/generative-ai-go/genai"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
 	"google.golang.org/api/iterator"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
 )
 
+const systemPrompt = "You are a helpful chatbot. Answer user's chat with markdown format."
+
+// memoryChatLimit is the number of other chats whose topics are remembered
+const memoryChatLimit = 10
+
 type MessageUseCase interface {
 	GetMessages(ctx context.Context, gmr *proto.GetMessagesRequest) (*proto.GetMessagesResponse, error)
 	CreateMessage(cmr proto.ChatService_CreateMessageServer) error
+	CompareMessage(cmr proto.ChatService_CompareMessageServer) error
+	PreferMessage(ctx context.Context, pmr *proto.PreferMessageRequest) (*proto.FullMessage, error)
 }
 
 type MessageUseCaseImpl struct {
 	DB              	*gorm.DB
 	MessageRepository  	repository.MessageRepository
 	ChatRepository     	repository.ChatRepository
+	AssistantRepository	repository.AssistantRepository
+	ProjectRepository	repository.ProjectRepository
+	ChatMemberRepository	repository.ChatMemberRepository
+	WebhookRepository	repository.WebhookRepository
+	UserClient			*client.UserClient
 	GenAI				*genai.Client
 	Log             	*logrus.Logger
 	Validate        	*validator.Validate
@@ -37,6 +56,11 @@ func NewMessageUseCaseImpl(
 	db *gorm.DB,
 	messageRepository repository.MessageRepository,
 	chatRepository repository.ChatRepository,
+	assistantRepository repository.AssistantRepository,
+	projectRepository repository.ProjectRepository,
+	chatMemberRepository repository.ChatMemberRepository,
+	webhookRepository repository.WebhookRepository,
+	userClient *client.UserClient,
 	genai *genai.Client,
 	log *logrus.Logger,
 	validate *validator.Validate,
@@ -45,6 +69,11 @@ func NewMessageUseCaseImpl(
 		DB:             db,
 		MessageRepository: messageRepository,
 		ChatRepository: chatRepository,
+		AssistantRepository: assistantRepository,
+		ProjectRepository: projectRepository,
+		ChatMemberRepository: chatMemberRepository,
+		WebhookRepository: webhookRepository,
+		UserClient:     userClient,
 		GenAI:			genai,
 		Log:            log,
 		Validate:       validate,
@@ -56,6 +85,7 @@ func (c *MessageUseCaseImpl) GetMessages(ctx context.Context, gmr *proto.GetMess
 	req := &dto.GetMessagesRequest{
 		ChatID: uint(gmr.GetChatId()),
 		UserID: uint(gmr.GetUserId()),
+		OrganizationID: uint(gmr.GetOrganizationId()),
 	}
 
 	// validate request
@@ -71,8 +101,24 @@ func (c *MessageUseCaseImpl) GetMessages(ctx context.Context, gmr *proto.GetMess
 		return nil, err.Err()
 	}
 
+	organization, err := organizationMembership(ctx, c.UserClient, c.Log, req.UserID, req.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
 	db := c.DB.WithContext(ctx)
 
+	// owners and members may read the messages
+	chat := new(entity.Chat)
+	if err := c.ChatRepository.FindByID(db, chat, int(req.ChatID)); err != nil {
+		c.Log.Error("Failed to get chat", err)
+		return nil, status.Error(codes.NotFound, "Chat not found")
+	}
+
+	if _, err := chatRole(db, c.ChatMemberRepository, chat, req.UserID, uint(organization.GetId())); err != nil {
+		return nil, status.Error(codes.NotFound, "Chat not found")
+	}
+
 	// get messages
 	messages := new([]entity.Message)
 	if err := c.MessageRepository.FindAllByChatID(db, messages, int(req.ChatID)); err != nil {
@@ -85,14 +131,8 @@ func (c *MessageUseCaseImpl) GetMessages(ctx context.Context, gmr *proto.GetMess
 		Messages: make([]*proto.FullMessage, 0),
 	}
 
-	for _, message := range *messages {
-		res.Messages = append(res.Messages, &proto.FullMessage{
-			Id:     int64(message.ID),
-			ChatId: int64(message.ChatID),
-			UserId: int64(message.UserID),
-			Role:  message.Role,
-			Content: message.Content,
-		})
+	for i := range *messages {
+		res.Messages = append(res.Messages, toFullMessage(&(*messages)[i]))
 	}
 
 	return res, nil
@@ -115,6 +155,10 @@ func (c *MessageUseCaseImpl) CreateMessage(cmr proto.ChatService_CreateMessageSe
 			ChatID: uint(message.GetChatId()),
 			UserID: uint(message.GetUserId()),
 			Message: message.GetMessage(),
+			AssistantID: uint(message.GetAssistantId()),
+			AssistantShareToken: message.GetAssistantShareToken(),
+			ProjectID: uint(message.GetProjectId()),
+			OrganizationID: uint(message.GetOrganizationId()),
 		}
 
 		// validate request
@@ -130,61 +174,50 @@ func (c *MessageUseCaseImpl) CreateMessage(cmr proto.ChatService_CreateMessageSe
 			return err.Err()
 		}
 
+		organization, err := organizationMembership(cmr.Context(), c.UserClient, c.Log, req.UserID, req.OrganizationID)
+		if err != nil {
+			return err
+		}
+
+		// Ephemeral messages skip every repository write and the topic summary
+		if message.GetEphemeral() {
+			if err := c.createEphemeralMessage(cmr, req, organization, message.GetHistory()); err != nil {
+				return err
+			}
+			continue
+		}
+
 		db := c.DB.WithContext(cmr.Context())
 		tx := db.Begin()
 		defer tx.Rollback()
 
-		// Check if chat exists
-		chat := new(entity.Chat)
-		if err := c.ChatRepository.FindByID(tx, chat, int(req.ChatID)); err != nil {
-			if err == gorm.ErrRecordNotFound {
-				// Create chat if not exists
-				chat.UserID = req.UserID
-				chat.Topic = nil
-
-				if err := c.ChatRepository.Create(tx, chat); err != nil {
-					c.Log.Error("Failed to create chat", err)
-					return status.Error(codes.Internal, "An error occurred while creating chat")
-				}
-			} else {
-				c.Log.Error("Failed to get chat", err)
-				return status.Error(codes.Internal, "An error occurred while getting chat")
-			}
-
+		preferences := c.userPreferences(cmr.Context(), req.UserID)
+		if err := checkHistoryEnabled(req.ChatID, organization, preferences); err != nil {
+			return err
 		}
 
-		// Initialize messages with history if exists
-		dbMessages := new([]entity.Message)
-		if err := c.MessageRepository.FindAllByChatID(db, dbMessages, int(req.ChatID)); err != nil {
-			c.Log.Error("Failed to get history messages", err)
-			return status.Error(codes.Internal, "An error occurred while getting messages")
+		// Find the chat, or create it bound to the requested assistant and project
+		chat, err := c.resolveChat(tx, req.ChatID, req.UserID, req.AssistantID, req.AssistantShareToken, req.ProjectID, organization)
+		if err != nil {
+			return err
 		}
 
-		messages := []genai.Content{
-			{
-				Parts: []genai.Part{
-					genai.Text("You are a helpful chatbot. Answer user's chat with markdown format."),
-				},
-				Role: "user",
-			},
-		}
+		if organization != nil {
+			model, err := c.chatModel(tx, chat, preferences)
+			if err != nil {
+				c.Log.Error("Failed to get chat model", err)
+				return status.Error(codes.Internal, "An error occurred while configuring model")
+			}
 
-		for _, dbMessage := range *dbMessages {
-			messages = append(messages, genai.Content{
-				Parts: []genai.Part{
-					genai.Text(dbMessage.Content),
-				},
-				Role: dbMessage.Role,
-			})
+			if err := c.checkOrganizationLimits(tx, organization, req.UserID, model); err != nil {
+				return err
+			}
 		}
 
-		// Start stream the messages
-		model := c.GenAI.GenerativeModel("gemini-1.5-flash")
-		cs := model.StartChat()
-
-		cs.History = make([]*genai.Content, len(messages))
-		for i := range messages {
-			cs.History[i] = &messages[i]
+		// Start the chat session with its history
+		cs, err := c.startChatSession(tx, chat, req.UserID, preferences)
+		if err != nil {
+			return err
 		}
 
 		cmr.Send(&proto.Part{
@@ -193,90 +226,665 @@ func (c *MessageUseCaseImpl) CreateMessage(cmr proto.ChatService_CreateMessageSe
 			Status: proto.Status_START,
 		})
 
-		iter := cs.SendMessageStream(cmr.Context(), genai.Text(req.Message))
-		respMessage := ""
-		for {
-			resp, err := iter.Next()
-			if err == iterator.Done {
-				break
+		respMessage, err := c.streamReply(cmr.Context(), cs, req.Message, func(part string) {
+			cmr.Send(&proto.Part{
+				ChatId: int64(req.ChatID),
+				Part:  part,
+				Status: proto.Status_PROGRESS,
+			})
+		})
+		if err != nil {
+			return err
+		}
+
+		cmr.Send(&proto.Part{
+			ChatId: int64(chat.ID),
+			Part:  "Chat ended.",
+			Status: proto.Status_END,
+		})
+
+		if err := c.storeReply(cmr.Context(), tx, chat, cs, req.UserID, req.Message, respMessage); err != nil {
+			return err
+		}
+
+		// Commit transaction
+		if err := tx.Commit().Error; err != nil {
+			return status.Error(codes.Internal, "An error occurred while committing transaction")
+		}
+	}
+
+	return nil
+}
+
+// resolveChat finds the chat the user may prompt, or creates it bound to the
+// requested assistant and project when it does not exist yet. New chats
+// belong to the organization of the request, nil is the personal workspace.
+func (c *MessageUseCaseImpl) resolveChat(tx *gorm.DB, chatID uint, userID uint, assistantID uint, assistantShareToken string, projectID uint, organization *proto.UserOrganization) (*entity.Chat, error) {
+	organizationID := uint(organization.GetId())
+
+	chat := new(entity.Chat)
+	if err := c.ChatRepository.FindByID(tx, chat, int(chatID)); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			// Create chat if not exists
+			chat.UserID = userID
+			chat.Topic = nil
+			if organization != nil {
+				chat.OrganizationID = &organizationID
 			}
-			if err != nil {
-				c.Log.Error("Failed to get next message", err)
-				return status.Error(codes.Internal, "An error occurred while sending message")
+
+			// Bind the chat to the requested assistant for its whole lifetime
+			if assistantID != 0 {
+				assistant := new(entity.Assistant)
+				if err := c.AssistantRepository.FindByID(tx, assistant, int(assistantID)); err != nil || !canUseAssistant(assistant, userID, organizationID, assistantShareToken) {
+					return nil, status.Error(codes.NotFound, "Assistant not found")
+				}
+
+				chat.AssistantID = &assistant.ID
 			}
 
-			for _, cand := range resp.Candidates {
-				if cand.Content != nil {
-					for _, part := range cand.Content.Parts {
-						respMessage += fmt.Sprintf("%v", part)
-						cmr.Send(&proto.Part{
-							ChatId: int64(req.ChatID),
-							Part:  fmt.Sprintf("%v", part),
-							Status: proto.Status_PROGRESS,
-						})
-					}
+			// Start the chat inside the requested project
+			if projectID != 0 {
+				if organization != nil {
+					return nil, status.Error(codes.InvalidArgument, "Projects are not available in organizations")
 				}
+
+				project := new(entity.Project)
+				if err := c.ProjectRepository.FindByIDAndUserID(tx, project, int(projectID), int(userID)); err != nil {
+					return nil, status.Error(codes.NotFound, "Project not found")
+				}
+
+				chat.ProjectID = &project.ID
+			}
+
+			if err := c.createChat(tx, chat); err != nil {
+				return nil, err
 			}
+		} else {
+			c.Log.Error("Failed to get chat", err)
+			return nil, status.Error(codes.Internal, "An error occurred while getting chat")
 		}
 
-		cmr.Send(&proto.Part{
-			ChatId: int64(chat.ID),
-			Part:  "Chat ended.",
-			Status: proto.Status_END,
+	} else {
+		// Only the owner and editors may prompt an existing chat
+		role, err := chatRole(tx, c.ChatMemberRepository, chat, userID, organizationID)
+		if err != nil {
+			return nil, status.Error(codes.NotFound, "Chat not found")
+		}
+		if !canWrite(role) {
+			return nil, status.Error(codes.PermissionDenied, "Viewers cannot send messages")
+		}
+	}
+
+	return chat, nil
+}
+
+// createChat saves a new chat and records its chat.created event.
+func (c *MessageUseCaseImpl) createChat(tx *gorm.DB, chat *entity.Chat) error {
+	if err := c.ChatRepository.Create(tx, chat); err != nil {
+		c.Log.Error("Failed to create chat", err)
+		return status.Error(codes.Internal, "An error occurred while creating chat")
+	}
+
+	if err := enqueueWebhookEvent(tx, c.WebhookRepository, chat.UserID, EventChatCreated, toChatEventData(chat)); err != nil {
+		c.Log.Error("Failed to enqueue webhook event", err)
+		return status.Error(codes.Internal, "An error occurred while creating chat")
+	}
+
+	return nil
+}
+
+// startChatSession configures the model of the chat for the prompting user
+// and seeds the session with the system prompt and the stored history.
+func (c *MessageUseCaseImpl) startChatSession(tx *gorm.DB, chat *entity.Chat, userID uint, preferences *proto.UserPreferences) (*genai.ChatSession, error) {
+	history, err := c.chatHistory(tx, chat)
+	if err != nil {
+		return nil, err
+	}
+
+	model, err := c.newGenerativeModel(tx, chat, userID, "", preferences)
+	if err != nil {
+		c.Log.Error("Failed to configure model", err)
+		return nil, status.Error(codes.Internal, "An error occurred while configuring model")
+	}
+	cs := model.StartChat()
+	cs.History = history
+
+	return cs, nil
+}
+
+// chatHistory returns the system prompt followed by the active messages of
+// the chat, replies left out of a comparison are not part of it.
+func (c *MessageUseCaseImpl) chatHistory(tx *gorm.DB, chat *entity.Chat) ([]*genai.Content, error) {
+	history := []*genai.Content{
+		{
+			Parts: []genai.Part{
+				genai.Text(systemPrompt),
+			},
+			Role: "user",
+		},
+	}
+
+	dbMessages := new([]entity.Message)
+	if err := c.MessageRepository.FindAllActiveByChatID(tx, dbMessages, int(chat.ID)); err != nil {
+		c.Log.Error("Failed to get history messages", err)
+		return nil, status.Error(codes.Internal, "An error occurred while getting messages")
+	}
+
+	for _, dbMessage := range *dbMessages {
+		history = append(history, &genai.Content{
+			Parts: []genai.Part{
+				genai.Text(dbMessage.Content),
+			},
+			Role: dbMessage.Role,
 		})
+	}
+
+	return history, nil
+}
+
+// storeReply saves the prompt and the model reply, both attributed to the
+// author of the prompt, and summarizes the topic of a fresh chat.
+func (c *MessageUseCaseImpl) storeReply(ctx context.Context, tx *gorm.DB, chat *entity.Chat, cs *genai.ChatSession, userID uint, prompt string, reply string) error {
+	userMsg := &entity.Message{
+		ChatID: uint(chat.ID),
+		UserID: userID,
+		Role:  "user",
+		Content: prompt,
+		Active: true,
+	}
+
+	respMsg := &entity.Message{
+		ChatID: uint(chat.ID),
+		UserID: userID,
+		Role:  "model",
+		Content: reply,
+		Active: true,
+	}
+
+	if err := c.MessageRepository.Create(tx, userMsg); err != nil {
+		c.Log.Error("Failed to create user message", err)
+		return status.Error(codes.Internal, "An error occurred while creating user message")
+	}
+
+	if err := c.MessageRepository.Create(tx, respMsg); err != nil {
+		c.Log.Error("Failed to create model message", err)
+		return status.Error(codes.Internal, "An error occurred while creating model message")
+	}
+
+	// Webhooks of the chat owner are notified once the reply is committed
+	if err := enqueueWebhookEvent(tx, c.WebhookRepository, chat.UserID, EventMessageCompleted, &messageEventData{
+		ChatID:    chat.ID,
+		MessageID: respMsg.ID,
+		UserID:    userID,
+		Prompt:    prompt,
+		Content:   reply,
+	}); err != nil {
+		c.Log.Error("Failed to enqueue webhook event", err)
+		return status.Error(codes.Internal, "An error occurred while creating model message")
+	}
+
+	return c.summarizeNewChat(ctx, tx, chat, cs.History)
+}
+
+// summarizeNewChat sets the topic of a chat that has none yet.
+func (c *MessageUseCaseImpl) summarizeNewChat(ctx context.Context, tx *gorm.DB, chat *entity.Chat, history []*genai.Content) error {
+	if chat.Topic == nil {
+		summarizedTopic, err := c.SummarizeTopic(ctx, history)
+		if err != nil {
+			c.Log.Error("Failed to get summarized topic", err)
+			return status.Error(codes.Internal, "An error occurred while summarizing topic")
+		}
 
-		// create message
-		userMsg := &entity.Message{
-			ChatID: uint(chat.ID),
-			UserID: uint(chat.UserID),
-			Role:  "user",
-			Content: req.Message,
+		// Update chat with summarized topic
+		chat.Topic = &summarizedTopic
+		if err := c.ChatRepository.Update(tx, chat); err != nil {
+			c.Log.Error("Failed to update chat", err)
+			return status.Error(codes.Internal, "An error occurred while updating chat")
 		}
+	}
 
-		respMsg := &entity.Message{
-			ChatID: uint(chat.ID),
-			UserID: uint(chat.UserID),
-			Role:  "model",
-			Content: respMessage,
+	return nil
+}
+
+// replyInChat sends a prompt on behalf of the user without streaming, as done
+// by scheduled prompts. A new chat is created when chatID is nil.
+func (c *MessageUseCaseImpl) replyInChat(ctx context.Context, userID uint, chatID *uint, prompt string) (*entity.Chat, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	chat := new(entity.Chat)
+	if chatID != nil {
+		if err := c.ChatRepository.FindByID(tx, chat, int(*chatID)); err != nil {
+			return nil, status.Error(codes.NotFound, "Chat not found")
 		}
 
-		if err := c.MessageRepository.Create(tx, userMsg); err != nil {
-			c.Log.Error("Failed to create user message", err)
-			return status.Error(codes.Internal, "An error occurred while creating user message")
+		// Scheduled prompts run in the personal workspace, out of reach of organization chats
+		if chat.OrganizationID != nil {
+			return nil, status.Error(codes.NotFound, "Chat not found")
 		}
 
-		if err := c.MessageRepository.Create(tx, respMsg); err != nil {
-			c.Log.Error("Failed to create model message", err)
-			return status.Error(codes.Internal, "An error occurred while creating model message")
+		// Access may have been revoked since the prompt was scheduled
+		role, err := chatRole(tx, c.ChatMemberRepository, chat, userID, 0)
+		if err != nil {
+			return nil, status.Error(codes.NotFound, "Chat not found")
+		}
+		if !canWrite(role) {
+			return nil, status.Error(codes.PermissionDenied, "Viewers cannot send messages")
 		}
+	} else {
+		chat.UserID = userID
+		if err := c.createChat(tx, chat); err != nil {
+			return nil, err
+		}
+	}
 
-		// If length of history is lower than or equal to 3, create summarize topic
-		if len(cs.History) <= 3 {
-			summarizedTopic, err := c.SummarizeTopic(cmr.Context(), cs.History)
-			if err != nil {
-				c.Log.Error("Failed to get summarized topic", err)
-				return status.Error(codes.Internal, "An error occurred while summarizing topic")
+	cs, err := c.startChatSession(tx, chat, userID, c.userPreferences(ctx, userID))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := cs.SendMessage(ctx, genai.Text(prompt))
+	if err != nil {
+		c.Log.Error("Failed to send message", err)
+		return nil, status.Error(codes.Internal, "An error occurred while sending message")
+	}
+
+	reply := ""
+	for _, cand := range resp.Candidates {
+		if cand.Content != nil {
+			for _, part := range cand.Content.Parts {
+				reply += fmt.Sprintf("%v", part)
 			}
-	
-			// Update chat with summarized topic
-			chat.Topic = &summarizedTopic
-			if err := c.ChatRepository.Update(tx, chat); err != nil {
-				c.Log.Error("Failed to update chat", err)
-				return status.Error(codes.Internal, "An error occurred while updating chat")
+		}
+	}
+
+	if err := c.storeReply(ctx, tx, chat, cs, userID, prompt, reply); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, status.Error(codes.Internal, "An error occurred while committing transaction")
+	}
+
+	return chat, nil
+}
+
+// createEphemeralMessage answers a prompt using the history kept by the
+// caller, nothing is written through the repositories.
+func (c *MessageUseCaseImpl) createEphemeralMessage(cmr proto.ChatService_CreateMessageServer, req *dto.CreateMessageRequest, organization *proto.UserOrganization, history []*proto.HistoryMessage) error {
+	db := c.DB.WithContext(cmr.Context())
+
+	// The transient chat only carries the assistant binding and is never saved
+	chat := &entity.Chat{
+		UserID: req.UserID,
+	}
+	if req.AssistantID != 0 {
+		assistant := new(entity.Assistant)
+		if err := c.AssistantRepository.FindByID(db, assistant, int(req.AssistantID)); err != nil || !canUseAssistant(assistant, req.UserID, uint(organization.GetId()), req.AssistantShareToken) {
+			return status.Error(codes.NotFound, "Assistant not found")
+		}
+
+		chat.AssistantID = &assistant.ID
+	}
+
+	preferences := c.userPreferences(cmr.Context(), req.UserID)
+	if organization != nil {
+		// Ephemeral prompts leave nothing to count against the quota
+		if organization.MemberDailyMessageQuota != nil {
+			return status.Error(codes.FailedPrecondition, "Ephemeral messages are not available in organizations with a message quota")
+		}
+
+		modelName, err := c.chatModel(db, chat, preferences)
+		if err != nil {
+			c.Log.Error("Failed to get chat model", err)
+			return status.Error(codes.Internal, "An error occurred while configuring model")
+		}
+
+		if err := c.checkOrganizationLimits(db, organization, req.UserID, modelName); err != nil {
+			return err
+		}
+	}
+
+	model, err := c.newGenerativeModel(db, chat, req.UserID, "", preferences)
+	if err != nil {
+		c.Log.Error("Failed to configure model", err)
+		return status.Error(codes.Internal, "An error occurred while configuring model")
+	}
+	cs := model.StartChat()
+
+	cs.History = []*genai.Content{
+		{
+			Parts: []genai.Part{
+				genai.Text(systemPrompt),
+			},
+			Role: "user",
+		},
+	}
+	for _, message := range history {
+		if message.GetRole() != "user" && message.GetRole() != "model" {
+			return status.Error(codes.InvalidArgument, "History role must be user or model")
+		}
+
+		cs.History = append(cs.History, &genai.Content{
+			Parts: []genai.Part{
+				genai.Text(message.GetContent()),
+			},
+			Role: message.GetRole(),
+		})
+	}
+
+	cmr.Send(&proto.Part{
+		Part:  "Starting chat...",
+		Status: proto.Status_START,
+	})
+
+	if _, err := c.streamReply(cmr.Context(), cs, req.Message, func(part string) {
+		cmr.Send(&proto.Part{
+			Part:  part,
+			Status: proto.Status_PROGRESS,
+		})
+	}); err != nil {
+		return err
+	}
+
+	cmr.Send(&proto.Part{
+		Part:  "Chat ended.",
+		Status: proto.Status_END,
+	})
+
+	return nil
+}
+
+// streamReply sends the prompt and hands every part of the reply to send, returning the full reply.
+func (c *MessageUseCaseImpl) streamReply(ctx context.Context, cs *genai.ChatSession, message string, send func(part string)) (string, error) {
+	iter := cs.SendMessageStream(ctx, genai.Text(message))
+	respMessage := ""
+	for {
+		resp, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			c.Log.Error("Failed to get next message", err)
+			return "", status.Error(codes.Internal, "An error occurred while sending message")
+		}
+
+		for _, cand := range resp.Candidates {
+			if cand.Content != nil {
+				for _, part := range cand.Content.Parts {
+					respMessage += fmt.Sprintf("%v", part)
+					send(fmt.Sprintf("%v", part))
+				}
+			}
+		}
+	}
+
+	return respMessage, nil
+}
+
+// newGenerativeModel creates the model answering in the chat, applying the
+// instructions and files of its project, the settings, instructions,
+// knowledge and tools of the bound assistant and the preferences of userID,
+// the user prompting in the chat. A non-empty modelName replaces the model of the assistant, as done when
+// comparing models, the preferred model is used when neither is set.
+func (c *MessageUseCaseImpl) newGenerativeModel(tx *gorm.DB, chat *entity.Chat, userID uint, modelName string, preferences *proto.UserPreferences) (*genai.GenerativeModel, error) {
+	instructions := make([]string, 0)
+
+	if chat.ProjectID != nil {
+		project := new(entity.Project)
+		if err := c.ProjectRepository.FindByIDAndUserID(tx, project, int(*chat.ProjectID), int(chat.UserID)); err != nil {
+			return nil, err
+		}
+
+		instructions = append(instructions, projectInstruction(project))
+	}
+
+	var assistant *entity.Assistant
+	if chat.AssistantID != nil {
+		assistant = new(entity.Assistant)
+		if err := c.AssistantRepository.FindByID(tx, assistant, int(*chat.AssistantID)); err != nil {
+			return nil, err
+		}
+
+		if modelName == "" {
+			modelName = assistant.Model
+		}
+	}
+	if modelName == "" {
+		modelName = preferences.GetDefaultModel()
+	}
+
+	model := c.GenAI.GenerativeModel(modelOrDefault(modelName))
+	if assistant != nil {
+		if assistant.Temperature != nil {
+			model.SetTemperature(*assistant.Temperature)
+		}
+		if assistant.TopP != nil {
+			model.SetTopP(*assistant.TopP)
+		}
+		if assistant.MaxOutputTokens != nil {
+			model.SetMaxOutputTokens(*assistant.MaxOutputTokens)
+		}
+
+		for _, tool := range assistant.Tools {
+			if tool == "code_execution" {
+				model.Tools = append(model.Tools, &genai.Tool{CodeExecution: &genai.CodeExecution{}})
 			}
 		}
 
-		// Commit transaction
-		if err := tx.Commit().Error; err != nil {
-			return status.Error(codes.Internal, "An error occurred while committing transaction")
+		instructions = append(instructions, assistantInstruction(assistant))
+	}
+
+	if instruction := preferencesInstruction(preferences, time.Now()); instruction != "" {
+		instructions = append(instructions, instruction)
+	}
+
+	instruction, err := c.chatMemory(tx, chat, userID, preferences)
+	if err != nil {
+		return nil, err
+	}
+	if instruction != "" {
+		instructions = append(instructions, instruction)
+	}
+
+	if len(instructions) > 0 {
+		model.SystemInstruction = &genai.Content{
+			Parts: []genai.Part{
+				genai.Text(strings.Join(instructions, "\n\n")),
+			},
 		}
 	}
 
+	return model, nil
+}
+
+// projectInstruction joins the project instructions with its pinned reference files.
+func projectInstruction(project *entity.Project) string {
+	var sb strings.Builder
+	if project.Instructions != nil {
+		sb.WriteString(*project.Instructions)
+	}
+
+	if len(project.Files) > 0 {
+		sb.WriteString("\n\nThe following reference files are pinned to this project:")
+		for _, file := range project.Files {
+			sb.WriteString(fmt.Sprintf("\n\n## %s\n%s", file.Name, file.Content))
+		}
+	}
+
+	return strings.TrimSpace(sb.String())
+}
+
+// assistantInstruction joins the assistant instructions with its knowledge documents.
+func assistantInstruction(assistant *entity.Assistant) string {
+	var sb strings.Builder
+	sb.WriteString(assistant.Instructions)
+
+	if len(assistant.Documents) > 0 {
+		sb.WriteString("\n\nUse the following knowledge documents when they are relevant:")
+		for _, document := range assistant.Documents {
+			sb.WriteString(fmt.Sprintf("\n\n## %s\n%s", document.Name, document.Content))
+		}
+	}
+
+	return sb.String()
+}
+
+// preferencesInstruction asks for replies in the preferred language and tells
+// the model the local time of the user.
+func preferencesInstruction(preferences *proto.UserPreferences, now time.Time) string {
+	instructions := make([]string, 0, 2)
+
+	if preferences.GetResponseLanguage() != "" {
+		if tag, err := language.Parse(preferences.GetResponseLanguage()); err == nil {
+			instructions = append(instructions, fmt.Sprintf("Always answer in %s, whatever the language of the prompt.", display.English.Tags().Name(tag)))
+		}
+	}
+
+	if preferences.GetTimezone() != "" {
+		if location, err := time.LoadLocation(preferences.GetTimezone()); err == nil {
+			instructions = append(instructions, fmt.Sprintf("The current local time of the user is %s (%s).", now.In(location).Format("Monday, 2 January 2006 15:04"), location))
+		}
+	}
+
+	return strings.Join(instructions, " ")
+}
+
+// chatMemory returns the memory instruction of the chat when userID prompts
+// in it. Memory draws on the other saved chats of the owner, so it is only
+// applied to the owner prompting alone in a personal chat, temporary chats,
+// shared chats and chats of an organization are left out.
+func (c *MessageUseCaseImpl) chatMemory(tx *gorm.DB, chat *entity.Chat, userID uint, preferences *proto.UserPreferences) (string, error) {
+	if !preferences.GetMemoryEnabled() || chat.ID == 0 || chat.UserID != userID || chat.OrganizationID != nil {
+		return "", nil
+	}
+
+	members := new([]entity.ChatMember)
+	if err := c.ChatMemberRepository.FindAllByChatID(tx, members, int(chat.ID)); err != nil {
+		return "", err
+	}
+	if len(*members) > 0 {
+		return "", nil
+	}
+
+	recentChats := new([]entity.Chat)
+	if err := c.ChatRepository.FindRecentTopicsByUserID(tx, recentChats, int(chat.UserID), int(chat.ID), memoryChatLimit); err != nil {
+		return "", err
+	}
+
+	return memoryInstruction(*recentChats), nil
+}
+
+// memoryInstruction reminds the model of the topics the user talked about in other chats.
+func memoryInstruction(chats []entity.Chat) string {
+	topics := make([]string, 0, len(chats))
+	for _, chat := range chats {
+		if chat.Topic != nil && *chat.Topic != "" {
+			topics = append(topics, fmt.Sprintf("- %s", *chat.Topic))
+		}
+	}
+
+	if len(topics) == 0 {
+		return ""
+	}
+
+	return "The user recently talked with you about these topics in other chats, refer to them when relevant:\n" + strings.Join(topics, "\n")
+}
+
+// checkHistoryEnabled refuses to save a new personal chat for users who turned
+// chat history off, their clients start temporary chats instead.
+func checkHistoryEnabled(chatID uint, organization *proto.UserOrganization, preferences *proto.UserPreferences) error {
+	if chatID == 0 && organization == nil && !preferences.GetHistoryEnabled() {
+		return status.Error(codes.FailedPrecondition, "Chat history is turned off, new chats must be ephemeral")
+	}
+
 	return nil
 }
 
+// userPreferences returns the preferences of the user, answering with the
+// defaults when the user service is unavailable.
+func (c *MessageUseCaseImpl) userPreferences(ctx context.Context, userID uint) *proto.UserPreferences {
+	getPreferencesCtx, cancelGetPreferences := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetPreferences()
+
+	preferences, err := c.UserClient.Service.GetPreferences(getPreferencesCtx, &proto.GetUserPreferencesRequest{
+		UserId: int64(userID),
+	})
+	if err != nil {
+		c.Log.Warn("Failed to get user preferences", err)
+		return &proto.UserPreferences{
+			MemoryEnabled:  true,
+			HistoryEnabled: true,
+		}
+	}
+
+	return preferences
+}
+
+// chatModel returns the model answering in the chat when no model is
+// requested, as chosen by newGenerativeModel.
+func (c *MessageUseCaseImpl) chatModel(tx *gorm.DB, chat *entity.Chat, preferences *proto.UserPreferences) (string, error) {
+	modelName := ""
+	if chat.AssistantID != nil {
+		assistant := new(entity.Assistant)
+		if err := c.AssistantRepository.FindByID(tx, assistant, int(*chat.AssistantID)); err != nil {
+			return "", err
+		}
+
+		modelName = assistant.Model
+	}
+	if modelName == "" {
+		modelName = preferences.GetDefaultModel()
+	}
+
+	return modelOrDefault(modelName), nil
+}
+
+// checkOrganizationLimits enforces the daily message quota of the members of
+// the organization and the models it allows.
+func (c *MessageUseCaseImpl) checkOrganizationLimits(tx *gorm.DB, organization *proto.UserOrganization, userID uint, models ...string) error {
+	if organization.MemberDailyMessageQuota != nil {
+		var count int64
+		since := time.Now().UTC().Truncate(24 * time.Hour)
+		if err := c.MessageRepository.CountPromptsByUserIDAndOrganizationID(tx, &count, int(userID), int(organization.GetId()), since); err != nil {
+			c.Log.Error("Failed to count organization prompts", err)
+			return status.Error(codes.Internal, "An error occurred while checking the organization quota")
+		}
+
+		if count >= int64(organization.GetMemberDailyMessageQuota()) {
+			return status.Error(codes.ResourceExhausted, "Daily message quota of the organization reached")
+		}
+	}
+
+	if allowedModels := organization.GetAllowedModels(); len(allowedModels) > 0 {
+		for _, model := range models {
+			if !slices.Contains(allowedModels, model) {
+				return status.Errorf(codes.PermissionDenied, "Model %s is not allowed in the organization", model)
+			}
+		}
+	}
+
+	return nil
+}
+
+// canUseAssistant reports whether the user owns the assistant, it belongs to
+// the organization of the request or the user presents its share token. Ids
+// are sequential, so sharing alone does not make an assistant usable.
+func canUseAssistant(assistant *entity.Assistant, userID uint, organizationID uint, shareToken string) bool {
+	if assistant.OrganizationID != nil && *assistant.OrganizationID == organizationID {
+		return true
+	}
+
+	if assistant.UserID == userID {
+		return true
+	}
+
+	return assistant.ShareToken != nil && shareToken != "" &&
+		subtle.ConstantTimeCompare([]byte(*assistant.ShareToken), []byte(shareToken)) == 1
+}
+
 func (c *MessageUseCaseImpl) SummarizeTopic(ctx context.Context, history []*genai.Content) (string, error) {
-	model := c.GenAI.GenerativeModel("gemini-1.5-flash")
+	model := c.GenAI.GenerativeModel(DefaultModel)
 	cs := model.StartChat()
 
 	cs.History = make([]*genai.Content, len(history))
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"auth-service/app/model/entity"
+	"auth-service/app/model/proto"
+	"auth-service/app/repository"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMessageUseCaseImpl_ChatMemory(t *testing.T) {
+    chatRepository := new(repository.ChatRepositoryMock)
+    chatMemberRepository := new(repository.ChatMemberRepositoryMock)
+    usecase := &MessageUseCaseImpl{ChatRepository: chatRepository, ChatMemberRepository: chatMemberRepository}
+
+    topic := "Trip to Lisbon"
+    chatMemberRepository.On("FindAllByChatID", mock.Anything, mock.Anything, 1).Return(nil)
+    chatRepository.On("FindRecentTopicsByUserID", mock.Anything, mock.Anything, 2, 1, memoryChatLimit).
+        Run(func(args mock.Arguments) {
+            *args.Get(1).(*[]entity.Chat) = []entity.Chat{{ID: 3, UserID: 2, Topic: &topic}}
+        }).
+        Return(nil)
+
+    instruction, err := usecase.chatMemory(nil, &entity.Chat{ID: 1, UserID: 2}, 2, &proto.UserPreferences{MemoryEnabled: true})
+
+    assert.NoError(t, err)
+    assert.Contains(t, instruction, "Trip to Lisbon")
+    chatRepository.AssertExpectations(t)
+    chatMemberRepository.AssertExpectations(t)
+}
+
+func TestMessageUseCaseImpl_ChatMemory_Editor(t *testing.T) {
+    chatRepository := new(repository.ChatRepositoryMock)
+    chatMemberRepository := new(repository.ChatMemberRepositoryMock)
+    usecase := &MessageUseCaseImpl{ChatRepository: chatRepository, ChatMemberRepository: chatMemberRepository}
+
+    // An editor prompting in the chat of user 2 must not see the topics of its owner
+    instruction, err := usecase.chatMemory(nil, &entity.Chat{ID: 1, UserID: 2}, 4, &proto.UserPreferences{MemoryEnabled: true})
+
+    assert.NoError(t, err)
+    assert.Empty(t, instruction)
+    chatRepository.AssertNotCalled(t, "FindRecentTopicsByUserID", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMessageUseCaseImpl_ChatMemory_SharedChat(t *testing.T) {
+    chatRepository := new(repository.ChatRepositoryMock)
+    chatMemberRepository := new(repository.ChatMemberRepositoryMock)
+    usecase := &MessageUseCaseImpl{ChatRepository: chatRepository, ChatMemberRepository: chatMemberRepository}
+
+    chatMemberRepository.On("FindAllByChatID", mock.Anything, mock.Anything, 1).
+        Run(func(args mock.Arguments) {
+            *args.Get(1).(*[]entity.ChatMember) = []entity.ChatMember{{ID: 1, ChatID: 1, UserID: 4, Role: RoleEditor}}
+        }).
+        Return(nil)
+
+    // The members would read the replies, so the owner prompting gets no memory either
+    instruction, err := usecase.chatMemory(nil, &entity.Chat{ID: 1, UserID: 2}, 2, &proto.UserPreferences{MemoryEnabled: true})
+
+    assert.NoError(t, err)
+    assert.Empty(t, instruction)
+    chatRepository.AssertNotCalled(t, "FindRecentTopicsByUserID", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+    chatMemberRepository.AssertExpectations(t)
+}
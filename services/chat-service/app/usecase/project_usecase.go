@@ -0,0 +1,257 @@
+package usecase
+
+import (
+	"auth-service/app/model/dto"
+	"auth-service/app/model/entity"
+	"auth-service/app/model/proto"
+	"auth-service/app/repository"
+	"context"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+type ProjectUseCase interface {
+	CreateProject(ctx context.Context, cpr *proto.CreateProjectRequest) (*proto.Project, error)
+	GetProjects(ctx context.Context, gpr *proto.GetProjectsRequest) (*proto.GetProjectsResponse, error)
+	GetProject(ctx context.Context, gpr *proto.GetProjectRequest) (*proto.Project, error)
+	UpdateProject(ctx context.Context, upr *proto.UpdateProjectRequest) (*proto.Project, error)
+	DeleteProject(ctx context.Context, dpr *proto.DeleteProjectRequest) (*proto.Empty, error)
+}
+
+type ProjectUseCaseImpl struct {
+	DB                	*gorm.DB
+	ProjectRepository 	repository.ProjectRepository
+	Log               	*logrus.Logger
+	Validate          	*validator.Validate
+}
+
+func NewProjectUseCaseImpl(
+	db *gorm.DB,
+	projectRepository repository.ProjectRepository,
+	log *logrus.Logger,
+	validate *validator.Validate,
+) *ProjectUseCaseImpl {
+	return &ProjectUseCaseImpl{
+		DB:                db,
+		ProjectRepository: projectRepository,
+		Log:               log,
+		Validate:          validate,
+	}
+}
+
+func (c *ProjectUseCaseImpl) CreateProject(ctx context.Context, cpr *proto.CreateProjectRequest) (*proto.Project, error) {
+	// create request
+	req := &dto.CreateProjectRequest{
+		UserID:       uint(cpr.GetUserId()),
+		Name:         cpr.GetName(),
+		Instructions: cpr.GetInstructions(),
+		Files:        toProjectFileDtos(cpr.GetFiles()),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, cpr); err != nil {
+		return nil, err
+	}
+
+	project := &entity.Project{
+		UserID:       req.UserID,
+		Name:         req.Name,
+		Instructions: nullableString(req.Instructions),
+		Files:        toProjectFileEntities(req.Files),
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	// create project
+	if err := c.ProjectRepository.Create(db, project); err != nil {
+		c.Log.Errorf("Failed to create project: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while creating project")
+	}
+
+	return toProjectProto(project), nil
+}
+
+func (c *ProjectUseCaseImpl) GetProjects(ctx context.Context, gpr *proto.GetProjectsRequest) (*proto.GetProjectsResponse, error) {
+	// create request
+	req := &dto.GetProjectsRequest{
+		UserID: uint(gpr.GetUserId()),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, gpr); err != nil {
+		return nil, err
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	// get projects
+	projects := new([]entity.Project)
+	if err := c.ProjectRepository.FindAllByUserID(db, projects, int(req.UserID)); err != nil {
+		c.Log.Errorf("Failed to get projects: %v", err)
+		return nil, status.Error(codes.NotFound, "Projects not found")
+	}
+
+	// create response
+	res := &proto.GetProjectsResponse{
+		Projects: make([]*proto.Project, 0),
+	}
+
+	for i := range *projects {
+		res.Projects = append(res.Projects, toProjectProto(&(*projects)[i]))
+	}
+
+	return res, nil
+}
+
+func (c *ProjectUseCaseImpl) GetProject(ctx context.Context, gpr *proto.GetProjectRequest) (*proto.Project, error) {
+	// create request
+	req := &dto.GetProjectRequest{
+		ID:     uint(gpr.GetId()),
+		UserID: uint(gpr.GetUserId()),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, gpr); err != nil {
+		return nil, err
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	// get project
+	project := new(entity.Project)
+	if err := c.ProjectRepository.FindByIDAndUserID(db, project, int(req.ID), int(req.UserID)); err != nil {
+		c.Log.Errorf("Failed to get project: %v", err)
+		return nil, status.Error(codes.NotFound, "Project not found")
+	}
+
+	return toProjectProto(project), nil
+}
+
+func (c *ProjectUseCaseImpl) UpdateProject(ctx context.Context, upr *proto.UpdateProjectRequest) (*proto.Project, error) {
+	// create request
+	req := &dto.UpdateProjectRequest{
+		ID:           uint(upr.GetId()),
+		UserID:       uint(upr.GetUserId()),
+		Name:         upr.GetName(),
+		Instructions: upr.GetInstructions(),
+		Files:        toProjectFileDtos(upr.GetFiles()),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, upr); err != nil {
+		return nil, err
+	}
+
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// get project
+	project := new(entity.Project)
+	if err := c.ProjectRepository.FindByIDAndUserID(tx, project, int(req.ID), int(req.UserID)); err != nil {
+		c.Log.Errorf("Failed to get project: %v", err)
+		return nil, status.Error(codes.NotFound, "Project not found")
+	}
+
+	// replace pinned files
+	if err := c.ProjectRepository.DeleteFiles(tx, int(project.ID)); err != nil {
+		c.Log.Errorf("Failed to delete project files: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while updating project")
+	}
+
+	project.Name = req.Name
+	project.Instructions = nullableString(req.Instructions)
+	project.Files = toProjectFileEntities(req.Files)
+
+	// update project
+	if err := c.ProjectRepository.Update(tx, project); err != nil {
+		c.Log.Errorf("Failed to update project: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while updating project")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, status.Error(codes.Internal, "An error occurred while committing transaction")
+	}
+
+	return toProjectProto(project), nil
+}
+
+func (c *ProjectUseCaseImpl) DeleteProject(ctx context.Context, dpr *proto.DeleteProjectRequest) (*proto.Empty, error) {
+	// create request
+	req := &dto.GetProjectRequest{
+		ID:     uint(dpr.GetId()),
+		UserID: uint(dpr.GetUserId()),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, dpr); err != nil {
+		return nil, err
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	// get project
+	project := new(entity.Project)
+	if err := c.ProjectRepository.FindByIDAndUserID(db, project, int(req.ID), int(req.UserID)); err != nil {
+		c.Log.Errorf("Failed to get project: %v", err)
+		return nil, status.Error(codes.NotFound, "Project not found")
+	}
+
+	// delete project, files cascade and its chats are moved out of the project
+	if err := c.ProjectRepository.Delete(db, project); err != nil {
+		c.Log.Errorf("Failed to delete project: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while deleting project")
+	}
+
+	return &proto.Empty{}, nil
+}
+
+func toProjectProto(project *entity.Project) *proto.Project {
+	res := &proto.Project{
+		Id:     int64(project.ID),
+		UserId: int64(project.UserID),
+		Name:   project.Name,
+		Files:  make([]*proto.ProjectFile, 0),
+	}
+
+	if project.Instructions != nil {
+		res.Instructions = *project.Instructions
+	}
+
+	for _, file := range project.Files {
+		res.Files = append(res.Files, &proto.ProjectFile{
+			Id:      int64(file.ID),
+			Name:    file.Name,
+			Content: file.Content,
+		})
+	}
+
+	return res
+}
+
+func toProjectFileDtos(files []*proto.ProjectFile) []dto.ProjectFile {
+	res := make([]dto.ProjectFile, 0, len(files))
+	for _, file := range files {
+		res = append(res, dto.ProjectFile{
+			Name:    file.GetName(),
+			Content: file.GetContent(),
+		})
+	}
+
+	return res
+}
+
+func toProjectFileEntities(files []dto.ProjectFile) []entity.ProjectFile {
+	res := make([]entity.ProjectFile, 0, len(files))
+	for _, file := range files {
+		res = append(res, entity.ProjectFile{
+			Name:    file.Name,
+			Content: file.Content,
+		})
+	}
+
+	return res
+}
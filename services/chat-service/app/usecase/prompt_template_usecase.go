@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"auth-service/app/delivery/client"
+	"auth-service/app/model/dto"
+	"auth-service/app/model/entity"
+	"auth-service/app/model/proto"
+	"auth-service/app/repository"
+	"context"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+type PromptTemplateUseCase interface {
+	CreatePromptTemplate(ctx context.Context, cpr *proto.CreatePromptTemplateRequest) (*proto.PromptTemplate, error)
+	GetPromptTemplates(ctx context.Context, gpr *proto.GetPromptTemplatesRequest) (*proto.GetPromptTemplatesResponse, error)
+	UpdatePromptTemplate(ctx context.Context, upr *proto.UpdatePromptTemplateRequest) (*proto.PromptTemplate, error)
+	DeletePromptTemplate(ctx context.Context, dpr *proto.DeletePromptTemplateRequest) (*proto.Empty, error)
+}
+
+type PromptTemplateUseCaseImpl struct {
+	DB                       	*gorm.DB
+	PromptTemplateRepository 	repository.PromptTemplateRepository
+	UserClient               	*client.UserClient
+	Log                      	*logrus.Logger
+	Validate                 	*validator.Validate
+}
+
+func NewPromptTemplateUseCaseImpl(
+	db *gorm.DB,
+	promptTemplateRepository repository.PromptTemplateRepository,
+	userClient *client.UserClient,
+	log *logrus.Logger,
+	validate *validator.Validate,
+) *PromptTemplateUseCaseImpl {
+	return &PromptTemplateUseCaseImpl{
+		DB:                       db,
+		PromptTemplateRepository: promptTemplateRepository,
+		UserClient:               userClient,
+		Log:                      log,
+		Validate:                 validate,
+	}
+}
+
+func (c *PromptTemplateUseCaseImpl) CreatePromptTemplate(ctx context.Context, cpr *proto.CreatePromptTemplateRequest) (*proto.PromptTemplate, error) {
+	// create request
+	req := &dto.CreatePromptTemplateRequest{
+		UserID:         uint(cpr.GetUserId()),
+		OrganizationID: uint(cpr.GetOrganizationId()),
+		Name:           cpr.GetName(),
+		Content:        cpr.GetContent(),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, cpr); err != nil {
+		return nil, err
+	}
+
+	organization, err := organizationMembership(ctx, c.UserClient, c.Log, req.UserID, req.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	promptTemplate := &entity.PromptTemplate{
+		UserID:  req.UserID,
+		Name:    req.Name,
+		Content: req.Content,
+	}
+	if organization != nil {
+		organizationID := uint(organization.GetId())
+		promptTemplate.OrganizationID = &organizationID
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	// create prompt template
+	if err := c.PromptTemplateRepository.Create(db, promptTemplate); err != nil {
+		c.Log.Errorf("Failed to create prompt template: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while creating prompt template")
+	}
+
+	return toPromptTemplateProto(promptTemplate), nil
+}
+
+func (c *PromptTemplateUseCaseImpl) GetPromptTemplates(ctx context.Context, gpr *proto.GetPromptTemplatesRequest) (*proto.GetPromptTemplatesResponse, error) {
+	// create request
+	req := &dto.GetPromptTemplatesRequest{
+		UserID:         uint(gpr.GetUserId()),
+		OrganizationID: uint(gpr.GetOrganizationId()),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, gpr); err != nil {
+		return nil, err
+	}
+
+	organization, err := organizationMembership(ctx, c.UserClient, c.Log, req.UserID, req.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	// get prompt templates, every member sees the templates of the organization
+	promptTemplates := new([]entity.PromptTemplate)
+	if organization != nil {
+		err = c.PromptTemplateRepository.FindAllByOrganizationID(db, promptTemplates, int(organization.GetId()))
+	} else {
+		err = c.PromptTemplateRepository.FindAllPersonalByUserID(db, promptTemplates, int(req.UserID))
+	}
+	if err != nil {
+		c.Log.Errorf("Failed to get prompt templates: %v", err)
+		return nil, status.Error(codes.NotFound, "Prompt templates not found")
+	}
+
+	// create response
+	res := &proto.GetPromptTemplatesResponse{
+		PromptTemplates: make([]*proto.PromptTemplate, 0),
+	}
+
+	for i := range *promptTemplates {
+		res.PromptTemplates = append(res.PromptTemplates, toPromptTemplateProto(&(*promptTemplates)[i]))
+	}
+
+	return res, nil
+}
+
+func (c *PromptTemplateUseCaseImpl) UpdatePromptTemplate(ctx context.Context, upr *proto.UpdatePromptTemplateRequest) (*proto.PromptTemplate, error) {
+	// create request
+	req := &dto.UpdatePromptTemplateRequest{
+		ID:      uint(upr.GetId()),
+		UserID:  uint(upr.GetUserId()),
+		Name:    upr.GetName(),
+		Content: upr.GetContent(),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, upr); err != nil {
+		return nil, err
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	// only the author edits the template, in an organization too
+	promptTemplate := new(entity.PromptTemplate)
+	if err := c.PromptTemplateRepository.FindByIDAndUserID(db, promptTemplate, int(req.ID), int(req.UserID)); err != nil {
+		c.Log.Errorf("Failed to get prompt template: %v", err)
+		return nil, status.Error(codes.NotFound, "Prompt template not found")
+	}
+
+	promptTemplate.Name = req.Name
+	promptTemplate.Content = req.Content
+
+	// update prompt template
+	if err := c.PromptTemplateRepository.Update(db, promptTemplate); err != nil {
+		c.Log.Errorf("Failed to update prompt template: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while updating prompt template")
+	}
+
+	return toPromptTemplateProto(promptTemplate), nil
+}
+
+func (c *PromptTemplateUseCaseImpl) DeletePromptTemplate(ctx context.Context, dpr *proto.DeletePromptTemplateRequest) (*proto.Empty, error) {
+	// create request
+	req := &dto.GetPromptTemplateRequest{
+		ID:     uint(dpr.GetId()),
+		UserID: uint(dpr.GetUserId()),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, dpr); err != nil {
+		return nil, err
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	// get prompt template
+	promptTemplate := new(entity.PromptTemplate)
+	if err := c.PromptTemplateRepository.FindByIDAndUserID(db, promptTemplate, int(req.ID), int(req.UserID)); err != nil {
+		c.Log.Errorf("Failed to get prompt template: %v", err)
+		return nil, status.Error(codes.NotFound, "Prompt template not found")
+	}
+
+	// delete prompt template
+	if err := c.PromptTemplateRepository.Delete(db, promptTemplate); err != nil {
+		c.Log.Errorf("Failed to delete prompt template: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while deleting prompt template")
+	}
+
+	return &proto.Empty{}, nil
+}
+
+func toPromptTemplateProto(promptTemplate *entity.PromptTemplate) *proto.PromptTemplate {
+	return &proto.PromptTemplate{
+		Id:             int64(promptTemplate.ID),
+		UserId:         int64(promptTemplate.UserID),
+		OrganizationId: nullableID(promptTemplate.OrganizationID),
+		Name:           promptTemplate.Name,
+		Content:        promptTemplate.Content,
+	}
+}
@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"auth-service/app/delivery/client"
+	"auth-service/app/model/dto"
+	"auth-service/app/model/entity"
+	"auth-service/app/model/proto"
+	"auth-service/app/repository"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+const (
+	// permissionReportsRead is granted by the role of the user in the user service
+	permissionReportsRead = "reports:read"
+	topUsersLimit         = 10
+)
+
+type ReportUseCase interface {
+	GetUsageReport(ctx context.Context, grr *proto.GetReportRequest) (*proto.UsageReport, error)
+	GetFeedbackReport(ctx context.Context, grr *proto.GetReportRequest) (*proto.FeedbackReport, error)
+}
+
+type ReportUseCaseImpl struct {
+	DB               	*gorm.DB
+	ReportRepository 	repository.ReportRepository
+	UserClient       	*client.UserClient
+	Log              	*logrus.Logger
+	Validate         	*validator.Validate
+}
+
+func NewReportUseCaseImpl(
+	db *gorm.DB,
+	reportRepository repository.ReportRepository,
+	userClient *client.UserClient,
+	log *logrus.Logger,
+	validate *validator.Validate,
+) *ReportUseCaseImpl {
+	return &ReportUseCaseImpl{
+		DB:               db,
+		ReportRepository: reportRepository,
+		UserClient:       userClient,
+		Log:              log,
+		Validate:         validate,
+	}
+}
+
+func (c *ReportUseCaseImpl) GetUsageReport(ctx context.Context, grr *proto.GetReportRequest) (*proto.UsageReport, error) {
+	// create request
+	req := &dto.GetReportRequest{
+		UserID: uint(grr.GetUserId()),
+		Since:  grr.GetSince(),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, grr); err != nil {
+		return nil, err
+	}
+
+	// reports are checked here too, the gateway is not the only caller
+	if err := c.authorize(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	db := c.DB.WithContext(ctx)
+	since := time.Unix(req.Since, 0)
+
+	totals := new(entity.UsageTotals)
+	if err := c.ReportRepository.UsageTotals(db, totals, since); err != nil {
+		c.Log.Errorf("Failed to get usage totals: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while getting usage report")
+	}
+
+	var days []entity.UsageDay
+	if err := c.ReportRepository.UsagePerDay(db, &days, since); err != nil {
+		c.Log.Errorf("Failed to get usage per day: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while getting usage report")
+	}
+
+	var users []entity.UserUsage
+	if err := c.ReportRepository.TopUsers(db, &users, since, topUsersLimit); err != nil {
+		c.Log.Errorf("Failed to get top users: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while getting usage report")
+	}
+
+	res := &proto.UsageReport{
+		Messages:    totals.Messages,
+		Prompts:     totals.Prompts,
+		Replies:     totals.Replies,
+		Chats:       totals.Chats,
+		ActiveUsers: totals.ActiveUsers,
+		Days:        make([]*proto.UsageDay, 0, len(days)),
+		TopUsers:    make([]*proto.UserUsage, 0, len(users)),
+	}
+	for _, day := range days {
+		res.Days = append(res.Days, &proto.UsageDay{
+			Date:        day.Day.Format(time.DateOnly),
+			Messages:    day.Messages,
+			ActiveUsers: day.ActiveUsers,
+		})
+	}
+	for _, user := range users {
+		res.TopUsers = append(res.TopUsers, &proto.UserUsage{
+			UserId:  int64(user.UserID),
+			Prompts: user.Prompts,
+		})
+	}
+
+	return res, nil
+}
+
+func (c *ReportUseCaseImpl) GetFeedbackReport(ctx context.Context, grr *proto.GetReportRequest) (*proto.FeedbackReport, error) {
+	// create request
+	req := &dto.GetReportRequest{
+		UserID: uint(grr.GetUserId()),
+		Since:  grr.GetSince(),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, grr); err != nil {
+		return nil, err
+	}
+
+	if err := c.authorize(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	db := c.DB.WithContext(ctx)
+	since := time.Unix(req.Since, 0)
+
+	var comparisons int64
+	if err := c.ReportRepository.CountComparisons(db, &comparisons, since); err != nil {
+		c.Log.Errorf("Failed to count comparisons: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while getting feedback report")
+	}
+
+	var preferences int64
+	if err := c.ReportRepository.CountPreferences(db, &preferences, since); err != nil {
+		c.Log.Errorf("Failed to count preferences: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while getting feedback report")
+	}
+
+	var models []entity.ModelFeedback
+	if err := c.ReportRepository.ModelFeedback(db, &models, since); err != nil {
+		c.Log.Errorf("Failed to get model feedback: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while getting feedback report")
+	}
+
+	res := &proto.FeedbackReport{
+		Comparisons: comparisons,
+		Preferences: preferences,
+		Models:      make([]*proto.ModelFeedback, 0, len(models)),
+	}
+	for _, model := range models {
+		res.Models = append(res.Models, &proto.ModelFeedback{
+			Model:     model.Model,
+			Shown:     model.Shown,
+			Preferred: model.Preferred,
+		})
+	}
+
+	return res, nil
+}
+
+// authorize asks the user service for the current role of the caller, so a
+// demoted or suspended user loses access before its tokens expire
+func (c *ReportUseCaseImpl) authorize(ctx context.Context, userID uint) error {
+	getUserCtx, cancelGetUser := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetUser()
+
+	user, err := c.UserClient.Service.GetUserByID(getUserCtx, &proto.GetUserByIDRequest{
+		Id: int64(userID),
+	})
+	if err != nil {
+		c.Log.Errorf("Failed to get user: %v", err)
+		return status.Error(codes.PermissionDenied, "Permission denied")
+	}
+
+	if user.GetSuspended() || !slices.Contains(user.GetPermissions(), permissionReportsRead) {
+		return status.Error(codes.PermissionDenied, "Permission denied")
+	}
+
+	return nil
+}
\ No newline at end of file
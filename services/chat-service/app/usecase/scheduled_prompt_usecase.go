@@ -0,0 +1,400 @@
+package usecase
+
+import (
+	"auth-service/app/model/dto"
+	"auth-service/app/model/entity"
+	"auth-service/app/model/proto"
+	"auth-service/app/repository"
+	"context"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/robfig/cron/v3"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+const (
+	CatchUpSkip    = "skip"
+	CatchUpRunOnce = "run_once"
+)
+
+type ScheduledPromptUseCase interface {
+	CreateScheduledPrompt(ctx context.Context, csr *proto.CreateScheduledPromptRequest) (*proto.ScheduledPrompt, error)
+	GetScheduledPrompts(ctx context.Context, gsr *proto.GetScheduledPromptsRequest) (*proto.GetScheduledPromptsResponse, error)
+	GetScheduledPrompt(ctx context.Context, gsr *proto.GetScheduledPromptRequest) (*proto.ScheduledPrompt, error)
+	UpdateScheduledPrompt(ctx context.Context, usr *proto.UpdateScheduledPromptRequest) (*proto.ScheduledPrompt, error)
+	DeleteScheduledPrompt(ctx context.Context, dsr *proto.DeleteScheduledPromptRequest) (*proto.Empty, error)
+	GetScheduledPromptRuns(ctx context.Context, gsr *proto.GetScheduledPromptRunsRequest) (*proto.GetScheduledPromptRunsResponse, error)
+}
+
+type ScheduledPromptUseCaseImpl struct {
+	DB                        	*gorm.DB
+	ScheduledPromptRepository 	repository.ScheduledPromptRepository
+	ChatRepository            	repository.ChatRepository
+	ChatMemberRepository      	repository.ChatMemberRepository
+	Log                       	*logrus.Logger
+	Validate                  	*validator.Validate
+}
+
+func NewScheduledPromptUseCaseImpl(
+	db *gorm.DB,
+	scheduledPromptRepository repository.ScheduledPromptRepository,
+	chatRepository repository.ChatRepository,
+	chatMemberRepository repository.ChatMemberRepository,
+	log *logrus.Logger,
+	validate *validator.Validate,
+) *ScheduledPromptUseCaseImpl {
+	return &ScheduledPromptUseCaseImpl{
+		DB:                        db,
+		ScheduledPromptRepository: scheduledPromptRepository,
+		ChatRepository:            chatRepository,
+		ChatMemberRepository:      chatMemberRepository,
+		Log:                       log,
+		Validate:                  validate,
+	}
+}
+
+func (c *ScheduledPromptUseCaseImpl) CreateScheduledPrompt(ctx context.Context, csr *proto.CreateScheduledPromptRequest) (*proto.ScheduledPrompt, error) {
+	// create request
+	req := &dto.CreateScheduledPromptRequest{
+		UserID:         uint(csr.GetUserId()),
+		Name:           csr.GetName(),
+		Prompt:         csr.GetPrompt(),
+		CronExpression: csr.GetCronExpression(),
+		Timezone:       csr.GetTimezone(),
+		ChatID:         uint(csr.GetChatId()),
+		CatchUpPolicy:  csr.GetCatchUpPolicy(),
+		Enabled:        csr.GetEnabled(),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, csr); err != nil {
+		return nil, err
+	}
+
+	scheduledPrompt := &entity.ScheduledPrompt{
+		UserID:         req.UserID,
+		Name:           req.Name,
+		Prompt:         req.Prompt,
+		CronExpression: req.CronExpression,
+		Timezone:       timezoneOrDefault(req.Timezone),
+		CatchUpPolicy:  catchUpPolicyOrDefault(req.CatchUpPolicy),
+		Enabled:        req.Enabled,
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	if err := c.applyTarget(db, scheduledPrompt, req.ChatID); err != nil {
+		return nil, err
+	}
+
+	if err := scheduleNextRun(scheduledPrompt, time.Now()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid schedule: %v", err)
+	}
+
+	// create scheduled prompt
+	if err := c.ScheduledPromptRepository.Create(db, scheduledPrompt); err != nil {
+		c.Log.Errorf("Failed to create scheduled prompt: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while creating scheduled prompt")
+	}
+
+	return toScheduledPromptProto(scheduledPrompt), nil
+}
+
+func (c *ScheduledPromptUseCaseImpl) GetScheduledPrompts(ctx context.Context, gsr *proto.GetScheduledPromptsRequest) (*proto.GetScheduledPromptsResponse, error) {
+	// create request
+	req := &dto.GetScheduledPromptsRequest{
+		UserID: uint(gsr.GetUserId()),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, gsr); err != nil {
+		return nil, err
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	// get scheduled prompts
+	scheduledPrompts := new([]entity.ScheduledPrompt)
+	if err := c.ScheduledPromptRepository.FindAllByUserID(db, scheduledPrompts, int(req.UserID)); err != nil {
+		c.Log.Errorf("Failed to get scheduled prompts: %v", err)
+		return nil, status.Error(codes.NotFound, "Scheduled prompts not found")
+	}
+
+	// create response
+	res := &proto.GetScheduledPromptsResponse{
+		ScheduledPrompts: make([]*proto.ScheduledPrompt, 0),
+	}
+
+	for i := range *scheduledPrompts {
+		res.ScheduledPrompts = append(res.ScheduledPrompts, toScheduledPromptProto(&(*scheduledPrompts)[i]))
+	}
+
+	return res, nil
+}
+
+func (c *ScheduledPromptUseCaseImpl) GetScheduledPrompt(ctx context.Context, gsr *proto.GetScheduledPromptRequest) (*proto.ScheduledPrompt, error) {
+	// create request
+	req := &dto.GetScheduledPromptRequest{
+		ID:     uint(gsr.GetId()),
+		UserID: uint(gsr.GetUserId()),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, gsr); err != nil {
+		return nil, err
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	// get scheduled prompt
+	scheduledPrompt := new(entity.ScheduledPrompt)
+	if err := c.ScheduledPromptRepository.FindByIDAndUserID(db, scheduledPrompt, int(req.ID), int(req.UserID)); err != nil {
+		c.Log.Errorf("Failed to get scheduled prompt: %v", err)
+		return nil, status.Error(codes.NotFound, "Scheduled prompt not found")
+	}
+
+	return toScheduledPromptProto(scheduledPrompt), nil
+}
+
+func (c *ScheduledPromptUseCaseImpl) UpdateScheduledPrompt(ctx context.Context, usr *proto.UpdateScheduledPromptRequest) (*proto.ScheduledPrompt, error) {
+	// create request
+	req := &dto.UpdateScheduledPromptRequest{
+		ID:             uint(usr.GetId()),
+		UserID:         uint(usr.GetUserId()),
+		Name:           usr.GetName(),
+		Prompt:         usr.GetPrompt(),
+		CronExpression: usr.GetCronExpression(),
+		Timezone:       usr.GetTimezone(),
+		ChatID:         uint(usr.GetChatId()),
+		CatchUpPolicy:  usr.GetCatchUpPolicy(),
+		Enabled:        usr.GetEnabled(),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, usr); err != nil {
+		return nil, err
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	// get scheduled prompt
+	scheduledPrompt := new(entity.ScheduledPrompt)
+	if err := c.ScheduledPromptRepository.FindByIDAndUserID(db, scheduledPrompt, int(req.ID), int(req.UserID)); err != nil {
+		c.Log.Errorf("Failed to get scheduled prompt: %v", err)
+		return nil, status.Error(codes.NotFound, "Scheduled prompt not found")
+	}
+
+	scheduledPrompt.Name = req.Name
+	scheduledPrompt.Prompt = req.Prompt
+	scheduledPrompt.CronExpression = req.CronExpression
+	scheduledPrompt.Timezone = timezoneOrDefault(req.Timezone)
+	scheduledPrompt.CatchUpPolicy = catchUpPolicyOrDefault(req.CatchUpPolicy)
+	scheduledPrompt.Enabled = req.Enabled
+
+	if err := c.applyTarget(db, scheduledPrompt, req.ChatID); err != nil {
+		return nil, err
+	}
+
+	// the schedule may have changed, the next run is computed again from now
+	if err := scheduleNextRun(scheduledPrompt, time.Now()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid schedule: %v", err)
+	}
+
+	// update scheduled prompt
+	if err := c.ScheduledPromptRepository.Update(db, scheduledPrompt); err != nil {
+		c.Log.Errorf("Failed to update scheduled prompt: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while updating scheduled prompt")
+	}
+
+	return toScheduledPromptProto(scheduledPrompt), nil
+}
+
+func (c *ScheduledPromptUseCaseImpl) DeleteScheduledPrompt(ctx context.Context, dsr *proto.DeleteScheduledPromptRequest) (*proto.Empty, error) {
+	// create request
+	req := &dto.GetScheduledPromptRequest{
+		ID:     uint(dsr.GetId()),
+		UserID: uint(dsr.GetUserId()),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, dsr); err != nil {
+		return nil, err
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	// get scheduled prompt
+	scheduledPrompt := new(entity.ScheduledPrompt)
+	if err := c.ScheduledPromptRepository.FindByIDAndUserID(db, scheduledPrompt, int(req.ID), int(req.UserID)); err != nil {
+		c.Log.Errorf("Failed to get scheduled prompt: %v", err)
+		return nil, status.Error(codes.NotFound, "Scheduled prompt not found")
+	}
+
+	// delete scheduled prompt, its run history cascades
+	if err := c.ScheduledPromptRepository.Delete(db, scheduledPrompt); err != nil {
+		c.Log.Errorf("Failed to delete scheduled prompt: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while deleting scheduled prompt")
+	}
+
+	return &proto.Empty{}, nil
+}
+
+func (c *ScheduledPromptUseCaseImpl) GetScheduledPromptRuns(ctx context.Context, gsr *proto.GetScheduledPromptRunsRequest) (*proto.GetScheduledPromptRunsResponse, error) {
+	// create request
+	req := &dto.GetScheduledPromptRequest{
+		ID:     uint(gsr.GetId()),
+		UserID: uint(gsr.GetUserId()),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, gsr); err != nil {
+		return nil, err
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	// get scheduled prompt
+	scheduledPrompt := new(entity.ScheduledPrompt)
+	if err := c.ScheduledPromptRepository.FindByIDAndUserID(db, scheduledPrompt, int(req.ID), int(req.UserID)); err != nil {
+		c.Log.Errorf("Failed to get scheduled prompt: %v", err)
+		return nil, status.Error(codes.NotFound, "Scheduled prompt not found")
+	}
+
+	// get runs
+	runs := new([]entity.ScheduledPromptRun)
+	if err := c.ScheduledPromptRepository.FindAllRunsByScheduledPromptID(db, runs, int(scheduledPrompt.ID)); err != nil {
+		c.Log.Errorf("Failed to get scheduled prompt runs: %v", err)
+		return nil, status.Error(codes.NotFound, "Runs not found")
+	}
+
+	// create response
+	res := &proto.GetScheduledPromptRunsResponse{
+		Runs: make([]*proto.ScheduledPromptRun, 0),
+	}
+
+	for i := range *runs {
+		res.Runs = append(res.Runs, toScheduledPromptRunProto(&(*runs)[i]))
+	}
+
+	return res, nil
+}
+
+// applyTarget points the scheduled prompt at an existing chat the user may
+// write to, or at a new chat per run when chatID is 0.
+func (c *ScheduledPromptUseCaseImpl) applyTarget(tx *gorm.DB, scheduledPrompt *entity.ScheduledPrompt, chatID uint) error {
+	scheduledPrompt.ChatID = nil
+	if chatID == 0 {
+		return nil
+	}
+
+	chat := new(entity.Chat)
+	if err := c.ChatRepository.FindByID(tx, chat, int(chatID)); err != nil {
+		c.Log.Errorf("Failed to get chat: %v", err)
+		return status.Error(codes.NotFound, "Chat not found")
+	}
+
+	role, err := chatRole(tx, c.ChatMemberRepository, chat, scheduledPrompt.UserID, 0)
+	if err != nil {
+		return status.Error(codes.NotFound, "Chat not found")
+	}
+	if !canWrite(role) {
+		return status.Error(codes.PermissionDenied, "Viewers cannot send messages")
+	}
+
+	scheduledPrompt.ChatID = &chat.ID
+	return nil
+}
+
+// parseSchedule parses a standard five field cron expression in the given timezone.
+func parseSchedule(expression string, timezone string) (cron.Schedule, *time.Location, error) {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	schedule, err := cron.ParseStandard(expression)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return schedule, location, nil
+}
+
+// scheduleNextRun sets the next run after now, disabled prompts have none.
+func scheduleNextRun(scheduledPrompt *entity.ScheduledPrompt, now time.Time) error {
+	schedule, location, err := parseSchedule(scheduledPrompt.CronExpression, scheduledPrompt.Timezone)
+	if err != nil {
+		return err
+	}
+
+	scheduledPrompt.NextRunAt = nil
+	if scheduledPrompt.Enabled {
+		next := schedule.Next(now.In(location)).UTC()
+		scheduledPrompt.NextRunAt = &next
+	}
+
+	return nil
+}
+
+func timezoneOrDefault(timezone string) string {
+	if timezone == "" {
+		return "UTC"
+	}
+
+	return timezone
+}
+
+func catchUpPolicyOrDefault(policy string) string {
+	if policy == "" {
+		return CatchUpSkip
+	}
+
+	return policy
+}
+
+// unixOrZero converts an optional time to unix seconds, where 0 means unset.
+func unixOrZero(t *time.Time) int64 {
+	if t == nil {
+		return 0
+	}
+
+	return t.Unix()
+}
+
+func toScheduledPromptProto(scheduledPrompt *entity.ScheduledPrompt) *proto.ScheduledPrompt {
+	return &proto.ScheduledPrompt{
+		Id:             int64(scheduledPrompt.ID),
+		UserId:         int64(scheduledPrompt.UserID),
+		Name:           scheduledPrompt.Name,
+		Prompt:         scheduledPrompt.Prompt,
+		CronExpression: scheduledPrompt.CronExpression,
+		Timezone:       scheduledPrompt.Timezone,
+		ChatId:         nullableID(scheduledPrompt.ChatID),
+		CatchUpPolicy:  scheduledPrompt.CatchUpPolicy,
+		Enabled:        scheduledPrompt.Enabled,
+		NextRunAt:      unixOrZero(scheduledPrompt.NextRunAt),
+		LastRunAt:      unixOrZero(scheduledPrompt.LastRunAt),
+	}
+}
+
+func toScheduledPromptRunProto(run *entity.ScheduledPromptRun) *proto.ScheduledPromptRun {
+	res := &proto.ScheduledPromptRun{
+		Id:                int64(run.ID),
+		ScheduledPromptId: int64(run.ScheduledPromptID),
+		ChatId:            nullableID(run.ChatID),
+		Status:            run.Status,
+		ScheduledFor:      run.ScheduledFor.Unix(),
+		StartedAt:         unixOrZero(run.StartedAt),
+		FinishedAt:        unixOrZero(run.FinishedAt),
+	}
+
+	if run.Error != nil {
+		res.Error = *run.Error
+	}
+
+	return res
+}
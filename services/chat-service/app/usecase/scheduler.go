@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"auth-service/app/model/entity"
+	"auth-service/app/model/proto"
+	"auth-service/app/repository"
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// schedulerLockKey identifies the advisory lock held by the replica that
+// claims due runs, so only one replica fires each scheduled prompt.
+const schedulerLockKey int64 = 20261019030
+
+const (
+	RunPending   = "pending"
+	RunRunning   = "running"
+	RunSucceeded = "succeeded"
+	RunFailed    = "failed"
+	RunSkipped   = "skipped"
+)
+
+type Scheduler struct {
+	DB                        	*gorm.DB
+	ScheduledPromptRepository 	repository.ScheduledPromptRepository
+	MessageUseCase            	*MessageUseCaseImpl
+	Log                       	*logrus.Logger
+	Interval                  	time.Duration
+	MissedGrace               	time.Duration
+}
+
+type claimedRun struct {
+	run             *entity.ScheduledPromptRun
+	scheduledPrompt entity.ScheduledPrompt
+}
+
+func NewScheduler(
+	db *gorm.DB,
+	scheduledPromptRepository repository.ScheduledPromptRepository,
+	messageUseCase *MessageUseCaseImpl,
+	log *logrus.Logger,
+	config *viper.Viper,
+) *Scheduler {
+	interval := config.GetDuration("SCHEDULER_INTERVAL")
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	missedGrace := config.GetDuration("SCHEDULER_MISSED_GRACE")
+	if missedGrace <= 0 {
+		missedGrace = 5 * time.Minute
+	}
+
+	return &Scheduler{
+		DB:                        db,
+		ScheduledPromptRepository: scheduledPromptRepository,
+		MessageUseCase:            messageUseCase,
+		Log:                       log,
+		Interval:                  interval,
+		MissedGrace:               missedGrace,
+	}
+}
+
+// Start runs due scheduled prompts on every tick until the context is done.
+func (s *Scheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			claimed, err := s.claim(ctx, time.Now().UTC())
+			if err != nil {
+				s.Log.Errorf("Failed to claim scheduled prompts: %v", err)
+				continue
+			}
+
+			for _, c := range claimed {
+				s.execute(ctx, c)
+			}
+		}
+	}
+}
+
+// claim records a run for every due scheduled prompt and advances its next
+// run. Only the replica holding the advisory lock claims anything, the lock
+// is released when the transaction ends.
+func (s *Scheduler) claim(ctx context.Context, now time.Time) ([]claimedRun, error) {
+	tx := s.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	locked, err := s.ScheduledPromptRepository.TryAdvisoryLock(tx, schedulerLockKey)
+	if err != nil || !locked {
+		return nil, err
+	}
+
+	scheduledPrompts := new([]entity.ScheduledPrompt)
+	if err := s.ScheduledPromptRepository.FindAllDue(tx, scheduledPrompts, now); err != nil {
+		return nil, err
+	}
+
+	claimed := make([]claimedRun, 0)
+	for _, scheduledPrompt := range *scheduledPrompts {
+		scheduledFor := *scheduledPrompt.NextRunAt
+
+		run := &entity.ScheduledPromptRun{
+			ScheduledPromptID: scheduledPrompt.ID,
+			ChatID:            scheduledPrompt.ChatID,
+			Status:            RunPending,
+			ScheduledFor:      scheduledFor,
+		}
+
+		// A slot older than the grace period was missed, e.g. while every replica was down
+		missed := now.Sub(scheduledFor) > s.MissedGrace
+		if missed && scheduledPrompt.CatchUpPolicy != CatchUpRunOnce {
+			run.Status = RunSkipped
+			run.FinishedAt = &now
+		}
+
+		if err := s.ScheduledPromptRepository.CreateRun(tx, run); err != nil {
+			return nil, err
+		}
+
+		// Later missed slots collapse into this run, the next one is after now
+		if err := scheduleNextRun(&scheduledPrompt, now); err != nil {
+			s.Log.Errorf("Failed to schedule prompt %d, disabling it: %v", scheduledPrompt.ID, err)
+			scheduledPrompt.Enabled = false
+			scheduledPrompt.NextRunAt = nil
+		}
+		scheduledPrompt.LastRunAt = &scheduledFor
+
+		if err := s.ScheduledPromptRepository.Update(tx, &scheduledPrompt); err != nil {
+			return nil, err
+		}
+
+		if run.Status == RunPending {
+			claimed = append(claimed, claimedRun{run: run, scheduledPrompt: scheduledPrompt})
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	return claimed, nil
+}
+
+// execute sends the prompt of a claimed run and records its outcome.
+func (s *Scheduler) execute(ctx context.Context, c claimedRun) {
+	db := s.DB.WithContext(ctx)
+
+	startedAt := time.Now().UTC()
+	c.run.Status = RunRunning
+	c.run.StartedAt = &startedAt
+	if err := s.ScheduledPromptRepository.UpdateRun(db, c.run); err != nil {
+		s.Log.Errorf("Failed to start scheduled prompt run %d: %v", c.run.ID, err)
+		return
+	}
+
+	// Prompts of deleted accounts stay until the account is purged, their runs are skipped meanwhile
+	if s.userDeleted(ctx, c.scheduledPrompt.UserID) {
+		finishedAt := time.Now().UTC()
+		message := "The account of the user is deleted"
+		c.run.Status = RunSkipped
+		c.run.FinishedAt = &finishedAt
+		c.run.Error = &message
+		if err := s.ScheduledPromptRepository.UpdateRun(db, c.run); err != nil {
+			s.Log.Errorf("Failed to skip scheduled prompt run %d: %v", c.run.ID, err)
+		}
+		return
+	}
+
+	chat, err := s.MessageUseCase.replyInChat(ctx, c.scheduledPrompt.UserID, c.scheduledPrompt.ChatID, c.scheduledPrompt.Prompt)
+
+	finishedAt := time.Now().UTC()
+	c.run.FinishedAt = &finishedAt
+	if err != nil {
+		message := err.Error()
+		c.run.Status = RunFailed
+		c.run.Error = &message
+		s.Log.Errorf("Scheduled prompt run %d failed: %v", c.run.ID, err)
+	} else {
+		c.run.Status = RunSucceeded
+		c.run.ChatID = &chat.ID
+	}
+
+	if err := s.ScheduledPromptRepository.UpdateRun(db, c.run); err != nil {
+		s.Log.Errorf("Failed to finish scheduled prompt run %d: %v", c.run.ID, err)
+	}
+}
+
+// userDeleted reports whether the user service no longer knows the user, other errors let the run go on
+func (s *Scheduler) userDeleted(ctx context.Context, userID uint) bool {
+	getUserCtx, cancelGetUser := context.WithTimeout(ctx, 2 * time.Second)
+	defer cancelGetUser()
+
+	_, err := s.MessageUseCase.UserClient.Service.GetUserByID(getUserCtx, &proto.GetUserByIDRequest{
+		Id: int64(userID),
+	})
+
+	return status.Code(err) == codes.NotFound
+}
\ No newline at end of file
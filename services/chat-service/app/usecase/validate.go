@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"auth-service/app/helper"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// validate checks the request and reports failures as an InvalidArgument
+// status carrying the original proto message as details.
+func validate(v *validator.Validate, log *logrus.Logger, req interface{}, details protoadapt.MessageV1) error {
+	if errors := helper.Validate(v, req); len(errors) > 0 {
+		err := status.Newf(codes.InvalidArgument, "Validation errors: %v", errors)
+
+		err, wde := err.WithDetails(details)
+		if wde != nil {
+			log.Errorf("An error occurred while adding details to error: %v", wde)
+			return status.Error(codes.Internal, "An error occurred while adding details to error")
+		}
+
+		return err.Err()
+	}
+
+	return nil
+}
@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"auth-service/app/helper"
+	"auth-service/app/model/entity"
+	"auth-service/app/repository"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+// errWebhookUnreachable is recorded for every transport failure, the cause is only
+// logged so deliveries cannot be used to probe the network of the service
+var errWebhookUnreachable = errors.New("could not reach the webhook endpoint")
+
+// WebhookDispatcher drains the webhook outbox, retrying failed deliveries with
+// exponential backoff until they succeed or run out of attempts.
+type WebhookDispatcher struct {
+	DB                	*gorm.DB
+	WebhookRepository 	repository.WebhookRepository
+	Log               	*logrus.Logger
+	Client            	*http.Client
+	Interval          	time.Duration
+	BatchSize         	int
+	MaxAttempts       	int32
+	BaseBackoff       	time.Duration
+	MaxBackoff        	time.Duration
+}
+
+func NewWebhookDispatcher(
+	db *gorm.DB,
+	webhookRepository repository.WebhookRepository,
+	log *logrus.Logger,
+	config *viper.Viper,
+) *WebhookDispatcher {
+	interval := config.GetDuration("WEBHOOK_DISPATCH_INTERVAL")
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	timeout := config.GetDuration("WEBHOOK_TIMEOUT")
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	maxAttempts := config.GetInt32("WEBHOOK_MAX_ATTEMPTS")
+	if maxAttempts <= 0 {
+		maxAttempts = 8
+	}
+
+	return &WebhookDispatcher{
+		DB:                db,
+		WebhookRepository: webhookRepository,
+		Log:               log,
+		Client:            helper.PublicHTTPClient(timeout),
+		Interval:          interval,
+		BatchSize:         20,
+		MaxAttempts:       maxAttempts,
+		BaseBackoff:       30 * time.Second,
+		MaxBackoff:        6 * time.Hour,
+	}
+}
+
+// Start delivers due webhook events on every tick until the context is done.
+func (d *WebhookDispatcher) Start(ctx context.Context) {
+	ticker := time.NewTicker(d.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deliveries, err := d.claim(ctx, time.Now().UTC())
+			if err != nil {
+				d.Log.Errorf("Failed to claim webhook deliveries: %v", err)
+				continue
+			}
+
+			for i := range deliveries {
+				d.deliver(ctx, &deliveries[i])
+			}
+		}
+	}
+}
+
+// claim leases due deliveries by pushing their next attempt past the request
+// timeout, so other replicas skip them while they are being sent.
+func (d *WebhookDispatcher) claim(ctx context.Context, now time.Time) ([]entity.WebhookDelivery, error) {
+	tx := d.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	deliveries := new([]entity.WebhookDelivery)
+	if err := d.WebhookRepository.FindAllDueDeliveries(tx, deliveries, now, d.BatchSize); err != nil {
+		return nil, err
+	}
+
+	for i := range *deliveries {
+		(*deliveries)[i].NextAttemptAt = now.Add(d.Client.Timeout + time.Minute)
+		if err := d.WebhookRepository.UpdateDelivery(tx, &(*deliveries)[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	return *deliveries, nil
+}
+
+// deliver posts the payload once and records the outcome of the attempt.
+func (d *WebhookDispatcher) deliver(ctx context.Context, delivery *entity.WebhookDelivery) {
+	delivery.Attempts++
+
+	responseStatus, err := d.send(ctx, delivery)
+	if responseStatus != 0 {
+		delivery.ResponseStatus = &responseStatus
+	}
+
+	now := time.Now().UTC()
+	if err == nil {
+		delivery.Status = DeliverySucceeded
+		delivery.Error = nil
+		delivery.DeliveredAt = &now
+	} else {
+		message := err.Error()
+		delivery.Error = &message
+
+		if delivery.Attempts >= d.MaxAttempts {
+			delivery.Status = DeliveryFailed
+		} else {
+			delivery.NextAttemptAt = now.Add(d.backoff(delivery.Attempts))
+		}
+	}
+
+	if err := d.WebhookRepository.UpdateDelivery(d.DB.WithContext(ctx), delivery); err != nil {
+		d.Log.Errorf("Failed to update webhook delivery %d: %v", delivery.ID, err)
+	}
+}
+
+func (d *WebhookDispatcher) send(ctx context.Context, delivery *entity.WebhookDelivery) (int32, error) {
+	payload := []byte(delivery.Payload)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	// Webhooks registered before https was required are not delivered
+	target, err := url.Parse(delivery.Webhook.URL)
+	if err != nil || target.Scheme != "https" {
+		return 0, errors.New("webhook URL must use https")
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, target.String(), bytes.NewReader(payload))
+	if err != nil {
+		return 0, errWebhookUnreachable
+	}
+
+	// Receivers verify the signature over "timestamp.payload" with their secret
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Webhook-Event", delivery.Event)
+	request.Header.Set("X-Webhook-Delivery", strconv.FormatUint(uint64(delivery.ID), 10))
+	request.Header.Set("X-Webhook-Timestamp", timestamp)
+	request.Header.Set("X-Webhook-Signature", "sha256="+helper.SignPayload(delivery.Webhook.Secret, timestamp, payload))
+
+	response, err := d.Client.Do(request)
+	if err != nil {
+		d.Log.Warnf("Failed to send webhook delivery %d: %v", delivery.ID, err)
+		return 0, errWebhookUnreachable
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return int32(response.StatusCode), fmt.Errorf("unexpected response status %d", response.StatusCode)
+	}
+
+	return int32(response.StatusCode), nil
+}
+
+// backoff doubles the wait after every failed attempt, up to the maximum.
+func (d *WebhookDispatcher) backoff(attempts int32) time.Duration {
+	wait := d.BaseBackoff
+	for i := int32(1); i < attempts; i++ {
+		wait *= 2
+		if wait >= d.MaxBackoff {
+			return d.MaxBackoff
+		}
+	}
+
+	return wait
+}
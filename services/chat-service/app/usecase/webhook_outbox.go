@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"auth-service/app/model/entity"
+	"auth-service/app/repository"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	EventChatCreated      = "chat.created"
+	EventMessageCompleted = "message.completed"
+	EventChatDeleted      = "chat.deleted"
+	EventFeedbackCreated  = "feedback.created"
+)
+
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+type webhookEvent struct {
+	Event     string      `json:"event"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+type chatEventData struct {
+	ID          uint    `json:"id"`
+	UserID      uint    `json:"user_id"`
+	Topic       *string `json:"topic"`
+	AssistantID *uint   `json:"assistant_id"`
+	ProjectID   *uint   `json:"project_id"`
+}
+
+type messageEventData struct {
+	ChatID    uint   `json:"chat_id"`
+	MessageID uint   `json:"message_id"`
+	UserID    uint   `json:"user_id"`
+	Prompt    string `json:"prompt"`
+	Content   string `json:"content"`
+}
+
+// enqueueWebhookEvent writes a pending delivery for every webhook of the user
+// subscribed to the event. It runs in the transaction of the change itself so
+// the event is recorded if and only if the change is committed.
+func enqueueWebhookEvent(tx *gorm.DB, webhookRepository repository.WebhookRepository, userID uint, event string, data interface{}) error {
+	webhooks := new([]entity.Webhook)
+	if err := webhookRepository.FindAllSubscribed(tx, webhooks, int(userID), event); err != nil {
+		return err
+	}
+	if len(*webhooks) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	payload, err := json.Marshal(&webhookEvent{
+		Event:     event,
+		CreatedAt: now,
+		Data:      data,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, webhook := range *webhooks {
+		delivery := &entity.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			Event:         event,
+			Payload:       string(payload),
+			Status:        DeliveryPending,
+			NextAttemptAt: now,
+		}
+
+		if err := webhookRepository.CreateDelivery(tx, delivery); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func toChatEventData(chat *entity.Chat) *chatEventData {
+	return &chatEventData{
+		ID:          chat.ID,
+		UserID:      chat.UserID,
+		Topic:       chat.Topic,
+		AssistantID: chat.AssistantID,
+		ProjectID:   chat.ProjectID,
+	}
+}
@@ -0,0 +1,363 @@
+package usecase
+
+import (
+	"auth-service/app/helper"
+	"auth-service/app/model/dto"
+	"auth-service/app/model/entity"
+	"auth-service/app/model/proto"
+	"auth-service/app/repository"
+	"context"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+type WebhookUseCase interface {
+	CreateWebhook(ctx context.Context, cwr *proto.CreateWebhookRequest) (*proto.Webhook, error)
+	GetWebhooks(ctx context.Context, gwr *proto.GetWebhooksRequest) (*proto.GetWebhooksResponse, error)
+	GetWebhook(ctx context.Context, gwr *proto.GetWebhookRequest) (*proto.Webhook, error)
+	UpdateWebhook(ctx context.Context, uwr *proto.UpdateWebhookRequest) (*proto.Webhook, error)
+	DeleteWebhook(ctx context.Context, dwr *proto.DeleteWebhookRequest) (*proto.Empty, error)
+	GetWebhookDeliveries(ctx context.Context, gdr *proto.GetWebhookDeliveriesRequest) (*proto.GetWebhookDeliveriesResponse, error)
+	RedeliverWebhookDelivery(ctx context.Context, rdr *proto.RedeliverWebhookDeliveryRequest) (*proto.WebhookDelivery, error)
+}
+
+type WebhookUseCaseImpl struct {
+	DB                	*gorm.DB
+	WebhookRepository 	repository.WebhookRepository
+	Log               	*logrus.Logger
+	Validate          	*validator.Validate
+}
+
+func NewWebhookUseCaseImpl(
+	db *gorm.DB,
+	webhookRepository repository.WebhookRepository,
+	log *logrus.Logger,
+	validate *validator.Validate,
+) *WebhookUseCaseImpl {
+	return &WebhookUseCaseImpl{
+		DB:                db,
+		WebhookRepository: webhookRepository,
+		Log:               log,
+		Validate:          validate,
+	}
+}
+
+func (c *WebhookUseCaseImpl) CreateWebhook(ctx context.Context, cwr *proto.CreateWebhookRequest) (*proto.Webhook, error) {
+	// create request
+	req := &dto.CreateWebhookRequest{
+		UserID:  uint(cwr.GetUserId()),
+		URL:     cwr.GetUrl(),
+		Events:  cwr.GetEvents(),
+		Enabled: cwr.GetEnabled(),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, cwr); err != nil {
+		return nil, err
+	}
+
+	if err := checkWebhookURL(req.URL); err != nil {
+		return nil, err
+	}
+
+	// the secret signs every payload sent to the webhook
+	secret, err := helper.RandomToken(32)
+	if err != nil {
+		c.Log.Errorf("Failed to generate webhook secret: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while creating webhook")
+	}
+
+	webhook := &entity.Webhook{
+		UserID:  req.UserID,
+		URL:     req.URL,
+		Secret:  secret,
+		Events:  req.Events,
+		Enabled: req.Enabled,
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	// create webhook
+	if err := c.WebhookRepository.Create(db, webhook); err != nil {
+		c.Log.Errorf("Failed to create webhook: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while creating webhook")
+	}
+
+	return toWebhookProto(webhook), nil
+}
+
+func (c *WebhookUseCaseImpl) GetWebhooks(ctx context.Context, gwr *proto.GetWebhooksRequest) (*proto.GetWebhooksResponse, error) {
+	// create request
+	req := &dto.GetWebhooksRequest{
+		UserID: uint(gwr.GetUserId()),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, gwr); err != nil {
+		return nil, err
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	// get webhooks
+	webhooks := new([]entity.Webhook)
+	if err := c.WebhookRepository.FindAllByUserID(db, webhooks, int(req.UserID)); err != nil {
+		c.Log.Errorf("Failed to get webhooks: %v", err)
+		return nil, status.Error(codes.NotFound, "Webhooks not found")
+	}
+
+	// create response
+	res := &proto.GetWebhooksResponse{
+		Webhooks: make([]*proto.Webhook, 0),
+	}
+
+	for i := range *webhooks {
+		res.Webhooks = append(res.Webhooks, toWebhookProto(&(*webhooks)[i]))
+	}
+
+	return res, nil
+}
+
+func (c *WebhookUseCaseImpl) GetWebhook(ctx context.Context, gwr *proto.GetWebhookRequest) (*proto.Webhook, error) {
+	// create request
+	req := &dto.GetWebhookRequest{
+		ID:     uint(gwr.GetId()),
+		UserID: uint(gwr.GetUserId()),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, gwr); err != nil {
+		return nil, err
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	// get webhook
+	webhook := new(entity.Webhook)
+	if err := c.WebhookRepository.FindByIDAndUserID(db, webhook, int(req.ID), int(req.UserID)); err != nil {
+		c.Log.Errorf("Failed to get webhook: %v", err)
+		return nil, status.Error(codes.NotFound, "Webhook not found")
+	}
+
+	return toWebhookProto(webhook), nil
+}
+
+func (c *WebhookUseCaseImpl) UpdateWebhook(ctx context.Context, uwr *proto.UpdateWebhookRequest) (*proto.Webhook, error) {
+	// create request
+	req := &dto.UpdateWebhookRequest{
+		ID:      uint(uwr.GetId()),
+		UserID:  uint(uwr.GetUserId()),
+		URL:     uwr.GetUrl(),
+		Events:  uwr.GetEvents(),
+		Enabled: uwr.GetEnabled(),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, uwr); err != nil {
+		return nil, err
+	}
+
+	if err := checkWebhookURL(req.URL); err != nil {
+		return nil, err
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	// get webhook
+	webhook := new(entity.Webhook)
+	if err := c.WebhookRepository.FindByIDAndUserID(db, webhook, int(req.ID), int(req.UserID)); err != nil {
+		c.Log.Errorf("Failed to get webhook: %v", err)
+		return nil, status.Error(codes.NotFound, "Webhook not found")
+	}
+
+	webhook.URL = req.URL
+	webhook.Events = req.Events
+	webhook.Enabled = req.Enabled
+
+	// update webhook
+	if err := c.WebhookRepository.Update(db, webhook); err != nil {
+		c.Log.Errorf("Failed to update webhook: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while updating webhook")
+	}
+
+	return toWebhookProto(webhook), nil
+}
+
+func (c *WebhookUseCaseImpl) DeleteWebhook(ctx context.Context, dwr *proto.DeleteWebhookRequest) (*proto.Empty, error) {
+	// create request
+	req := &dto.GetWebhookRequest{
+		ID:     uint(dwr.GetId()),
+		UserID: uint(dwr.GetUserId()),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, dwr); err != nil {
+		return nil, err
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	// get webhook
+	webhook := new(entity.Webhook)
+	if err := c.WebhookRepository.FindByIDAndUserID(db, webhook, int(req.ID), int(req.UserID)); err != nil {
+		c.Log.Errorf("Failed to get webhook: %v", err)
+		return nil, status.Error(codes.NotFound, "Webhook not found")
+	}
+
+	// delete webhook, its deliveries cascade
+	if err := c.WebhookRepository.Delete(db, webhook); err != nil {
+		c.Log.Errorf("Failed to delete webhook: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while deleting webhook")
+	}
+
+	return &proto.Empty{}, nil
+}
+
+func (c *WebhookUseCaseImpl) GetWebhookDeliveries(ctx context.Context, gdr *proto.GetWebhookDeliveriesRequest) (*proto.GetWebhookDeliveriesResponse, error) {
+	// create request
+	req := &dto.GetWebhookRequest{
+		ID:     uint(gdr.GetId()),
+		UserID: uint(gdr.GetUserId()),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, gdr); err != nil {
+		return nil, err
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	// get webhook
+	webhook := new(entity.Webhook)
+	if err := c.WebhookRepository.FindByIDAndUserID(db, webhook, int(req.ID), int(req.UserID)); err != nil {
+		c.Log.Errorf("Failed to get webhook: %v", err)
+		return nil, status.Error(codes.NotFound, "Webhook not found")
+	}
+
+	// get deliveries
+	deliveries := new([]entity.WebhookDelivery)
+	if err := c.WebhookRepository.FindAllDeliveriesByWebhookID(db, deliveries, int(webhook.ID)); err != nil {
+		c.Log.Errorf("Failed to get webhook deliveries: %v", err)
+		return nil, status.Error(codes.NotFound, "Deliveries not found")
+	}
+
+	// create response
+	res := &proto.GetWebhookDeliveriesResponse{
+		Deliveries: make([]*proto.WebhookDelivery, 0),
+	}
+
+	for i := range *deliveries {
+		res.Deliveries = append(res.Deliveries, toWebhookDeliveryProto(&(*deliveries)[i]))
+	}
+
+	return res, nil
+}
+
+func (c *WebhookUseCaseImpl) RedeliverWebhookDelivery(ctx context.Context, rdr *proto.RedeliverWebhookDeliveryRequest) (*proto.WebhookDelivery, error) {
+	// create request
+	req := &dto.RedeliverWebhookDeliveryRequest{
+		ID:        uint(rdr.GetId()),
+		WebhookID: uint(rdr.GetWebhookId()),
+		UserID:    uint(rdr.GetUserId()),
+	}
+
+	// validate request
+	if err := validate(c.Validate, c.Log, req, rdr); err != nil {
+		return nil, err
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	// get webhook
+	webhook := new(entity.Webhook)
+	if err := c.WebhookRepository.FindByIDAndUserID(db, webhook, int(req.WebhookID), int(req.UserID)); err != nil {
+		c.Log.Errorf("Failed to get webhook: %v", err)
+		return nil, status.Error(codes.NotFound, "Webhook not found")
+	}
+
+	// get delivery
+	delivery := new(entity.WebhookDelivery)
+	if err := c.WebhookRepository.FindDeliveryByIDAndWebhookID(db, delivery, int(req.ID), int(webhook.ID)); err != nil {
+		c.Log.Errorf("Failed to get webhook delivery: %v", err)
+		return nil, status.Error(codes.NotFound, "Delivery not found")
+	}
+
+	// the same payload is queued again as a new delivery, keeping the old log intact
+	redelivery := &entity.WebhookDelivery{
+		WebhookID:     webhook.ID,
+		Event:         delivery.Event,
+		Payload:       delivery.Payload,
+		Status:        DeliveryPending,
+		NextAttemptAt: time.Now().UTC(),
+	}
+
+	if err := c.WebhookRepository.CreateDelivery(db, redelivery); err != nil {
+		c.Log.Errorf("Failed to create webhook delivery: %v", err)
+		return nil, status.Error(codes.Internal, "An error occurred while redelivering webhook")
+	}
+
+	return toWebhookDeliveryProto(redelivery), nil
+}
+
+func toWebhookProto(webhook *entity.Webhook) *proto.Webhook {
+	return &proto.Webhook{
+		Id:      int64(webhook.ID),
+		UserId:  int64(webhook.UserID),
+		Url:     webhook.URL,
+		Secret:  webhook.Secret,
+		Events:  webhook.Events,
+		Enabled: webhook.Enabled,
+	}
+}
+
+// checkWebhookURL refuses hosts that are internal addresses up front, hostnames are
+// checked again by the dispatcher once they are resolved and dialed
+func checkWebhookURL(rawURL string) error {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "URL is invalid")
+	}
+
+	host := strings.ToLower(target.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return status.Error(codes.InvalidArgument, "URL must point to a public address")
+	}
+
+	if address, err := netip.ParseAddr(host); err == nil && !helper.IsPublicAddress(address) {
+		return status.Error(codes.InvalidArgument, "URL must point to a public address")
+	}
+
+	return nil
+}
+
+func toWebhookDeliveryProto(delivery *entity.WebhookDelivery) *proto.WebhookDelivery {
+	res := &proto.WebhookDelivery{
+		Id:            int64(delivery.ID),
+		WebhookId:     int64(delivery.WebhookID),
+		Event:         delivery.Event,
+		Payload:       delivery.Payload,
+		Status:        delivery.Status,
+		Attempts:      delivery.Attempts,
+		NextAttemptAt: delivery.NextAttemptAt.Unix(),
+		DeliveredAt:   unixOrZero(delivery.DeliveredAt),
+		CreatedAt:     delivery.CreatedAt.Unix(),
+	}
+
+	if delivery.ResponseStatus != nil {
+		res.ResponseStatus = *delivery.ResponseStatus
+	}
+
+	if delivery.Error != nil {
+		res.Error = *delivery.Error
+	}
+
+	return res
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"auth-service/app/di"
+	"context"
 	"fmt"
 	"log"
 	"net"
@@ -13,10 +14,18 @@ func main() {
 	config := app.Config
 	server := app.GrpcServerRouter.GrpcServer
 	db, _ := app.Gorm.DB()
+	userClient := app.UserClient
 
 	defer db.Close()
+	defer userClient.Conn.Close()
 	defer server.GracefulStop()
 
+	// Run scheduled prompts and webhook deliveries in the background, replicas coordinate through the database
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go app.Scheduler.Start(ctx)
+	go app.WebhookDispatcher.Start(ctx)
+
 	port := config.GetString("PORT")
 	if port == "" {
 		port = "3003"
@@ -0,0 +1,26 @@
+CREATE TABLE assistants (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT DEFAULT NULL,
+    instructions TEXT NOT NULL,
+    model VARCHAR(255) NOT NULL DEFAULT 'gemini-1.5-flash',
+    temperature REAL DEFAULT NULL,
+    top_p REAL DEFAULT NULL,
+    max_output_tokens INTEGER DEFAULT NULL,
+    tools JSONB NOT NULL DEFAULT '[]',
+    share_token VARCHAR(64) UNIQUE DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'utc'),
+    updated_at TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'utc')
+);
+
+CREATE TABLE assistant_documents (
+    id BIGSERIAL PRIMARY KEY,
+    assistant_id BIGINT NOT NULL REFERENCES assistants(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    content TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'utc'),
+    updated_at TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'utc')
+);
+
+ALTER TABLE chats ADD COLUMN assistant_id BIGINT DEFAULT NULL REFERENCES assistants(id) ON DELETE SET NULL;
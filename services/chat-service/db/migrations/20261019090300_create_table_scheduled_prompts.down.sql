@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS scheduled_prompt_runs;
+DROP TABLE IF EXISTS scheduled_prompts;
\ No newline at end of file
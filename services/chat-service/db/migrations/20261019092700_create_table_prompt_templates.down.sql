@@ -0,0 +1 @@
+DROP TABLE IF EXISTS prompt_templates;
\ No newline at end of file
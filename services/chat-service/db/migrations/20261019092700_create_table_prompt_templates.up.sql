@@ -0,0 +1,12 @@
+CREATE TABLE prompt_templates (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    organization_id BIGINT DEFAULT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    content TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'utc'),
+    updated_at TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'utc')
+);
+
+CREATE INDEX prompt_templates_user_id_index ON prompt_templates (user_id);
+CREATE INDEX prompt_templates_organization_id_index ON prompt_templates (organization_id);
\ No newline at end of file
@@ -1,12 +1,15 @@
 package config
 
 import (
+	"user-service/app/helper"
 	"context"
 	"fmt"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 func InterceptorLogger(l logrus.FieldLogger) logging.Logger {
@@ -34,13 +37,26 @@ func InterceptorLogger(l logrus.FieldLogger) logging.Logger {
 	})
 }
 
-func NewGrpcServer(log *logrus.Logger) *grpc.Server {
+func NewGrpcServer(log *logrus.Logger, config *viper.Viper) *grpc.Server {
 	opts := []logging.Option{
 		logging.WithLogOnEvents(logging.StartCall, logging.PayloadReceived, logging.PayloadSent, logging.FinishCall),
 	}
 
-	return grpc.NewServer(
-		grpc.UnaryInterceptor(logging.UnaryServerInterceptor(InterceptorLogger(log), opts...)),
-		grpc.StreamInterceptor(logging.StreamServerInterceptor(InterceptorLogger(log), opts...)),
-	)
+	identity := newInternalIdentity(config, log)
+
+	serverOpts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(logging.UnaryServerInterceptor(InterceptorLogger(log), opts...), identity.UnaryInterceptor),
+		grpc.ChainStreamInterceptor(logging.StreamServerInterceptor(InterceptorLogger(log), opts...), identity.StreamInterceptor),
+	}
+
+	// Mutual TLS when GRPC_TLS_CA_FILE is set next to the certificate
+	tlsConfig, err := helper.ServerTLSConfig(config)
+	if err != nil {
+		log.Fatalf("Failed to load gRPC TLS: %v", err)
+	}
+	if tlsConfig != nil {
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	return grpc.NewServer(serverOpts...)
 }
\ No newline at end of file
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"context"
+	"testing"
+	"user-service/app/helper"
+	"user-service/app/model/proto"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const suspendUserMethod = "/proto.UserService/SuspendUser"
+
+// callWithIdentity runs the interceptor with the token and returns the request the handler received
+func callWithIdentity(identity *internalIdentity, token string, method string, req *proto.SuspendUserRequest) (*proto.SuspendUserRequest, error) {
+    ctx := context.Background()
+    if token != "" {
+        ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(helper.InternalIdentityHeader, token))
+    }
+
+    var received *proto.SuspendUserRequest
+    _, err := identity.UnaryInterceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req any) (any, error) {
+        received = req.(*proto.SuspendUserRequest)
+        return nil, nil
+    })
+
+    return received, err
+}
+
+func TestInternalIdentity_BindsSignedActor(t *testing.T) {
+    identity := &internalIdentity{secret: []byte("secret")}
+
+    token, err := helper.SignInternalIdentity([]byte("secret"), "auth-service", 7, suspendUserMethod)
+    assert.NoError(t, err)
+
+    // The actor in the body is replaced by the signed user
+    received, err := callWithIdentity(identity, token, suspendUserMethod, &proto.SuspendUserRequest{ActorId: 9, Id: 3})
+
+    assert.NoError(t, err)
+    assert.Equal(t, int64(7), received.GetActorId())
+    assert.Equal(t, int64(3), received.GetId())
+}
+
+func TestInternalIdentity_ServiceCallHasNoActor(t *testing.T) {
+    identity := &internalIdentity{secret: []byte("secret")}
+
+    token, err := helper.SignInternalIdentity([]byte("secret"), "chat-service", 0, suspendUserMethod)
+    assert.NoError(t, err)
+
+    received, err := callWithIdentity(identity, token, suspendUserMethod, &proto.SuspendUserRequest{ActorId: 9, Id: 3})
+
+    assert.NoError(t, err)
+    assert.Equal(t, int64(0), received.GetActorId())
+}
+
+func TestInternalIdentity_RefusesInvalidIdentity(t *testing.T) {
+    identity := &internalIdentity{secret: []byte("secret")}
+
+    otherSecret, err := helper.SignInternalIdentity([]byte("other"), "auth-service", 7, suspendUserMethod)
+    assert.NoError(t, err)
+
+    otherMethod, err := helper.SignInternalIdentity([]byte("secret"), "auth-service", 7, "/proto.UserService/UpdateUserRole")
+    assert.NoError(t, err)
+
+    for name, token := range map[string]string{
+        "missing":      "",
+        "other secret": otherSecret,
+        "other method": otherMethod,
+        "malformed":    "not-a-token",
+    } {
+        t.Run(name, func(t *testing.T) {
+            received, err := callWithIdentity(identity, token, suspendUserMethod, &proto.SuspendUserRequest{ActorId: 9, Id: 3})
+
+            assert.Nil(t, received)
+            assert.Equal(t, codes.Unauthenticated, status.Code(err))
+        })
+    }
+}
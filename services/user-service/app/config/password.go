@@ -0,0 +1,57 @@
+package config
+
+import (
+	"user-service/app/helper"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// bcryptMaxLength is the number of bytes bcrypt hashes, the rest is ignored
+const bcryptMaxLength = 72
+
+func NewPasswordHasher(config *viper.Viper, log *logrus.Logger) *helper.PasswordHasher {
+	config.SetDefault("PASSWORD_HASH_ALGORITHM", helper.PasswordAlgorithmArgon2id)
+	config.SetDefault("BCRYPT_COST", 12)
+	// OWASP minimum for Argon2id: 19 MiB, 2 iterations, 1 lane
+	config.SetDefault("ARGON2_MEMORY", 19456)
+	config.SetDefault("ARGON2_ITERATIONS", 2)
+	config.SetDefault("ARGON2_PARALLELISM", 1)
+
+	hasher, err := helper.NewPasswordHasher(helper.PasswordHashing{
+		Algorithm:         config.GetString("PASSWORD_HASH_ALGORITHM"),
+		BcryptCost:        config.GetInt("BCRYPT_COST"),
+		Argon2Memory:      config.GetUint32("ARGON2_MEMORY"),
+		Argon2Iterations:  config.GetUint32("ARGON2_ITERATIONS"),
+		Argon2Parallelism: uint8(config.GetUint("ARGON2_PARALLELISM")),
+		Argon2SaltLength:  16,
+		Argon2KeyLength:   32,
+	})
+	if err != nil {
+		log.WithError(err).Panic("invalid password hashing configuration")
+	}
+
+	return hasher
+}
+
+func NewPasswordPolicy(config *viper.Viper, log *logrus.Logger) *helper.PasswordPolicy {
+	config.SetDefault("PASSWORD_MIN_LENGTH", 8)
+	config.SetDefault("PASSWORD_MAX_LENGTH", 128)
+
+	policy := &helper.PasswordPolicy{
+		MinLength: config.GetInt("PASSWORD_MIN_LENGTH"),
+		MaxLength: config.GetInt("PASSWORD_MAX_LENGTH"),
+	}
+
+	if config.GetString("PASSWORD_HASH_ALGORITHM") == helper.PasswordAlgorithmBcrypt {
+		policy.MaxBytes = bcryptMaxLength
+	}
+
+	if path := config.GetString("PASSWORD_BREACHED_LIST_FILE"); path != "" {
+		if err := policy.LoadBreachedPasswords(path); err != nil {
+			log.WithError(err).Panic("invalid password policy configuration")
+		}
+	}
+
+	return policy
+}
\ No newline at end of file
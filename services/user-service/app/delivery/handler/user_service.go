@@ -2,26 +2,64 @@ package handler
 
 import (
 	"context"
+	"user-service/app/model/entity"
 	"user-service/app/model/proto"
 	"user-service/app/usecase"
+
+	"github.com/spf13/viper"
 )
 
 type UserService interface {
     proto.UserServiceServer
     GetUserByEmail(ctx context.Context, req *proto.GetUserByEmailRequest) (*proto.GetUserResponse, error)
     GetUserByID(ctx context.Context, req *proto.GetUserByIDRequest) (*proto.GetUserResponse, error)
+    VerifyCredentials(ctx context.Context, req *proto.VerifyCredentialsRequest) (*proto.GetUserResponse, error)
     CreateUser(ctx context.Context, req *proto.CreateUserRequest) (*proto.GetUserResponse, error)
     UpdateUser(ctx context.Context, req *proto.UpdateUserRequest) (*proto.GetUserResponse, error)
+    VerifyUserEmail(ctx context.Context, req *proto.VerifyUserEmailRequest) (*proto.GetUserResponse, error)
+    ListUsers(ctx context.Context, req *proto.ListUsersRequest) (*proto.ListUsersResponse, error)
+    SuspendUser(ctx context.Context, req *proto.SuspendUserRequest) (*proto.GetUserResponse, error)
+    UpdateUserRole(ctx context.Context, req *proto.UpdateUserRoleRequest) (*proto.GetUserResponse, error)
+    GetUserStats(ctx context.Context, req *proto.GetUserStatsRequest) (*proto.GetUserStatsResponse, error)
+    GetPreferences(ctx context.Context, req *proto.GetUserPreferencesRequest) (*proto.UserPreferences, error)
+    UpdatePreferences(ctx context.Context, req *proto.UpdateUserPreferencesRequest) (*proto.UserPreferences, error)
+    DeleteUser(ctx context.Context, req *proto.DeleteUserRequest) (*proto.DeleteUserResponse, error)
+    RestoreUser(ctx context.Context, req *proto.RestoreUserRequest) (*proto.GetUserResponse, error)
+    PurgeUser(ctx context.Context, req *proto.PurgeUserRequest) (*proto.DeleteUserResponse, error)
+    CreateOrganization(ctx context.Context, req *proto.CreateUserOrganizationRequest) (*proto.UserOrganization, error)
+    ListOrganizations(ctx context.Context, req *proto.ListUserOrganizationsRequest) (*proto.ListUserOrganizationsResponse, error)
+    GetOrganizationMembership(ctx context.Context, req *proto.GetUserOrganizationRequest) (*proto.UserOrganization, error)
+    UpdateOrganization(ctx context.Context, req *proto.UpdateUserOrganizationRequest) (*proto.UserOrganization, error)
+    DeleteOrganization(ctx context.Context, req *proto.DeleteUserOrganizationRequest) (*proto.DeleteUserOrganizationResponse, error)
+    ListOrganizationMembers(ctx context.Context, req *proto.ListUserOrganizationMembersRequest) (*proto.ListUserOrganizationMembersResponse, error)
+    UpdateOrganizationMember(ctx context.Context, req *proto.UpdateUserOrganizationMemberRequest) (*proto.UserOrganizationMember, error)
+    RemoveOrganizationMember(ctx context.Context, req *proto.RemoveUserOrganizationMemberRequest) (*proto.DeleteUserOrganizationResponse, error)
+    CreateOrganizationInvite(ctx context.Context, req *proto.CreateUserOrganizationInviteRequest) (*proto.UserOrganizationInvite, error)
+    ListOrganizationInvites(ctx context.Context, req *proto.ListUserOrganizationInvitesRequest) (*proto.ListUserOrganizationInvitesResponse, error)
+    RevokeOrganizationInvite(ctx context.Context, req *proto.RevokeUserOrganizationInviteRequest) (*proto.DeleteUserOrganizationResponse, error)
+    AcceptOrganizationInvite(ctx context.Context, req *proto.AcceptUserOrganizationInviteRequest) (*proto.UserOrganization, error)
 }
 
 type UserServiceImpl struct {
     proto.UnimplementedUserServiceServer
-    userUseCase usecase.UserUseCase
+    userUseCase        usecase.UserUseCase
+    preferencesUseCase usecase.PreferencesUseCase
+    organizationUseCase usecase.OrganizationUseCase
+    // legacyPasswordHashes keeps sending hashes to auth services released before VerifyCredentials
+    legacyPasswordHashes bool
 }
 
-func NewUserServiceImpl(userUseCase usecase.UserUseCase) *UserServiceImpl {
+func NewUserServiceImpl(
+    userUseCase usecase.UserUseCase,
+    preferencesUseCase usecase.PreferencesUseCase,
+    organizationUseCase usecase.OrganizationUseCase,
+    config *viper.Viper,
+) *UserServiceImpl {
     return &UserServiceImpl{
-        userUseCase: userUseCase,
+        userUseCase:          userUseCase,
+        preferencesUseCase:   preferencesUseCase,
+        organizationUseCase:  organizationUseCase,
+        legacyPasswordHashes: config.GetBool("LEGACY_PASSWORD_HASHES"),
     }
 }
 
@@ -31,13 +69,7 @@ func (s *UserServiceImpl) GetUserByEmail(ctx context.Context, req *proto.GetUser
         return nil, err
     }
 
-    return &proto.GetUserResponse{
-        Id:    int64(user.ID),
-        Name:  user.Name,
-        ImageUrl: *user.ImageURL,
-        Email: user.Email,
-        Password: *user.Password,
-    }, nil
+    return s.toUserResponse(user), nil
 }
 
 func (s *UserServiceImpl) GetUserByID(ctx context.Context, req *proto.GetUserByIDRequest) (*proto.GetUserResponse, error) {
@@ -46,13 +78,16 @@ func (s *UserServiceImpl) GetUserByID(ctx context.Context, req *proto.GetUserByI
         return nil, err
     }
 
-    return &proto.GetUserResponse{
-        Id:    int64(user.ID),
-        Name:  user.Name,
-        ImageUrl: *user.ImageURL,
-        Email: user.Email,
-        Password: *user.Password,
-    }, nil
+    return s.toUserResponse(user), nil
+}
+
+func (s *UserServiceImpl) VerifyCredentials(ctx context.Context, req *proto.VerifyCredentialsRequest) (*proto.GetUserResponse, error) {
+    user, err := s.userUseCase.VerifyCredentials(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return s.toUserResponse(user), nil
 }
 
 func (s *UserServiceImpl) CreateUser(ctx context.Context, req *proto.CreateUserRequest) (*proto.GetUserResponse, error) {
@@ -61,13 +96,7 @@ func (s *UserServiceImpl) CreateUser(ctx context.Context, req *proto.CreateUserR
         return nil, err
     }
 
-    return &proto.GetUserResponse{
-        Id:    int64(user.ID),
-        Name:  user.Name,
-        ImageUrl: *user.ImageURL,
-        Email: user.Email,
-        Password: *user.Password,
-    }, nil
+    return s.toUserResponse(user), nil
 }
 
 func (s *UserServiceImpl) UpdateUser(ctx context.Context, req *proto.UpdateUserRequest) (*proto.GetUserResponse, error) {
@@ -76,11 +105,303 @@ func (s *UserServiceImpl) UpdateUser(ctx context.Context, req *proto.UpdateUserR
         return nil, err
     }
 
-    return &proto.GetUserResponse{
-        Id:    int64(user.ID),
-        Name:  user.Name,
-        ImageUrl: *user.ImageURL,
-        Email: user.Email,
-        Password: *user.Password,
+    return s.toUserResponse(user), nil
+}
+
+func (s *UserServiceImpl) VerifyUserEmail(ctx context.Context, req *proto.VerifyUserEmailRequest) (*proto.GetUserResponse, error) {
+    user, err := s.userUseCase.VerifyEmail(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return s.toUserResponse(user), nil
+}
+
+func (s *UserServiceImpl) ListUsers(ctx context.Context, req *proto.ListUsersRequest) (*proto.ListUsersResponse, error) {
+    users, total, err := s.userUseCase.List(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    res := &proto.ListUsersResponse{
+        Users: make([]*proto.GetUserResponse, 0, len(users)),
+        Total: total,
+    }
+    for i := range users {
+        res.Users = append(res.Users, s.toUserResponse(&users[i]))
+    }
+
+    return res, nil
+}
+
+func (s *UserServiceImpl) SuspendUser(ctx context.Context, req *proto.SuspendUserRequest) (*proto.GetUserResponse, error) {
+    user, err := s.userUseCase.Suspend(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return s.toUserResponse(user), nil
+}
+
+func (s *UserServiceImpl) UpdateUserRole(ctx context.Context, req *proto.UpdateUserRoleRequest) (*proto.GetUserResponse, error) {
+    user, err := s.userUseCase.UpdateRole(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return s.toUserResponse(user), nil
+}
+
+func (s *UserServiceImpl) GetUserStats(ctx context.Context, req *proto.GetUserStatsRequest) (*proto.GetUserStatsResponse, error) {
+    stats, err := s.userUseCase.Stats(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return &proto.GetUserStatsResponse{
+        Total:     stats.Total,
+        Verified:  stats.Verified,
+        Suspended: stats.Suspended,
+        Created:   stats.Created,
     }, nil
+}
+
+func (s *UserServiceImpl) DeleteUser(ctx context.Context, req *proto.DeleteUserRequest) (*proto.DeleteUserResponse, error) {
+    if err := s.userUseCase.Delete(ctx, req); err != nil {
+        return nil, err
+    }
+
+    return &proto.DeleteUserResponse{}, nil
+}
+
+func (s *UserServiceImpl) RestoreUser(ctx context.Context, req *proto.RestoreUserRequest) (*proto.GetUserResponse, error) {
+    user, err := s.userUseCase.Restore(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return s.toUserResponse(user), nil
+}
+
+func (s *UserServiceImpl) PurgeUser(ctx context.Context, req *proto.PurgeUserRequest) (*proto.DeleteUserResponse, error) {
+    if err := s.userUseCase.Purge(ctx, req); err != nil {
+        return nil, err
+    }
+
+    return &proto.DeleteUserResponse{}, nil
+}
+
+func (s *UserServiceImpl) GetPreferences(ctx context.Context, req *proto.GetUserPreferencesRequest) (*proto.UserPreferences, error) {
+    preferences, err := s.preferencesUseCase.Get(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return s.toPreferences(preferences), nil
+}
+
+func (s *UserServiceImpl) UpdatePreferences(ctx context.Context, req *proto.UpdateUserPreferencesRequest) (*proto.UserPreferences, error) {
+    preferences, err := s.preferencesUseCase.Update(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return s.toPreferences(preferences), nil
+}
+
+func (s *UserServiceImpl) CreateOrganization(ctx context.Context, req *proto.CreateUserOrganizationRequest) (*proto.UserOrganization, error) {
+    member, err := s.organizationUseCase.Create(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return s.toOrganization(member), nil
+}
+
+func (s *UserServiceImpl) ListOrganizations(ctx context.Context, req *proto.ListUserOrganizationsRequest) (*proto.ListUserOrganizationsResponse, error) {
+    members, err := s.organizationUseCase.List(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    res := &proto.ListUserOrganizationsResponse{Organizations: make([]*proto.UserOrganization, 0, len(members))}
+    for i := range members {
+        res.Organizations = append(res.Organizations, s.toOrganization(&members[i]))
+    }
+
+    return res, nil
+}
+
+func (s *UserServiceImpl) GetOrganizationMembership(ctx context.Context, req *proto.GetUserOrganizationRequest) (*proto.UserOrganization, error) {
+    member, err := s.organizationUseCase.GetMembership(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return s.toOrganization(member), nil
+}
+
+func (s *UserServiceImpl) UpdateOrganization(ctx context.Context, req *proto.UpdateUserOrganizationRequest) (*proto.UserOrganization, error) {
+    member, err := s.organizationUseCase.Update(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return s.toOrganization(member), nil
+}
+
+func (s *UserServiceImpl) DeleteOrganization(ctx context.Context, req *proto.DeleteUserOrganizationRequest) (*proto.DeleteUserOrganizationResponse, error) {
+    if err := s.organizationUseCase.Delete(ctx, req); err != nil {
+        return nil, err
+    }
+
+    return &proto.DeleteUserOrganizationResponse{}, nil
+}
+
+func (s *UserServiceImpl) ListOrganizationMembers(ctx context.Context, req *proto.ListUserOrganizationMembersRequest) (*proto.ListUserOrganizationMembersResponse, error) {
+    members, err := s.organizationUseCase.ListMembers(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    res := &proto.ListUserOrganizationMembersResponse{Members: make([]*proto.UserOrganizationMember, 0, len(members))}
+    for i := range members {
+        res.Members = append(res.Members, s.toOrganizationMember(&members[i]))
+    }
+
+    return res, nil
+}
+
+func (s *UserServiceImpl) UpdateOrganizationMember(ctx context.Context, req *proto.UpdateUserOrganizationMemberRequest) (*proto.UserOrganizationMember, error) {
+    member, err := s.organizationUseCase.UpdateMember(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return s.toOrganizationMember(member), nil
+}
+
+func (s *UserServiceImpl) RemoveOrganizationMember(ctx context.Context, req *proto.RemoveUserOrganizationMemberRequest) (*proto.DeleteUserOrganizationResponse, error) {
+    if err := s.organizationUseCase.RemoveMember(ctx, req); err != nil {
+        return nil, err
+    }
+
+    return &proto.DeleteUserOrganizationResponse{}, nil
+}
+
+func (s *UserServiceImpl) CreateOrganizationInvite(ctx context.Context, req *proto.CreateUserOrganizationInviteRequest) (*proto.UserOrganizationInvite, error) {
+    invite, err := s.organizationUseCase.CreateInvite(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return s.toOrganizationInvite(invite), nil
+}
+
+func (s *UserServiceImpl) ListOrganizationInvites(ctx context.Context, req *proto.ListUserOrganizationInvitesRequest) (*proto.ListUserOrganizationInvitesResponse, error) {
+    invites, err := s.organizationUseCase.ListInvites(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    res := &proto.ListUserOrganizationInvitesResponse{Invites: make([]*proto.UserOrganizationInvite, 0, len(invites))}
+    for i := range invites {
+        res.Invites = append(res.Invites, s.toOrganizationInvite(&invites[i]))
+    }
+
+    return res, nil
+}
+
+func (s *UserServiceImpl) RevokeOrganizationInvite(ctx context.Context, req *proto.RevokeUserOrganizationInviteRequest) (*proto.DeleteUserOrganizationResponse, error) {
+    if err := s.organizationUseCase.RevokeInvite(ctx, req); err != nil {
+        return nil, err
+    }
+
+    return &proto.DeleteUserOrganizationResponse{}, nil
+}
+
+func (s *UserServiceImpl) AcceptOrganizationInvite(ctx context.Context, req *proto.AcceptUserOrganizationInviteRequest) (*proto.UserOrganization, error) {
+    member, err := s.organizationUseCase.AcceptInvite(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    return s.toOrganization(member), nil
+}
+
+func (s *UserServiceImpl) toUserResponse(user *entity.User) *proto.GetUserResponse {
+    res := &proto.GetUserResponse{
+        Id:            int64(user.ID),
+        Name:          user.Name,
+        Email:         user.Email,
+        ImageUrl:      user.ImageURL,
+        EmailVerified: user.EmailVerifiedAt != nil,
+        Role:          user.Role,
+        Permissions:   user.Permissions(),
+        Suspended:     user.SuspendedAt != nil,
+        CreatedAt:     user.CreatedAt.Unix(),
+        HasPassword:   user.HasPassword(),
+    }
+    if s.legacyPasswordHashes && user.HasPassword() {
+        res.Password = *user.Password
+    }
+
+    return res
+}
+
+// toPreferences expects resolved preferences so every field is set
+func (s *UserServiceImpl) toPreferences(preferences *entity.Preferences) *proto.UserPreferences {
+    return &proto.UserPreferences{
+        DefaultModel:     *preferences.DefaultModel,
+        ResponseLanguage: *preferences.ResponseLanguage,
+        Theme:            *preferences.Theme,
+        Timezone:         *preferences.Timezone,
+        MemoryEnabled:    *preferences.MemoryEnabled,
+        HistoryEnabled:   *preferences.HistoryEnabled,
+    }
+}
+
+// toOrganization expects the membership with its organization loaded
+func (s *UserServiceImpl) toOrganization(member *entity.OrganizationMember) *proto.UserOrganization {
+    res := &proto.UserOrganization{
+        Id:            int64(member.Organization.ID),
+        Name:          member.Organization.Name,
+        AllowedModels: member.Organization.AllowedModels,
+        Role:          member.Role,
+        CreatedAt:     member.Organization.CreatedAt.Unix(),
+    }
+    if quota := member.Organization.MemberDailyMessageQuota; quota != nil {
+        value := int32(*quota)
+        res.MemberDailyMessageQuota = &value
+    }
+
+    return res
+}
+
+// toOrganizationMember expects the membership with its user loaded
+func (s *UserServiceImpl) toOrganizationMember(member *entity.OrganizationMember) *proto.UserOrganizationMember {
+    return &proto.UserOrganizationMember{
+        UserId:   int64(member.UserID),
+        Name:     member.User.Name,
+        Email:    member.User.Email,
+        ImageUrl: member.User.ImageURL,
+        Role:     member.Role,
+        JoinedAt: member.CreatedAt.Unix(),
+    }
+}
+
+func (s *UserServiceImpl) toOrganizationInvite(invite *entity.OrganizationInvite) *proto.UserOrganizationInvite {
+    res := &proto.UserOrganizationInvite{
+        Id:             int64(invite.ID),
+        OrganizationId: int64(invite.OrganizationID),
+        Email:          invite.Email,
+        Role:           invite.Role,
+        ExpiresAt:      invite.ExpiresAt.Unix(),
+        CreatedAt:      invite.CreatedAt.Unix(),
+    }
+    if invite.Organization != nil {
+        res.OrganizationName = invite.Organization.Name
+    }
+
+    return res
 }
\ No newline at end of file
@@ -13,6 +13,24 @@ import (
 	"github.com/google/wire"
 )
 
+var preferencesSet = wire.NewSet(
+	repository.NewUserPreferencesRepositoryImpl,
+	wire.Bind(new(repository.UserPreferencesRepository), new(*repository.UserPreferencesRepositoryImpl)),
+	usecase.NewPreferencesUseCaseImpl,
+	wire.Bind(new(usecase.PreferencesUseCase), new(*usecase.PreferencesUseCaseImpl)),
+)
+
+var organizationSet = wire.NewSet(
+	repository.NewOrganizationRepositoryImpl,
+	wire.Bind(new(repository.OrganizationRepository), new(*repository.OrganizationRepositoryImpl)),
+	repository.NewOrganizationMemberRepositoryImpl,
+	wire.Bind(new(repository.OrganizationMemberRepository), new(*repository.OrganizationMemberRepositoryImpl)),
+	repository.NewOrganizationInviteRepositoryImpl,
+	wire.Bind(new(repository.OrganizationInviteRepository), new(*repository.OrganizationInviteRepositoryImpl)),
+	usecase.NewOrganizationUseCaseImpl,
+	wire.Bind(new(usecase.OrganizationUseCase), new(*usecase.OrganizationUseCaseImpl)),
+)
+
 var userSet = wire.NewSet(
 	repository.NewUserRepositoryImpl,
 	wire.Bind(new(repository.UserRepository), new(*repository.UserRepositoryImpl)),
@@ -29,9 +47,13 @@ func InitializedApp() *config.App {
 		config.NewApp,
 		config.NewGrpcServer,
 		config.NewValidator,
+		config.NewPasswordHasher,
+		config.NewPasswordPolicy,
 		config.NewLogger,
 		route.NewGrpcServerRouter,
 		userSet,
+		preferencesSet,
+		organizationSet,
 	)
 	return nil
 }
\ No newline at end of file
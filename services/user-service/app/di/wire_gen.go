@@ -19,13 +19,21 @@ import (
 
 func InitializedApp() *config.App {
 	logger := config.NewLogger()
-	server := config.NewGrpcServer(logger)
 	viper := config.NewViper(logger)
+	server := config.NewGrpcServer(logger, viper)
 	db := config.NewDatabase(viper)
 	validate := config.NewValidator()
 	userRepositoryImpl := repository.NewUserRepositoryImpl()
-	userUseCaseImpl := usecase.NewUserUseCaseImpl(db, validate, userRepositoryImpl, logger)
-	userServiceImpl := handler.NewUserServiceImpl(userUseCaseImpl)
+	passwordHasher := config.NewPasswordHasher(viper, logger)
+	passwordPolicy := config.NewPasswordPolicy(viper, logger)
+	userUseCaseImpl := usecase.NewUserUseCaseImpl(db, validate, userRepositoryImpl, passwordHasher, passwordPolicy, logger)
+	userPreferencesRepositoryImpl := repository.NewUserPreferencesRepositoryImpl()
+	preferencesUseCaseImpl := usecase.NewPreferencesUseCaseImpl(db, validate, userRepositoryImpl, userPreferencesRepositoryImpl, logger)
+	organizationRepositoryImpl := repository.NewOrganizationRepositoryImpl()
+	organizationMemberRepositoryImpl := repository.NewOrganizationMemberRepositoryImpl()
+	organizationInviteRepositoryImpl := repository.NewOrganizationInviteRepositoryImpl()
+	organizationUseCaseImpl := usecase.NewOrganizationUseCaseImpl(db, validate, userRepositoryImpl, organizationRepositoryImpl, organizationMemberRepositoryImpl, organizationInviteRepositoryImpl, logger)
+	userServiceImpl := handler.NewUserServiceImpl(userUseCaseImpl, preferencesUseCaseImpl, organizationUseCaseImpl, viper)
 	grpcServerRouter := route.NewGrpcServerRouter(server, userServiceImpl)
 	app := config.NewApp(grpcServerRouter, viper)
 	return app
@@ -33,4 +41,8 @@ func InitializedApp() *config.App {
 
 // wire.go:
 
+var preferencesSet = wire.NewSet(repository.NewUserPreferencesRepositoryImpl, wire.Bind(new(repository.UserPreferencesRepository), new(*repository.UserPreferencesRepositoryImpl)), usecase.NewPreferencesUseCaseImpl, wire.Bind(new(usecase.PreferencesUseCase), new(*usecase.PreferencesUseCaseImpl)))
+
+var organizationSet = wire.NewSet(repository.NewOrganizationRepositoryImpl, wire.Bind(new(repository.OrganizationRepository), new(*repository.OrganizationRepositoryImpl)), repository.NewOrganizationMemberRepositoryImpl, wire.Bind(new(repository.OrganizationMemberRepository), new(*repository.OrganizationMemberRepositoryImpl)), repository.NewOrganizationInviteRepositoryImpl, wire.Bind(new(repository.OrganizationInviteRepository), new(*repository.OrganizationInviteRepositoryImpl)), usecase.NewOrganizationUseCaseImpl, wire.Bind(new(usecase.OrganizationUseCase), new(*usecase.OrganizationUseCaseImpl)))
+
 var userSet = wire.NewSet(repository.NewUserRepositoryImpl, wire.Bind(new(repository.UserRepository), new(*repository.UserRepositoryImpl)), usecase.NewUserUseCaseImpl, wire.Bind(new(usecase.UserUseCase), new(*usecase.UserUseCaseImpl)), handler.NewUserServiceImpl, wire.Bind(new(handler.UserService), new(*handler.UserServiceImpl)))
@@ -1,13 +1,164 @@
 package helper
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
 
-func HashPassword(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), 14)
-	return string(bytes), err
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	PasswordAlgorithmBcrypt   = "bcrypt"
+	PasswordAlgorithmArgon2id = "argon2id"
+)
+
+var ErrUnknownPasswordHash = errors.New("unknown password hash format")
+
+// PasswordHashing are the parameters new hashes are created with. Every hash encodes
+// the parameters it was created with, so changing them only affects new hashes and
+// the ones rehashed on login.
+type PasswordHashing struct {
+	Algorithm         string
+	BcryptCost        int
+	Argon2Memory      uint32
+	Argon2Iterations  uint32
+	Argon2Parallelism uint8
+	Argon2SaltLength  uint32
+	Argon2KeyLength   uint32
+}
+
+type PasswordHasher struct {
+	params PasswordHashing
+	dummy  string
 }
 
-func VerifyPassword(hashedPassword, password string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
-	return err == nil
+func NewPasswordHasher(params PasswordHashing) (*PasswordHasher, error) {
+	hasher := &PasswordHasher{params: params}
+
+	// The dummy hash costs as much as real hashes, it is compared when the account
+	// has no password so unknown emails take as long to answer as wrong passwords
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+
+	dummy, err := hasher.Hash(base64.RawStdEncoding.EncodeToString(secret))
+	if err != nil {
+		return nil, err
+	}
+	hasher.dummy = dummy
+
+	return hasher, nil
+}
+
+// Hash encodes the password with the configured algorithm: bcrypt in its own format,
+// Argon2id as $argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<key>
+func (h *PasswordHasher) Hash(password string) (string, error) {
+	switch h.params.Algorithm {
+	case PasswordAlgorithmBcrypt:
+		bytes, err := bcrypt.GenerateFromPassword([]byte(password), h.params.BcryptCost)
+		return string(bytes), err
+	case PasswordAlgorithmArgon2id:
+		salt := make([]byte, h.params.Argon2SaltLength)
+		if _, err := rand.Read(salt); err != nil {
+			return "", err
+		}
+
+		key := argon2.IDKey([]byte(password), salt, h.params.Argon2Iterations, h.params.Argon2Memory, h.params.Argon2Parallelism, h.params.Argon2KeyLength)
+		return fmt.Sprintf(
+			"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+			argon2.Version, h.params.Argon2Memory, h.params.Argon2Iterations, h.params.Argon2Parallelism,
+			base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key),
+		), nil
+	default:
+		return "", fmt.Errorf("unknown password hash algorithm %q", h.params.Algorithm)
+	}
+}
+
+// Verify compares the password with a hash of any supported format, an empty hash is
+// compared with the dummy hash and never matches. needsRehash reports a match whose
+// hash was created with another algorithm or weaker parameters than the configured ones.
+func (h *PasswordHasher) Verify(hashedPassword, password string) (ok bool, needsRehash bool) {
+	if hashedPassword == "" {
+		h.compare(h.dummy, password)
+		return false, false
+	}
+
+	ok, err := h.compare(hashedPassword, password)
+	if !ok || err != nil {
+		return false, false
+	}
+
+	return true, h.outdated(hashedPassword)
+}
+
+func (h *PasswordHasher) compare(hashedPassword, password string) (bool, error) {
+	if strings.HasPrefix(hashedPassword, "$argon2id$") {
+		params, salt, key, err := decodeArgon2id(hashedPassword)
+		if err != nil {
+			return false, err
+		}
+
+		other := argon2.IDKey([]byte(password), salt, params.Argon2Iterations, params.Argon2Memory, params.Argon2Parallelism, uint32(len(key)))
+		return subtle.ConstantTimeCompare(key, other) == 1, nil
+	}
+
+	if _, err := bcrypt.Cost([]byte(hashedPassword)); err != nil {
+		return false, ErrUnknownPasswordHash
+	}
+
+	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password)) == nil, nil
+}
+
+func (h *PasswordHasher) outdated(hashedPassword string) bool {
+	switch h.params.Algorithm {
+	case PasswordAlgorithmBcrypt:
+		cost, err := bcrypt.Cost([]byte(hashedPassword))
+		return err != nil || cost < h.params.BcryptCost
+	case PasswordAlgorithmArgon2id:
+		params, salt, key, err := decodeArgon2id(hashedPassword)
+		return err != nil ||
+			params.Argon2Memory < h.params.Argon2Memory ||
+			params.Argon2Iterations < h.params.Argon2Iterations ||
+			params.Argon2Parallelism != h.params.Argon2Parallelism ||
+			uint32(len(salt)) < h.params.Argon2SaltLength ||
+			uint32(len(key)) < h.params.Argon2KeyLength
+	default:
+		return false
+	}
+}
+
+func decodeArgon2id(hashedPassword string) (*PasswordHashing, []byte, []byte, error) {
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, key
+	parts := strings.Split(hashedPassword, "$")
+	if len(parts) != 6 {
+		return nil, nil, nil, ErrUnknownPasswordHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, nil, nil, ErrUnknownPasswordHash
+	}
+
+	params := &PasswordHashing{Algorithm: PasswordAlgorithmArgon2id}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Argon2Memory, &params.Argon2Iterations, &params.Argon2Parallelism); err != nil || params.Argon2Iterations == 0 || params.Argon2Parallelism == 0 {
+		return nil, nil, nil, ErrUnknownPasswordHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, nil, nil, ErrUnknownPasswordHash
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return nil, nil, nil, ErrUnknownPasswordHash
+	}
+
+	return params, salt, key, nil
 }
\ No newline at end of file
@@ -0,0 +1,89 @@
+package helper
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// PasswordPolicy is checked whenever a password is set, existing passwords keep
+// working until they are changed
+type PasswordPolicy struct {
+	MinLength int
+	MaxLength int
+	// MaxBytes limits the encoded length for hashes that ignore the rest, like bcrypt
+	MaxBytes int
+	// breached holds the SHA-1 of every password of the breached list
+	breached map[string]struct{}
+}
+
+// LoadBreachedPasswords reads one password per line, either plain text or as the
+// SHA-1 hex digest with an optional ":count" suffix like the Pwned Passwords lists
+func (p *PasswordPolicy) LoadBreachedPasswords(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("error opening breached password list: %v", err)
+	}
+	defer file.Close()
+
+	p.breached = make(map[string]struct{})
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if digest, _, _ := strings.Cut(line, ":"); isSHA1Hex(digest) {
+			p.breached[strings.ToLower(digest)] = struct{}{}
+			continue
+		}
+
+		p.breached[sha1Hex(line)] = struct{}{}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading breached password list: %v", err)
+	}
+
+	return nil
+}
+
+// Check returns the message shown to the user when the password is not allowed
+func (p *PasswordPolicy) Check(password string) (string, bool) {
+	length := utf8.RuneCountInString(password)
+	if length < p.MinLength {
+		return fmt.Sprintf("Password must be at least %d characters", p.MinLength), false
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		return fmt.Sprintf("Password must be at most %d characters", p.MaxLength), false
+	}
+	if p.MaxBytes > 0 && len(password) > p.MaxBytes {
+		return "Password is too long", false
+	}
+
+	if _, ok := p.breached[sha1Hex(password)]; ok {
+		return "Password has appeared in a data breach, choose another one", false
+	}
+
+	return "", true
+}
+
+func sha1Hex(value string) string {
+	sum := sha1.Sum([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+func isSHA1Hex(value string) bool {
+	if len(value) != sha1.Size*2 {
+		return false
+	}
+
+	_, err := hex.DecodeString(value)
+	return err == nil
+}
\ No newline at end of file
@@ -0,0 +1,64 @@
+package helper
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/spf13/viper"
+)
+
+// ServerTLSConfig serves the certificate of GRPC_TLS_CERT_FILE and GRPC_TLS_KEY_FILE,
+// it is nil without them and the server stays plaintext. With GRPC_TLS_CA_FILE the
+// clients have to present a certificate signed by it, which is mutual TLS.
+func ServerTLSConfig(config *viper.Viper) (*tls.Config, error) {
+	certFile, keyFile := config.GetString("GRPC_TLS_CERT_FILE"), config.GetString("GRPC_TLS_KEY_FILE")
+	if certFile == "" || keyFile == "" {
+		return nil, nil
+	}
+
+	certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("error loading server certificate: %v", err)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{certificate},
+	}
+
+	if caFile := config.GetString("GRPC_TLS_CA_FILE"); caFile != "" {
+		clientCAs, err := certPool(caFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
+}
+
+// certPool loads the CA file, an empty path returns the system roots
+func certPool(caFile string) (*x509.CertPool, error) {
+	if caFile == "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			return nil, fmt.Errorf("error loading system root CA pool: %v", err)
+		}
+		return pool, nil
+	}
+
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading CA file: %v", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %s", caFile)
+	}
+
+	return pool, nil
+}
\ No newline at end of file
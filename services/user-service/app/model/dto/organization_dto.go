@@ -0,0 +1,29 @@
+package dto
+
+type CreateOrganizationRequest struct {
+    ActorID uint   `json:"actor_id" validate:"required"`
+    Name    string `json:"name" validate:"required,max=255"`
+}
+
+type UpdateOrganizationRequest struct {
+    ActorID       uint     `json:"actor_id" validate:"required"`
+    ID            uint     `json:"id" validate:"required"`
+    Name          *string  `json:"name" validate:"omitempty,min=1,max=255"`
+    AllowedModels []string `json:"allowed_models" validate:"max=50,dive,required,max=100,printascii"`
+}
+
+type UpdateOrganizationMemberRequest struct {
+    ActorID        uint   `json:"actor_id" validate:"required"`
+    OrganizationID uint   `json:"organization_id" validate:"required"`
+    UserID         uint   `json:"user_id" validate:"required"`
+    Role           string `json:"role" validate:"required,oneof=owner admin member"`
+}
+
+type CreateOrganizationInviteRequest struct {
+    ActorID        uint   `json:"actor_id" validate:"required"`
+    OrganizationID uint   `json:"organization_id" validate:"required"`
+    Email          string `json:"email" validate:"required,email,max=255"`
+    Role           string `json:"role" validate:"required,oneof=admin member"`
+    TokenHash      string `json:"token_hash" validate:"required,max=64"`
+    ExpiresAt      int64  `json:"expires_at" validate:"required"`
+}
\ No newline at end of file
@@ -1,16 +1,43 @@
 package dto
 
 type CreateUserRequest struct {
-    Name     string `json:"name" validate:"required"`
-    Email    string `json:"email" validate:"required,email"`
-    Password string `json:"password"`
-    ImageURL string `json:"image_url"`
+    Name     string  `json:"name" validate:"required"`
+    Email    string  `json:"email" validate:"required,email"`
+    Password *string `json:"password" validate:"omitempty,min=1"`
+    ImageURL *string `json:"image_url"`
 }
 
 type UpdateUserRequest struct {
-    ID       uint   `json:"id" validate:"required"`
-    Name     string `json:"name" validate:"required"`
+    ID       uint    `json:"id" validate:"required"`
+    Name     string  `json:"name" validate:"required"`
+    Email    string  `json:"email" validate:"required,email"`
+    Password *string `json:"password" validate:"omitempty,min=1"`
+    ImageURL *string `json:"image_url"`
+}
+
+type VerifyCredentialsRequest struct {
     Email    string `json:"email" validate:"required,email"`
-    Password string `json:"password"`
-    ImageURL string `json:"image_url"`
+    Password string `json:"password" validate:"required"`
+}
+
+type ListUsersRequest struct {
+    ActorID  uint   `json:"actor_id" validate:"required"`
+    Query    string `json:"query" validate:"max=255"`
+    Page     int    `json:"page" validate:"min=1"`
+    PageSize int    `json:"page_size" validate:"min=1,max=100"`
+}
+
+type UpdateUserRoleRequest struct {
+    ActorID uint   `json:"actor_id" validate:"required"`
+    ID      uint   `json:"id" validate:"required"`
+    Role    string `json:"role" validate:"required,oneof=user support admin"`
+}
+
+// Empty strings pass the validation, they restore the default
+type UpdatePreferencesRequest struct {
+    UserID           uint   `json:"user_id" validate:"required"`
+    DefaultModel     string `json:"default_model" validate:"omitempty,max=100,printascii"`
+    ResponseLanguage string `json:"response_language" validate:"omitempty,bcp47_language_tag"`
+    Theme            string `json:"theme" validate:"omitempty,oneof=system light dark"`
+    Timezone         string `json:"timezone" validate:"omitempty,timezone"`
 }
\ No newline at end of file
@@ -0,0 +1,52 @@
+package entity
+
+import "time"
+
+const (
+	OrganizationRoleOwner  = "owner"
+	OrganizationRoleAdmin  = "admin"
+	OrganizationRoleMember = "member"
+)
+
+type Organization struct {
+	ID                      uint
+	Name                    string
+	MemberDailyMessageQuota *int
+	AllowedModels           []string `gorm:"type:jsonb;serializer:json"`
+	CreatedAt               time.Time
+	UpdatedAt               time.Time
+}
+
+type OrganizationMember struct {
+	ID             uint
+	OrganizationID uint
+	UserID         uint
+	Role           string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	Organization   *Organization
+	User           *User
+}
+
+// CanManage reports whether the member may change the settings, invites and members of the organization
+func (m *OrganizationMember) CanManage() bool {
+	return m.Role == OrganizationRoleOwner || m.Role == OrganizationRoleAdmin
+}
+
+type OrganizationInvite struct {
+	ID             uint
+	OrganizationID uint
+	Email          string
+	Role           string
+	TokenHash      string
+	InvitedBy      *uint
+	ExpiresAt      time.Time
+	AcceptedAt     *time.Time
+	CreatedAt      time.Time
+	Organization   *Organization
+}
+
+// Pending reports whether the invite can still be accepted
+func (i *OrganizationInvite) Pending() bool {
+	return i.AcceptedAt == nil && i.ExpiresAt.After(time.Now())
+}
\ No newline at end of file
@@ -1,6 +1,32 @@
 package entity
 
-import "time"
+import (
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	RoleUser    = "user"
+	RoleSupport = "support"
+	RoleAdmin   = "admin"
+)
+
+const (
+	PermissionUsersRead    = "users:read"
+	PermissionUsersSuspend = "users:suspend"
+	PermissionUsersRoles   = "users:roles"
+	PermissionReportsRead  = "reports:read"
+	PermissionAuditRead    = "audit:read"
+)
+
+// RolePermissions lists what every role is allowed to do, users have no permissions
+var RolePermissions = map[string][]string{
+	RoleUser:    {},
+	RoleSupport: {PermissionUsersRead, PermissionUsersSuspend, PermissionReportsRead},
+	RoleAdmin:   {PermissionUsersRead, PermissionUsersSuspend, PermissionUsersRoles, PermissionReportsRead, PermissionAuditRead},
+}
 
 type User struct {
 	ID        uint   
@@ -8,6 +34,34 @@ type User struct {
 	Email     string 
 	ImageURL  *string `gorm:"column:image_url"`
 	Password  *string 
+	Role      string
+	EmailVerifiedAt *time.Time
+	SuspendedAt     *time.Time
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	// DeletedAt is set while an account deletion waits to be purged, queries skip the user
+	DeletedAt gorm.DeletedAt
+}
+
+// HasPassword reports whether the user can sign in with a password, accounts
+// created by identity providers have none
+func (u *User) HasPassword() bool {
+	return u.Password != nil && *u.Password != ""
+}
+
+func (u *User) Permissions() []string {
+	return RolePermissions[u.Role]
 }
+
+// Can reports whether the user holds the permission, suspended users hold none
+func (u *User) Can(permission string) bool {
+	return u.SuspendedAt == nil && slices.Contains(u.Permissions(), permission)
+}
+
+// UserStats aggregates the users table for reports
+type UserStats struct {
+	Total     int64
+	Verified  int64
+	Suspended int64
+	Created   int64
+}
\ No newline at end of file
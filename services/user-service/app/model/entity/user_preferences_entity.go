@@ -0,0 +1,55 @@
+package entity
+
+import "time"
+
+// Preference defaults, the empty model and language leave the choice to the chat service
+const (
+	DefaultTheme    = "system"
+	DefaultTimezone = "UTC"
+)
+
+// Preferences is the typed settings document of a user. Only the settings the user
+// changed are stored, unset ones resolve to the defaults when read.
+type Preferences struct {
+	DefaultModel     *string `json:"default_model,omitempty"`
+	ResponseLanguage *string `json:"response_language,omitempty"`
+	Theme            *string `json:"theme,omitempty"`
+	Timezone         *string `json:"timezone,omitempty"`
+	MemoryEnabled    *bool   `json:"memory_enabled,omitempty"`
+	HistoryEnabled   *bool   `json:"history_enabled,omitempty"`
+}
+
+type UserPreferences struct {
+	UserID      uint        `gorm:"primaryKey;autoIncrement:false"`
+	Preferences Preferences `gorm:"type:jsonb;serializer:json"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Resolved returns the preferences with every unset setting replaced by its default
+func (p Preferences) Resolved() Preferences {
+	return Preferences{
+		DefaultModel:     stringOr(p.DefaultModel, ""),
+		ResponseLanguage: stringOr(p.ResponseLanguage, ""),
+		Theme:            stringOr(p.Theme, DefaultTheme),
+		Timezone:         stringOr(p.Timezone, DefaultTimezone),
+		MemoryEnabled:    boolOr(p.MemoryEnabled, true),
+		HistoryEnabled:   boolOr(p.HistoryEnabled, true),
+	}
+}
+
+func stringOr(value *string, fallback string) *string {
+	if value == nil || *value == "" {
+		return &fallback
+	}
+
+	return value
+}
+
+func boolOr(value *bool, fallback bool) *bool {
+	if value == nil {
+		return &fallback
+	}
+
+	return value
+}
\ No newline at end of file
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"time"
+	"user-service/app/model/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OrganizationInviteRepository interface {
+    FindByIDAndOrganizationID(tx *gorm.DB, invite *entity.OrganizationInvite, id int, organizationID int) error
+    FindByTokenHashForUpdate(tx *gorm.DB, invite *entity.OrganizationInvite, tokenHash string) error
+    FindAllPendingByOrganizationID(tx *gorm.DB, invites *[]entity.OrganizationInvite, organizationID int, now time.Time) error
+    Create(tx *gorm.DB, invite *entity.OrganizationInvite) error
+    Update(tx *gorm.DB, invite *entity.OrganizationInvite) error
+    Delete(tx *gorm.DB, invite *entity.OrganizationInvite) error
+}
+
+type OrganizationInviteRepositoryImpl struct {}
+
+func NewOrganizationInviteRepositoryImpl() *OrganizationInviteRepositoryImpl {
+    return &OrganizationInviteRepositoryImpl{}
+}
+
+func (r *OrganizationInviteRepositoryImpl) FindByIDAndOrganizationID(tx *gorm.DB, invite *entity.OrganizationInvite, id int, organizationID int) error {
+    return tx.Where("id = ? AND organization_id = ?", id, organizationID).First(invite).Error
+}
+
+// FindByTokenHashForUpdate locks the invite, so it is accepted once
+func (r *OrganizationInviteRepositoryImpl) FindByTokenHashForUpdate(tx *gorm.DB, invite *entity.OrganizationInvite, tokenHash string) error {
+    return tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("token_hash = ?", tokenHash).First(invite).Error
+}
+
+func (r *OrganizationInviteRepositoryImpl) FindAllPendingByOrganizationID(tx *gorm.DB, invites *[]entity.OrganizationInvite, organizationID int, now time.Time) error {
+    return tx.Where("organization_id = ? AND accepted_at IS NULL AND expires_at > ?", organizationID, now).
+        Order("id ASC").
+        Find(invites).Error
+}
+
+func (r *OrganizationInviteRepositoryImpl) Create(tx *gorm.DB, invite *entity.OrganizationInvite) error {
+    return tx.Omit("Organization").Create(invite).Error
+}
+
+func (r *OrganizationInviteRepositoryImpl) Update(tx *gorm.DB, invite *entity.OrganizationInvite) error {
+    return tx.Omit("Organization").Save(invite).Error
+}
+
+func (r *OrganizationInviteRepositoryImpl) Delete(tx *gorm.DB, invite *entity.OrganizationInvite) error {
+    return tx.Delete(invite).Error
+}
\ No newline at end of file
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"time"
+	"user-service/app/model/entity"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type OrganizationInviteRepositoryMock struct {
+    mock.Mock
+}
+
+func (m *OrganizationInviteRepositoryMock) FindByIDAndOrganizationID(tx *gorm.DB, invite *entity.OrganizationInvite, id int, organizationID int) error {
+    args := m.Called(tx, invite, id, organizationID)
+    return args.Error(0)
+}
+
+func (m *OrganizationInviteRepositoryMock) FindByTokenHashForUpdate(tx *gorm.DB, invite *entity.OrganizationInvite, tokenHash string) error {
+    args := m.Called(tx, invite, tokenHash)
+    return args.Error(0)
+}
+
+func (m *OrganizationInviteRepositoryMock) FindAllPendingByOrganizationID(tx *gorm.DB, invites *[]entity.OrganizationInvite, organizationID int, now time.Time) error {
+    args := m.Called(tx, invites, organizationID, now)
+    return args.Error(0)
+}
+
+func (m *OrganizationInviteRepositoryMock) Create(tx *gorm.DB, invite *entity.OrganizationInvite) error {
+    args := m.Called(tx, invite)
+    return args.Error(0)
+}
+
+func (m *OrganizationInviteRepositoryMock) Update(tx *gorm.DB, invite *entity.OrganizationInvite) error {
+    args := m.Called(tx, invite)
+    return args.Error(0)
+}
+
+func (m *OrganizationInviteRepositoryMock) Delete(tx *gorm.DB, invite *entity.OrganizationInvite) error {
+    args := m.Called(tx, invite)
+    return args.Error(0)
+}
\ No newline at end of file
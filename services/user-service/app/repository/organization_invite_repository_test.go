@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+	"user-service/app/model/entity"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestOrganizationInviteRepositoryImpl_FindByIDAndOrganizationID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewOrganizationInviteRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "organization_invites" WHERE id = $1 AND organization_id = $2 ORDER BY "organization_invites"."id" LIMIT $3`)).
+        WithArgs(4, 1, 1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "organization_id", "email", "role"}).
+            AddRow(4, 1, "jane@example.com", "member"))
+
+    var invite entity.OrganizationInvite
+    err := repo.FindByIDAndOrganizationID(db, &invite, 4, 1)
+
+    assert.NoError(t, err)
+    assert.Equal(t, "jane@example.com", invite.Email)
+}
+
+func TestOrganizationInviteRepositoryImpl_FindByTokenHashForUpdate(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewOrganizationInviteRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "organization_invites" WHERE token_hash = $1 ORDER BY "organization_invites"."id" LIMIT $2 FOR UPDATE`)).
+        WithArgs("hash", 1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "organization_id", "email", "role", "token_hash", "expires_at"}).
+            AddRow(4, 1, "jane@example.com", "member", "hash", time.Now().Add(time.Hour)))
+
+    var invite entity.OrganizationInvite
+    err := repo.FindByTokenHashForUpdate(db, &invite, "hash")
+
+    assert.NoError(t, err)
+    assert.True(t, invite.Pending())
+}
+
+func TestOrganizationInviteRepositoryImpl_FindByTokenHashForUpdate_NotFound(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewOrganizationInviteRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "organization_invites" WHERE token_hash = $1 ORDER BY "organization_invites"."id" LIMIT $2 FOR UPDATE`)).
+        WithArgs("hash", 1).
+        WillReturnError(gorm.ErrRecordNotFound)
+
+    var invite entity.OrganizationInvite
+    err := repo.FindByTokenHashForUpdate(db, &invite, "hash")
+
+    assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestOrganizationInviteRepositoryImpl_FindAllPendingByOrganizationID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewOrganizationInviteRepositoryImpl()
+
+    now := time.Now()
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "organization_invites" WHERE organization_id = $1 AND accepted_at IS NULL AND expires_at > $2 ORDER BY id ASC`)).
+        WithArgs(1, now).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "organization_id", "email"}).
+            AddRow(4, 1, "jane@example.com").
+            AddRow(5, 1, "john@example.com"))
+
+    var invites []entity.OrganizationInvite
+    err := repo.FindAllPendingByOrganizationID(db, &invites, 1, now)
+
+    assert.NoError(t, err)
+    assert.Len(t, invites, 2)
+}
+
+func TestOrganizationInviteRepositoryImpl_Create(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewOrganizationInviteRepositoryImpl()
+
+    expiresAt := time.Now().Add(time.Hour)
+    invitedBy := uint(2)
+    mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "organization_invites" ("organization_id","email","role","token_hash","invited_by","expires_at","accepted_at","created_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING "id"`)).
+        WithArgs(1, "jane@example.com", "member", "hash", 2, expiresAt, nil, sqlmock.AnyArg()).
+        WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+
+    invite := &entity.OrganizationInvite{
+        OrganizationID: 1,
+        Email:          "jane@example.com",
+        Role:           "member",
+        TokenHash:      "hash",
+        InvitedBy:      &invitedBy,
+        ExpiresAt:      expiresAt,
+    }
+    err := repo.Create(db, invite)
+
+    assert.NoError(t, err)
+    assert.Equal(t, uint(4), invite.ID)
+}
+
+func TestOrganizationInviteRepositoryImpl_Update(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewOrganizationInviteRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`UPDATE "organization_invites" SET "organization_id"=$1,"email"=$2,"role"=$3,"token_hash"=$4,"invited_by"=$5,"expires_at"=$6,"accepted_at"=$7,"created_at"=$8 WHERE "id" = $9`)).
+        WithArgs(1, "jane@example.com", "member", "hash", nil, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), 4).
+        WillReturnResult(sqlmock.NewResult(0, 1))
+
+    acceptedAt := time.Now()
+    err := repo.Update(db, &entity.OrganizationInvite{
+        ID:             4,
+        OrganizationID: 1,
+        Email:          "jane@example.com",
+        Role:           "member",
+        TokenHash:      "hash",
+        ExpiresAt:      acceptedAt.Add(time.Hour),
+        AcceptedAt:     &acceptedAt,
+    })
+
+    assert.NoError(t, err)
+}
+
+func TestOrganizationInviteRepositoryImpl_Delete(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewOrganizationInviteRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "organization_invites" WHERE "organization_invites"."id" = $1`)).
+        WithArgs(4).
+        WillReturnResult(sqlmock.NewResult(0, 1))
+
+    err := repo.Delete(db, &entity.OrganizationInvite{ID: 4})
+
+    assert.NoError(t, err)
+}
\ No newline at end of file
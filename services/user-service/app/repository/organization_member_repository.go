@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"user-service/app/model/entity"
+
+	"gorm.io/gorm"
+)
+
+type OrganizationMemberRepository interface {
+    FindByOrganizationIDAndUserID(tx *gorm.DB, member *entity.OrganizationMember, organizationID int, userID int) error
+    FindAllByOrganizationID(tx *gorm.DB, members *[]entity.OrganizationMember, organizationID int) error
+    FindAllByUserID(tx *gorm.DB, members *[]entity.OrganizationMember, userID int) error
+    CountByOrganizationIDAndRole(tx *gorm.DB, count *int64, organizationID int, role string) error
+    Create(tx *gorm.DB, member *entity.OrganizationMember) error
+    Update(tx *gorm.DB, member *entity.OrganizationMember) error
+    Delete(tx *gorm.DB, member *entity.OrganizationMember) error
+}
+
+type OrganizationMemberRepositoryImpl struct {}
+
+func NewOrganizationMemberRepositoryImpl() *OrganizationMemberRepositoryImpl {
+    return &OrganizationMemberRepositoryImpl{}
+}
+
+// FindByOrganizationIDAndUserID loads the membership with its organization
+func (r *OrganizationMemberRepositoryImpl) FindByOrganizationIDAndUserID(tx *gorm.DB, member *entity.OrganizationMember, organizationID int, userID int) error {
+    return tx.Preload("Organization").
+        Where("organization_id = ? AND user_id = ?", organizationID, userID).
+        First(member).Error
+}
+
+// FindAllByOrganizationID loads the members with their users, deleted users are left nil
+func (r *OrganizationMemberRepositoryImpl) FindAllByOrganizationID(tx *gorm.DB, members *[]entity.OrganizationMember, organizationID int) error {
+    return tx.Preload("User").
+        Where("organization_id = ?", organizationID).
+        Order("id ASC").
+        Find(members).Error
+}
+
+// FindAllByUserID loads the memberships of the user with their organizations
+func (r *OrganizationMemberRepositoryImpl) FindAllByUserID(tx *gorm.DB, members *[]entity.OrganizationMember, userID int) error {
+    return tx.Preload("Organization").
+        Where("user_id = ?", userID).
+        Order("organization_id ASC").
+        Find(members).Error
+}
+
+func (r *OrganizationMemberRepositoryImpl) CountByOrganizationIDAndRole(tx *gorm.DB, count *int64, organizationID int, role string) error {
+    return tx.Model(&entity.OrganizationMember{}).
+        Where("organization_id = ? AND role = ?", organizationID, role).
+        Count(count).Error
+}
+
+func (r *OrganizationMemberRepositoryImpl) Create(tx *gorm.DB, member *entity.OrganizationMember) error {
+    return tx.Omit("Organization", "User").Create(member).Error
+}
+
+func (r *OrganizationMemberRepositoryImpl) Update(tx *gorm.DB, member *entity.OrganizationMember) error {
+    return tx.Omit("Organization", "User").Save(member).Error
+}
+
+func (r *OrganizationMemberRepositoryImpl) Delete(tx *gorm.DB, member *entity.OrganizationMember) error {
+    return tx.Delete(member).Error
+}
\ No newline at end of file
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"user-service/app/model/entity"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type OrganizationMemberRepositoryMock struct {
+    mock.Mock
+}
+
+func (m *OrganizationMemberRepositoryMock) FindByOrganizationIDAndUserID(tx *gorm.DB, member *entity.OrganizationMember, organizationID int, userID int) error {
+    args := m.Called(tx, member, organizationID, userID)
+    return args.Error(0)
+}
+
+func (m *OrganizationMemberRepositoryMock) FindAllByOrganizationID(tx *gorm.DB, members *[]entity.OrganizationMember, organizationID int) error {
+    args := m.Called(tx, members, organizationID)
+    return args.Error(0)
+}
+
+func (m *OrganizationMemberRepositoryMock) FindAllByUserID(tx *gorm.DB, members *[]entity.OrganizationMember, userID int) error {
+    args := m.Called(tx, members, userID)
+    return args.Error(0)
+}
+
+func (m *OrganizationMemberRepositoryMock) CountByOrganizationIDAndRole(tx *gorm.DB, count *int64, organizationID int, role string) error {
+    args := m.Called(tx, count, organizationID, role)
+    return args.Error(0)
+}
+
+func (m *OrganizationMemberRepositoryMock) Create(tx *gorm.DB, member *entity.OrganizationMember) error {
+    args := m.Called(tx, member)
+    return args.Error(0)
+}
+
+func (m *OrganizationMemberRepositoryMock) Update(tx *gorm.DB, member *entity.OrganizationMember) error {
+    args := m.Called(tx, member)
+    return args.Error(0)
+}
+
+func (m *OrganizationMemberRepositoryMock) Delete(tx *gorm.DB, member *entity.OrganizationMember) error {
+    args := m.Called(tx, member)
+    return args.Error(0)
+}
\ No newline at end of file
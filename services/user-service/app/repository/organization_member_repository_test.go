@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+	"user-service/app/model/entity"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestOrganizationMemberRepositoryImpl_FindByOrganizationIDAndUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewOrganizationMemberRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "organization_members" WHERE organization_id = $1 AND user_id = $2 ORDER BY "organization_members"."id" LIMIT $3`)).
+        WithArgs(1, 2, 1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "organization_id", "user_id", "role", "created_at", "updated_at"}).
+            AddRow(3, 1, 2, "admin", time.Now(), time.Now()))
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "organizations" WHERE "organizations"."id" = $1`)).
+        WithArgs(1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "name", "allowed_models"}).
+            AddRow(1, "Acme", `[]`))
+
+    var member entity.OrganizationMember
+    err := repo.FindByOrganizationIDAndUserID(db, &member, 1, 2)
+
+    assert.NoError(t, err)
+    assert.Equal(t, "admin", member.Role)
+    assert.Equal(t, "Acme", member.Organization.Name)
+}
+
+func TestOrganizationMemberRepositoryImpl_FindByOrganizationIDAndUserID_NotFound(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewOrganizationMemberRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "organization_members" WHERE organization_id = $1 AND user_id = $2 ORDER BY "organization_members"."id" LIMIT $3`)).
+        WithArgs(1, 2, 1).
+        WillReturnError(gorm.ErrRecordNotFound)
+
+    var member entity.OrganizationMember
+    err := repo.FindByOrganizationIDAndUserID(db, &member, 1, 2)
+
+    assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestOrganizationMemberRepositoryImpl_FindAllByOrganizationID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewOrganizationMemberRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "organization_members" WHERE organization_id = $1 ORDER BY id ASC`)).
+        WithArgs(1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "organization_id", "user_id", "role"}).
+            AddRow(1, 1, 2, "owner").
+            AddRow(2, 1, 3, "member"))
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE "users"."id" IN ($1,$2) AND "users"."deleted_at" IS NULL`)).
+        WithArgs(2, 3).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email"}).
+            AddRow(2, "Jane", "jane@example.com"))
+
+    var members []entity.OrganizationMember
+    err := repo.FindAllByOrganizationID(db, &members, 1)
+
+    assert.NoError(t, err)
+    assert.Len(t, members, 2)
+    assert.Equal(t, "Jane", members[0].User.Name)
+    assert.Nil(t, members[1].User)
+}
+
+func TestOrganizationMemberRepositoryImpl_FindAllByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewOrganizationMemberRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "organization_members" WHERE user_id = $1 ORDER BY organization_id ASC`)).
+        WithArgs(2).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "organization_id", "user_id", "role"}).
+            AddRow(1, 1, 2, "owner"))
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "organizations" WHERE "organizations"."id" = $1`)).
+        WithArgs(1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "name", "allowed_models"}).
+            AddRow(1, "Acme", `[]`))
+
+    var members []entity.OrganizationMember
+    err := repo.FindAllByUserID(db, &members, 2)
+
+    assert.NoError(t, err)
+    assert.Len(t, members, 1)
+    assert.Equal(t, "Acme", members[0].Organization.Name)
+}
+
+func TestOrganizationMemberRepositoryImpl_CountByOrganizationIDAndRole(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewOrganizationMemberRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "organization_members" WHERE organization_id = $1 AND role = $2`)).
+        WithArgs(1, "owner").
+        WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+    var count int64
+    err := repo.CountByOrganizationIDAndRole(db, &count, 1, "owner")
+
+    assert.NoError(t, err)
+    assert.Equal(t, int64(2), count)
+}
+
+func TestOrganizationMemberRepositoryImpl_Create(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewOrganizationMemberRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "organization_members" ("organization_id","user_id","role","created_at","updated_at") VALUES ($1,$2,$3,$4,$5) RETURNING "id"`)).
+        WithArgs(1, 2, "owner", sqlmock.AnyArg(), sqlmock.AnyArg()).
+        WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+    member := &entity.OrganizationMember{OrganizationID: 1, UserID: 2, Role: "owner"}
+    err := repo.Create(db, member)
+
+    assert.NoError(t, err)
+    assert.Equal(t, uint(1), member.ID)
+}
+
+func TestOrganizationMemberRepositoryImpl_Update(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewOrganizationMemberRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`UPDATE "organization_members" SET "organization_id"=$1,"user_id"=$2,"role"=$3,"created_at"=$4,"updated_at"=$5 WHERE "id" = $6`)).
+        WithArgs(1, 2, "admin", sqlmock.AnyArg(), sqlmock.AnyArg(), 3).
+        WillReturnResult(sqlmock.NewResult(0, 1))
+
+    err := repo.Update(db, &entity.OrganizationMember{
+        ID:             3,
+        OrganizationID: 1,
+        UserID:         2,
+        Role:           "admin",
+        Organization:   &entity.Organization{ID: 1, Name: "Acme"},
+    })
+
+    assert.NoError(t, err)
+}
+
+func TestOrganizationMemberRepositoryImpl_Delete(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewOrganizationMemberRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "organization_members" WHERE "organization_members"."id" = $1`)).
+        WithArgs(3).
+        WillReturnResult(sqlmock.NewResult(0, 1))
+
+    err := repo.Delete(db, &entity.OrganizationMember{ID: 3})
+
+    assert.NoError(t, err)
+}
\ No newline at end of file
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"user-service/app/model/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OrganizationRepository interface {
+    FindByID(tx *gorm.DB, organization *entity.Organization, id int) error
+    FindByIDForUpdate(tx *gorm.DB, organization *entity.Organization, id int) error
+    Create(tx *gorm.DB, organization *entity.Organization) error
+    Update(tx *gorm.DB, organization *entity.Organization) error
+    Delete(tx *gorm.DB, id int) error
+}
+
+type OrganizationRepositoryImpl struct {}
+
+func NewOrganizationRepositoryImpl() *OrganizationRepositoryImpl {
+    return &OrganizationRepositoryImpl{}
+}
+
+func (r *OrganizationRepositoryImpl) FindByID(tx *gorm.DB, organization *entity.Organization, id int) error {
+    return tx.Where("id = ?", id).First(organization).Error
+}
+
+// FindByIDForUpdate locks the organization, changes to its members are made one after another
+func (r *OrganizationRepositoryImpl) FindByIDForUpdate(tx *gorm.DB, organization *entity.Organization, id int) error {
+    return tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(organization).Error
+}
+
+func (r *OrganizationRepositoryImpl) Create(tx *gorm.DB, organization *entity.Organization) error {
+    return tx.Create(organization).Error
+}
+
+func (r *OrganizationRepositoryImpl) Update(tx *gorm.DB, organization *entity.Organization) error {
+    return tx.Save(organization).Error
+}
+
+// Delete removes the organization, its members and invites are removed by the database
+func (r *OrganizationRepositoryImpl) Delete(tx *gorm.DB, id int) error {
+    return tx.Where("id = ?", id).Delete(&entity.Organization{}).Error
+}
\ No newline at end of file
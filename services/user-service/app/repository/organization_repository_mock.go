@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"user-service/app/model/entity"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type OrganizationRepositoryMock struct {
+    mock.Mock
+}
+
+func (m *OrganizationRepositoryMock) FindByID(tx *gorm.DB, organization *entity.Organization, id int) error {
+    args := m.Called(tx, organization, id)
+    return args.Error(0)
+}
+
+func (m *OrganizationRepositoryMock) FindByIDForUpdate(tx *gorm.DB, organization *entity.Organization, id int) error {
+    args := m.Called(tx, organization, id)
+    return args.Error(0)
+}
+
+func (m *OrganizationRepositoryMock) Create(tx *gorm.DB, organization *entity.Organization) error {
+    args := m.Called(tx, organization)
+    return args.Error(0)
+}
+
+func (m *OrganizationRepositoryMock) Update(tx *gorm.DB, organization *entity.Organization) error {
+    args := m.Called(tx, organization)
+    return args.Error(0)
+}
+
+func (m *OrganizationRepositoryMock) Delete(tx *gorm.DB, id int) error {
+    args := m.Called(tx, id)
+    return args.Error(0)
+}
\ No newline at end of file
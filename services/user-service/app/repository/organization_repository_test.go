@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+	"user-service/app/model/entity"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestOrganizationRepositoryImpl_FindByID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewOrganizationRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "organizations" WHERE id = $1 ORDER BY "organizations"."id" LIMIT $2`)).
+        WithArgs(1, 1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "name", "member_daily_message_quota", "allowed_models", "created_at", "updated_at"}).
+            AddRow(1, "Acme", 50, `["gemini-1.5-flash"]`, time.Now(), time.Now()))
+
+    var organization entity.Organization
+    err := repo.FindByID(db, &organization, 1)
+
+    assert.NoError(t, err)
+    assert.Equal(t, "Acme", organization.Name)
+    assert.Equal(t, 50, *organization.MemberDailyMessageQuota)
+    assert.Equal(t, []string{"gemini-1.5-flash"}, organization.AllowedModels)
+}
+
+func TestOrganizationRepositoryImpl_FindByID_NotFound(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewOrganizationRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "organizations" WHERE id = $1 ORDER BY "organizations"."id" LIMIT $2`)).
+        WithArgs(1, 1).
+        WillReturnError(gorm.ErrRecordNotFound)
+
+    var organization entity.Organization
+    err := repo.FindByID(db, &organization, 1)
+
+    assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestOrganizationRepositoryImpl_FindByIDForUpdate(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewOrganizationRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "organizations" WHERE id = $1 ORDER BY "organizations"."id" LIMIT $2 FOR UPDATE`)).
+        WithArgs(1, 1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "name", "allowed_models"}).
+            AddRow(1, "Acme", `[]`))
+
+    var organization entity.Organization
+    err := repo.FindByIDForUpdate(db, &organization, 1)
+
+    assert.NoError(t, err)
+    assert.Nil(t, organization.MemberDailyMessageQuota)
+    assert.Empty(t, organization.AllowedModels)
+}
+
+func TestOrganizationRepositoryImpl_Create(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewOrganizationRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "organizations" ("name","member_daily_message_quota","allowed_models","created_at","updated_at") VALUES ($1,$2,$3,$4,$5) RETURNING "id"`)).
+        WithArgs("Acme", nil, `[]`, sqlmock.AnyArg(), sqlmock.AnyArg()).
+        WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+    organization := &entity.Organization{Name: "Acme", AllowedModels: []string{}}
+    err := repo.Create(db, organization)
+
+    assert.NoError(t, err)
+    assert.Equal(t, uint(1), organization.ID)
+}
+
+func TestOrganizationRepositoryImpl_Update(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewOrganizationRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`UPDATE "organizations" SET "name"=$1,"member_daily_message_quota"=$2,"allowed_models"=$3,"created_at"=$4,"updated_at"=$5 WHERE "id" = $6`)).
+        WithArgs("Acme", 10, `["gemini-1.5-pro"]`, sqlmock.AnyArg(), sqlmock.AnyArg(), 1).
+        WillReturnResult(sqlmock.NewResult(0, 1))
+
+    quota := 10
+    err := repo.Update(db, &entity.Organization{
+        ID:                      1,
+        Name:                    "Acme",
+        MemberDailyMessageQuota: &quota,
+        AllowedModels:           []string{"gemini-1.5-pro"},
+    })
+
+    assert.NoError(t, err)
+}
+
+func TestOrganizationRepositoryImpl_Delete(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewOrganizationRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "organizations" WHERE id = $1`)).
+        WithArgs(1).
+        WillReturnResult(sqlmock.NewResult(0, 1))
+
+    err := repo.Delete(db, 1)
+
+    assert.NoError(t, err)
+}
\ No newline at end of file
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"user-service/app/model/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type UserPreferencesRepository interface {
+    FindByUserID(tx *gorm.DB, preferences *entity.UserPreferences, userID int) error
+    FindOrCreateForUpdate(tx *gorm.DB, preferences *entity.UserPreferences, userID int) error
+    Update(tx *gorm.DB, preferences *entity.UserPreferences) error
+}
+
+type UserPreferencesRepositoryImpl struct {}
+
+func NewUserPreferencesRepositoryImpl() *UserPreferencesRepositoryImpl {
+    return &UserPreferencesRepositoryImpl{}
+}
+
+func (r *UserPreferencesRepositoryImpl) FindByUserID(tx *gorm.DB, preferences *entity.UserPreferences, userID int) error {
+    return tx.Where("user_id = ?", userID).First(preferences).Error
+}
+
+// FindOrCreateForUpdate locks the preferences of the user, concurrent partial updates are merged one after another
+func (r *UserPreferencesRepositoryImpl) FindOrCreateForUpdate(tx *gorm.DB, preferences *entity.UserPreferences, userID int) error {
+    if err := tx.Clauses(clause.OnConflict{
+        Columns:   []clause.Column{{Name: "user_id"}},
+        DoNothing: true,
+    }).Create(&entity.UserPreferences{UserID: uint(userID)}).Error; err != nil {
+        return err
+    }
+
+    return tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+        Where("user_id = ?", userID).
+        First(preferences).Error
+}
+
+func (r *UserPreferencesRepositoryImpl) Update(tx *gorm.DB, preferences *entity.UserPreferences) error {
+    return tx.Save(preferences).Error
+}
\ No newline at end of file
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"user-service/app/model/entity"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type UserPreferencesRepositoryMock struct {
+    mock.Mock
+}
+
+func (m *UserPreferencesRepositoryMock) FindByUserID(tx *gorm.DB, preferences *entity.UserPreferences, userID int) error {
+    args := m.Called(tx, preferences, userID)
+    return args.Error(0)
+}
+
+func (m *UserPreferencesRepositoryMock) FindOrCreateForUpdate(tx *gorm.DB, preferences *entity.UserPreferences, userID int) error {
+    args := m.Called(tx, preferences, userID)
+    return args.Error(0)
+}
+
+func (m *UserPreferencesRepositoryMock) Update(tx *gorm.DB, preferences *entity.UserPreferences) error {
+    args := m.Called(tx, preferences)
+    return args.Error(0)
+}
\ No newline at end of file
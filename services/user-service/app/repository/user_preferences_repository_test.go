@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+	"user-service/app/model/entity"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestUserPreferencesRepositoryImpl_FindByUserID(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewUserPreferencesRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_preferences" WHERE user_id = $1 ORDER BY "user_preferences"."user_id" LIMIT $2`)).
+        WithArgs(1, 1).
+        WillReturnRows(sqlmock.NewRows([]string{"user_id", "preferences", "created_at", "updated_at"}).
+            AddRow(1, `{"theme":"dark"}`, time.Now(), time.Now()))
+
+    var preferences entity.UserPreferences
+    err := repo.FindByUserID(db, &preferences, 1)
+
+    assert.NoError(t, err)
+    assert.Equal(t, "dark", *preferences.Preferences.Theme)
+    assert.Nil(t, preferences.Preferences.Timezone)
+}
+
+func TestUserPreferencesRepositoryImpl_FindByUserID_NotFound(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewUserPreferencesRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_preferences" WHERE user_id = $1 ORDER BY "user_preferences"."user_id" LIMIT $2`)).
+        WithArgs(1, 1).
+        WillReturnError(gorm.ErrRecordNotFound)
+
+    var preferences entity.UserPreferences
+    err := repo.FindByUserID(db, &preferences, 1)
+
+    assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestUserPreferencesRepositoryImpl_FindOrCreateForUpdate(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewUserPreferencesRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "user_preferences" ("user_id","preferences","created_at","updated_at") VALUES ($1,$2,$3,$4) ON CONFLICT ("user_id") DO NOTHING`)).
+        WithArgs(1, "{}", sqlmock.AnyArg(), sqlmock.AnyArg()).
+        WillReturnResult(sqlmock.NewResult(0, 0))
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_preferences" WHERE user_id = $1 ORDER BY "user_preferences"."user_id" LIMIT $2 FOR UPDATE`)).
+        WithArgs(1, 1).
+        WillReturnRows(sqlmock.NewRows([]string{"user_id", "preferences"}).
+            AddRow(1, `{"memory_enabled":false}`))
+
+    var preferences entity.UserPreferences
+    err := repo.FindOrCreateForUpdate(db, &preferences, 1)
+
+    assert.NoError(t, err)
+    assert.False(t, *preferences.Preferences.MemoryEnabled)
+}
+
+func TestUserPreferencesRepositoryImpl_Update(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewUserPreferencesRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`UPDATE "user_preferences" SET "preferences"=$1,"created_at"=$2,"updated_at"=$3 WHERE "user_id" = $4`)).
+        WithArgs(`{"timezone":"Asia/Jakarta"}`, sqlmock.AnyArg(), sqlmock.AnyArg(), 1).
+        WillReturnResult(sqlmock.NewResult(0, 1))
+
+    timezone := "Asia/Jakarta"
+    err := repo.Update(db, &entity.UserPreferences{
+        UserID:      1,
+        Preferences: entity.Preferences{Timezone: &timezone},
+    })
+
+    assert.NoError(t, err)
+}
\ No newline at end of file
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"time"
 	"user-service/app/model/entity"
 
 	"gorm.io/gorm"
@@ -11,6 +12,14 @@ type UserRepository interface {
     FindByEmail(tx *gorm.DB, user *entity.User, email string) error
     Create(tx *gorm.DB, user *entity.User) error
     Update(tx *gorm.DB, user *entity.User) error
+    UpdatePassword(tx *gorm.DB, user *entity.User, hashedPassword string) error
+    Search(tx *gorm.DB, users *[]entity.User, query string, offset int, limit int) error
+    CountSearch(tx *gorm.DB, count *int64, query string) error
+    Stats(tx *gorm.DB, stats *entity.UserStats, since time.Time) error
+    Delete(tx *gorm.DB, user *entity.User) error
+    FindDeleted(tx *gorm.DB, user *entity.User, id int) error
+    Restore(tx *gorm.DB, id int) error
+    Purge(tx *gorm.DB, id int) error
 }
 
 type UserRepositoryImpl struct {}
@@ -34,3 +43,50 @@ func (r *UserRepositoryImpl) Create(tx *gorm.DB, user *entity.User) error {
 func (r *UserRepositoryImpl) Update(tx *gorm.DB, user *entity.User) error {
     return tx.Save(user).Error
 }
+
+// UpdatePassword only writes the password, it runs next to requests updating the rest of the user
+func (r *UserRepositoryImpl) UpdatePassword(tx *gorm.DB, user *entity.User, hashedPassword string) error {
+    return tx.Model(user).Update("password", hashedPassword).Error
+}
+
+func (r *UserRepositoryImpl) Search(tx *gorm.DB, users *[]entity.User, query string, offset int, limit int) error {
+    return searchUsers(tx, query).Order("id ASC").Offset(offset).Limit(limit).Find(users).Error
+}
+
+func (r *UserRepositoryImpl) CountSearch(tx *gorm.DB, count *int64, query string) error {
+    return searchUsers(tx, query).Count(count).Error
+}
+
+func (r *UserRepositoryImpl) Stats(tx *gorm.DB, stats *entity.UserStats, since time.Time) error {
+    return tx.Model(&entity.User{}).
+        Select("COUNT(*) AS total, COUNT(email_verified_at) AS verified, COUNT(suspended_at) AS suspended, COUNT(*) FILTER (WHERE created_at >= ?) AS created", since).
+        Scan(stats).Error
+}
+
+// Delete soft deletes the user, Purge removes it for good
+func (r *UserRepositoryImpl) Delete(tx *gorm.DB, user *entity.User) error {
+    return tx.Delete(user).Error
+}
+
+func (r *UserRepositoryImpl) FindDeleted(tx *gorm.DB, user *entity.User, id int) error {
+    return tx.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(user).Error
+}
+
+func (r *UserRepositoryImpl) Restore(tx *gorm.DB, id int) error {
+    return tx.Unscoped().Model(&entity.User{}).Where("id = ?", id).Update("deleted_at", nil).Error
+}
+
+func (r *UserRepositoryImpl) Purge(tx *gorm.DB, id int) error {
+    return tx.Unscoped().Where("id = ?", id).Delete(&entity.User{}).Error
+}
+
+// searchUsers matches the query against name and email, an empty query matches everyone
+func searchUsers(tx *gorm.DB, query string) *gorm.DB {
+    tx = tx.Model(&entity.User{})
+    if query != "" {
+        pattern := "%" + query + "%"
+        tx = tx.Where("name ILIKE ? OR email ILIKE ?", pattern, pattern)
+    }
+
+    return tx
+}
\ No newline at end of file
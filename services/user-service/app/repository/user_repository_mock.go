@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"time"
 	"user-service/app/model/entity"
 
 	"github.com/stretchr/testify/mock"
@@ -11,6 +12,11 @@ type UserRepositoryMock struct {
     mock.Mock
 }
 
+func (m *UserRepositoryMock) Find(tx *gorm.DB, user *entity.User, id int) error {
+    args := m.Called(tx, user, id)
+    return args.Error(0)
+}
+
 func (m *UserRepositoryMock) FindByEmail(tx *gorm.DB, user *entity.User, email string) error {
     args := m.Called(tx, user, email)
     return args.Error(0)
@@ -36,7 +42,42 @@ func (m *UserRepositoryMock) Update(tx *gorm.DB, user *entity.User) error {
     return args.Error(0)
 }
 
+func (m *UserRepositoryMock) UpdatePassword(tx *gorm.DB, user *entity.User, hashedPassword string) error {
+    args := m.Called(tx, user, hashedPassword)
+    return args.Error(0)
+}
+
 func (m *UserRepositoryMock) Delete(tx *gorm.DB, user *entity.User) error {
     args := m.Called(tx, user)
     return args.Error(0)
+}
+
+func (m *UserRepositoryMock) Search(tx *gorm.DB, users *[]entity.User, query string, offset int, limit int) error {
+    args := m.Called(tx, users, query, offset, limit)
+    return args.Error(0)
+}
+
+func (m *UserRepositoryMock) CountSearch(tx *gorm.DB, count *int64, query string) error {
+    args := m.Called(tx, count, query)
+    return args.Error(0)
+}
+
+func (m *UserRepositoryMock) Stats(tx *gorm.DB, stats *entity.UserStats, since time.Time) error {
+    args := m.Called(tx, stats, since)
+    return args.Error(0)
+}
+
+func (m *UserRepositoryMock) FindDeleted(tx *gorm.DB, user *entity.User, id int) error {
+    args := m.Called(tx, user, id)
+    return args.Error(0)
+}
+
+func (m *UserRepositoryMock) Restore(tx *gorm.DB, id int) error {
+    args := m.Called(tx, id)
+    return args.Error(0)
+}
+
+func (m *UserRepositoryMock) Purge(tx *gorm.DB, id int) error {
+    args := m.Called(tx, id)
+    return args.Error(0)
 }
\ No newline at end of file
@@ -17,7 +17,7 @@ func TestUserRepositoryImpl_FindByEmail(t *testing.T) {
     db, mock := setupMockDB(t)
     repo := NewUserRepositoryImpl()
 
-    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE email = $1 ORDER BY "users"."id" LIMIT $2`)).
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE email = $1 AND "users"."deleted_at" IS NULL ORDER BY "users"."id" LIMIT $2`)).
         WithArgs(email, 1).
         WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "created_at", "updated_at"}).
             AddRow(1, "Test User", email, time.Now(), time.Now()))
@@ -33,7 +33,7 @@ func TestUserRepositoryImpl_FindByEmail_Error(t *testing.T) {
     db, mock := setupMockDB(t)
     repo := NewUserRepositoryImpl()
 
-    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE email = $1 ORDER BY "users"."id" LIMIT $2`)).
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE email = $1 AND "users"."deleted_at" IS NULL ORDER BY "users"."id" LIMIT $2`)).
         WithArgs(email, 1).
         WillReturnError(gorm.ErrRecordNotFound)
 
@@ -126,3 +126,132 @@ func TestUserRepositoryImpl_Update_Error(t *testing.T) {
     assert.Error(t, err)
 }
 
+func TestUserRepositoryImpl_UpdatePassword(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewUserRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "password"=$1,"updated_at"=$2 WHERE "users"."deleted_at" IS NULL AND "id" = $3`)).
+        WithArgs("rehashed", sqlmock.AnyArg(), 1).
+        WillReturnResult(sqlmock.NewResult(1, 1))
+
+    err := repo.UpdatePassword(db, &entity.User{ID: 1}, "rehashed")
+
+    assert.NoError(t, err)
+}
+
+func TestUserRepositoryImpl_UpdatePassword_Error(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewUserRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "password"=$1,"updated_at"=$2 WHERE "users"."deleted_at" IS NULL AND "id" = $3`)).
+        WithArgs("rehashed", sqlmock.AnyArg(), 1).
+        WillReturnError(gorm.ErrInvalidData)
+
+    err := repo.UpdatePassword(db, &entity.User{ID: 1}, "rehashed")
+
+    assert.Error(t, err)
+}
+
+func TestUserRepositoryImpl_Search(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewUserRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE (name ILIKE $1 OR email ILIKE $2) AND "users"."deleted_at" IS NULL ORDER BY id ASC LIMIT $3 OFFSET $4`)).
+        WithArgs("%test%", "%test%", 20, 20).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "role"}).
+            AddRow(1, "Test User", email, entity.RoleAdmin))
+
+    var users []entity.User
+    err := repo.Search(db, &users, "test", 20, 20)
+
+    assert.NoError(t, err)
+    assert.Len(t, users, 1)
+    assert.Equal(t, entity.RoleAdmin, users[0].Role)
+}
+
+func TestUserRepositoryImpl_CountSearch(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewUserRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "users"`)).
+        WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+    var count int64
+    err := repo.CountSearch(db, &count, "")
+
+    assert.NoError(t, err)
+    assert.Equal(t, int64(3), count)
+}
+
+func TestUserRepositoryImpl_Stats(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewUserRepositoryImpl()
+    since := time.Now().AddDate(0, 0, -30)
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS total, COUNT(email_verified_at) AS verified, COUNT(suspended_at) AS suspended, COUNT(*) FILTER (WHERE created_at >= $1) AS created FROM "users"`)).
+        WithArgs(since).
+        WillReturnRows(sqlmock.NewRows([]string{"total", "verified", "suspended", "created"}).AddRow(10, 8, 1, 4))
+
+    var stats entity.UserStats
+    err := repo.Stats(db, &stats, since)
+
+    assert.NoError(t, err)
+    assert.Equal(t, int64(10), stats.Total)
+    assert.Equal(t, int64(4), stats.Created)
+}
+
+
+func TestUserRepositoryImpl_Delete(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewUserRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "deleted_at"=$1 WHERE "users"."id" = $2 AND "users"."deleted_at" IS NULL`)).
+        WithArgs(sqlmock.AnyArg(), 1).
+        WillReturnResult(sqlmock.NewResult(0, 1))
+
+    err := repo.Delete(db, &entity.User{ID: 1})
+
+    assert.NoError(t, err)
+}
+
+func TestUserRepositoryImpl_FindDeleted(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewUserRepositoryImpl()
+
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE id = $1 AND deleted_at IS NOT NULL ORDER BY "users"."id" LIMIT $2`)).
+        WithArgs(1, 1).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "email", "deleted_at"}).
+            AddRow(1, email, time.Now()))
+
+    var user entity.User
+    err := repo.FindDeleted(db, &user, 1)
+
+    assert.NoError(t, err)
+    assert.True(t, user.DeletedAt.Valid)
+}
+
+func TestUserRepositoryImpl_Restore(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewUserRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "deleted_at"=$1,"updated_at"=$2 WHERE id = $3`)).
+        WithArgs(nil, sqlmock.AnyArg(), 1).
+        WillReturnResult(sqlmock.NewResult(0, 1))
+
+    err := repo.Restore(db, 1)
+
+    assert.NoError(t, err)
+}
+
+func TestUserRepositoryImpl_Purge(t *testing.T) {
+    db, mock := setupMockDB(t)
+    repo := NewUserRepositoryImpl()
+
+    mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "users" WHERE id = $1`)).
+        WithArgs(1).
+        WillReturnResult(sqlmock.NewResult(0, 1))
+
+    err := repo.Purge(db, 1)
+
+    assert.NoError(t, err)
+}
\ No newline at end of file
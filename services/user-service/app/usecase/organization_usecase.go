@@ -0,0 +1,549 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+	"user-service/app/helper"
+	"user-service/app/model/dto"
+	"user-service/app/model/entity"
+	"user-service/app/model/proto"
+	"user-service/app/repository"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+type OrganizationUseCase interface {
+    Create(ctx context.Context, cor *proto.CreateUserOrganizationRequest) (*entity.OrganizationMember, error)
+    List(ctx context.Context, lor *proto.ListUserOrganizationsRequest) ([]entity.OrganizationMember, error)
+    GetMembership(ctx context.Context, gmr *proto.GetUserOrganizationRequest) (*entity.OrganizationMember, error)
+    Update(ctx context.Context, uor *proto.UpdateUserOrganizationRequest) (*entity.OrganizationMember, error)
+    Delete(ctx context.Context, dor *proto.DeleteUserOrganizationRequest) error
+    ListMembers(ctx context.Context, lmr *proto.ListUserOrganizationMembersRequest) ([]entity.OrganizationMember, error)
+    UpdateMember(ctx context.Context, umr *proto.UpdateUserOrganizationMemberRequest) (*entity.OrganizationMember, error)
+    RemoveMember(ctx context.Context, rmr *proto.RemoveUserOrganizationMemberRequest) error
+    CreateInvite(ctx context.Context, cir *proto.CreateUserOrganizationInviteRequest) (*entity.OrganizationInvite, error)
+    ListInvites(ctx context.Context, lir *proto.ListUserOrganizationInvitesRequest) ([]entity.OrganizationInvite, error)
+    RevokeInvite(ctx context.Context, rir *proto.RevokeUserOrganizationInviteRequest) error
+    AcceptInvite(ctx context.Context, air *proto.AcceptUserOrganizationInviteRequest) (*entity.OrganizationMember, error)
+}
+
+type OrganizationUseCaseImpl struct {
+    DB                           *gorm.DB
+    Validate                     *validator.Validate
+    UserRepository               repository.UserRepository
+    OrganizationRepository       repository.OrganizationRepository
+    OrganizationMemberRepository repository.OrganizationMemberRepository
+    OrganizationInviteRepository repository.OrganizationInviteRepository
+    Log                          *logrus.Logger
+}
+
+func NewOrganizationUseCaseImpl(
+    db *gorm.DB,
+    validator *validator.Validate,
+    userRepository repository.UserRepository,
+    organizationRepository repository.OrganizationRepository,
+    organizationMemberRepository repository.OrganizationMemberRepository,
+    organizationInviteRepository repository.OrganizationInviteRepository,
+    log *logrus.Logger,
+) *OrganizationUseCaseImpl {
+    return &OrganizationUseCaseImpl{
+        DB:                           db,
+        Validate:                     validator,
+        UserRepository:               userRepository,
+        OrganizationRepository:       organizationRepository,
+        OrganizationMemberRepository: organizationMemberRepository,
+        OrganizationInviteRepository: organizationInviteRepository,
+        Log:                          log,
+    }
+}
+
+// Create makes the acting user the owner of a new organization
+func (c *OrganizationUseCaseImpl) Create(ctx context.Context, cor *proto.CreateUserOrganizationRequest) (*entity.OrganizationMember, error) {
+    // Create request
+    req := &dto.CreateOrganizationRequest{
+        ActorID: uint(cor.GetActorId()),
+        Name:    strings.TrimSpace(cor.GetName()),
+    }
+
+    // Validate request
+    if errors := helper.Validate(c.Validate, req); len(errors) > 0 {
+        messages := helper.GetErrorMessages(errors)
+        return nil, status.Error(codes.InvalidArgument, messages[0])
+    }
+
+    tx := c.DB.WithContext(ctx).Begin()
+    defer tx.Rollback()
+
+    actor := new(entity.User)
+    if err := c.UserRepository.Find(tx, actor, int(req.ActorID)); err != nil || actor.ID == 0 {
+        return nil, status.Error(codes.PermissionDenied, "Permission denied")
+    }
+
+    organization := &entity.Organization{Name: req.Name, AllowedModels: []string{}}
+    if err := c.OrganizationRepository.Create(tx, organization); err != nil {
+        c.Log.Errorf("Failed to create organization: %v", err)
+        return nil, status.Error(codes.Internal, "Failed to create organization")
+    }
+
+    member := &entity.OrganizationMember{
+        OrganizationID: organization.ID,
+        UserID:         actor.ID,
+        Role:           entity.OrganizationRoleOwner,
+    }
+    if err := c.OrganizationMemberRepository.Create(tx, member); err != nil {
+        c.Log.Errorf("Failed to create organization owner: %v", err)
+        return nil, status.Error(codes.Internal, "Failed to create organization")
+    }
+
+    if err := tx.Commit().Error; err != nil {
+        return nil, status.Error(codes.Internal, "Failed to create organization")
+    }
+
+    member.Organization = organization
+    return member, nil
+}
+
+func (c *OrganizationUseCaseImpl) List(ctx context.Context, lor *proto.ListUserOrganizationsRequest) ([]entity.OrganizationMember, error) {
+    var members []entity.OrganizationMember
+    if err := c.OrganizationMemberRepository.FindAllByUserID(c.DB.WithContext(ctx), &members, int(lor.GetActorId())); err != nil {
+        c.Log.Errorf("Failed to list organizations: %v", err)
+        return nil, status.Error(codes.Internal, "Failed to list organizations")
+    }
+
+    return members, nil
+}
+
+func (c *OrganizationUseCaseImpl) GetMembership(ctx context.Context, gmr *proto.GetUserOrganizationRequest) (*entity.OrganizationMember, error) {
+    return c.member(c.DB.WithContext(ctx), uint(gmr.GetUserId()), uint(gmr.GetOrganizationId()))
+}
+
+func (c *OrganizationUseCaseImpl) Update(ctx context.Context, uor *proto.UpdateUserOrganizationRequest) (*entity.OrganizationMember, error) {
+    // Create request
+    req := &dto.UpdateOrganizationRequest{
+        ActorID: uint(uor.GetActorId()),
+        ID:      uint(uor.GetId()),
+    }
+    if uor.Name != nil {
+        name := strings.TrimSpace(uor.GetName())
+        req.Name = &name
+    }
+    if uor.AllowedModels != nil {
+        req.AllowedModels = uor.GetAllowedModels().GetModels()
+    }
+
+    // Validate request
+    if errors := helper.Validate(c.Validate, req); len(errors) > 0 {
+        messages := helper.GetErrorMessages(errors)
+        return nil, status.Error(codes.InvalidArgument, messages[0])
+    }
+
+    tx := c.DB.WithContext(ctx).Begin()
+    defer tx.Rollback()
+
+    member, err := c.manager(tx, req.ActorID, req.ID)
+    if err != nil {
+        return nil, err
+    }
+
+    organization := member.Organization
+    if req.Name != nil {
+        organization.Name = *req.Name
+    }
+    if uor.MemberDailyMessageQuota != nil {
+        organization.MemberDailyMessageQuota = nil
+        if quota := int(uor.GetMemberDailyMessageQuota()); quota >= 0 {
+            organization.MemberDailyMessageQuota = &quota
+        }
+    }
+    if uor.AllowedModels != nil {
+        organization.AllowedModels = append([]string{}, req.AllowedModels...)
+    }
+
+    if err := c.OrganizationRepository.Update(tx, organization); err != nil {
+        c.Log.Errorf("Failed to update organization: %v", err)
+        return nil, status.Error(codes.Internal, "Failed to update organization")
+    }
+
+    if err := tx.Commit().Error; err != nil {
+        return nil, status.Error(codes.Internal, "Failed to update organization")
+    }
+
+    return member, nil
+}
+
+// Delete removes the organization with its members and invites, only owners may delete it
+func (c *OrganizationUseCaseImpl) Delete(ctx context.Context, dor *proto.DeleteUserOrganizationRequest) error {
+    db := c.DB.WithContext(ctx)
+
+    member, err := c.member(db, uint(dor.GetActorId()), uint(dor.GetId()))
+    if err != nil {
+        return err
+    }
+    if member.Role != entity.OrganizationRoleOwner {
+        return status.Error(codes.PermissionDenied, "Only owners can delete the organization")
+    }
+
+    if err := c.OrganizationRepository.Delete(db, int(member.OrganizationID)); err != nil {
+        c.Log.Errorf("Failed to delete organization: %v", err)
+        return status.Error(codes.Internal, "Failed to delete organization")
+    }
+
+    return nil
+}
+
+func (c *OrganizationUseCaseImpl) ListMembers(ctx context.Context, lmr *proto.ListUserOrganizationMembersRequest) ([]entity.OrganizationMember, error) {
+    db := c.DB.WithContext(ctx)
+
+    if _, err := c.member(db, uint(lmr.GetActorId()), uint(lmr.GetOrganizationId())); err != nil {
+        return nil, err
+    }
+
+    var members []entity.OrganizationMember
+    if err := c.OrganizationMemberRepository.FindAllByOrganizationID(db, &members, int(lmr.GetOrganizationId())); err != nil {
+        c.Log.Errorf("Failed to list organization members: %v", err)
+        return nil, status.Error(codes.Internal, "Failed to list organization members")
+    }
+
+    // Members whose account waits to be purged are hidden
+    visible := make([]entity.OrganizationMember, 0, len(members))
+    for _, member := range members {
+        if member.User != nil {
+            visible = append(visible, member)
+        }
+    }
+
+    return visible, nil
+}
+
+func (c *OrganizationUseCaseImpl) UpdateMember(ctx context.Context, umr *proto.UpdateUserOrganizationMemberRequest) (*entity.OrganizationMember, error) {
+    // Create request
+    req := &dto.UpdateOrganizationMemberRequest{
+        ActorID:        uint(umr.GetActorId()),
+        OrganizationID: uint(umr.GetOrganizationId()),
+        UserID:         uint(umr.GetUserId()),
+        Role:           umr.GetRole(),
+    }
+
+    // Validate request
+    if errors := helper.Validate(c.Validate, req); len(errors) > 0 {
+        messages := helper.GetErrorMessages(errors)
+        return nil, status.Error(codes.InvalidArgument, messages[0])
+    }
+
+    tx := c.DB.WithContext(ctx).Begin()
+    defer tx.Rollback()
+
+    if err := c.lock(tx, req.OrganizationID); err != nil {
+        return nil, err
+    }
+
+    actor, err := c.manager(tx, req.ActorID, req.OrganizationID)
+    if err != nil {
+        return nil, err
+    }
+
+    member := new(entity.OrganizationMember)
+    if err := c.OrganizationMemberRepository.FindByOrganizationIDAndUserID(tx, member, int(req.OrganizationID), int(req.UserID)); err != nil {
+        return nil, status.Error(codes.NotFound, "Member not found")
+    }
+
+    if member.Role == req.Role {
+        return c.withUser(tx, member)
+    }
+
+    // Admins manage admins and members, the owner role is handed over by owners
+    if (member.Role == entity.OrganizationRoleOwner || req.Role == entity.OrganizationRoleOwner) && actor.Role != entity.OrganizationRoleOwner {
+        return nil, status.Error(codes.PermissionDenied, "Only owners can change the owner role")
+    }
+    if member.Role == entity.OrganizationRoleOwner {
+        if err := c.keepOwner(tx, req.OrganizationID); err != nil {
+            return nil, err
+        }
+    }
+
+    member.Role = req.Role
+    if err := c.OrganizationMemberRepository.Update(tx, member); err != nil {
+        c.Log.Errorf("Failed to update organization member: %v", err)
+        return nil, status.Error(codes.Internal, "Failed to update organization member")
+    }
+
+    if err := tx.Commit().Error; err != nil {
+        return nil, status.Error(codes.Internal, "Failed to update organization member")
+    }
+
+    return c.withUser(c.DB.WithContext(ctx), member)
+}
+
+func (c *OrganizationUseCaseImpl) RemoveMember(ctx context.Context, rmr *proto.RemoveUserOrganizationMemberRequest) error {
+    tx := c.DB.WithContext(ctx).Begin()
+    defer tx.Rollback()
+
+    organizationID := uint(rmr.GetOrganizationId())
+    if err := c.lock(tx, organizationID); err != nil {
+        return err
+    }
+
+    actor, err := c.member(tx, uint(rmr.GetActorId()), organizationID)
+    if err != nil {
+        return err
+    }
+
+    member := actor
+    if actor.UserID != uint(rmr.GetUserId()) {
+        if !actor.CanManage() {
+            return status.Error(codes.PermissionDenied, "Permission denied")
+        }
+
+        member = new(entity.OrganizationMember)
+        if err := c.OrganizationMemberRepository.FindByOrganizationIDAndUserID(tx, member, int(organizationID), int(rmr.GetUserId())); err != nil {
+            return status.Error(codes.NotFound, "Member not found")
+        }
+        if member.Role == entity.OrganizationRoleOwner && actor.Role != entity.OrganizationRoleOwner {
+            return status.Error(codes.PermissionDenied, "Only owners can remove owners")
+        }
+    }
+
+    if member.Role == entity.OrganizationRoleOwner {
+        if err := c.keepOwner(tx, organizationID); err != nil {
+            return err
+        }
+    }
+
+    if err := c.OrganizationMemberRepository.Delete(tx, member); err != nil {
+        c.Log.Errorf("Failed to remove organization member: %v", err)
+        return status.Error(codes.Internal, "Failed to remove organization member")
+    }
+
+    if err := tx.Commit().Error; err != nil {
+        return status.Error(codes.Internal, "Failed to remove organization member")
+    }
+
+    return nil
+}
+
+func (c *OrganizationUseCaseImpl) CreateInvite(ctx context.Context, cir *proto.CreateUserOrganizationInviteRequest) (*entity.OrganizationInvite, error) {
+    // Create request
+    req := &dto.CreateOrganizationInviteRequest{
+        ActorID:        uint(cir.GetActorId()),
+        OrganizationID: uint(cir.GetOrganizationId()),
+        Email:          strings.ToLower(strings.TrimSpace(cir.GetEmail())),
+        Role:           cir.GetRole(),
+        TokenHash:      cir.GetTokenHash(),
+        ExpiresAt:      cir.GetExpiresAt(),
+    }
+    if req.Role == "" {
+        req.Role = entity.OrganizationRoleMember
+    }
+
+    // Validate request
+    if errors := helper.Validate(c.Validate, req); len(errors) > 0 {
+        messages := helper.GetErrorMessages(errors)
+        return nil, status.Error(codes.InvalidArgument, messages[0])
+    }
+
+    db := c.DB.WithContext(ctx)
+
+    actor, err := c.manager(db, req.ActorID, req.OrganizationID)
+    if err != nil {
+        return nil, err
+    }
+
+    user := new(entity.User)
+    if err := c.UserRepository.FindByEmail(db, user, req.Email); err == nil {
+        existing := new(entity.OrganizationMember)
+        if err := c.OrganizationMemberRepository.FindByOrganizationIDAndUserID(db, existing, int(req.OrganizationID), int(user.ID)); err == nil {
+            return nil, status.Error(codes.AlreadyExists, "User is already a member")
+        }
+    }
+
+    invite := &entity.OrganizationInvite{
+        OrganizationID: req.OrganizationID,
+        Email:          req.Email,
+        Role:           req.Role,
+        TokenHash:      req.TokenHash,
+        InvitedBy:      &actor.UserID,
+        ExpiresAt:      time.Unix(req.ExpiresAt, 0),
+    }
+    if err := c.OrganizationInviteRepository.Create(db, invite); err != nil {
+        c.Log.Errorf("Failed to create organization invite: %v", err)
+        return nil, status.Error(codes.Internal, "Failed to create organization invite")
+    }
+
+    invite.Organization = actor.Organization
+    return invite, nil
+}
+
+// ListInvites returns the invites that can still be accepted
+func (c *OrganizationUseCaseImpl) ListInvites(ctx context.Context, lir *proto.ListUserOrganizationInvitesRequest) ([]entity.OrganizationInvite, error) {
+    db := c.DB.WithContext(ctx)
+
+    actor, err := c.manager(db, uint(lir.GetActorId()), uint(lir.GetOrganizationId()))
+    if err != nil {
+        return nil, err
+    }
+
+    var invites []entity.OrganizationInvite
+    if err := c.OrganizationInviteRepository.FindAllPendingByOrganizationID(db, &invites, int(actor.OrganizationID), time.Now()); err != nil {
+        c.Log.Errorf("Failed to list organization invites: %v", err)
+        return nil, status.Error(codes.Internal, "Failed to list organization invites")
+    }
+    for i := range invites {
+        invites[i].Organization = actor.Organization
+    }
+
+    return invites, nil
+}
+
+func (c *OrganizationUseCaseImpl) RevokeInvite(ctx context.Context, rir *proto.RevokeUserOrganizationInviteRequest) error {
+    db := c.DB.WithContext(ctx)
+
+    actor, err := c.manager(db, uint(rir.GetActorId()), uint(rir.GetOrganizationId()))
+    if err != nil {
+        return err
+    }
+
+    invite := new(entity.OrganizationInvite)
+    if err := c.OrganizationInviteRepository.FindByIDAndOrganizationID(db, invite, int(rir.GetId()), int(actor.OrganizationID)); err != nil {
+        return status.Error(codes.NotFound, "Invite not found")
+    }
+    if invite.AcceptedAt != nil {
+        return status.Error(codes.FailedPrecondition, "Invite was already accepted")
+    }
+
+    if err := c.OrganizationInviteRepository.Delete(db, invite); err != nil {
+        c.Log.Errorf("Failed to revoke organization invite: %v", err)
+        return status.Error(codes.Internal, "Failed to revoke organization invite")
+    }
+
+    return nil
+}
+
+// AcceptInvite adds the acting user to the organization with the role of the invite
+func (c *OrganizationUseCaseImpl) AcceptInvite(ctx context.Context, air *proto.AcceptUserOrganizationInviteRequest) (*entity.OrganizationMember, error) {
+    if air.GetTokenHash() == "" {
+        return nil, status.Error(codes.InvalidArgument, "Token is required")
+    }
+
+    tx := c.DB.WithContext(ctx).Begin()
+    defer tx.Rollback()
+
+    invite := new(entity.OrganizationInvite)
+    if err := c.OrganizationInviteRepository.FindByTokenHashForUpdate(tx, invite, air.GetTokenHash()); err != nil || !invite.Pending() {
+        return nil, status.Error(codes.NotFound, "Invite is invalid or has expired")
+    }
+
+    user := new(entity.User)
+    if err := c.UserRepository.Find(tx, user, int(air.GetActorId())); err != nil || user.ID == 0 {
+        return nil, status.Error(codes.PermissionDenied, "Permission denied")
+    }
+    if !strings.EqualFold(user.Email, invite.Email) {
+        return nil, status.Error(codes.PermissionDenied, "The invite was sent to another email")
+    }
+    // The email only proves the invite was meant for the user once it is verified
+    if user.EmailVerifiedAt == nil {
+        return nil, status.Error(codes.FailedPrecondition, "Verify your email before accepting the invite")
+    }
+
+    if err := c.lock(tx, invite.OrganizationID); err != nil {
+        return nil, err
+    }
+
+    member := new(entity.OrganizationMember)
+    err := c.OrganizationMemberRepository.FindByOrganizationIDAndUserID(tx, member, int(invite.OrganizationID), int(user.ID))
+    if err != nil && err != gorm.ErrRecordNotFound {
+        c.Log.Errorf("Failed to get organization member: %v", err)
+        return nil, status.Error(codes.Internal, "Failed to accept organization invite")
+    }
+    if err == gorm.ErrRecordNotFound {
+        member = &entity.OrganizationMember{
+            OrganizationID: invite.OrganizationID,
+            UserID:         user.ID,
+            Role:           invite.Role,
+        }
+        if err := c.OrganizationMemberRepository.Create(tx, member); err != nil {
+            c.Log.Errorf("Failed to create organization member: %v", err)
+            return nil, status.Error(codes.Internal, "Failed to accept organization invite")
+        }
+    }
+
+    now := time.Now()
+    invite.AcceptedAt = &now
+    if err := c.OrganizationInviteRepository.Update(tx, invite); err != nil {
+        c.Log.Errorf("Failed to update organization invite: %v", err)
+        return nil, status.Error(codes.Internal, "Failed to accept organization invite")
+    }
+
+    if err := tx.Commit().Error; err != nil {
+        return nil, status.Error(codes.Internal, "Failed to accept organization invite")
+    }
+
+    return c.member(c.DB.WithContext(ctx), user.ID, invite.OrganizationID)
+}
+
+// member returns the membership of the user with its organization. Organizations
+// the user is not a member of are reported as not found, so their ids do not leak.
+func (c *OrganizationUseCaseImpl) member(db *gorm.DB, userID uint, organizationID uint) (*entity.OrganizationMember, error) {
+    member := new(entity.OrganizationMember)
+    err := c.OrganizationMemberRepository.FindByOrganizationIDAndUserID(db, member, int(organizationID), int(userID))
+    if err == gorm.ErrRecordNotFound {
+        return nil, status.Error(codes.NotFound, "Organization not found")
+    }
+    if err != nil {
+        c.Log.Errorf("Failed to get organization member: %v", err)
+        return nil, status.Error(codes.Internal, "Failed to get organization")
+    }
+
+    return member, nil
+}
+
+// manager is member for requests that need the owner or admin role
+func (c *OrganizationUseCaseImpl) manager(db *gorm.DB, userID uint, organizationID uint) (*entity.OrganizationMember, error) {
+    member, err := c.member(db, userID, organizationID)
+    if err != nil {
+        return nil, err
+    }
+    if !member.CanManage() {
+        return nil, status.Error(codes.PermissionDenied, "Permission denied")
+    }
+
+    return member, nil
+}
+
+// lock serializes the changes to the members of an organization
+func (c *OrganizationUseCaseImpl) lock(tx *gorm.DB, organizationID uint) error {
+    organization := new(entity.Organization)
+    if err := c.OrganizationRepository.FindByIDForUpdate(tx, organization, int(organizationID)); err != nil {
+        return status.Error(codes.NotFound, "Organization not found")
+    }
+
+    return nil
+}
+
+// keepOwner fails when the owner about to be demoted or removed is the last one
+func (c *OrganizationUseCaseImpl) keepOwner(tx *gorm.DB, organizationID uint) error {
+    var owners int64
+    if err := c.OrganizationMemberRepository.CountByOrganizationIDAndRole(tx, &owners, int(organizationID), entity.OrganizationRoleOwner); err != nil {
+        c.Log.Errorf("Failed to count organization owners: %v", err)
+        return status.Error(codes.Internal, "Failed to update organization member")
+    }
+    if owners <= 1 {
+        return status.Error(codes.FailedPrecondition, "An organization needs at least one owner")
+    }
+
+    return nil
+}
+
+func (c *OrganizationUseCaseImpl) withUser(db *gorm.DB, member *entity.OrganizationMember) (*entity.OrganizationMember, error) {
+    user := new(entity.User)
+    if err := c.UserRepository.Find(db, user, int(member.UserID)); err != nil || user.ID == 0 {
+        return nil, status.Error(codes.NotFound, "Member not found")
+    }
+
+    member.User = user
+    return member, nil
+}
\ No newline at end of file
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+	"user-service/app/model/entity"
+	"user-service/app/model/proto"
+	"user-service/app/repository"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestOrganizationUseCaseImpl_AcceptInvite_RequiresVerifiedEmail(t *testing.T) {
+    db, sqlMock := setupMockDB(t)
+    log := logrus.New()
+    log.SetOutput(io.Discard)
+
+    userRepository := new(repository.UserRepositoryMock)
+    organizationMemberRepository := new(repository.OrganizationMemberRepositoryMock)
+    organizationInviteRepository := new(repository.OrganizationInviteRepositoryMock)
+    usecase := NewOrganizationUseCaseImpl(db, validator.New(), userRepository, new(repository.OrganizationRepositoryMock), organizationMemberRepository, organizationInviteRepository, log)
+
+    sqlMock.ExpectBegin()
+    sqlMock.ExpectRollback()
+    organizationInviteRepository.On("FindByTokenHashForUpdate", mock.Anything, mock.Anything, "hash").
+        Run(func(args mock.Arguments) {
+            *args.Get(1).(*entity.OrganizationInvite) = entity.OrganizationInvite{
+                ID:             1,
+                OrganizationID: 5,
+                Email:          "john@example.com",
+                Role:           entity.OrganizationRoleMember,
+                ExpiresAt:      time.Now().Add(time.Hour),
+            }
+        }).
+        Return(nil)
+    // Anyone may sign up with the invited email, only its owner verifies it
+    userRepository.On("Find", mock.Anything, mock.Anything, 2).
+        Run(func(args mock.Arguments) {
+            *args.Get(1).(*entity.User) = entity.User{ID: 2, Email: "John@Example.com"}
+        }).
+        Return(nil)
+
+    member, err := usecase.AcceptInvite(context.Background(), &proto.AcceptUserOrganizationInviteRequest{
+        ActorId:   2,
+        TokenHash: "hash",
+    })
+
+    assert.Nil(t, member)
+    assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+    organizationMemberRepository.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+    organizationInviteRepository.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+    assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
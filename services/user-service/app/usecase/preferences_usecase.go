@@ -0,0 +1,140 @@
+package usecase
+
+import (
+    "context"
+    "user-service/app/helper"
+    "user-service/app/model/dto"
+    "user-service/app/model/entity"
+    "user-service/app/model/proto"
+    "user-service/app/repository"
+
+    "github.com/go-playground/validator/v10"
+    "github.com/sirupsen/logrus"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "gorm.io/gorm"
+)
+
+type PreferencesUseCase interface {
+    Get(ctx context.Context, gpr *proto.GetUserPreferencesRequest) (*entity.Preferences, error)
+    Update(ctx context.Context, upr *proto.UpdateUserPreferencesRequest) (*entity.Preferences, error)
+}
+
+type PreferencesUseCaseImpl struct {
+    DB                        *gorm.DB
+    Validate                  *validator.Validate
+    UserRepository            repository.UserRepository
+    UserPreferencesRepository repository.UserPreferencesRepository
+    Log                       *logrus.Logger
+}
+
+func NewPreferencesUseCaseImpl(
+    db *gorm.DB,
+    validator *validator.Validate,
+    userRepository repository.UserRepository,
+    userPreferencesRepository repository.UserPreferencesRepository,
+    log *logrus.Logger,
+) *PreferencesUseCaseImpl {
+    return &PreferencesUseCaseImpl{
+        DB:                        db,
+        Validate:                  validator,
+        UserRepository:            userRepository,
+        UserPreferencesRepository: userPreferencesRepository,
+        Log:                       log,
+    }
+}
+
+// Get returns the resolved preferences, users who never changed a setting get the defaults
+func (c *PreferencesUseCaseImpl) Get(ctx context.Context, gpr *proto.GetUserPreferencesRequest) (*entity.Preferences, error) {
+    if gpr.GetUserId() <= 0 {
+        return nil, status.Error(codes.InvalidArgument, "User id is required")
+    }
+
+    preferences := new(entity.UserPreferences)
+    err := c.UserPreferencesRepository.FindByUserID(c.DB.WithContext(ctx), preferences, int(gpr.GetUserId()))
+    if err != nil && err != gorm.ErrRecordNotFound {
+        c.Log.Errorf("Failed to get preferences: %v", err)
+        return nil, status.Error(codes.Internal, "Failed to get preferences")
+    }
+
+    resolved := preferences.Preferences.Resolved()
+    return &resolved, nil
+}
+
+// Update merges the set fields into the stored preferences, empty strings remove the setting
+func (c *PreferencesUseCaseImpl) Update(ctx context.Context, upr *proto.UpdateUserPreferencesRequest) (*entity.Preferences, error) {
+    // Create request
+    req := &dto.UpdatePreferencesRequest{
+        UserID:           uint(upr.GetUserId()),
+        DefaultModel:     upr.GetDefaultModel(),
+        ResponseLanguage: upr.GetResponseLanguage(),
+        Theme:            upr.GetTheme(),
+        Timezone:         upr.GetTimezone(),
+    }
+
+    // Validate request
+    if errors := helper.Validate(c.Validate, req); len(errors) > 0 {
+        err := status.Newf(codes.InvalidArgument, "Validation errors: %v", errors)
+
+        err, wde := err.WithDetails(upr)
+        if wde != nil {
+            return nil, status.Error(codes.Internal, "An error occurred while adding details to error")
+        }
+
+        return nil, err.Err()
+    }
+
+    tx := c.DB.WithContext(ctx).Begin()
+    defer tx.Rollback()
+
+    // Validate if user exists
+    user := new(entity.User)
+    if err := c.UserRepository.Find(tx, user, int(req.UserID)); err != nil || user.ID == 0 {
+        return nil, status.Error(codes.NotFound, "User not found")
+    }
+
+    preferences := new(entity.UserPreferences)
+    if err := c.UserPreferencesRepository.FindOrCreateForUpdate(tx, preferences, int(req.UserID)); err != nil {
+        c.Log.Errorf("Failed to get preferences: %v", err)
+        return nil, status.Error(codes.Internal, "Failed to update preferences")
+    }
+
+    stored := &preferences.Preferences
+    mergeSetting(&stored.DefaultModel, upr.DefaultModel)
+    mergeSetting(&stored.ResponseLanguage, upr.ResponseLanguage)
+    mergeSetting(&stored.Theme, upr.Theme)
+    mergeSetting(&stored.Timezone, upr.Timezone)
+    if upr.MemoryEnabled != nil {
+        stored.MemoryEnabled = upr.MemoryEnabled
+    }
+    if upr.HistoryEnabled != nil {
+        stored.HistoryEnabled = upr.HistoryEnabled
+    }
+
+    if err := c.UserPreferencesRepository.Update(tx, preferences); err != nil {
+        c.Log.Errorf("Failed to update preferences: %v", err)
+        return nil, status.Error(codes.Internal, "Failed to update preferences")
+    }
+
+    if err := tx.Commit().Error; err != nil {
+        return nil, status.Error(codes.Internal, "Failed to update preferences")
+    }
+
+    resolved := preferences.Preferences.Resolved()
+    return &resolved, nil
+}
+
+// mergeSetting keeps the stored value when the update leaves it unset and drops it when the update is empty
+func mergeSetting(stored **string, update *string) {
+    if update == nil {
+        return
+    }
+
+    if *update == "" {
+        *stored = nil
+        return
+    }
+
+    value := *update
+    *stored = &value
+}
\ No newline at end of file
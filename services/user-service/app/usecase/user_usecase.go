@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"strings"
+	"time"
 	"user-service/app/helper"
 	"user-service/app/model/dto"
 	"user-service/app/model/entity"
@@ -18,14 +20,25 @@ import (
 type UserUseCase interface {
     GetByEmail(ctx context.Context, gur *proto.GetUserByEmailRequest) (*entity.User, error)
     GetByID(ctx context.Context, gur *proto.GetUserByIDRequest) (*entity.User, error)
+    VerifyCredentials(ctx context.Context, vcr *proto.VerifyCredentialsRequest) (*entity.User, error)
     Create(ctx context.Context, cur *proto.CreateUserRequest) (*entity.User, error)
     Update(ctx context.Context, uur *proto.UpdateUserRequest) (*entity.User, error)
+    VerifyEmail(ctx context.Context, vur *proto.VerifyUserEmailRequest) (*entity.User, error)
+    List(ctx context.Context, lur *proto.ListUsersRequest) ([]entity.User, int64, error)
+    Suspend(ctx context.Context, sur *proto.SuspendUserRequest) (*entity.User, error)
+    UpdateRole(ctx context.Context, urr *proto.UpdateUserRoleRequest) (*entity.User, error)
+    Stats(ctx context.Context, gsr *proto.GetUserStatsRequest) (*entity.UserStats, error)
+    Delete(ctx context.Context, dur *proto.DeleteUserRequest) error
+    Restore(ctx context.Context, rur *proto.RestoreUserRequest) (*entity.User, error)
+    Purge(ctx context.Context, pur *proto.PurgeUserRequest) error
 }
 
 type UserUseCaseImpl struct {
     DB              *gorm.DB
     Validate        *validator.Validate
     UserRepository  repository.UserRepository
+    PasswordHasher  *helper.PasswordHasher
+    PasswordPolicy  *helper.PasswordPolicy
     Log             *logrus.Logger
 }
 
@@ -33,12 +46,16 @@ func NewUserUseCaseImpl(
     db *gorm.DB,
     validator *validator.Validate,
     userRepository repository.UserRepository,
+    passwordHasher *helper.PasswordHasher,
+    passwordPolicy *helper.PasswordPolicy,
     log *logrus.Logger,
 ) *UserUseCaseImpl {
     return &UserUseCaseImpl{
         DB:             db,
         Validate:       validator,
         UserRepository: userRepository,
+        PasswordHasher: passwordHasher,
+        PasswordPolicy: passwordPolicy,
         Log:            log,
     }
 }
@@ -65,13 +82,60 @@ func (c *UserUseCaseImpl) GetByID(ctx context.Context, gur *proto.GetUserByIDReq
     return user, nil
 }
 
+// VerifyCredentials compares the password with the stored hash, so the hash never
+// leaves the service. Unknown emails and accounts without password are compared
+// with a dummy hash, every failure takes as long and answers the same.
+func (c *UserUseCaseImpl) VerifyCredentials(ctx context.Context, vcr *proto.VerifyCredentialsRequest) (*entity.User, error) {
+    // Create request
+    req := &dto.VerifyCredentialsRequest{
+        Email:    vcr.GetEmail(),
+        Password: vcr.GetPassword(),
+    }
+
+    // Validate request
+    if errors := helper.Validate(c.Validate, req); len(errors) > 0 {
+        messages := helper.GetErrorMessages(errors)
+        return nil, status.Error(codes.InvalidArgument, messages[0])
+    }
+
+    db := c.DB.WithContext(ctx)
+
+    user := new(entity.User)
+    err := c.UserRepository.FindByEmail(db, user, req.Email)
+    if err != nil && err != gorm.ErrRecordNotFound {
+        c.Log.Errorf("Failed to get user: %v", err)
+        return nil, status.Error(codes.Internal, "Failed to verify credentials")
+    }
+
+    hashedPassword := ""
+    if err == nil && user.HasPassword() {
+        hashedPassword = *user.Password
+    }
+
+    ok, needsRehash := c.PasswordHasher.Verify(hashedPassword, req.Password)
+    if !ok {
+        return nil, status.Error(codes.Unauthenticated, "Invalid email or password")
+    }
+
+    // Upgrade hashes made with an older algorithm or parameters while the password is known
+    if needsRehash {
+        if hashedPassword, err := c.PasswordHasher.Hash(req.Password); err != nil {
+            c.Log.Errorf("Failed to rehash password: %v", err)
+        } else if err := c.UserRepository.UpdatePassword(db, user, hashedPassword); err != nil {
+            c.Log.Errorf("Failed to update rehashed password: %v", err)
+        }
+    }
+
+    return user, nil
+}
+
 func (c *UserUseCaseImpl) Create(ctx context.Context, cur *proto.CreateUserRequest) (*entity.User, error) {
     // Create request
     req := &dto.CreateUserRequest{
         Name:     cur.GetName(),
         Email:    cur.GetEmail(),
-        Password: cur.GetPassword(),
-        ImageURL: cur.GetImageUrl(),
+        Password: cur.Password,
+        ImageURL: cur.ImageUrl,
     }
 
     // Validate request
@@ -87,11 +151,18 @@ func (c *UserUseCaseImpl) Create(ctx context.Context, cur *proto.CreateUserReque
         return nil, err.Err()
     }
 
+    // Validate password policy
+    if req.Password != nil {
+        if message, ok := c.PasswordPolicy.Check(*req.Password); !ok {
+            return nil, status.Error(codes.InvalidArgument, message)
+        }
+    }
+
     db := c.DB.WithContext(ctx)
 
-    // Validate if email already exists
+    // Validate if email already exists, deleted users keep it until they are purged
     user := new(entity.User)
-    if err := c.UserRepository.FindByEmail(db, user, req.Email); err == nil {
+    if err := c.UserRepository.FindByEmail(db.Unscoped(), user, req.Email); err == nil {
         return nil, status.Error(codes.AlreadyExists, "User already exists")
     }
 
@@ -99,13 +170,20 @@ func (c *UserUseCaseImpl) Create(ctx context.Context, cur *proto.CreateUserReque
     user = &entity.User{
         Name:     req.Name,
         Email:    req.Email,
-        Password: &req.Password,
-        ImageURL: &req.ImageURL,
+        ImageURL: req.ImageURL,
+        Role:     entity.RoleUser,
+    }
+
+    // Identity providers that already verified the address skip verification
+    if cur.GetEmailVerified() {
+        now := time.Now()
+        user.EmailVerifiedAt = &now
     }
 
-    if user.Password != nil {
-        hashedPassword, err := helper.HashPassword(*user.Password)
+    if req.Password != nil {
+        hashedPassword, err := c.PasswordHasher.Hash(*req.Password)
         if err != nil {
+            c.Log.Errorf("Failed to hash password: %v", err)
             return nil, status.Error(codes.Internal, "Failed to hash password")
         }
 
@@ -125,8 +203,8 @@ func (c *UserUseCaseImpl) Update(ctx context.Context, uur *proto.UpdateUserReque
         ID:       uint(uur.GetId()),
         Name:     uur.GetName(),
         Email:    uur.GetEmail(),
-        Password: uur.GetPassword(),
-        ImageURL: uur.GetImageUrl(),
+        Password: uur.Password,
+        ImageURL: uur.ImageUrl,
     }
 
     // Validate request
@@ -141,6 +219,13 @@ func (c *UserUseCaseImpl) Update(ctx context.Context, uur *proto.UpdateUserReque
         return nil, err.Err()
     }
 
+    // Validate password policy
+    if req.Password != nil {
+        if message, ok := c.PasswordPolicy.Check(*req.Password); !ok {
+            return nil, status.Error(codes.InvalidArgument, message)
+        }
+    }
+
     db := c.DB.WithContext(ctx)
 
     // Validate if user exists
@@ -151,19 +236,26 @@ func (c *UserUseCaseImpl) Update(ctx context.Context, uur *proto.UpdateUserReque
 
     // Validate if email already exist but not the same user
     uniqueUser := new(entity.User)
-    if err := c.UserRepository.FindByEmail(db, uniqueUser, req.Email); err == nil && uniqueUser.ID != req.ID {
+    if err := c.UserRepository.FindByEmail(db.Unscoped(), uniqueUser, req.Email); err == nil && uniqueUser.ID != req.ID {
         return nil, status.Error(codes.AlreadyExists, "Email already exists")
     }
 
+    // A new address has to be verified again
+    if !strings.EqualFold(user.Email, req.Email) {
+        user.EmailVerifiedAt = nil
+    }
+
     // Update user
     user.Name = req.Name
     user.Email = req.Email
-    user.Password = &req.Password
-    user.ImageURL = &req.ImageURL
+    if req.ImageURL != nil {
+        user.ImageURL = req.ImageURL
+    }
 
-    if req.Password != "" {
-        hashedPassword, err := helper.HashPassword(req.Password)
+    if req.Password != nil {
+        hashedPassword, err := c.PasswordHasher.Hash(*req.Password)
         if err != nil {
+            c.Log.Errorf("Failed to hash password: %v", err)
             return nil, status.Error(codes.Internal, "Failed to hash password")
         }
 
@@ -175,4 +267,249 @@ func (c *UserUseCaseImpl) Update(ctx context.Context, uur *proto.UpdateUserReque
     }
 
     return user, nil
+}
+
+func (c *UserUseCaseImpl) VerifyEmail(ctx context.Context, vur *proto.VerifyUserEmailRequest) (*entity.User, error) {
+    db := c.DB.WithContext(ctx)
+
+    user := new(entity.User)
+    if err := c.UserRepository.Find(db, user, int(vur.GetId())); err != nil || user.ID == 0 {
+        return nil, status.Error(codes.NotFound, "User not found")
+    }
+
+    if user.EmailVerifiedAt != nil {
+        return user, nil
+    }
+
+    now := time.Now()
+    user.EmailVerifiedAt = &now
+    if err := c.UserRepository.Update(db, user); err != nil {
+        return nil, status.Error(codes.Internal, "Failed to verify email")
+    }
+
+    return user, nil
+}
+
+func (c *UserUseCaseImpl) List(ctx context.Context, lur *proto.ListUsersRequest) ([]entity.User, int64, error) {
+    // Create request
+    req := &dto.ListUsersRequest{
+        ActorID:  uint(lur.GetActorId()),
+        Query:    strings.TrimSpace(lur.GetQuery()),
+        Page:     int(lur.GetPage()),
+        PageSize: int(lur.GetPageSize()),
+    }
+    if req.Page == 0 {
+        req.Page = 1
+    }
+    if req.PageSize == 0 {
+        req.PageSize = 20
+    }
+
+    // Validate request
+    if errors := helper.Validate(c.Validate, req); len(errors) > 0 {
+        messages := helper.GetErrorMessages(errors)
+        return nil, 0, status.Error(codes.InvalidArgument, messages[0])
+    }
+
+    db := c.DB.WithContext(ctx)
+
+    if _, err := c.authorize(db, req.ActorID, entity.PermissionUsersRead); err != nil {
+        return nil, 0, err
+    }
+
+    var users []entity.User
+    if err := c.UserRepository.Search(db, &users, req.Query, (req.Page-1)*req.PageSize, req.PageSize); err != nil {
+        c.Log.Errorf("Failed to search users: %v", err)
+        return nil, 0, status.Error(codes.Internal, "Failed to list users")
+    }
+
+    var total int64
+    if err := c.UserRepository.CountSearch(db, &total, req.Query); err != nil {
+        c.Log.Errorf("Failed to count users: %v", err)
+        return nil, 0, status.Error(codes.Internal, "Failed to list users")
+    }
+
+    return users, total, nil
+}
+
+func (c *UserUseCaseImpl) Suspend(ctx context.Context, sur *proto.SuspendUserRequest) (*entity.User, error) {
+    db := c.DB.WithContext(ctx)
+
+    actor, err := c.authorize(db, uint(sur.GetActorId()), entity.PermissionUsersSuspend)
+    if err != nil {
+        return nil, err
+    }
+
+    user := new(entity.User)
+    if err := c.UserRepository.Find(db, user, int(sur.GetId())); err != nil || user.ID == 0 {
+        return nil, status.Error(codes.NotFound, "User not found")
+    }
+
+    // Nobody locks themselves out, and only admins suspend other staff
+    if user.ID == actor.ID {
+        return nil, status.Error(codes.FailedPrecondition, "You cannot suspend yourself")
+    }
+    if user.Role != entity.RoleUser && actor.Role != entity.RoleAdmin {
+        return nil, status.Error(codes.PermissionDenied, "Only admins can suspend staff")
+    }
+
+    switch {
+    case sur.GetSuspended() && user.SuspendedAt == nil:
+        now := time.Now()
+        user.SuspendedAt = &now
+    case !sur.GetSuspended() && user.SuspendedAt != nil:
+        user.SuspendedAt = nil
+    default:
+        return user, nil
+    }
+
+    if err := c.UserRepository.Update(db, user); err != nil {
+        c.Log.Errorf("Failed to suspend user: %v", err)
+        return nil, status.Error(codes.Internal, "Failed to suspend user")
+    }
+
+    return user, nil
+}
+
+func (c *UserUseCaseImpl) UpdateRole(ctx context.Context, urr *proto.UpdateUserRoleRequest) (*entity.User, error) {
+    // Create request
+    req := &dto.UpdateUserRoleRequest{
+        ActorID: uint(urr.GetActorId()),
+        ID:      uint(urr.GetId()),
+        Role:    urr.GetRole(),
+    }
+
+    // Validate request
+    if errors := helper.Validate(c.Validate, req); len(errors) > 0 {
+        messages := helper.GetErrorMessages(errors)
+        return nil, status.Error(codes.InvalidArgument, messages[0])
+    }
+
+    db := c.DB.WithContext(ctx)
+
+    actor, err := c.authorize(db, req.ActorID, entity.PermissionUsersRoles)
+    if err != nil {
+        return nil, err
+    }
+
+    // Admins cannot demote themselves, so there is always one left
+    if req.ID == actor.ID {
+        return nil, status.Error(codes.FailedPrecondition, "You cannot change your own role")
+    }
+
+    user := new(entity.User)
+    if err := c.UserRepository.Find(db, user, int(req.ID)); err != nil || user.ID == 0 {
+        return nil, status.Error(codes.NotFound, "User not found")
+    }
+
+    if user.Role == req.Role {
+        return user, nil
+    }
+
+    user.Role = req.Role
+    if err := c.UserRepository.Update(db, user); err != nil {
+        c.Log.Errorf("Failed to update user role: %v", err)
+        return nil, status.Error(codes.Internal, "Failed to update user role")
+    }
+
+    return user, nil
+}
+
+func (c *UserUseCaseImpl) Stats(ctx context.Context, gsr *proto.GetUserStatsRequest) (*entity.UserStats, error) {
+    db := c.DB.WithContext(ctx)
+
+    if _, err := c.authorize(db, uint(gsr.GetActorId()), entity.PermissionReportsRead); err != nil {
+        return nil, err
+    }
+
+    stats := new(entity.UserStats)
+    if err := c.UserRepository.Stats(db, stats, time.Unix(gsr.GetSince(), 0)); err != nil {
+        c.Log.Errorf("Failed to aggregate users: %v", err)
+        return nil, status.Error(codes.Internal, "Failed to get user stats")
+    }
+
+    return stats, nil
+}
+
+// authorize loads the acting user and checks the permission against its current role,
+// so a demoted or suspended user loses access before its tokens expire
+// Delete hides the user until it is restored or purged, deleting a deleted user does nothing
+func (c *UserUseCaseImpl) Delete(ctx context.Context, dur *proto.DeleteUserRequest) error {
+    db := c.DB.WithContext(ctx)
+
+    user := new(entity.User)
+    if err := c.UserRepository.Find(db, user, int(dur.GetId())); err != nil {
+        c.Log.Errorf("Failed to get user: %v", err)
+        return status.Error(codes.Internal, "Failed to delete user")
+    }
+    if user.ID == 0 {
+        if err := c.UserRepository.FindDeleted(db, user, int(dur.GetId())); err == nil {
+            return nil
+        }
+        return status.Error(codes.NotFound, "User not found")
+    }
+
+    if err := c.UserRepository.Delete(db, user); err != nil {
+        c.Log.Errorf("Failed to delete user: %v", err)
+        return status.Error(codes.Internal, "Failed to delete user")
+    }
+
+    return nil
+}
+
+func (c *UserUseCaseImpl) Restore(ctx context.Context, rur *proto.RestoreUserRequest) (*entity.User, error) {
+    tx := c.DB.WithContext(ctx).Begin()
+    defer tx.Rollback()
+
+    user := new(entity.User)
+    if err := c.UserRepository.FindDeleted(tx, user, int(rur.GetId())); err != nil {
+        // Restoring a user that is not deleted does nothing
+        if err := c.UserRepository.Find(tx, user, int(rur.GetId())); err == nil && user.ID != 0 {
+            return user, nil
+        }
+        return nil, status.Error(codes.NotFound, "User not found")
+    }
+
+    if err := c.UserRepository.Restore(tx, int(user.ID)); err != nil {
+        c.Log.Errorf("Failed to restore user: %v", err)
+        return nil, status.Error(codes.Internal, "Failed to restore user")
+    }
+
+    if err := tx.Commit().Error; err != nil {
+        return nil, status.Error(codes.Internal, "Failed to restore user")
+    }
+
+    user.DeletedAt = gorm.DeletedAt{}
+    return user, nil
+}
+
+// Purge removes a deleted user with its preferences. Users that are not deleted
+// are refused, a user that is already gone counts as purged.
+func (c *UserUseCaseImpl) Purge(ctx context.Context, pur *proto.PurgeUserRequest) error {
+    db := c.DB.WithContext(ctx)
+
+    user := new(entity.User)
+    if err := c.UserRepository.Find(db, user, int(pur.GetId())); err == nil && user.ID != 0 {
+        return status.Error(codes.FailedPrecondition, "Only deleted users can be purged")
+    }
+
+    if err := c.UserRepository.Purge(db, int(pur.GetId())); err != nil {
+        c.Log.Errorf("Failed to purge user: %v", err)
+        return status.Error(codes.Internal, "Failed to purge user")
+    }
+
+    return nil
+}
+
+func (c *UserUseCaseImpl) authorize(db *gorm.DB, actorID uint, permission string) (*entity.User, error) {
+    actor := new(entity.User)
+    if err := c.UserRepository.Find(db, actor, int(actorID)); err != nil || actor.ID == 0 {
+        return nil, status.Error(codes.PermissionDenied, "Permission denied")
+    }
+
+    if !actor.Can(permission) {
+        return nil, status.Error(codes.PermissionDenied, "Permission denied")
+    }
+
+    return actor, nil
 }
\ No newline at end of file
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"testing"
+	"user-service/app/helper"
+	"user-service/app/model/entity"
+	"user-service/app/model/proto"
+	"user-service/app/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+    mockDB, mock, err := sqlmock.New()
+    if err != nil {
+        t.Fatalf("failed to open sqlmock database: %v", err)
+    }
+
+    db, err := gorm.Open(postgres.New(postgres.Config{
+        Conn:       mockDB,
+        DriverName: "postgres",
+    }), &gorm.Config{
+        SkipDefaultTransaction: true,
+        Logger: logger.Default.LogMode(logger.Silent),
+    })
+    if err != nil {
+        t.Fatalf("failed to open gorm database: %v", err)
+    }
+
+    return db, mock
+}
+
+func setupUserUseCase(t *testing.T) (*UserUseCaseImpl, *repository.UserRepositoryMock) {
+    db, _ := setupMockDB(t)
+
+    // The cheapest bcrypt cost keeps the tests fast
+    hasher, err := helper.NewPasswordHasher(helper.PasswordHashing{
+        Algorithm:  helper.PasswordAlgorithmBcrypt,
+        BcryptCost: bcrypt.MinCost,
+    })
+    if err != nil {
+        t.Fatalf("failed to create password hasher: %v", err)
+    }
+
+    log := logrus.New()
+    log.SetOutput(io.Discard)
+
+    userRepository := new(repository.UserRepositoryMock)
+    return NewUserUseCaseImpl(db, validator.New(), userRepository, hasher, &helper.PasswordPolicy{}, log), userRepository
+}
+
+// expectUser finds the user by the email, with the password hashed when set
+func expectUser(t *testing.T, usecase *UserUseCaseImpl, userRepository *repository.UserRepositoryMock, password string) {
+    user := entity.User{ID: 1, Name: "John", Email: "john@example.com"}
+    if password != "" {
+        hashedPassword, err := usecase.PasswordHasher.Hash(password)
+        assert.NoError(t, err)
+        user.Password = &hashedPassword
+    }
+
+    userRepository.On("FindByEmail", mock.Anything, mock.Anything, "john@example.com").
+        Run(func(args mock.Arguments) {
+            *args.Get(1).(*entity.User) = user
+        }).
+        Return(nil)
+}
+
+func TestUserUseCaseImpl_VerifyCredentials(t *testing.T) {
+    usecase, userRepository := setupUserUseCase(t)
+    expectUser(t, usecase, userRepository, "secret-password")
+
+    user, err := usecase.VerifyCredentials(context.Background(), &proto.VerifyCredentialsRequest{
+        Email:    "john@example.com",
+        Password: "secret-password",
+    })
+
+    assert.NoError(t, err)
+    assert.Equal(t, uint(1), user.ID)
+    userRepository.AssertExpectations(t)
+}
+
+func TestUserUseCaseImpl_VerifyCredentials_Refused(t *testing.T) {
+    for name, setup := range map[string]func(t *testing.T, usecase *UserUseCaseImpl, userRepository *repository.UserRepositoryMock){
+        "wrong password": func(t *testing.T, usecase *UserUseCaseImpl, userRepository *repository.UserRepositoryMock) {
+            expectUser(t, usecase, userRepository, "other-password")
+        },
+        "no password": func(t *testing.T, usecase *UserUseCaseImpl, userRepository *repository.UserRepositoryMock) {
+            expectUser(t, usecase, userRepository, "")
+        },
+        "unknown email": func(t *testing.T, usecase *UserUseCaseImpl, userRepository *repository.UserRepositoryMock) {
+            userRepository.On("FindByEmail", mock.Anything, mock.Anything, "john@example.com").Return(gorm.ErrRecordNotFound)
+        },
+    } {
+        t.Run(name, func(t *testing.T) {
+            usecase, userRepository := setupUserUseCase(t)
+            setup(t, usecase, userRepository)
+
+            user, err := usecase.VerifyCredentials(context.Background(), &proto.VerifyCredentialsRequest{
+                Email:    "john@example.com",
+                Password: "secret-password",
+            })
+
+            // Every failure answers the same
+            assert.Nil(t, user)
+            assert.Equal(t, codes.Unauthenticated, status.Code(err))
+            assert.Equal(t, "Invalid email or password", status.Convert(err).Message())
+        })
+    }
+}
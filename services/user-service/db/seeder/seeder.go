@@ -32,7 +32,15 @@ func OpenConnection() *gorm.DB {
 }
 
 func SeedData(db *gorm.DB) {
-	password, err := helper.HashPassword("secretpassword")
+	hasher, err := helper.NewPasswordHasher(helper.PasswordHashing{
+		Algorithm:  helper.PasswordAlgorithmBcrypt,
+		BcryptCost: 12,
+	})
+	if err != nil {
+		panic(fmt.Errorf("error creating password hasher: %v", err))
+	}
+
+	password, err := hasher.Hash("secretpassword")
 	if err != nil {
 		panic(fmt.Errorf("error hashing password: %v", err))
 	}